@@ -0,0 +1,47 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/CABGenOrg/cabgen_backend/internal/pipeline"
+	"github.com/joho/godotenv"
+)
+
+// check-resfinder reports the entries of a ResFinder phenotypes.txt catalog
+// that cannot be parsed. It exits with status 1 when there are any.
+func main() {
+	godotenv.Overload()
+
+	path := flag.String("catalog", os.Getenv("RESFINDER_DB_PATH"),
+		"ResFinder phenotypes.txt (default: RESFINDER_DB_PATH)")
+	flag.Parse()
+
+	if *path == "" {
+		log.Fatal("no catalog given; set -catalog or RESFINDER_DB_PATH")
+	}
+
+	file, err := os.Open(*path)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer file.Close()
+
+	catalog, issues, err := pipeline.ParseResfinderCatalog(file)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	for _, issue := range issues {
+		fmt.Println(issue)
+	}
+	fmt.Printf("%s: %d entries, %d issues\n", *path, len(catalog.Entries),
+		len(issues))
+
+	if len(issues) > 0 {
+		file.Close()
+		os.Exit(1)
+	}
+}
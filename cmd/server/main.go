@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"log"
 	"time"
 
@@ -19,6 +20,7 @@ import (
 	"github.com/CABGenOrg/cabgen_backend/internal/utils"
 	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
 )
 
 func main() {
@@ -47,6 +49,9 @@ func main() {
 		&models.HealthService{},
 		&models.Sample{},
 		&models.Analysis{},
+		&models.AnalysisLog{},
+		&models.AnalysisDatabase{},
+		&models.QCRule{},
 		&models.Ticket{},
 		&models.PasswordReset{},
 		&models.EmailUpdateRequest{},
@@ -73,6 +78,15 @@ func main() {
 		log.Fatal(err)
 	}
 
+	// Redis pub/sub for analysis events and the worker environments
+	redisClient, err := queue.NewRedisClient(config.RedisURL)
+	if err != nil {
+		log.Fatal(err)
+	}
+	analysisEventBus := queue.NewAnalysisEventBus(redisClient)
+	workerRegistry := queue.NewWorkerRegistry(redisClient,
+		config.AnalysisHeartbeatTTL)
+
 	// Load translations
 	translation.LoadTranslation()
 
@@ -81,6 +95,11 @@ func main() {
 		log.Fatal(err)
 	}
 
+	// Metrics stored before AMR hits were typed
+	if err := utils.MigrateAnalysisMetrics(mainDB.DB()); err != nil {
+		log.Fatal(err)
+	}
+
 	// Logs
 	logging.SetupLoggers("./logs/api.log")
 	defer logging.ConsoleLogger.Sync()
@@ -131,6 +150,7 @@ func main() {
 	labSvc := container.BuildLaboratoryService(mainDB.DB(), logging.FileLogger)
 	sequencerSvc := container.BuildSequencerService(mainDB.DB(),
 		logging.FileLogger)
+	qcRuleSvc := container.BuildQCRuleService(mainDB.DB(), logging.FileLogger)
 	originSvc := container.BuildOriginService(mainDB.DB(), logging.FileLogger)
 	sampleSourceSvc := container.BuildSampleSourceService(mainDB.DB(),
 		logging.FileLogger)
@@ -142,11 +162,42 @@ func main() {
 	sampleSvc := container.BuildSampleService(mainDB.DB(), rootDir,
 		logging.FileLogger)
 	analysisSvc := container.BuildAnalysisService(mainDB.DB(), asynqClient,
-		asynqInspector, logging.FileLogger, rootDir)
+		asynqInspector, workerRegistry, logging.FileLogger, rootDir)
+	analysisEventSvc := container.BuildAnalysisEventService(mainDB.DB(),
+		analysisEventBus, logging.FileLogger)
 	ticketSvc := container.BuildTicketService(mainDB.DB(), asynqClient,
 		logging.FileLogger)
 	metricsSvc := container.BuildMetricsService(mainDB.DB(),
 		logging.FileLogger)
+	speciesProfileSvc := container.BuildSpeciesProfileService(
+		config.SpeciesProfilesDir, logging.FileLogger)
+	workerEnvironmentSvc := container.BuildWorkerEnvironmentService(
+		workerRegistry, logging.FileLogger)
+	analysisReaperSvc := container.BuildAnalysisReaperService(mainDB.DB(),
+		queue.NewAnalysisHeartbeats(redisClient, ""), asynqClient,
+		asynqInspector, queue.NewLock(redisClient,
+			queue.AnalysisReaperLockKey, config.AnalysisReaperInterval),
+		analysisEventBus, logging.FileLogger, config.AnalysisHeartbeatTTL,
+		config.AnalysisReaperRequeue)
+
+	// Fail the analyses left RUNNING by a lost worker. Every replica ticks,
+	// the reaper lock lets one of them reap.
+	go func() {
+		ticker := time.NewTicker(config.AnalysisReaperInterval)
+		defer ticker.Stop()
+		for range ticker.C {
+			reaped, err := analysisReaperSvc.Reap(context.Background())
+			if err != nil {
+				logging.FileLogger.Error("Analysis reaper failed",
+					zap.Error(err))
+				continue
+			}
+			if reaped > 0 {
+				logging.FileLogger.Info("Analysis reaper finished",
+					zap.Int("reaped", reaped))
+			}
+		}
+	}()
 
 	// Public handlers
 	healthHandler := container.BuildHealthHandler()
@@ -160,6 +211,8 @@ func main() {
 	userHandler := container.BuildUserHandler(userSvc)
 	sampleHandler := container.BuildSampleHandler(sampleSvc)
 	analysisHandler := container.BuildAnalysisHandler(analysisSvc)
+	analysisEventHandler := container.BuildAnalysisEventHandler(
+		analysisEventSvc)
 
 	labRepo := repositories.NewLaboratoryRepo(mainDB.DB())
 	seqRepo := repositories.NewSequencerRepo(mainDB.DB())
@@ -181,6 +234,7 @@ func main() {
 	adminUserHandler := container.BuildAdminUserHandler(admUserSvc)
 	adminLaboratoryHandler := container.BuildAdminLaboratoryHandler(labSvc)
 	adminSequencerHandler := container.BuildAdminSequencerHandler(sequencerSvc)
+	adminQCRuleHandler := container.BuildAdminQCRuleHandler(qcRuleSvc)
 	adminOriginHandler := container.BuildAdminOriginHandler(originSvc)
 	adminSampleSourceHandler := container.BuildAdminSampleSourceHandler(
 		sampleSourceSvc)
@@ -190,8 +244,14 @@ func main() {
 		healthServiceSvc)
 	adminSampleHandler := container.BuildAdminSampleHandler(sampleSvc)
 	adminAnalysisHandler := container.BuildAdminAnalysisHandler(analysisSvc)
+	adminAnalysisEventHandler := container.BuildAdminAnalysisEventHandler(
+		analysisEventSvc)
 	adminTicketHandler := container.BuildAdminTicketHandler(ticketSvc)
 	adminMetricsHandler := container.BuildAdminMetricsHandler(metricsSvc)
+	adminSpeciesProfileHandler := container.BuildAdminSpeciesProfileHandler(
+		speciesProfileSvc)
+	adminWorkerHandler := container.BuildAdminWorkerHandler(
+		workerEnvironmentSvc)
 
 	// Public routes
 	publicRouter := api.Group("")
@@ -206,7 +266,8 @@ func main() {
 	common.SetupCommonAuthRoutes(commonRouter, authHandler)
 	common.SetupUserRoutes(commonRouter, userHandler)
 	common.SetupSampleRoutes(commonRouter, sampleHandler)
-	common.SetupAnalysisRoutes(commonRouter, analysisHandler)
+	common.SetupAnalysisRoutes(commonRouter, analysisHandler,
+		analysisEventHandler)
 	common.SetupSelectOptionRoutes(commonRouter, selectOptionHandler)
 	common.SetupCityRoutes(commonRouter, cityHandler)
 
@@ -215,6 +276,7 @@ func main() {
 		middlewares.AdminMiddleware())
 	admin.SetupAdminUserRoutes(adminRouter, adminUserHandler)
 	admin.SetupAdminSequencerRoutes(adminRouter, adminSequencerHandler)
+	admin.SetupAdminQCRuleRoutes(adminRouter, adminQCRuleHandler)
 	admin.SetupAdminLaboratoryRoutes(adminRouter, adminLaboratoryHandler)
 	admin.SetupAdminOriginRoutes(adminRouter, adminOriginHandler)
 	admin.SetupAdminSampleSourceRoutes(adminRouter, adminSampleSourceHandler)
@@ -222,9 +284,13 @@ func main() {
 	admin.SetupAdminMicroorganismRoutes(adminRouter, adminMicroHandler)
 	admin.SetupAdminHealthServiceRoutes(adminRouter, adminHealthServiceHandler)
 	admin.SetupAdminSampleRoutes(adminRouter, adminSampleHandler)
-	admin.SetupAdminAnalysisRoutes(adminRouter, adminAnalysisHandler)
+	admin.SetupAdminAnalysisRoutes(adminRouter, adminAnalysisHandler,
+		adminAnalysisEventHandler)
 	admin.SetupAdminTicketRoutes(adminRouter, adminTicketHandler)
 	admin.SetupAdminMetricsRoutes(adminRouter, adminMetricsHandler)
+	admin.SetupAdminSpeciesProfileRoutes(adminRouter,
+		adminSpeciesProfileHandler)
+	admin.SetupAdminWorkerRoutes(adminRouter, adminWorkerHandler)
 
 	r.Run()
 }
@@ -1,13 +1,18 @@
 package main
 
 import (
+	"context"
+	"fmt"
 	"log"
 	"os"
+	"strings"
+	"time"
 
 	"github.com/CABGenOrg/cabgen_backend/internal/config"
 	"github.com/CABGenOrg/cabgen_backend/internal/container"
 	"github.com/CABGenOrg/cabgen_backend/internal/db"
 	"github.com/CABGenOrg/cabgen_backend/internal/logging"
+	"github.com/CABGenOrg/cabgen_backend/internal/models"
 	"github.com/CABGenOrg/cabgen_backend/internal/pipeline"
 	"github.com/CABGenOrg/cabgen_backend/internal/queue"
 	"github.com/CABGenOrg/cabgen_backend/internal/queue/tasks"
@@ -35,17 +40,6 @@ func main() {
 		{"KRAKEN_DB_PATH", config.KrakenDBPath},
 		{"CHECKM_DATA_PATH", os.Getenv("CHECKM_DATA_PATH")},
 		{"FASTANI_PATH", config.FastaniPath},
-		{"POLI_DB_PSEUDO", config.PoliDbPseudo},
-		{"POLI_DB_KLEB", config.PoliDbKleb},
-		{"POLI_DB_ENTERO", config.PoliDbEntero},
-		{"POLI_DB_ACINETO", config.PoliDbAcineto},
-		{"OTHER_DB_PSEUDO", config.OtherDbPseudo},
-		{"OTHER_DB_KLEB", config.OtherDbKleb},
-		{"OTHER_DB_ENTERO", config.OtherDbEntero},
-		{"OTHER_DB_ACINETO", config.OtherDbAcineto},
-		{"FASTANI_LIST_KLEB", config.FastaniListKleb},
-		{"FASTANI_LIST_ENTERO", config.FastaniListEntero},
-		{"FASTANI_LIST_ACINETO", config.FastaniListAcineto},
 	}
 	for _, db := range dbPaths {
 		if db.path == "" {
@@ -56,6 +50,36 @@ func main() {
 		}
 	}
 
+	// AMR engine used unless a species profile chooses one
+	amrEngine, err := pipeline.ParseAMREngine(config.AMREngine)
+	if err != nil {
+		log.Fatalf("AMR_ENGINE: %v", err)
+	}
+	if config.AMRFinderDBPath != "" {
+		if _, err := os.Stat(config.AMRFinderDBPath); err != nil {
+			log.Fatalf("AMRFINDER_DB_PATH: path %s not accessible: %v",
+				config.AMRFinderDBPath, err)
+		}
+		dbPaths = append(dbPaths, struct{ name, path string }{
+			"AMRFINDER_DB_PATH", config.AMRFinderDBPath,
+		})
+	}
+
+	// Species profiles: refuse to start with missing databases or lists
+	var speciesRegistry *pipeline.SpeciesRegistry
+	if config.SpeciesProfilesDir != "" {
+		speciesRegistry, err = pipeline.LoadSpeciesRegistry(
+			config.SpeciesProfilesDir)
+	} else {
+		speciesRegistry, err = pipeline.DefaultSpeciesRegistry()
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := speciesRegistry.Validate(); err != nil {
+		log.Fatalf("invalid species profiles; refusing to start:\n%v", err)
+	}
+
 	// Setup database
 	mainDriver := "postgres"
 	mainDSN := config.DatabaseConnectionString
@@ -75,32 +99,151 @@ func main() {
 		log.Fatal(err)
 	}
 
+	// Redis pub/sub for analysis events
+	redisClient, err := queue.NewRedisClient(config.RedisURL)
+	if err != nil {
+		log.Fatal(err)
+	}
+	analysisEventBus := queue.NewAnalysisEventBus(redisClient)
+
+	// Server profile for the parameters not chosen per analysis
+	parameters := pipeline.DefaultAnalysisParameters()
+	if config.AnalysisParametersFile != "" {
+		parameters, err = pipeline.LoadAnalysisParameters(
+			config.AnalysisParametersFile)
+		if err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	// Per-step timeouts, threads and memory
+	var resources *pipeline.ResourceLimits
+	if config.StepResourcesFile != "" {
+		resources, err = pipeline.LoadResourceLimits(config.StepResourcesFile)
+		if err != nil {
+			log.Fatal(err)
+		}
+	}
+	memoryLimiter, err := pipeline.ParseMemoryLimiter(config.StepMemoryLimiter)
+	if err != nil {
+		log.Fatalf("STEP_MEMORY_LIMITER: %v", err)
+	}
+
 	// Analysis Runner Service
 	toolsConfig := pipeline.ToolsConfig{
-		FastQCPath:         config.FastQCPath,
-		UnicyclerPath:      config.UnicyclerPath,
-		SpadesPath:         config.SpadesPath,
-		CheckMPath:         config.CheckMPath,
-		Kraken2Path:        config.Kraken2Path,
-		KrakenDBPath:       config.KrakenDBPath,
-		FastANIPath:        config.FastaniPath,
-		AbricatePath:       config.AbricatePath,
-		MLSTPath:           config.MlstPath,
-		ResfinderDBPath:    config.ResfinderDBPath,
-		PoliDbPseudo:       config.PoliDbPseudo,
-		PoliDbKleb:         config.PoliDbKleb,
-		PoliDbEntero:       config.PoliDbEntero,
-		PoliDbAcineto:      config.PoliDbAcineto,
-		OtherDbPseudo:      config.OtherDbPseudo,
-		OtherDbKleb:        config.OtherDbKleb,
-		OtherDbEntero:      config.OtherDbEntero,
-		OtherDbAcineto:     config.OtherDbAcineto,
-		FastaniListKleb:    config.FastaniListKleb,
-		FastaniListEntero:  config.FastaniListEntero,
-		FastaniListAcineto: config.FastaniListAcineto,
+		FastQCPath:      config.FastQCPath,
+		UnicyclerPath:   config.UnicyclerPath,
+		SpadesPath:      config.SpadesPath,
+		CheckMPath:      config.CheckMPath,
+		Kraken2Path:     config.Kraken2Path,
+		KrakenDBPath:    config.KrakenDBPath,
+		FastANIPath:     config.FastaniPath,
+		AbricatePath:    config.AbricatePath,
+		MLSTPath:        config.MlstPath,
+		ResfinderDBPath: config.ResfinderDBPath,
+		AMRFinderPath:   config.AMRFinderPath,
+		AMRFinderDBPath: config.AMRFinderDBPath,
+		AMREngine:       amrEngine,
+		Species:         speciesRegistry,
+		Parameters:      parameters,
+		Resources:       resources,
 	}
+
+	// Tools run on the host, under the step memory limits, unless a
+	// container runtime is configured; Docker and Podman containers get the
+	// limits through the runtime instead
+	hostCmdr := &pipeline.ResourceCommander{
+		Next: &pipeline.RealCommander{}, Limiter: memoryLimiter,
+	}
+	var cmdr pipeline.Commander = hostCmdr
+	if config.ContainerRuntime != "" {
+		images, err := pipeline.ParseContainerImages(config.ContainerImages)
+		if err != nil {
+			log.Fatal(err)
+		}
+		mounts := make([]string, 0, len(dbPaths))
+		for _, db := range dbPaths {
+			mounts = append(mounts, db.path)
+		}
+		mounts = append(mounts, speciesRegistry.Paths()...)
+
+		cmdr, err = pipeline.NewContainerCommander(pipeline.ContainerConfig{
+			Runtime:     pipeline.ContainerRuntime(config.ContainerRuntime),
+			RuntimePath: config.ContainerRuntimePath,
+			Images:      images,
+			Mounts:      mounts,
+			Env: []string{
+				"CHECKM_DATA_PATH=" + os.Getenv("CHECKM_DATA_PATH"),
+			},
+			CPUs:   config.ContainerCPUs,
+			Memory: config.ContainerMemory,
+		}, &pipeline.RealCommander{}, hostCmdr)
+		if err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	// Resolved toolset: refuse to start when it does not match the manifest
+	// unless degraded workers are allowed
+	var manifest *pipeline.ToolManifest
+	if config.ToolManifestPath != "" {
+		manifest, err = pipeline.LoadToolManifest(config.ToolManifestPath)
+		if err != nil {
+			log.Fatal(err)
+		}
+	}
+	hostname, _ := os.Hostname()
+	environment := models.NewWorkerEnvironment(
+		fmt.Sprintf("%s:%d", hostname, os.Getpid()), hostname,
+		pipeline.GetBioinfoProgramVersions(context.Background(), cmdr),
+		pipeline.CollectDatabaseProvenance(context.Background(), cmdr,
+			&toolsConfig),
+		manifest,
+	)
+	if environment.Status == models.WorkerStatusDegraded {
+		mismatches := make([]string, 0, len(environment.Mismatches))
+		for _, mismatch := range environment.Mismatches {
+			mismatches = append(mismatches, mismatch.String())
+		}
+		if !config.ToolManifestDegraded {
+			log.Fatalf("environment does not match tool manifest %s; "+
+				"refusing to start:\n%s", config.ToolManifestPath,
+				strings.Join(mismatches, "\n"))
+		}
+		logging.FileLogger.Warn("Environment does not match tool manifest; "+
+			"running degraded", zap.Strings("mismatches", mismatches))
+	}
+
+	// The environment expires unless refreshed, so a worker that dies
+	// without unregistering drops out of the list
+	workerRegistry := queue.NewWorkerRegistry(redisClient,
+		config.AnalysisHeartbeatTTL)
+	if err := workerRegistry.PublishWorkerEnvironment(context.Background(),
+		environment); err != nil {
+		log.Fatal(err)
+	}
+	defer workerRegistry.RemoveWorkerEnvironment(context.Background(),
+		environment.ID)
+
+	go func() {
+		ticker := time.NewTicker(config.AnalysisHeartbeatInterval)
+		defer ticker.Stop()
+		for range ticker.C {
+			if err := workerRegistry.PublishWorkerEnvironment(
+				context.Background(), environment); err != nil {
+				logging.FileLogger.Warn("Failed to refresh worker environment",
+					zap.Error(err))
+			}
+		}
+	}()
+
+	// Heartbeats let the API reaper fail the analyses of a lost worker
+	analysisHeartbeats := queue.NewAnalysisHeartbeats(redisClient,
+		environment.ID)
+
 	analysisRunnerSvc := container.BuildAnalysisRunnerService(
-		mainDB.DB(), toolsConfig, asynqClient, rootDir, logging.FileLogger,
+		mainDB.DB(), toolsConfig, cmdr, asynqClient, analysisEventBus,
+		analysisHeartbeats, rootDir, logging.FileLogger,
 	)
 
 	// Handler
@@ -126,6 +269,8 @@ func main() {
 
 	logging.FileLogger.Info("Starting CABGen Analysis Worker...",
 		zap.String("redis_addr", config.RedisURL),
+		zap.String("worker_id", environment.ID),
+		zap.String("status", string(environment.Status)),
 		zap.Int("concurrency", 4))
 
 	if err := srv.Run(mux); err != nil {
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 	"strconv"
+	"time"
 
 	"github.com/joho/godotenv"
 )
@@ -33,18 +34,28 @@ var (
 	FastaniPath              = ""
 	SpadesPath               = ""
 	ResfinderDBPath          = ""
-	PoliDbPseudo             = ""
-	PoliDbKleb               = ""
-	PoliDbEntero             = ""
-	PoliDbAcineto            = ""
-	OtherDbPseudo            = ""
-	OtherDbKleb              = ""
-	OtherDbEntero            = ""
-	OtherDbAcineto           = ""
-	FastaniListKleb          = ""
-	FastaniListEntero        = ""
-	FastaniListAcineto       = ""
 	AnalysisConcurrency      = 0
+	ContainerRuntime         = ""
+	ContainerRuntimePath     = ""
+	ContainerImages          = ""
+	ContainerCPUs            = ""
+	ContainerMemory          = ""
+	AnalysisParametersFile   = ""
+	SpeciesProfilesDir       = ""
+	AMRFinderPath            = ""
+	AMRFinderDBPath          = ""
+	AMREngine                = ""
+	NotifySpeciesDiscordance = false
+	ToolManifestPath         = ""
+	ToolManifestDegraded     = false
+	StepResourcesFile        = ""
+	StepMemoryLimiter        = ""
+	// Analyses still RUNNING without a heartbeat for AnalysisHeartbeatTTL
+	// lost their worker and are failed by the reaper.
+	AnalysisHeartbeatInterval = 30 * time.Second
+	AnalysisHeartbeatTTL      = 2 * time.Minute
+	AnalysisReaperInterval    = time.Minute
+	AnalysisReaperRequeue     = false
 )
 
 /*
@@ -112,17 +123,58 @@ func LoadEnvVariables(envFile string) error {
 	FastaniPath = os.Getenv("FASTANI_PATH")
 	SpadesPath = os.Getenv("SPADES_PATH")
 	ResfinderDBPath = os.Getenv("RESFINDER_DB_PATH")
-	PoliDbPseudo = os.Getenv("POLI_DB_PSEUDO")
-	PoliDbKleb = os.Getenv("POLI_DB_KLEB")
-	PoliDbEntero = os.Getenv("POLI_DB_ENTERO")
-	PoliDbAcineto = os.Getenv("POLI_DB_ACINETO")
-	OtherDbPseudo = os.Getenv("OTHER_DB_PSEUDO")
-	OtherDbKleb = os.Getenv("OTHER_DB_KLEB")
-	OtherDbEntero = os.Getenv("OTHER_DB_ENTERO")
-	OtherDbAcineto = os.Getenv("OTHER_DB_ACINETO")
-	FastaniListKleb = os.Getenv("FASTANI_LIST_KLEB")
-	FastaniListEntero = os.Getenv("FASTANI_LIST_ENTERO")
-	FastaniListAcineto = os.Getenv("FASTANI_LIST_ACINETO")
+	ContainerRuntime = os.Getenv("CONTAINER_RUNTIME")
+	ContainerRuntimePath = os.Getenv("CONTAINER_RUNTIME_PATH")
+	ContainerImages = os.Getenv("CONTAINER_IMAGES")
+	ContainerCPUs = os.Getenv("CONTAINER_CPUS")
+	ContainerMemory = os.Getenv("CONTAINER_MEMORY")
+	AnalysisParametersFile = os.Getenv("ANALYSIS_PARAMETERS_FILE")
+	SpeciesProfilesDir = os.Getenv("SPECIES_PROFILES_DIR")
+	AMRFinderPath = os.Getenv("AMRFINDER_PATH")
+	AMRFinderDBPath = os.Getenv("AMRFINDER_DB_PATH")
+	AMREngine = os.Getenv("AMR_ENGINE")
+	NotifySpeciesDiscordance, _ = strconv.ParseBool(
+		os.Getenv("NOTIFY_SPECIES_DISCORDANCE"))
+	ToolManifestPath = os.Getenv("TOOL_MANIFEST_PATH")
+	ToolManifestDegraded, _ = strconv.ParseBool(
+		os.Getenv("TOOL_MANIFEST_DEGRADED"))
+	StepResourcesFile = os.Getenv("STEP_RESOURCES_FILE")
+	StepMemoryLimiter = os.Getenv("STEP_MEMORY_LIMITER")
+	AnalysisReaperRequeue, _ = strconv.ParseBool(
+		os.Getenv("ANALYSIS_REAPER_REQUEUE"))
+
+	AnalysisHeartbeatInterval, err = durationEnv(
+		"ANALYSIS_HEARTBEAT_INTERVAL", 30*time.Second)
+	if err != nil {
+		return err
+	}
+
+	AnalysisHeartbeatTTL, err = durationEnv("ANALYSIS_HEARTBEAT_TTL",
+		2*time.Minute)
+	if err != nil {
+		return err
+	}
+
+	AnalysisReaperInterval, err = durationEnv("ANALYSIS_REAPER_INTERVAL",
+		time.Minute)
+	if err != nil {
+		return err
+	}
 
 	return nil
 }
+
+// durationEnv parses the duration in the variable key, or returns fallback
+// when it is unset.
+func durationEnv(key string, fallback time.Duration) (time.Duration, error) {
+	raw := os.Getenv(key)
+	if raw == "" {
+		return fallback, nil
+	}
+
+	duration, err := time.ParseDuration(raw)
+	if err != nil || duration <= 0 {
+		return 0, fmt.Errorf("invalid %s: %q", key, raw)
+	}
+	return duration, nil
+}
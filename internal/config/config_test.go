@@ -5,6 +5,7 @@ import (
 	"path/filepath"
 	"strconv"
 	"testing"
+	"time"
 
 	"github.com/CABGenOrg/cabgen_backend/internal/config"
 	"github.com/CABGenOrg/cabgen_backend/internal/testutils"
@@ -57,6 +58,18 @@ func TestLoadEnvVariables(t *testing.T) {
 			FASTANI_LIST_ENTERO=/dbs/fastani/fastANI/list_entero
 			FASTANI_LIST_ACINETO=/dbs/fastani/fastANI_acineto/list-acineto
 			ANALYSIS_CONCURRENCY=4
+			CONTAINER_RUNTIME=podman
+			CONTAINER_RUNTIME_PATH=/usr/bin/podman
+			CONTAINER_IMAGES=abricate=staphb/abricate:1.0.1,mlst=staphb/mlst:2.23
+			CONTAINER_CPUS=4
+			CONTAINER_MEMORY=8g
+			ANALYSIS_PARAMETERS_FILE=/etc/cabgen/parameters.json
+			SPECIES_PROFILES_DIR=/etc/cabgen/species
+			AMRFINDER_PATH=/usr/bin/amrfinder
+			AMRFINDER_DB_PATH=/data/amrfinder_db
+			AMR_ENGINE=amrfinderplus
+			ANALYSIS_HEARTBEAT_TTL=5m
+			ANALYSIS_REAPER_REQUEUE=true
 		`
 		expectedAppRoot := "/app"
 		expectedDbHost := "localhost"
@@ -97,6 +110,18 @@ func TestLoadEnvVariables(t *testing.T) {
 		expectedFastaniListEntero := "/dbs/fastani/fastANI/list_entero"
 		expectedFastaniListAcineto := "/dbs/fastani/fastANI_acineto/list-acineto"
 		expectedAnalysisConcurrency := 4
+		expectedContainerRuntime := "podman"
+		expectedContainerRuntimePath := "/usr/bin/podman"
+		expectedContainerImages := "abricate=staphb/abricate:1.0.1,mlst=staphb/mlst:2.23"
+		expectedContainerCPUs := "4"
+		expectedContainerMemory := "8g"
+		expectedAnalysisParametersFile := "/etc/cabgen/parameters.json"
+		expectedSpeciesProfilesDir := "/etc/cabgen/species"
+		expectedAMRFinderPath := "/usr/bin/amrfinder"
+		expectedAMRFinderDBPath := "/data/amrfinder_db"
+		expectedAMREngine := "amrfinderplus"
+		expectedAnalysisHeartbeatInterval := 30 * time.Second
+		expectedAnalysisHeartbeatTTL := 5 * time.Minute
 
 		tempDir := t.TempDir()
 		testEnvFile := filepath.Join(tempDir, "test.env")
@@ -143,6 +168,19 @@ func TestLoadEnvVariables(t *testing.T) {
 	    assert.Equal(t, expectedFastaniListEntero, os.Getenv("FASTANI_LIST_ENTERO"), "expected fastani list entero to be equal")
 		assert.Equal(t, expectedFastaniListAcineto, os.Getenv("FASTANI_LIST_ACINETO"), "expected fastani list acineto to be equal")
 		assert.Equal(t, expectedAnalysisConcurrency, config.AnalysisConcurrency, "expected analysis concurrency to be equal")
+		assert.Equal(t, expectedContainerRuntime, config.ContainerRuntime, "expected container runtimes to be equal")
+		assert.Equal(t, expectedContainerRuntimePath, config.ContainerRuntimePath, "expected container runtime paths to be equal")
+		assert.Equal(t, expectedContainerImages, config.ContainerImages, "expected container images to be equal")
+		assert.Equal(t, expectedContainerCPUs, config.ContainerCPUs, "expected container cpus to be equal")
+		assert.Equal(t, expectedContainerMemory, config.ContainerMemory, "expected container memory to be equal")
+		assert.Equal(t, expectedAnalysisParametersFile, config.AnalysisParametersFile, "expected analysis parameters files to be equal")
+		assert.Equal(t, expectedSpeciesProfilesDir, config.SpeciesProfilesDir, "expected species profiles dirs to be equal")
+		assert.Equal(t, expectedAMRFinderPath, config.AMRFinderPath, "expected amrfinder paths to be equal")
+		assert.Equal(t, expectedAMRFinderDBPath, config.AMRFinderDBPath, "expected amrfinder db paths to be equal")
+		assert.Equal(t, expectedAMREngine, config.AMREngine, "expected amr engines to be equal")
+		assert.Equal(t, expectedAnalysisHeartbeatInterval, config.AnalysisHeartbeatInterval, "expected heartbeat intervals to be equal")
+		assert.Equal(t, expectedAnalysisHeartbeatTTL, config.AnalysisHeartbeatTTL, "expected heartbeat ttls to be equal")
+		assert.True(t, config.AnalysisReaperRequeue, "expected reaper requeue to be enabled")
 
 		Port, err := strconv.Atoi(os.Getenv("PORT"))
 		assert.NoError(t, err)
@@ -225,4 +263,23 @@ func TestLoadEnvVariables(t *testing.T) {
 		err := config.LoadEnvVariables(testEnvFile)
 		assert.Error(t, err)
 	})
+	t.Run("Error - Invalid heartbeat TTL", func(t *testing.T) {
+		os.Unsetenv("PORT")
+		defer os.Unsetenv("PORT")
+		defer os.Unsetenv("ANALYSIS_HEARTBEAT_TTL")
+
+		envContent := `
+			PORT=8080
+			SMTP_PORT=587
+			ANALYSIS_CONCURRENCY=4
+			ANALYSIS_HEARTBEAT_TTL=soon
+		`
+		tempDir := t.TempDir()
+		testEnvFile := filepath.Join(tempDir, "test.env")
+
+		testutils.WriteMockEnvFile(t, testEnvFile, envContent)
+
+		err := config.LoadEnvVariables(testEnvFile)
+		assert.ErrorContains(t, err, "invalid ANALYSIS_HEARTBEAT_TTL")
+	})
 }
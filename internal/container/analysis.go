@@ -3,6 +3,7 @@ package container
 import (
 	adminHandler "github.com/CABGenOrg/cabgen_backend/internal/handlers/admin/analysis"
 	"github.com/CABGenOrg/cabgen_backend/internal/handlers/common/analysis"
+	"github.com/CABGenOrg/cabgen_backend/internal/queue"
 	"github.com/CABGenOrg/cabgen_backend/internal/repositories"
 	"github.com/CABGenOrg/cabgen_backend/internal/services"
 	"github.com/hibiken/asynq"
@@ -11,14 +12,14 @@ import (
 )
 
 func BuildAnalysisService(db *gorm.DB, asynqClient *asynq.Client,
-	inspector *asynq.Inspector, logger *zap.Logger,
-	rootDir string) services.AnalysisService {
+	inspector *asynq.Inspector, registry *queue.WorkerRegistry,
+	logger *zap.Logger, rootDir string) services.AnalysisService {
 	analysisRepo := repositories.NewAnalysisRepository(db)
 	sampleRepo := repositories.NewSampleRepo(db)
 	userRepo := repositories.NewUserRepo(db)
 	analysisService := services.NewAnalysisService(
 		analysisRepo, sampleRepo,
-		userRepo, asynqClient, inspector, logger, rootDir,
+		userRepo, asynqClient, inspector, registry, logger, rootDir,
 	)
 
 	return analysisService
@@ -33,3 +34,20 @@ func BuildAdminAnalysisHandler(svc services.AnalysisService,
 ) *adminHandler.AdminAnalysisHandler {
 	return adminHandler.NewAdminAnalysisHandler(svc)
 }
+
+func BuildAnalysisEventService(db *gorm.DB,
+	subscriber services.AnalysisEventSubscriber,
+	logger *zap.Logger) services.AnalysisEventService {
+	analysisRepo := repositories.NewAnalysisRepository(db)
+	return services.NewAnalysisEventService(analysisRepo, subscriber, logger)
+}
+
+func BuildAnalysisEventHandler(svc services.AnalysisEventService,
+) *analysis.AnalysisEventHandler {
+	return analysis.NewAnalysisEventHandler(svc)
+}
+
+func BuildAdminAnalysisEventHandler(svc services.AnalysisEventService,
+) *adminHandler.AdminAnalysisEventHandler {
+	return adminHandler.NewAdminAnalysisEventHandler(svc)
+}
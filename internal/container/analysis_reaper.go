@@ -0,0 +1,22 @@
+package container
+
+import (
+	"time"
+
+	"github.com/CABGenOrg/cabgen_backend/internal/repositories"
+	"github.com/CABGenOrg/cabgen_backend/internal/services"
+	"github.com/hibiken/asynq"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+func BuildAnalysisReaperService(db *gorm.DB,
+	heartbeats services.AnalysisHeartbeatReader, asynqClient *asynq.Client,
+	inspector *asynq.Inspector, lock services.Locker,
+	events services.AnalysisEventPublisher, logger *zap.Logger,
+	ttl time.Duration, requeue bool) services.AnalysisReaperService {
+	analysisRepo := repositories.NewAnalysisRepository(db)
+
+	return services.NewAnalysisReaperService(analysisRepo, heartbeats,
+		asynqClient, inspector, lock, events, logger, ttl, requeue)
+}
@@ -10,14 +10,16 @@ import (
 )
 
 func BuildAnalysisRunnerService(db *gorm.DB, config pipeline.ToolsConfig,
-	asynqClient *asynq.Client, rootDir string,
+	cmdr pipeline.Commander, asynqClient *asynq.Client,
+	events services.AnalysisEventPublisher,
+	heartbeats services.AnalysisHeartbeatWriter, rootDir string,
 	logger *zap.Logger) services.AnalysisRunnerService {
 	analysisRepo := repositories.NewAnalysisRepository(db)
-	cmdr := &pipeline.RealCommander{}
 	runner := pipeline.NewToolRunner(cmdr)
 	pipeline := pipeline.NewCabgenPipeline(runner, config, logger)
 
 	return services.NewAnalysisRunnerService(
-		analysisRepo, pipeline, cmdr, asynqClient, logger, rootDir,
+		analysisRepo, pipeline, cmdr, asynqClient, events, heartbeats, logger,
+		rootDir,
 	)
 }
@@ -0,0 +1,20 @@
+package container
+
+import (
+	adminQCRule "github.com/CABGenOrg/cabgen_backend/internal/handlers/admin/qcrule"
+	"github.com/CABGenOrg/cabgen_backend/internal/repositories"
+	"github.com/CABGenOrg/cabgen_backend/internal/services"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+func BuildQCRuleService(db *gorm.DB, logger *zap.Logger) services.QCRuleService {
+	qcRuleRepo := repositories.NewQCRuleRepo(db)
+	qcRuleService := services.NewQCRuleService(qcRuleRepo, logger)
+
+	return qcRuleService
+}
+
+func BuildAdminQCRuleHandler(svc services.QCRuleService) *adminQCRule.AdminQCRuleHandler {
+	return adminQCRule.NewAdminQCRuleHandler(svc)
+}
@@ -0,0 +1,26 @@
+package container
+
+import (
+	adminHandler "github.com/CABGenOrg/cabgen_backend/internal/handlers/admin/speciesprofile"
+	"github.com/CABGenOrg/cabgen_backend/internal/pipeline"
+	"github.com/CABGenOrg/cabgen_backend/internal/services"
+	"go.uber.org/zap"
+)
+
+// BuildSpeciesProfileService reads the profiles from dir, or the built-in
+// profiles when dir is empty.
+func BuildSpeciesProfileService(dir string,
+	logger *zap.Logger) services.SpeciesProfileService {
+	load := pipeline.DefaultSpeciesRegistry
+	if dir != "" {
+		load = func() (*pipeline.SpeciesRegistry, error) {
+			return pipeline.LoadSpeciesRegistry(dir)
+		}
+	}
+	return services.NewSpeciesProfileService(load, logger)
+}
+
+func BuildAdminSpeciesProfileHandler(
+	svc services.SpeciesProfileService) *adminHandler.AdminSpeciesProfileHandler {
+	return adminHandler.NewAdminSpeciesProfileHandler(svc)
+}
@@ -0,0 +1,18 @@
+package container
+
+import (
+	adminHandler "github.com/CABGenOrg/cabgen_backend/internal/handlers/admin/worker"
+	"github.com/CABGenOrg/cabgen_backend/internal/queue"
+	"github.com/CABGenOrg/cabgen_backend/internal/services"
+	"go.uber.org/zap"
+)
+
+func BuildWorkerEnvironmentService(registry *queue.WorkerRegistry,
+	logger *zap.Logger) services.WorkerEnvironmentService {
+	return services.NewWorkerEnvironmentService(registry, logger)
+}
+
+func BuildAdminWorkerHandler(
+	svc services.WorkerEnvironmentService) *adminHandler.AdminWorkerHandler {
+	return adminHandler.NewAdminWorkerHandler(svc)
+}
@@ -0,0 +1,50 @@
+package analysis
+
+import (
+	"net/http"
+
+	commonanalysis "github.com/CABGenOrg/cabgen_backend/internal/handlers/common/analysis"
+	"github.com/CABGenOrg/cabgen_backend/internal/handlers/handlererrors"
+	"github.com/CABGenOrg/cabgen_backend/internal/responses"
+	"github.com/CABGenOrg/cabgen_backend/internal/services"
+	"github.com/CABGenOrg/cabgen_backend/internal/translation"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type AdminAnalysisEventHandler struct {
+	Service services.AnalysisEventService
+}
+
+func NewAdminAnalysisEventHandler(
+	svc services.AnalysisEventService) *AdminAnalysisEventHandler {
+	return &AdminAnalysisEventHandler{
+		Service: svc,
+	}
+}
+
+func (h *AdminAnalysisEventHandler) StreamAnalysisEvents(c *gin.Context) {
+	localizer := translation.GetLocalizerFromContext(c)
+	language := translation.GetLanguageFromContext(c)
+	rawID := c.Param("analysisId")
+
+	id, err := uuid.Parse(rawID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, responses.APIResponse{
+			Error: responses.GetResponse(localizer, responses.InvalidURLID),
+		})
+		return
+	}
+
+	events, err := h.Service.SubscribeAnalysis(c.Request.Context(), id,
+		uuid.Nil)
+	if err != nil {
+		code, errMsg := handlererrors.HandleAnalysisError(err)
+		c.JSON(code, responses.APIResponse{
+			Error: responses.GetResponse(localizer, errMsg),
+		})
+		return
+	}
+
+	commonanalysis.StreamEvents(c, events, language)
+}
@@ -100,7 +100,7 @@ func (h *AdminAnalysisHandler) CreateAnalysis(c *gin.Context) {
 	if err != nil {
 		code, errMsg := handlererrors.HandleAnalysisError(err)
 		c.JSON(code, responses.APIResponse{
-			Error: responses.GetResponse(localizer, errMsg),
+			Error: handlererrors.AnalysisErrorMessage(localizer, err, errMsg),
 		})
 		return
 	}
@@ -177,6 +177,140 @@ func (h *AdminAnalysisHandler) DeleteAnalysis(c *gin.Context) {
 	})
 }
 
+func (h *AdminAnalysisHandler) ResumeAnalysis(c *gin.Context) {
+	localizer := translation.GetLocalizerFromContext(c)
+	language := translation.GetLanguageFromContext(c)
+	rawID := c.Param("analysisId")
+
+	id, err := uuid.Parse(rawID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, responses.APIResponse{
+			Error: responses.GetResponse(localizer, responses.InvalidURLID),
+		})
+		return
+	}
+
+	analysis, err := h.Service.Resume(c.Request.Context(), id, uuid.Nil,
+		language)
+	if err != nil {
+		code, errMsg := handlererrors.HandleAnalysisError(err)
+		c.JSON(code, responses.APIResponse{
+			Error: responses.GetResponse(localizer, errMsg),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, responses.APIResponse{
+		Data: analysis,
+		Message: responses.GetResponse(localizer,
+			responses.AnalysisResumeSuccess),
+	})
+}
+
+func (h *AdminAnalysisHandler) CancelAnalysis(c *gin.Context) {
+	localizer := translation.GetLocalizerFromContext(c)
+	language := translation.GetLanguageFromContext(c)
+	rawID := c.Param("analysisId")
+
+	id, err := uuid.Parse(rawID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, responses.APIResponse{
+			Error: responses.GetResponse(localizer, responses.InvalidURLID),
+		})
+		return
+	}
+
+	analysis, err := h.Service.Cancel(c.Request.Context(), id, uuid.Nil,
+		language)
+	if err != nil {
+		code, errMsg := handlererrors.HandleAnalysisError(err)
+		c.JSON(code, responses.APIResponse{
+			Error: responses.GetResponse(localizer, errMsg),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, responses.APIResponse{
+		Data: analysis,
+		Message: responses.GetResponse(localizer,
+			responses.AnalysisCancelSuccess),
+	})
+}
+
+// GetAnalysisLogs lists the tool invocations of an analysis. When a step is
+// given, the raw stdout and stderr of its invocations are included.
+func (h *AdminAnalysisHandler) GetAnalysisLogs(c *gin.Context) {
+	localizer := translation.GetLocalizerFromContext(c)
+	rawID := c.Param("analysisId")
+
+	id, err := uuid.Parse(rawID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, responses.APIResponse{
+			Error: responses.GetResponse(localizer, responses.InvalidURLID),
+		})
+		return
+	}
+
+	step := models.AnalysisStep(c.Param("step"))
+	logs, err := h.Service.FindLogs(c.Request.Context(), id, uuid.Nil, step,
+		step != "")
+	if err != nil {
+		code, errMsg := handlererrors.HandleAnalysisError(err)
+		c.JSON(code, responses.APIResponse{
+			Error: responses.GetResponse(localizer, errMsg),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, responses.APIResponse{Data: logs})
+}
+
+// GetAnalysisTaxonomy returns the Kraken2 species and genus composition of
+// any analysis.
+func (h *AdminAnalysisHandler) GetAnalysisTaxonomy(c *gin.Context) {
+	localizer := translation.GetLocalizerFromContext(c)
+	rawID := c.Param("analysisId")
+
+	id, err := uuid.Parse(rawID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, responses.APIResponse{
+			Error: responses.GetResponse(localizer, responses.InvalidURLID),
+		})
+		return
+	}
+
+	taxonomy, err := h.Service.FindTaxonomy(c.Request.Context(), id,
+		uuid.Nil)
+	if err != nil {
+		code, errMsg := handlererrors.HandleAnalysisError(err)
+		c.JSON(code, responses.APIResponse{
+			Error: responses.GetResponse(localizer, errMsg),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, responses.APIResponse{Data: taxonomy})
+}
+
+// GetOutdatedAnalyses lists the finished analyses run against databases
+// that have been updated since.
+func (h *AdminAnalysisHandler) GetOutdatedAnalyses(c *gin.Context) {
+	localizer := translation.GetLocalizerFromContext(c)
+	language := translation.GetLanguageFromContext(c)
+
+	analyses, err := h.Service.FindOutdatedDatabases(c.Request.Context(),
+		language)
+	if err != nil {
+		code, errMsg := handlererrors.HandleAnalysisError(err)
+		c.JSON(code, responses.APIResponse{
+			Error: responses.GetResponse(localizer, errMsg),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, responses.APIResponse{Data: analyses})
+}
+
 func (h *AdminAnalysisHandler) DownloadZip(c *gin.Context) {
 	localizer := translation.GetLocalizerFromContext(c)
 	rawID := c.Param("analysisId")
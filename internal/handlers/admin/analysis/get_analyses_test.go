@@ -46,6 +46,26 @@ func TestGetAnalyses(t *testing.T) {
 		assert.JSONEq(t, expected, w.Body.String())
 	})
 
+	t.Run("Error - Invalid QC Verdict", func(t *testing.T) {
+		svc := &mocks.MockAnalysisService{}
+
+		handler := analysis.NewAdminAnalysisHandler(svc)
+
+		c, w := testutils.SetupGinContext(
+			http.MethodGet, "/api/admin/analysis?qcVerdict=MAYBE", "", nil, nil,
+		)
+		handler.GetAnalyses(c)
+
+		expected := testutils.ToJSON(
+			map[string]string{
+				"error": "Invalid query parameters.",
+			},
+		)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.JSONEq(t, expected, w.Body.String())
+	})
+
 	t.Run("Error - Internal Server", func(t *testing.T) {
 		svc := &mocks.MockAnalysisService{
 			FindAllFunc: func(ctx context.Context, userID uuid.UUID, filter models.AnalysisFilter, language string) (
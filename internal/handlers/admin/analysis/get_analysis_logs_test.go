@@ -0,0 +1,134 @@
+package analysis_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/CABGenOrg/cabgen_backend/internal/handlers/admin/analysis"
+	"github.com/CABGenOrg/cabgen_backend/internal/models"
+	"github.com/CABGenOrg/cabgen_backend/internal/services"
+	"github.com/CABGenOrg/cabgen_backend/internal/testutils"
+	"github.com/CABGenOrg/cabgen_backend/internal/testutils/mocks"
+	testmodels "github.com/CABGenOrg/cabgen_backend/internal/testutils/models"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetAnalysisLogs(t *testing.T) {
+	testutils.SetupTestContext()
+
+	mockAnalysis := testmodels.CreateMockAnalysis()
+	mockLog := testmodels.NewAnalysisLog(mockAnalysis.ID, models.StepCheckM,
+		time.Now().UTC())
+	mockResponse := []models.AnalysisLogResponse{mockLog.ToResponse()}
+
+	t.Run("Success", func(t *testing.T) {
+		svc := &mocks.MockAnalysisService{
+			FindLogsFunc: func(ctx context.Context, analysisID,
+				userID uuid.UUID, step models.AnalysisStep,
+				withOutput bool) ([]models.AnalysisLogResponse, error) {
+				assert.Equal(t, uuid.Nil, userID)
+				assert.Equal(t, models.StepCheckM, step)
+				assert.True(t, withOutput)
+				return mockResponse, nil
+			},
+		}
+
+		handler := analysis.NewAdminAnalysisHandler(svc)
+		c, w := testutils.SetupGinContext(
+			http.MethodGet, "/api/admin/analysis", "", nil,
+			gin.Params{
+				{Key: "analysisId", Value: mockAnalysis.ID.String()},
+				{Key: "step", Value: string(models.StepCheckM)},
+			},
+		)
+		handler.GetAnalysisLogs(c)
+
+		expected := testutils.ToJSON(
+			map[string]any{
+				"data": mockResponse,
+			},
+		)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.JSONEq(t, expected, w.Body.String())
+	})
+
+	t.Run("Error - Invalid ID", func(t *testing.T) {
+		svc := &mocks.MockAnalysisService{}
+
+		handler := analysis.NewAdminAnalysisHandler(svc)
+		c, w := testutils.SetupGinContext(
+			http.MethodGet, "/api/admin/analysis", "", nil,
+			gin.Params{{Key: "analysisId", Value: "123"}},
+		)
+		handler.GetAnalysisLogs(c)
+
+		expected := testutils.ToJSON(
+			map[string]string{
+				"error": "The URL ID is invalid.",
+			},
+		)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.JSONEq(t, expected, w.Body.String())
+	})
+
+	t.Run("Error - Invalid Step", func(t *testing.T) {
+		svc := &mocks.MockAnalysisService{
+			FindLogsFunc: func(ctx context.Context, analysisID,
+				userID uuid.UUID, step models.AnalysisStep,
+				withOutput bool) ([]models.AnalysisLogResponse, error) {
+				return nil, services.ErrInvalidAnalysisStep
+			},
+		}
+
+		handler := analysis.NewAdminAnalysisHandler(svc)
+		c, w := testutils.SetupGinContext(
+			http.MethodGet, "/api/admin/analysis", "", nil,
+			gin.Params{
+				{Key: "analysisId", Value: mockAnalysis.ID.String()},
+				{Key: "step", Value: "Unknown"},
+			},
+		)
+		handler.GetAnalysisLogs(c)
+
+		expected := testutils.ToJSON(
+			map[string]string{
+				"error": "This analysis step is invalid.",
+			},
+		)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.JSONEq(t, expected, w.Body.String())
+	})
+
+	t.Run("Error - Not Found", func(t *testing.T) {
+		svc := &mocks.MockAnalysisService{
+			FindLogsFunc: func(ctx context.Context, analysisID,
+				userID uuid.UUID, step models.AnalysisStep,
+				withOutput bool) ([]models.AnalysisLogResponse, error) {
+				return nil, services.ErrNotFound
+			},
+		}
+
+		handler := analysis.NewAdminAnalysisHandler(svc)
+		c, w := testutils.SetupGinContext(
+			http.MethodGet, "/api/admin/analysis", "", nil,
+			gin.Params{{Key: "analysisId", Value: mockAnalysis.ID.String()}},
+		)
+		handler.GetAnalysisLogs(c)
+
+		expected := testutils.ToJSON(
+			map[string]string{
+				"error": "Analysis not found.",
+			},
+		)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		assert.JSONEq(t, expected, w.Body.String())
+	})
+}
@@ -0,0 +1,88 @@
+package analysis_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/CABGenOrg/cabgen_backend/internal/handlers/admin/analysis"
+	"github.com/CABGenOrg/cabgen_backend/internal/models"
+	"github.com/CABGenOrg/cabgen_backend/internal/pipeline"
+	"github.com/CABGenOrg/cabgen_backend/internal/services"
+	"github.com/CABGenOrg/cabgen_backend/internal/testutils"
+	"github.com/CABGenOrg/cabgen_backend/internal/testutils/mocks"
+	testmodels "github.com/CABGenOrg/cabgen_backend/internal/testutils/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetOutdatedAnalyses(t *testing.T) {
+	testutils.SetupTestContext()
+
+	mockAnalysis := testmodels.CreateMockAnalysis()
+	mockResponse := models.OutdatedAnalysisResponse{
+		Analysis: mockAnalysis.ToResponse("en"),
+		Databases: []pipeline.OutdatedDatabase{
+			{
+				Name: "kraken2",
+				Used: pipeline.DatabaseFingerprint{
+					Name: "kraken2", Checksum: "old",
+				},
+				Current: pipeline.DatabaseFingerprint{
+					Name: "kraken2", Checksum: "new",
+				},
+			},
+		},
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		svc := &mocks.MockAnalysisService{
+			FindOutdatedDatabasesFunc: func(ctx context.Context,
+				language string) ([]models.OutdatedAnalysisResponse, error) {
+				return []models.OutdatedAnalysisResponse{mockResponse}, nil
+			},
+		}
+
+		handler := analysis.NewAdminAnalysisHandler(svc)
+
+		c, w := testutils.SetupGinContext(
+			http.MethodGet, "/api/admin/analysis/outdated-databases", "",
+			nil, nil,
+		)
+		handler.GetOutdatedAnalyses(c)
+
+		expected := testutils.ToJSON(
+			map[string][]models.OutdatedAnalysisResponse{
+				"data": {mockResponse},
+			},
+		)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.JSONEq(t, expected, w.Body.String())
+	})
+
+	t.Run("Error - Internal Server", func(t *testing.T) {
+		svc := &mocks.MockAnalysisService{
+			FindOutdatedDatabasesFunc: func(ctx context.Context,
+				language string) ([]models.OutdatedAnalysisResponse, error) {
+				return nil, services.ErrInternal
+			},
+		}
+
+		handler := analysis.NewAdminAnalysisHandler(svc)
+
+		c, w := testutils.SetupGinContext(
+			http.MethodGet, "/api/admin/analysis/outdated-databases", "",
+			nil, nil,
+		)
+		handler.GetOutdatedAnalyses(c)
+
+		expected := testutils.ToJSON(
+			map[string]string{
+				"error": "There was a server error. Please try again.",
+			},
+		)
+
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+		assert.JSONEq(t, expected, w.Body.String())
+	})
+}
@@ -0,0 +1,125 @@
+package analysis_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/CABGenOrg/cabgen_backend/internal/handlers/admin/analysis"
+	"github.com/CABGenOrg/cabgen_backend/internal/models"
+	"github.com/CABGenOrg/cabgen_backend/internal/services"
+	"github.com/CABGenOrg/cabgen_backend/internal/testutils"
+	"github.com/CABGenOrg/cabgen_backend/internal/testutils/mocks"
+	testmodels "github.com/CABGenOrg/cabgen_backend/internal/testutils/models"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResumeAnalysis(t *testing.T) {
+	testutils.SetupTestContext()
+
+	mockAnalysis := testmodels.CreateMockAnalysis()
+	mockAnalysis.Status = models.AnalysisStatusPending
+	mockResponse := mockAnalysis.ToResponse("en")
+
+	t.Run("Success", func(t *testing.T) {
+		svc := &mocks.MockAnalysisService{
+			ResumeFunc: func(ctx context.Context, analysisID,
+				userID uuid.UUID, language string) (
+				*models.AnalysisResponse, error) {
+				assert.Equal(t, uuid.Nil, userID)
+				return &mockResponse, nil
+			},
+		}
+
+		handler := analysis.NewAdminAnalysisHandler(svc)
+		c, w := testutils.SetupGinContext(
+			http.MethodPost, "/api/admin/analysis", "", nil,
+			gin.Params{{Key: "analysisId", Value: mockAnalysis.ID.String()}},
+		)
+		handler.ResumeAnalysis(c)
+
+		expected := testutils.ToJSON(
+			map[string]any{
+				"data":    mockResponse,
+				"message": "Analysis resumed successfully. Completed steps will be skipped.",
+			},
+		)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.JSONEq(t, expected, w.Body.String())
+	})
+
+	t.Run("Error - Invalid ID", func(t *testing.T) {
+		svc := &mocks.MockAnalysisService{}
+
+		handler := analysis.NewAdminAnalysisHandler(svc)
+		c, w := testutils.SetupGinContext(
+			http.MethodPost, "/api/admin/analysis", "", nil,
+			gin.Params{{Key: "analysisId", Value: "123"}},
+		)
+		handler.ResumeAnalysis(c)
+
+		expected := testutils.ToJSON(
+			map[string]string{
+				"error": "The URL ID is invalid.",
+			},
+		)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.JSONEq(t, expected, w.Body.String())
+	})
+
+	t.Run("Error - Not Resumable", func(t *testing.T) {
+		svc := &mocks.MockAnalysisService{
+			ResumeFunc: func(ctx context.Context, analysisID,
+				userID uuid.UUID, language string) (
+				*models.AnalysisResponse, error) {
+				return nil, services.ErrAnalysisNotResumable
+			},
+		}
+
+		handler := analysis.NewAdminAnalysisHandler(svc)
+		c, w := testutils.SetupGinContext(
+			http.MethodPost, "/api/admin/analysis", "", nil,
+			gin.Params{{Key: "analysisId", Value: mockAnalysis.ID.String()}},
+		)
+		handler.ResumeAnalysis(c)
+
+		expected := testutils.ToJSON(
+			map[string]string{
+				"error": "Only failed analyses can be resumed.",
+			},
+		)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.JSONEq(t, expected, w.Body.String())
+	})
+
+	t.Run("Error - Not Found", func(t *testing.T) {
+		svc := &mocks.MockAnalysisService{
+			ResumeFunc: func(ctx context.Context, analysisID,
+				userID uuid.UUID, language string) (
+				*models.AnalysisResponse, error) {
+				return nil, services.ErrNotFound
+			},
+		}
+
+		handler := analysis.NewAdminAnalysisHandler(svc)
+		c, w := testutils.SetupGinContext(
+			http.MethodPost, "/api/admin/analysis", "", nil,
+			gin.Params{{Key: "analysisId", Value: mockAnalysis.ID.String()}},
+		)
+		handler.ResumeAnalysis(c)
+
+		expected := testutils.ToJSON(
+			map[string]string{
+				"error": "Analysis not found.",
+			},
+		)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		assert.JSONEq(t, expected, w.Body.String())
+	})
+}
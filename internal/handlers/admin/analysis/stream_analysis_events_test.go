@@ -0,0 +1,141 @@
+package analysis_test
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/CABGenOrg/cabgen_backend/internal/handlers/admin/analysis"
+	commonanalysis "github.com/CABGenOrg/cabgen_backend/internal/handlers/common/analysis"
+	"github.com/CABGenOrg/cabgen_backend/internal/models"
+	"github.com/CABGenOrg/cabgen_backend/internal/pipeline"
+	"github.com/CABGenOrg/cabgen_backend/internal/services"
+	"github.com/CABGenOrg/cabgen_backend/internal/testutils"
+	"github.com/CABGenOrg/cabgen_backend/internal/testutils/mocks"
+	testmodels "github.com/CABGenOrg/cabgen_backend/internal/testutils/models"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func newEventStream(events ...models.AnalysisEvent) <-chan models.AnalysisEvent {
+	stream := make(chan models.AnalysisEvent, len(events))
+	for _, event := range events {
+		stream <- event
+	}
+	close(stream)
+	return stream
+}
+
+func TestStreamAnalysisEvents(t *testing.T) {
+	testutils.SetupTestContext()
+
+	mockAnalysis := testmodels.CreateMockAnalysis()
+
+	t.Run("Success", func(t *testing.T) {
+		errMsg := pipeline.ErrCheckM.Error()
+		svc := &mocks.MockAnalysisEventService{
+			SubscribeAnalysisFunc: func(ctx context.Context, analysisID,
+				userID uuid.UUID) (<-chan models.AnalysisEvent, error) {
+				assert.Equal(t, mockAnalysis.ID, analysisID)
+				assert.Equal(t, uuid.Nil, userID)
+				return newEventStream(
+					models.AnalysisEvent{AnalysisID: analysisID,
+						Status: models.AnalysisStatusRunning,
+						Step:   models.StepCheckM},
+					models.AnalysisEvent{AnalysisID: analysisID,
+						Status:       models.AnalysisStatusFailed,
+						ErrorMessage: &errMsg},
+				), nil
+			},
+		}
+
+		handler := analysis.NewAdminAnalysisEventHandler(svc)
+		c, w := testutils.SetupGinContext(
+			http.MethodGet, "/api/admin/analysis", "", nil,
+			gin.Params{{Key: "analysisId", Value: mockAnalysis.ID.String()}},
+		)
+		handler.StreamAnalysisEvents(c)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "text/event-stream", w.Result().Header.Get("Content-Type"))
+		assert.Equal(t, 2, strings.Count(w.Body.String(), "event:analysis"))
+		assert.Contains(t, w.Body.String(), `"step":"CheckM"`)
+		assert.Contains(t, w.Body.String(),
+			"The CheckM step failed. Create a new analysis.")
+	})
+
+	t.Run("Success - Ping While Idle", func(t *testing.T) {
+		interval := commonanalysis.EventPingInterval
+		commonanalysis.EventPingInterval = 5 * time.Millisecond
+		t.Cleanup(func() { commonanalysis.EventPingInterval = interval })
+
+		svc := &mocks.MockAnalysisEventService{
+			SubscribeAnalysisFunc: func(ctx context.Context, analysisID,
+				userID uuid.UUID) (<-chan models.AnalysisEvent, error) {
+				return make(chan models.AnalysisEvent), nil
+			},
+		}
+
+		handler := analysis.NewAdminAnalysisEventHandler(svc)
+		c, w := testutils.SetupGinContext(
+			http.MethodGet, "/api/admin/analysis", "", nil,
+			gin.Params{{Key: "analysisId", Value: mockAnalysis.ID.String()}},
+		)
+		ctx, cancel := context.WithTimeout(c.Request.Context(),
+			50*time.Millisecond)
+		defer cancel()
+		c.Request = c.Request.WithContext(ctx)
+		handler.StreamAnalysisEvents(c)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), ": ping\n\n")
+		assert.NotContains(t, w.Body.String(), "event:analysis")
+	})
+
+	t.Run("Error - Invalid ID", func(t *testing.T) {
+		handler := analysis.NewAdminAnalysisEventHandler(
+			&mocks.MockAnalysisEventService{})
+		c, w := testutils.SetupGinContext(
+			http.MethodGet, "/api/admin/analysis", "", nil,
+			gin.Params{{Key: "analysisId", Value: "123"}},
+		)
+		handler.StreamAnalysisEvents(c)
+
+		expected := testutils.ToJSON(
+			map[string]string{
+				"error": "The URL ID is invalid.",
+			},
+		)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.JSONEq(t, expected, w.Body.String())
+	})
+
+	t.Run("Error - Not Found", func(t *testing.T) {
+		svc := &mocks.MockAnalysisEventService{
+			SubscribeAnalysisFunc: func(ctx context.Context, analysisID,
+				userID uuid.UUID) (<-chan models.AnalysisEvent, error) {
+				return nil, services.ErrNotFound
+			},
+		}
+
+		handler := analysis.NewAdminAnalysisEventHandler(svc)
+		c, w := testutils.SetupGinContext(
+			http.MethodGet, "/api/admin/analysis", "", nil,
+			gin.Params{{Key: "analysisId", Value: mockAnalysis.ID.String()}},
+		)
+		handler.StreamAnalysisEvents(c)
+
+		expected := testutils.ToJSON(
+			map[string]string{
+				"error": "Analysis not found.",
+			},
+		)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		assert.JSONEq(t, expected, w.Body.String())
+	})
+}
@@ -0,0 +1,125 @@
+package qcrule_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/CABGenOrg/cabgen_backend/internal/handlers/admin/qcrule"
+	"github.com/CABGenOrg/cabgen_backend/internal/models"
+	"github.com/CABGenOrg/cabgen_backend/internal/pipeline"
+	"github.com/CABGenOrg/cabgen_backend/internal/services"
+	"github.com/CABGenOrg/cabgen_backend/internal/testutils"
+	"github.com/CABGenOrg/cabgen_backend/internal/testutils/data"
+	"github.com/CABGenOrg/cabgen_backend/internal/testutils/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateQCRule(t *testing.T) {
+	testutils.SetupTestContext()
+
+	minCompleteness := 90.0
+	input := models.QCRuleCreateInput{
+		Metric:   pipeline.QCMetricCompleteness,
+		Min:      &minCompleteness,
+		Severity: pipeline.QCSeverityFail,
+		IsActive: true,
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		svc := &mocks.MockQCRuleService{
+			CreateFunc: func(ctx context.Context, input models.QCRuleCreateInput) (*models.QCRuleAdminTableResponse, error) {
+				return &models.QCRuleAdminTableResponse{
+					Metric:   input.Metric,
+					Min:      input.Min,
+					Severity: input.Severity,
+					IsActive: input.IsActive,
+				}, nil
+			},
+		}
+		handler := qcrule.NewAdminQCRuleHandler(svc)
+
+		body := testutils.ToJSON(input)
+		c, w := testutils.SetupGinContext(
+			http.MethodPost, "/api/admin/qc-rules", body,
+			nil, nil,
+		)
+		handler.CreateQCRule(c)
+
+		expected := testutils.ToJSON(
+			map[string]any{
+				"message": "QC rule registered successfully.",
+				"data": models.QCRuleAdminTableResponse{
+					Metric:   input.Metric,
+					Min:      input.Min,
+					Severity: input.Severity,
+					IsActive: input.IsActive,
+				},
+			},
+		)
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+		assert.JSONEq(t, expected, w.Body.String())
+	})
+
+	for _, tt := range data.CreateQCRuleTests {
+		t.Run(tt.Name, func(t *testing.T) {
+			handler := qcrule.NewAdminQCRuleHandler(&mocks.MockQCRuleService{})
+
+			c, w := testutils.SetupGinContext(
+				http.MethodPost, "/api/admin/qc-rules", tt.Body,
+				nil, nil,
+			)
+			handler.CreateQCRule(c)
+
+			assert.Equal(t, http.StatusBadRequest, w.Code)
+			assert.JSONEq(t, tt.Expected, w.Body.String())
+		})
+	}
+
+	t.Run("Error - Invalid Rule", func(t *testing.T) {
+		svc := &mocks.MockQCRuleService{
+			CreateFunc: func(ctx context.Context, input models.QCRuleCreateInput) (*models.QCRuleAdminTableResponse, error) {
+				return nil, services.ErrInvalidQCRule
+			},
+		}
+		handler := qcrule.NewAdminQCRuleHandler(svc)
+
+		body := testutils.ToJSON(input)
+		c, w := testutils.SetupGinContext(
+			http.MethodPost, "/api/admin/qc-rules", body,
+			nil, nil,
+		)
+		handler.CreateQCRule(c)
+
+		expected := testutils.ToJSON(map[string]any{
+			"error": "Invalid QC rule. Use a known metric and severity (WARN or FAIL) and set min and/or max, with min not above max.",
+		})
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.JSONEq(t, expected, w.Body.String())
+	})
+
+	t.Run("Error - Internal Server", func(t *testing.T) {
+		svc := &mocks.MockQCRuleService{
+			CreateFunc: func(ctx context.Context, input models.QCRuleCreateInput) (*models.QCRuleAdminTableResponse, error) {
+				return nil, services.ErrInternal
+			},
+		}
+		handler := qcrule.NewAdminQCRuleHandler(svc)
+
+		body := testutils.ToJSON(input)
+		c, w := testutils.SetupGinContext(
+			http.MethodPost, "/api/admin/qc-rules", body,
+			nil, nil,
+		)
+		handler.CreateQCRule(c)
+
+		expected := testutils.ToJSON(map[string]any{
+			"error": "There was a server error. Please try again.",
+		})
+
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+		assert.JSONEq(t, expected, w.Body.String())
+	})
+}
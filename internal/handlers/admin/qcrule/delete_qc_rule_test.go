@@ -0,0 +1,99 @@
+package qcrule_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/CABGenOrg/cabgen_backend/internal/handlers/admin/qcrule"
+	"github.com/CABGenOrg/cabgen_backend/internal/services"
+	"github.com/CABGenOrg/cabgen_backend/internal/testutils"
+	"github.com/CABGenOrg/cabgen_backend/internal/testutils/mocks"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeleteQCRule(t *testing.T) {
+	testutils.SetupTestContext()
+
+	params := gin.Params{{Key: "qcRuleId", Value: uuid.NewString()}}
+
+	t.Run("Success", func(t *testing.T) {
+		handler := qcrule.NewAdminQCRuleHandler(&mocks.MockQCRuleService{})
+
+		c, w := testutils.SetupGinContext(
+			http.MethodDelete, "/api/admin/qc-rules", "",
+			nil, params,
+		)
+		handler.DeleteQCRule(c)
+
+		expected := testutils.ToJSON(map[string]any{
+			"message": "QC rule deleted successfully.",
+		})
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.JSONEq(t, expected, w.Body.String())
+	})
+
+	t.Run("Error - Invalid ID", func(t *testing.T) {
+		handler := qcrule.NewAdminQCRuleHandler(&mocks.MockQCRuleService{})
+
+		c, w := testutils.SetupGinContext(
+			http.MethodDelete, "/api/admin/qc-rules", "",
+			nil, gin.Params{{Key: "qcRuleId", Value: "invalid"}},
+		)
+		handler.DeleteQCRule(c)
+
+		expected := testutils.ToJSON(map[string]any{
+			"error": "The URL ID is invalid.",
+		})
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.JSONEq(t, expected, w.Body.String())
+	})
+
+	t.Run("Error - Not Found", func(t *testing.T) {
+		svc := &mocks.MockQCRuleService{
+			DeleteFunc: func(ctx context.Context, ID uuid.UUID) error {
+				return services.ErrNotFound
+			},
+		}
+		handler := qcrule.NewAdminQCRuleHandler(svc)
+
+		c, w := testutils.SetupGinContext(
+			http.MethodDelete, "/api/admin/qc-rules", "",
+			nil, params,
+		)
+		handler.DeleteQCRule(c)
+
+		expected := testutils.ToJSON(map[string]any{
+			"error": "QC rule not found.",
+		})
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		assert.JSONEq(t, expected, w.Body.String())
+	})
+
+	t.Run("Error - Internal Server", func(t *testing.T) {
+		svc := &mocks.MockQCRuleService{
+			DeleteFunc: func(ctx context.Context, ID uuid.UUID) error {
+				return services.ErrInternal
+			},
+		}
+		handler := qcrule.NewAdminQCRuleHandler(svc)
+
+		c, w := testutils.SetupGinContext(
+			http.MethodDelete, "/api/admin/qc-rules", "",
+			nil, params,
+		)
+		handler.DeleteQCRule(c)
+
+		expected := testutils.ToJSON(map[string]any{
+			"error": "There was a server error. Please try again.",
+		})
+
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+		assert.JSONEq(t, expected, w.Body.String())
+	})
+}
@@ -0,0 +1,92 @@
+package qcrule_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/CABGenOrg/cabgen_backend/internal/handlers/admin/qcrule"
+	"github.com/CABGenOrg/cabgen_backend/internal/models"
+	"github.com/CABGenOrg/cabgen_backend/internal/pipeline"
+	"github.com/CABGenOrg/cabgen_backend/internal/services"
+	"github.com/CABGenOrg/cabgen_backend/internal/testutils"
+	"github.com/CABGenOrg/cabgen_backend/internal/testutils/mocks"
+	testmodels "github.com/CABGenOrg/cabgen_backend/internal/testutils/models"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetQCRuleByID(t *testing.T) {
+	testutils.SetupTestContext()
+
+	maxContamination := 5.0
+	mockRule := testmodels.NewQCRule(uuid.NewString(),
+		pipeline.QCMetricContamination, nil, &maxContamination,
+		pipeline.QCSeverityWarn, true)
+	params := gin.Params{{Key: "qcRuleId", Value: mockRule.ID.String()}}
+
+	t.Run("Success", func(t *testing.T) {
+		svc := &mocks.MockQCRuleService{
+			FindByIDFunc: func(ctx context.Context, ID uuid.UUID) (*models.QCRuleAdminTableResponse, error) {
+				response := mockRule.ToAdminTableResponse()
+				return &response, nil
+			},
+		}
+		handler := qcrule.NewAdminQCRuleHandler(svc)
+
+		c, w := testutils.SetupGinContext(
+			http.MethodGet, "/api/admin/qc-rules", "",
+			nil, params,
+		)
+		handler.GetQCRuleByID(c)
+
+		expected := testutils.ToJSON(
+			map[string]any{
+				"data": mockRule.ToAdminTableResponse(),
+			},
+		)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.JSONEq(t, expected, w.Body.String())
+	})
+
+	t.Run("Error - Invalid ID", func(t *testing.T) {
+		handler := qcrule.NewAdminQCRuleHandler(&mocks.MockQCRuleService{})
+
+		c, w := testutils.SetupGinContext(
+			http.MethodGet, "/api/admin/qc-rules", "",
+			nil, gin.Params{{Key: "qcRuleId", Value: "invalid"}},
+		)
+		handler.GetQCRuleByID(c)
+
+		expected := testutils.ToJSON(map[string]any{
+			"error": "The URL ID is invalid.",
+		})
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.JSONEq(t, expected, w.Body.String())
+	})
+
+	t.Run("Error - Not Found", func(t *testing.T) {
+		svc := &mocks.MockQCRuleService{
+			FindByIDFunc: func(ctx context.Context, ID uuid.UUID) (*models.QCRuleAdminTableResponse, error) {
+				return nil, services.ErrNotFound
+			},
+		}
+		handler := qcrule.NewAdminQCRuleHandler(svc)
+
+		c, w := testutils.SetupGinContext(
+			http.MethodGet, "/api/admin/qc-rules", "",
+			nil, params,
+		)
+		handler.GetQCRuleByID(c)
+
+		expected := testutils.ToJSON(map[string]any{
+			"error": "QC rule not found.",
+		})
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		assert.JSONEq(t, expected, w.Body.String())
+	})
+}
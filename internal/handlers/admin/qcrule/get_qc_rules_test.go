@@ -0,0 +1,76 @@
+package qcrule_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/CABGenOrg/cabgen_backend/internal/handlers/admin/qcrule"
+	"github.com/CABGenOrg/cabgen_backend/internal/models"
+	"github.com/CABGenOrg/cabgen_backend/internal/pipeline"
+	"github.com/CABGenOrg/cabgen_backend/internal/services"
+	"github.com/CABGenOrg/cabgen_backend/internal/testutils"
+	"github.com/CABGenOrg/cabgen_backend/internal/testutils/mocks"
+	testmodels "github.com/CABGenOrg/cabgen_backend/internal/testutils/models"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetQCRules(t *testing.T) {
+	testutils.SetupTestContext()
+
+	minCompleteness := 90.0
+	mockRule := testmodels.NewQCRule(uuid.NewString(),
+		pipeline.QCMetricCompleteness, &minCompleteness, nil,
+		pipeline.QCSeverityFail, true)
+
+	t.Run("Success", func(t *testing.T) {
+		svc := &mocks.MockQCRuleService{
+			FindAllFunc: func(ctx context.Context) ([]models.QCRuleAdminTableResponse, error) {
+				return []models.QCRuleAdminTableResponse{
+					mockRule.ToAdminTableResponse(),
+				}, nil
+			},
+		}
+		handler := qcrule.NewAdminQCRuleHandler(svc)
+
+		c, w := testutils.SetupGinContext(
+			http.MethodGet, "/api/admin/qc-rules", "",
+			nil, nil,
+		)
+		handler.GetQCRules(c)
+
+		expected := testutils.ToJSON(
+			map[string]any{
+				"data": []models.QCRuleAdminTableResponse{
+					mockRule.ToAdminTableResponse(),
+				},
+			},
+		)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.JSONEq(t, expected, w.Body.String())
+	})
+
+	t.Run("Error - Internal Server", func(t *testing.T) {
+		svc := &mocks.MockQCRuleService{
+			FindAllFunc: func(ctx context.Context) ([]models.QCRuleAdminTableResponse, error) {
+				return nil, services.ErrInternal
+			},
+		}
+		handler := qcrule.NewAdminQCRuleHandler(svc)
+
+		c, w := testutils.SetupGinContext(
+			http.MethodGet, "/api/admin/qc-rules", "",
+			nil, nil,
+		)
+		handler.GetQCRules(c)
+
+		expected := testutils.ToJSON(map[string]any{
+			"error": "There was a server error. Please try again.",
+		})
+
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+		assert.JSONEq(t, expected, w.Body.String())
+	})
+}
@@ -0,0 +1,146 @@
+package qcrule
+
+import (
+	"net/http"
+
+	"github.com/CABGenOrg/cabgen_backend/internal/handlers/handlererrors"
+	"github.com/CABGenOrg/cabgen_backend/internal/models"
+	"github.com/CABGenOrg/cabgen_backend/internal/responses"
+	"github.com/CABGenOrg/cabgen_backend/internal/services"
+	"github.com/CABGenOrg/cabgen_backend/internal/translation"
+	"github.com/CABGenOrg/cabgen_backend/internal/validations"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type AdminQCRuleHandler struct {
+	Service services.QCRuleService
+}
+
+func NewAdminQCRuleHandler(svc services.QCRuleService) *AdminQCRuleHandler {
+	return &AdminQCRuleHandler{Service: svc}
+}
+
+func (h *AdminQCRuleHandler) GetQCRules(c *gin.Context) {
+	localizer := translation.GetLocalizerFromContext(c)
+
+	rules, err := h.Service.FindAll(c.Request.Context())
+	if err != nil {
+		code, errMsg := handlererrors.HandleQCRuleError(err)
+		c.JSON(code, responses.APIResponse{
+			Error: responses.GetResponse(localizer, errMsg),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, responses.APIResponse{Data: rules})
+}
+
+func (h *AdminQCRuleHandler) GetQCRuleByID(c *gin.Context) {
+	localizer := translation.GetLocalizerFromContext(c)
+	rawID := c.Param("qcRuleId")
+
+	id, err := uuid.Parse(rawID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, responses.APIResponse{
+			Error: responses.GetResponse(localizer, responses.InvalidURLID),
+		})
+		return
+	}
+
+	rule, err := h.Service.FindByID(c.Request.Context(), id)
+	if err != nil {
+		code, errMsg := handlererrors.HandleQCRuleError(err)
+		c.JSON(code, responses.APIResponse{
+			Error: responses.GetResponse(localizer, errMsg),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, responses.APIResponse{Data: rule})
+}
+
+func (h *AdminQCRuleHandler) CreateQCRule(c *gin.Context) {
+	localizer := translation.GetLocalizerFromContext(c)
+
+	var newRule models.QCRuleCreateInput
+	if errMsg, valid := validations.Validate(c, localizer, &newRule); !valid {
+		c.JSON(http.StatusBadRequest, responses.APIResponse{
+			Error: errMsg,
+		})
+		return
+	}
+
+	rule, err := h.Service.Create(c.Request.Context(), newRule)
+	if err != nil {
+		code, errMsg := handlererrors.HandleQCRuleError(err)
+		c.JSON(code, responses.APIResponse{
+			Error: responses.GetResponse(localizer, errMsg),
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, responses.APIResponse{
+		Message: responses.GetResponse(localizer, responses.QCRuleCreationSuccess),
+		Data:    rule,
+	})
+}
+
+func (h *AdminQCRuleHandler) UpdateQCRule(c *gin.Context) {
+	localizer := translation.GetLocalizerFromContext(c)
+	rawID := c.Param("qcRuleId")
+
+	id, err := uuid.Parse(rawID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, responses.APIResponse{
+			Error: responses.GetResponse(localizer, responses.InvalidURLID),
+		})
+		return
+	}
+
+	var ruleUpdateInput models.QCRuleUpdateInput
+	errMsg, ok := validations.Validate(c, localizer, &ruleUpdateInput)
+	if !ok {
+		c.JSON(http.StatusBadRequest, responses.APIResponse{
+			Error: errMsg,
+		})
+		return
+	}
+
+	ruleUpdated, err := h.Service.Update(c.Request.Context(), id,
+		ruleUpdateInput)
+	if err != nil {
+		code, errMsg := handlererrors.HandleQCRuleError(err)
+		c.JSON(code, responses.APIResponse{
+			Error: responses.GetResponse(localizer, errMsg),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, responses.APIResponse{Data: ruleUpdated})
+}
+
+func (h *AdminQCRuleHandler) DeleteQCRule(c *gin.Context) {
+	localizer := translation.GetLocalizerFromContext(c)
+	rawID := c.Param("qcRuleId")
+
+	id, err := uuid.Parse(rawID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, responses.APIResponse{
+			Error: responses.GetResponse(localizer, responses.InvalidURLID),
+		})
+		return
+	}
+
+	if err := h.Service.Delete(c.Request.Context(), id); err != nil {
+		code, errMsg := handlererrors.HandleQCRuleError(err)
+		c.JSON(code, responses.APIResponse{
+			Error: responses.GetResponse(localizer, errMsg),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, responses.APIResponse{
+		Message: responses.GetResponse(localizer, responses.QCRuleDeleted),
+	})
+}
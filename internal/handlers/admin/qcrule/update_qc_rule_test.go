@@ -0,0 +1,125 @@
+package qcrule_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/CABGenOrg/cabgen_backend/internal/handlers/admin/qcrule"
+	"github.com/CABGenOrg/cabgen_backend/internal/models"
+	"github.com/CABGenOrg/cabgen_backend/internal/pipeline"
+	"github.com/CABGenOrg/cabgen_backend/internal/services"
+	"github.com/CABGenOrg/cabgen_backend/internal/testutils"
+	"github.com/CABGenOrg/cabgen_backend/internal/testutils/data"
+	"github.com/CABGenOrg/cabgen_backend/internal/testutils/mocks"
+	testmodels "github.com/CABGenOrg/cabgen_backend/internal/testutils/models"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUpdateQCRule(t *testing.T) {
+	testutils.SetupTestContext()
+
+	minCompleteness := 90.0
+	mockRule := testmodels.NewQCRule(uuid.NewString(),
+		pipeline.QCMetricCompleteness, &minCompleteness, nil,
+		pipeline.QCSeverityFail, true)
+	params := gin.Params{{Key: "qcRuleId", Value: mockRule.ID.String()}}
+
+	severity := pipeline.QCSeverityWarn
+	input := models.QCRuleUpdateInput{Severity: &severity}
+
+	t.Run("Success", func(t *testing.T) {
+		updated := mockRule.ToAdminTableResponse()
+		updated.Severity = severity
+		svc := &mocks.MockQCRuleService{
+			UpdateFunc: func(ctx context.Context, ID uuid.UUID, input models.QCRuleUpdateInput) (*models.QCRuleAdminTableResponse, error) {
+				return &updated, nil
+			},
+		}
+		handler := qcrule.NewAdminQCRuleHandler(svc)
+
+		c, w := testutils.SetupGinContext(
+			http.MethodPut, "/api/admin/qc-rules", testutils.ToJSON(input),
+			nil, params,
+		)
+		handler.UpdateQCRule(c)
+
+		expected := testutils.ToJSON(map[string]any{"data": updated})
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.JSONEq(t, expected, w.Body.String())
+	})
+
+	t.Run("Error - Invalid ID", func(t *testing.T) {
+		handler := qcrule.NewAdminQCRuleHandler(&mocks.MockQCRuleService{})
+
+		c, w := testutils.SetupGinContext(
+			http.MethodPut, "/api/admin/qc-rules", testutils.ToJSON(input),
+			nil, gin.Params{{Key: "qcRuleId", Value: "invalid"}},
+		)
+		handler.UpdateQCRule(c)
+
+		expected := testutils.ToJSON(map[string]any{
+			"error": "The URL ID is invalid.",
+		})
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.JSONEq(t, expected, w.Body.String())
+	})
+
+	for _, tt := range data.UpdateQCRuleTests {
+		t.Run(tt.Name, func(t *testing.T) {
+			handler := qcrule.NewAdminQCRuleHandler(&mocks.MockQCRuleService{})
+
+			c, w := testutils.SetupGinContext(
+				http.MethodPut, "/api/admin/qc-rules", tt.Body,
+				nil, params,
+			)
+			handler.UpdateQCRule(c)
+
+			assert.Equal(t, http.StatusBadRequest, w.Code)
+			assert.JSONEq(t, tt.Expected, w.Body.String())
+		})
+	}
+
+	t.Run("Error - Not Found", func(t *testing.T) {
+		svc := &mocks.MockQCRuleService{
+			UpdateFunc: func(ctx context.Context, ID uuid.UUID, input models.QCRuleUpdateInput) (*models.QCRuleAdminTableResponse, error) {
+				return nil, services.ErrNotFound
+			},
+		}
+		handler := qcrule.NewAdminQCRuleHandler(svc)
+
+		c, w := testutils.SetupGinContext(
+			http.MethodPut, "/api/admin/qc-rules", testutils.ToJSON(input),
+			nil, params,
+		)
+		handler.UpdateQCRule(c)
+
+		expected := testutils.ToJSON(map[string]any{
+			"error": "QC rule not found.",
+		})
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		assert.JSONEq(t, expected, w.Body.String())
+	})
+
+	t.Run("Error - Invalid Rule", func(t *testing.T) {
+		svc := &mocks.MockQCRuleService{
+			UpdateFunc: func(ctx context.Context, ID uuid.UUID, input models.QCRuleUpdateInput) (*models.QCRuleAdminTableResponse, error) {
+				return nil, services.ErrInvalidQCRule
+			},
+		}
+		handler := qcrule.NewAdminQCRuleHandler(svc)
+
+		c, w := testutils.SetupGinContext(
+			http.MethodPut, "/api/admin/qc-rules", testutils.ToJSON(input),
+			nil, params,
+		)
+		handler.UpdateQCRule(c)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
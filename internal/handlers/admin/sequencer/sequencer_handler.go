@@ -104,6 +104,15 @@ func (h *AdminSequencerHandler) CreateSequencer(c *gin.Context) {
 		return
 	}
 
+	if newSequencer.ReadTechnology != "" &&
+		!newSequencer.ReadTechnology.IsValid() {
+		c.JSON(http.StatusBadRequest, responses.APIResponse{
+			Error: responses.GetResponse(localizer,
+				responses.SequencerInvalidReadTechnology),
+		})
+		return
+	}
+
 	sequencer, err := h.Service.Create(c.Request.Context(), newSequencer)
 	if err != nil {
 		code, errMsg := handlererrors.HandleSequencerError(err)
@@ -141,6 +150,15 @@ func (h *AdminSequencerHandler) UpdateSequencer(c *gin.Context) {
 		return
 	}
 
+	if sequencerUpdateInput.ReadTechnology != nil &&
+		!sequencerUpdateInput.ReadTechnology.IsValid() {
+		c.JSON(http.StatusBadRequest, responses.APIResponse{
+			Error: responses.GetResponse(localizer,
+				responses.SequencerInvalidReadTechnology),
+		})
+		return
+	}
+
 	sequencerUpdated, err := h.Service.Update(c.Request.Context(), id, sequencerUpdateInput)
 	if err != nil {
 		code, errMsg := handlererrors.HandleSequencerError(err)
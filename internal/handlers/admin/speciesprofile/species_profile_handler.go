@@ -0,0 +1,36 @@
+package speciesprofile
+
+import (
+	"net/http"
+
+	"github.com/CABGenOrg/cabgen_backend/internal/responses"
+	"github.com/CABGenOrg/cabgen_backend/internal/services"
+	"github.com/CABGenOrg/cabgen_backend/internal/translation"
+	"github.com/gin-gonic/gin"
+)
+
+type AdminSpeciesProfileHandler struct {
+	Service services.SpeciesProfileService
+}
+
+func NewAdminSpeciesProfileHandler(
+	svc services.SpeciesProfileService) *AdminSpeciesProfileHandler {
+	return &AdminSpeciesProfileHandler{
+		Service: svc,
+	}
+}
+
+func (h *AdminSpeciesProfileHandler) GetSpeciesProfiles(c *gin.Context) {
+	localizer := translation.GetLocalizerFromContext(c)
+
+	profiles, err := h.Service.FindAll(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, responses.APIResponse{
+			Error: responses.GetResponse(localizer,
+				responses.GenericInternalServerError),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, responses.APIResponse{Data: profiles})
+}
@@ -0,0 +1,76 @@
+package speciesprofile_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/CABGenOrg/cabgen_backend/internal/handlers/admin/speciesprofile"
+	"github.com/CABGenOrg/cabgen_backend/internal/models"
+	"github.com/CABGenOrg/cabgen_backend/internal/services"
+	"github.com/CABGenOrg/cabgen_backend/internal/testutils"
+	"github.com/CABGenOrg/cabgen_backend/internal/testutils/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAdminGetSpeciesProfiles(t *testing.T) {
+	testutils.SetupTestContext()
+
+	mockResponse := []models.SpeciesProfileResponse{
+		{
+			Name:           "Klebsiella pneumoniae",
+			Source:         "klebsiella.toml",
+			KrakenMatchers: []string{"Klebsiella pneumoniae"},
+			PoliGenes:      []string{"PmrB"},
+			OtherGenes:     []string{"GyrA"},
+			Valid:          false,
+			Issues: []string{
+				"environment variable POLI_DB_KLEB is not set",
+			},
+		},
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		svc := &mocks.MockSpeciesProfileService{
+			FindAllFunc: func(ctx context.Context) (
+				[]models.SpeciesProfileResponse, error) {
+				return mockResponse, nil
+			},
+		}
+
+		handler := speciesprofile.NewAdminSpeciesProfileHandler(svc)
+
+		c, w := testutils.SetupGinContext(
+			http.MethodGet, "/api/admin/species-profiles", "", nil, nil,
+		)
+		handler.GetSpeciesProfiles(c)
+
+		resp := testutils.ToJSON(map[string]any{"data": mockResponse})
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.JSONEq(t, resp, w.Body.String())
+	})
+
+	t.Run("Error - Internal Server", func(t *testing.T) {
+		svc := &mocks.MockSpeciesProfileService{
+			FindAllFunc: func(ctx context.Context) (
+				[]models.SpeciesProfileResponse, error) {
+				return nil, services.ErrInternal
+			},
+		}
+
+		handler := speciesprofile.NewAdminSpeciesProfileHandler(svc)
+
+		c, w := testutils.SetupGinContext(
+			http.MethodGet, "/api/admin/species-profiles", "", nil, nil,
+		)
+		handler.GetSpeciesProfiles(c)
+
+		expected := testutils.ToJSON(map[string]string{
+			"error": "There was a server error. Please try again.",
+		})
+
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+		assert.JSONEq(t, expected, w.Body.String())
+	})
+}
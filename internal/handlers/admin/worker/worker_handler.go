@@ -0,0 +1,38 @@
+package worker
+
+import (
+	"net/http"
+
+	"github.com/CABGenOrg/cabgen_backend/internal/responses"
+	"github.com/CABGenOrg/cabgen_backend/internal/services"
+	"github.com/CABGenOrg/cabgen_backend/internal/translation"
+	"github.com/gin-gonic/gin"
+)
+
+type AdminWorkerHandler struct {
+	Service services.WorkerEnvironmentService
+}
+
+func NewAdminWorkerHandler(
+	svc services.WorkerEnvironmentService) *AdminWorkerHandler {
+	return &AdminWorkerHandler{
+		Service: svc,
+	}
+}
+
+// GetWorkers lists the tools and databases of every analysis worker and
+// whether they match the manifest.
+func (h *AdminWorkerHandler) GetWorkers(c *gin.Context) {
+	localizer := translation.GetLocalizerFromContext(c)
+
+	environments, err := h.Service.FindAll(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, responses.APIResponse{
+			Error: responses.GetResponse(localizer,
+				responses.GenericInternalServerError),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, responses.APIResponse{Data: environments})
+}
@@ -0,0 +1,81 @@
+package worker_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/CABGenOrg/cabgen_backend/internal/handlers/admin/worker"
+	"github.com/CABGenOrg/cabgen_backend/internal/models"
+	"github.com/CABGenOrg/cabgen_backend/internal/pipeline"
+	"github.com/CABGenOrg/cabgen_backend/internal/services"
+	"github.com/CABGenOrg/cabgen_backend/internal/testutils"
+	"github.com/CABGenOrg/cabgen_backend/internal/testutils/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAdminGetWorkers(t *testing.T) {
+	testutils.SetupTestContext()
+
+	mockResponse := []models.WorkerEnvironment{
+		{
+			ID:       "worker-1:42",
+			Hostname: "worker-1",
+			Status:   models.WorkerStatusDegraded,
+			Tools: []pipeline.ToolVersion{
+				{Name: "Prokka", Version: "1.13.7"},
+			},
+			Databases: []pipeline.DatabaseFingerprint{
+				{Name: "kraken2", Checksum: "abc"},
+			},
+			Mismatches: []pipeline.ManifestMismatch{
+				{Kind: "tool", Name: "Prokka", Expected: "1.14",
+					Actual: "1.13.7"},
+			},
+		},
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		svc := &mocks.MockWorkerEnvironmentService{
+			FindAllFunc: func(ctx context.Context) (
+				[]models.WorkerEnvironment, error) {
+				return mockResponse, nil
+			},
+		}
+
+		handler := worker.NewAdminWorkerHandler(svc)
+
+		c, w := testutils.SetupGinContext(
+			http.MethodGet, "/api/admin/workers", "", nil, nil,
+		)
+		handler.GetWorkers(c)
+
+		resp := testutils.ToJSON(map[string]any{"data": mockResponse})
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.JSONEq(t, resp, w.Body.String())
+	})
+
+	t.Run("Error - Internal Server", func(t *testing.T) {
+		svc := &mocks.MockWorkerEnvironmentService{
+			FindAllFunc: func(ctx context.Context) (
+				[]models.WorkerEnvironment, error) {
+				return nil, services.ErrInternal
+			},
+		}
+
+		handler := worker.NewAdminWorkerHandler(svc)
+
+		c, w := testutils.SetupGinContext(
+			http.MethodGet, "/api/admin/workers", "", nil, nil,
+		)
+		handler.GetWorkers(c)
+
+		expected := testutils.ToJSON(map[string]string{
+			"error": "There was a server error. Please try again.",
+		})
+
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+		assert.JSONEq(t, expected, w.Body.String())
+	})
+}
@@ -0,0 +1,121 @@
+package analysis
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/CABGenOrg/cabgen_backend/internal/handlers/handlererrors"
+	"github.com/CABGenOrg/cabgen_backend/internal/models"
+	"github.com/CABGenOrg/cabgen_backend/internal/responses"
+	"github.com/CABGenOrg/cabgen_backend/internal/services"
+	"github.com/CABGenOrg/cabgen_backend/internal/translation"
+	"github.com/CABGenOrg/cabgen_backend/internal/validations"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type AnalysisEventHandler struct {
+	Service services.AnalysisEventService
+}
+
+func NewAnalysisEventHandler(
+	svc services.AnalysisEventService) *AnalysisEventHandler {
+	return &AnalysisEventHandler{
+		Service: svc,
+	}
+}
+
+func (h *AnalysisEventHandler) StreamAnalysisEvents(c *gin.Context) {
+	localizer := translation.GetLocalizerFromContext(c)
+	language := translation.GetLanguageFromContext(c)
+	rawID := c.Param("analysisId")
+
+	id, err := uuid.Parse(rawID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, responses.APIResponse{
+			Error: responses.GetResponse(localizer, responses.InvalidURLID),
+		})
+		return
+	}
+
+	userToken, ok := validations.GetUserTokenFromContext(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, responses.APIResponse{
+			Error: responses.GetResponse(localizer, responses.UnauthorizedError),
+		})
+		return
+	}
+
+	events, err := h.Service.SubscribeAnalysis(c.Request.Context(), id,
+		userToken.ID)
+	if err != nil {
+		code, errMsg := handlererrors.HandleAnalysisError(err)
+		c.JSON(code, responses.APIResponse{
+			Error: responses.GetResponse(localizer, errMsg),
+		})
+		return
+	}
+
+	StreamEvents(c, events, language)
+}
+
+func (h *AnalysisEventHandler) StreamUserEvents(c *gin.Context) {
+	localizer := translation.GetLocalizerFromContext(c)
+	language := translation.GetLanguageFromContext(c)
+
+	userToken, ok := validations.GetUserTokenFromContext(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, responses.APIResponse{
+			Error: responses.GetResponse(localizer, responses.UnauthorizedError),
+		})
+		return
+	}
+
+	events, err := h.Service.SubscribeUser(c.Request.Context(), userToken.ID)
+	if err != nil {
+		code, errMsg := handlererrors.HandleAnalysisError(err)
+		c.JSON(code, responses.APIResponse{
+			Error: responses.GetResponse(localizer, errMsg),
+		})
+		return
+	}
+
+	StreamEvents(c, events, language)
+}
+
+// EventPingInterval is how often an idle event stream gets an SSE comment,
+// so proxies do not close it while a long step emits no event.
+var EventPingInterval = 30 * time.Second
+
+// StreamEvents writes every event as an SSE message, and a ping comment
+// after each EventPingInterval without one, until the stream ends or the
+// client goes away.
+func StreamEvents(c *gin.Context, events <-chan models.AnalysisEvent,
+	language string) {
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no")
+	c.Status(http.StatusOK)
+	c.Writer.Flush()
+
+	ping := time.NewTicker(EventPingInterval)
+	defer ping.Stop()
+
+	for {
+		select {
+		case <-c.Request.Context().Done():
+			return
+		case <-ping.C:
+			_, _ = c.Writer.WriteString(": ping\n\n")
+			c.Writer.Flush()
+		case event, ok := <-events:
+			if !ok {
+				return
+			}
+			c.SSEvent("analysis", event.Localize(language))
+			c.Writer.Flush()
+			ping.Reset(EventPingInterval)
+		}
+	}
+}
@@ -176,7 +176,7 @@ func (h *AnalysisHandler) CreateAnalysis(c *gin.Context) {
 	if err != nil {
 		code, errMsg := handlererrors.HandleAnalysisError(err)
 		c.JSON(code, responses.APIResponse{
-			Error: responses.GetResponse(localizer, errMsg),
+			Error: handlererrors.AnalysisErrorMessage(localizer, err, errMsg),
 		})
 		return
 	}
@@ -221,6 +221,151 @@ func (h *AnalysisHandler) DeleteAnalysis(c *gin.Context) {
 	})
 }
 
+func (h *AnalysisHandler) ResumeAnalysis(c *gin.Context) {
+	localizer := translation.GetLocalizerFromContext(c)
+	language := translation.GetLanguageFromContext(c)
+	rawID := c.Param("analysisId")
+
+	id, err := uuid.Parse(rawID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, responses.APIResponse{
+			Error: responses.GetResponse(localizer, responses.InvalidURLID),
+		})
+		return
+	}
+
+	userToken, ok := validations.GetUserTokenFromContext(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, responses.APIResponse{
+			Error: responses.GetResponse(localizer, responses.UnauthorizedError),
+		})
+		return
+	}
+
+	analysis, err := h.Service.Resume(c.Request.Context(), id, userToken.ID,
+		language)
+	if err != nil {
+		code, errMsg := handlererrors.HandleAnalysisError(err)
+		c.JSON(code, responses.APIResponse{
+			Error: responses.GetResponse(localizer, errMsg),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, responses.APIResponse{
+		Data: analysis,
+		Message: responses.GetResponse(localizer,
+			responses.AnalysisResumeSuccess),
+	})
+}
+
+func (h *AnalysisHandler) CancelAnalysis(c *gin.Context) {
+	localizer := translation.GetLocalizerFromContext(c)
+	language := translation.GetLanguageFromContext(c)
+	rawID := c.Param("analysisId")
+
+	id, err := uuid.Parse(rawID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, responses.APIResponse{
+			Error: responses.GetResponse(localizer, responses.InvalidURLID),
+		})
+		return
+	}
+
+	userToken, ok := validations.GetUserTokenFromContext(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, responses.APIResponse{
+			Error: responses.GetResponse(localizer, responses.UnauthorizedError),
+		})
+		return
+	}
+
+	analysis, err := h.Service.Cancel(c.Request.Context(), id, userToken.ID,
+		language)
+	if err != nil {
+		code, errMsg := handlererrors.HandleAnalysisError(err)
+		c.JSON(code, responses.APIResponse{
+			Error: responses.GetResponse(localizer, errMsg),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, responses.APIResponse{
+		Data: analysis,
+		Message: responses.GetResponse(localizer,
+			responses.AnalysisCancelSuccess),
+	})
+}
+
+func (h *AnalysisHandler) GetAnalysisLogs(c *gin.Context) {
+	localizer := translation.GetLocalizerFromContext(c)
+	rawID := c.Param("analysisId")
+
+	id, err := uuid.Parse(rawID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, responses.APIResponse{
+			Error: responses.GetResponse(localizer, responses.InvalidURLID),
+		})
+		return
+	}
+
+	userToken, ok := validations.GetUserTokenFromContext(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, responses.APIResponse{
+			Error: responses.GetResponse(localizer, responses.UnauthorizedError),
+		})
+		return
+	}
+
+	step := models.AnalysisStep(c.Param("step"))
+	logs, err := h.Service.FindLogs(c.Request.Context(), id, userToken.ID,
+		step, false)
+	if err != nil {
+		code, errMsg := handlererrors.HandleAnalysisError(err)
+		c.JSON(code, responses.APIResponse{
+			Error: responses.GetResponse(localizer, errMsg),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, responses.APIResponse{Data: logs})
+}
+
+// GetAnalysisTaxonomy returns the Kraken2 species and genus composition of
+// an analysis.
+func (h *AnalysisHandler) GetAnalysisTaxonomy(c *gin.Context) {
+	localizer := translation.GetLocalizerFromContext(c)
+	rawID := c.Param("analysisId")
+
+	id, err := uuid.Parse(rawID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, responses.APIResponse{
+			Error: responses.GetResponse(localizer, responses.InvalidURLID),
+		})
+		return
+	}
+
+	userToken, ok := validations.GetUserTokenFromContext(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, responses.APIResponse{
+			Error: responses.GetResponse(localizer, responses.UnauthorizedError),
+		})
+		return
+	}
+
+	taxonomy, err := h.Service.FindTaxonomy(c.Request.Context(), id,
+		userToken.ID)
+	if err != nil {
+		code, errMsg := handlererrors.HandleAnalysisError(err)
+		c.JSON(code, responses.APIResponse{
+			Error: responses.GetResponse(localizer, errMsg),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, responses.APIResponse{Data: taxonomy})
+}
+
 func (h *AnalysisHandler) DownloadZip(c *gin.Context) {
 	localizer := translation.GetLocalizerFromContext(c)
 	rawID := c.Param("analysisId")
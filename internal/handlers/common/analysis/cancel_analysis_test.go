@@ -0,0 +1,149 @@
+package analysis_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/CABGenOrg/cabgen_backend/internal/handlers/common/analysis"
+	"github.com/CABGenOrg/cabgen_backend/internal/models"
+	"github.com/CABGenOrg/cabgen_backend/internal/services"
+	"github.com/CABGenOrg/cabgen_backend/internal/testutils"
+	"github.com/CABGenOrg/cabgen_backend/internal/testutils/mocks"
+	testmodels "github.com/CABGenOrg/cabgen_backend/internal/testutils/models"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCancelAnalysis(t *testing.T) {
+	testutils.SetupTestContext()
+
+	mockAnalysis := testmodels.CreateMockAnalysis()
+	mockAnalysis.Status = models.AnalysisStatusCancelled
+	mockResponse := mockAnalysis.ToResponse("en")
+
+	t.Run("Success", func(t *testing.T) {
+		svc := &mocks.MockAnalysisService{
+			CancelFunc: func(ctx context.Context, analysisID,
+				userID uuid.UUID, language string) (
+				*models.AnalysisResponse, error) {
+				assert.Equal(t, mockAnalysis.UserID, userID)
+				return &mockResponse, nil
+			},
+		}
+
+		handler := analysis.NewAnalysisHandler(svc)
+		c, w := testutils.SetupGinContext(
+			http.MethodPost, "/api/analysis", "", nil,
+			gin.Params{{Key: "analysisId", Value: mockAnalysis.ID.String()}},
+		)
+		c.Set("user", &models.UserToken{ID: mockAnalysis.UserID})
+		handler.CancelAnalysis(c)
+
+		expected := testutils.ToJSON(
+			map[string]any{
+				"data":    mockResponse,
+				"message": "Analysis cancelled successfully.",
+			},
+		)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.JSONEq(t, expected, w.Body.String())
+	})
+
+	t.Run("Error - Invalid ID", func(t *testing.T) {
+		svc := &mocks.MockAnalysisService{}
+
+		handler := analysis.NewAnalysisHandler(svc)
+		c, w := testutils.SetupGinContext(
+			http.MethodPost, "/api/analysis", "", nil,
+			gin.Params{{Key: "analysisId", Value: "123"}},
+		)
+		c.Set("user", &models.UserToken{ID: mockAnalysis.UserID})
+		handler.CancelAnalysis(c)
+
+		expected := testutils.ToJSON(
+			map[string]string{
+				"error": "The URL ID is invalid.",
+			},
+		)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.JSONEq(t, expected, w.Body.String())
+	})
+
+	t.Run("Error - Unauthorized", func(t *testing.T) {
+		svc := &mocks.MockAnalysisService{}
+
+		handler := analysis.NewAnalysisHandler(svc)
+		c, w := testutils.SetupGinContext(
+			http.MethodPost, "/api/analysis", "", nil,
+			gin.Params{{Key: "analysisId", Value: mockAnalysis.ID.String()}},
+		)
+		handler.CancelAnalysis(c)
+
+		expected := testutils.ToJSON(
+			map[string]string{
+				"error": "Unauthorized. Please log in to continue.",
+			},
+		)
+
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+		assert.JSONEq(t, expected, w.Body.String())
+	})
+
+	t.Run("Error - Not Cancellable", func(t *testing.T) {
+		svc := &mocks.MockAnalysisService{
+			CancelFunc: func(ctx context.Context, analysisID,
+				userID uuid.UUID, language string) (
+				*models.AnalysisResponse, error) {
+				return nil, services.ErrAnalysisNotCancellable
+			},
+		}
+
+		handler := analysis.NewAnalysisHandler(svc)
+		c, w := testutils.SetupGinContext(
+			http.MethodPost, "/api/analysis", "", nil,
+			gin.Params{{Key: "analysisId", Value: mockAnalysis.ID.String()}},
+		)
+		c.Set("user", &models.UserToken{ID: mockAnalysis.UserID})
+		handler.CancelAnalysis(c)
+
+		expected := testutils.ToJSON(
+			map[string]string{
+				"error": "Only pending or running analyses can be cancelled.",
+			},
+		)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.JSONEq(t, expected, w.Body.String())
+	})
+
+	t.Run("Error - Not Found", func(t *testing.T) {
+		svc := &mocks.MockAnalysisService{
+			CancelFunc: func(ctx context.Context, analysisID,
+				userID uuid.UUID, language string) (
+				*models.AnalysisResponse, error) {
+				return nil, services.ErrNotFound
+			},
+		}
+
+		handler := analysis.NewAnalysisHandler(svc)
+		c, w := testutils.SetupGinContext(
+			http.MethodPost, "/api/analysis", "", nil,
+			gin.Params{{Key: "analysisId", Value: mockAnalysis.ID.String()}},
+		)
+		c.Set("user", &models.UserToken{ID: mockAnalysis.UserID})
+		handler.CancelAnalysis(c)
+
+		expected := testutils.ToJSON(
+			map[string]string{
+				"error": "Analysis not found.",
+			},
+		)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		assert.JSONEq(t, expected, w.Body.String())
+	})
+}
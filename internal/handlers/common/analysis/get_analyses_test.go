@@ -67,6 +67,27 @@ func TestGetAnalyses(t *testing.T) {
 		assert.JSONEq(t, expected, w.Body.String())
 	})
 
+	t.Run("Error - Invalid QC Verdict", func(t *testing.T) {
+		svc := &mocks.MockAnalysisService{}
+
+		handler := analysis.NewAnalysisHandler(svc)
+
+		c, w := testutils.SetupGinContext(
+			http.MethodGet, "/api/analysis?qcVerdict=MAYBE", "", nil, nil,
+		)
+		c.Set("user", &models.UserToken{ID: mockAnalysis.UserID})
+		handler.GetAnalyses(c)
+
+		expected := testutils.ToJSON(
+			map[string]string{
+				"error": "Invalid query parameters.",
+			},
+		)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.JSONEq(t, expected, w.Body.String())
+	})
+
 	t.Run("Error - Internal Server", func(t *testing.T) {
 		svc := &mocks.MockAnalysisService{
 			FindAllFunc: func(ctx context.Context,
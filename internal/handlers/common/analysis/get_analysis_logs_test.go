@@ -0,0 +1,158 @@
+package analysis_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/CABGenOrg/cabgen_backend/internal/handlers/common/analysis"
+	"github.com/CABGenOrg/cabgen_backend/internal/models"
+	"github.com/CABGenOrg/cabgen_backend/internal/services"
+	"github.com/CABGenOrg/cabgen_backend/internal/testutils"
+	"github.com/CABGenOrg/cabgen_backend/internal/testutils/mocks"
+	testmodels "github.com/CABGenOrg/cabgen_backend/internal/testutils/models"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetAnalysisLogs(t *testing.T) {
+	testutils.SetupTestContext()
+
+	mockAnalysis := testmodels.CreateMockAnalysis()
+	mockLog := testmodels.NewAnalysisLog(mockAnalysis.ID, models.StepCheckM,
+		time.Now().UTC())
+	mockResponse := []models.AnalysisLogResponse{mockLog.ToResponse()}
+
+	t.Run("Success", func(t *testing.T) {
+		svc := &mocks.MockAnalysisService{
+			FindLogsFunc: func(ctx context.Context, analysisID,
+				userID uuid.UUID, step models.AnalysisStep,
+				withOutput bool) ([]models.AnalysisLogResponse, error) {
+				assert.Equal(t, mockAnalysis.UserID, userID)
+				assert.Equal(t, models.StepCheckM, step)
+				assert.False(t, withOutput)
+				return mockResponse, nil
+			},
+		}
+
+		handler := analysis.NewAnalysisHandler(svc)
+		c, w := testutils.SetupGinContext(
+			http.MethodGet, "/api/analysis", "", nil,
+			gin.Params{
+				{Key: "analysisId", Value: mockAnalysis.ID.String()},
+				{Key: "step", Value: string(models.StepCheckM)},
+			},
+		)
+		c.Set("user", &models.UserToken{ID: mockAnalysis.UserID})
+		handler.GetAnalysisLogs(c)
+
+		expected := testutils.ToJSON(
+			map[string]any{
+				"data": mockResponse,
+			},
+		)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.JSONEq(t, expected, w.Body.String())
+	})
+
+	t.Run("Error - Invalid ID", func(t *testing.T) {
+		svc := &mocks.MockAnalysisService{}
+
+		handler := analysis.NewAnalysisHandler(svc)
+		c, w := testutils.SetupGinContext(
+			http.MethodGet, "/api/analysis", "", nil,
+			gin.Params{{Key: "analysisId", Value: "123"}},
+		)
+		c.Set("user", &models.UserToken{ID: mockAnalysis.UserID})
+		handler.GetAnalysisLogs(c)
+
+		expected := testutils.ToJSON(
+			map[string]string{
+				"error": "The URL ID is invalid.",
+			},
+		)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.JSONEq(t, expected, w.Body.String())
+	})
+
+	t.Run("Error - Unauthorized", func(t *testing.T) {
+		svc := &mocks.MockAnalysisService{}
+
+		handler := analysis.NewAnalysisHandler(svc)
+		c, w := testutils.SetupGinContext(
+			http.MethodGet, "/api/analysis", "", nil,
+			gin.Params{{Key: "analysisId", Value: mockAnalysis.ID.String()}},
+		)
+		handler.GetAnalysisLogs(c)
+
+		expected := testutils.ToJSON(
+			map[string]string{
+				"error": "Unauthorized. Please log in to continue.",
+			},
+		)
+
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+		assert.JSONEq(t, expected, w.Body.String())
+	})
+
+	t.Run("Error - Invalid Step", func(t *testing.T) {
+		svc := &mocks.MockAnalysisService{
+			FindLogsFunc: func(ctx context.Context, analysisID,
+				userID uuid.UUID, step models.AnalysisStep,
+				withOutput bool) ([]models.AnalysisLogResponse, error) {
+				return nil, services.ErrInvalidAnalysisStep
+			},
+		}
+
+		handler := analysis.NewAnalysisHandler(svc)
+		c, w := testutils.SetupGinContext(
+			http.MethodGet, "/api/analysis", "", nil,
+			gin.Params{
+				{Key: "analysisId", Value: mockAnalysis.ID.String()},
+				{Key: "step", Value: "Unknown"},
+			},
+		)
+		c.Set("user", &models.UserToken{ID: mockAnalysis.UserID})
+		handler.GetAnalysisLogs(c)
+
+		expected := testutils.ToJSON(
+			map[string]string{
+				"error": "This analysis step is invalid.",
+			},
+		)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.JSONEq(t, expected, w.Body.String())
+	})
+
+	t.Run("Error - Not Found", func(t *testing.T) {
+		svc := &mocks.MockAnalysisService{
+			FindLogsFunc: func(ctx context.Context, analysisID,
+				userID uuid.UUID, step models.AnalysisStep,
+				withOutput bool) ([]models.AnalysisLogResponse, error) {
+				return nil, services.ErrNotFound
+			},
+		}
+
+		handler := analysis.NewAnalysisHandler(svc)
+		c, w := testutils.SetupGinContext(
+			http.MethodGet, "/api/analysis", "", nil,
+			gin.Params{{Key: "analysisId", Value: mockAnalysis.ID.String()}},
+		)
+		c.Set("user", &models.UserToken{ID: mockAnalysis.UserID})
+		handler.GetAnalysisLogs(c)
+
+		expected := testutils.ToJSON(
+			map[string]string{
+				"error": "Analysis not found.",
+			},
+		)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		assert.JSONEq(t, expected, w.Body.String())
+	})
+}
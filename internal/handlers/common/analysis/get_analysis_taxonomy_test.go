@@ -0,0 +1,155 @@
+package analysis_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/CABGenOrg/cabgen_backend/internal/handlers/common/analysis"
+	"github.com/CABGenOrg/cabgen_backend/internal/models"
+	"github.com/CABGenOrg/cabgen_backend/internal/pipeline"
+	"github.com/CABGenOrg/cabgen_backend/internal/services"
+	"github.com/CABGenOrg/cabgen_backend/internal/testutils"
+	"github.com/CABGenOrg/cabgen_backend/internal/testutils/mocks"
+	testmodels "github.com/CABGenOrg/cabgen_backend/internal/testutils/models"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetAnalysisTaxonomy(t *testing.T) {
+	testutils.SetupTestContext()
+
+	mockAnalysis := testmodels.CreateMockAnalysis()
+	mockTaxonomy := &pipeline.KrakenComposition{
+		Species: []pipeline.KrakenTaxon{
+			{Name: "Escherichia coli", TaxID: 562, CladeReads: 950,
+				Percent: 95},
+		},
+		Genus: []pipeline.KrakenTaxon{
+			{Name: "Escherichia", TaxID: 561, CladeReads: 960, Percent: 96},
+		},
+		Unclassified: 4,
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		svc := &mocks.MockAnalysisService{
+			FindTaxonomyFunc: func(ctx context.Context, analysisID,
+				userID uuid.UUID) (*pipeline.KrakenComposition, error) {
+				assert.Equal(t, mockAnalysis.ID, analysisID)
+				assert.Equal(t, mockAnalysis.UserID, userID)
+				return mockTaxonomy, nil
+			},
+		}
+
+		handler := analysis.NewAnalysisHandler(svc)
+		c, w := testutils.SetupGinContext(
+			http.MethodGet, "/api/analysis", "", nil,
+			gin.Params{{Key: "analysisId", Value: mockAnalysis.ID.String()}},
+		)
+		c.Set("user", &models.UserToken{ID: mockAnalysis.UserID})
+		handler.GetAnalysisTaxonomy(c)
+
+		expected := testutils.ToJSON(
+			map[string]any{
+				"data": mockTaxonomy,
+			},
+		)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.JSONEq(t, expected, w.Body.String())
+	})
+
+	t.Run("Error - Invalid ID", func(t *testing.T) {
+		svc := &mocks.MockAnalysisService{}
+
+		handler := analysis.NewAnalysisHandler(svc)
+		c, w := testutils.SetupGinContext(
+			http.MethodGet, "/api/analysis", "", nil,
+			gin.Params{{Key: "analysisId", Value: "123"}},
+		)
+		c.Set("user", &models.UserToken{ID: mockAnalysis.UserID})
+		handler.GetAnalysisTaxonomy(c)
+
+		expected := testutils.ToJSON(
+			map[string]string{
+				"error": "The URL ID is invalid.",
+			},
+		)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.JSONEq(t, expected, w.Body.String())
+	})
+
+	t.Run("Error - Unauthorized", func(t *testing.T) {
+		svc := &mocks.MockAnalysisService{}
+
+		handler := analysis.NewAnalysisHandler(svc)
+		c, w := testutils.SetupGinContext(
+			http.MethodGet, "/api/analysis", "", nil,
+			gin.Params{{Key: "analysisId", Value: mockAnalysis.ID.String()}},
+		)
+		handler.GetAnalysisTaxonomy(c)
+
+		expected := testutils.ToJSON(
+			map[string]string{
+				"error": "Unauthorized. Please log in to continue.",
+			},
+		)
+
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+		assert.JSONEq(t, expected, w.Body.String())
+	})
+
+	t.Run("Error - Taxonomy Not Found", func(t *testing.T) {
+		svc := &mocks.MockAnalysisService{
+			FindTaxonomyFunc: func(ctx context.Context, analysisID,
+				userID uuid.UUID) (*pipeline.KrakenComposition, error) {
+				return nil, services.ErrTaxonomyNotFound
+			},
+		}
+
+		handler := analysis.NewAnalysisHandler(svc)
+		c, w := testutils.SetupGinContext(
+			http.MethodGet, "/api/analysis", "", nil,
+			gin.Params{{Key: "analysisId", Value: mockAnalysis.ID.String()}},
+		)
+		c.Set("user", &models.UserToken{ID: mockAnalysis.UserID})
+		handler.GetAnalysisTaxonomy(c)
+
+		expected := testutils.ToJSON(
+			map[string]string{
+				"error": "The Kraken2 composition is not available for this analysis.",
+			},
+		)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		assert.JSONEq(t, expected, w.Body.String())
+	})
+
+	t.Run("Error - Not Found", func(t *testing.T) {
+		svc := &mocks.MockAnalysisService{
+			FindTaxonomyFunc: func(ctx context.Context, analysisID,
+				userID uuid.UUID) (*pipeline.KrakenComposition, error) {
+				return nil, services.ErrNotFound
+			},
+		}
+
+		handler := analysis.NewAnalysisHandler(svc)
+		c, w := testutils.SetupGinContext(
+			http.MethodGet, "/api/analysis", "", nil,
+			gin.Params{{Key: "analysisId", Value: mockAnalysis.ID.String()}},
+		)
+		c.Set("user", &models.UserToken{ID: mockAnalysis.UserID})
+		handler.GetAnalysisTaxonomy(c)
+
+		expected := testutils.ToJSON(
+			map[string]string{
+				"error": "Analysis not found.",
+			},
+		)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		assert.JSONEq(t, expected, w.Body.String())
+	})
+}
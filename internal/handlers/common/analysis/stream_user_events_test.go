@@ -0,0 +1,84 @@
+package analysis_test
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/CABGenOrg/cabgen_backend/internal/handlers/common/analysis"
+	"github.com/CABGenOrg/cabgen_backend/internal/models"
+	"github.com/CABGenOrg/cabgen_backend/internal/services"
+	"github.com/CABGenOrg/cabgen_backend/internal/testutils"
+	"github.com/CABGenOrg/cabgen_backend/internal/testutils/mocks"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStreamUserEvents(t *testing.T) {
+	testutils.SetupTestContext()
+
+	userID := uuid.New()
+
+	t.Run("Success", func(t *testing.T) {
+		svc := &mocks.MockAnalysisEventService{
+			SubscribeUserFunc: func(ctx context.Context, id uuid.UUID) (
+				<-chan models.AnalysisEvent, error) {
+				assert.Equal(t, userID, id)
+				return newEventStream(
+					models.AnalysisEvent{AnalysisID: uuid.New(),
+						Status: models.AnalysisStatusRunning},
+					models.AnalysisEvent{AnalysisID: uuid.New(),
+						Status: models.AnalysisStatusDone},
+				), nil
+			},
+		}
+
+		handler := analysis.NewAnalysisEventHandler(svc)
+		c, w := testutils.SetupGinContext(
+			http.MethodGet, "/api/analysis/events", "", nil, nil,
+		)
+		c.Set("user", &models.UserToken{ID: userID})
+		handler.StreamUserEvents(c)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, 2, strings.Count(w.Body.String(), "event:analysis"))
+		assert.Contains(t, w.Body.String(), `"status":"DONE"`)
+	})
+
+	t.Run("Error - Unauthorized", func(t *testing.T) {
+		handler := analysis.NewAnalysisEventHandler(
+			&mocks.MockAnalysisEventService{})
+		c, w := testutils.SetupGinContext(
+			http.MethodGet, "/api/analysis/events", "", nil, nil,
+		)
+		handler.StreamUserEvents(c)
+
+		expected := testutils.ToJSON(
+			map[string]string{
+				"error": "Unauthorized. Please log in to continue.",
+			},
+		)
+
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+		assert.JSONEq(t, expected, w.Body.String())
+	})
+
+	t.Run("Error - Internal", func(t *testing.T) {
+		svc := &mocks.MockAnalysisEventService{
+			SubscribeUserFunc: func(ctx context.Context, id uuid.UUID) (
+				<-chan models.AnalysisEvent, error) {
+				return nil, services.ErrInternal
+			},
+		}
+
+		handler := analysis.NewAnalysisEventHandler(svc)
+		c, w := testutils.SetupGinContext(
+			http.MethodGet, "/api/analysis/events", "", nil, nil,
+		)
+		c.Set("user", &models.UserToken{ID: userID})
+		handler.StreamUserEvents(c)
+
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+	})
+}
@@ -47,6 +47,12 @@ func TestAdminUploadFiles(t *testing.T) {
 			},
 			AttachFilesFunc: func(ctx context.Context, sampleID,
 				userID uuid.UUID, input models.SampleAttachmentInput) error {
+				if assert.NotNil(t, input.Fastq1) {
+					content, err := os.ReadFile(filepath.Join(dir,
+						*input.Fastq1))
+					assert.NoError(t, err)
+					assert.Equal(t, "dummy", string(content))
+				}
 				return nil
 			},
 		}
@@ -63,11 +69,10 @@ func TestAdminUploadFiles(t *testing.T) {
 		c.Set("user", &mockAdminUserToken)
 		handler.UploadFiles(c)
 
-		expectedFilePath := filepath.Join(dir, "reads_R1.fastq.gz")
-		fileContent, err := os.ReadFile(expectedFilePath)
-
+		// The staged upload is removed once AttachFiles returns.
+		entries, err := os.ReadDir(dir)
 		assert.NoError(t, err)
-		assert.Equal(t, "dummy", string(fileContent))
+		assert.Empty(t, entries)
 
 		expected := testutils.ToJSON(map[string]string{
 			"message": "Sample files submitted successfully.",
@@ -99,7 +104,12 @@ func TestAdminUploadFiles(t *testing.T) {
 				input models.SampleAttachmentInput) error {
 				assert.Equal(t, uuid.Nil, userID,
 					"admin scope passes uuid.Nil")
-				assert.NotNil(t, input.Fasta)
+				if assert.NotNil(t, input.Fasta) {
+					content, err := os.ReadFile(filepath.Join(dir,
+						*input.Fasta))
+					assert.NoError(t, err)
+					assert.Equal(t, "dummy", string(content))
+				}
 				return nil
 			},
 		}
@@ -116,11 +126,10 @@ func TestAdminUploadFiles(t *testing.T) {
 		c.Set("user", &mockAdminUserToken)
 		handler.UploadFiles(c)
 
-		expectedFilePath := filepath.Join(dir, "contigs.fasta")
-		fileContent, err := os.ReadFile(expectedFilePath)
-
+		// The staged upload is removed once AttachFiles returns.
+		entries, err := os.ReadDir(dir)
 		assert.NoError(t, err)
-		assert.Equal(t, "dummy", string(fileContent))
+		assert.Empty(t, entries)
 
 		expected := testutils.ToJSON(map[string]string{
 			"message": "Sample files submitted successfully.",
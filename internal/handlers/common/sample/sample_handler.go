@@ -225,6 +225,17 @@ func (h *SampleHandler) UploadFiles(c *gin.Context) {
 		return
 	}
 
+	// Uploads are staged next to the sample files so that AttachFiles can
+	// validate them before they replace anything the sample points to.
+	stagingDir, err := os.MkdirTemp(uploadDir, ".upload-")
+	if err != nil {
+		c.JSON(http.StatusInternalServerError,
+			responses.APIResponse{Error: responses.GetResponse(localizer,
+				responses.GenericInternalServerError)})
+		return
+	}
+	defer os.RemoveAll(stagingDir)
+
 	var attachmentInput models.SampleAttachmentInput
 
 	for {
@@ -249,8 +260,34 @@ func (h *SampleHandler) UploadFiles(c *gin.Context) {
 			continue
 		}
 
-		// Save to the disk
-		dstPath := filepath.Join(uploadDir, fileName)
+		var target **string
+		switch formName {
+		case "fastq1":
+			target = &attachmentInput.Fastq1
+		case "fastq2":
+			target = &attachmentInput.Fastq2
+		case "fasta":
+			target = &attachmentInput.Fasta
+		case "long_reads":
+			target = &attachmentInput.LongReads
+		default:
+			continue
+		}
+
+		// Each slot gets its own folder so that parts sharing a file name
+		// do not overwrite each other.
+		stagedName := filepath.Join(filepath.Base(stagingDir), formName,
+			fileName)
+		dstPath := filepath.Join(uploadDir, stagedName)
+
+		if err := os.MkdirAll(filepath.Dir(dstPath), 0755); err != nil {
+			c.JSON(http.StatusInternalServerError, responses.APIResponse{
+				Error: responses.GetResponse(
+					localizer, responses.GenericInternalServerError,
+				),
+			})
+			return
+		}
 
 		out, err := os.Create(dstPath)
 		if err != nil {
@@ -274,14 +311,7 @@ func (h *SampleHandler) UploadFiles(c *gin.Context) {
 			return
 		}
 
-		switch formName {
-		case "fastq1":
-			attachmentInput.Fastq1 = &fileName
-		case "fastq2":
-			attachmentInput.Fastq2 = &fileName
-		case "fasta":
-			attachmentInput.Fasta = &fileName
-		}
+		*target = &stagedName
 	}
 
 	if err := h.Service.AttachFiles(c.Request.Context(),
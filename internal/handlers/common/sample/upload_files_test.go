@@ -3,6 +3,7 @@ package sample_test
 import (
 	"bytes"
 	"context"
+	"fmt"
 	"io"
 	"mime/multipart"
 	"net/http"
@@ -12,6 +13,7 @@ import (
 
 	"github.com/CABGenOrg/cabgen_backend/internal/handlers/common/sample"
 	"github.com/CABGenOrg/cabgen_backend/internal/models"
+	"github.com/CABGenOrg/cabgen_backend/internal/pipeline"
 	"github.com/CABGenOrg/cabgen_backend/internal/services"
 	"github.com/CABGenOrg/cabgen_backend/internal/testutils"
 	"github.com/CABGenOrg/cabgen_backend/internal/testutils/mocks"
@@ -56,6 +58,14 @@ func TestUploadFiles(t *testing.T) {
 			AttachFilesFunc: func(ctx context.Context, sampleID,
 				userID uuid.UUID, input models.SampleAttachmentInput) error {
 				assert.Equal(t, mockUserID, userID)
+				if assert.NotNil(t, input.Fastq1) {
+					content, err := os.ReadFile(filepath.Join(dir,
+						*input.Fastq1))
+					assert.NoError(t, err)
+					assert.Equal(t, "dummy", string(content))
+					assert.NotEqual(t, "reads_R1.fastq.gz",
+						*input.Fastq1, "uploads are staged first")
+				}
 				return nil
 			},
 		}
@@ -72,11 +82,10 @@ func TestUploadFiles(t *testing.T) {
 		c.Set("user", &models.UserToken{ID: mockUserID})
 		handler.UploadFiles(c)
 
-		expectedFilePath := filepath.Join(dir, "reads_R1.fastq.gz")
-		fileContent, err := os.ReadFile(expectedFilePath)
-
+		// The staged upload is removed once AttachFiles returns.
+		entries, err := os.ReadDir(dir)
 		assert.NoError(t, err)
-		assert.Equal(t, "dummy", string(fileContent))
+		assert.Empty(t, entries)
 
 		expected := testutils.ToJSON(map[string]string{
 			"message": "Sample files submitted successfully.",
@@ -109,7 +118,12 @@ func TestUploadFiles(t *testing.T) {
 					input models.SampleAttachmentInput) error {
 					assert.Equal(t, mockUserID, userID,
 						"should pass collaborator's ID for auth")
-					assert.NotNil(t, input.Fasta)
+					if assert.NotNil(t, input.Fasta) {
+						content, err := os.ReadFile(filepath.Join(dir,
+							*input.Fasta))
+						assert.NoError(t, err)
+						assert.Equal(t, "dummy", string(content))
+					}
 					return nil
 				},
 			}
@@ -126,11 +140,10 @@ func TestUploadFiles(t *testing.T) {
 			c.Set("user", &models.UserToken{ID: mockUserID})
 			handler.UploadFiles(c)
 
-			expectedFilePath := filepath.Join(dir, "contigs.fasta")
-			fileContent, err := os.ReadFile(expectedFilePath)
-
+			// The staged upload is removed once AttachFiles returns.
+			entries, err := os.ReadDir(dir)
 			assert.NoError(t, err)
-			assert.Equal(t, "dummy", string(fileContent))
+			assert.Empty(t, entries)
 
 			expected := testutils.ToJSON(map[string]string{
 				"message": "Sample files submitted successfully.",
@@ -455,6 +468,51 @@ func TestUploadFiles(t *testing.T) {
 		assert.Equal(t, expected, w.Body.String())
 	})
 
+	t.Run("Error - AttachFiles Unpaired Reads", func(t *testing.T) {
+		dir := t.TempDir()
+		buf, mw := createFormFile("fastq1", "reads_R1.fastq.gz")
+
+		svc := &mocks.MockSampleService{
+			GetSampleForUploadFunc: func(_ context.Context,
+				_ uuid.UUID) (*models.Sample, error) {
+				sample := testmodels.CreateMockSample()
+				sample.UserID = mockOwnerID
+				return &sample, nil
+			},
+			PrepareSampleFolderFunc: func(userID,
+				sampleID uuid.UUID) (string, error) {
+				return dir, nil
+			},
+			AttachFilesFunc: func(ctx context.Context, sampleID,
+				userID uuid.UUID, input models.SampleAttachmentInput) error {
+				return fmt.Errorf("%w: read 1 is a in r1 and b in r2",
+					pipeline.ErrUnpairedReads)
+			},
+		}
+		handler := sample.NewSampleHandler(svc)
+
+		c, w := testutils.SetupGinMultipartContext(
+			http.MethodPut,
+			"/api/sample",
+			buf,
+			mw.FormDataContentType(),
+			nil,
+			gin.Params{{Key: "sampleId", Value: uuid.NewString()}},
+		)
+		c.Set("user", &models.UserToken{ID: mockUserID})
+		handler.UploadFiles(c)
+
+		expected := testutils.ToJSON(
+			map[string]string{
+				"error": "The Fastq1 and Fastq2 files do not hold the " +
+					"same reads in the same order.",
+			},
+		)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Equal(t, expected, w.Body.String())
+	})
+
 	t.Run("Error - PrepareSampleFolder Internal Error", func(t *testing.T) {
 		dir := t.TempDir()
 		buf, mw := createFormFile("fastq1", "reads_R1.fastq.gz")
@@ -4,10 +4,12 @@ import (
 	"errors"
 	"html/template"
 	"net/http"
+	"strings"
 
 	"github.com/CABGenOrg/cabgen_backend/internal/responses"
 	"github.com/CABGenOrg/cabgen_backend/internal/services"
 	"github.com/gin-gonic/gin"
+	"github.com/nicksnyder/go-i18n/v2/i18n"
 )
 
 func HandleAnalysisError(err error) (int, string) {
@@ -20,6 +22,8 @@ func HandleAnalysisError(err error) (int, string) {
 		return http.StatusBadRequest, responses.AnalysisFastQCDownloadError
 	case errors.Is(err, services.ErrZipNotFound):
 		return http.StatusNotFound, responses.AnalysisZipNotFound
+	case errors.Is(err, services.ErrTaxonomyNotFound):
+		return http.StatusNotFound, responses.AnalysisTaxonomyNotFound
 	case errors.Is(err, services.ErrUnauthorized):
 		return http.StatusUnauthorized, responses.UnauthorizedError
 	case errors.Is(err, services.ErrSampleNotFound):
@@ -32,16 +36,50 @@ func HandleAnalysisError(err error) (int, string) {
 		return http.StatusBadRequest, responses.SampleMissingFastq1
 	case errors.Is(err, services.ErrMissingFastq2):
 		return http.StatusBadRequest, responses.SampleMissingFastq2
+	case errors.Is(err, services.ErrMissingLongReads):
+		return http.StatusBadRequest, responses.SampleMissingLongReads
 	case errors.Is(err, services.ErrDeleteRunningAnalysis):
 		return http.StatusBadRequest, responses.AnalysisDeleteRunningError
 	case errors.Is(err, services.ErrInvalidStatusTransition):
 		return http.StatusBadRequest, responses.AnalysisInvalidStatus
+	case errors.Is(err, services.ErrAnalysisNotResumable):
+		return http.StatusBadRequest, responses.AnalysisNotResumableError
+	case errors.Is(err, services.ErrAnalysisNotCancellable):
+		return http.StatusBadRequest, responses.AnalysisNotCancellableError
+	case errors.Is(err, services.ErrInvalidAnalysisStep):
+		return http.StatusBadRequest, responses.AnalysisInvalidStep
+	case errors.Is(err, services.ErrInvalidAnalysisParameters):
+		return http.StatusBadRequest, responses.AnalysisInvalidParameters
 	default:
 		return http.StatusInternalServerError,
 			responses.GenericInternalServerError
 	}
 }
 
+// AnalysisErrorMessage localizes errMsg and, when the sample files cannot
+// feed the requested analysis, tells which analyses they can.
+func AnalysisErrorMessage(localizer *i18n.Localizer, err error,
+	errMsg string) string {
+	msg := responses.GetResponse(localizer, errMsg)
+
+	var inputErr *services.AnalysisInputError
+	if !errors.As(err, &inputErr) {
+		return msg
+	}
+	if len(inputErr.Available) == 0 {
+		return msg + " " + responses.GetResponse(localizer,
+			responses.AnalysisNoAvailableTypes)
+	}
+
+	types := make([]string, len(inputErr.Available))
+	for i, analysisType := range inputErr.Available {
+		types[i] = string(analysisType)
+	}
+	return msg + " " + responses.GetResponseWithData(localizer,
+		responses.AnalysisAvailableTypes,
+		map[string]any{"Types": strings.Join(types, ", ")})
+}
+
 var errorPageTemplate = template.Must(template.New(
 	"error").Parse(`<!DOCTYPE html>
 <html lang="pt-br">
@@ -8,9 +8,13 @@ import (
 	"testing"
 
 	"github.com/CABGenOrg/cabgen_backend/internal/handlers/handlererrors"
+	"github.com/CABGenOrg/cabgen_backend/internal/models"
+	"github.com/CABGenOrg/cabgen_backend/internal/responses"
 	"github.com/CABGenOrg/cabgen_backend/internal/services"
 	"github.com/CABGenOrg/cabgen_backend/internal/testutils"
+	"github.com/CABGenOrg/cabgen_backend/internal/translation"
 	"github.com/gin-gonic/gin"
+	"github.com/nicksnyder/go-i18n/v2/i18n"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -33,6 +37,9 @@ func TestHandleAnalysisError(t *testing.T) {
 		{"MissingFastq1", services.ErrMissingFastq1, http.StatusBadRequest},
 		{"MissingFastq2", services.ErrMissingFastq2, http.StatusBadRequest},
 		{"DeleteRunningAnalysis", services.ErrDeleteRunningAnalysis, http.StatusBadRequest},
+		{"NotResumable", services.ErrAnalysisNotResumable, http.StatusBadRequest},
+		{"InvalidStep", services.ErrInvalidAnalysisStep, http.StatusBadRequest},
+		{"InvalidParameters", services.ErrInvalidAnalysisParameters, http.StatusBadRequest},
 		{"Default", errors.New("unknown"), http.StatusInternalServerError},
 	}
 
@@ -58,3 +65,39 @@ func TestRespondHTMLError(t *testing.T) {
 	assert.Contains(t, w.Header().Get("Content-Type"), "text/html")
 	assert.True(t, strings.Contains(w.Body.String(), "Not found"))
 }
+
+func TestAnalysisErrorMessage(t *testing.T) {
+	testutils.SetupTestContext()
+	localizer := i18n.NewLocalizer(translation.Bundle, "en")
+
+	t.Run("Available types", func(t *testing.T) {
+		err := &services.AnalysisInputError{
+			Err: services.ErrMissingLongReads,
+			Available: []models.AnalysisType{models.AnalysisTypeFastQC,
+				models.AnalysisTypeGenome},
+		}
+
+		msg := handlererrors.AnalysisErrorMessage(localizer, err,
+			responses.SampleMissingLongReads)
+
+		assert.Equal(t, "The long reads file was not sent. Analyses "+
+			"available for the files of this sample: FASTQC, GENOME.", msg)
+	})
+
+	t.Run("No available types", func(t *testing.T) {
+		err := &services.AnalysisInputError{Err: services.ErrMissingFiles}
+
+		msg := handlererrors.AnalysisErrorMessage(localizer, err,
+			responses.SampleMissingFiles)
+
+		assert.Equal(t, "No files were sent for upload. No analysis is "+
+			"available for the files of this sample yet.", msg)
+	})
+
+	t.Run("Other errors", func(t *testing.T) {
+		msg := handlererrors.AnalysisErrorMessage(localizer,
+			services.ErrNotFound, responses.AnalysisNotFoundError)
+
+		assert.Equal(t, "Analysis not found.", msg)
+	})
+}
@@ -0,0 +1,20 @@
+package handlererrors
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/CABGenOrg/cabgen_backend/internal/responses"
+	"github.com/CABGenOrg/cabgen_backend/internal/services"
+)
+
+func HandleQCRuleError(err error) (int, string) {
+	switch {
+	case errors.Is(err, services.ErrNotFound):
+		return http.StatusNotFound, responses.QCRuleNotFoundError
+	case errors.Is(err, services.ErrInvalidQCRule):
+		return http.StatusBadRequest, responses.QCRuleInvalidError
+	default:
+		return http.StatusInternalServerError, responses.GenericInternalServerError
+	}
+}
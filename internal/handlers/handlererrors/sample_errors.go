@@ -4,6 +4,7 @@ import (
 	"errors"
 	"net/http"
 
+	"github.com/CABGenOrg/cabgen_backend/internal/pipeline"
 	"github.com/CABGenOrg/cabgen_backend/internal/responses"
 	"github.com/CABGenOrg/cabgen_backend/internal/services"
 )
@@ -34,8 +35,20 @@ func HandleSampleError(err error) (int, string) {
 		return http.StatusBadRequest, responses.SampleMissingFastq1
 	case errors.Is(err, services.ErrMissingFastq2):
 		return http.StatusBadRequest, responses.SampleMissingFastq2
+	case errors.Is(err, services.ErrMissingLongReads):
+		return http.StatusBadRequest, responses.SampleMissingLongReads
 	case errors.Is(err, services.ErrMissingFiles):
 		return http.StatusBadRequest, responses.SampleMissingFiles
+	case errors.Is(err, pipeline.ErrInvalidFormat):
+		return http.StatusBadRequest, responses.SampleUploadInvalidFormat
+	case errors.Is(err, pipeline.ErrCorruptedInput):
+		return http.StatusBadRequest, responses.SampleUploadCorruptedFile
+	case errors.Is(err, pipeline.ErrEmptyReads):
+		return http.StatusBadRequest, responses.SampleUploadEmptyFile
+	case errors.Is(err, pipeline.ErrUnpairedReads):
+		return http.StatusBadRequest, responses.SampleUploadUnpairedReads
+	case errors.Is(err, pipeline.ErrFileNotFound):
+		return http.StatusBadRequest, responses.SampleUploadFileNotFound
 	default:
 		return http.StatusInternalServerError,
 			responses.GenericInternalServerError
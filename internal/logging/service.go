@@ -25,7 +25,9 @@ const (
 	CreateFolderError               = "CREATE_FOLDER_ERROR"
 	DeleteFolderError               = "DELETE_FOLDER_ERROR"
 	DeleteFileError                 = "DELETE_FILE_ERROR"
+	MoveFileError                   = "MOVE_FILE_ERROR"
 	MissingFileError                = "MISSING_FILE_ERROR"
+	InvalidFileError                = "INVALID_FILE_ERROR"
 	ExceededDownloadLimitError      = "EXCEEDED_DOWNLOAD_LIMIT"
 	AsynqTaskError                  = "ASYNQ_TASK_ERROR"
 	RedisDispatchError              = "REDIS_DISPATCH_ERROR"
@@ -34,11 +36,26 @@ const (
 	DeletePasswordResetTokenError   = "DELETE_PASSWORD_RESET_TOKEN_ERROR"
 	DeleteEmailUpdateRequestError   = "DELETE_EMAIL_UPDATE_REQUEST_ERROR"
 	AnalysisRunError                = "ANALYSIS_RUN_ERROR"
+	CheckpointError                 = "CHECKPOINT_ERROR"
+	ToolLogError                    = "TOOL_LOG_ERROR"
+	EventSubscribeError             = "EVENT_SUBSCRIBE_ERROR"
+	InvalidParametersError          = "INVALID_PARAMETERS_ERROR"
+	SpeciesProfileError             = "SPECIES_PROFILE_ERROR"
+	QCGateError                     = "QC_GATE_ERROR"
+	WorkerRegistryError             = "WORKER_REGISTRY_ERROR"
+	HeartbeatError                  = "HEARTBEAT_ERROR"
+	TaskCancelError                 = "TASK_CANCEL_ERROR"
+	RedisLockError                  = "REDIS_LOCK_ERROR"
 )
 
 const (
 	TaskEnqueuedSuccess = "TASK_ENQUEUED_SUCCESS"
 	EmailSentSuccess    = "EMAIL_SENT_SUCCESS"
+	CheckpointRestored  = "CHECKPOINT_RESTORED"
+	SpeciesDiscordant   = "SPECIES_DISCORDANT"
+	AnalysisReaped      = "ANALYSIS_REAPED"
+	AnalysisCancelled   = "ANALYSIS_CANCELLED"
+	AnalysisNotRunning  = "ANALYSIS_NOT_RUNNING"
 )
 
 const ()
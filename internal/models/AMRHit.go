@@ -0,0 +1,250 @@
+package models
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/CABGenOrg/cabgen_backend/internal/pipeline"
+	"github.com/CABGenOrg/cabgen_backend/internal/translation"
+	"gorm.io/datatypes"
+)
+
+// amrHitKeys are the AnalysisResults fields holding AMR hits, with the
+// database their legacy display strings came from.
+var amrHitKeys = []struct{ key, database string }{
+	{pipeline.KeyAcquiredResistance, "resfinder"},
+	{pipeline.KeyPointMutations, "amrfinderplus"},
+	{pipeline.KeyVFDB, "vfdb"},
+	{pipeline.KeyPlasmidFinder, "plasmidfinder"},
+}
+
+var amrHitLabels = map[string]map[string]string{
+	"resistance": {
+		"en": "resistance to",
+		"pt": "resistência a",
+		"es": "resistencia a",
+	},
+	"confidence": {
+		"en": "allele confidence",
+		"pt": "confiança do alelo",
+		"es": "confianza del alelo",
+	},
+	"identity": {
+		"en": "IDENTITY",
+		"pt": "IDENTIDADE",
+		"es": "IDENTIDAD",
+	},
+	"coverage": {
+		"en": "COVERAGE",
+		"pt": "COBERTURA",
+		"es": "COBERTURA",
+	},
+	"database": {
+		"en": "DATABASE",
+		"pt": "BANCO",
+		"es": "BASE DE DATOS",
+	},
+}
+
+// AMRHitResponse is an AMR hit with its display string in the caller's
+// language.
+type AMRHitResponse struct {
+	pipeline.AMRHit
+	Display string `json:"display"`
+}
+
+// AnalysisResultsResponse is the shape of AnalysisResponse.Metrics: the
+// stored results with the AMR hits rendered for the caller.
+type AnalysisResultsResponse struct {
+	AnalysisResults
+	AcquiredResistance []AMRHitResponse `json:"acquired_resistance,omitempty"`
+	PointMutations     []AMRHitResponse `json:"point_mutations,omitempty"`
+	VFDB               []AMRHitResponse `json:"vfdb,omitempty"`
+	PlasmidFinder      []AMRHitResponse `json:"plasmid,omitempty"`
+}
+
+// DisplayAMRHit renders a hit the way each database has always been shown:
+// "blaOXA-23 (resistance to carbapenem) (allele confidence 100.00)" for
+// ResFinder and AMRFinderPlus, "contig_1: fimH (type 1 fimbriae)" for VFDB and
+// "IncFIB (IDENTITY: 99.50 COVERAGE: 100.00 DATABASE: plasmidfinder)" for
+// PlasmidFinder.
+func DisplayAMRHit(hit pipeline.AMRHit, language string) string {
+	lang := translation.ParseLanguage(language)
+	label := func(key string) string {
+		return amrHitLabels[key][lang]
+	}
+
+	name := hit.Allele
+	if name == "" {
+		name = hit.Gene
+	}
+
+	switch hit.Database {
+	case "vfdb":
+		display := name
+		if hit.Contig != "" {
+			display = hit.Contig + ": " + display
+		}
+		if hit.Product != "" {
+			display += " (" + hit.Product + ")"
+		}
+		return display
+	case "plasmidfinder":
+		if hit.Identity == 0 && hit.Coverage == 0 {
+			return name
+		}
+		return fmt.Sprintf("%s (%s: %.2f %s: %.2f %s: %s)", name,
+			label("identity"), hit.Identity, label("coverage"), hit.Coverage,
+			label("database"), hit.Database)
+	default:
+		display := name
+		if hit.Phenotype != "" {
+			display += fmt.Sprintf(" (%s %s)", label("resistance"),
+				hit.Phenotype)
+		}
+		if hit.Identity > 0 {
+			display += fmt.Sprintf(" (%s %.2f)", label("confidence"),
+				hit.Identity)
+		}
+		return display
+	}
+}
+
+// ToAMRHitResponses renders every hit in the given language.
+func ToAMRHitResponses(hits []pipeline.AMRHit,
+	language string) []AMRHitResponse {
+	responses := make([]AMRHitResponse, 0, len(hits))
+	for _, hit := range hits {
+		responses = append(responses, AMRHitResponse{
+			AMRHit: hit, Display: DisplayAMRHit(hit, language),
+		})
+	}
+	return responses
+}
+
+// localizeMetrics adds the display string of each AMR hit. Metrics that are
+// not in the current shape are returned as stored.
+func localizeMetrics(raw datatypes.JSON, language string) datatypes.JSON {
+	if len(raw) == 0 {
+		return raw
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &fields); err != nil {
+		return raw
+	}
+
+	changed := false
+	for _, entry := range amrHitKeys {
+		value, ok := fields[entry.key]
+		if !ok {
+			continue
+		}
+		var hits []pipeline.AMRHit
+		if err := json.Unmarshal(value, &hits); err != nil {
+			continue
+		}
+		localized, err := json.Marshal(ToAMRHitResponses(hits, language))
+		if err != nil {
+			continue
+		}
+		fields[entry.key] = localized
+		changed = true
+	}
+	if !changed {
+		return raw
+	}
+
+	localized, err := json.Marshal(fields)
+	if err != nil {
+		return raw
+	}
+	return localized
+}
+
+var (
+	legacyResfinderPattern = regexp.MustCompile(
+		`^(\S+?)(?: \(resistance to (.+?)\))?(?: \(allele confidence ([\d.]+)\))?$`)
+	legacyVFDBPattern    = regexp.MustCompile(`^(.+?): (\S+) \((.*)\)$`)
+	legacyPlasmidPattern = regexp.MustCompile(
+		`^(\S+) \(IDENTITY: ([\d.]+) COVERAGE: ([\d.]+) DATABASE: (\S+)\)$`)
+)
+
+// parseLegacyAMRHit reads a display string stored before hits were typed.
+func parseLegacyAMRHit(display, database string) pipeline.AMRHit {
+	hit := pipeline.AMRHit{
+		Allele: display, Database: database,
+		Engine: pipeline.AMREngineAbricate,
+	}
+
+	switch database {
+	case "vfdb":
+		if m := legacyVFDBPattern.FindStringSubmatch(display); m != nil {
+			hit.Contig, hit.Allele, hit.Product = m[1], m[2], m[3]
+		}
+	case "plasmidfinder":
+		if m := legacyPlasmidPattern.FindStringSubmatch(display); m != nil {
+			hit.Allele, hit.Database = m[1], m[4]
+			hit.Identity, _ = strconv.ParseFloat(m[2], 64)
+			hit.Coverage, _ = strconv.ParseFloat(m[3], 64)
+		}
+	default:
+		if m := legacyResfinderPattern.FindStringSubmatch(display); m != nil {
+			hit.Allele, hit.Phenotype = m[1], m[2]
+			hit.Identity, _ = strconv.ParseFloat(m[3], 64)
+		}
+	}
+	hit.Gene = strings.Split(hit.Allele, "_")[0]
+
+	return hit
+}
+
+// MigrateAnalysisMetrics converts metrics whose AMR hits are display strings
+// to typed hits. It reports whether anything changed.
+func MigrateAnalysisMetrics(raw datatypes.JSON) (datatypes.JSON, bool,
+	error) {
+	if len(raw) == 0 {
+		return raw, false, nil
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &fields); err != nil {
+		return nil, false, fmt.Errorf("invalid metrics: %w", err)
+	}
+
+	changed := false
+	for _, entry := range amrHitKeys {
+		value, ok := fields[entry.key]
+		if !ok {
+			continue
+		}
+		var legacy []string
+		if err := json.Unmarshal(value, &legacy); err != nil ||
+			len(legacy) == 0 {
+			continue
+		}
+
+		hits := make([]pipeline.AMRHit, 0, len(legacy))
+		for _, display := range legacy {
+			hits = append(hits, parseLegacyAMRHit(display, entry.database))
+		}
+		migrated, err := json.Marshal(hits)
+		if err != nil {
+			return nil, false, err
+		}
+		fields[entry.key] = migrated
+		changed = true
+	}
+	if !changed {
+		return raw, false, nil
+	}
+
+	migrated, err := json.Marshal(fields)
+	if err != nil {
+		return nil, false, err
+	}
+	return migrated, true, nil
+}
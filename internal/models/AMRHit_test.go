@@ -0,0 +1,116 @@
+package models_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/CABGenOrg/cabgen_backend/internal/models"
+	"github.com/CABGenOrg/cabgen_backend/internal/pipeline"
+	testmodels "github.com/CABGenOrg/cabgen_backend/internal/testutils/models"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/datatypes"
+)
+
+func TestDisplayAMRHit(t *testing.T) {
+	resfinder := pipeline.AMRHit{
+		Gene: "blaOXA-23", Allele: "blaOXA-23_1", Database: "resfinder",
+		Identity: 99.87, Phenotype: "meropenem",
+	}
+	vfdb := pipeline.AMRHit{
+		Gene: "fimH", Allele: "fimH", Database: "vfdb", Contig: "contig_3",
+		Product: "type 1 fimbriae",
+	}
+	plasmid := pipeline.AMRHit{
+		Gene: "IncFIB", Allele: "IncFIB", Database: "plasmidfinder",
+		Identity: 99.5, Coverage: 100,
+	}
+
+	assert.Equal(t,
+		"blaOXA-23_1 (resistance to meropenem) (allele confidence 99.87)",
+		models.DisplayAMRHit(resfinder, "en"))
+	assert.Equal(t,
+		"blaOXA-23_1 (resistência a meropenem) (confiança do alelo 99.87)",
+		models.DisplayAMRHit(resfinder, "pt"))
+	assert.Equal(t, "contig_3: fimH (type 1 fimbriae)",
+		models.DisplayAMRHit(vfdb, "es"))
+	assert.Equal(t,
+		"IncFIB (IDENTIDAD: 99.50 COBERTURA: 100.00 BASE DE DATOS: plasmidfinder)",
+		models.DisplayAMRHit(plasmid, "es"))
+	assert.Equal(t, "armA", models.DisplayAMRHit(pipeline.AMRHit{
+		Gene: "armA", Database: "resfinder",
+	}, "en"))
+}
+
+func TestAnalysisToResponseLocalizesAMRHits(t *testing.T) {
+	analysis := testmodels.CreateMockAnalysis()
+	analysis.Metrics = datatypes.JSON(`{"mlst":"ST2","vfdb":[{"gene":"fimH",` +
+		`"allele":"fimH","database":"vfdb","contig":"contig_3"}]}`)
+
+	result := analysis.ToResponse("pt")
+
+	var metrics models.AnalysisResultsResponse
+	assert.NoError(t, json.Unmarshal(result.Metrics, &metrics))
+	assert.Equal(t, "ST2", metrics.MLST)
+	assert.Equal(t, []models.AMRHitResponse{{
+		AMRHit: pipeline.AMRHit{
+			Gene: "fimH", Allele: "fimH", Database: "vfdb",
+			Contig: "contig_3",
+		},
+		Display: "contig_3: fimH",
+	}}, metrics.VFDB)
+}
+
+func TestMigrateAnalysisMetrics(t *testing.T) {
+	t.Run("Success - Legacy Strings", func(t *testing.T) {
+		raw := datatypes.JSON(`{
+			"mlst": "ST2",
+			"acquired_resistance": [
+				"blaOXA-23_1 (resistance to meropenem) (allele confidence 100.00)",
+				"armA (allele confidence 99.5)"
+			],
+			"vfdb": ["contig_3: fimH (type 1 fimbriae)"],
+			"plasmid": [
+				"IncFIB (IDENTITY: 99.50 COVERAGE: 100.00 DATABASE: plasmidfinder)"
+			]
+		}`)
+
+		migrated, changed, err := models.MigrateAnalysisMetrics(raw)
+
+		assert.NoError(t, err)
+		assert.True(t, changed)
+		var results models.AnalysisResults
+		assert.NoError(t, json.Unmarshal(migrated, &results))
+		assert.Equal(t, "ST2", results.MLST)
+		assert.Equal(t, []pipeline.AMRHit{
+			{Gene: "blaOXA-23", Allele: "blaOXA-23_1", Database: "resfinder",
+				Engine: pipeline.AMREngineAbricate, Identity: 100,
+				Phenotype: "meropenem"},
+			{Gene: "armA", Allele: "armA", Database: "resfinder",
+				Engine: pipeline.AMREngineAbricate, Identity: 99.5},
+		}, results.AcquiredResistance)
+		assert.Equal(t, []pipeline.AMRHit{{
+			Gene: "fimH", Allele: "fimH", Database: "vfdb",
+			Engine: pipeline.AMREngineAbricate, Contig: "contig_3",
+			Product: "type 1 fimbriae",
+		}}, results.VFDB)
+		assert.Equal(t, []pipeline.AMRHit{{
+			Gene: "IncFIB", Allele: "IncFIB", Database: "plasmidfinder",
+			Engine: pipeline.AMREngineAbricate, Identity: 99.5, Coverage: 100,
+		}}, results.PlasmidFinder)
+	})
+
+	t.Run("Success - Current Shape", func(t *testing.T) {
+		raw := datatypes.JSON(`{"vfdb":[{"gene":"fimH","database":"vfdb"}]}`)
+
+		migrated, changed, err := models.MigrateAnalysisMetrics(raw)
+
+		assert.NoError(t, err)
+		assert.False(t, changed)
+		assert.Equal(t, raw, migrated)
+	})
+
+	t.Run("Error - Invalid JSON", func(t *testing.T) {
+		_, _, err := models.MigrateAnalysisMetrics(datatypes.JSON(`[`))
+		assert.Error(t, err)
+	})
+}
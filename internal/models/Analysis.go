@@ -1,6 +1,8 @@
 package models
 
 import (
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/CABGenOrg/cabgen_backend/internal/pipeline"
@@ -67,6 +69,26 @@ var errorMessageTranslations = map[error]map[string]string{
 		"pt": "A etapa do Abricate falhou. Crie uma nova análise.",
 		"es": "El paso de Abricate falló. Cree un nuevo análisis.",
 	},
+	pipeline.ErrAMRFinder: {
+		"en": "The AMRFinderPlus step failed. Create a new analysis.",
+		"pt": "A etapa do AMRFinderPlus falhou. Crie uma nova análise.",
+		"es": "El paso de AMRFinderPlus falló. Cree un nuevo análisis.",
+	},
+	pipeline.ErrStepTimeout: {
+		"en": "A step exceeded its time limit. Create a new analysis.",
+		"pt": "Uma etapa excedeu o tempo limite. Crie uma nova análise.",
+		"es": "Un paso excedió su tiempo límite. Cree un nuevo análisis.",
+	},
+	pipeline.ErrStepOutOfMemory: {
+		"en": "A step exceeded its memory limit. Create a new analysis.",
+		"pt": "Uma etapa excedeu o limite de memória. Crie uma nova análise.",
+		"es": "Un paso excedió su límite de memoria. Cree un nuevo análisis.",
+	},
+	pipeline.ErrWorkerLost: {
+		"en": "The worker running the analysis was lost. Create a new analysis.",
+		"pt": "O worker que executava a análise foi perdido. Crie uma nova análise.",
+		"es": "Se perdió el worker que ejecutaba el análisis. Cree un nuevo análisis.",
+	},
 	pipeline.ErrPrepareFolders: {
 		"en": "Folder preparation failed. Create a new analysis.",
 		"pt": "Falha ao preparar os arquivos da análise. Crie uma nova análise.",
@@ -81,12 +103,15 @@ const (
 	AnalysisStatusRunning AnalysisStatus = "RUNNING"
 	AnalysisStatusDone    AnalysisStatus = "DONE"
 	AnalysisStatusFailed  AnalysisStatus = "FAILED"
+	// AnalysisStatusCancelled is an analysis stopped by its owner or an
+	// admin before it finished.
+	AnalysisStatusCancelled AnalysisStatus = "CANCELLED"
 )
 
 func (a AnalysisStatus) IsValid() bool {
 	switch a {
 	case AnalysisStatusPending, AnalysisStatusRunning, AnalysisStatusDone,
-		AnalysisStatusFailed:
+		AnalysisStatusFailed, AnalysisStatusCancelled:
 		return true
 	default:
 		return false
@@ -98,10 +123,11 @@ func (a AnalysisStatus) CanTransitionTo(target AnalysisStatus) bool {
 	case AnalysisStatusDone, AnalysisStatusFailed:
 		return target == AnalysisStatusPending ||
 			target == AnalysisStatusFailed
-	case AnalysisStatusRunning:
-		return target == AnalysisStatusFailed
-	case AnalysisStatusPending:
-		return target == AnalysisStatusFailed
+	case AnalysisStatusRunning, AnalysisStatusPending:
+		return target == AnalysisStatusFailed ||
+			target == AnalysisStatusCancelled
+	case AnalysisStatusCancelled:
+		return target == AnalysisStatusPending
 	default:
 		return false
 	}
@@ -110,27 +136,46 @@ func (a AnalysisStatus) CanTransitionTo(target AnalysisStatus) bool {
 type AnalysisStep string
 
 const (
-	StepFastQC    AnalysisStep = "FastQC"
-	StepUnicycler AnalysisStep = "Unicycler"
-	StepProkka    AnalysisStep = "Prokka"
-	StepCheckM    AnalysisStep = "CheckM"
-	StepKraken2   AnalysisStep = "Kraken2"
-	StepSpecies   AnalysisStep = "Species"
-	StepAbricate  AnalysisStep = "Abricate"
-	StepCoverage  AnalysisStep = "Coverage"
+	StepFastQC     AnalysisStep = "FastQC"
+	StepLongReadQC AnalysisStep = "LongReadQC"
+	StepUnicycler  AnalysisStep = "Unicycler"
+	StepStats      AnalysisStep = "AssemblyStats"
+	StepProkka     AnalysisStep = "Prokka"
+	StepCheckM     AnalysisStep = "CheckM"
+	StepKraken2    AnalysisStep = "Kraken2"
+	StepSpecies    AnalysisStep = "Species"
+	StepAbricate   AnalysisStep = "Abricate"
+	StepAMRFinder  AnalysisStep = "AMRFinderPlus"
+	StepCoverage   AnalysisStep = "Coverage"
 )
 
+func (a AnalysisStep) IsValid() bool {
+	switch a {
+	case StepFastQC, StepLongReadQC, StepUnicycler, StepStats, StepProkka,
+		StepCheckM, StepKraken2, StepSpecies, StepAbricate, StepAMRFinder,
+		StepCoverage:
+		return true
+	default:
+		return false
+	}
+}
+
 type AnalysisType string
 
 const (
 	AnalysisTypeFastQC   AnalysisType = "FASTQC"
 	AnalysisTypeGenome   AnalysisType = "GENOME"
 	AnalysisTypeComplete AnalysisType = "COMPLETE"
+	// Long-read only assembly
+	AnalysisTypeLongRead AnalysisType = "LONG_READ"
+	// Assembly of the short-read pair scaffolded with long reads
+	AnalysisTypeHybrid AnalysisType = "HYBRID"
 )
 
 func (a AnalysisType) IsValid() bool {
 	switch a {
-	case AnalysisTypeFastQC, AnalysisTypeGenome, AnalysisTypeComplete:
+	case AnalysisTypeFastQC, AnalysisTypeGenome, AnalysisTypeComplete,
+		AnalysisTypeLongRead, AnalysisTypeHybrid:
 		return true
 	default:
 		return false
@@ -138,11 +183,39 @@ func (a AnalysisType) IsValid() bool {
 }
 
 var AnalysisTypes = []AnalysisType{AnalysisTypeFastQC, AnalysisTypeGenome,
-	AnalysisTypeComplete}
+	AnalysisTypeComplete, AnalysisTypeLongRead, AnalysisTypeHybrid}
+
+// analysisParameterSchemas lists the parameter sections each analysis type
+// accepts.
+var analysisParameterSchemas = map[AnalysisType][]string{
+	AnalysisTypeFastQC: {},
+	AnalysisTypeGenome: {pipeline.ParametersUnicycler,
+		pipeline.ParametersAbricate, pipeline.ParametersBlastX,
+		pipeline.ParametersBlastN, pipeline.ParametersKraken2},
+	AnalysisTypeComplete: {pipeline.ParametersUnicycler,
+		pipeline.ParametersAbricate, pipeline.ParametersBlastX,
+		pipeline.ParametersBlastN, pipeline.ParametersKraken2},
+	AnalysisTypeLongRead: {pipeline.ParametersUnicycler,
+		pipeline.ParametersAbricate, pipeline.ParametersBlastX,
+		pipeline.ParametersBlastN, pipeline.ParametersKraken2},
+	AnalysisTypeHybrid: {pipeline.ParametersUnicycler,
+		pipeline.ParametersAbricate, pipeline.ParametersBlastX,
+		pipeline.ParametersBlastN, pipeline.ParametersKraken2},
+}
+
+// ParseParameters validates raw against the parameter schema of the type.
+func (a AnalysisType) ParseParameters(raw datatypes.JSON) (
+	pipeline.AnalysisParameters, error) {
+	return pipeline.ParseAnalysisParameters(raw, analysisParameterSchemas[a])
+}
 
 type AnalysisResults struct {
+	// --- Read Quality ---
+	ReadQC *pipeline.ReadQCReport `json:"read_qc,omitempty"`
+
 	// --- Genomic Coverage ---
-	Coverage float64 `json:"coverage,omitempty"`
+	Coverage         float64 `json:"coverage,omitempty"`
+	LongReadCoverage float64 `json:"long_read_coverage,omitempty"`
 
 	// --- Assembly Quality (CheckM) ---
 	CheckMCompleteness  string `json:"completeness,omitempty"`
@@ -150,22 +223,89 @@ type AnalysisResults struct {
 	CheckMGenomeSize    string `json:"genome_size,omitempty"`
 	CheckMN50           string `json:"n50,omitempty"`
 
+	// --- Assembly Statistics ---
+	AssemblyStats *pipeline.AssemblyStats `json:"assembly_stats,omitempty"`
+
 	// --- Taxonomy and Typing ---
 	PrimarySpeciesName   string `json:"primary_species,omitempty"`
 	SecondarySpeciesName string `json:"secondary_species,omitempty"`
 	MLST                 string `json:"mlst,omitempty"`
 
+	// --- Composition (Kraken2) ---
+	Taxonomy *pipeline.KrakenComposition `json:"taxonomy,omitempty"`
+
 	// --- Identified Mutations ---
 	PoliMutations  []string `json:"poli_mutations,omitempty"`
 	OtherMutations []string `json:"other_mutations,omitempty"`
 
-	// --- Virulence (Abricate) ---
-	AcquiredResistance []string `json:"acquired_resistance,omitempty"`
-	VFDB               []string `json:"vfdb,omitempty"`
-	PlasmidFinder      []string `json:"plasmid,omitempty"`
+	// --- Resistance and Virulence (Abricate, AMRFinderPlus) ---
+	AcquiredResistance []pipeline.AMRHit `json:"acquired_resistance,omitempty"`
+	PointMutations     []pipeline.AMRHit `json:"point_mutations,omitempty"`
+	VFDB               []pipeline.AMRHit `json:"vfdb,omitempty"`
+	PlasmidFinder      []pipeline.AMRHit `json:"plasmid,omitempty"`
 
 	// --- Versions ---
 	Versions []pipeline.ToolVersion `json:"versions,omitempty"`
+
+	// --- Reference databases used ---
+	Databases []pipeline.DatabaseFingerprint `json:"databases,omitempty"`
+
+	// --- Parameters used ---
+	Parameters *pipeline.AnalysisParameters `json:"parameters,omitempty"`
+
+	// --- QC gate ---
+	QC *pipeline.QCReport `json:"qc,omitempty"`
+
+	// --- Declared vs detected species ---
+	Concordance *pipeline.ConcordanceReport `json:"concordance,omitempty"`
+}
+
+// QCValues gathers the measures the QC rules are checked against. The
+// assembly statistics are preferred to CheckM's and the long-read depth
+// stands in for the coverage of long-read only assemblies.
+func (r *AnalysisResults) QCValues() pipeline.QCValues {
+	values := pipeline.QCValues{
+		Species:       r.PrimarySpeciesName,
+		Completeness:  parseQCValue(r.CheckMCompleteness),
+		Contamination: parseQCValue(r.CheckMContamination),
+		N50:           parseQCValue(r.CheckMN50),
+		GenomeSize:    parseQCValue(r.CheckMGenomeSize),
+	}
+
+	if r.Coverage > 0 {
+		values.Coverage = &r.Coverage
+	} else if r.LongReadCoverage > 0 {
+		values.Coverage = &r.LongReadCoverage
+	}
+
+	if stats := r.AssemblyStats; stats != nil {
+		n50 := float64(stats.N50)
+		contigs := float64(stats.Contigs)
+		values.N50, values.Contigs = &n50, &contigs
+		if values.GenomeSize == nil {
+			genomeSize := float64(stats.TotalLength)
+			values.GenomeSize = &genomeSize
+		}
+	}
+
+	// The share of the second species, 0 when Kraken2 found a single one.
+	if r.Taxonomy != nil {
+		var secondary float64
+		if species := r.Taxonomy.Secondary(); species != nil {
+			secondary = species.Percent
+		}
+		values.SecondarySpecies = &secondary
+	}
+
+	return values
+}
+
+func parseQCValue(raw string) *float64 {
+	value, err := strconv.ParseFloat(strings.TrimSpace(raw), 64)
+	if err != nil {
+		return nil
+	}
+	return &value
 }
 
 type Analysis struct {
@@ -177,6 +317,9 @@ type Analysis struct {
 	Step   AnalysisStep   `gorm:"type:varchar(20);default:''"`
 	TaskID *string        `gorm:"type:varchar(255)"`
 
+	// Parameters chosen by the user; unset ones come from the server profile
+	Parameters datatypes.JSON `gorm:"type:jsonb"`
+
 	// Paths
 	FastQC1 *string `gorm:"type:varchar(255)"`
 	FastQC2 *string `gorm:"type:varchar(255)"`
@@ -184,6 +327,15 @@ type Analysis struct {
 	// Results
 	Metrics        datatypes.JSON `gorm:"type:jsonb"`
 	ResultsZipPath *string        `gorm:"type:varchar(255)"`
+	// Copied from the QC report of the metrics so analyses can be filtered
+	QCVerdict pipeline.QCVerdict `gorm:"type:varchar(10);default:'';index"`
+	// Copied from the concordance report of the metrics, as above
+	SpeciesConcordance pipeline.SpeciesConcordance `gorm:"type:varchar(20);default:''"`
+	// Copied from the headline of the read QC of the metrics, as above
+	ReadCount       *int64   `gorm:"index"`
+	ReadMeanQuality *float64 `gorm:"index"`
+	ReadQ30Percent  *float64 `gorm:"index"`
+	ReadMeanLength  *float64
 
 	// Run Metadata
 	ErrorMessage *string `gorm:"type:text"`
@@ -202,86 +354,112 @@ type Analysis struct {
 }
 
 type AnalysisResponse struct {
-	ID             uuid.UUID      `json:"id"`
-	Type           AnalysisType   `json:"type"`
-	Status         AnalysisStatus `json:"status"`
-	Step           AnalysisStep   `json:"step"`
-	ErrorMessage   *string        `json:"error_message"`
-	Sample         string         `json:"sample"`
-	SampleID       uuid.UUID      `json:"sample_id"`
-	User           string         `json:"user"`
-	UserID         uuid.UUID      `json:"user_id"`
-	Metrics        datatypes.JSON `json:"metrics"`
-	ResultsZipPath *string        `json:"results_zip_path"`
-	FastQC1        *string        `json:"fastqc1"`
-	FastQC2        *string        `json:"fastqc2"`
-	StartedAt      *time.Time     `json:"started_at"`
-	FinishedAt     *time.Time     `json:"finished_at"`
+	ID                 uuid.UUID                   `json:"id"`
+	Type               AnalysisType                `json:"type"`
+	Status             AnalysisStatus              `json:"status"`
+	Step               AnalysisStep                `json:"step"`
+	ErrorMessage       *string                     `json:"error_message"`
+	Sample             string                      `json:"sample"`
+	SampleID           uuid.UUID                   `json:"sample_id"`
+	User               string                      `json:"user"`
+	UserID             uuid.UUID                   `json:"user_id"`
+	Parameters         datatypes.JSON              `json:"parameters"`
+	Metrics            datatypes.JSON              `json:"metrics"`
+	QCVerdict          pipeline.QCVerdict          `json:"qc_verdict"`
+	SpeciesConcordance pipeline.SpeciesConcordance `json:"species_concordance"`
+	ReadCount          *int64                      `json:"read_count"`
+	ReadMeanQuality    *float64                    `json:"read_mean_quality"`
+	ReadQ30Percent     *float64                    `json:"read_q30_percent"`
+	ReadMeanLength     *float64                    `json:"read_mean_length"`
+	ResultsZipPath     *string                     `json:"results_zip_path"`
+	FastQC1            *string                     `json:"fastqc1"`
+	FastQC2            *string                     `json:"fastqc2"`
+	StartedAt          *time.Time                  `json:"started_at"`
+	FinishedAt         *time.Time                  `json:"finished_at"`
 }
 
-func (a *Analysis) ToResponse(language string) AnalysisResponse {
-	lang := translation.ParseLanguage(language)
+func translateErrorMessage(errorMessage *string, language string) *string {
+	if errorMessage == nil {
+		return nil
+	}
 
-	var errorMsg *string
-	if a.ErrorMessage != nil {
-		msg := *a.ErrorMessage
-		for errVal, translations := range errorMessageTranslations {
-			if errVal.Error() == msg {
-				if translated, ok := translations[lang]; ok {
-					errorMsg = &translated
-				} else {
-					errorMsg = a.ErrorMessage
-				}
-				break
+	lang := translation.ParseLanguage(language)
+	msg := *errorMessage
+	for errVal, translations := range errorMessageTranslations {
+		if errVal.Error() == msg {
+			if translated, ok := translations[lang]; ok {
+				return &translated
 			}
-		}
-		if errorMsg == nil {
-			errorMsg = a.ErrorMessage
+			break
 		}
 	}
 
+	return errorMessage
+}
+
+func (a *Analysis) ToResponse(language string) AnalysisResponse {
+	errorMsg := translateErrorMessage(a.ErrorMessage, language)
+
 	return AnalysisResponse{
-		ID:             a.ID,
-		Type:           a.Type,
-		Status:         a.Status,
-		Step:           a.Step,
-		ErrorMessage:   errorMsg,
-		Sample:         a.Sample.OriginCode,
-		SampleID:       a.SampleID,
-		User:           a.User.Username,
-		UserID:         a.UserID,
-		Metrics:        a.Metrics,
-		ResultsZipPath: a.ResultsZipPath,
-		FastQC1:        a.FastQC1,
-		FastQC2:        a.FastQC2,
-		StartedAt:      a.StartedAt,
-		FinishedAt:     a.FinishedAt,
+		ID:                 a.ID,
+		Type:               a.Type,
+		Status:             a.Status,
+		Step:               a.Step,
+		ErrorMessage:       errorMsg,
+		Sample:             a.Sample.OriginCode,
+		SampleID:           a.SampleID,
+		User:               a.User.Username,
+		UserID:             a.UserID,
+		Parameters:         a.Parameters,
+		Metrics:            localizeMetrics(a.Metrics, language),
+		QCVerdict:          a.QCVerdict,
+		SpeciesConcordance: a.SpeciesConcordance,
+		ReadCount:          a.ReadCount,
+		ReadMeanQuality:    a.ReadMeanQuality,
+		ReadQ30Percent:     a.ReadQ30Percent,
+		ReadMeanLength:     a.ReadMeanLength,
+		ResultsZipPath:     a.ResultsZipPath,
+		FastQC1:            a.FastQC1,
+		FastQC2:            a.FastQC2,
+		StartedAt:          a.StartedAt,
+		FinishedAt:         a.FinishedAt,
 	}
 }
 
+// OutdatedAnalysisResponse is an analysis run against databases that were
+// updated afterwards.
+type OutdatedAnalysisResponse struct {
+	Analysis  AnalysisResponse            `json:"analysis"`
+	Databases []pipeline.OutdatedDatabase `json:"databases"`
+}
+
 type AdminAnalysisCreateInput struct {
-	Type     AnalysisType `json:"type" binding:"required"`
-	SampleID uuid.UUID    `json:"sample_id" binding:"required"`
-	UserID   uuid.UUID    `json:"user_id" binding:"required"`
+	Type       AnalysisType   `json:"type" binding:"required"`
+	SampleID   uuid.UUID      `json:"sample_id" binding:"required"`
+	UserID     uuid.UUID      `json:"user_id" binding:"required"`
+	Parameters datatypes.JSON `json:"parameters" binding:"omitempty"`
 }
 
 type AnalysisCreateInput struct {
-	Type     AnalysisType `json:"type" binding:"required"`
-	SampleID uuid.UUID    `json:"sample_id" binding:"required"`
+	Type       AnalysisType   `json:"type" binding:"required"`
+	SampleID   uuid.UUID      `json:"sample_id" binding:"required"`
+	Parameters datatypes.JSON `json:"parameters" binding:"omitempty"`
 }
 
 type AnalysisCreateDTO struct {
-	Type     AnalysisType
-	SampleID uuid.UUID
-	UserID   uuid.UUID
+	Type       AnalysisType
+	SampleID   uuid.UUID
+	UserID     uuid.UUID
+	Parameters datatypes.JSON
 }
 
 func AnalysisCreateInputToDTO(i AnalysisCreateInput,
 	userID uuid.UUID) AnalysisCreateDTO {
 	return AnalysisCreateDTO{
-		Type:     i.Type,
-		SampleID: i.SampleID,
-		UserID:   userID,
+		Type:       i.Type,
+		SampleID:   i.SampleID,
+		UserID:     userID,
+		Parameters: i.Parameters,
 	}
 }
 
@@ -299,7 +477,8 @@ type AnalysisTSVDownloadInput struct {
 }
 
 type AnalysisFilter struct {
-	OriginCode string       `form:"originCode"`
-	Type       AnalysisType `form:"type"`
-	Username   string       `form:"username"`
+	OriginCode string             `form:"originCode"`
+	Type       AnalysisType       `form:"type"`
+	Username   string             `form:"username"`
+	QCVerdict  pipeline.QCVerdict `form:"qcVerdict" binding:"omitempty,oneof=PASS WARN FAIL"`
 }
@@ -0,0 +1,54 @@
+package models
+
+import (
+	"time"
+
+	"github.com/CABGenOrg/cabgen_backend/internal/pipeline"
+	"github.com/google/uuid"
+)
+
+// AnalysisDatabase is a fingerprinted database an analysis ran against. The
+// fingerprints are also in the metrics; this copy lets the analyses run
+// against outdated databases be found with a query.
+type AnalysisDatabase struct {
+	ID uuid.UUID `gorm:"type:uuid;default:uuid_generate_v4();primaryKey"`
+
+	Name     string `gorm:"type:varchar(255);not null;index"`
+	Checksum string `gorm:"type:varchar(64);not null"`
+	Version  string `gorm:"type:varchar(255)"`
+
+	// Datetime
+	CreatedAt time.Time
+
+	// Foreign Keys
+	AnalysisID uuid.UUID `gorm:"type:uuid;not null;index"`
+	Analysis   Analysis  `gorm:"foreignKey:AnalysisID;references:ID;constraint:OnDelete:CASCADE"`
+}
+
+// NewAnalysisDatabases keeps the fingerprints that have a checksum; the
+// others cannot be compared.
+func NewAnalysisDatabases(analysisID uuid.UUID,
+	fingerprints []pipeline.DatabaseFingerprint) []AnalysisDatabase {
+	var databases []AnalysisDatabase
+	for _, fingerprint := range fingerprints {
+		if fingerprint.Checksum == "" {
+			continue
+		}
+		databases = append(databases, AnalysisDatabase{
+			Name:       fingerprint.Name,
+			Checksum:   fingerprint.Checksum,
+			Version:    fingerprint.Version,
+			AnalysisID: analysisID,
+		})
+	}
+	return databases
+}
+
+// ToFingerprint returns the stored part of the fingerprint.
+func (d *AnalysisDatabase) ToFingerprint() pipeline.DatabaseFingerprint {
+	return pipeline.DatabaseFingerprint{
+		Name:     d.Name,
+		Checksum: d.Checksum,
+		Version:  d.Version,
+	}
+}
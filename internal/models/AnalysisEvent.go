@@ -0,0 +1,35 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// AnalysisEvent is published whenever the status or the step of an analysis
+// changes.
+type AnalysisEvent struct {
+	AnalysisID   uuid.UUID      `json:"analysis_id"`
+	UserID       uuid.UUID      `json:"user_id"`
+	Status       AnalysisStatus `json:"status"`
+	Step         AnalysisStep   `json:"step"`
+	ErrorMessage *string        `json:"error_message,omitempty"`
+	Timestamp    time.Time      `json:"timestamp"`
+}
+
+func NewAnalysisEvent(analysis *Analysis) AnalysisEvent {
+	return AnalysisEvent{
+		AnalysisID:   analysis.ID,
+		UserID:       analysis.UserID,
+		Status:       analysis.Status,
+		Step:         analysis.Step,
+		ErrorMessage: analysis.ErrorMessage,
+		Timestamp:    time.Now().UTC(),
+	}
+}
+
+// Localize returns the event with its error message translated.
+func (e AnalysisEvent) Localize(language string) AnalysisEvent {
+	e.ErrorMessage = translateErrorMessage(e.ErrorMessage, language)
+	return e
+}
@@ -0,0 +1,41 @@
+package models_test
+
+import (
+	"testing"
+
+	"github.com/CABGenOrg/cabgen_backend/internal/models"
+	"github.com/CABGenOrg/cabgen_backend/internal/pipeline"
+	testmodels "github.com/CABGenOrg/cabgen_backend/internal/testutils/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewAnalysisEvent(t *testing.T) {
+	mockAnalysis := testmodels.CreateMockAnalysis()
+	mockAnalysis.Status = models.AnalysisStatusRunning
+	mockAnalysis.Step = models.StepKraken2
+
+	result := models.NewAnalysisEvent(&mockAnalysis)
+
+	assert.Equal(t, mockAnalysis.ID, result.AnalysisID)
+	assert.Equal(t, mockAnalysis.UserID, result.UserID)
+	assert.Equal(t, models.AnalysisStatusRunning, result.Status)
+	assert.Equal(t, models.StepKraken2, result.Step)
+	assert.False(t, result.Timestamp.IsZero())
+}
+
+func TestAnalysisEventLocalize(t *testing.T) {
+	t.Run("Translates known error", func(t *testing.T) {
+		msg := pipeline.ErrKraken2.Error()
+		event := models.AnalysisEvent{ErrorMessage: &msg}
+
+		result := event.Localize("pt")
+		assert.Equal(t, "A etapa do Kraken2 falhou. Crie uma nova análise.",
+			*result.ErrorMessage)
+		assert.Equal(t, pipeline.ErrKraken2.Error(), *event.ErrorMessage)
+	})
+
+	t.Run("Keeps empty error", func(t *testing.T) {
+		result := models.AnalysisEvent{}.Localize("es")
+		assert.Nil(t, result.ErrorMessage)
+	})
+}
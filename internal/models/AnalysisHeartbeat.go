@@ -0,0 +1,17 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// AnalysisHeartbeat is written periodically by the worker running an
+// analysis. An analysis still RUNNING once its heartbeat expired lost its
+// worker.
+type AnalysisHeartbeat struct {
+	AnalysisID uuid.UUID    `json:"analysis_id"`
+	WorkerID   string       `json:"worker_id"`
+	Step       AnalysisStep `json:"step,omitempty"`
+	LastSeen   time.Time    `json:"last_seen"`
+}
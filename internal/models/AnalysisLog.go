@@ -0,0 +1,73 @@
+package models
+
+import (
+	"strings"
+	"time"
+
+	"github.com/CABGenOrg/cabgen_backend/internal/pipeline"
+	"github.com/google/uuid"
+)
+
+type AnalysisLog struct {
+	ID uuid.UUID `gorm:"type:uuid;default:uuid_generate_v4();primaryKey"`
+
+	Step       AnalysisStep `gorm:"type:varchar(20);not null;index"`
+	Tool       string       `gorm:"type:varchar(100);not null"`
+	Command    string       `gorm:"type:text;not null"`
+	ExitCode   int          `gorm:"not null"`
+	DurationMs int64        `gorm:"not null"`
+
+	// Paths
+	StdoutPath string `gorm:"type:varchar(255)"`
+	StderrPath string `gorm:"type:varchar(255)"`
+
+	// Datetime
+	StartedAt time.Time
+	CreatedAt time.Time
+
+	// Foreign Keys
+	AnalysisID uuid.UUID `gorm:"type:uuid;not null;index"`
+	Analysis   Analysis  `gorm:"foreignKey:AnalysisID;references:ID;constraint:OnDelete:CASCADE"`
+}
+
+type AnalysisLogResponse struct {
+	ID         uuid.UUID    `json:"id"`
+	Step       AnalysisStep `json:"step"`
+	Tool       string       `json:"tool"`
+	Command    string       `json:"command"`
+	ExitCode   int          `json:"exit_code"`
+	DurationMs int64        `json:"duration_ms"`
+	StartedAt  time.Time    `json:"started_at"`
+	Stdout     *string      `json:"stdout,omitempty"`
+	Stderr     *string      `json:"stderr,omitempty"`
+}
+
+func (l *AnalysisLog) ToResponse() AnalysisLogResponse {
+	return AnalysisLogResponse{
+		ID:         l.ID,
+		Step:       l.Step,
+		Tool:       l.Tool,
+		Command:    l.Command,
+		ExitCode:   l.ExitCode,
+		DurationMs: l.DurationMs,
+		StartedAt:  l.StartedAt,
+	}
+}
+
+func NewAnalysisLog(analysisID uuid.UUID, invocation pipeline.ToolInvocation,
+	files *pipeline.ToolLogFiles) AnalysisLog {
+	log := AnalysisLog{
+		Step:       AnalysisStep(invocation.Step),
+		Tool:       invocation.Tool(),
+		Command:    strings.Join(invocation.Command, " "),
+		ExitCode:   invocation.ExitCode,
+		DurationMs: invocation.Duration.Milliseconds(),
+		StartedAt:  invocation.StartedAt,
+		AnalysisID: analysisID,
+	}
+	if files != nil {
+		log.StdoutPath = files.Stdout
+		log.StderrPath = files.Stderr
+	}
+	return log
+}
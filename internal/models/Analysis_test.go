@@ -23,6 +23,7 @@ func TestAnalysisToResponse(t *testing.T) {
 		SampleID:       mockAnalysis.Sample.ID,
 		User:           mockAnalysis.User.Username,
 		UserID:         mockAnalysis.UserID,
+		Parameters:     mockAnalysis.Parameters,
 		Metrics:        mockAnalysis.Metrics,
 		ResultsZipPath: mockAnalysis.ResultsZipPath,
 		FastQC1:        mockAnalysis.FastQC1,
@@ -106,6 +107,8 @@ func TestAnalysisStatusCanTransitionTo(t *testing.T) {
 			models.AnalysisStatusPending, false},
 		{"RUNNING to DONE", models.AnalysisStatusRunning,
 			models.AnalysisStatusDone, false},
+		{"RUNNING to CANCELLED", models.AnalysisStatusRunning,
+			models.AnalysisStatusCancelled, true},
 
 		{"PENDING to FAILED", models.AnalysisStatusPending,
 			models.AnalysisStatusFailed, true},
@@ -113,6 +116,19 @@ func TestAnalysisStatusCanTransitionTo(t *testing.T) {
 			models.AnalysisStatusPending, false},
 		{"PENDING to RUNNING", models.AnalysisStatusPending,
 			models.AnalysisStatusRunning, false},
+		{"PENDING to CANCELLED", models.AnalysisStatusPending,
+			models.AnalysisStatusCancelled, true},
+
+		{"DONE to CANCELLED", models.AnalysisStatusDone,
+			models.AnalysisStatusCancelled, false},
+		{"FAILED to CANCELLED", models.AnalysisStatusFailed,
+			models.AnalysisStatusCancelled, false},
+		{"CANCELLED to PENDING", models.AnalysisStatusCancelled,
+			models.AnalysisStatusPending, true},
+		{"CANCELLED to FAILED", models.AnalysisStatusCancelled,
+			models.AnalysisStatusFailed, false},
+		{"CANCELLED to CANCELLED", models.AnalysisStatusCancelled,
+			models.AnalysisStatusCancelled, false},
 	}
 
 	for _, tt := range tests {
@@ -135,3 +151,85 @@ func TestAnalysisTaskID(t *testing.T) {
 		assert.Equal(t, "asynq:task-123", *analysis.TaskID)
 	})
 }
+
+func TestAnalysisTypeParseParameters(t *testing.T) {
+	t.Run("Success - Genome", func(t *testing.T) {
+		params, err := models.AnalysisTypeGenome.ParseParameters(
+			[]byte(`{"abricate":{"min_coverage":80}}`))
+
+		assert.NoError(t, err)
+		assert.Equal(t, 80.0, params.Abricate.MinCoverage)
+	})
+
+	t.Run("Success - No Parameters", func(t *testing.T) {
+		_, err := models.AnalysisTypeFastQC.ParseParameters(nil)
+		assert.NoError(t, err)
+	})
+
+	t.Run("Error - Section Not In Type Schema", func(t *testing.T) {
+		_, err := models.AnalysisTypeFastQC.ParseParameters(
+			[]byte(`{"unicycler":{"mode":"bold"}}`))
+		assert.ErrorIs(t, err, pipeline.ErrInvalidParameters)
+	})
+}
+
+func TestAnalysisResultsQCValues(t *testing.T) {
+	t.Run("Assembly Stats Override CheckM", func(t *testing.T) {
+		results := models.AnalysisResults{
+			CheckMCompleteness:   "98.5",
+			CheckMContamination:  "1.2",
+			CheckMN50:            "1000",
+			PrimarySpeciesName:   "Klebsiella pneumoniae",
+			SecondarySpeciesName: "Escherichia coli",
+			Coverage:             45,
+			AssemblyStats: &pipeline.AssemblyStats{
+				Contigs: 120, TotalLength: 5400000, N50: 250000,
+			},
+			Taxonomy: &pipeline.KrakenComposition{
+				Species: []pipeline.KrakenTaxon{
+					{Name: "Klebsiella pneumoniae", Percent: 88.4},
+					{Name: "Escherichia coli", Percent: 6.2},
+				},
+			},
+		}
+
+		values := results.QCValues()
+
+		assert.Equal(t, "Klebsiella pneumoniae", values.Species)
+		assert.Equal(t, 98.5, *values.Completeness)
+		assert.Equal(t, 1.2, *values.Contamination)
+		assert.Equal(t, 45.0, *values.Coverage)
+		assert.Equal(t, 250000.0, *values.N50)
+		assert.Equal(t, 120.0, *values.Contigs)
+		assert.Equal(t, 5400000.0, *values.GenomeSize)
+		assert.Equal(t, 6.2, *values.SecondarySpecies)
+	})
+
+	t.Run("Single Species Measured As Zero", func(t *testing.T) {
+		results := models.AnalysisResults{
+			Taxonomy: &pipeline.KrakenComposition{
+				Species: []pipeline.KrakenTaxon{
+					{Name: "Klebsiella pneumoniae", Percent: 99.1},
+				},
+			},
+		}
+
+		values := results.QCValues()
+
+		assert.Equal(t, 0.0, *values.SecondarySpecies)
+	})
+
+	t.Run("Missing Metrics Stay Unmeasured", func(t *testing.T) {
+		results := models.AnalysisResults{
+			CheckMCompleteness: "n/a",
+			LongReadCoverage:   80,
+		}
+
+		values := results.QCValues()
+
+		assert.Nil(t, values.Completeness)
+		assert.Nil(t, values.Contigs)
+		assert.Nil(t, values.SecondarySpecies)
+		assert.Equal(t, 80.0, *values.Coverage)
+	})
+}
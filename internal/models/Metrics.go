@@ -9,18 +9,28 @@ type PublicMetricsResponse struct {
 
 type AdminMetricsResponse struct {
 	PublicMetricsResponse
-	TotalUsers       int64            `json:"total_users"`
-	TotalAnalyses    int64            `json:"total_analyses"`
-	AnalysesByStatus AnalysesByStatus `json:"analyses_by_status"`
-	TopCountries     []CountryMetric  `json:"top_countries"`
-	SpeciesBreakdown []SpeciesMetric  `json:"species_breakdown"`
+	TotalUsers         int64              `json:"total_users"`
+	TotalAnalyses      int64              `json:"total_analyses"`
+	AnalysesByStatus   AnalysesByStatus   `json:"analyses_by_status"`
+	TopCountries       []CountryMetric    `json:"top_countries"`
+	SpeciesBreakdown   []SpeciesMetric    `json:"species_breakdown"`
+	SpeciesConcordance SpeciesConcordance `json:"species_concordance"`
 }
 
 type AnalysesByStatus struct {
-	Done    int64 `json:"done"`
-	Running int64 `json:"running"`
-	Pending int64 `json:"pending"`
-	Failed  int64 `json:"failed"`
+	Done      int64 `json:"done"`
+	Running   int64 `json:"running"`
+	Pending   int64 `json:"pending"`
+	Failed    int64 `json:"failed"`
+	Cancelled int64 `json:"cancelled"`
+}
+
+// SpeciesConcordance counts the analyses by the agreement of the declared
+// and detected species.
+type SpeciesConcordance struct {
+	Concordant   int64 `json:"concordant"`
+	Discordant   int64 `json:"discordant"`
+	Inconclusive int64 `json:"inconclusive"`
 }
 
 type CountryMetric struct {
@@ -29,6 +29,7 @@ type Microorganism struct {
 	Taxon    Taxon     `gorm:"not null"`
 	Species  string    `gorm:"type:varchar(255);not null"`
 	Variety  JSONMap   `gorm:"type:jsonb"`
+	TaxID    *int      `gorm:"column:taxid"` // NCBI taxonomy ID
 	IsActive bool      `gorm:"not null" json:"is_active"`
 }
 
@@ -37,6 +38,7 @@ type MicroorganismAdminDetailResponse struct {
 	Taxon    Taxon             `json:"taxon"`
 	Species  string            `json:"species"`
 	Variety  map[string]string `json:"variety"`
+	TaxID    *int              `json:"taxid"`
 	IsActive bool              `json:"is_active"`
 }
 
@@ -59,6 +61,7 @@ func (m *Microorganism) ToAdminDetailResponse() MicroorganismAdminDetailResponse
 		Taxon:    m.Taxon,
 		Species:  m.Species,
 		Variety:  m.Variety,
+		TaxID:    m.TaxID,
 		IsActive: m.IsActive,
 	}
 }
@@ -98,6 +101,7 @@ type MicroorganismCreateInput struct {
 	Taxon    Taxon             `json:"taxon" binding:"required"`
 	Species  string            `json:"species" binding:"required,min=3,max=255"`
 	Variety  map[string]string `json:"variety" binding:"omitempty,min=3"`
+	TaxID    *int              `json:"taxid,omitempty" binding:"omitempty,min=1"`
 	IsActive bool              `json:"is_active"`
 }
 
@@ -105,5 +109,6 @@ type MicroorganismUpdateInput struct {
 	Taxon    *Taxon            `json:"taxon,omitempty" binding:"omitempty"`
 	Species  *string           `json:"species,omitempty" binding:"omitempty,min=3,max=255"`
 	Variety  map[string]string `json:"variety,omitempty" binding:"omitempty,min=3"`
+	TaxID    *int              `json:"taxid,omitempty" binding:"omitempty,min=1"`
 	IsActive *bool             `json:"is_active,omitempty" binding:"omitempty"`
 }
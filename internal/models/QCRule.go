@@ -0,0 +1,69 @@
+package models
+
+import (
+	"github.com/CABGenOrg/cabgen_backend/internal/pipeline"
+	"github.com/google/uuid"
+)
+
+// QCRule is a threshold checked when an analysis finishes. Empty Species
+// applies the rule to every species.
+type QCRule struct {
+	ID       uuid.UUID           `gorm:"type:uuid;default:uuid_generate_v4();primaryKey"`
+	Metric   pipeline.QCMetric   `gorm:"type:varchar(30);not null"`
+	Species  string              `gorm:"type:varchar(255);not null;default:''"`
+	Min      *float64            `gorm:"type:double precision"`
+	Max      *float64            `gorm:"type:double precision"`
+	Severity pipeline.QCSeverity `gorm:"type:varchar(10);not null;default:'FAIL'"`
+	IsActive bool                `gorm:"not null"`
+}
+
+type QCRuleAdminTableResponse struct {
+	ID       uuid.UUID           `json:"id"`
+	Metric   pipeline.QCMetric   `json:"metric"`
+	Species  string              `json:"species"`
+	Min      *float64            `json:"min"`
+	Max      *float64            `json:"max"`
+	Severity pipeline.QCSeverity `json:"severity"`
+	IsActive bool                `json:"is_active"`
+}
+
+func (r *QCRule) ToAdminTableResponse() QCRuleAdminTableResponse {
+	return QCRuleAdminTableResponse{
+		ID:       r.ID,
+		Metric:   r.Metric,
+		Species:  r.Species,
+		Min:      r.Min,
+		Max:      r.Max,
+		Severity: r.Severity,
+		IsActive: r.IsActive,
+	}
+}
+
+func (r *QCRule) ToPipelineRule() pipeline.QCRule {
+	return pipeline.QCRule{
+		Metric:   r.Metric,
+		Species:  r.Species,
+		Min:      r.Min,
+		Max:      r.Max,
+		Severity: r.Severity,
+	}
+}
+
+type QCRuleCreateInput struct {
+	Metric  pipeline.QCMetric `json:"metric" binding:"required"`
+	Species string            `json:"species,omitempty" binding:"omitempty,max=255"`
+	Min     *float64          `json:"min,omitempty" binding:"omitempty"`
+	Max     *float64          `json:"max,omitempty" binding:"omitempty"`
+	// Defaults to FAIL
+	Severity pipeline.QCSeverity `json:"severity,omitempty" binding:"omitempty"`
+	IsActive bool                `json:"is_active"`
+}
+
+type QCRuleUpdateInput struct {
+	Metric   *pipeline.QCMetric   `json:"metric,omitempty" binding:"omitempty"`
+	Species  *string              `json:"species,omitempty" binding:"omitempty,max=255"`
+	Min      *float64             `json:"min,omitempty" binding:"omitempty"`
+	Max      *float64             `json:"max,omitempty" binding:"omitempty"`
+	Severity *pipeline.QCSeverity `json:"severity,omitempty" binding:"omitempty"`
+	IsActive *bool                `json:"is_active,omitempty" binding:"omitempty"`
+}
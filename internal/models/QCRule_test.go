@@ -0,0 +1,54 @@
+package models_test
+
+import (
+	"testing"
+
+	"github.com/CABGenOrg/cabgen_backend/internal/models"
+	"github.com/CABGenOrg/cabgen_backend/internal/pipeline"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQCRuleToAdminTableResponse(t *testing.T) {
+	minCoverage := 30.0
+	rule := models.QCRule{
+		ID:       uuid.New(),
+		Metric:   pipeline.QCMetricCoverage,
+		Species:  "Escherichia coli",
+		Min:      &minCoverage,
+		Severity: pipeline.QCSeverityWarn,
+		IsActive: true,
+	}
+
+	expected := models.QCRuleAdminTableResponse{
+		ID:       rule.ID,
+		Metric:   rule.Metric,
+		Species:  rule.Species,
+		Min:      rule.Min,
+		Severity: rule.Severity,
+		IsActive: rule.IsActive,
+	}
+	result := rule.ToAdminTableResponse()
+
+	assert.Equal(t, expected, result)
+}
+
+func TestQCRuleToPipelineRule(t *testing.T) {
+	maxContigs := 500.0
+	rule := models.QCRule{
+		ID:       uuid.New(),
+		Metric:   pipeline.QCMetricContigs,
+		Max:      &maxContigs,
+		Severity: pipeline.QCSeverityFail,
+		IsActive: true,
+	}
+
+	expected := pipeline.QCRule{
+		Metric:   rule.Metric,
+		Max:      rule.Max,
+		Severity: rule.Severity,
+	}
+	result := rule.ToPipelineRule()
+
+	assert.Equal(t, expected, result)
+}
@@ -6,8 +6,10 @@ import (
 	"strings"
 	"time"
 
+	"github.com/CABGenOrg/cabgen_backend/internal/pipeline"
 	"github.com/CABGenOrg/cabgen_backend/internal/translation"
 	"github.com/google/uuid"
+	"gorm.io/datatypes"
 )
 
 type Gender string
@@ -87,6 +89,9 @@ type Sample struct {
 	Fastq1         *string `gorm:"type:varchar(255);default:null"`
 	Fastq2         *string `gorm:"type:varchar(255);default:null"`
 	Fasta          *string `gorm:"type:varchar(255);default:null"`
+	LongReads      *string `gorm:"type:varchar(255);default:null"`
+	// Validation of the files of the last upload
+	ValidationReport datatypes.JSON `gorm:"type:jsonb"`
 	// Foreign Keys
 	CountryID       uint          `gorm:"not null"`
 	Country         Country       `gorm:"foreignKey:CountryID;references:ID"`
@@ -118,6 +123,9 @@ type SampleResponse struct {
 	Fastq1         *string    `json:"fastq1"`
 	Fastq2         *string    `json:"fastq2"`
 	Fasta          *string    `json:"fasta"`
+	LongReads      *string    `json:"long_reads"`
+	// Validation of the files of the last upload
+	ValidationReport datatypes.JSON `json:"validation_report,omitempty"`
 	// Foreign Keys
 	CountryCode   string `json:"country_code"`
 	User          string `json:"user"`
@@ -137,7 +145,7 @@ func (s *Sample) ToResponse(language string) SampleResponse {
 		s.Microorganism.Variety[language])
 	gender := s.Gender.ToTranslatedString(language)
 
-	var fastq1Path, fastq2Path, fastaPath *string
+	var fastq1Path, fastq2Path, fastaPath, longReadsPath *string
 
 	if s.Fastq1 != nil {
 		path := filepath.Base(*s.Fastq1)
@@ -151,27 +159,33 @@ func (s *Sample) ToResponse(language string) SampleResponse {
 		path := filepath.Base(*s.Fasta)
 		fastaPath = &path
 	}
+	if s.LongReads != nil {
+		path := filepath.Base(*s.LongReads)
+		longReadsPath = &path
+	}
 
 	return SampleResponse{
-		ID:             s.ID,
-		CollectionDate: s.CollectionDate,
-		RunNumber:      s.RunNumber,
-		RunDate:        s.RunDate,
-		City:           s.City,
-		OriginCode:     s.OriginCode,
-		Gender:         gender,
-		DateOfBirth:    s.DateOfBirth,
-		Fastq1:         fastq1Path,
-		Fastq2:         fastq2Path,
-		Fasta:          fastaPath,
-		CountryCode:    s.Country.Code,
-		User:           s.User.Username,
-		Origin:         s.Origin.Names[language],
-		SampleSource:   s.SampleSource.Names[language],
-		Microorganism:  species,
-		Sequencer:      sequencer,
-		Laboratory:     s.Laboratory.Name,
-		HealthService:  s.HealthService.Name,
+		ID:               s.ID,
+		CollectionDate:   s.CollectionDate,
+		RunNumber:        s.RunNumber,
+		RunDate:          s.RunDate,
+		City:             s.City,
+		OriginCode:       s.OriginCode,
+		Gender:           gender,
+		DateOfBirth:      s.DateOfBirth,
+		Fastq1:           fastq1Path,
+		Fastq2:           fastq2Path,
+		Fasta:            fastaPath,
+		LongReads:        longReadsPath,
+		ValidationReport: s.ValidationReport,
+		CountryCode:      s.Country.Code,
+		User:             s.User.Username,
+		Origin:           s.Origin.Names[language],
+		SampleSource:     s.SampleSource.Names[language],
+		Microorganism:    species,
+		Sequencer:        sequencer,
+		Laboratory:       s.Laboratory.Name,
+		HealthService:    s.HealthService.Name,
 	}
 }
 
@@ -325,8 +339,23 @@ func SampleUpdateInputToDTO(input SampleUpdateInput, id uuid.UUID) SampleUpdateD
 	}
 }
 
+// SampleValidationReport is stored with the sample after each upload. Error
+// holds the reason the files were rejected; the files of a rejected upload
+// are not attached.
+type SampleValidationReport struct {
+	Valid       bool                     `json:"valid"`
+	Error       string                   `json:"error,omitempty"`
+	Fastq1      *pipeline.FileValidation `json:"fastq1,omitempty"`
+	Fastq2      *pipeline.FileValidation `json:"fastq2,omitempty"`
+	Fasta       *pipeline.FileValidation `json:"fasta,omitempty"`
+	LongReads   *pipeline.FileValidation `json:"long_reads,omitempty"`
+	ValidatedAt time.Time                `json:"validated_at"`
+}
+
 type SampleAttachmentInput struct {
 	Fastq1 *string `json:"fastq1" binding:"max=255"`
 	Fastq2 *string `json:"fastq2" binding:"max=255"`
 	Fasta  *string `json:"fasta" binding:"max=255"`
+	// Nanopore or PacBio reads
+	LongReads *string `json:"long_reads" binding:"max=255"`
 }
@@ -5,20 +5,29 @@ type SelectOption struct {
 	Value string `json:"value"`
 }
 
+// SequencerSelectOption tells the sample form which analysis types the reads
+// of the sequencer can go through.
+type SequencerSelectOption struct {
+	SelectOption
+	ReadTechnology ReadTechnology `json:"read_technology"`
+	AnalysisTypes  []AnalysisType `json:"analysis_types"`
+}
+
 type EnumSelectsResponse struct {
 	Roles              []SelectOption `json:"roles"`
 	Taxons             []SelectOption `json:"taxons"`
 	Genders            []SelectOption `json:"genders"`
 	HealthServiceTypes []SelectOption `json:"health_service_types"`
 	AnalysisTypes      []SelectOption `json:"analysis_types"`
+	ReadTechnologies   []SelectOption `json:"read_technologies"`
 	Languages          []SelectOption `json:"languages"`
 }
 
 type FormSelectsResponse struct {
-	Laboratories   []SelectOption `json:"laboratories"`
-	Sequencers     []SelectOption `json:"sequencers"`
-	HealthServices []SelectOption `json:"health_services"`
-	Origins        []SelectOption `json:"origins"`
-	Microorganisms []SelectOption `json:"microorganisms"`
-	SampleSources  []SelectOption `json:"sample_sources"`
+	Laboratories   []SelectOption          `json:"laboratories"`
+	Sequencers     []SequencerSelectOption `json:"sequencers"`
+	HealthServices []SelectOption          `json:"health_services"`
+	Origins        []SelectOption          `json:"origins"`
+	Microorganisms []SelectOption          `json:"microorganisms"`
+	SampleSources  []SelectOption          `json:"sample_sources"`
 }
@@ -2,49 +2,90 @@ package models
 
 import "github.com/google/uuid"
 
+type ReadTechnology string
+
+const (
+	ReadTechnologyShort ReadTechnology = "SHORT_READ"
+	ReadTechnologyLong  ReadTechnology = "LONG_READ"
+)
+
+func (r ReadTechnology) IsValid() bool {
+	switch r {
+	case ReadTechnologyShort, ReadTechnologyLong:
+		return true
+	default:
+		return false
+	}
+}
+
+// AnalysisTypes lists the analysis types the reads of a sequencer using this
+// technology can go through. Hybrid assemblies take reads of both.
+func (r ReadTechnology) AnalysisTypes() []AnalysisType {
+	switch r {
+	case ReadTechnologyShort:
+		return []AnalysisType{AnalysisTypeFastQC, AnalysisTypeGenome,
+			AnalysisTypeComplete, AnalysisTypeHybrid}
+	case ReadTechnologyLong:
+		return []AnalysisType{AnalysisTypeLongRead, AnalysisTypeHybrid}
+	default:
+		return nil
+	}
+}
+
+var ReadTechnologies = []ReadTechnology{ReadTechnologyShort,
+	ReadTechnologyLong}
+
 type Sequencer struct {
-	ID       uuid.UUID `gorm:"type:uuid;default:uuid_generate_v4();primaryKey"`
-	Model    string    `gorm:"type:varchar(255);not null"`
-	Brand    string    `gorm:"type:varchar(255);not null"`
-	IsActive bool      `gorm:"not null" json:"is_active"`
+	ID             uuid.UUID      `gorm:"type:uuid;default:uuid_generate_v4();primaryKey"`
+	Model          string         `gorm:"type:varchar(255);not null"`
+	Brand          string         `gorm:"type:varchar(255);not null"`
+	ReadTechnology ReadTechnology `gorm:"type:varchar(20);not null;default:'SHORT_READ'"`
+	IsActive       bool           `gorm:"not null" json:"is_active"`
 }
 
 type SequencerAdminTableResponse struct {
-	ID       uuid.UUID `json:"id"`
-	Model    string    `json:"model"`
-	Brand    string    `json:"brand"`
-	IsActive bool      `json:"is_active"`
+	ID             uuid.UUID      `json:"id"`
+	Model          string         `json:"model"`
+	Brand          string         `json:"brand"`
+	ReadTechnology ReadTechnology `json:"read_technology"`
+	IsActive       bool           `json:"is_active"`
 }
 
 type SequencerFormResponse struct {
-	ID    uuid.UUID `json:"id"`
-	Brand string    `json:"brand"`
+	ID             uuid.UUID      `json:"id"`
+	Brand          string         `json:"brand"`
+	ReadTechnology ReadTechnology `json:"read_technology"`
 }
 
 func (s *Sequencer) ToAdminTableResponse() SequencerAdminTableResponse {
 	return SequencerAdminTableResponse{
-		ID:       s.ID,
-		Model:    s.Model,
-		Brand:    s.Brand,
-		IsActive: s.IsActive,
+		ID:             s.ID,
+		Model:          s.Model,
+		Brand:          s.Brand,
+		ReadTechnology: s.ReadTechnology,
+		IsActive:       s.IsActive,
 	}
 }
 
 func (s *Sequencer) ToFormResponse() SequencerFormResponse {
 	return SequencerFormResponse{
-		ID:    s.ID,
-		Brand: s.Brand,
+		ID:             s.ID,
+		Brand:          s.Brand,
+		ReadTechnology: s.ReadTechnology,
 	}
 }
 
 type SequencerCreateInput struct {
-	Model    string `json:"model" binding:"required,min=3,max=255"`
-	Brand    string `json:"brand" binding:"required,min=3,max=255"`
-	IsActive bool   `json:"is_active"`
+	Model string `json:"model" binding:"required,min=3,max=255"`
+	Brand string `json:"brand" binding:"required,min=3,max=255"`
+	// Defaults to SHORT_READ
+	ReadTechnology ReadTechnology `json:"read_technology,omitempty" binding:"omitempty"`
+	IsActive       bool           `json:"is_active"`
 }
 
 type SequencerUpdateInput struct {
-	Model    *string `json:"model,omitempty" binding:"omitempty,min=3,max=255"`
-	Brand    *string `json:"brand,omitempty" binding:"omitempty,min=3,max=255"`
-	IsActive *bool   `json:"is_active,omitempty" binding:"omitempty"`
+	Model          *string         `json:"model,omitempty" binding:"omitempty,min=3,max=255"`
+	Brand          *string         `json:"brand,omitempty" binding:"omitempty,min=3,max=255"`
+	ReadTechnology *ReadTechnology `json:"read_technology,omitempty" binding:"omitempty"`
+	IsActive       *bool           `json:"is_active,omitempty" binding:"omitempty"`
 }
@@ -0,0 +1,44 @@
+package models
+
+import "github.com/CABGenOrg/cabgen_backend/internal/pipeline"
+
+type SpeciesProfileResponse struct {
+	Name           string   `json:"name"`
+	Source         string   `json:"source"`
+	KrakenMatchers []string `json:"kraken_matchers"`
+	PoliGenes      []string `json:"poli_genes"`
+	OtherGenes     []string `json:"other_genes"`
+	PoliDB         string   `json:"poli_db"`
+	OtherDB        string   `json:"other_db"`
+	RRNAGenes      []string `json:"rrna_genes"`
+	RRNADB         string   `json:"rrna_db"`
+	FastANIList    string   `json:"fastani_list"`
+	MLSTExclude    []string `json:"mlst_exclude"`
+	Valid          bool     `json:"valid"`
+	Issues         []string `json:"issues"`
+}
+
+func ToSpeciesProfileResponse(
+	check pipeline.SpeciesProfileCheck) SpeciesProfileResponse {
+	profile := check.Profile
+	issues := check.Issues
+	if issues == nil {
+		issues = []string{}
+	}
+
+	return SpeciesProfileResponse{
+		Name:           profile.Name,
+		Source:         profile.Source,
+		KrakenMatchers: profile.KrakenMatchers,
+		PoliGenes:      profile.PoliGenes,
+		OtherGenes:     profile.OtherGenes,
+		PoliDB:         profile.PoliDB,
+		OtherDB:        profile.OtherDB,
+		RRNAGenes:      profile.RRNAGenes,
+		RRNADB:         profile.RRNADB,
+		FastANIList:    profile.FastANIList,
+		MLSTExclude:    profile.MLSTExclude,
+		Valid:          len(check.Issues) == 0,
+		Issues:         issues,
+	}
+}
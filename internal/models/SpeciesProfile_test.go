@@ -0,0 +1,49 @@
+package models_test
+
+import (
+	"testing"
+
+	"github.com/CABGenOrg/cabgen_backend/internal/models"
+	"github.com/CABGenOrg/cabgen_backend/internal/pipeline"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestToSpeciesProfileResponse(t *testing.T) {
+	profile := &pipeline.SpeciesProfile{
+		Name:           "Klebsiella pneumoniae",
+		Source:         "klebsiella.toml",
+		KrakenMatchers: []string{"Klebsiella pneumoniae"},
+		PoliGenes:      []string{"PmrB"},
+		OtherGenes:     []string{"GyrA"},
+		PoliDB:         "/dbs/poli.fasta",
+		OtherDB:        "/dbs/other.fasta",
+	}
+
+	t.Run("Valid", func(t *testing.T) {
+		result := models.ToSpeciesProfileResponse(
+			pipeline.SpeciesProfileCheck{Profile: profile})
+
+		assert.Equal(t, models.SpeciesProfileResponse{
+			Name:           "Klebsiella pneumoniae",
+			Source:         "klebsiella.toml",
+			KrakenMatchers: []string{"Klebsiella pneumoniae"},
+			PoliGenes:      []string{"PmrB"},
+			OtherGenes:     []string{"GyrA"},
+			PoliDB:         "/dbs/poli.fasta",
+			OtherDB:        "/dbs/other.fasta",
+			Valid:          true,
+			Issues:         []string{},
+		}, result)
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		result := models.ToSpeciesProfileResponse(
+			pipeline.SpeciesProfileCheck{
+				Profile: profile,
+				Issues:  []string{"duplicate profile name"},
+			})
+
+		assert.False(t, result.Valid)
+		assert.Equal(t, []string{"duplicate profile name"}, result.Issues)
+	})
+}
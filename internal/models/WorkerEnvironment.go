@@ -0,0 +1,54 @@
+package models
+
+import (
+	"time"
+
+	"github.com/CABGenOrg/cabgen_backend/internal/pipeline"
+)
+
+type WorkerStatus string
+
+const (
+	WorkerStatusReady WorkerStatus = "READY"
+	// WorkerStatusDegraded is a worker running with tools or databases that
+	// do not match the manifest.
+	WorkerStatusDegraded WorkerStatus = "DEGRADED"
+)
+
+// WorkerEnvironment is the toolset an analysis worker resolved at start,
+// published so admins can compare the workers of the pool.
+type WorkerEnvironment struct {
+	ID              string                         `json:"id"`
+	Hostname        string                         `json:"hostname"`
+	Status          WorkerStatus                   `json:"status"`
+	ManifestVersion string                         `json:"manifest_version,omitempty"`
+	Tools           []pipeline.ToolVersion         `json:"tools"`
+	Databases       []pipeline.DatabaseFingerprint `json:"databases"`
+	Mismatches      []pipeline.ManifestMismatch    `json:"mismatches,omitempty"`
+	StartedAt       time.Time                      `json:"started_at"`
+}
+
+// NewWorkerEnvironment returns a ready environment. When a manifest is
+// given, it is checked and any mismatch marks the worker as degraded.
+func NewWorkerEnvironment(id, hostname string, tools []pipeline.ToolVersion,
+	databases []pipeline.DatabaseFingerprint,
+	manifest *pipeline.ToolManifest) WorkerEnvironment {
+	environment := WorkerEnvironment{
+		ID:        id,
+		Hostname:  hostname,
+		Status:    WorkerStatusReady,
+		Tools:     tools,
+		Databases: databases,
+		StartedAt: time.Now().UTC(),
+	}
+
+	if manifest != nil {
+		environment.ManifestVersion = manifest.Version
+		environment.Mismatches = manifest.Check(tools, databases)
+		if len(environment.Mismatches) > 0 {
+			environment.Status = WorkerStatusDegraded
+		}
+	}
+
+	return environment
+}
@@ -0,0 +1,54 @@
+package models_test
+
+import (
+	"testing"
+
+	"github.com/CABGenOrg/cabgen_backend/internal/models"
+	"github.com/CABGenOrg/cabgen_backend/internal/pipeline"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewWorkerEnvironment(t *testing.T) {
+	tools := []pipeline.ToolVersion{{Name: "Prokka", Version: "1.14.6"}}
+	databases := []pipeline.DatabaseFingerprint{
+		{Name: "kraken2", Checksum: "abc"},
+	}
+
+	t.Run("Ready Without Manifest", func(t *testing.T) {
+		result := models.NewWorkerEnvironment("host:1", "host", tools,
+			databases, nil)
+
+		assert.Equal(t, models.WorkerStatusReady, result.Status)
+		assert.Equal(t, "host:1", result.ID)
+		assert.Empty(t, result.ManifestVersion)
+		assert.False(t, result.StartedAt.IsZero())
+	})
+
+	t.Run("Ready With Matching Manifest", func(t *testing.T) {
+		manifest := &pipeline.ToolManifest{
+			Version: "2024.06",
+			Tools:   map[string]string{"Prokka": "1.14"},
+		}
+
+		result := models.NewWorkerEnvironment("host:1", "host", tools,
+			databases, manifest)
+
+		assert.Equal(t, models.WorkerStatusReady, result.Status)
+		assert.Equal(t, "2024.06", result.ManifestVersion)
+		assert.Empty(t, result.Mismatches)
+	})
+
+	t.Run("Degraded With Mismatch", func(t *testing.T) {
+		manifest := &pipeline.ToolManifest{
+			Databases: map[string]pipeline.ManifestDatabase{
+				"kraken2": {Checksum: "def"},
+			},
+		}
+
+		result := models.NewWorkerEnvironment("host:1", "host", tools,
+			databases, manifest)
+
+		assert.Equal(t, models.WorkerStatusDegraded, result.Status)
+		assert.Len(t, result.Mismatches, 1)
+	})
+}
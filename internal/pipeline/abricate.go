@@ -13,7 +13,69 @@ import (
 
 var vanPattern = regexp.MustCompile(`(?i)^Van`)
 
-func GetAbricateResult(filePath string) ([]string, error) {
+// AMRHit is a gene found by ABRicate in one of its databases, or by
+// AMRFinderPlus. Display strings are built from it when the results are read.
+type AMRHit struct {
+	// Gene is the allele name without the ResFinder variant suffix
+	// (blaOXA-23 for blaOXA-23_1).
+	Gene     string `json:"gene"`
+	Allele   string `json:"allele"`
+	Database string `json:"database"`
+	// Engine is the tool that reported the hit.
+	Engine    AMREngine `json:"engine,omitempty"`
+	Contig    string    `json:"contig,omitempty"`
+	Start     int       `json:"start,omitempty"`
+	End       int       `json:"end,omitempty"`
+	Strand    string    `json:"strand,omitempty"`
+	Coverage  float64   `json:"coverage,omitempty"`
+	Identity  float64   `json:"identity,omitempty"`
+	Accession string    `json:"accession,omitempty"`
+	Product   string    `json:"product,omitempty"`
+	// DrugClass is the RESISTANCE column of ABRicate and Phenotype the
+	// antibiotics listed for the gene in the ResFinder catalog. For
+	// AMRFinderPlus they are its Class and Subclass columns.
+	DrugClass string `json:"drug_class,omitempty"`
+	Phenotype string `json:"phenotype,omitempty"`
+	// DrugClasses, Antibiotics and PMIDs come from the ResFinder catalog.
+	DrugClasses []string `json:"drug_classes,omitempty"`
+	Antibiotics []string `json:"antibiotics,omitempty"`
+	PMIDs       []string `json:"pmids,omitempty"`
+}
+
+// parseAbricateHit reads an ABRicate report line with at least 11 columns.
+// database is used when the DATABASE column is missing.
+func parseAbricateHit(fields []string, database string) AMRHit {
+	column := func(i int) string {
+		if i >= len(fields) || fields[i] == "." {
+			return ""
+		}
+		return strings.TrimSpace(fields[i])
+	}
+
+	hit := AMRHit{
+		Gene:      strings.Split(column(5), "_")[0],
+		Allele:    column(5),
+		Database:  column(11),
+		Engine:    AMREngineAbricate,
+		Contig:    column(1),
+		Strand:    column(4),
+		Accession: column(12),
+		Product:   column(13),
+		DrugClass: column(14),
+	}
+	if hit.Database == "" {
+		hit.Database = database
+	}
+	hit.Start, _ = strconv.Atoi(column(2))
+	hit.End, _ = strconv.Atoi(column(3))
+	hit.Coverage, _ = strconv.ParseFloat(column(9), 64)
+	hit.Identity, _ = strconv.ParseFloat(column(10), 64)
+
+	return hit
+}
+
+func GetAbricateResult(filePath string, params AbricateParameters) (
+	[]string, error) {
 	file, err := os.Open(filePath)
 	if err != nil {
 		return nil, fmt.Errorf("Failed to open Abricate result: %v", err)
@@ -46,7 +108,8 @@ func GetAbricateResult(filePath string) ([]string, error) {
 
 		gene := fields[5]
 
-		if (coverage > 90.0 && identity > 90.0) ||
+		if (coverage > params.MinCoverage &&
+			identity > params.MinIdentity) ||
 			vanPattern.MatchString(gene) {
 			results = append(results, line)
 		}
@@ -60,72 +123,39 @@ func GetAbricateResult(filePath string) ([]string, error) {
 	return results, nil
 }
 
+// ProcessResfinder reads the ResFinder hits of ABRicate and adds the drug
+// classes, antibiotics and PMIDs of each gene in the phenotypes.txt catalog
+// at refCatalogPath.
 func ProcessResfinder(abricateResult []string, refCatalogPath string) (
-	[]string, error) {
-	var geneResults []string
-	var refList [][]string
-
-	refFile, err := os.Open(refCatalogPath)
+	[]AMRHit, error) {
+	catalog, err := LoadResfinderCatalog(refCatalogPath)
 	if err != nil {
-		return nil, fmt.Errorf(
-			"Failed to open Resfinder reference file:%v", err)
-	}
-	defer refFile.Close()
-
-	br := bufio.NewReader(refFile)
-	_, err = br.Peek(1)
-	if err == io.EOF {
-		return nil, errors.New("Empty Resfinder reference file")
-	}
-
-	refScanner := bufio.NewScanner(br)
-	for refScanner.Scan() {
-		line := strings.TrimSpace(refScanner.Text())
-		if line != "" {
-			refList = append(refList, strings.Split(line, "\t"))
-		}
+		return nil, err
 	}
 
+	var hits []AMRHit
 	for _, line := range abricateResult {
 		fields := strings.Split(line, "\t")
 		if len(fields) < 11 {
 			continue
 		}
 
-		gene := fields[5]
-		identity := fields[10]
-
-		nameGeneParts := strings.Split(gene, "_")
-		baseNameGene := nameGeneParts[0]
-
-		foundAntibiotic := false
-		for _, refItem := range refList {
-			if len(refItem) < 17 {
-				continue
-			}
-
-			if strings.Contains(strings.ToLower(refItem[0]),
-				strings.ToLower(baseNameGene)) {
-				antibioticName := strings.ToLower(refItem[len(refItem)-17])
-				geneResults = append(geneResults, fmt.Sprintf(
-					"%s (resistance to %s) (allele confidence %s)", gene,
-					antibioticName, identity))
-				foundAntibiotic = true
-				break
-			}
-		}
-
-		if !foundAntibiotic {
-			geneResults = append(geneResults, fmt.Sprintf(
-				"%s (allele confidence %s)", gene, identity))
+		hit := parseAbricateHit(fields, "resfinder")
+		if entry, ok := catalog.Lookup(hit.Allele, hit.Accession); ok {
+			hit.DrugClasses = entry.Classes
+			hit.Antibiotics = entry.Antibiotics
+			hit.PMIDs = entry.PMIDs
+			hit.Phenotype = strings.ToLower(
+				strings.Join(entry.Antibiotics, ", "))
 		}
+		hits = append(hits, hit)
 	}
 
-	return geneResults, nil
+	return hits, nil
 }
 
-func ProcessVFDB(abricateResult []string) []string {
-	var results []string
+func ProcessVFDB(abricateResult []string) []AMRHit {
+	var hits []AMRHit
 
 	for _, line := range abricateResult {
 		fields := strings.Split(line, "\t")
@@ -133,27 +163,23 @@ func ProcessVFDB(abricateResult []string) []string {
 			continue
 		}
 
-		results = append(results,
-			fmt.Sprintf("%s: %s (%s)",
-				fields[1], fields[5], fields[13]))
+		hits = append(hits, parseAbricateHit(fields, "vfdb"))
 	}
 
-	return results
+	return hits
 }
 
-func ProcessPlasmidFinder(abricateResult []string) []string {
-	var results []string
+func ProcessPlasmidFinder(abricateResult []string) []AMRHit {
+	var hits []AMRHit
 
 	for _, line := range abricateResult {
 		fields := strings.Split(line, "\t")
-		if len(fields) < 11 {
+		if len(fields) < 12 {
 			continue
 		}
 
-		results = append(results,
-			fmt.Sprintf("%s (IDENTITY: %s COVERAGE: %s DATABASE: %s)",
-				fields[5], fields[10], fields[9], fields[11]))
+		hits = append(hits, parseAbricateHit(fields, "plasmidfinder"))
 	}
 
-	return results
+	return hits
 }
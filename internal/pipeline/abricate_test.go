@@ -2,7 +2,6 @@ package pipeline
 
 import (
 	"os"
-	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -31,11 +30,13 @@ func buildAbricateLine(file, gene, database, accession, coverage, identity strin
 }
 
 func TestGetAbricateResult(t *testing.T) {
+	defaultAbricate := *DefaultAnalysisParameters().Abricate
+
 	t.Run("Success - High Coverage And Identity", func(t *testing.T) {
 		line := buildAbricateLine("seq1", "blaTEM", "resfinder", "AF123456", "95.5", "98.0")
 		path := createMockAbricateFile(t, line+"\n")
 
-		results, err := GetAbricateResult(path)
+		results, err := GetAbricateResult(path, defaultAbricate)
 		assert.NoError(t, err)
 		assert.Len(t, results, 1)
 		assert.Contains(t, results[0], "blaTEM")
@@ -45,7 +46,7 @@ func TestGetAbricateResult(t *testing.T) {
 		line := buildAbricateLine("seq1", "blaTEM", "resfinder", "AF123456", "80.0", "98.0")
 		path := createMockAbricateFile(t, line+"\n")
 
-		results, err := GetAbricateResult(path)
+		results, err := GetAbricateResult(path, defaultAbricate)
 		assert.NoError(t, err)
 		assert.Empty(t, results)
 	})
@@ -54,7 +55,7 @@ func TestGetAbricateResult(t *testing.T) {
 		line := buildAbricateLine("seq1", "blaTEM", "resfinder", "AF123456", "95.0", "85.0")
 		path := createMockAbricateFile(t, line+"\n")
 
-		results, err := GetAbricateResult(path)
+		results, err := GetAbricateResult(path, defaultAbricate)
 		assert.NoError(t, err)
 		assert.Empty(t, results)
 	})
@@ -63,7 +64,7 @@ func TestGetAbricateResult(t *testing.T) {
 		line := buildAbricateLine("seq1", "VanA", "resfinder", "AF123456", "50.0", "50.0")
 		path := createMockAbricateFile(t, line+"\n")
 
-		results, err := GetAbricateResult(path)
+		results, err := GetAbricateResult(path, defaultAbricate)
 		assert.NoError(t, err)
 		assert.Len(t, results, 1)
 		assert.Contains(t, results[0], "VanA")
@@ -73,7 +74,7 @@ func TestGetAbricateResult(t *testing.T) {
 		line := buildAbricateLine("seq1", "vanB", "resfinder", "AF123456", "50.0", "50.0")
 		path := createMockAbricateFile(t, line+"\n")
 
-		results, err := GetAbricateResult(path)
+		results, err := GetAbricateResult(path, defaultAbricate)
 		assert.NoError(t, err)
 		assert.Len(t, results, 1)
 		assert.Contains(t, results[0], "vanB")
@@ -85,17 +86,28 @@ func TestGetAbricateResult(t *testing.T) {
 		line3 := buildAbricateLine("seq3", "VanC", "resfinder", "AF789", "60.0", "60.0")
 		path := createMockAbricateFile(t, line1+"\n"+line2+"\n"+line3+"\n")
 
-		results, err := GetAbricateResult(path)
+		results, err := GetAbricateResult(path, defaultAbricate)
 		assert.NoError(t, err)
 		assert.Len(t, results, 2)
 	})
 
+	t.Run("Success - Custom Thresholds", func(t *testing.T) {
+		line := buildAbricateLine("seq1", "blaTEM", "resfinder", "AF123456", "80.0", "85.0")
+		path := createMockAbricateFile(t, line+"\n")
+
+		results, err := GetAbricateResult(path, AbricateParameters{
+			MinCoverage: 75, MinIdentity: 80,
+		})
+		assert.NoError(t, err)
+		assert.Len(t, results, 1)
+	})
+
 	t.Run("Success - Lines With Fewer Than 11 Fields Skipped", func(t *testing.T) {
 		shortLine := "seq1\t100\t200\t+\t100/100\tblaTEM"
 		highLine := buildAbricateLine("seq2", "blaCTX", "resfinder", "AF456", "95.0", "98.0")
 		path := createMockAbricateFile(t, shortLine+"\n"+highLine+"\n")
 
-		results, err := GetAbricateResult(path)
+		results, err := GetAbricateResult(path, defaultAbricate)
 		assert.NoError(t, err)
 		assert.Len(t, results, 1)
 		assert.Contains(t, results[0], "blaCTX")
@@ -105,7 +117,7 @@ func TestGetAbricateResult(t *testing.T) {
 		line := "seq1\t100\t200\t+\t100/100\tblaTEM\tresfinder\tAF123\t0\tnotanumber\tnotanumber"
 		path := createMockAbricateFile(t, line+"\n")
 
-		results, err := GetAbricateResult(path)
+		results, err := GetAbricateResult(path, defaultAbricate)
 		assert.NoError(t, err)
 		assert.Empty(t, results)
 	})
@@ -113,14 +125,14 @@ func TestGetAbricateResult(t *testing.T) {
 	t.Run("Success - Empty File", func(t *testing.T) {
 		path := createMockAbricateFile(t, "")
 
-		results, err := GetAbricateResult(path)
+		results, err := GetAbricateResult(path, defaultAbricate)
 		assert.Error(t, err)
 		assert.Nil(t, results)
 		assert.Contains(t, err.Error(), "Empty Abricate result")
 	})
 
 	t.Run("Error - File Not Found", func(t *testing.T) {
-		results, err := GetAbricateResult("nonexistent.txt")
+		results, err := GetAbricateResult("nonexistent.txt", defaultAbricate)
 		assert.Error(t, err)
 		assert.Nil(t, results)
 		assert.Contains(t, err.Error(), "Failed to open Abricate result")
@@ -128,88 +140,64 @@ func TestGetAbricateResult(t *testing.T) {
 }
 
 func TestProcessResfinder(t *testing.T) {
-	// refCatalog format: TSV with at least 17 columns, last column is antibiotic name
-	// refItem[0] = gene name in DB, refItem[len-17] = antibiotic
-	// NOTE: ProcessResfinder uses bufio.NewReader.Peek to check for empty file,
-	// which consumes data from the underlying refFile via its internal buffer.
-	// Then bufio.NewScanner reads from refFile's advanced position.
-	// To ensure the scanner reads the gene data, we pad a header line > 4096 bytes
-	// so br.Peek consumes the header, and the scanner reads the gene lines.
-	buildRefLine := func(gene, antibiotic string) string {
-		// The code uses refItem[len(refItem)-17] as the antibiotic name.
-		// The reference file is read with strings.TrimSpace which strips trailing tabs,
-		// so we need a non-empty last field to preserve the column count.
-		// With 34 columns and a non-empty last field, after TrimSpace we still have 34 fields.
-		// refItem[34-17] = refItem[17] = antibiotic.
-		cols := make([]string, 34)
-		cols[0] = gene
-		cols[17] = antibiotic
-		cols[33] = "placeholder"
-		result := ""
-		for i, c := range cols {
-			if i > 0 {
-				result += "\t"
-			}
-			result += c
-		}
-		return result
-	}
-
-	buildRefContent := func(gene, antibiotic string) string {
-		// Pad header line to > 4096 bytes so br.Peek consumes it entirely
-		header := strings.Repeat("P", 10000) + "\n"
-		return header + buildRefLine(gene, antibiotic) + "\n"
-	}
+	catalog := resfinderCatalogHeader + "\n" +
+		"blaTEM-1B_1_AY458016\tBeta-lactam\tAmoxicillin, Ampicillin\t" +
+		"16023012\tAntibiotic inactivation\t\t\n" +
+		"oqxA_1_EU370913\tQuinolone\tCiprofloxacin\t18440636\t" +
+		"Antibiotic efflux\t\t\n"
 
-	t.Run("Success - Gene Found In Reference", func(t *testing.T) {
-		refContent := buildRefContent("blaTEM", "Ampicillin")
-		refPath := createMockAbricateFile(t, refContent)
+	t.Run("Success - Allele And Accession Found In Catalog", func(t *testing.T) {
+		refPath := createMockAbricateFile(t, catalog)
 
 		abricateResult := []string{
-			buildAbricateLine("seq1", "blaTEM", "resfinder", "AF123", "95.0", "98.0"),
+			buildAbricateLine("seq1", "blaTEM-1B_1", "resfinder", "AY458016", "95.0", "98.0"),
 		}
 
 		geneResults, err := ProcessResfinder(abricateResult, refPath)
 		assert.NoError(t, err)
-		assert.Len(t, geneResults, 1)
-		assert.Contains(t, geneResults[0], "blaTEM")
-		assert.Contains(t, geneResults[0], "resistance to ampicillin")
-		assert.Contains(t, geneResults[0], "allele confidence 98.0")
+		assert.Equal(t, []AMRHit{{
+			Gene: "blaTEM-1B", Allele: "blaTEM-1B_1", Database: "resfinder",
+			Engine: AMREngineAbricate,
+			Contig: "seq1", Start: 100, End: 200, Strand: "+",
+			Coverage: 95, Identity: 98, Accession: "AY458016",
+			Product: "product", Phenotype: "amoxicillin, ampicillin",
+			DrugClasses: []string{"Beta-lactam"},
+			Antibiotics: []string{"Amoxicillin", "Ampicillin"},
+			PMIDs:       []string{"16023012"},
+		}}, geneResults)
 	})
 
-	t.Run("Success - Gene Not Found In Reference", func(t *testing.T) {
-		refContent := buildRefContent("otherGene", "Other")
-		refPath := createMockAbricateFile(t, refContent)
+	t.Run("Success - Gene Found By Base Name", func(t *testing.T) {
+		refPath := createMockAbricateFile(t, catalog)
 
 		abricateResult := []string{
-			buildAbricateLine("seq1", "blaTEM", "resfinder", "AF123", "95.0", "98.0"),
+			buildAbricateLine("seq1", "oqxA_2", "resfinder", "OTHER1", "95.0", "98.0"),
 		}
 
 		geneResults, err := ProcessResfinder(abricateResult, refPath)
 		assert.NoError(t, err)
 		assert.Len(t, geneResults, 1)
-		assert.Contains(t, geneResults[0], "blaTEM")
-		assert.Contains(t, geneResults[0], "allele confidence 98.0")
-		assert.NotContains(t, geneResults[0], "resistance")
+		assert.Equal(t, "ciprofloxacin", geneResults[0].Phenotype)
+		assert.Equal(t, []string{"Quinolone"}, geneResults[0].DrugClasses)
 	})
 
-	t.Run("Success - Gene With Underscore Split", func(t *testing.T) {
-		refContent := buildRefContent("blaTEM", "Ampicillin")
-		refPath := createMockAbricateFile(t, refContent)
+	t.Run("Success - No Prefix Match", func(t *testing.T) {
+		refPath := createMockAbricateFile(t, catalog)
 
 		abricateResult := []string{
-			buildAbricateLine("seq1", "blaTEM_extra", "resfinder", "AF123", "95.0", "98.0"),
+			buildAbricateLine("seq1", "oqxA10_1", "resfinder", "AF123", "95.0", "98.0"),
+			buildAbricateLine("seq1", "blaTEM", "resfinder", "AF123", "95.0", "98.0"),
 		}
 
 		geneResults, err := ProcessResfinder(abricateResult, refPath)
 		assert.NoError(t, err)
-		assert.Len(t, geneResults, 1)
-		assert.Contains(t, geneResults[0], "resistance to ampicillin")
+		assert.Len(t, geneResults, 2)
+		assert.Empty(t, geneResults[0].Phenotype)
+		assert.Empty(t, geneResults[1].Antibiotics)
 	})
 
 	t.Run("Success - Empty Abricate Result", func(t *testing.T) {
-		refContent := buildRefContent("blaTEM", "Ampicillin")
-		refPath := createMockAbricateFile(t, refContent)
+		refPath := createMockAbricateFile(t, catalog)
 
 		geneResults, err := ProcessResfinder([]string{}, refPath)
 		assert.NoError(t, err)
@@ -217,8 +205,7 @@ func TestProcessResfinder(t *testing.T) {
 	})
 
 	t.Run("Success - Lines With Fewer Than 11 Fields Skipped", func(t *testing.T) {
-		refContent := buildRefContent("blaTEM", "Ampicillin")
-		refPath := createMockAbricateFile(t, refContent)
+		refPath := createMockAbricateFile(t, catalog)
 
 		abricateResult := []string{
 			"seq1\t100\t200\t+\t100/100\tblaTEM",
@@ -253,10 +240,9 @@ func TestProcessResfinder(t *testing.T) {
 		assert.Contains(t, err.Error(), "Failed to open Resfinder reference file")
 	})
 
-	t.Run("Success - Reference Line With Fewer Than 17 Columns Skipped", func(t *testing.T) {
-		header := strings.Repeat("P", 10000) + "\n"
-		shortRef := "blaTEM\tshort"
-		refPath := createMockAbricateFile(t, header+shortRef+"\n")
+	t.Run("Success - Unparsable Catalog Line Skipped", func(t *testing.T) {
+		refPath := createMockAbricateFile(t,
+			resfinderCatalogHeader+"\nblaTEM\tshort\n")
 
 		abricateResult := []string{
 			buildAbricateLine("seq1", "blaTEM", "resfinder", "AF123", "95.0", "98.0"),
@@ -265,8 +251,7 @@ func TestProcessResfinder(t *testing.T) {
 		geneResults, err := ProcessResfinder(abricateResult, refPath)
 		assert.NoError(t, err)
 		assert.Len(t, geneResults, 1)
-		assert.Contains(t, geneResults[0], "allele confidence")
-		assert.NotContains(t, geneResults[0], "resistance")
+		assert.Empty(t, geneResults[0].Phenotype)
 	})
 }
 
@@ -301,7 +286,12 @@ func TestProcessVFDB(t *testing.T) {
 		line := buildVFDBLine("VF0001", "virB4", "TypeIV secretion", "95.0", "98.0", "vfdb")
 		results := ProcessVFDB([]string{line})
 		assert.Len(t, results, 1)
-		assert.Equal(t, "VF0001: virB4 (TypeIV secretion)", results[0])
+		assert.Equal(t, AMRHit{
+			Gene: "virB4", Allele: "virB4", Database: "vfdb",
+			Engine: AMREngineAbricate,
+			Contig: "VF0001", Coverage: 95, Identity: 98,
+			Product: "TypeIV secretion",
+		}, results[0])
 	})
 
 	t.Run("Success - Multiple Lines", func(t *testing.T) {
@@ -329,10 +319,11 @@ func TestProcessPlasmidFinder(t *testing.T) {
 		line := buildAbricateLine("seq1", "repB", "plasmidfinder", "AF123", "95.0", "98.0")
 		results := ProcessPlasmidFinder([]string{line})
 		assert.Len(t, results, 1)
-		assert.Contains(t, results[0], "repB")
-		assert.Contains(t, results[0], "IDENTITY: 98.0")
-		assert.Contains(t, results[0], "COVERAGE: 95.0")
-		assert.Contains(t, results[0], "DATABASE: plasmidfinder")
+		assert.Equal(t, "repB", results[0].Gene)
+		assert.Equal(t, 98.0, results[0].Identity)
+		assert.Equal(t, 95.0, results[0].Coverage)
+		assert.Equal(t, "plasmidfinder", results[0].Database)
+		assert.Equal(t, "AF123", results[0].Accession)
 	})
 
 	t.Run("Success - Multiple Lines", func(t *testing.T) {
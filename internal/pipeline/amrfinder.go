@@ -0,0 +1,175 @@
+package pipeline
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// AMREngine is the tool detecting acquired resistance genes.
+type AMREngine string
+
+const (
+	AMREngineAbricate      AMREngine = "abricate"
+	AMREngineAMRFinderPlus AMREngine = "amrfinderplus"
+)
+
+// ParseAMREngine reads an engine name; an empty name selects ABRicate.
+func ParseAMREngine(name string) (AMREngine, error) {
+	switch engine := AMREngine(strings.ToLower(strings.TrimSpace(name))); engine {
+	case "":
+		return AMREngineAbricate, nil
+	case AMREngineAbricate, AMREngineAMRFinderPlus:
+		return engine, nil
+	default:
+		return "", fmt.Errorf("unknown AMR engine %q", name)
+	}
+}
+
+// amrfinderColumns maps the report columns to their names in AMRFinderPlus
+// 3.x and 4.x.
+var amrfinderColumns = map[string][]string{
+	"contig":    {"Contig id"},
+	"start":     {"Start"},
+	"end":       {"Stop"},
+	"strand":    {"Strand"},
+	"symbol":    {"Element symbol", "Gene symbol"},
+	"name":      {"Element name", "Sequence name"},
+	"type":      {"Type", "Element type"},
+	"subtype":   {"Subtype", "Element subtype"},
+	"class":     {"Class"},
+	"subclass":  {"Subclass"},
+	"coverage":  {"% Coverage of reference", "% Coverage of reference sequence"},
+	"identity":  {"% Identity to reference", "% Identity to reference sequence"},
+	"accession": {"Closest reference accession", "Accession of closest sequence"},
+}
+
+// AMRFinderResult holds the AMR elements of an AMRFinderPlus report: the
+// acquired genes and the point mutations, reported only when amrfinder ran
+// with --organism.
+type AMRFinderResult struct {
+	Genes          []AMRHit
+	PointMutations []AMRHit
+}
+
+// ParseAMRFinder reads an AMRFinderPlus report. Elements other than AMR
+// (stress and virulence, reported with --plus) are left out. A point
+// mutation keeps the gene in Gene and the mutation in Allele (gyrA and
+// gyrA_S83L).
+func ParseAMRFinder(r io.Reader) (*AMRFinderResult, error) {
+	scanner := bufio.NewScanner(r)
+
+	const maxCapacity = 1024 * 1024
+	buf := make([]byte, maxCapacity)
+	scanner.Buffer(buf, maxCapacity)
+
+	if !scanner.Scan() {
+		if err := scanner.Err(); err != nil {
+			return nil, fmt.Errorf(
+				"An error occurred while reading the file: %v", err)
+		}
+		return nil, errors.New("Empty AMRFinderPlus result")
+	}
+
+	header := strings.Split(strings.TrimRight(scanner.Text(), "\r\n"), "\t")
+	index := map[string]int{}
+	for key, names := range amrfinderColumns {
+		for i, column := range header {
+			if slicesContainsFold(names, column) {
+				index[key] = i
+				break
+			}
+		}
+	}
+	for _, key := range []string{"contig", "symbol", "type", "subtype"} {
+		if _, ok := index[key]; !ok {
+			return nil, fmt.Errorf(
+				"invalid AMRFinderPlus header: missing %s column",
+				amrfinderColumns[key][0])
+		}
+	}
+
+	result := &AMRFinderResult{Genes: []AMRHit{}, PointMutations: []AMRHit{}}
+	lineNumber := 1
+	for scanner.Scan() {
+		lineNumber++
+		line := strings.TrimRight(scanner.Text(), "\r\n")
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		fields := strings.Split(line, "\t")
+		if len(fields) != len(header) {
+			return nil, fmt.Errorf(
+				"invalid AMRFinderPlus record at line %d: expected %d "+
+					"columns, got %d", lineNumber, len(header), len(fields))
+		}
+
+		column := func(key string) string {
+			i, ok := index[key]
+			if !ok || fields[i] == "NA" {
+				return ""
+			}
+			return strings.TrimSpace(fields[i])
+		}
+		if !strings.EqualFold(column("type"), "AMR") {
+			continue
+		}
+
+		hit := AMRHit{
+			Gene:      column("symbol"),
+			Allele:    column("symbol"),
+			Database:  string(AMREngineAMRFinderPlus),
+			Engine:    AMREngineAMRFinderPlus,
+			Contig:    column("contig"),
+			Strand:    column("strand"),
+			Accession: column("accession"),
+			Product:   column("name"),
+			DrugClass: column("class"),
+			Phenotype: strings.ToLower(column("subclass")),
+		}
+		hit.Start, _ = strconv.Atoi(column("start"))
+		hit.End, _ = strconv.Atoi(column("end"))
+		hit.Coverage, _ = strconv.ParseFloat(column("coverage"), 64)
+		hit.Identity, _ = strconv.ParseFloat(column("identity"), 64)
+
+		if strings.EqualFold(column("subtype"), "POINT") {
+			if gene, _, ok := strings.Cut(hit.Allele, "_"); ok {
+				hit.Gene = gene
+			}
+			result.PointMutations = append(result.PointMutations, hit)
+			continue
+		}
+		result.Genes = append(result.Genes, hit)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf(
+			"An error occurred while reading the file: %v", err)
+	}
+
+	return result, nil
+}
+
+// GetAMRFinderResult parses the AMRFinderPlus report at filePath.
+func GetAMRFinderResult(filePath string) (*AMRFinderResult, error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to open AMRFinderPlus result: %v", err)
+	}
+	defer file.Close()
+
+	return ParseAMRFinder(file)
+}
+
+func slicesContainsFold(values []string, value string) bool {
+	for _, v := range values {
+		if strings.EqualFold(v, strings.TrimSpace(value)) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,166 @@
+package pipeline
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const amrfinderHeaderV4 = "Protein id\tContig id\tStart\tStop\tStrand\t" +
+	"Element symbol\tElement name\tScope\tType\tSubtype\tClass\tSubclass\t" +
+	"Method\tTarget length\tReference sequence length\t" +
+	"% Coverage of reference\t% Identity to reference\tAlignment length\t" +
+	"Closest reference accession\tClosest reference name\tHMM accession\t" +
+	"HMM description"
+
+const amrfinderHeaderV3 = "Protein identifier\tContig id\tStart\tStop\t" +
+	"Strand\tGene symbol\tSequence name\tScope\tElement type\t" +
+	"Element subtype\tClass\tSubclass\tMethod\tTarget length\t" +
+	"Reference sequence length\t% Coverage of reference sequence\t" +
+	"% Identity to reference sequence\tAlignment length\t" +
+	"Accession of closest sequence\tName of closest sequence\tHMM id\t" +
+	"HMM description"
+
+func amrfinderRecord(contig, symbol, name, elementType, subtype, class,
+	subclass, coverage, identity, accession string) string {
+	return strings.Join([]string{
+		"NA", contig, "101", "925", "+", symbol, name, "core", elementType,
+		subtype, class, subclass, "EXACTX", "275", "275", coverage, identity,
+		"275", accession, name, "NA", "NA",
+	}, "\t")
+}
+
+func TestParseAMRFinder(t *testing.T) {
+	records := []string{
+		amrfinderRecord("contig_1", "blaOXA-23",
+			"carbapenem-hydrolyzing class D beta-lactamase OXA-23", "AMR",
+			"AMR", "BETA-LACTAM", "CARBAPENEM", "100.00", "100.00",
+			"WP_001046004.1"),
+		amrfinderRecord("contig_2", "gyrA_S83L", "Escherichia coli gyrA",
+			"AMR", "POINT", "QUINOLONE", "QUINOLONE", "NA", "NA",
+			"WP_000072067.1"),
+		amrfinderRecord("contig_3", "fimH", "type 1 fimbrial adhesin",
+			"VIRULENCE", "VIRULENCE", "NA", "NA", "98.10", "99.00",
+			"WP_000832295.1"),
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		report := amrfinderHeaderV4 + "\n" + strings.Join(records, "\n") +
+			"\n"
+
+		result, err := ParseAMRFinder(strings.NewReader(report))
+
+		assert.NoError(t, err)
+		assert.Equal(t, []AMRHit{{
+			Gene: "blaOXA-23", Allele: "blaOXA-23",
+			Database: "amrfinderplus", Engine: AMREngineAMRFinderPlus,
+			Contig: "contig_1", Start: 101, End: 925, Strand: "+",
+			Coverage: 100, Identity: 100, Accession: "WP_001046004.1",
+			Product:   "carbapenem-hydrolyzing class D beta-lactamase OXA-23",
+			DrugClass: "BETA-LACTAM", Phenotype: "carbapenem",
+		}}, result.Genes)
+		assert.Len(t, result.PointMutations, 1)
+		assert.Equal(t, "gyrA", result.PointMutations[0].Gene)
+		assert.Equal(t, "gyrA_S83L", result.PointMutations[0].Allele)
+		assert.Equal(t, "quinolone", result.PointMutations[0].Phenotype)
+		assert.Zero(t, result.PointMutations[0].Coverage)
+	})
+
+	t.Run("Success - Version 3 Header", func(t *testing.T) {
+		report := amrfinderHeaderV3 + "\n" + records[0] + "\n"
+
+		result, err := ParseAMRFinder(strings.NewReader(report))
+
+		assert.NoError(t, err)
+		assert.Len(t, result.Genes, 1)
+		assert.Equal(t, "blaOXA-23", result.Genes[0].Gene)
+		assert.Equal(t, "WP_001046004.1", result.Genes[0].Accession)
+		assert.Empty(t, result.PointMutations)
+	})
+
+	t.Run("Success - No Hits", func(t *testing.T) {
+		result, err := ParseAMRFinder(strings.NewReader(
+			amrfinderHeaderV4 + "\n"))
+
+		assert.NoError(t, err)
+		assert.Empty(t, result.Genes)
+		assert.Empty(t, result.PointMutations)
+	})
+
+	t.Run("Error - Empty Report", func(t *testing.T) {
+		_, err := ParseAMRFinder(strings.NewReader(""))
+		assert.Error(t, err)
+	})
+
+	t.Run("Error - Unknown Header", func(t *testing.T) {
+		_, err := ParseAMRFinder(strings.NewReader("GENE\tCOVERAGE\n"))
+		assert.ErrorContains(t, err, "missing Contig id column")
+	})
+
+	t.Run("Error - Short Record", func(t *testing.T) {
+		_, err := ParseAMRFinder(strings.NewReader(
+			amrfinderHeaderV4 + "\ncontig_1\tblaOXA-23\n"))
+		assert.ErrorContains(t, err, "line 2")
+	})
+}
+
+func TestParseAMREngine(t *testing.T) {
+	engine, err := ParseAMREngine("")
+	assert.NoError(t, err)
+	assert.Equal(t, AMREngineAbricate, engine)
+
+	engine, err = ParseAMREngine(" AMRFinderPlus ")
+	assert.NoError(t, err)
+	assert.Equal(t, AMREngineAMRFinderPlus, engine)
+
+	_, err = ParseAMREngine("rgi")
+	assert.Error(t, err)
+}
+
+func TestAMREngineFor(t *testing.T) {
+	registry := &SpeciesRegistry{Profiles: []SpeciesProfile{
+		{
+			Name:              "Klebsiella pneumoniae",
+			KrakenMatchers:    []string{"Klebsiella pneumoniae"},
+			AMREngine:         "amrfinderplus",
+			AMRFinderOrganism: "Klebsiella_pneumoniae",
+		},
+		{
+			Name:              "Escherichia coli",
+			KrakenMatchers:    []string{"Escherichia coli"},
+			AMRFinderOrganism: "Escherichia",
+		},
+	}}
+
+	t.Run("Species Profile Engine", func(t *testing.T) {
+		config := &ToolsConfig{Species: registry}
+
+		engine, organism := config.AMREngineFor("Klebsiella pneumoniae")
+
+		assert.Equal(t, AMREngineAMRFinderPlus, engine)
+		assert.Equal(t, "Klebsiella_pneumoniae", organism)
+	})
+
+	t.Run("Deployment Engine", func(t *testing.T) {
+		config := &ToolsConfig{
+			Species: registry, AMREngine: AMREngineAMRFinderPlus,
+		}
+
+		engine, organism := config.AMREngineFor("Escherichia coli")
+		assert.Equal(t, AMREngineAMRFinderPlus, engine)
+		assert.Equal(t, "Escherichia", organism)
+
+		engine, organism = config.AMREngineFor("Salmonella enterica")
+		assert.Equal(t, AMREngineAMRFinderPlus, engine)
+		assert.Empty(t, organism)
+	})
+
+	t.Run("Default Engine", func(t *testing.T) {
+		config := &ToolsConfig{Species: registry}
+
+		engine, _ := config.AMREngineFor("Escherichia coli")
+
+		assert.Equal(t, AMREngineAbricate, engine)
+	})
+}
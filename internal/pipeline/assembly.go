@@ -0,0 +1,121 @@
+package pipeline
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+)
+
+// AssemblyStats describes the contigs of an assembly. GCPercent is computed
+// over the A, C, G and T bases, so Ns do not lower it.
+type AssemblyStats struct {
+	Contigs       int     `json:"contigs"`
+	TotalLength   int64   `json:"total_length"`
+	N50           int64   `json:"n50"`
+	L50           int     `json:"l50"`
+	N90           int64   `json:"n90"`
+	GCPercent     float64 `json:"gc_percent"`
+	LongestContig int64   `json:"longest_contig"`
+	NCount        int64   `json:"n_count"`
+}
+
+// ComputeAssemblyStats reads a FASTA assembly.
+func ComputeAssemblyStats(r io.Reader) (*AssemblyStats, error) {
+	scanner := bufio.NewScanner(r)
+
+	const maxCapacity = 1024 * 1024 * 4
+	buf := make([]byte, maxCapacity)
+	scanner.Buffer(buf, maxCapacity)
+
+	var lengths []int64
+	var gc, acgt, nCount int64
+	lineNumber := 0
+	for scanner.Scan() {
+		lineNumber++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+
+		if strings.HasPrefix(line, ">") {
+			lengths = append(lengths, 0)
+			continue
+		}
+		if len(lengths) == 0 {
+			return nil, fmt.Errorf("%w: sequence before the first header "+
+				"at line %d", ErrInvalidFormat, lineNumber)
+		}
+
+		for i := 0; i < len(line); i++ {
+			switch line[i] {
+			case 'G', 'C', 'g', 'c':
+				gc++
+				acgt++
+			case 'A', 'T', 'a', 't':
+				acgt++
+			case 'N', 'n':
+				nCount++
+			}
+		}
+		lengths[len(lengths)-1] += int64(len(line))
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrCorruptedInput, err)
+	}
+	if len(lengths) == 0 {
+		return nil, fmt.Errorf("%w: no FASTA records", ErrInvalidFormat)
+	}
+
+	stats := &AssemblyStats{Contigs: len(lengths), NCount: nCount}
+	for _, length := range lengths {
+		stats.TotalLength += length
+	}
+	if acgt > 0 {
+		stats.GCPercent = float64(gc) / float64(acgt) * 100
+	}
+
+	slices.SortFunc(lengths, func(a, b int64) int {
+		switch {
+		case a > b:
+			return -1
+		case a < b:
+			return 1
+		default:
+			return 0
+		}
+	})
+	stats.LongestContig = lengths[0]
+
+	var cumulative int64
+	for i, length := range lengths {
+		cumulative += length
+		if stats.N50 == 0 && cumulative*2 >= stats.TotalLength {
+			stats.N50 = length
+			stats.L50 = i + 1
+		}
+		if cumulative*10 >= stats.TotalLength*9 {
+			stats.N90 = length
+			break
+		}
+	}
+
+	return stats, nil
+}
+
+// GetAssemblyStats computes the statistics of a FASTA file, gzipped or not.
+func GetAssemblyStats(filePath string) (*AssemblyStats, error) {
+	reader, err := openSequenceFile(filePath)
+	if errors.Is(err, errEmptyFile) {
+		return nil, fmt.Errorf("%w: empty assembly", ErrInvalidFormat)
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+
+	return ComputeAssemblyStats(reader)
+}
@@ -0,0 +1,90 @@
+package pipeline
+
+import (
+	"compress/gzip"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestComputeAssemblyStats(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		// Contigs of 50, 30, 10, 6 and 4 bases: 100 in total.
+		fasta := ">contig_1\n" + strings.Repeat("GC", 15) +
+			strings.Repeat("AT", 10) + "\n" +
+			">contig_2 length=30\n" + strings.Repeat("ACGT", 5) + "\n" +
+			strings.Repeat("NNNNN", 2) + "\n" +
+			">contig_3\n" + strings.Repeat("a", 10) + "\n\n" +
+			">contig_4\nACGTAC\n" +
+			">contig_5\nGGCC\n"
+
+		stats, err := ComputeAssemblyStats(strings.NewReader(fasta))
+
+		assert.NoError(t, err)
+		assert.Equal(t, 5, stats.Contigs)
+		assert.Equal(t, int64(100), stats.TotalLength)
+		assert.Equal(t, int64(50), stats.N50)
+		assert.Equal(t, 1, stats.L50)
+		assert.Equal(t, int64(10), stats.N90)
+		assert.Equal(t, int64(50), stats.LongestContig)
+		assert.Equal(t, int64(10), stats.NCount)
+		// 30 + 10 + 0 + 3 + 4 GC bases out of 90 A/C/G/T.
+		assert.InDelta(t, 47.0/90*100, stats.GCPercent, 1e-9)
+	})
+
+	t.Run("Success - L50 Over Several Contigs", func(t *testing.T) {
+		fasta := ">a\nAAAA\n>b\nAAAA\n>c\nAAAA\n>d\nAAAA\n"
+
+		stats, err := ComputeAssemblyStats(strings.NewReader(fasta))
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(4), stats.N50)
+		assert.Equal(t, 2, stats.L50)
+		assert.Zero(t, stats.GCPercent)
+	})
+
+	t.Run("Error - Sequence Before Header", func(t *testing.T) {
+		_, err := ComputeAssemblyStats(strings.NewReader("ACGT\n>a\nACGT\n"))
+		assert.ErrorIs(t, err, ErrInvalidFormat)
+	})
+
+	t.Run("Error - No Records", func(t *testing.T) {
+		_, err := ComputeAssemblyStats(strings.NewReader("\n\n"))
+		assert.ErrorIs(t, err, ErrInvalidFormat)
+	})
+}
+
+func TestGetAssemblyStats(t *testing.T) {
+	t.Run("Success - Gzipped", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "assembly.fasta.gz")
+		file, err := os.Create(path)
+		assert.NoError(t, err)
+		writer := gzip.NewWriter(file)
+		_, err = writer.Write([]byte(">a\nACGTACGT\n>b\nACGT\n"))
+		assert.NoError(t, err)
+		assert.NoError(t, writer.Close())
+		assert.NoError(t, file.Close())
+
+		stats, err := GetAssemblyStats(path)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 2, stats.Contigs)
+		assert.Equal(t, int64(12), stats.TotalLength)
+	})
+
+	t.Run("Error - Empty File", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "assembly.fasta")
+		assert.NoError(t, os.WriteFile(path, nil, 0644))
+
+		_, err := GetAssemblyStats(path)
+		assert.ErrorIs(t, err, ErrInvalidFormat)
+	})
+
+	t.Run("Error - File Not Found", func(t *testing.T) {
+		_, err := GetAssemblyStats(filepath.Join(t.TempDir(), "missing.fa"))
+		assert.ErrorIs(t, err, ErrFileNotFound)
+	})
+}
@@ -0,0 +1,113 @@
+package pipeline
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+const checkpointDirName = ".checkpoints"
+
+// CheckpointStore keeps one checkpoint per pipeline step inside the analysis
+// folder. A checkpoint is valid when its completion marker exists, its
+// outputs can be decoded and every file it references is still on disk.
+type CheckpointStore struct {
+	Dir string
+}
+
+type checkpoint struct {
+	Step        string          `json:"step"`
+	Files       []string        `json:"files,omitempty"`
+	Outputs     json.RawMessage `json:"outputs,omitempty"`
+	CompletedAt time.Time       `json:"completed_at"`
+}
+
+func NewCheckpointStore(analysisDir string) *CheckpointStore {
+	return &CheckpointStore{Dir: filepath.Join(analysisDir, checkpointDirName)}
+}
+
+func (c *CheckpointStore) dataPath(step string) string {
+	return filepath.Join(c.Dir, step+".json")
+}
+
+func (c *CheckpointStore) markerPath(step string) string {
+	return filepath.Join(c.Dir, step+".done")
+}
+
+// Save writes the step outputs and then its completion marker, so a crash
+// between the two never leaves a checkpoint that looks complete.
+func (c *CheckpointStore) Save(step string, outputs any,
+	files ...string) error {
+	if err := os.MkdirAll(c.Dir, 0755); err != nil {
+		return fmt.Errorf("failed to create checkpoint dir: %w", err)
+	}
+
+	rawOutputs, err := json.Marshal(outputs)
+	if err != nil {
+		return fmt.Errorf("failed to marshal %s outputs: %w", step, err)
+	}
+
+	data, err := json.Marshal(checkpoint{
+		Step:        step,
+		Files:       files,
+		Outputs:     rawOutputs,
+		CompletedAt: time.Now(),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal %s checkpoint: %w", step, err)
+	}
+
+	_ = os.Remove(c.markerPath(step))
+
+	tmpPath := c.dataPath(step) + ".tmp"
+	if err := os.WriteFile(tmpPath, data, 0644); err != nil {
+		return fmt.Errorf("failed to write %s checkpoint: %w", step, err)
+	}
+	if err := os.Rename(tmpPath, c.dataPath(step)); err != nil {
+		return fmt.Errorf("failed to write %s checkpoint: %w", step, err)
+	}
+
+	if err := os.WriteFile(c.markerPath(step), nil, 0644); err != nil {
+		return fmt.Errorf("failed to write %s marker: %w", step, err)
+	}
+
+	return nil
+}
+
+// Load decodes the step outputs into dst and reports whether the checkpoint
+// is valid. dst must be discarded when it returns false.
+func (c *CheckpointStore) Load(step string, dst any) bool {
+	if _, err := os.Stat(c.markerPath(step)); err != nil {
+		return false
+	}
+
+	data, err := os.ReadFile(c.dataPath(step))
+	if err != nil {
+		return false
+	}
+
+	var cp checkpoint
+	if err := json.Unmarshal(data, &cp); err != nil || cp.Step != step {
+		return false
+	}
+
+	for _, file := range cp.Files {
+		if _, err := os.Stat(file); err != nil {
+			return false
+		}
+	}
+
+	if dst != nil && len(cp.Outputs) > 0 {
+		if err := json.Unmarshal(cp.Outputs, dst); err != nil {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (c *CheckpointStore) Clear() error {
+	return os.RemoveAll(c.Dir)
+}
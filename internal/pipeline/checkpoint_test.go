@@ -0,0 +1,79 @@
+package pipeline
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckpointStore(t *testing.T) {
+	t.Run("Success - Save And Load", func(t *testing.T) {
+		dir := t.TempDir()
+		output := filepath.Join(dir, "assembly.fasta")
+		assert.NoError(t, os.WriteFile(output, []byte(">seq\nATCG\n"), 0644))
+
+		store := NewCheckpointStore(dir)
+		err := store.Save("CheckM", &CheckMResult{Completeness: "98.0",
+			N50: "1000"}, output)
+		assert.NoError(t, err)
+
+		var result *CheckMResult
+		assert.True(t, store.Load("CheckM", &result))
+		assert.Equal(t, "98.0", result.Completeness)
+		assert.Equal(t, "1000", result.N50)
+	})
+
+	t.Run("Success - Nil Outputs", func(t *testing.T) {
+		store := NewCheckpointStore(t.TempDir())
+		assert.NoError(t, store.Save("Prokka", nil))
+		assert.True(t, store.Load("Prokka", nil))
+	})
+
+	t.Run("Error - Missing Checkpoint", func(t *testing.T) {
+		store := NewCheckpointStore(t.TempDir())
+		var result string
+		assert.False(t, store.Load("Unicycler", &result))
+	})
+
+	t.Run("Error - Missing Marker", func(t *testing.T) {
+		store := NewCheckpointStore(t.TempDir())
+		assert.NoError(t, store.Save("Kraken2", "x"))
+		assert.NoError(t, os.Remove(store.markerPath("Kraken2")))
+
+		var result string
+		assert.False(t, store.Load("Kraken2", &result))
+	})
+
+	t.Run("Error - Missing Output File", func(t *testing.T) {
+		dir := t.TempDir()
+		output := filepath.Join(dir, "genome.ffn")
+		assert.NoError(t, os.WriteFile(output, []byte(">g\nATG\n"), 0644))
+
+		store := NewCheckpointStore(dir)
+		assert.NoError(t, store.Save("Prokka", nil, output))
+		assert.NoError(t, os.Remove(output))
+
+		assert.False(t, store.Load("Prokka", nil))
+	})
+
+	t.Run("Error - Corrupted Checkpoint", func(t *testing.T) {
+		store := NewCheckpointStore(t.TempDir())
+		assert.NoError(t, store.Save("Species", "x"))
+		assert.NoError(t, os.WriteFile(store.dataPath("Species"),
+			[]byte("{not json"), 0644))
+
+		var result string
+		assert.False(t, store.Load("Species", &result))
+	})
+
+	t.Run("Success - Clear", func(t *testing.T) {
+		store := NewCheckpointStore(t.TempDir())
+		assert.NoError(t, store.Save("FastQC", "x"))
+		assert.NoError(t, store.Clear())
+
+		var result string
+		assert.False(t, store.Load("FastQC", &result))
+	})
+}
@@ -4,8 +4,14 @@ import (
 	"context"
 	"io"
 	"os/exec"
+	"syscall"
+	"time"
 )
 
+// ToolStopGracePeriod is how long a tool may take to exit once its context
+// is cancelled before it is killed.
+const ToolStopGracePeriod = 10 * time.Second
+
 type Cmd interface {
 	Start() error
 	Run() error
@@ -32,7 +38,16 @@ func (r *RealCmd) SetStdin(rdr io.Reader) { r.cmd.Stdin = rdr }
 
 type RealCommander struct{}
 
+// Command runs the tool in its own process group. Cancelling ctx sends
+// SIGTERM to the whole group, so the processes the tool spawned and the
+// containers proxied by the runtime CLI stop too.
 func (r *RealCommander) Command(ctx context.Context, name string,
 	args ...string) Cmd {
-	return &RealCmd{cmd: exec.CommandContext(ctx, name, args...)}
+	cmd := exec.CommandContext(ctx, name, args...)
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+	cmd.Cancel = func() error {
+		return syscall.Kill(-cmd.Process.Pid, syscall.SIGTERM)
+	}
+	cmd.WaitDelay = ToolStopGracePeriod
+	return &RealCmd{cmd: cmd}
 }
@@ -0,0 +1,94 @@
+package pipeline
+
+import (
+	"regexp"
+	"strings"
+)
+
+// SpeciesConcordance tells whether the species detected in a sample is the
+// one declared for it.
+type SpeciesConcordance string
+
+const (
+	SpeciesConcordant   SpeciesConcordance = "CONCORDANT"
+	SpeciesDiscordant   SpeciesConcordance = "DISCORDANT"
+	SpeciesInconclusive SpeciesConcordance = "INCONCLUSIVE"
+)
+
+func (c SpeciesConcordance) IsValid() bool {
+	switch c {
+	case SpeciesConcordant, SpeciesDiscordant, SpeciesInconclusive:
+		return true
+	default:
+		return false
+	}
+}
+
+// SpeciesRef names a species and, when known, its NCBI taxid.
+type SpeciesRef struct {
+	Name  string `json:"name"`
+	TaxID int    `json:"taxid,omitempty"`
+}
+
+// ConcordanceReport compares the declared species of a sample with the
+// detected one.
+type ConcordanceReport struct {
+	Status   SpeciesConcordance `json:"status"`
+	Declared SpeciesRef         `json:"declared"`
+	Detected SpeciesRef         `json:"detected"`
+}
+
+var speciesNameNoise = regexp.MustCompile(`\([^)]*\)|[\[\]'"]`)
+
+// NormalizeSpeciesName reduces a species name to its lower-case binomial,
+// dropping brackets, strain and subspecies. A name without a species
+// epithet ("Klebsiella sp.") is reduced to its genus.
+func NormalizeSpeciesName(name string) string {
+	words := strings.Fields(strings.ToLower(
+		speciesNameNoise.ReplaceAllString(name, " ")))
+	if len(words) > 0 && words[0] == "candidatus" {
+		words = words[1:]
+	}
+
+	switch {
+	case len(words) == 0:
+		return ""
+	case len(words) == 1 || words[1] == "sp." || words[1] == "spp." ||
+		words[1] == "sp" || words[1] == "spp":
+		return words[0]
+	default:
+		return words[0] + " " + words[1]
+	}
+}
+
+// CheckSpeciesConcordance compares the taxids when both are known and the
+// normalized names otherwise. The check is inconclusive when either side
+// does not name a species.
+func CheckSpeciesConcordance(declared, detected SpeciesRef) ConcordanceReport {
+	report := ConcordanceReport{
+		Status:   SpeciesInconclusive,
+		Declared: declared,
+		Detected: detected,
+	}
+
+	if declared.TaxID > 0 && detected.TaxID > 0 {
+		report.Status = SpeciesDiscordant
+		if declared.TaxID == detected.TaxID {
+			report.Status = SpeciesConcordant
+		}
+		return report
+	}
+
+	declaredName := NormalizeSpeciesName(declared.Name)
+	detectedName := NormalizeSpeciesName(detected.Name)
+	if !strings.Contains(declaredName, " ") ||
+		!strings.Contains(detectedName, " ") {
+		return report
+	}
+
+	report.Status = SpeciesDiscordant
+	if declaredName == detectedName {
+		report.Status = SpeciesConcordant
+	}
+	return report
+}
@@ -0,0 +1,79 @@
+package pipeline
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalizeSpeciesName(t *testing.T) {
+	cases := map[string]string{
+		"Klebsiella pneumoniae":                   "klebsiella pneumoniae",
+		"  Escherichia   coli (strain K12)":       "escherichia coli",
+		"Klebsiella pneumoniae subsp. pneumoniae": "klebsiella pneumoniae",
+		"[Enterobacter] aerogenes":                "enterobacter aerogenes",
+		"Candidatus Liberibacter asiaticus":       "liberibacter asiaticus",
+		"Acinetobacter sp.":                       "acinetobacter",
+		"Pseudomonas":                             "pseudomonas",
+		"":                                        "",
+	}
+
+	for name, expected := range cases {
+		assert.Equal(t, expected, NormalizeSpeciesName(name), name)
+	}
+}
+
+func TestCheckSpeciesConcordance(t *testing.T) {
+	t.Run("Concordant - Names", func(t *testing.T) {
+		report := CheckSpeciesConcordance(
+			SpeciesRef{Name: "Klebsiella pneumoniae"},
+			SpeciesRef{Name: "Klebsiella pneumoniae subsp. pneumoniae",
+				TaxID: 573},
+		)
+
+		assert.Equal(t, SpeciesConcordant, report.Status)
+		assert.Equal(t, 573, report.Detected.TaxID)
+	})
+
+	t.Run("Discordant - Names", func(t *testing.T) {
+		report := CheckSpeciesConcordance(
+			SpeciesRef{Name: "Klebsiella pneumoniae"},
+			SpeciesRef{Name: "Escherichia coli"},
+		)
+
+		assert.Equal(t, SpeciesDiscordant, report.Status)
+	})
+
+	t.Run("Taxids Take Precedence", func(t *testing.T) {
+		report := CheckSpeciesConcordance(
+			SpeciesRef{Name: "Enterobacter aerogenes", TaxID: 548},
+			SpeciesRef{Name: "Klebsiella aerogenes", TaxID: 548},
+		)
+		assert.Equal(t, SpeciesConcordant, report.Status)
+
+		report = CheckSpeciesConcordance(
+			SpeciesRef{Name: "Escherichia coli", TaxID: 562},
+			SpeciesRef{Name: "Escherichia coli", TaxID: 564},
+		)
+		assert.Equal(t, SpeciesDiscordant, report.Status)
+	})
+
+	t.Run("Inconclusive - Genus Only", func(t *testing.T) {
+		report := CheckSpeciesConcordance(
+			SpeciesRef{Name: "Acinetobacter baumannii"},
+			SpeciesRef{Name: "Acinetobacter sp."},
+		)
+
+		assert.Equal(t, SpeciesInconclusive, report.Status)
+	})
+
+	t.Run("Inconclusive - Nothing Detected", func(t *testing.T) {
+		report := CheckSpeciesConcordance(
+			SpeciesRef{Name: "Escherichia coli", TaxID: 562},
+			SpeciesRef{},
+		)
+
+		assert.Equal(t, SpeciesInconclusive, report.Status)
+		assert.Equal(t, "Escherichia coli", report.Declared.Name)
+	})
+}
@@ -0,0 +1,290 @@
+package pipeline
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+type ContainerRuntime string
+
+const (
+	ContainerRuntimeDocker    ContainerRuntime = "docker"
+	ContainerRuntimePodman    ContainerRuntime = "podman"
+	ContainerRuntimeApptainer ContainerRuntime = "apptainer"
+)
+
+func (r ContainerRuntime) IsValid() bool {
+	switch r {
+	case ContainerRuntimeDocker, ContainerRuntimePodman,
+		ContainerRuntimeApptainer:
+		return true
+	default:
+		return false
+	}
+}
+
+// ContainerConfig describes how tools are run inside containers. Images maps
+// the tool executable base name (e.g. "abricate") to its image; tools without
+// an image keep running on the host.
+type ContainerConfig struct {
+	Runtime     ContainerRuntime
+	RuntimePath string
+	Images      map[string]string
+	// Mounts are the reference database paths, bound read-only.
+	Mounts []string
+	// Env holds NAME=value pairs set inside the container.
+	Env    []string
+	CPUs   string
+	Memory string
+}
+
+type analysisDirKey struct{}
+
+// WithAnalysisDir sets the analysis folder, the only folder containers may
+// write to.
+func WithAnalysisDir(ctx context.Context, dir string) context.Context {
+	return context.WithValue(ctx, analysisDirKey{}, dir)
+}
+
+func AnalysisDirFromContext(ctx context.Context) string {
+	dir, _ := ctx.Value(analysisDirKey{}).(string)
+	return dir
+}
+
+// ContainerCommander runs each tool with an image configured in a container
+// of the configured runtime, and everything else through Tools.
+type ContainerCommander struct {
+	Config ContainerConfig
+	// Host runs the Docker and Podman CLI, whose containers get the step
+	// memory limit through the runtime.
+	Host Commander
+	// Tools runs the tools without an image and the Apptainer CLI, whose
+	// containers are plain child processes, under the step memory limit
+	// (see ResourceCommander). It defaults to Host.
+	Tools Commander
+}
+
+func NewContainerCommander(config ContainerConfig,
+	host, tools Commander) (*ContainerCommander, error) {
+	if !config.Runtime.IsValid() {
+		return nil, fmt.Errorf("invalid container runtime: %q",
+			config.Runtime)
+	}
+	if config.RuntimePath == "" {
+		config.RuntimePath = string(config.Runtime)
+	}
+	if host == nil {
+		host = &RealCommander{}
+	}
+	if tools == nil {
+		tools = host
+	}
+
+	return &ContainerCommander{Config: config, Host: host, Tools: tools}, nil
+}
+
+func (c *ContainerCommander) Command(ctx context.Context, name string,
+	args ...string) Cmd {
+	tool := filepath.Base(name)
+	image, ok := c.Config.Images[tool]
+	if !ok || image == "" {
+		return c.Tools.Command(ctx, name, args...)
+	}
+
+	if c.Config.Runtime == ContainerRuntimeApptainer {
+		return c.Tools.Command(ctx, c.Config.RuntimePath,
+			c.runtimeArgs(ctx, image, tool, "", args)...)
+	}
+
+	// The container is kept after it exits so the runtime can tell whether
+	// the OOM killer stopped it, and removed afterwards.
+	dir, err := os.MkdirTemp("", "cabgen-container-")
+	if err != nil {
+		return c.Host.Command(ctx, c.Config.RuntimePath,
+			c.runtimeArgs(ctx, image, tool, "", args)...)
+	}
+	cidFile := filepath.Join(dir, "cid")
+	return &oomCheckCmd{
+		Cmd: c.Host.Command(ctx, c.Config.RuntimePath,
+			c.runtimeArgs(ctx, image, tool, cidFile, args)...),
+		oomKilled: func() bool {
+			id := readContainerID(cidFile)
+			if id == "" {
+				return false
+			}
+			output, err := c.runtime("inspect", "--format",
+				"{{.State.OOMKilled}}", id)
+			return err == nil && strings.TrimSpace(output) == "true"
+		},
+		cleanup: func() {
+			if id := readContainerID(cidFile); id != "" {
+				_, _ = c.runtime("rm", "--force", id)
+			}
+			os.RemoveAll(dir)
+		},
+	}
+}
+
+func readContainerID(cidFile string) string {
+	raw, err := os.ReadFile(cidFile)
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(raw))
+}
+
+// runtime runs a runtime command about a finished container. It does not
+// use the context of the step, which may already be cancelled.
+func (c *ContainerCommander) runtime(args ...string) (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(),
+		ToolStopGracePeriod)
+	defer cancel()
+
+	var stdout bytes.Buffer
+	cmd := c.Host.Command(ctx, c.Config.RuntimePath, args...)
+	cmd.SetStdout(&stdout)
+	cmd.SetStderr(io.Discard)
+	err := cmd.Run()
+	return stdout.String(), err
+}
+
+type containerMount struct {
+	path     string
+	readOnly bool
+}
+
+// mounts returns the analysis folder (read-write), the reference databases
+// and any absolute input path outside of them (read-only). Paths keep the
+// same location inside the container so tool arguments need no rewriting.
+func (c *ContainerCommander) mounts(ctx context.Context,
+	args []string) []containerMount {
+	var mounts []containerMount
+	if dir := AnalysisDirFromContext(ctx); dir != "" {
+		mounts = append(mounts, containerMount{path: filepath.Clean(dir)})
+	}
+	for _, path := range c.Config.Mounts {
+		if path != "" {
+			mounts = append(mounts, containerMount{
+				path: filepath.Clean(path), readOnly: true,
+			})
+		}
+	}
+
+	for _, arg := range args {
+		if !filepath.IsAbs(arg) || isMounted(mounts, arg) {
+			continue
+		}
+		if _, err := os.Stat(arg); err != nil {
+			continue
+		}
+		mounts = append(mounts, containerMount{
+			path: filepath.Clean(arg), readOnly: true,
+		})
+	}
+
+	return mounts
+}
+
+func isMounted(mounts []containerMount, path string) bool {
+	path = filepath.Clean(path)
+	for _, mount := range mounts {
+		if path == mount.path ||
+			strings.HasPrefix(path, mount.path+string(filepath.Separator)) {
+			return true
+		}
+	}
+	return false
+}
+
+// runtimeArgs builds the runtime arguments of tool. Docker and Podman write
+// the container ID to cidFile and keep the container, unless cidFile is
+// empty.
+func (c *ContainerCommander) runtimeArgs(ctx context.Context, image,
+	tool, cidFile string, args []string) []string {
+	mounts := c.mounts(ctx, args)
+	workdir := AnalysisDirFromContext(ctx)
+
+	var runtimeArgs []string
+	switch c.Config.Runtime {
+	case ContainerRuntimeApptainer:
+		runtimeArgs = []string{"exec", "--containall", "--no-home"}
+		if workdir != "" {
+			runtimeArgs = append(runtimeArgs, "--pwd", workdir)
+		}
+		for _, mount := range mounts {
+			bind := mount.path + ":" + mount.path
+			if mount.readOnly {
+				bind += ":ro"
+			}
+			runtimeArgs = append(runtimeArgs, "--bind", bind)
+		}
+	default:
+		runtimeArgs = []string{"run", "--rm"}
+		if cidFile != "" {
+			runtimeArgs = []string{"run", "--cidfile", cidFile}
+		}
+		runtimeArgs = append(runtimeArgs, "--network", "none")
+		if c.Config.Runtime == ContainerRuntimePodman {
+			runtimeArgs = append(runtimeArgs, "--userns", "keep-id")
+		} else {
+			runtimeArgs = append(runtimeArgs, "--user",
+				fmt.Sprintf("%d:%d", os.Getuid(), os.Getgid()))
+		}
+		if workdir != "" {
+			runtimeArgs = append(runtimeArgs, "--workdir", workdir)
+		}
+		for _, mount := range mounts {
+			volume := mount.path + ":" + mount.path
+			if mount.readOnly {
+				volume += ":ro"
+			}
+			runtimeArgs = append(runtimeArgs, "--volume", volume)
+		}
+	}
+
+	if c.Config.CPUs != "" {
+		runtimeArgs = append(runtimeArgs, "--cpus", c.Config.CPUs)
+	}
+	// The memory limit of the running step replaces the global one. Tools
+	// enforces it on Apptainer instead, so the OOM kills can be told apart.
+	memory := c.Config.Memory
+	if limit := StepResourcesFromContext(ctx).MaxMemory; limit > 0 {
+		memory = strconv.FormatInt(limit, 10)
+		if c.Config.Runtime == ContainerRuntimeApptainer {
+			memory = ""
+		}
+	}
+	if memory != "" {
+		runtimeArgs = append(runtimeArgs, "--memory", memory)
+	}
+	for _, env := range c.Config.Env {
+		runtimeArgs = append(runtimeArgs, "--env", env)
+	}
+
+	runtimeArgs = append(runtimeArgs, image, tool)
+	return append(runtimeArgs, args...)
+}
+
+// ParseContainerImages parses a "tool=image,tool=image" list.
+func ParseContainerImages(raw string) (map[string]string, error) {
+	images := map[string]string{}
+	for _, entry := range strings.Split(raw, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		tool, image, ok := strings.Cut(entry, "=")
+		tool, image = strings.TrimSpace(tool), strings.TrimSpace(image)
+		if !ok || tool == "" || image == "" {
+			return nil, fmt.Errorf("invalid container image entry: %q", entry)
+		}
+		images[tool] = image
+	}
+	return images, nil
+}
@@ -0,0 +1,318 @@
+package pipeline
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeRuntime writes a container runtime stand-in that prints its arguments,
+// one per line.
+func fakeRuntime(t *testing.T) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "runtime")
+	err := os.WriteFile(path, []byte("#!/bin/sh\nprintf '%s\\n' \"$@\"\n"),
+		0755)
+	assert.NoError(t, err)
+	return path
+}
+
+// oomRuntime writes a container runtime stand-in whose containers exit with
+// 137 and report oomKilled on inspect.
+func oomRuntime(t *testing.T, oomKilled string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "runtime")
+	script := `#!/bin/sh
+case "$1" in
+run) echo c0ffee >"$3"; exit 137 ;;
+inspect) [ "$4" = c0ffee ] && echo ` + oomKilled + ` ;;
+rm) [ "$3" = c0ffee ] ;;
+esac
+`
+	assert.NoError(t, os.WriteFile(path, []byte(script), 0755))
+	return path
+}
+
+func runContainerCommand(t *testing.T, commander Commander,
+	ctx context.Context, args ...string) []string {
+	t.Helper()
+	output, err := NewToolRunner(commander).Run(ctx, args)
+	assert.NoError(t, err)
+	return strings.Split(strings.TrimSpace(output), "\n")
+}
+
+func TestNewContainerCommander(t *testing.T) {
+	t.Run("Success - Runtime Path Defaults To Runtime", func(t *testing.T) {
+		commander, err := NewContainerCommander(ContainerConfig{
+			Runtime: ContainerRuntimePodman,
+		}, nil, nil)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "podman", commander.Config.RuntimePath)
+		assert.NotNil(t, commander.Host)
+		assert.Equal(t, commander.Host, commander.Tools)
+	})
+
+	t.Run("Error - Invalid Runtime", func(t *testing.T) {
+		commander, err := NewContainerCommander(ContainerConfig{
+			Runtime: "lxc",
+		}, nil, nil)
+
+		assert.Error(t, err)
+		assert.Nil(t, commander)
+	})
+}
+
+func TestContainerCommanderCommand(t *testing.T) {
+	analysisDir := t.TempDir()
+	dbDir := t.TempDir()
+	inputDir := t.TempDir()
+	input := filepath.Join(inputDir, "reads.fastq.gz")
+	assert.NoError(t, os.WriteFile(input, []byte("@r\nA\n+\nI\n"), 0644))
+	output := filepath.Join(analysisDir, "out.fasta")
+
+	ctx := WithAnalysisDir(context.Background(), analysisDir)
+	user := fmt.Sprintf("%d:%d", os.Getuid(), os.Getgid())
+
+	newCommander := func(runtime ContainerRuntime) Commander {
+		commander, err := NewContainerCommander(ContainerConfig{
+			Runtime:     runtime,
+			RuntimePath: fakeRuntime(t),
+			Images:      map[string]string{"abricate": "abricate:1.0"},
+			Mounts:      []string{dbDir},
+			Env:         []string{"CHECKM_DATA_PATH=" + dbDir},
+			CPUs:        "4",
+			Memory:      "8g",
+		}, &RealCommander{}, nil)
+		assert.NoError(t, err)
+		return commander
+	}
+
+	t.Run("Success - Docker", func(t *testing.T) {
+		args := runContainerCommand(t, newCommander(ContainerRuntimeDocker),
+			ctx, "/usr/local/bin/abricate", "--db", "resfinder", input,
+			output)
+
+		assert.Equal(t, []string{
+			"run", "--cidfile", args[2], "--network", "none",
+			"--user", user,
+			"--workdir", analysisDir,
+			"--volume", analysisDir + ":" + analysisDir,
+			"--volume", dbDir + ":" + dbDir + ":ro",
+			"--volume", input + ":" + input + ":ro",
+			"--cpus", "4", "--memory", "8g",
+			"--env", "CHECKM_DATA_PATH=" + dbDir,
+			"abricate:1.0", "abricate", "--db", "resfinder", input, output,
+		}, args)
+		assert.NoDirExists(t, filepath.Dir(args[2]))
+	})
+
+	t.Run("Success - Podman", func(t *testing.T) {
+		args := runContainerCommand(t, newCommander(ContainerRuntimePodman),
+			ctx, "abricate", "--list")
+
+		assert.Equal(t, []string{
+			"run", "--cidfile", args[2], "--network", "none",
+			"--userns", "keep-id",
+			"--workdir", analysisDir,
+			"--volume", analysisDir + ":" + analysisDir,
+			"--volume", dbDir + ":" + dbDir + ":ro",
+			"--cpus", "4", "--memory", "8g",
+			"--env", "CHECKM_DATA_PATH=" + dbDir,
+			"abricate:1.0", "abricate", "--list",
+		}, args)
+		assert.NoDirExists(t, filepath.Dir(args[2]))
+	})
+
+	t.Run("Success - Apptainer", func(t *testing.T) {
+		args := runContainerCommand(t,
+			newCommander(ContainerRuntimeApptainer), ctx, "abricate", input)
+
+		assert.Equal(t, []string{
+			"exec", "--containall", "--no-home", "--pwd", analysisDir,
+			"--bind", analysisDir + ":" + analysisDir,
+			"--bind", dbDir + ":" + dbDir + ":ro",
+			"--bind", input + ":" + input + ":ro",
+			"--cpus", "4", "--memory", "8g",
+			"--env", "CHECKM_DATA_PATH=" + dbDir,
+			"abricate:1.0", "abricate", input,
+		}, args)
+	})
+
+	t.Run("Success - Step Memory Limit", func(t *testing.T) {
+		limits := &ResourceLimits{
+			Default: StepResources{MaxMemory: 2 << 30},
+		}
+		stepCtx, cancel := limits.StepContext(ctx, StepNameAbricate)
+		defer cancel()
+
+		args := runContainerCommand(t, newCommander(ContainerRuntimePodman),
+			stepCtx, "abricate", "--list")
+
+		assert.Contains(t, strings.Join(args, " "),
+			"--cpus 4 --memory 2147483648")
+	})
+
+	t.Run("Error - Container OOM Killed", func(t *testing.T) {
+		stepCtx, cancel := (&ResourceLimits{
+			Default: StepResources{MaxMemory: 2 << 30},
+		}).StepContext(ctx, StepNameAbricate)
+		defer cancel()
+
+		commander, err := NewContainerCommander(ContainerConfig{
+			Runtime:     ContainerRuntimeDocker,
+			RuntimePath: oomRuntime(t, "true"),
+			Images:      map[string]string{"abricate": "abricate:1.0"},
+		}, &RealCommander{}, nil)
+		assert.NoError(t, err)
+
+		_, err = NewToolRunner(commander).Run(stepCtx,
+			[]string{"abricate", "--list"})
+
+		assert.Equal(t, ErrStepOutOfMemory, ResourceError(stepCtx, err))
+	})
+
+	t.Run("Error - Container Killed Without OOM", func(t *testing.T) {
+		stepCtx, cancel := (&ResourceLimits{
+			Default: StepResources{MaxMemory: 2 << 30},
+		}).StepContext(ctx, StepNameAbricate)
+		defer cancel()
+
+		commander, err := NewContainerCommander(ContainerConfig{
+			Runtime:     ContainerRuntimeDocker,
+			RuntimePath: oomRuntime(t, "false"),
+			Images:      map[string]string{"abricate": "abricate:1.0"},
+		}, &RealCommander{}, nil)
+		assert.NoError(t, err)
+
+		_, err = NewToolRunner(commander).Run(stepCtx,
+			[]string{"abricate", "--list"})
+
+		assert.Error(t, err)
+		assert.Nil(t, ResourceError(stepCtx, err))
+	})
+
+	t.Run("Success - Tool Without Image Runs On Host", func(t *testing.T) {
+		var gotName string
+		var gotArgs []string
+		commander, err := NewContainerCommander(ContainerConfig{
+			Runtime: ContainerRuntimeDocker,
+			Images:  map[string]string{"abricate": "abricate:1.0"},
+		}, nil, &mockCommander{
+			cmdFunc: func(_ context.Context, name string, args ...string) Cmd {
+				gotName, gotArgs = name, args
+				return &mockCmd{runFunc: func() error { return nil }}
+			},
+		})
+		assert.NoError(t, err)
+
+		commander.Command(ctx, "mlst", "--csv", "contigs.fa")
+
+		assert.Equal(t, "mlst", gotName)
+		assert.Equal(t, []string{"--csv", "contigs.fa"}, gotArgs)
+	})
+}
+
+func TestContainerCommanderStepMemoryLimit(t *testing.T) {
+	limits := &ResourceLimits{Default: StepResources{MaxMemory: 512 << 20}}
+	ctx, cancel := limits.StepContext(context.Background(), StepNameAbricate)
+	defer cancel()
+
+	// newCommander records the commands of the runtime CLI and those run
+	// under the step limits.
+	newCommander := func(runtime ContainerRuntime, host,
+		limited *[]string) *ContainerCommander {
+		record := func(got *[]string) Commander {
+			return &mockCommander{
+				cmdFunc: func(_ context.Context, name string,
+					args ...string) Cmd {
+					*got = append([]string{name}, args...)
+					return &mockCmd{runFunc: func() error { return nil }}
+				},
+			}
+		}
+		commander, err := NewContainerCommander(ContainerConfig{
+			Runtime:     runtime,
+			RuntimePath: "runtime",
+			Images:      map[string]string{"abricate": "abricate:1.0"},
+			Memory:      "8g",
+		}, record(host), &ResourceCommander{
+			Next: record(limited), Limiter: MemoryLimiterSystemd,
+		})
+		assert.NoError(t, err)
+		return commander
+	}
+
+	t.Run("Success - Tool Without Image", func(t *testing.T) {
+		var host, limited []string
+		commander := newCommander(ContainerRuntimeDocker, &host, &limited)
+
+		assert.NoError(t, commander.Command(ctx, "blastn", "-query",
+			"in.fa").Run())
+
+		assert.Empty(t, host)
+		if assert.NotEmpty(t, limited) {
+			assert.Equal(t, "systemd-run", limited[0])
+			assert.Contains(t, limited, "MemoryMax=536870912")
+			assert.Equal(t, []string{"blastn", "-query", "in.fa"},
+				limited[len(limited)-3:])
+		}
+	})
+
+	t.Run("Success - Apptainer", func(t *testing.T) {
+		var host, limited []string
+		commander := newCommander(ContainerRuntimeApptainer, &host,
+			&limited)
+
+		assert.NoError(t, commander.Command(ctx, "abricate",
+			"--list").Run())
+
+		assert.Empty(t, host)
+		if assert.NotEmpty(t, limited) {
+			assert.Equal(t, "systemd-run", limited[0])
+			assert.Contains(t, limited, "MemoryMax=536870912")
+			assert.NotContains(t, limited, "--memory")
+		}
+	})
+
+	t.Run("Success - Docker", func(t *testing.T) {
+		var host, limited []string
+		commander := newCommander(ContainerRuntimeDocker, &host, &limited)
+
+		assert.NoError(t, commander.Command(ctx, "abricate",
+			"--list").Run())
+
+		assert.Empty(t, limited)
+		assert.Contains(t, strings.Join(host, " "), "--memory 536870912")
+	})
+}
+
+func TestParseContainerImages(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		images, err := ParseContainerImages(
+			"abricate=staphb/abricate:1.0.1, mlst=staphb/mlst:2.23,")
+
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]string{
+			"abricate": "staphb/abricate:1.0.1",
+			"mlst":     "staphb/mlst:2.23",
+		}, images)
+	})
+
+	t.Run("Success - Empty", func(t *testing.T) {
+		images, err := ParseContainerImages("")
+
+		assert.NoError(t, err)
+		assert.Empty(t, images)
+	})
+
+	t.Run("Error - Missing Image", func(t *testing.T) {
+		_, err := ParseContainerImages("abricate")
+		assert.Error(t, err)
+	})
+}
@@ -10,38 +10,93 @@ import (
 	"strings"
 )
 
-func processFastq(filePath string) (int64, int64, error) {
+var errEmptyFile = errors.New("empty file")
+
+// sequenceFile reads a FASTA or FASTQ file, decompressed when gzipped.
+type sequenceFile struct {
+	io.Reader
+	compressed bool
+	closers    []io.Closer
+}
+
+// archiveMagics are the leading bytes of the compressed formats the tools do
+// not read: bzip2, zip, xz and zstd.
+var archiveMagics = map[[2]byte]string{
+	{'B', 'Z'}:   "bzip2",
+	{'P', 'K'}:   "zip",
+	{0xfd, '7'}:  "xz",
+	{0x28, 0xb5}: "zstd",
+}
+
+func (f *sequenceFile) Close() error {
+	var errs []error
+	for i := len(f.closers) - 1; i >= 0; i-- {
+		errs = append(errs, f.closers[i].Close())
+	}
+	return errors.Join(errs...)
+}
+
+// openSequenceFile opens filePath, decompressing it when it starts with the
+// gzip magic number. Other archive formats are rejected with
+// ErrInvalidFormat and an empty file returns errEmptyFile.
+func openSequenceFile(filePath string) (*sequenceFile, error) {
 	file, err := os.Open(filePath)
 	if err != nil {
 		if errors.Is(err, os.ErrNotExist) {
-			return 0, 0, ErrFileNotFound
+			return nil, ErrFileNotFound
 		}
-		return 0, 0, fmt.Errorf("%w: %v", ErrCorruptedInput, err)
+		return nil, fmt.Errorf("%w: %v", ErrCorruptedInput, err)
 	}
-	defer file.Close()
 
-	var reader io.Reader = file
+	sequence := &sequenceFile{Reader: file, closers: []io.Closer{file}}
 
 	buf := make([]byte, 2)
-	if _, err := file.Read(buf); err == nil {
+	if _, err := io.ReadFull(file, buf); err == nil {
 		if _, err := file.Seek(0, io.SeekStart); err != nil {
-			return 0, 0, fmt.Errorf("%w: %v", ErrCorruptedInput, err)
+			sequence.Close()
+			return nil, fmt.Errorf("%w: %v", ErrCorruptedInput, err)
 		}
 
 		if buf[0] == 0x1f && buf[1] == 0x8b {
 			gzReader, err := gzip.NewReader(file)
 			if err != nil {
-				return 0, 0, fmt.Errorf("%w: %v", ErrCorruptedInput, err)
+				sequence.Close()
+				return nil, fmt.Errorf("%w: %v", ErrCorruptedInput, err)
 			}
-			defer gzReader.Close()
-			reader = gzReader
+			sequence.Reader = gzReader
+			sequence.compressed = true
+			sequence.closers = append(sequence.closers, gzReader)
+		} else if format, ok := archiveMagics[[2]byte{buf[0], buf[1]}]; ok {
+			sequence.Close()
+			return nil, fmt.Errorf("%w: %s archives are not supported",
+				ErrInvalidFormat, format)
+		}
+	} else if errors.Is(err, io.ErrUnexpectedEOF) {
+		if _, err := file.Seek(0, io.SeekStart); err != nil {
+			sequence.Close()
+			return nil, fmt.Errorf("%w: %v", ErrCorruptedInput, err)
 		}
 	} else if errors.Is(err, io.EOF) {
-		return 0, 0, ErrEmptyReads
+		sequence.Close()
+		return nil, errEmptyFile
 	} else {
-		return 0, 0, fmt.Errorf("%w: %v", ErrCorruptedInput, err)
+		sequence.Close()
+		return nil, fmt.Errorf("%w: %v", ErrCorruptedInput, err)
 	}
 
+	return sequence, nil
+}
+
+func processFastq(filePath string) (int64, int64, error) {
+	reader, err := openSequenceFile(filePath)
+	if errors.Is(err, errEmptyFile) {
+		return 0, 0, ErrEmptyReads
+	}
+	if err != nil {
+		return 0, 0, err
+	}
+	defer reader.Close()
+
 	scanner := bufio.NewScanner(reader)
 
 	const maxCapacity = 1024 * 1024 * 4
@@ -82,6 +137,8 @@ func processFastq(filePath string) (int64, int64, error) {
 	return readCount, totalBases, nil
 }
 
+// CalculateCoverage is the short-read depth. Single-end samples pass an empty
+// read2.
 func CalculateCoverage(read1, read2 string, genomeSize int64) (float64, error) {
 	if genomeSize <= 0 {
 		return 0, fmt.Errorf("Invalid genome size: %d", genomeSize)
@@ -92,9 +149,12 @@ func CalculateCoverage(read1, read2 string, genomeSize int64) (float64, error) {
 		return 0, fmt.Errorf("Failed to process read1: %w", err)
 	}
 
-	count2, _, err := processFastq(read2)
-	if err != nil {
-		return 0, fmt.Errorf("Failed to process read2: %w", err)
+	var count2 int64
+	if read2 != "" {
+		count2, _, err = processFastq(read2)
+		if err != nil {
+			return 0, fmt.Errorf("Failed to process read2: %w", err)
+		}
 	}
 
 	if count1 == 0 {
@@ -107,3 +167,22 @@ func CalculateCoverage(read1, read2 string, genomeSize int64) (float64, error) {
 
 	return coverage, nil
 }
+
+// CalculateLongReadCoverage is the long-read depth: every sequenced base over
+// the genome size, since long reads vary too much in length for an average.
+func CalculateLongReadCoverage(reads string, genomeSize int64) (float64,
+	error) {
+	if genomeSize <= 0 {
+		return 0, fmt.Errorf("Invalid genome size: %d", genomeSize)
+	}
+
+	count, bases, err := processFastq(reads)
+	if err != nil {
+		return 0, fmt.Errorf("Failed to process long reads: %w", err)
+	}
+	if count == 0 {
+		return 0, ErrEmptyReads
+	}
+
+	return float64(bases) / float64(genomeSize), nil
+}
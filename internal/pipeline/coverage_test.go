@@ -171,6 +171,15 @@ func TestCalculateCoverage(t *testing.T) {
 		assert.Equal(t, 0.4, coverage)
 	})
 
+	t.Run("Success - Single-end", func(t *testing.T) {
+		// 2 reads, avg len 8, genome 100 → (8*2)/100 = 0.16
+		read1 := createMockFastqFile(t, fastqRead("@r1", "ATCGATCG")+fastqRead("@r2", "ATCGATCG"))
+
+		coverage, err := CalculateCoverage(read1, "", 100)
+		assert.NoError(t, err)
+		assert.Equal(t, 0.16, coverage)
+	})
+
 	t.Run("Success - Gzipped Files", func(t *testing.T) {
 		read1 := createMockGzipFastqFile(t, fastqRead("@r1", "ATCGATCG")+fastqRead("@r2", "ATCGATCG"))
 		read2 := createMockGzipFastqFile(t, fastqRead("@r3", "GCTAGCTA")+fastqRead("@r4", "GCTAGCTA"))
@@ -256,3 +265,28 @@ func TestCalculateCoverage(t *testing.T) {
 		assert.Equal(t, float64(0), coverage)
 	})
 }
+
+func TestCalculateLongReadCoverage(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		// 12 + 8 bases over a genome of 10 → 2x
+		reads := createMockFastqFile(t, fastqRead("@r1", "ATCGATCGATCG")+fastqRead("@r2", "ATCGATCG"))
+
+		coverage, err := CalculateLongReadCoverage(reads, 10)
+		assert.NoError(t, err)
+		assert.Equal(t, 2.0, coverage)
+	})
+
+	t.Run("Error - Genome Size Zero", func(t *testing.T) {
+		reads := createMockFastqFile(t, fastqRead("@r1", "ATCG"))
+
+		_, err := CalculateLongReadCoverage(reads, 0)
+		assert.ErrorContains(t, err, "Invalid genome size")
+	})
+
+	t.Run("Error - Empty Reads", func(t *testing.T) {
+		reads := createMockFastqFile(t, "")
+
+		_, err := CalculateLongReadCoverage(reads, 100)
+		assert.ErrorIs(t, err, ErrEmptyReads)
+	})
+}
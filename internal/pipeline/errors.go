@@ -8,6 +8,7 @@ var (
 	ErrCorruptedInput = errors.New("input file is corrupted or truncated")
 	ErrEmptyReads     = errors.New("no reads found in input file")
 	ErrInvalidFormat  = errors.New("invalid file format")
+	ErrUnpairedReads  = errors.New("read files are not mates of each other")
 )
 
 // Pipeline step errors
@@ -19,7 +20,30 @@ var (
 	ErrKraken2             = errors.New("The Kraken2 step failed. Create a new analysis.")
 	ErrSpecies             = errors.New("The Species identification (mlst, fastani) step failed. Create a new analysis.")
 	ErrAbricate            = errors.New("The Abricate step failed. Create a new analysis.")
+	ErrAMRFinder           = errors.New("The AMRFinderPlus step failed. Create a new analysis.")
 	ErrPrepareFolders      = errors.New("Folder preparation failed. Create a new analysis.")
+	ErrWorkerLost          = errors.New("The worker running the analysis was lost. Create a new analysis.")
 	ErrAnalysisRun         = errors.New("analysis failed")
 	ErrUnknownAnalysisType = errors.New("unknown analysis type")
 )
+
+// Resource limit errors
+var (
+	ErrStepTimeout     = errors.New("A step exceeded its time limit. Create a new analysis.")
+	ErrStepOutOfMemory = errors.New("A step exceeded its memory limit. Create a new analysis.")
+)
+
+// Parameter errors
+var (
+	ErrInvalidParameters = errors.New("invalid analysis parameters")
+)
+
+// QC gate errors
+var (
+	ErrInvalidQCRule = errors.New("invalid QC rule")
+)
+
+// Step graph errors
+var (
+	ErrInvalidStepGraph = errors.New("invalid step graph")
+)
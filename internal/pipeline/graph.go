@@ -0,0 +1,372 @@
+package pipeline
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// StepData is the shared store steps read their inputs from and write their
+// outputs to. It is safe for concurrent use.
+type StepData struct {
+	mu     sync.RWMutex
+	values map[string]any
+}
+
+func NewStepData() *StepData {
+	return &StepData{values: map[string]any{}}
+}
+
+func (d *StepData) Get(key string) (any, bool) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	value, ok := d.values[key]
+	return value, ok
+}
+
+func (d *StepData) Set(key string, value any) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.values[key] = value
+}
+
+// Snapshot returns a copy of every value currently stored.
+func (d *StepData) Snapshot() map[string]any {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	snapshot := make(map[string]any, len(d.values))
+	for key, value := range d.values {
+		snapshot[key] = value
+	}
+	return snapshot
+}
+
+// Value returns the value stored under key when it has type T.
+func Value[T any](d *StepData, key string) (T, bool) {
+	var zero T
+	raw, ok := d.Get(key)
+	if !ok {
+		return zero, false
+	}
+	value, ok := raw.(T)
+	if !ok {
+		return zero, false
+	}
+	return value, true
+}
+
+// StepOutput declares a value a step produces. The type parameter given to
+// Output is used to decode the value back from a checkpoint.
+type StepOutput struct {
+	Key    string
+	decode func(raw json.RawMessage) (any, error)
+}
+
+func Output[T any](key string) StepOutput {
+	return StepOutput{
+		Key: key,
+		decode: func(raw json.RawMessage) (any, error) {
+			var value T
+			if err := json.Unmarshal(raw, &value); err != nil {
+				return nil, err
+			}
+			return value, nil
+		},
+	}
+}
+
+// StepResult is what a step hands back to the runner: its output values and
+// the files that must still exist for its checkpoint to stay valid.
+type StepResult struct {
+	Values map[string]any
+	Files  []string
+}
+
+type Step struct {
+	Name      string
+	DependsOn []string
+	Inputs    []string
+	Outputs   []StepOutput
+	// Err replaces the tool error returned by Run, so users only see which
+	// step failed.
+	Err error
+	// PassInputErrors returns input errors (see IsInputError) as they are
+	// instead of Err, so the user knows the uploaded files are at fault.
+	PassInputErrors bool
+	// Optional steps only report their failure; the graph keeps running.
+	Optional bool
+	// Skip reports whether the step does not apply to this run. Skipped
+	// steps still satisfy the steps that depend on them.
+	Skip func(data *StepData) bool
+	Run  func(ctx context.Context, data *StepData) (*StepResult, error)
+}
+
+type StepHooks struct {
+	OnStart           func(step string)
+	OnRestore         func(step string)
+	OnError           func(step string, err error)
+	OnCheckpointError func(step string, err error)
+}
+
+type StepRunOptions struct {
+	Checkpoints *CheckpointStore
+	Resume      bool
+	Hooks       StepHooks
+	// Resources limits each step (see ResourceLimits.StepContext). Steps
+	// exceeding them fail with ErrStepTimeout or ErrStepOutOfMemory.
+	Resources *ResourceLimits
+}
+
+type StepGraph struct {
+	steps []Step
+	index map[string]int
+}
+
+// NewStepGraph validates the steps: names must be unique, dependencies must
+// exist and be acyclic, and every input must be a seed or an output of a
+// (transitive) dependency.
+func NewStepGraph(seeds []string, steps ...Step) (*StepGraph, error) {
+	index := make(map[string]int, len(steps))
+	for i, step := range steps {
+		if step.Name == "" || step.Run == nil {
+			return nil, fmt.Errorf("%w: step %d has no name or run func",
+				ErrInvalidStepGraph, i)
+		}
+		if _, ok := index[step.Name]; ok {
+			return nil, fmt.Errorf("%w: duplicated step %s",
+				ErrInvalidStepGraph, step.Name)
+		}
+		index[step.Name] = i
+	}
+
+	for _, step := range steps {
+		for _, dep := range step.DependsOn {
+			if _, ok := index[dep]; !ok {
+				return nil, fmt.Errorf("%w: %s depends on unknown step %s",
+					ErrInvalidStepGraph, step.Name, dep)
+			}
+		}
+	}
+
+	graph := &StepGraph{steps: steps, index: index}
+
+	// 0 = unvisited, 1 = visiting, 2 = done
+	state := make([]int, len(steps))
+	var visit func(i int) error
+	visit = func(i int) error {
+		switch state[i] {
+		case 1:
+			return fmt.Errorf("%w: cycle at step %s", ErrInvalidStepGraph,
+				steps[i].Name)
+		case 2:
+			return nil
+		}
+		state[i] = 1
+		for _, dep := range steps[i].DependsOn {
+			if err := visit(index[dep]); err != nil {
+				return err
+			}
+		}
+		state[i] = 2
+		return nil
+	}
+	for i := range steps {
+		if err := visit(i); err != nil {
+			return nil, err
+		}
+	}
+
+	seedSet := make(map[string]bool, len(seeds))
+	for _, seed := range seeds {
+		seedSet[seed] = true
+	}
+	for _, step := range steps {
+		available := graph.upstreamOutputs(step.Name)
+		for _, input := range step.Inputs {
+			if !seedSet[input] && !available[input] {
+				return nil, fmt.Errorf("%w: input %s of %s is not produced "+
+					"by any dependency", ErrInvalidStepGraph, input,
+					step.Name)
+			}
+		}
+	}
+
+	return graph, nil
+}
+
+func (g *StepGraph) upstreamOutputs(name string) map[string]bool {
+	outputs := map[string]bool{}
+	seen := map[string]bool{}
+	var walk func(string)
+	walk = func(current string) {
+		for _, dep := range g.steps[g.index[current]].DependsOn {
+			if seen[dep] {
+				continue
+			}
+			seen[dep] = true
+			for _, output := range g.steps[g.index[dep]].Outputs {
+				outputs[output.Key] = true
+			}
+			walk(dep)
+		}
+	}
+	walk(name)
+	return outputs
+}
+
+type stepOutcome struct {
+	// unchanged is true when the step was restored or skipped, so its
+	// dependents may still be restored from their own checkpoints.
+	unchanged bool
+	failed    bool
+	err       error
+}
+
+// Run executes every step as soon as its dependencies finish, so independent
+// branches run concurrently. A failed step only stops the steps depending on
+// it; the other branches still finish and save their checkpoints, which keeps
+// the reported error deterministic: when several steps fail, the error of the
+// first one in declaration order is returned.
+func (g *StepGraph) Run(ctx context.Context, data *StepData,
+	opts StepRunOptions) error {
+	done := make([]chan struct{}, len(g.steps))
+	outcomes := make([]stepOutcome, len(g.steps))
+	for i := range g.steps {
+		done[i] = make(chan struct{})
+	}
+
+	var wg sync.WaitGroup
+
+	for i := range g.steps {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			defer close(done[i])
+
+			step := g.steps[i]
+			outcome := &outcomes[i]
+			canRestore := opts.Resume && opts.Checkpoints != nil
+			for _, dep := range step.DependsOn {
+				j := g.index[dep]
+				<-done[j]
+				if outcomes[j].failed {
+					outcome.failed = true
+					return
+				}
+				canRestore = canRestore && outcomes[j].unchanged
+			}
+
+			if err := ctx.Err(); err != nil {
+				outcome.failed = true
+				outcome.err = err
+				return
+			}
+
+			if step.Skip != nil && step.Skip(data) {
+				outcome.unchanged = true
+				return
+			}
+
+			if canRestore && g.restore(step, data, opts.Checkpoints) {
+				if opts.Hooks.OnRestore != nil {
+					opts.Hooks.OnRestore(step.Name)
+				}
+				outcome.unchanged = true
+				return
+			}
+
+			if opts.Hooks.OnStart != nil {
+				opts.Hooks.OnStart(step.Name)
+			}
+
+			stepCtx, cancel := opts.Resources.StepContext(ctx, step.Name)
+			result, err := step.Run(stepCtx, data)
+			limitErr := ResourceError(stepCtx, err)
+			cancel()
+			if err != nil {
+				if limitErr != nil {
+					err = fmt.Errorf("%w: %w", limitErr, err)
+				}
+				if opts.Hooks.OnError != nil {
+					opts.Hooks.OnError(step.Name, err)
+				}
+				if step.Optional {
+					return
+				}
+				outcome.failed = true
+				switch {
+				case limitErr != nil:
+					outcome.err = limitErr
+				case step.Err == nil ||
+					(step.PassInputErrors && IsInputError(err)):
+					outcome.err = err
+				default:
+					outcome.err = step.Err
+				}
+				return
+			}
+
+			if result == nil {
+				result = &StepResult{}
+			}
+			for key, value := range result.Values {
+				data.Set(key, value)
+			}
+
+			if opts.Checkpoints != nil {
+				if err := opts.Checkpoints.Save(step.Name, result.Values,
+					result.Files...); err != nil &&
+					opts.Hooks.OnCheckpointError != nil {
+					opts.Hooks.OnCheckpointError(step.Name, err)
+				}
+			}
+		}(i)
+	}
+
+	wg.Wait()
+
+	for _, outcome := range outcomes {
+		if outcome.err != nil {
+			return outcome.err
+		}
+	}
+	return nil
+}
+
+func (g *StepGraph) restore(step Step, data *StepData,
+	checkpoints *CheckpointStore) bool {
+	var raw map[string]json.RawMessage
+	if !checkpoints.Load(step.Name, &raw) {
+		return false
+	}
+
+	values := make(map[string]any, len(raw))
+	for _, output := range step.Outputs {
+		encoded, ok := raw[output.Key]
+		if !ok {
+			continue
+		}
+		value, err := output.decode(encoded)
+		if err != nil {
+			return false
+		}
+		values[output.Key] = value
+	}
+
+	for key, value := range values {
+		data.Set(key, value)
+	}
+	return true
+}
+
+// IsInputError reports whether err was caused by the input files rather than
+// by the tool itself.
+func IsInputError(err error) bool {
+	return errors.Is(err, ErrCorruptedInput) ||
+		errors.Is(err, ErrEmptyReads) ||
+		errors.Is(err, ErrInvalidFormat) ||
+		errors.Is(err, ErrUnpairedReads) ||
+		errors.Is(err, ErrFileNotFound)
+}
@@ -0,0 +1,412 @@
+package pipeline
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func valueStep(name, key, value string, deps ...string) Step {
+	return Step{
+		Name:      name,
+		DependsOn: deps,
+		Outputs:   []StepOutput{Output[string](key)},
+		Run: func(_ context.Context, _ *StepData) (*StepResult, error) {
+			return &StepResult{Values: map[string]any{key: value}}, nil
+		},
+	}
+}
+
+func failingStep(name string, stepErr, runErr error, deps ...string) Step {
+	return Step{
+		Name:      name,
+		DependsOn: deps,
+		Err:       stepErr,
+		Run: func(_ context.Context, _ *StepData) (*StepResult, error) {
+			return nil, runErr
+		},
+	}
+}
+
+func TestNewStepGraph(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		step := valueStep("B", "b", "x", "A")
+		step.Inputs = []string{"a", "seed"}
+
+		graph, err := NewStepGraph([]string{"seed"},
+			valueStep("A", "a", "x"), step)
+
+		assert.NoError(t, err)
+		assert.NotNil(t, graph)
+	})
+
+	t.Run("Error - Missing Run Func", func(t *testing.T) {
+		_, err := NewStepGraph(nil, Step{Name: "A"})
+		assert.ErrorIs(t, err, ErrInvalidStepGraph)
+	})
+
+	t.Run("Error - Duplicated Step", func(t *testing.T) {
+		_, err := NewStepGraph(nil, valueStep("A", "a", "x"),
+			valueStep("A", "b", "x"))
+		assert.ErrorIs(t, err, ErrInvalidStepGraph)
+	})
+
+	t.Run("Error - Unknown Dependency", func(t *testing.T) {
+		_, err := NewStepGraph(nil, valueStep("A", "a", "x", "Z"))
+		assert.ErrorIs(t, err, ErrInvalidStepGraph)
+	})
+
+	t.Run("Error - Cycle", func(t *testing.T) {
+		_, err := NewStepGraph(nil, valueStep("A", "a", "x", "B"),
+			valueStep("B", "b", "x", "A"))
+		assert.ErrorIs(t, err, ErrInvalidStepGraph)
+	})
+
+	t.Run("Error - Input Not Produced Upstream", func(t *testing.T) {
+		step := valueStep("B", "b", "x")
+		step.Inputs = []string{"a"}
+
+		_, err := NewStepGraph(nil, valueStep("A", "a", "x"), step)
+		assert.ErrorIs(t, err, ErrInvalidStepGraph)
+	})
+}
+
+func TestStepGraphRun(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Success - Independent Steps Run Concurrently", func(t *testing.T) {
+		var started sync.WaitGroup
+		started.Add(2)
+		release := make(chan struct{})
+		blocking := func(name string) Step {
+			return Step{
+				Name:      name,
+				DependsOn: []string{"Root"},
+				Run: func(_ context.Context, _ *StepData) (*StepResult,
+					error) {
+					started.Done()
+					<-release
+					return nil, nil
+				},
+			}
+		}
+
+		graph, err := NewStepGraph(nil, valueStep("Root", "root", "x"),
+			blocking("Left"), blocking("Right"))
+		assert.NoError(t, err)
+
+		go func() {
+			started.Wait()
+			close(release)
+		}()
+
+		errCh := make(chan error, 1)
+		go func() { errCh <- graph.Run(ctx, NewStepData(), StepRunOptions{}) }()
+
+		select {
+		case err := <-errCh:
+			assert.NoError(t, err)
+		case <-time.After(5 * time.Second):
+			t.Fatal("independent steps did not run concurrently")
+		}
+	})
+
+	t.Run("Success - Outputs Are Visible To Dependents", func(t *testing.T) {
+		var got string
+		consumer := Step{
+			Name:      "B",
+			DependsOn: []string{"A"},
+			Inputs:    []string{"a"},
+			Run: func(_ context.Context, data *StepData) (*StepResult,
+				error) {
+				got, _ = Value[string](data, "a")
+				return nil, nil
+			},
+		}
+
+		graph, err := NewStepGraph(nil, valueStep("A", "a", "hello"),
+			consumer)
+		assert.NoError(t, err)
+
+		data := NewStepData()
+		assert.NoError(t, graph.Run(ctx, data, StepRunOptions{}))
+		assert.Equal(t, "hello", got)
+		assert.Equal(t, "hello", data.Snapshot()["a"])
+	})
+
+	t.Run("Success - Skipped Step Satisfies Dependents", func(t *testing.T) {
+		skipped := valueStep("A", "a", "x")
+		skipped.Skip = func(_ *StepData) bool { return true }
+		ran := false
+		dependent := Step{
+			Name:      "B",
+			DependsOn: []string{"A"},
+			Run: func(_ context.Context, _ *StepData) (*StepResult,
+				error) {
+				ran = true
+				return nil, nil
+			},
+		}
+
+		graph, err := NewStepGraph(nil, skipped, dependent)
+		assert.NoError(t, err)
+
+		data := NewStepData()
+		assert.NoError(t, graph.Run(ctx, data, StepRunOptions{}))
+		_, ok := data.Get("a")
+		assert.False(t, ok)
+		assert.True(t, ran)
+	})
+
+	t.Run("Success - Optional Step Failure", func(t *testing.T) {
+		optional := failingStep("A", ErrCheckM, errors.New("boom"))
+		optional.Optional = true
+		var failed string
+
+		graph, err := NewStepGraph(nil, optional, valueStep("B", "b", "x"))
+		assert.NoError(t, err)
+
+		err = graph.Run(ctx, NewStepData(), StepRunOptions{
+			Hooks: StepHooks{OnError: func(step string, _ error) {
+				failed = step
+			}},
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, "A", failed)
+	})
+
+	t.Run("Success - Resume Restores Checkpoints", func(t *testing.T) {
+		store := NewCheckpointStore(t.TempDir())
+		runs := 0
+		step := Step{
+			Name:    "A",
+			Outputs: []StepOutput{Output[[]string]("genes")},
+			Run: func(_ context.Context, _ *StepData) (*StepResult,
+				error) {
+				runs++
+				return &StepResult{Values: map[string]any{
+					"genes": []string{"blaTEM"},
+				}}, nil
+			},
+		}
+
+		graph, err := NewStepGraph(nil, step)
+		assert.NoError(t, err)
+
+		assert.NoError(t, graph.Run(ctx, NewStepData(),
+			StepRunOptions{Checkpoints: store}))
+
+		var restored []string
+		data := NewStepData()
+		assert.NoError(t, graph.Run(ctx, data, StepRunOptions{
+			Checkpoints: store,
+			Resume:      true,
+			Hooks: StepHooks{OnRestore: func(step string) {
+				restored = append(restored, step)
+			}},
+		}))
+
+		genes, ok := Value[[]string](data, "genes")
+		assert.True(t, ok)
+		assert.Equal(t, []string{"blaTEM"}, genes)
+		assert.Equal(t, 1, runs)
+		assert.Equal(t, []string{"A"}, restored)
+	})
+
+	t.Run("Success - Rerun Step Invalidates Dependents", func(t *testing.T) {
+		store := NewCheckpointStore(t.TempDir())
+		runs := map[string]int{}
+		var mu sync.Mutex
+		counting := func(name string, deps ...string) Step {
+			return Step{
+				Name:      name,
+				DependsOn: deps,
+				Run: func(_ context.Context, _ *StepData) (*StepResult,
+					error) {
+					mu.Lock()
+					defer mu.Unlock()
+					runs[name]++
+					return nil, nil
+				},
+			}
+		}
+
+		graph, err := NewStepGraph(nil, counting("A"), counting("B", "A"),
+			counting("C"))
+		assert.NoError(t, err)
+		assert.NoError(t, graph.Run(ctx, NewStepData(),
+			StepRunOptions{Checkpoints: store}))
+
+		assert.NoError(t, os.Remove(store.markerPath("A")))
+		assert.NoError(t, graph.Run(ctx, NewStepData(),
+			StepRunOptions{Checkpoints: store, Resume: true}))
+
+		assert.Equal(t, 2, runs["A"])
+		assert.Equal(t, 2, runs["B"])
+		assert.Equal(t, 1, runs["C"])
+	})
+
+	t.Run("Error - Step Error Replaces Tool Error", func(t *testing.T) {
+		graph, err := NewStepGraph(nil,
+			failingStep("A", ErrKraken2, errors.New("kraken2 crashed")))
+		assert.NoError(t, err)
+
+		err = graph.Run(ctx, NewStepData(), StepRunOptions{})
+		assert.Equal(t, ErrKraken2, err)
+	})
+
+	t.Run("Error - Input Error Passed Through", func(t *testing.T) {
+		step := failingStep("A", ErrUnicycler, ErrCorruptedInput)
+		step.PassInputErrors = true
+
+		graph, err := NewStepGraph(nil, step)
+		assert.NoError(t, err)
+
+		err = graph.Run(ctx, NewStepData(), StepRunOptions{})
+		assert.ErrorIs(t, err, ErrCorruptedInput)
+	})
+
+	t.Run("Error - Input Error Not Passed Through", func(t *testing.T) {
+		graph, err := NewStepGraph(nil,
+			failingStep("A", ErrCheckM, ErrCorruptedInput))
+		assert.NoError(t, err)
+
+		err = graph.Run(ctx, NewStepData(), StepRunOptions{})
+		assert.Equal(t, ErrCheckM, err)
+	})
+
+	t.Run("Error - First Failure In Declaration Order", func(t *testing.T) {
+		release := make(chan struct{})
+		slow := failingStep("B", ErrCheckM, errors.New("checkm"), "A")
+		slow.Run = func(_ context.Context, _ *StepData) (*StepResult, error) {
+			<-release
+			return nil, errors.New("checkm")
+		}
+		fast := failingStep("C", ErrAbricate, errors.New("abricate"))
+		fastRun := fast.Run
+		fast.Run = func(ctx context.Context, data *StepData) (*StepResult,
+			error) {
+			defer close(release)
+			return fastRun(ctx, data)
+		}
+
+		graph, err := NewStepGraph(nil, valueStep("A", "a", "x"), slow,
+			fast)
+		assert.NoError(t, err)
+
+		err = graph.Run(ctx, NewStepData(), StepRunOptions{})
+		assert.Equal(t, ErrCheckM, err)
+	})
+
+	t.Run("Error - Independent Branches Still Run", func(t *testing.T) {
+		ran := false
+		independent := valueStep("B", "b", "x")
+		independent.Run = func(_ context.Context, _ *StepData) (*StepResult,
+			error) {
+			ran = true
+			return nil, nil
+		}
+
+		graph, err := NewStepGraph(nil,
+			failingStep("A", ErrProkka, errors.New("prokka crashed")),
+			independent)
+		assert.NoError(t, err)
+
+		err = graph.Run(ctx, NewStepData(), StepRunOptions{})
+		assert.Equal(t, ErrProkka, err)
+		assert.True(t, ran)
+	})
+
+	t.Run("Error - Dependents Of Failed Step Do Not Run", func(t *testing.T) {
+		ran := false
+		dependent := valueStep("B", "b", "x", "A")
+		dependent.Run = func(_ context.Context, _ *StepData) (*StepResult,
+			error) {
+			ran = true
+			return nil, nil
+		}
+
+		graph, err := NewStepGraph(nil,
+			failingStep("A", ErrProkka, errors.New("prokka crashed")),
+			dependent)
+		assert.NoError(t, err)
+
+		err = graph.Run(ctx, NewStepData(), StepRunOptions{})
+		assert.Equal(t, ErrProkka, err)
+		assert.False(t, ran)
+	})
+
+	t.Run("Success - Step Runs With Its Resources", func(t *testing.T) {
+		var got StepResources
+		step := valueStep(StepNameProkka, "a", "x")
+		step.Run = func(ctx context.Context, _ *StepData) (*StepResult,
+			error) {
+			got = StepResourcesFromContext(ctx)
+			return nil, nil
+		}
+
+		graph, err := NewStepGraph(nil, step)
+		assert.NoError(t, err)
+
+		err = graph.Run(ctx, NewStepData(), StepRunOptions{
+			Resources: &ResourceLimits{
+				Default: StepResources{Threads: 4},
+				Steps: map[string]StepResources{
+					StepNameProkka: {Threads: 8, MaxMemory: 1 << 30},
+				},
+			},
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, StepResources{Threads: 8, MaxMemory: 1 << 30}, got)
+	})
+
+	t.Run("Error - Step Timeout Replaces Step Error", func(t *testing.T) {
+		step := failingStep("A", ErrUnicycler, nil)
+		step.Run = func(ctx context.Context, _ *StepData) (*StepResult,
+			error) {
+			<-ctx.Done()
+			return nil, ctx.Err()
+		}
+
+		graph, err := NewStepGraph(nil, step)
+		assert.NoError(t, err)
+
+		err = graph.Run(ctx, NewStepData(), StepRunOptions{
+			Resources: &ResourceLimits{
+				Default: StepResources{Timeout: 10 * time.Millisecond},
+			},
+		})
+		assert.Equal(t, ErrStepTimeout, err)
+	})
+
+	t.Run("Error - Step Out Of Memory", func(t *testing.T) {
+		graph, err := NewStepGraph(nil, failingStep("A", ErrKraken2,
+			fmt.Errorf("%w: %w", errOOMKilled,
+				errors.New("signal: killed"))))
+		assert.NoError(t, err)
+
+		err = graph.Run(ctx, NewStepData(), StepRunOptions{
+			Resources: &ResourceLimits{
+				Default: StepResources{MaxMemory: 1 << 30},
+			},
+		})
+		assert.Equal(t, ErrStepOutOfMemory, err)
+	})
+
+	t.Run("Error - Cancelled Context", func(t *testing.T) {
+		cancelled, cancel := context.WithCancel(ctx)
+		cancel()
+
+		graph, err := NewStepGraph(nil, valueStep("A", "a", "x"))
+		assert.NoError(t, err)
+
+		err = graph.Run(cancelled, NewStepData(), StepRunOptions{})
+		assert.ErrorIs(t, err, context.Canceled)
+	})
+}
@@ -11,23 +11,48 @@ import (
 	"strings"
 )
 
+// Kraken2 report rank codes kept in the composition.
+const (
+	krakenRankUnclassified = "U"
+	krakenRankGenus        = "G"
+	krakenRankSpecies      = "S"
+)
+
 type KrakenSpecies struct {
-	Name  string
-	Count int
+	Name    string
+	Count   int
+	Percent float64
+}
+
+// KrakenTaxon is a line of the Kraken2 report. Percent is the share of the
+// classified sequences in the clade, as reported by Kraken2.
+type KrakenTaxon struct {
+	Name       string  `json:"name"`
+	TaxID      int     `json:"taxid"`
+	CladeReads int     `json:"clade_reads"`
+	Percent    float64 `json:"percent"`
 }
 
-func KrakenSpeciesCounter(krakenReport string) (*KrakenSpecies, *KrakenSpecies,
-	error) {
+// KrakenComposition is the species- and genus-level composition of a
+// sample, each level sorted by clade reads.
+type KrakenComposition struct {
+	Species      []KrakenTaxon `json:"species"`
+	Genus        []KrakenTaxon `json:"genus"`
+	Unclassified float64       `json:"unclassified_percent"`
+}
+
+// ParseKrakenReport reads the species and genus lines of a Kraken2 report.
+func ParseKrakenReport(krakenReport string) (*KrakenComposition, error) {
 	file, err := os.Open(krakenReport)
 	if err != nil {
-		return nil, nil, fmt.Errorf("Kraken report file not found: %v", err)
+		return nil, fmt.Errorf("Kraken report file not found: %v", err)
 	}
 	defer file.Close()
 
 	br := bufio.NewReader(file)
 	_, err = br.Peek(1)
 	if err == io.EOF {
-		return nil, nil, errors.New("Empty Kraken report")
+		return nil, errors.New("Empty Kraken report")
 	}
 
 	scanner := bufio.NewScanner(br)
@@ -35,7 +60,7 @@ func KrakenSpeciesCounter(krakenReport string) (*KrakenSpecies, *KrakenSpecies,
 	lineBuf := make([]byte, 1024*64)
 	scanner.Buffer(lineBuf, maxCapacity)
 
-	var species []KrakenSpecies
+	composition := &KrakenComposition{}
 
 	for scanner.Scan() {
 		fields := strings.Split(scanner.Text(), "\t")
@@ -43,7 +68,13 @@ func KrakenSpeciesCounter(krakenReport string) (*KrakenSpecies, *KrakenSpecies,
 			continue
 		}
 
-		if strings.TrimSpace(fields[3]) != "S" {
+		rank := strings.TrimSpace(fields[3])
+		percent, _ := strconv.ParseFloat(strings.TrimSpace(fields[0]), 64)
+		if rank == krakenRankUnclassified {
+			composition.Unclassified = percent
+			continue
+		}
+		if rank != krakenRankSpecies && rank != krakenRankGenus {
 			continue
 		}
 
@@ -57,27 +88,86 @@ func KrakenSpeciesCounter(krakenReport string) (*KrakenSpecies, *KrakenSpecies,
 			continue
 		}
 
-		species = append(species, KrakenSpecies{Name: name, Count: cladeReads})
+		taxID, _ := strconv.Atoi(strings.TrimSpace(fields[4]))
+		taxon := KrakenTaxon{
+			Name: name, TaxID: taxID, CladeReads: cladeReads, Percent: percent,
+		}
+		if rank == krakenRankSpecies {
+			composition.Species = append(composition.Species, taxon)
+		} else {
+			composition.Genus = append(composition.Genus, taxon)
+		}
 	}
 
 	if err := scanner.Err(); err != nil {
-		return nil, nil, fmt.Errorf("error reading kraken2 report: %w", err)
+		return nil, fmt.Errorf("error reading kraken2 report: %w", err)
 	}
 
-	sort.Slice(species, func(i, j int) bool {
-		if species[i].Count == species[j].Count {
-			return species[i].Name < species[j].Name
+	sortKrakenTaxa(composition.Species)
+	sortKrakenTaxa(composition.Genus)
+
+	return composition, nil
+}
+
+func sortKrakenTaxa(taxa []KrakenTaxon) {
+	sort.Slice(taxa, func(i, j int) bool {
+		if taxa[i].CladeReads == taxa[j].CladeReads {
+			return taxa[i].Name < taxa[j].Name
 		}
-		return species[i].Count > species[j].Count
+		return taxa[i].CladeReads > taxa[j].CladeReads
 	})
+}
 
-	var first, second *KrakenSpecies
-	if len(species) > 0 {
-		first = &species[0]
+// Filter returns the taxa at or above minPercent.
+func (c *KrakenComposition) Filter(minPercent float64) *KrakenComposition {
+	keep := func(taxa []KrakenTaxon) []KrakenTaxon {
+		kept := []KrakenTaxon{}
+		for _, taxon := range taxa {
+			if taxon.Percent >= minPercent {
+				kept = append(kept, taxon)
+			}
+		}
+		return kept
+	}
+
+	return &KrakenComposition{
+		Species:      keep(c.Species),
+		Genus:        keep(c.Genus),
+		Unclassified: c.Unclassified,
+	}
+}
+
+// Primary returns the species with the most clade reads, or nil.
+func (c *KrakenComposition) Primary() *KrakenSpecies {
+	return c.species(0)
+}
+
+// Secondary returns the species with the second most clade reads, or nil.
+func (c *KrakenComposition) Secondary() *KrakenSpecies {
+	return c.species(1)
+}
+
+func (c *KrakenComposition) species(i int) *KrakenSpecies {
+	if c == nil || len(c.Species) <= i {
+		return nil
 	}
-	if len(species) > 1 {
-		second = &species[1]
+	taxon := c.Species[i]
+	return &KrakenSpecies{
+		Name: taxon.Name, Count: taxon.CladeReads, Percent: taxon.Percent,
 	}
+}
 
-	return first, second, nil
+// SpeciesTaxID returns the taxid of the species named name, or 0 when it is
+// not in the composition.
+func (c *KrakenComposition) SpeciesTaxID(name string) int {
+	normalized := NormalizeSpeciesName(name)
+	if c == nil || normalized == "" {
+		return 0
+	}
+	for _, taxon := range c.Species {
+		if NormalizeSpeciesName(taxon.Name) == normalized {
+			return taxon.TaxID
+		}
+	}
+	return 0
 }
@@ -28,13 +28,21 @@ func krakenReportLine(name string, cladeReads int) string {
 		name)
 }
 
-func TestKrakenSpeciesCounter(t *testing.T) {
+func krakenTopSpecies(path string) (*KrakenSpecies, *KrakenSpecies, error) {
+	composition, err := ParseKrakenReport(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	return composition.Primary(), composition.Secondary(), nil
+}
+
+func TestParseKrakenReport(t *testing.T) {
 	t.Run("Success - Single Clade Each Species", func(t *testing.T) {
 		mockContent := krakenReportLine("Escherichia coli", 1) +
 			krakenReportLine("Klebsiella pneumoniae", 1)
 		path := createMockKrakenFile(t, mockContent)
 
-		first, second, err := KrakenSpeciesCounter(path)
+		first, second, err := krakenTopSpecies(path)
 		assert.NoError(t, err)
 		assert.NotNil(t, first)
 		assert.NotNil(t, second)
@@ -50,7 +58,7 @@ func TestKrakenSpeciesCounter(t *testing.T) {
 			krakenReportLine("Klebsiella pneumoniae", 1)
 		path := createMockKrakenFile(t, mockContent)
 
-		first, second, err := KrakenSpeciesCounter(path)
+		first, second, err := krakenTopSpecies(path)
 		assert.NoError(t, err)
 		assert.NotNil(t, first)
 		assert.Equal(t, "Escherichia coli", first.Name)
@@ -65,7 +73,7 @@ func TestKrakenSpeciesCounter(t *testing.T) {
 			krakenReportLine("Klebsiella pneumoniae", 1)
 		path := createMockKrakenFile(t, mockContent)
 
-		first, second, err := KrakenSpeciesCounter(path)
+		first, second, err := krakenTopSpecies(path)
 		assert.NoError(t, err)
 		assert.NotNil(t, first)
 		assert.Equal(t, "Escherichia coli (strain K12)", first.Name)
@@ -80,7 +88,7 @@ func TestKrakenSpeciesCounter(t *testing.T) {
 			krakenReportLine("Klebsiella pneumoniae", 1)
 		path := createMockKrakenFile(t, mockContent)
 
-		first, second, err := KrakenSpeciesCounter(path)
+		first, second, err := krakenTopSpecies(path)
 		assert.NoError(t, err)
 		assert.NotNil(t, first)
 		assert.Equal(t, "Escherichia coli", first.Name)
@@ -95,7 +103,7 @@ func TestKrakenSpeciesCounter(t *testing.T) {
 			krakenReportLine("Alpha", 1)
 		path := createMockKrakenFile(t, mockContent)
 
-		first, second, err := KrakenSpeciesCounter(path)
+		first, second, err := krakenTopSpecies(path)
 		assert.NoError(t, err)
 		assert.NotNil(t, first)
 		assert.Equal(t, "Alpha", first.Name)
@@ -107,7 +115,7 @@ func TestKrakenSpeciesCounter(t *testing.T) {
 		mockContent := krakenReportLine("Escherichia coli", 2)
 		path := createMockKrakenFile(t, mockContent)
 
-		first, second, err := KrakenSpeciesCounter(path)
+		first, second, err := krakenTopSpecies(path)
 		assert.NoError(t, err)
 		assert.NotNil(t, first)
 		assert.Equal(t, "Escherichia coli", first.Name)
@@ -115,12 +123,12 @@ func TestKrakenSpeciesCounter(t *testing.T) {
 		assert.Nil(t, second)
 	})
 
-	t.Run("Success - Non-Species Ranks Ignored", func(t *testing.T) {
+	t.Run("Success - Genus Not Counted As Species", func(t *testing.T) {
 		mockContent := "50.00\t10\t10\tG\t561\tEscherichia" + "\n" +
 			krakenReportLine("Escherichia coli", 1)
 		path := createMockKrakenFile(t, mockContent)
 
-		first, second, err := KrakenSpeciesCounter(path)
+		first, second, err := krakenTopSpecies(path)
 		assert.NoError(t, err)
 		assert.NotNil(t, first)
 		assert.Equal(t, "Escherichia coli", first.Name)
@@ -128,12 +136,39 @@ func TestKrakenSpeciesCounter(t *testing.T) {
 		assert.Nil(t, second)
 	})
 
+	t.Run("Success - Composition Fields", func(t *testing.T) {
+		mockContent := "4.50\t45\t45\tU\t0\tunclassified\n" +
+			"80.00\t800\t10\tG\t561\t    Escherichia\n" +
+			"79.50\t795\t795\tS\t562\t      Escherichia coli\n" +
+			"15.00\t150\t0\tG\t570\t    Klebsiella\n" +
+			"15.00\t150\t150\tS\t573\t      Klebsiella pneumoniae\n"
+		path := createMockKrakenFile(t, mockContent)
+
+		composition, err := ParseKrakenReport(path)
+		assert.NoError(t, err)
+		assert.Equal(t, 4.5, composition.Unclassified)
+		assert.Equal(t, []KrakenTaxon{
+			{Name: "Escherichia coli", TaxID: 562, CladeReads: 795,
+				Percent: 79.5},
+			{Name: "Klebsiella pneumoniae", TaxID: 573, CladeReads: 150,
+				Percent: 15},
+		}, composition.Species)
+		assert.Equal(t, []KrakenTaxon{
+			{Name: "Escherichia", TaxID: 561, CladeReads: 800, Percent: 80},
+			{Name: "Klebsiella", TaxID: 570, CladeReads: 150, Percent: 15},
+		}, composition.Genus)
+
+		secondary := composition.Secondary()
+		assert.NotNil(t, secondary)
+		assert.Equal(t, 15.0, secondary.Percent)
+	})
+
 	t.Run("Success - Lines With Fewer Than 6 Fields Ignored", func(t *testing.T) {
 		mockContent := "1.00\t1\t1\tS\n" +
 			krakenReportLine("Escherichia coli", 1)
 		path := createMockKrakenFile(t, mockContent)
 
-		first, second, err := KrakenSpeciesCounter(path)
+		first, second, err := krakenTopSpecies(path)
 		assert.NoError(t, err)
 		assert.NotNil(t, first)
 		assert.Equal(t, "Escherichia coli", first.Name)
@@ -147,7 +182,7 @@ func TestKrakenSpeciesCounter(t *testing.T) {
 			krakenReportLine("Klebsiella pneumoniae", 1)
 		path := createMockKrakenFile(t, mockContent)
 
-		first, second, err := KrakenSpeciesCounter(path)
+		first, second, err := krakenTopSpecies(path)
 		assert.NoError(t, err)
 		assert.NotNil(t, first)
 		assert.NotNil(t, second)
@@ -164,7 +199,7 @@ func TestKrakenSpeciesCounter(t *testing.T) {
 			krakenReportLine("Klebsiella pneumoniae", 1)
 		path := createMockKrakenFile(t, mockContent)
 
-		first, second, err := KrakenSpeciesCounter(path)
+		first, second, err := krakenTopSpecies(path)
 		assert.NoError(t, err)
 		assert.NotNil(t, first)
 		assert.Equal(t, "Klebsiella pneumoniae", first.Name)
@@ -175,7 +210,7 @@ func TestKrakenSpeciesCounter(t *testing.T) {
 	t.Run("Success - Empty File Returns Error", func(t *testing.T) {
 		path := createMockKrakenFile(t, "")
 
-		first, second, err := KrakenSpeciesCounter(path)
+		first, second, err := krakenTopSpecies(path)
 		assert.Error(t, err)
 		assert.Nil(t, first)
 		assert.Nil(t, second)
@@ -183,10 +218,57 @@ func TestKrakenSpeciesCounter(t *testing.T) {
 	})
 
 	t.Run("Error - File Not Found", func(t *testing.T) {
-		first, second, err := KrakenSpeciesCounter("nonexistent_path.txt")
+		first, second, err := krakenTopSpecies("nonexistent_path.txt")
 		assert.Error(t, err)
 		assert.Nil(t, first)
 		assert.Nil(t, second)
 		assert.Contains(t, err.Error(), "Kraken report file not found")
 	})
 }
+
+func TestKrakenCompositionFilter(t *testing.T) {
+	composition := &KrakenComposition{
+		Species: []KrakenTaxon{
+			{Name: "Escherichia coli", CladeReads: 950, Percent: 95},
+			{Name: "Klebsiella pneumoniae", CladeReads: 1, Percent: 0.1},
+			{Name: "Salmonella enterica", CladeReads: 0, Percent: 0.01},
+		},
+		Genus: []KrakenTaxon{
+			{Name: "Escherichia", CladeReads: 950, Percent: 95},
+		},
+		Unclassified: 4.89,
+	}
+
+	t.Run("Success - Below Cut-Off Dropped", func(t *testing.T) {
+		filtered := composition.Filter(0.1)
+		assert.Len(t, filtered.Species, 2)
+		assert.Equal(t, "Klebsiella pneumoniae", filtered.Species[1].Name)
+		assert.Len(t, filtered.Genus, 1)
+		assert.Equal(t, 4.89, filtered.Unclassified)
+		assert.Len(t, composition.Species, 3)
+	})
+
+	t.Run("Success - Nothing Kept", func(t *testing.T) {
+		filtered := composition.Filter(100)
+		assert.NotNil(t, filtered.Species)
+		assert.Empty(t, filtered.Species)
+		assert.Nil(t, filtered.Primary())
+	})
+
+	t.Run("Success - Species TaxID", func(t *testing.T) {
+		withTaxID := &KrakenComposition{Species: []KrakenTaxon{
+			{Name: "Escherichia coli", TaxID: 562},
+		}}
+		assert.Equal(t, 562,
+			withTaxID.SpeciesTaxID("Escherichia coli (strain K12)"))
+		assert.Equal(t, 0, withTaxID.SpeciesTaxID("Klebsiella pneumoniae"))
+		assert.Equal(t, 0, withTaxID.SpeciesTaxID(""))
+	})
+
+	t.Run("Success - Nil Composition", func(t *testing.T) {
+		var empty *KrakenComposition
+		assert.Nil(t, empty.Primary())
+		assert.Nil(t, empty.Secondary())
+		assert.Equal(t, 0, empty.SpeciesTaxID("Escherichia coli"))
+	})
+}
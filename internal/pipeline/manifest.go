@@ -0,0 +1,127 @@
+package pipeline
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/pelletier/go-toml/v2"
+)
+
+// ToolManifest pins the tool versions and the database fingerprints a
+// worker must run with. A tool version matches when it is equal to the
+// pinned one or extends it ("2.1" matches "2.1.3"). A database matches when
+// its checksum, and its version when one is pinned, are equal.
+type ToolManifest struct {
+	Version   string                      `toml:"version" json:"version"`
+	Tools     map[string]string           `toml:"tools" json:"tools"`
+	Databases map[string]ManifestDatabase `toml:"databases" json:"databases"`
+}
+
+type ManifestDatabase struct {
+	Checksum string `toml:"checksum" json:"checksum"`
+	Version  string `toml:"version" json:"version,omitempty"`
+}
+
+// ManifestMismatch is a tool or database of the worker that differs from the
+// manifest. Actual is empty when it is missing.
+type ManifestMismatch struct {
+	Kind     string `json:"kind"`
+	Name     string `json:"name"`
+	Expected string `json:"expected"`
+	Actual   string `json:"actual"`
+}
+
+func (m ManifestMismatch) String() string {
+	actual := m.Actual
+	if actual == "" {
+		actual = "missing"
+	}
+	return fmt.Sprintf("%s %s: expected %s, found %s", m.Kind, m.Name,
+		m.Expected, actual)
+}
+
+// LoadToolManifest reads the manifest at path.
+func LoadToolManifest(path string) (*ToolManifest, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read tool manifest: %w", err)
+	}
+
+	var manifest ToolManifest
+	decoder := toml.NewDecoder(bytes.NewReader(raw))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&manifest); err != nil {
+		return nil, fmt.Errorf("invalid tool manifest %s: %w", path, err)
+	}
+
+	for name, database := range manifest.Databases {
+		if database.Checksum == "" {
+			return nil, fmt.Errorf(
+				"invalid tool manifest %s: databases.%s: checksum is required",
+				path, name)
+		}
+	}
+
+	return &manifest, nil
+}
+
+// Check returns the tools and databases that do not match the manifest,
+// sorted by kind and name. Tools and databases the manifest does not pin
+// are not checked.
+func (m *ToolManifest) Check(tools []ToolVersion,
+	databases []DatabaseFingerprint) []ManifestMismatch {
+	versions := make(map[string]string, len(tools))
+	for _, tool := range tools {
+		if tool.Version != "unknown" {
+			versions[tool.Name] = tool.Version
+		}
+	}
+	fingerprints := make(map[string]DatabaseFingerprint, len(databases))
+	for _, database := range databases {
+		if database.Checksum != "" {
+			fingerprints[database.Name] = database
+		}
+	}
+
+	var mismatches []ManifestMismatch
+	for name, required := range m.Tools {
+		version := versions[name]
+		if version != required &&
+			!strings.HasPrefix(version, required+".") {
+			mismatches = append(mismatches, ManifestMismatch{
+				Kind: "tool", Name: name, Expected: required, Actual: version,
+			})
+		}
+	}
+
+	for name, required := range m.Databases {
+		fingerprint, ok := fingerprints[name]
+		switch {
+		case !ok:
+			mismatches = append(mismatches, ManifestMismatch{
+				Kind: "database", Name: name, Expected: required.Checksum,
+			})
+		case fingerprint.Checksum != required.Checksum:
+			mismatches = append(mismatches, ManifestMismatch{
+				Kind: "database", Name: name, Expected: required.Checksum,
+				Actual: fingerprint.Checksum,
+			})
+		case required.Version != "" && fingerprint.Version != required.Version:
+			mismatches = append(mismatches, ManifestMismatch{
+				Kind: "database", Name: name, Expected: required.Version,
+				Actual: fingerprint.Version,
+			})
+		}
+	}
+
+	sort.Slice(mismatches, func(i, j int) bool {
+		if mismatches[i].Kind == mismatches[j].Kind {
+			return mismatches[i].Name < mismatches[j].Name
+		}
+		return mismatches[i].Kind > mismatches[j].Kind
+	})
+	return mismatches
+}
@@ -0,0 +1,127 @@
+package pipeline
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func writeToolManifest(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "manifest.toml")
+	assert.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	return path
+}
+
+func TestLoadToolManifest(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		path := writeToolManifest(t, `
+version = "2024.06"
+
+[tools]
+Prokka = "1.14"
+Kraken2 = "2.1.3"
+
+[databases.kraken2]
+checksum = "abc"
+
+[databases."abricate/vfdb"]
+checksum = "def"
+version = "2023-Jan-12 (2597 sequences)"
+`)
+
+		manifest, err := LoadToolManifest(path)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "2024.06", manifest.Version)
+		assert.Equal(t, "1.14", manifest.Tools["Prokka"])
+		assert.Equal(t, "abc", manifest.Databases["kraken2"].Checksum)
+		assert.Equal(t, "2023-Jan-12 (2597 sequences)",
+			manifest.Databases["abricate/vfdb"].Version)
+	})
+
+	t.Run("Error - Unknown Field", func(t *testing.T) {
+		path := writeToolManifest(t, "version = \"1\"\nimages = {}\n")
+
+		_, err := LoadToolManifest(path)
+
+		assert.Error(t, err)
+	})
+
+	t.Run("Error - Missing Checksum", func(t *testing.T) {
+		path := writeToolManifest(t, "[databases.kraken2]\nversion = \"1\"\n")
+
+		_, err := LoadToolManifest(path)
+
+		assert.ErrorContains(t, err, "databases.kraken2: checksum is required")
+	})
+
+	t.Run("Error - Missing File", func(t *testing.T) {
+		_, err := LoadToolManifest(filepath.Join(t.TempDir(), "missing"))
+
+		assert.Error(t, err)
+	})
+}
+
+func TestToolManifestCheck(t *testing.T) {
+	manifest := &ToolManifest{
+		Tools: map[string]string{
+			"Prokka": "1.14", "Kraken2": "2.1.3", "CheckM": "1.2.2",
+		},
+		Databases: map[string]ManifestDatabase{
+			"kraken2":       {Checksum: "abc"},
+			"abricate/vfdb": {Checksum: "def", Version: "2023-Jan-12"},
+			"amrfinder":     {Checksum: "ghi"},
+		},
+	}
+
+	t.Run("Success - Matching", func(t *testing.T) {
+		mismatches := manifest.Check(
+			[]ToolVersion{
+				{Name: "Prokka", Version: "1.14.6"},
+				{Name: "Kraken2", Version: "2.1.3"},
+				{Name: "CheckM", Version: "1.2.2"},
+				{Name: "MLST", Version: "2.23.0"},
+			},
+			[]DatabaseFingerprint{
+				{Name: "kraken2", Checksum: "abc"},
+				{Name: "abricate/vfdb", Checksum: "def",
+					Version: "2023-Jan-12"},
+				{Name: "amrfinder", Checksum: "ghi"},
+			},
+		)
+
+		assert.Empty(t, mismatches)
+	})
+
+	t.Run("Mismatches", func(t *testing.T) {
+		mismatches := manifest.Check(
+			[]ToolVersion{
+				{Name: "Prokka", Version: "1.145"},
+				{Name: "Kraken2", Version: "2.1.3"},
+				{Name: "CheckM", Version: "unknown"},
+			},
+			[]DatabaseFingerprint{
+				{Name: "kraken2", Checksum: "other"},
+				{Name: "abricate/vfdb", Checksum: "def",
+					Version: "2024-Mar-01"},
+				{Name: "amrfinder", Error: "not accessible"},
+			},
+		)
+
+		assert.Equal(t, []ManifestMismatch{
+			{Kind: "tool", Name: "CheckM", Expected: "1.2.2"},
+			{Kind: "tool", Name: "Prokka", Expected: "1.14",
+				Actual: "1.145"},
+			{Kind: "database", Name: "abricate/vfdb",
+				Expected: "2023-Jan-12", Actual: "2024-Mar-01"},
+			{Kind: "database", Name: "amrfinder", Expected: "ghi"},
+			{Kind: "database", Name: "kraken2", Expected: "abc",
+				Actual: "other"},
+		}, mismatches)
+		assert.Equal(t, "tool CheckM: expected 1.2.2, found missing",
+			mismatches[0].String())
+	})
+}
@@ -2,260 +2,384 @@ package pipeline
 
 import (
 	"bufio"
-	"errors"
 	"fmt"
 	"io"
 	"os"
-	"regexp"
 	"strconv"
 	"strings"
 )
 
-var (
-	subjectPattern       = regexp.MustCompile(`(?i)>\s{0,1}(\w+)\|`)
-	subjectLengthPattern = regexp.MustCompile(`(?i)Length=(\d+)`)
-	identitiesPattern    = regexp.MustCompile(
-		`(?i)Identities = \d+\/(\d+)\s\((\d+)%\)`)
-	querySequencePattern = regexp.MustCompile(
-		`(?i)Query\s+\d+\s+(\w+)`)
-	subjectSequencePattern = regexp.MustCompile(
-		`(?i)Sbjct\s+(\d+)\s+(\w+)\s+(\d+)`)
+// BlastOutputFormat is the tabular layout requested from blastx and blastn.
+// qseq and sseq hold the aligned sequences, so every column of the
+// alignment is available to the parser.
+const BlastOutputFormat = "6 stitle slen qseqid qstart qend sstart send " +
+	"length pident qseq sseq"
+
+const blastOutputColumns = 11
+
+const (
+	// A hit covering less than TruncationMaxCoverage of the reference with
+	// more than TruncationMinIdentity is reported as a truncation.
+	TruncationMaxCoverage = 90.0
+	TruncationMinIdentity = 80.0
+	// Substitutions and indels are only reported for hits covering more than
+	// MutationMinCoverage of the reference with more than
+	// MutationMinIdentity.
+	MutationMinCoverage = 90.0
+	MutationMinIdentity = 90.0
 )
 
-type MutationFinder interface {
-	FindAcinetoMutations() ([]string, []string, error)
-	FindEcloacaeMutations() ([]string, []string, error)
-	FindKlebMutations() ([]string, []string, error)
-	FindPseudoMutations() ([]string, []string, error)
+// BlastHSP is one high-scoring pair of a tabular BLAST report.
+type BlastHSP struct {
+	Gene            string
+	SubjectTitle    string
+	SubjectLength   int
+	QueryID         string
+	QueryStart      int
+	QueryEnd        int
+	SubjectStart    int
+	SubjectEnd      int
+	AlignmentLength int
+	Identity        float64
+	QuerySequence   string
+	SubjectSequence string
 }
 
-type mutationFinder struct {
-	BlastResultPath string
+// Coverage is the percentage of the reference covered by the alignment.
+func (h BlastHSP) Coverage() float64 {
+	if h.SubjectLength <= 0 {
+		return 0
+	}
+	return float64(h.AlignmentLength) / float64(h.SubjectLength) * 100
 }
 
-func NewMutationFinder(blastResultPath string) MutationFinder {
-	return &mutationFinder{
-		BlastResultPath: blastResultPath,
+// ScanBlastHSPs reads a report written with BlastOutputFormat and calls fn
+// for each HSP, in file order. Comment lines are skipped.
+func ScanBlastHSPs(r io.Reader, fn func(BlastHSP) error) error {
+	scanner := bufio.NewScanner(r)
+
+	const maxCapacity = 1024 * 1024
+	buf := make([]byte, maxCapacity)
+	scanner.Buffer(buf, maxCapacity)
+
+	lineNumber := 0
+	for scanner.Scan() {
+		lineNumber++
+		line := strings.TrimRight(scanner.Text(), "\r\n")
+		if strings.TrimSpace(line) == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		hsp, err := parseBlastHSP(line)
+		if err != nil {
+			return fmt.Errorf("invalid BLAST record at line %d: %v",
+				lineNumber, err)
+		}
+		if err := fn(hsp); err != nil {
+			return err
+		}
 	}
+
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf(
+			"An error occurred while reading the file: %v", err)
+	}
+
+	return nil
 }
 
-func (f *mutationFinder) findMutation(mutations []string) (
-	[]string, error) {
-	file, err := os.Open(f.BlastResultPath)
-	if err != nil {
-		return nil, fmt.Errorf("BLAST result file not found: %v", err)
+func parseBlastHSP(line string) (BlastHSP, error) {
+	fields := strings.Split(line, "\t")
+	if len(fields) != blastOutputColumns {
+		return BlastHSP{}, fmt.Errorf("expected %d columns, got %d",
+			blastOutputColumns, len(fields))
 	}
-	defer file.Close()
 
-	br := bufio.NewReader(file)
-	_, err = br.Peek(1)
-	if err == io.EOF {
-		return nil, errors.New("Empty BLAST result")
+	hsp := BlastHSP{
+		SubjectTitle:    fields[0],
+		Gene:            blastGeneName(fields[0]),
+		QueryID:         fields[2],
+		QuerySequence:   strings.ToUpper(fields[9]),
+		SubjectSequence: strings.ToUpper(fields[10]),
 	}
 
-	mutationsMap := make(map[string]bool)
-	for _, t := range mutations {
-		mutationsMap[t] = true
+	integers := []struct {
+		name  string
+		raw   string
+		value *int
+	}{
+		{"slen", fields[1], &hsp.SubjectLength},
+		{"qstart", fields[3], &hsp.QueryStart},
+		{"qend", fields[4], &hsp.QueryEnd},
+		{"sstart", fields[5], &hsp.SubjectStart},
+		{"send", fields[6], &hsp.SubjectEnd},
+		{"length", fields[7], &hsp.AlignmentLength},
+	}
+	for _, column := range integers {
+		value, err := strconv.Atoi(column.raw)
+		if err != nil {
+			return BlastHSP{}, fmt.Errorf("invalid %s %q", column.name,
+				column.raw)
+		}
+		*column.value = value
 	}
 
-	var foundMutations []string
+	identity, err := strconv.ParseFloat(fields[8], 64)
+	if err != nil {
+		return BlastHSP{}, fmt.Errorf("invalid pident %q", fields[8])
+	}
+	hsp.Identity = identity
 
-	var subject string
-	var subjectLength, identitiesTotal, percentualIdentity int
-	var querySequence, subjectSequence, alignmentSequence []rune
-	var subjectSequenceStart, subjectSequenceEnd int
-	var test, nextLineIsAlignment bool
+	if hsp.Gene == "" {
+		return BlastHSP{}, fmt.Errorf("missing subject title")
+	}
+	if len(hsp.QuerySequence) != len(hsp.SubjectSequence) {
+		return BlastHSP{}, fmt.Errorf(
+			"aligned sequences differ in length (%d and %d)",
+			len(hsp.QuerySequence), len(hsp.SubjectSequence))
+	}
 
-	scanner := bufio.NewScanner(br)
+	return hsp, nil
+}
 
-	const maxCapacity = 1024 * 1024
-	buf := make([]byte, maxCapacity)
-	scanner.Buffer(buf, maxCapacity)
+// blastGeneName returns the gene of a reference title such as
+// "GyrA|WP_000072067.1 DNA gyrase subunit A".
+func blastGeneName(title string) string {
+	name, _, _ := strings.Cut(title, "|")
+	fields := strings.Fields(name)
+	if len(fields) == 0 {
+		return ""
+	}
+	return fields[0]
+}
 
-	for scanner.Scan() {
-		line := strings.TrimSpace(scanner.Text())
+type MutationKind string
 
-		if matches := subjectPattern.FindStringSubmatch(line); len(
-			matches) > 1 {
-			subject = matches[1]
-			test = false
-			continue
-		}
+const (
+	MutationSubstitution MutationKind = "substitution"
+	MutationInsertion    MutationKind = "insertion"
+	MutationDeletion     MutationKind = "deletion"
+	MutationTruncation   MutationKind = "truncation"
+)
 
-		if matches := subjectLengthPattern.FindStringSubmatch(line); len(
-			matches) > 1 {
-			subjectLength, _ = strconv.Atoi(matches[1])
-			continue
-		}
+// Mutation is a difference between an assembly and a reference protein (or
+// rRNA), in 1-based reference coordinates. Deletions span Start to End, an
+// insertion lies between Start and End, and a truncation covers Start to
+// End of ReferenceLength residues.
+type Mutation struct {
+	Gene            string       `json:"gene"`
+	Kind            MutationKind `json:"kind"`
+	Start           int          `json:"start"`
+	End             int          `json:"end"`
+	Reference       string       `json:"reference,omitempty"`
+	Alternate       string       `json:"alternate,omitempty"`
+	AlignedLength   int          `json:"aligned_length,omitempty"`
+	ReferenceLength int          `json:"reference_length,omitempty"`
+	Contig          string       `json:"contig,omitempty"`
+}
 
-		if matches := identitiesPattern.FindStringSubmatch(line); len(
-			matches) > 2 {
-			identitiesTotal, _ = strconv.Atoi(matches[1])
-			percentualIdentity, _ = strconv.Atoi(matches[2])
-
-			if float64(identitiesTotal) < (float64(subjectLength)/100.0)*90.0 &&
-				percentualIdentity > 80 {
-								mutation := fmt.Sprintf(
-									"%s truncation: %d/%d", subject,
-									identitiesTotal, subjectLength)
-				foundMutations = append(foundMutations, mutation)
-			}
-			continue
+// String renders the mutation as reported to users: "GyrA:S83L",
+// "MgrB:K3_L5del", "PmrB:14_15insGA" or "MgrB truncation: 30/47".
+func (m Mutation) String() string {
+	switch m.Kind {
+	case MutationTruncation:
+		return fmt.Sprintf("%s truncation: %d/%d", m.Gene, m.AlignedLength,
+			m.ReferenceLength)
+	case MutationDeletion:
+		if m.Start == m.End || len(m.Reference) < 2 {
+			return fmt.Sprintf("%s:%s%ddel", m.Gene, m.Reference, m.Start)
 		}
+		return fmt.Sprintf("%s:%c%d_%c%ddel", m.Gene, m.Reference[0],
+			m.Start, m.Reference[len(m.Reference)-1], m.End)
+	case MutationInsertion:
+		return fmt.Sprintf("%s:%d_%dins%s", m.Gene, m.Start, m.End,
+			m.Alternate)
+	default:
+		return fmt.Sprintf("%s:%s%d%s", m.Gene, m.Reference, m.Start,
+			m.Alternate)
+	}
+}
 
-		if matches := querySequencePattern.FindStringSubmatch(line); len(
-			matches) > 1 {
-			if percentualIdentity > 90 {
-				querySequence = []rune(strings.TrimSpace(matches[1]))
-				nextLineIsAlignment = true
-			}
-			continue
-		}
+// RenderMutations returns the string form of each mutation.
+func RenderMutations(mutations []Mutation) []string {
+	rendered := make([]string, 0, len(mutations))
+	for _, mutation := range mutations {
+		rendered = append(rendered, mutation.String())
+	}
+	return rendered
+}
 
-		if nextLineIsAlignment {
-			alignmentSequence = []rune(strings.TrimSpace(line))
-			nextLineIsAlignment = false
-			continue
+// HSPMutations walks the alignment column by column and returns its
+// substitutions, insertions and deletions. Hits on the reverse strand of a
+// nucleotide reference are reported on the forward strand.
+func HSPMutations(hsp BlastHSP) []Mutation {
+	step := 1
+	if hsp.SubjectStart > hsp.SubjectEnd {
+		step = -1
+	}
+
+	var mutations []Mutation
+	var open *Mutation
+	flush := func() {
+		if open != nil {
+			mutations = append(mutations, orientMutation(*open, step))
+			open = nil
 		}
+	}
 
-		if matches := subjectSequencePattern.FindStringSubmatch(
-			line); len(matches) > 3 {
-			if percentualIdentity > 90 {
-				subjectSequenceStart, _ = strconv.Atoi(matches[1])
-				subjectSequence = []rune(strings.TrimSpace(matches[2]))
-				subjectSequenceEnd, _ = strconv.Atoi(matches[3])
+	// position is the last reference residue consumed by the alignment.
+	position := hsp.SubjectStart - step
+	for i := 0; i < len(hsp.QuerySequence); i++ {
+		query, subject := hsp.QuerySequence[i], hsp.SubjectSequence[i]
+		if subject != '-' {
+			position += step
+		}
 
-				if subjectSequenceStart == 1 {
-					test = true
+		switch {
+		case subject == '-':
+			if open == nil || open.Kind != MutationInsertion {
+				flush()
+				open = &Mutation{
+					Gene: hsp.Gene, Kind: MutationInsertion,
+					Start: position, End: position + step,
+					Contig: hsp.QueryID,
 				}
-
-				if test {
-					for i := range 60 {
-						if i >= len(querySequence) {
-							break
-						}
-
-						if i >= len(alignmentSequence) || i >= len(
-							subjectSequence) {
-							break
-						}
-
-						queryAA := strings.ToUpper(string(querySequence[i]))
-						alignmentAA := strings.ToUpper(string(alignmentSequence[i]))
-						subjectAA := strings.ToUpper(string(subjectSequence[i]))
-
-						if queryAA != alignmentAA {
-							var position int
-							if subjectSequenceStart > subjectSequenceEnd {
-								position = subjectSequenceStart - i
-							} else {
-								position = subjectSequenceStart + i
-							}
-
-							if mutationsMap[subject] && queryAA != subjectAA {
-								if float64(identitiesTotal) > (float64(
-									subjectLength)/100.0)*90.0 {
-								mutation := fmt.Sprintf(
-									"%s:%s%d%s", subject, subjectAA,
-									position, queryAA)
-									foundMutations = append(foundMutations,
-										mutation)
-								}
-							}
-						}
-					}
+			}
+			open.Alternate += string(query)
+		case query == '-':
+			if open == nil || open.Kind != MutationDeletion {
+				flush()
+				open = &Mutation{
+					Gene: hsp.Gene, Kind: MutationDeletion,
+					Start: position, Contig: hsp.QueryID,
 				}
 			}
+			open.End = position
+			open.Reference += string(subject)
+		default:
+			flush()
+			if query != subject {
+				mutations = append(mutations, orientMutation(Mutation{
+					Gene: hsp.Gene, Kind: MutationSubstitution,
+					Start: position, End: position,
+					Reference: string(subject), Alternate: string(query),
+					Contig: hsp.QueryID,
+				}, step))
+			}
 		}
 	}
+	flush()
 
-	if err := scanner.Err(); err != nil {
-		return nil, fmt.Errorf(
-			"An error occurred while reading the file: %v", err)
-	}
-
-	return foundMutations, nil
+	return mutations
 }
 
-func (f *mutationFinder) FindAcinetoMutations() (
-	[]string, []string, error) {
-	otherMutations := []string{"GyrA", "GyrB", "ParC", "AdeN",
-		"AdeR", "CarO", "OmpA", "AdeL", "AdeS"}
-	poliMutations := []string{"PmrA", "PmrB", "LpxA", "LpxD", "LpxC"}
-
-	otherResult, err := f.findMutation(otherMutations)
-	if err != nil {
-		return nil, nil, fmt.Errorf(
-			"Failed to find other mutations to acineto: %v", err)
+// orientMutation puts a mutation found on the reverse strand in forward
+// reference coordinates and bases.
+func orientMutation(m Mutation, step int) Mutation {
+	if step > 0 {
+		return m
 	}
+	m.Start, m.End = m.End, m.Start
+	m.Reference = reverseComplement(m.Reference)
+	m.Alternate = reverseComplement(m.Alternate)
+	return m
+}
 
-	poliResult, err := f.findMutation(poliMutations)
-	if err != nil {
-		return nil, nil, fmt.Errorf(
-			"Failed to find poli mutations to acineto: %v", err)
+func reverseComplement(sequence string) string {
+	complement := map[byte]byte{'A': 'T', 'T': 'A', 'C': 'G', 'G': 'C'}
+	reversed := make([]byte, len(sequence))
+	for i := 0; i < len(sequence); i++ {
+		base := sequence[len(sequence)-1-i]
+		if c, ok := complement[base]; ok {
+			base = c
+		}
+		reversed[i] = base
 	}
+	return string(reversed)
+}
+
+type MutationFinder interface {
+	// FindMutations reports the mutations and truncations of the given
+	// target genes, as listed by a species profile.
+	FindMutations(genes []string) ([]Mutation, error)
+}
 
-	return otherResult, poliResult, nil
+type mutationFinder struct {
+	BlastResultPath string
 }
 
-func (f *mutationFinder) FindEcloacaeMutations() (
-	[]string, []string, error) {
-	otherMutations := []string{"GyrA", "ParC"}
-	poliMutations := []string{"PmrA", "PmrB", "MgrB", "PhoP", "PhoQ"}
+func NewMutationFinder(blastResultPath string) MutationFinder {
+	return &mutationFinder{
+		BlastResultPath: blastResultPath,
+	}
+}
 
-	otherResult, err := f.findMutation(otherMutations)
+func (f *mutationFinder) findMutation(genes []string) ([]Mutation, error) {
+	file, err := os.Open(f.BlastResultPath)
 	if err != nil {
-		return nil, nil, fmt.Errorf(
-			"Failed to find other mutations to ecloacae: %v", err)
+		return nil, fmt.Errorf("BLAST result file not found: %v", err)
 	}
+	defer file.Close()
 
-	poliResult, err := f.findMutation(poliMutations)
-	if err != nil {
-		return nil, nil, fmt.Errorf(
-			"Failed to find poli mutations to ecloacae: %v", err)
+	genesMap := make(map[string]bool)
+	for _, gene := range genes {
+		genesMap[gene] = true
 	}
 
-	return otherResult, poliResult, nil
-}
+	foundMutations := []Mutation{}
+	seen := map[string]bool{}
+	add := func(mutation Mutation) {
+		if key := mutation.String(); !seen[key] {
+			seen[key] = true
+			foundMutations = append(foundMutations, mutation)
+		}
+	}
 
-func (f *mutationFinder) FindKlebMutations() (
-	[]string, []string, error) {
-	otherMutations := []string{"GyrA", "GyrB", "ParC", "AcrR", "RamR"}
-	poliMutations := []string{"PmrB", "PmrA", "MgrB", "PhoP", "PhoQ"}
+	err = ScanBlastHSPs(file, func(hsp BlastHSP) error {
+		if !genesMap[hsp.Gene] {
+			return nil
+		}
 
-	otherResult, err := f.findMutation(otherMutations)
-	if err != nil {
-		return nil, nil, fmt.Errorf(
-			"Failed to find other mutations to kleb: %v", err)
-	}
+		coverage := hsp.Coverage()
+		if coverage < TruncationMaxCoverage &&
+			hsp.Identity > TruncationMinIdentity {
+			start, end := hsp.SubjectStart, hsp.SubjectEnd
+			if start > end {
+				start, end = end, start
+			}
+			add(Mutation{
+				Gene: hsp.Gene, Kind: MutationTruncation,
+				Start: start, End: end,
+				AlignedLength:   hsp.AlignmentLength,
+				ReferenceLength: hsp.SubjectLength,
+				Contig:          hsp.QueryID,
+			})
+		}
 
-	poliResult, err := f.findMutation(poliMutations)
+		if coverage > MutationMinCoverage &&
+			hsp.Identity > MutationMinIdentity {
+			for _, mutation := range HSPMutations(hsp) {
+				add(mutation)
+			}
+		}
+		return nil
+	})
 	if err != nil {
-		return nil, nil, fmt.Errorf(
-			"Failed to find poli mutations to kleb: %v", err)
+		return nil, err
 	}
 
-	return otherResult, poliResult, nil
+	return foundMutations, nil
 }
 
-func (f *mutationFinder) FindPseudoMutations() (
-	[]string, []string, error) {
-	otherMutations := []string{"OprD", "MexT", "AmpC",
-		"AmpR", "GyrA", "GyrB", "ParC", "ParE"}
-	poliMutations := []string{"PmrA", "PmrB", "PhoQ",
-		"ParR", "ParS", "CrpS", "ColR", "ColS"}
-
-	otherResult, err := f.findMutation(otherMutations)
-	if err != nil {
-		return nil, nil, fmt.Errorf(
-			"Failed to find other mutations to pseudo: %v", err)
+func (f *mutationFinder) FindMutations(genes []string) ([]Mutation, error) {
+	if len(genes) == 0 {
+		return []Mutation{}, nil
 	}
 
-	poliResult, err := f.findMutation(poliMutations)
+	result, err := f.findMutation(genes)
 	if err != nil {
-		return nil, nil, fmt.Errorf(
-			"Failed to find poli mutations to pseudo: %v", err)
+		return nil, fmt.Errorf("Failed to find mutations: %v", err)
 	}
 
-	return otherResult, poliResult, nil
+	return result, nil
 }
@@ -1,16 +1,28 @@
 package pipeline
 
 import (
+	"fmt"
 	"os"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 )
 
+const (
+	proteinAlphabet    = "ACDEFGHIKLMNPQRSTVWY"
+	nucleotideAlphabet = "ACGT"
+)
+
+var substitutionPattern = regexp.MustCompile(`^([A-Z*])(\d+)([A-Z*])$`)
+
 func createMockBlastFile(t *testing.T, content string) string {
 	t.Helper()
 
-	tmpFile, err := os.CreateTemp(t.TempDir(), "blast_mock_*.txt")
+	tmpFile, err := os.CreateTemp(t.TempDir(), "blast_mock_*.tsv")
 	assert.NoError(t, err)
 
 	_, err = tmpFile.WriteString(content)
@@ -22,35 +34,206 @@ func createMockBlastFile(t *testing.T, content string) string {
 	return tmpFile.Name()
 }
 
-const organismMockContent = `
-> GyrA|
-Length=100
-Identities = 95/95 (95%)
-Query  1   ATCG 4
-           || |
-Sbjct  1   ATAG 4
+func blastRecord(title string, subjectLength, subjectStart, subjectEnd int,
+	identity float64, query, subject string) string {
+	return fmt.Sprintf("%s\t%d\tcontig_1\t1\t%d\t%d\t%d\t%d\t%.3f\t%s\t%s\n",
+		title, subjectLength, len(query)*3, subjectStart, subjectEnd,
+		len(query), identity, query, subject)
+}
 
-> PmrA|
-Length=100
-Identities = 95/95 (95%)
-Query  1   ATCG 4
-           || |
-Sbjct  1   ATAG 4
-`
+// blastHit renders an HSP aligning the whole reference of the given length,
+// whose query differs by the given substitutions (e.g. "S83L").
+func blastHit(t *testing.T, gene, alphabet string, length int,
+	substitutions ...string) string {
+	t.Helper()
+
+	reference := []byte(strings.Repeat(alphabet,
+		length/len(alphabet)+1)[:length])
+	query := slices.Clone(reference)
+	for _, substitution := range substitutions {
+		matches := substitutionPattern.FindStringSubmatch(substitution)
+		if !assert.NotNil(t, matches) {
+			continue
+		}
+		position, _ := strconv.Atoi(matches[2])
+		reference[position-1] = matches[1][0]
+		query[position-1] = matches[3][0]
+	}
+
+	identity := float64(length-len(substitutions)) / float64(length) * 100
+	return blastRecord(gene+"|"+gene+"_reference", length, 1, length,
+		identity, string(query), string(reference))
+}
+
+// truncatedHit renders an HSP aligning only the first residues of the
+// reference.
+func truncatedHit(gene string, aligned, length int) string {
+	sequence := strings.Repeat(proteinAlphabet, aligned/20+1)[:aligned]
+	return blastRecord(gene+"|"+gene+"_reference", length, 1, aligned, 100,
+		sequence, sequence)
+}
+
+// The fixtures below hold known resistance mutations of each species
+// profile: E. coli GyrA S83L/D87N, ParC S80I and a truncated MgrB;
+// K. pneumoniae GyrA S83I, ParC S80I, PmrB R256G and a truncated MgrB;
+// E. cloacae GyrA S83F, ParC S80I and PhoQ L26P; P. aeruginosa GyrA T83I,
+// ParC S87L and PmrB A248V; S. aureus GrlA S80F, GyrA S84L and RpoB H481Y;
+// Enterococcus LiaR W73C, LiaF I177N, LiaS T120A and the 23S rRNA G2576T
+// found by blastn. The E. cloacae and P. aeruginosa fixtures also hold a
+// gene their profile does not screen.
+func organismMockContent(t *testing.T) string {
+	return blastHit(t, "GyrA", proteinAlphabet, 20, "A3C") +
+		blastHit(t, "PmrA", proteinAlphabet, 20, "A3C")
+}
+
+func ecoliMockContent(t *testing.T) string {
+	return blastHit(t, "GyrA", proteinAlphabet, 120, "S83L", "D87N") +
+		blastHit(t, "ParC", proteinAlphabet, 120, "S80I") +
+		truncatedHit("MgrB", 30, 47)
+}
+
+func klebMockContent(t *testing.T) string {
+	return blastHit(t, "GyrA", proteinAlphabet, 120, "S83I") +
+		blastHit(t, "ParC", proteinAlphabet, 120, "S80I") +
+		blastHit(t, "PmrB", proteinAlphabet, 360, "R256G") +
+		truncatedHit("MgrB", 30, 47)
+}
+
+func ecloacaeMockContent(t *testing.T) string {
+	return blastHit(t, "GyrA", proteinAlphabet, 120, "S83F") +
+		blastHit(t, "ParC", proteinAlphabet, 120, "S80I") +
+		blastHit(t, "ParE", proteinAlphabet, 480, "S458A") +
+		blastHit(t, "PhoQ", proteinAlphabet, 120, "L26P")
+}
+
+func pseudoMockContent(t *testing.T) string {
+	return blastHit(t, "GyrA", proteinAlphabet, 120, "T83I") +
+		blastHit(t, "ParC", proteinAlphabet, 120, "S87L") +
+		blastHit(t, "PmrB", proteinAlphabet, 360, "A248V") +
+		truncatedHit("MgrB", 30, 47)
+}
+
+func saureusMockContent(t *testing.T) string {
+	return blastHit(t, "GrlA", proteinAlphabet, 120, "S80F") +
+		blastHit(t, "GyrA", proteinAlphabet, 120, "S84L") +
+		blastHit(t, "RpoB", proteinAlphabet, 540, "H481Y")
+}
+
+func enterococcusMockContent(t *testing.T) string {
+	return blastHit(t, "LiaR", proteinAlphabet, 120, "W73C") +
+		blastHit(t, "LiaF", proteinAlphabet, 240, "I177N") +
+		blastHit(t, "LiaS", proteinAlphabet, 120, "T120A")
+}
+
+func enterococcusRRNAMockContent(t *testing.T) string {
+	return blastHit(t, "23S", nucleotideAlphabet, 2640, "G2576T")
+}
+
+func TestScanBlastHSPs(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		content := "# BLASTX 2.15.0+\n\n" +
+			blastRecord("GyrA|WP_000072067.1 DNA gyrase", 4, 1, 4, 75,
+				"ATCG", "ATAG")
+
+		var hsps []BlastHSP
+		err := ScanBlastHSPs(strings.NewReader(content),
+			func(hsp BlastHSP) error {
+				hsps = append(hsps, hsp)
+				return nil
+			})
+
+		assert.NoError(t, err)
+		assert.Equal(t, []BlastHSP{{
+			Gene:            "GyrA",
+			SubjectTitle:    "GyrA|WP_000072067.1 DNA gyrase",
+			SubjectLength:   4,
+			QueryID:         "contig_1",
+			QueryStart:      1,
+			QueryEnd:        12,
+			SubjectStart:    1,
+			SubjectEnd:      4,
+			AlignmentLength: 4,
+			Identity:        75,
+			QuerySequence:   "ATCG",
+			SubjectSequence: "ATAG",
+		}}, hsps)
+		assert.Equal(t, 100.0, hsps[0].Coverage())
+	})
+
+	t.Run("Error - Missing Columns", func(t *testing.T) {
+		err := ScanBlastHSPs(strings.NewReader("GyrA|\t4\tcontig_1\n"),
+			func(BlastHSP) error { return nil })
+
+		assert.EqualError(t, err,
+			"invalid BLAST record at line 1: expected 11 columns, got 3")
+	})
+
+	t.Run("Error - Aligned Sequences Differ", func(t *testing.T) {
+		err := ScanBlastHSPs(strings.NewReader(
+			blastRecord("GyrA|", 4, 1, 4, 75, "ATCG", "ATA")),
+			func(BlastHSP) error { return nil })
+
+		assert.Error(t, err)
+	})
+}
+
+func TestHSPMutations(t *testing.T) {
+	t.Run("Success - Substitutions And Indels", func(t *testing.T) {
+		reference := strings.Repeat(proteinAlphabet, 3)[:60]
+		// Residues 10-11 are deleted, GA is inserted after residue 20 and
+		// residue 30 is replaced.
+		query := reference[:9] + "--" + reference[11:20] + "GA" +
+			reference[20:29] + "W" + reference[30:]
+		subject := reference[:20] + "--" + reference[20:]
+
+		mutations := HSPMutations(BlastHSP{
+			Gene: "PmrB", QueryID: "contig_7", SubjectStart: 1,
+			SubjectEnd: 60, QuerySequence: query, SubjectSequence: subject,
+		})
+
+		assert.Equal(t, []Mutation{
+			{Gene: "PmrB", Kind: MutationDeletion, Start: 10, End: 11,
+				Reference: "LM", Contig: "contig_7"},
+			{Gene: "PmrB", Kind: MutationInsertion, Start: 20, End: 21,
+				Alternate: "GA", Contig: "contig_7"},
+			{Gene: "PmrB", Kind: MutationSubstitution, Start: 30, End: 30,
+				Reference: "L", Alternate: "W", Contig: "contig_7"},
+		}, mutations)
+		assert.Equal(t, []string{
+			"PmrB:L10_M11del", "PmrB:20_21insGA", "PmrB:L30W",
+		}, RenderMutations(mutations))
+	})
+
+	t.Run("Success - Reverse Strand", func(t *testing.T) {
+		reference := []byte(strings.Repeat(nucleotideAlphabet, 10))
+		reference[37] = 'G'
+		query := slices.Clone(reference)
+		query[37] = 'T'
+
+		mutations := HSPMutations(BlastHSP{
+			Gene: "23S", SubjectStart: 40, SubjectEnd: 1,
+			QuerySequence:   reverseComplement(string(query)),
+			SubjectSequence: reverseComplement(string(reference)),
+		})
+
+		assert.Equal(t, []string{"23S:G38T"}, RenderMutations(mutations))
+	})
+}
+
+func TestMutationString(t *testing.T) {
+	assert.Equal(t, "GyrA:S83L", Mutation{Gene: "GyrA",
+		Kind: MutationSubstitution, Start: 83, End: 83, Reference: "S",
+		Alternate: "L"}.String())
+	assert.Equal(t, "MgrB:K3del", Mutation{Gene: "MgrB",
+		Kind: MutationDeletion, Start: 3, End: 3, Reference: "K"}.String())
+	assert.Equal(t, "MgrB truncation: 30/47", Mutation{Gene: "MgrB",
+		Kind: MutationTruncation, Start: 1, End: 30, AlignedLength: 30,
+		ReferenceLength: 47}.String())
+}
 
 func TestFindMutation(t *testing.T) {
-	mockContent := `
-> GyrA|
-Length=100
-Identities = 95/95 (95%)
-Query  1   ATCG 4
-           || |
-Sbjct  1   ATAG 4
-
-> PmrA|
-Length=100
-Identities = 85/85 (85%)
-`
+	mockContent := blastHit(t, "GyrA", proteinAlphabet, 100, "A3C") +
+		truncatedHit("PmrA", 85, 100)
 	path := createMockBlastFile(t, mockContent)
 
 	finder := NewMutationFinder(path).(*mutationFinder)
@@ -59,17 +242,39 @@ Identities = 85/85 (85%)
 		res, err := finder.findMutation([]string{"GyrA", "PmrA"})
 		assert.NoError(t, err)
 		assert.Len(t, res, 2)
-		assert.Contains(t, res, "GyrA:A3C")
-		assert.Contains(t, res, "PmrA truncation: 85/100")
+		assert.Contains(t, RenderMutations(res), "GyrA:A3C")
+		assert.Contains(t, RenderMutations(res), "PmrA truncation: 85/100")
+		assert.Equal(t, Mutation{
+			Gene: "PmrA", Kind: MutationTruncation, Start: 1, End: 85,
+			AlignedLength: 85, ReferenceLength: 100, Contig: "contig_1",
+		}, res[1])
+	})
+
+	t.Run("Success - Duplicate Hits", func(t *testing.T) {
+		hit := blastHit(t, "GyrA", proteinAlphabet, 100, "A3C")
+		duplicateFinder := NewMutationFinder(createMockBlastFile(t,
+			hit+hit)).(*mutationFinder)
+
+		res, err := duplicateFinder.findMutation([]string{"GyrA"})
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"GyrA:A3C"}, RenderMutations(res))
 	})
 
-	t.Run("Error - Empty File", func(t *testing.T) {
+	t.Run("Success - No Hits", func(t *testing.T) {
 		emptyPath := createMockBlastFile(t, "")
 
 		emptyFinder := NewMutationFinder(emptyPath).(*mutationFinder)
 		res, err := emptyFinder.findMutation([]string{"GyrA"})
+		assert.NoError(t, err)
+		assert.Empty(t, res)
+	})
+
+	t.Run("Error - Malformed Record", func(t *testing.T) {
+		malformedFinder := NewMutationFinder(createMockBlastFile(t,
+			"> GyrA|\nLength=100\n")).(*mutationFinder)
+
+		res, err := malformedFinder.findMutation([]string{"GyrA"})
 		assert.Error(t, err)
-		assert.Equal(t, "Empty BLAST result", err.Error())
 		assert.Nil(t, res)
 	})
 
@@ -82,46 +287,116 @@ Identities = 85/85 (85%)
 	})
 }
 
-func TestAcinetoMutations(t *testing.T) {
-	path := createMockBlastFile(t, organismMockContent)
-
+func TestFindMutations(t *testing.T) {
+	path := createMockBlastFile(t, organismMockContent(t))
 	finder := NewMutationFinder(path)
-	other, poli, err := finder.FindAcinetoMutations()
 
+	t.Run("Success", func(t *testing.T) {
+		mutations, err := finder.FindMutations([]string{"GyrA", "ParC"})
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"GyrA:A3C"}, RenderMutations(mutations))
+	})
+
+	t.Run("Success - No Genes", func(t *testing.T) {
+		mutations, err := NewMutationFinder("missing.txt").FindMutations(nil)
+
+		assert.NoError(t, err)
+		assert.Empty(t, mutations)
+	})
+
+	t.Run("Error - File Not Found", func(t *testing.T) {
+		_, err := NewMutationFinder("missing.txt").FindMutations(
+			[]string{"GyrA"})
+		assert.Error(t, err)
+	})
+}
+
+func defaultProfile(t *testing.T, normalizedName string) *SpeciesProfile {
+	t.Helper()
+	registry, err := DefaultSpeciesRegistry()
 	assert.NoError(t, err)
-	assert.Contains(t, other, "GyrA:A3C")
-	assert.Contains(t, poli, "PmrA:A3C")
+	profile := registry.Match(normalizedName)
+	assert.NotNil(t, profile)
+	return profile
 }
 
-func TestEcloacaeMutations(t *testing.T) {
-	path := createMockBlastFile(t, organismMockContent)
+func findRenderedMutations(t *testing.T, content string,
+	genes []string) []string {
+	t.Helper()
+	mutations, err := NewMutationFinder(createMockBlastFile(t,
+		content)).FindMutations(genes)
+	assert.NoError(t, err)
+	return RenderMutations(mutations)
+}
 
-	finder := NewMutationFinder(path)
-	other, poli, err := finder.FindEcloacaeMutations()
+func TestAcinetoMutations(t *testing.T) {
+	profile := defaultProfile(t, "acinetobacterbaumannii")
+	content := organismMockContent(t)
 
-	assert.NoError(t, err)
-	assert.Contains(t, other, "GyrA:A3C")
-	assert.Contains(t, poli, "PmrA:A3C")
+	assert.Contains(t, findRenderedMutations(t, content, profile.OtherGenes),
+		"GyrA:A3C")
+	assert.Contains(t, findRenderedMutations(t, content, profile.PoliGenes),
+		"PmrA:A3C")
+}
+
+func TestEcoliMutations(t *testing.T) {
+	profile := defaultProfile(t, "escherichiacoli")
+	content := ecoliMockContent(t)
+
+	assert.Equal(t, []string{"GyrA:S83L", "GyrA:D87N", "ParC:S80I"},
+		findRenderedMutations(t, content, profile.OtherGenes))
+
+	poli := findRenderedMutations(t, content, profile.PoliGenes)
+	assert.Equal(t, []string{"MgrB truncation: 30/47"}, poli)
 }
 
 func TestKlebMutations(t *testing.T) {
-	path := createMockBlastFile(t, organismMockContent)
+	profile := defaultProfile(t, "klebsiellapneumoniae")
+	content := klebMockContent(t)
 
-	finder := NewMutationFinder(path)
-	other, poli, err := finder.FindKlebMutations()
+	assert.ElementsMatch(t, []string{"GyrA:S83I", "ParC:S80I"},
+		findRenderedMutations(t, content, profile.OtherGenes))
+	assert.ElementsMatch(t, []string{"PmrB:R256G", "MgrB truncation: 30/47"},
+		findRenderedMutations(t, content, profile.PoliGenes))
+}
 
-	assert.NoError(t, err)
-	assert.Contains(t, other, "GyrA:A3C")
-	assert.Contains(t, poli, "PmrA:A3C")
+func TestEcloacaeMutations(t *testing.T) {
+	profile := defaultProfile(t, "enterobactercloacae")
+	content := ecloacaeMockContent(t)
+
+	assert.ElementsMatch(t, []string{"GyrA:S83F", "ParC:S80I"},
+		findRenderedMutations(t, content, profile.OtherGenes))
+	assert.Equal(t, []string{"PhoQ:L26P"},
+		findRenderedMutations(t, content, profile.PoliGenes))
 }
 
 func TestPseudoMutations(t *testing.T) {
-	path := createMockBlastFile(t, organismMockContent)
+	profile := defaultProfile(t, "pseudomonasaeruginosa")
+	content := pseudoMockContent(t)
 
-	finder := NewMutationFinder(path)
-	other, poli, err := finder.FindPseudoMutations()
+	assert.ElementsMatch(t, []string{"GyrA:T83I", "ParC:S87L"},
+		findRenderedMutations(t, content, profile.OtherGenes))
+	assert.Equal(t, []string{"PmrB:A248V"},
+		findRenderedMutations(t, content, profile.PoliGenes))
+}
 
-	assert.NoError(t, err)
-	assert.Contains(t, other, "GyrA:A3C")
-	assert.Contains(t, poli, "PmrA:A3C")
+func TestSaureusMutations(t *testing.T) {
+	profile := defaultProfile(t, "staphylococcusaureus")
+
+	assert.ElementsMatch(t, []string{
+		"GrlA:S80F", "GyrA:S84L", "RpoB:H481Y",
+	}, findRenderedMutations(t, saureusMockContent(t), profile.OtherGenes))
+	assert.Empty(t, profile.PoliGenes)
+}
+
+func TestEnterococcusMutations(t *testing.T) {
+	profile := defaultProfile(t, "enterococcusfaecium")
+
+	assert.ElementsMatch(t, []string{"LiaR:W73C", "LiaF:I177N", "LiaS:T120A"},
+		findRenderedMutations(t, enterococcusMockContent(t),
+			profile.OtherGenes))
+	assert.Equal(t, []string{"23S:G2576T"},
+		findRenderedMutations(t, enterococcusRRNAMockContent(t),
+			profile.RRNAGenes))
 }
@@ -0,0 +1,261 @@
+package pipeline
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+)
+
+// Parameter sections, named after the JSON keys of AnalysisParameters.
+const (
+	ParametersUnicycler = "unicycler"
+	ParametersAbricate  = "abricate"
+	ParametersBlastX    = "blastx"
+	ParametersBlastN    = "blastn"
+	ParametersKraken2   = "kraken2"
+)
+
+var unicyclerModes = []string{"conservative", "normal", "bold"}
+
+type UnicyclerParameters struct {
+	Mode           string `json:"mode,omitempty"`
+	MinFastaLength int    `json:"min_fasta_length,omitempty"`
+}
+
+type AbricateParameters struct {
+	MinCoverage float64 `json:"min_coverage,omitempty"`
+	MinIdentity float64 `json:"min_identity,omitempty"`
+}
+
+type BlastXParameters struct {
+	Evalue float64 `json:"evalue,omitempty"`
+}
+
+// BlastNParameters apply to the nucleotide search of the rRNA targets.
+type BlastNParameters struct {
+	Evalue float64 `json:"evalue,omitempty"`
+}
+
+// Kraken2Parameters are percentages of the classified sequences. Taxa under
+// MinPercent are left out of the composition; the second species is
+// reported when it reaches SecondaryMinPercent.
+type Kraken2Parameters struct {
+	MinPercent          float64 `json:"min_percent,omitempty"`
+	SecondaryMinPercent float64 `json:"secondary_min_percent,omitempty"`
+}
+
+// AnalysisParameters are the tool settings chosen for an analysis. Unset
+// sections and fields are taken from the server defaults (see WithDefaults).
+type AnalysisParameters struct {
+	Unicycler *UnicyclerParameters `json:"unicycler,omitempty"`
+	Abricate  *AbricateParameters  `json:"abricate,omitempty"`
+	BlastX    *BlastXParameters    `json:"blastx,omitempty"`
+	BlastN    *BlastNParameters    `json:"blastn,omitempty"`
+	Kraken2   *Kraken2Parameters   `json:"kraken2,omitempty"`
+}
+
+// DefaultAnalysisParameters returns the settings used when neither the
+// analysis nor the server profile sets them.
+func DefaultAnalysisParameters() AnalysisParameters {
+	return AnalysisParameters{
+		Unicycler: &UnicyclerParameters{
+			Mode: "conservative", MinFastaLength: 500,
+		},
+		Abricate: &AbricateParameters{MinCoverage: 90, MinIdentity: 90},
+		BlastX:   &BlastXParameters{Evalue: 0.001},
+		BlastN:   &BlastNParameters{Evalue: 0.001},
+		Kraken2: &Kraken2Parameters{
+			MinPercent: 0.1, SecondaryMinPercent: 5,
+		},
+	}
+}
+
+// ParseAnalysisParameters decodes raw and checks it only sets the allowed
+// sections with valid values. Empty input yields empty parameters.
+func ParseAnalysisParameters(raw []byte, allowed []string) (
+	AnalysisParameters, error) {
+	var params AnalysisParameters
+	if len(bytes.TrimSpace(raw)) == 0 || string(raw) == "null" {
+		return params, nil
+	}
+
+	var sections map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &sections); err != nil {
+		return params, fmt.Errorf("%w: %v", ErrInvalidParameters, err)
+	}
+	for section := range sections {
+		if !slices.Contains(allowed, section) {
+			return params, fmt.Errorf("%w: %s not accepted",
+				ErrInvalidParameters, section)
+		}
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&params); err != nil {
+		return params, fmt.Errorf("%w: %v", ErrInvalidParameters, err)
+	}
+
+	return params, params.Validate()
+}
+
+// LoadAnalysisParameters reads the server parameter profile, a JSON file
+// with the same shape as AnalysisParameters, on top of the defaults.
+func LoadAnalysisParameters(path string) (AnalysisParameters, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return AnalysisParameters{}, fmt.Errorf(
+			"failed to read parameters profile: %w", err)
+	}
+
+	params, err := ParseAnalysisParameters(raw, []string{
+		ParametersUnicycler, ParametersAbricate, ParametersBlastX,
+		ParametersBlastN, ParametersKraken2,
+	})
+	if err != nil {
+		return AnalysisParameters{}, err
+	}
+
+	return params.WithDefaults(DefaultAnalysisParameters()), nil
+}
+
+func (p AnalysisParameters) Validate() error {
+	if u := p.Unicycler; u != nil {
+		if u.Mode != "" && !slices.Contains(unicyclerModes, u.Mode) {
+			return fmt.Errorf("%w: unicycler mode must be one of %v",
+				ErrInvalidParameters, unicyclerModes)
+		}
+		if u.MinFastaLength < 0 {
+			return fmt.Errorf("%w: unicycler min_fasta_length must be "+
+				"positive", ErrInvalidParameters)
+		}
+	}
+	if a := p.Abricate; a != nil {
+		if a.MinCoverage < 0 || a.MinCoverage > 100 ||
+			a.MinIdentity < 0 || a.MinIdentity > 100 {
+			return fmt.Errorf("%w: abricate thresholds must be between "+
+				"0 and 100", ErrInvalidParameters)
+		}
+	}
+	if b := p.BlastX; b != nil {
+		if b.Evalue < 0 || b.Evalue > 10 {
+			return fmt.Errorf("%w: blastx evalue must be between 0 and 10",
+				ErrInvalidParameters)
+		}
+	}
+	if b := p.BlastN; b != nil {
+		if b.Evalue < 0 || b.Evalue > 10 {
+			return fmt.Errorf("%w: blastn evalue must be between 0 and 10",
+				ErrInvalidParameters)
+		}
+	}
+	if k := p.Kraken2; k != nil {
+		if k.MinPercent < 0 || k.MinPercent > 100 ||
+			k.SecondaryMinPercent < 0 || k.SecondaryMinPercent > 100 {
+			return fmt.Errorf("%w: kraken2 percentages must be between "+
+				"0 and 100", ErrInvalidParameters)
+		}
+	}
+
+	return nil
+}
+
+// WithDefaults returns a copy of p with every unset field taken from
+// defaults.
+func (p AnalysisParameters) WithDefaults(
+	defaults AnalysisParameters) AnalysisParameters {
+	var result AnalysisParameters
+
+	if p.Unicycler != nil || defaults.Unicycler != nil {
+		unicycler := UnicyclerParameters{}
+		if p.Unicycler != nil {
+			unicycler = *p.Unicycler
+		}
+		if d := defaults.Unicycler; d != nil {
+			if unicycler.Mode == "" {
+				unicycler.Mode = d.Mode
+			}
+			if unicycler.MinFastaLength == 0 {
+				unicycler.MinFastaLength = d.MinFastaLength
+			}
+		}
+		result.Unicycler = &unicycler
+	}
+
+	if p.Abricate != nil || defaults.Abricate != nil {
+		abricate := AbricateParameters{}
+		if p.Abricate != nil {
+			abricate = *p.Abricate
+		}
+		if d := defaults.Abricate; d != nil {
+			if abricate.MinCoverage == 0 {
+				abricate.MinCoverage = d.MinCoverage
+			}
+			if abricate.MinIdentity == 0 {
+				abricate.MinIdentity = d.MinIdentity
+			}
+		}
+		result.Abricate = &abricate
+	}
+
+	if p.BlastX != nil || defaults.BlastX != nil {
+		blastX := BlastXParameters{}
+		if p.BlastX != nil {
+			blastX = *p.BlastX
+		}
+		if d := defaults.BlastX; d != nil && blastX.Evalue == 0 {
+			blastX.Evalue = d.Evalue
+		}
+		result.BlastX = &blastX
+	}
+
+	if p.BlastN != nil || defaults.BlastN != nil {
+		blastN := BlastNParameters{}
+		if p.BlastN != nil {
+			blastN = *p.BlastN
+		}
+		if d := defaults.BlastN; d != nil && blastN.Evalue == 0 {
+			blastN.Evalue = d.Evalue
+		}
+		result.BlastN = &blastN
+	}
+
+	if p.Kraken2 != nil || defaults.Kraken2 != nil {
+		kraken2 := Kraken2Parameters{}
+		if p.Kraken2 != nil {
+			kraken2 = *p.Kraken2
+		}
+		if d := defaults.Kraken2; d != nil {
+			if kraken2.MinPercent == 0 {
+				kraken2.MinPercent = d.MinPercent
+			}
+			if kraken2.SecondaryMinPercent == 0 {
+				kraken2.SecondaryMinPercent = d.SecondaryMinPercent
+			}
+		}
+		result.Kraken2 = &kraken2
+	}
+
+	return result
+}
+
+type analysisParametersKey struct{}
+
+// WithAnalysisParameters sets the parameters chosen for the analysis run
+// with ctx.
+func WithAnalysisParameters(ctx context.Context,
+	params AnalysisParameters) context.Context {
+	return context.WithValue(ctx, analysisParametersKey{}, params)
+}
+
+// AnalysisParametersFromContext returns the parameters set on ctx completed
+// with profile and then with the built-in defaults, so every field is set.
+func AnalysisParametersFromContext(ctx context.Context,
+	profile AnalysisParameters) AnalysisParameters {
+	params, _ := ctx.Value(analysisParametersKey{}).(AnalysisParameters)
+	return params.WithDefaults(profile).
+		WithDefaults(DefaultAnalysisParameters())
+}
@@ -0,0 +1,137 @@
+package pipeline
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var allParameterSections = []string{
+	ParametersUnicycler, ParametersAbricate, ParametersBlastX,
+	ParametersBlastN, ParametersKraken2,
+}
+
+func TestParseAnalysisParameters(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		params, err := ParseAnalysisParameters([]byte(
+			`{"unicycler":{"mode":"bold"},"blastx":{"evalue":1e-5},`+
+				`"blastn":{"evalue":1e-20}}`),
+			allParameterSections)
+
+		assert.NoError(t, err)
+		assert.Equal(t, AnalysisParameters{
+			Unicycler: &UnicyclerParameters{Mode: "bold"},
+			BlastX:    &BlastXParameters{Evalue: 1e-5},
+			BlastN:    &BlastNParameters{Evalue: 1e-20},
+		}, params)
+	})
+
+	t.Run("Success - Empty", func(t *testing.T) {
+		params, err := ParseAnalysisParameters(nil, nil)
+
+		assert.NoError(t, err)
+		assert.Equal(t, AnalysisParameters{}, params)
+	})
+
+	t.Run("Error - Section Not Allowed", func(t *testing.T) {
+		_, err := ParseAnalysisParameters([]byte(
+			`{"abricate":{"min_coverage":80}}`),
+			[]string{ParametersUnicycler})
+		assert.ErrorIs(t, err, ErrInvalidParameters)
+	})
+
+	t.Run("Error - Unknown Field", func(t *testing.T) {
+		_, err := ParseAnalysisParameters([]byte(
+			`{"unicycler":{"depth":3}}`), allParameterSections)
+		assert.ErrorIs(t, err, ErrInvalidParameters)
+	})
+
+	t.Run("Error - Invalid Mode", func(t *testing.T) {
+		_, err := ParseAnalysisParameters([]byte(
+			`{"unicycler":{"mode":"fast"}}`), allParameterSections)
+		assert.ErrorIs(t, err, ErrInvalidParameters)
+	})
+
+	t.Run("Error - Threshold Out Of Range", func(t *testing.T) {
+		_, err := ParseAnalysisParameters([]byte(
+			`{"abricate":{"min_identity":120}}`), allParameterSections)
+		assert.ErrorIs(t, err, ErrInvalidParameters)
+	})
+
+	t.Run("Error - BlastN Evalue Out Of Range", func(t *testing.T) {
+		_, err := ParseAnalysisParameters([]byte(
+			`{"blastn":{"evalue":11}}`), allParameterSections)
+		assert.ErrorIs(t, err, ErrInvalidParameters)
+	})
+
+	t.Run("Error - Kraken2 Cut-Off Out Of Range", func(t *testing.T) {
+		_, err := ParseAnalysisParameters([]byte(
+			`{"kraken2":{"secondary_min_percent":-1}}`),
+			allParameterSections)
+		assert.ErrorIs(t, err, ErrInvalidParameters)
+	})
+
+	t.Run("Error - Malformed JSON", func(t *testing.T) {
+		_, err := ParseAnalysisParameters([]byte(`[1]`),
+			allParameterSections)
+		assert.ErrorIs(t, err, ErrInvalidParameters)
+	})
+}
+
+func TestAnalysisParametersWithDefaults(t *testing.T) {
+	params := AnalysisParameters{
+		Abricate: &AbricateParameters{MinCoverage: 80},
+		BlastX:   &BlastXParameters{Evalue: 1e-5},
+		Kraken2:  &Kraken2Parameters{SecondaryMinPercent: 10},
+	}
+
+	result := params.WithDefaults(DefaultAnalysisParameters())
+
+	assert.Equal(t, DefaultAnalysisParameters().Unicycler, result.Unicycler)
+	assert.Equal(t, &AbricateParameters{MinCoverage: 80, MinIdentity: 90},
+		result.Abricate)
+	assert.Equal(t, 1e-5, result.BlastX.Evalue)
+	assert.Equal(t, 0.001, result.BlastN.Evalue)
+	assert.Equal(t, &Kraken2Parameters{MinPercent: 0.1,
+		SecondaryMinPercent: 10}, result.Kraken2)
+	assert.Equal(t, 0.0, params.Abricate.MinIdentity)
+}
+
+func TestLoadAnalysisParameters(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "parameters.json")
+		assert.NoError(t, os.WriteFile(path,
+			[]byte(`{"abricate":{"min_coverage":85,"min_identity":85}}`),
+			0644))
+
+		params, err := LoadAnalysisParameters(path)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 85.0, params.Abricate.MinCoverage)
+		assert.Equal(t, "conservative", params.Unicycler.Mode)
+	})
+
+	t.Run("Error - File Not Found", func(t *testing.T) {
+		_, err := LoadAnalysisParameters(filepath.Join(t.TempDir(),
+			"missing.json"))
+		assert.Error(t, err)
+	})
+}
+
+func TestAnalysisParametersFromContext(t *testing.T) {
+	profile := AnalysisParameters{BlastX: &BlastXParameters{Evalue: 1e-3}}
+	ctx := WithAnalysisParameters(context.Background(), AnalysisParameters{
+		Unicycler: &UnicyclerParameters{MinFastaLength: 1000},
+	})
+
+	params := AnalysisParametersFromContext(ctx, profile)
+
+	assert.Equal(t, &UnicyclerParameters{
+		Mode: "conservative", MinFastaLength: 1000,
+	}, params.Unicycler)
+	assert.Equal(t, 1e-3, params.BlastX.Evalue)
+	assert.Equal(t, 90.0, params.Abricate.MinCoverage)
+}
@@ -12,44 +12,51 @@ import (
 )
 
 type ToolsConfig struct {
-	FastQCPath         string
-	UnicyclerPath      string
-	SpadesPath         string
-	CheckMPath         string
-	Kraken2Path        string
-	KrakenDBPath       string
-	FastANIPath        string
-	AbricatePath       string
-	MLSTPath           string
-	ResfinderDBPath    string
-	PoliDbPseudo       string
-	PoliDbKleb         string
-	PoliDbEntero       string
-	PoliDbAcineto      string
-	OtherDbPseudo      string
-	OtherDbKleb        string
-	OtherDbEntero      string
-	OtherDbAcineto     string
-	FastaniListKleb    string
-	FastaniListEntero  string
-	FastaniListAcineto string
+	FastQCPath      string
+	UnicyclerPath   string
+	SpadesPath      string
+	CheckMPath      string
+	Kraken2Path     string
+	KrakenDBPath    string
+	FastANIPath     string
+	AbricatePath    string
+	MLSTPath        string
+	ResfinderDBPath string
+	AMRFinderPath   string
+	// AMRFinderDBPath overrides the database installed with amrfinder.
+	AMRFinderDBPath string
+	// AMREngine detects acquired resistance unless the species profile
+	// chooses another one. ABRicate is used when empty.
+	AMREngine AMREngine
+	// Species holds the profiles ProcessSpecies types species with. The
+	// built-in profiles are used when nil.
+	Species *SpeciesRegistry
+	// Parameters is the server profile completing the parameters chosen for
+	// each analysis.
+	Parameters AnalysisParameters
+	// Resources limits the time, threads and memory of each step. Steps
+	// are not limited when nil.
+	Resources *ResourceLimits
 }
 
 type CabgenPipeline interface {
 	GetConfig() *ToolsConfig
 	RunFastQC(ctx context.Context, read1, read2, outputDir string) (
 		string, string, error)
-	RunUnicycler(ctx context.Context, threads int,
-		read1, read2, spadesPath, outputDir, outputFile string) (string, error)
+	RunUnicycler(ctx context.Context, threads int, reads UnicyclerReads,
+		spadesPath, outputDir, outputFile string) (string, error)
 	RunProkka(ctx context.Context, threads int,
 		assembly, outputDir string) error
 	RunCheckM(ctx context.Context, threads int, sample, assemblyDir,
 		outputDir string) (*CheckMResult, error)
 	RunKraken2(ctx context.Context, threads int, assembly,
-		outputDir string) (*KrakenSpecies, *KrakenSpecies, error)
+		outputDir string) (*KrakenComposition, error)
 	RunBlastX(ctx context.Context, query, DB, outputFile string) error
+	RunBlastN(ctx context.Context, query, DB, outputFile string) error
 	RunAbricate(ctx context.Context, threads int, db, input,
 		outputFile string) error
+	RunAMRFinder(ctx context.Context, threads int, assembly, organism,
+		outputFile string) error
 	ProcessSpecies(ctx context.Context, threads int,
 		sampleID, mostCommon, assemblyPath, outputDir string) (
 		*SpeciesResult, error)
@@ -85,10 +92,14 @@ func (p *cabgenPipeline) RunFastQC(
 	}
 
 	read1Name, _, _ := strings.Cut(filepath.Base(read1), ".")
-	read2Name, _, _ := strings.Cut(filepath.Base(read2), ".")
-
 	outputHTMLfile1 := filepath.Join(outputDir,
 		fmt.Sprintf("%s_fastqc.html", read1Name))
+	// Single-end samples have no second report.
+	if read2 == "" {
+		return outputHTMLfile1, "", nil
+	}
+
+	read2Name, _, _ := strings.Cut(filepath.Base(read2), ".")
 	outputHTMLfile2 := filepath.Join(outputDir,
 		fmt.Sprintf("%s_fastqc.html", read2Name))
 
@@ -96,12 +107,15 @@ func (p *cabgenPipeline) RunFastQC(
 }
 
 func (p *cabgenPipeline) RunUnicycler(ctx context.Context, threads int,
-	read1, read2, spadesPath, outputDir, outputFile string) (string, error) {
+	reads UnicyclerReads, spadesPath, outputDir, outputFile string) (string,
+	error) {
 	threadsStr := strconv.Itoa(threads)
 
+	params := AnalysisParametersFromContext(ctx, p.Config.Parameters)
+
 	unicyclerCmdArgs := p.Runner.BuildUnicyclerCmd(
-		p.Config.UnicyclerPath, read1, read2, outputDir, threadsStr,
-		p.Config.SpadesPath)
+		p.Config.UnicyclerPath, reads, outputDir, threadsStr,
+		p.Config.SpadesPath, *params.Unicycler)
 
 	if _, err := p.Runner.Run(ctx, unicyclerCmdArgs); err != nil {
 		return "", err
@@ -159,8 +173,11 @@ func (p *cabgenPipeline) RunCheckM(ctx context.Context, threads int,
 	return result, nil
 }
 
+// RunKraken2 classifies the assembly and returns the composition above the
+// min_percent cut-off of the analysis parameters.
 func (p *cabgenPipeline) RunKraken2(ctx context.Context, threads int, assembly,
-	outputDir string) (*KrakenSpecies, *KrakenSpecies, error) {
+	outputDir string) (*KrakenComposition, error) {
+	params := AnalysisParametersFromContext(ctx, p.Config.Parameters)
 	threadsStr := strconv.Itoa(threads)
 
 	krakenArgs := p.Runner.BuildKraken2Cmd(
@@ -168,21 +185,37 @@ func (p *cabgenPipeline) RunKraken2(ctx context.Context, threads int, assembly,
 		threadsStr, assembly,
 	)
 	if _, err := p.Runner.Run(ctx, krakenArgs); err != nil {
-		return nil, nil, err
+		return nil, err
 	}
 
 	krakenReport := filepath.Join(outputDir, "report_kraken")
-	kResult1, kResult2, err := KrakenSpeciesCounter(krakenReport)
+	composition, err := ParseKrakenReport(krakenReport)
 	if err != nil {
-		return nil, nil, err
+		return nil, err
 	}
 
-	return kResult1, kResult2, nil
+	return composition.Filter(params.Kraken2.MinPercent), nil
 }
 
 func (p *cabgenPipeline) RunBlastX(ctx context.Context, query, DB,
 	outputFile string) error {
-	blastArgs := p.Runner.BuildBlastXCmd(DB, query, outputFile)
+	params := AnalysisParametersFromContext(ctx, p.Config.Parameters)
+
+	blastArgs := p.Runner.BuildBlastXCmd(DB, query, outputFile,
+		*params.BlastX)
+	if _, err := p.Runner.Run(ctx, blastArgs); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (p *cabgenPipeline) RunBlastN(ctx context.Context, query, DB,
+	outputFile string) error {
+	params := AnalysisParametersFromContext(ctx, p.Config.Parameters)
+
+	blastArgs := p.Runner.BuildBlastNCmd(DB, query, outputFile,
+		*params.BlastN)
 	if _, err := p.Runner.Run(ctx, blastArgs); err != nil {
 		return err
 	}
@@ -195,14 +228,71 @@ func (p *cabgenPipeline) RunAbricate(ctx context.Context, threads int, db,
 	threadsStr := strconv.Itoa(threads)
 
 	abricateArgs := p.Runner.BuildAbricateCmd(p.Config.AbricatePath, db,
-		input, outputFile, threadsStr)
-	if _, err := p.Runner.Run(ctx, abricateArgs); err != nil {
+		input, threadsStr)
+	output, err := p.Runner.Run(ctx, abricateArgs)
+	if err != nil {
+		return err
+	}
+
+	if err := os.WriteFile(outputFile, []byte(output), 0644); err != nil {
+		return fmt.Errorf("failed to write abricate output: %w", err)
+	}
+
+	return nil
+}
+
+func (p *cabgenPipeline) RunAMRFinder(ctx context.Context, threads int,
+	assembly, organism, outputFile string) error {
+	threadsStr := strconv.Itoa(threads)
+
+	amrfinderArgs := p.Runner.BuildAMRFinderCmd(p.Config.AMRFinderPath,
+		assembly, organism, p.Config.AMRFinderDBPath, outputFile, threadsStr)
+	if _, err := p.Runner.Run(ctx, amrfinderArgs); err != nil {
 		return err
 	}
 
 	return nil
 }
 
+// SpeciesRegistry returns the configured profiles, or the built-in ones.
+func (c *ToolsConfig) SpeciesRegistry() *SpeciesRegistry {
+	if c.Species != nil {
+		return c.Species
+	}
+	registry, _ := DefaultSpeciesRegistry()
+	return registry
+}
+
+// AMREngineFor returns the engine detecting acquired resistance in the
+// species Kraken2 found, with the AMRFinderPlus organism of its profile.
+func (c *ToolsConfig) AMREngineFor(mostCommon string) (AMREngine, string) {
+	engine := c.AMREngine
+	if engine == "" {
+		engine = AMREngineAbricate
+	}
+
+	profile := c.SpeciesRegistry().Match(normalizeKrakenName(mostCommon))
+	if profile == nil {
+		return engine, ""
+	}
+	if profile.AMREngine != "" {
+		if parsed, err := ParseAMREngine(profile.AMREngine); err == nil {
+			engine = parsed
+		}
+	}
+	return engine, profile.AMRFinderOrganism
+}
+
+// normalizeKrakenName returns the genus and species of a Kraken2 name,
+// lowercased and without spaces, as matched by species profiles.
+func normalizeKrakenName(mostCommon string) string {
+	parts := strings.Fields(mostCommon)
+	if len(parts) >= 2 {
+		return strings.ToLower(parts[0] + parts[1])
+	}
+	return strings.ToLower(strings.TrimSpace(mostCommon))
+}
+
 func (p *cabgenPipeline) ProcessSpecies(ctx context.Context, threads int,
 	sampleID, mostCommon, assemblyPath, outputDir string) (
 	*SpeciesResult, error) {
@@ -216,7 +306,7 @@ func (p *cabgenPipeline) ProcessSpecies(ctx context.Context, threads int,
 		species = parts[1]
 	}
 
-	normalizedName := strings.ToLower(genus + species)
+	normalizedName := normalizeKrakenName(mostCommon)
 
 	displayName := fmt.Sprintf("%s %s", capitalizeFirst(genus),
 		strings.ToLower(species))
@@ -227,67 +317,54 @@ func (p *cabgenPipeline) ProcessSpecies(ctx context.Context, threads int,
 	result := &SpeciesResult{
 		DisplayName:    strings.TrimSpace(displayName),
 		MLSTSpecies:    "",
-		OtherMutations: []string{},
-		PoliMutations:  []string{},
+		OtherMutations: []Mutation{},
+		PoliMutations:  []Mutation{},
+	}
+
+	registry := p.Config.SpeciesRegistry()
+	profile := registry.Match(normalizedName)
+
+	mlstExclude := registry.MLSTExclude()
+	if profile != nil {
+		mlstExclude = profile.MLSTExclude
 	}
 
 	threadsStr := strconv.Itoa(threads)
 
 	mlstResultPath := filepath.Join(outputDir, "mlst.csv")
 	mlstArgs := p.Runner.BuildMLSTCmd(p.Config.MLSTPath, threadsStr,
-		assemblyPath, mlstResultPath)
-	if _, err := p.Runner.Run(ctx, mlstArgs); err == nil {
+		assemblyPath, mlstExclude)
+	if output, err := p.Runner.Run(ctx, mlstArgs); err == nil &&
+		os.WriteFile(mlstResultPath, []byte(output), 0644) == nil {
 		if mlstResult, err := ParseMLST(mlstResultPath); err == nil &&
 			mlstResult != "" {
 			result.MLSTSpecies = mlstResult
 		}
 	}
 
-	isEntero := isEnterobacter(normalizedName)
-	isAcineto := isAcinetobacter(normalizedName)
-	isKleb := isKlebsiella(normalizedName)
-	isPseudo := isPseudomonas(normalizedName)
-
-	var poliDbFullPath, otherDbFullPath, fastAniRefFullPath string
-
-	if isPseudo {
-		poliDbFullPath = p.Config.PoliDbPseudo
-		otherDbFullPath = p.Config.OtherDbPseudo
-	} else if isKleb {
-		poliDbFullPath = p.Config.PoliDbKleb
-		otherDbFullPath = p.Config.OtherDbKleb
-		fastAniRefFullPath = p.Config.FastaniListKleb
-	} else if isEntero {
-		poliDbFullPath = p.Config.PoliDbEntero
-		otherDbFullPath = p.Config.OtherDbEntero
-		fastAniRefFullPath = p.Config.FastaniListEntero
-	} else if isAcineto {
-		poliDbFullPath = p.Config.PoliDbAcineto
-		otherDbFullPath = p.Config.OtherDbAcineto
-		fastAniRefFullPath = p.Config.FastaniListAcineto
-	} else {
+	if profile == nil {
 		if p.Logger != nil {
-			p.Logger.Debug("Species did not match any known genus, skipping BlastX/FastANI",
+			p.Logger.Debug("Species did not match any species profile, skipping BlastX/FastANI",
 				zap.String("sampleID", sampleID),
 				zap.String("species", mostCommon),
 				zap.String("normalizedName", normalizedName))
 		}
+		return result, nil
 	}
 
-	if (isEntero || isAcineto || isKleb) && fastAniRefFullPath == "" {
+	if profile.FastANIList == "" {
 		if p.Logger != nil {
-			p.Logger.Warn("Matched genus but FASTANI ref list not configured, skipping FastANI",
+			p.Logger.Debug("Species profile has no FastANI reference list, skipping FastANI",
 				zap.String("sampleID", sampleID),
-				zap.String("species", mostCommon))
+				zap.String("species", mostCommon),
+				zap.String("profile", profile.Name))
 		}
-	}
-
-	if (isEntero || isAcineto || isKleb) && fastAniRefFullPath != "" {
+	} else {
 		fastAniOut := filepath.Join(outputDir,
 			fmt.Sprintf("%s_out-fastANI", sampleID))
 
 		fastAniArgs := p.Runner.BuildFastANICmd(
-			p.Config.FastANIPath, assemblyPath, fastAniRefFullPath,
+			p.Config.FastANIPath, assemblyPath, profile.FastANIList,
 			fastAniOut, threadsStr,
 		)
 		if _, err := p.Runner.Run(ctx, fastAniArgs); err != nil {
@@ -311,48 +388,37 @@ func (p *cabgenPipeline) ProcessSpecies(ctx context.Context, threads int,
 		}
 	}
 
-	if poliDbFullPath != "" && otherDbFullPath != "" {
-		blastPoliFile := filepath.Join(outputDir, fmt.Sprintf(
-			"%s_blastPoli", sampleID))
-
-		if err := p.RunBlastX(ctx, assemblyPath, poliDbFullPath,
-			blastPoliFile); err != nil {
-			return result, nil
+	// Each database is searched independently; a failed search stops the
+	// remaining ones and keeps what was found so far.
+	searches := []struct {
+		db, suffix string
+		genes      []string
+		run        func(ctx context.Context, query, DB, outputFile string) error
+		found      *[]Mutation
+	}{
+		{profile.PoliDB, "blastPoli", profile.PoliGenes, p.RunBlastX,
+			&result.PoliMutations},
+		{profile.OtherDB, "blastOther", profile.OtherGenes, p.RunBlastX,
+			&result.OtherMutations},
+		{profile.RRNADB, "blastRRNA", profile.RRNAGenes, p.RunBlastN,
+			&result.OtherMutations},
+	}
+	for _, search := range searches {
+		if search.db == "" {
+			continue
 		}
 
-		blastOtherFile := filepath.Join(outputDir, fmt.Sprintf(
-			"%s_blastOther", sampleID))
-
-		if err := p.RunBlastX(ctx, assemblyPath, otherDbFullPath,
-			blastOtherFile); err != nil {
+		blastFile := filepath.Join(outputDir, fmt.Sprintf("%s_%s", sampleID,
+			search.suffix))
+		if err := search.run(ctx, assemblyPath, search.db,
+			blastFile); err != nil {
 			return result, nil
 		}
 
-		poliFinder := NewMutationFinder(blastPoliFile)
-		otherFinder := NewMutationFinder(blastOtherFile)
-
-		var otherMut, poliMut []string
-		var errPoli, errOther error
-
-		if isAcineto {
-			_, poliMut, errPoli = poliFinder.FindAcinetoMutations()
-			otherMut, _, errOther = otherFinder.FindAcinetoMutations()
-		} else if isEntero {
-			_, poliMut, errPoli = poliFinder.FindEcloacaeMutations()
-			otherMut, _, errOther = otherFinder.FindEcloacaeMutations()
-		} else if isKleb {
-			_, poliMut, errPoli = poliFinder.FindKlebMutations()
-			otherMut, _, errOther = otherFinder.FindKlebMutations()
-		} else if isPseudo {
-			_, poliMut, errPoli = poliFinder.FindPseudoMutations()
-			otherMut, _, errOther = otherFinder.FindPseudoMutations()
-		}
-
-		if errPoli == nil && poliMut != nil {
-			result.PoliMutations = poliMut
-		}
-		if errOther == nil && otherMut != nil {
-			result.OtherMutations = otherMut
+		mutations, err := NewMutationFinder(blastFile).FindMutations(
+			search.genes)
+		if err == nil && mutations != nil {
+			*search.found = append(*search.found, mutations...)
 		}
 	}
 
@@ -22,30 +22,61 @@ func writeFile(t *testing.T, path, content string) {
 
 func defaultConfig() pipeline.ToolsConfig {
 	return pipeline.ToolsConfig{
-		FastQCPath:         "fastqc",
-		UnicyclerPath:      "unicycler",
-		SpadesPath:         "/spades",
-		CheckMPath:         "checkm",
-		Kraken2Path:        "kraken2",
-		KrakenDBPath:       "/db",
-		FastANIPath:        "fastani",
-		AbricatePath:       "abricate",
-		MLSTPath:           "mlst",
-		ResfinderDBPath:    "/resfinder_db",
-		PoliDbPseudo:       "/blast/poli/proteins_pseudo_poli.fasta",
-		PoliDbKleb:         "/blast/poli/proteins_kleb_poli.fasta",
-		PoliDbEntero:       "/blast/poli/proteins_Ecloacae_poli.fasta",
-		PoliDbAcineto:      "/blast/poli/proteins_acineto_poli.fasta",
-		OtherDbPseudo:      "/blast/other/proteins_outrasMut_pseudo.fasta",
-		OtherDbKleb:        "/blast/other/proteins_outrasMut_kleb.fasta",
-		OtherDbEntero:      "/blast/other/proteins_outrasMut_Ecloacae.fasta",
-		OtherDbAcineto:     "/blast/other/proteins_outrasMut_acineto.fasta",
-		FastaniListKleb:    "/fastani/kleb_database/lista-kleb",
-		FastaniListEntero:  "/fastani/fastANI/list_entero",
-		FastaniListAcineto: "/fastani/fastANI_acineto/list-acineto",
+		FastQCPath:      "fastqc",
+		UnicyclerPath:   "unicycler",
+		SpadesPath:      "/spades",
+		CheckMPath:      "checkm",
+		Kraken2Path:     "kraken2",
+		KrakenDBPath:    "/db",
+		FastANIPath:     "fastani",
+		AbricatePath:    "abricate",
+		MLSTPath:        "mlst",
+		ResfinderDBPath: "/resfinder_db",
+		Species:         defaultSpecies(),
 	}
 }
 
+func defaultSpecies() *pipeline.SpeciesRegistry {
+	return &pipeline.SpeciesRegistry{Profiles: []pipeline.SpeciesProfile{
+		{
+			Name:           "Acinetobacter baumannii complex",
+			KrakenMatchers: []string{"Acinetobacter baumannii"},
+			PoliGenes:      []string{"PmrA", "PmrB"},
+			OtherGenes:     []string{"GyrA", "ParC"},
+			PoliDB:         "/blast/poli/proteins_acineto_poli.fasta",
+			OtherDB:        "/blast/other/proteins_outrasMut_acineto.fasta",
+			FastANIList:    "/fastani/fastANI_acineto/list-acineto",
+			MLSTExclude:    []string{"abaumannii"},
+		},
+		{
+			Name:           "Enterobacter cloacae complex",
+			KrakenMatchers: []string{"Enterobacter cloacae"},
+			PoliGenes:      []string{"PmrA", "MgrB"},
+			OtherGenes:     []string{"GyrA", "ParC"},
+			PoliDB:         "/blast/poli/proteins_Ecloacae_poli.fasta",
+			OtherDB:        "/blast/other/proteins_outrasMut_Ecloacae.fasta",
+			FastANIList:    "/fastani/fastANI/list_entero",
+		},
+		{
+			Name:           "Klebsiella pneumoniae",
+			KrakenMatchers: []string{"klebsiellapneumoniae"},
+			PoliGenes:      []string{"PmrA", "PmrB"},
+			OtherGenes:     []string{"GyrA", "AcrR"},
+			PoliDB:         "/blast/poli/proteins_kleb_poli.fasta",
+			OtherDB:        "/blast/other/proteins_outrasMut_kleb.fasta",
+			FastANIList:    "/fastani/kleb_database/lista-kleb",
+		},
+		{
+			Name:           "Pseudomonas aeruginosa",
+			KrakenMatchers: []string{"Pseudomonas aeruginosa"},
+			PoliGenes:      []string{"PmrA", "ColR"},
+			OtherGenes:     []string{"GyrA", "OprD"},
+			PoliDB:         "/blast/poli/proteins_pseudo_poli.fasta",
+			OtherDB:        "/blast/other/proteins_outrasMut_pseudo.fasta",
+		},
+	}}
+}
+
 func successRun(_ context.Context, _ []string) (string, error) {
 	return "", nil
 }
@@ -54,21 +85,12 @@ func errorRun(_ context.Context, _ []string) (string, error) {
 	return "", fmt.Errorf("command failed")
 }
 
-const organismMockContent = `
-> GyrA|
-Length=100
-Identities = 95/95 (95%)
-Query  1   ATCG 4
-           || |
-Sbjct  1   ATAG 4
-
-> PmrA|
-Length=100
-Identities = 95/95 (95%)
-Query  1   ATCG 4
-           || |
-Sbjct  1   ATAG 4
-`
+// organismMockContent holds tabular blastx hits of GyrA and PmrA, both
+// with an A3C substitution.
+const organismMockContent = "GyrA|WP_000072067.1\t20\tcontig_1\t1\t60\t1\t20\t20\t" +
+	"95.000\tATCGKLMNPQRSTVWYACDE\tATAGKLMNPQRSTVWYACDE\n" +
+	"PmrA|WP_000125009.1\t20\tcontig_1\t1\t60\t1\t20\t20\t" +
+	"95.000\tATCGKLMNPQRSTVWYACDE\tATAGKLMNPQRSTVWYACDE\n"
 
 func krakenReportLine(name string, cladeReads int) string {
 	return fmt.Sprintf("1.00\t%d\t%d\tS\t0\t%s\n", cladeReads, cladeReads,
@@ -92,6 +114,16 @@ func TestRunFastQC(t *testing.T) {
 		assert.Equal(t, "/out/r2_fastqc.html", html2)
 	})
 
+	t.Run("Success - Single-end", func(t *testing.T) {
+		p := pipeline.NewCabgenPipeline(&mocks.MockToolRunner{RunFunc: successRun},
+			defaultConfig(), nil)
+		html1, html2, err := p.RunFastQC(context.Background(),
+			"/data/r1.fq", "", "/out")
+		assert.NoError(t, err)
+		assert.Equal(t, "/out/r1_fastqc.html", html1)
+		assert.Empty(t, html2)
+	})
+
 	t.Run("Error", func(t *testing.T) {
 		p := pipeline.NewCabgenPipeline(&mocks.MockToolRunner{RunFunc: errorRun},
 			defaultConfig(), nil)
@@ -101,6 +133,8 @@ func TestRunFastQC(t *testing.T) {
 }
 
 func TestRunUnicycler(t *testing.T) {
+	reads := pipeline.UnicyclerReads{Read1: "r1", Read2: "r2"}
+
 	t.Run("Success", func(t *testing.T) {
 		outDir := t.TempDir()
 		assemblyFile := filepath.Join(outDir, "assembly.fasta")
@@ -108,7 +142,7 @@ func TestRunUnicycler(t *testing.T) {
 
 		p := pipeline.NewCabgenPipeline(&mocks.MockToolRunner{RunFunc: successRun},
 			defaultConfig(), nil)
-		path, err := p.RunUnicycler(context.Background(), 4, "r1", "r2",
+		path, err := p.RunUnicycler(context.Background(), 4, reads,
 			"/spades", outDir, "A01_assembly.fasta")
 		assert.NoError(t, err)
 		assert.Equal(t, filepath.Join(outDir, "A01_assembly.fasta"), path)
@@ -119,7 +153,7 @@ func TestRunUnicycler(t *testing.T) {
 	t.Run("Success - File Missing Falls Back", func(t *testing.T) {
 		p := pipeline.NewCabgenPipeline(&mocks.MockToolRunner{RunFunc: successRun},
 			defaultConfig(), nil)
-		path, err := p.RunUnicycler(context.Background(), 4, "r1", "r2",
+		path, err := p.RunUnicycler(context.Background(), 4, reads,
 			"/spades", "/out", "A01_assembly.fasta")
 		assert.NoError(t, err)
 		assert.Equal(t, "/out/assembly.fasta", path)
@@ -128,7 +162,7 @@ func TestRunUnicycler(t *testing.T) {
 	t.Run("Error", func(t *testing.T) {
 		p := pipeline.NewCabgenPipeline(&mocks.MockToolRunner{RunFunc: errorRun},
 			defaultConfig(), nil)
-		_, err := p.RunUnicycler(context.Background(), 4, "r1", "r2", "",
+		_, err := p.RunUnicycler(context.Background(), 4, reads, "",
 			"/out", "A01_assembly.fasta")
 		assert.Error(t, err)
 	})
@@ -168,6 +202,39 @@ func TestRunBlastX(t *testing.T) {
 	})
 }
 
+func TestRunBlastN(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		var got []string
+		var evalue float64
+		p := pipeline.NewCabgenPipeline(&mocks.MockToolRunner{
+			RunFunc: successRun,
+			BuildBlastNCmdFunc: func(blastDB, inputFile, outputFile string,
+				params pipeline.BlastNParameters) []string {
+				got = []string{blastDB, inputFile, outputFile}
+				evalue = params.Evalue
+				return []string{"blastn"}
+			},
+		}, defaultConfig(), nil)
+		ctx := pipeline.WithAnalysisParameters(context.Background(),
+			pipeline.AnalysisParameters{
+				BlastX: &pipeline.BlastXParameters{Evalue: 1e-5},
+				BlastN: &pipeline.BlastNParameters{Evalue: 1e-20},
+			})
+		err := p.RunBlastN(ctx, "contigs.fa", "/db", "out.txt")
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"/db", "contigs.fa", "out.txt"}, got)
+		assert.Equal(t, 1e-20, evalue)
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		p := pipeline.NewCabgenPipeline(&mocks.MockToolRunner{RunFunc: errorRun},
+			defaultConfig(), nil)
+		err := p.RunBlastN(context.Background(), "contigs.fa", "/db",
+			"out.txt")
+		assert.Error(t, err)
+	})
+}
+
 func TestRunCheckM(t *testing.T) {
 	t.Run("Success", func(t *testing.T) {
 		outDir := t.TempDir()
@@ -225,23 +292,41 @@ func TestRunKraken2(t *testing.T) {
 
 		p := pipeline.NewCabgenPipeline(&mocks.MockToolRunner{RunFunc: successRun},
 			defaultConfig(), nil)
-		first, second, err := p.RunKraken2(context.Background(), 4,
+		composition, err := p.RunKraken2(context.Background(), 4,
 			"contigs.fa", outDir)
 		assert.NoError(t, err)
-		assert.NotNil(t, first)
-		assert.Equal(t, "Escherichia coli", first.Name)
-		assert.NotNil(t, second)
-		assert.Equal(t, "Klebsiella pneumoniae", second.Name)
+		assert.NotNil(t, composition)
+		assert.Len(t, composition.Species, 2)
+		assert.Equal(t, "Escherichia coli", composition.Primary().Name)
+		assert.Equal(t, "Klebsiella pneumoniae",
+			composition.Secondary().Name)
+	})
+
+	t.Run("Success - Species Below Cut-Off Dropped", func(t *testing.T) {
+		outDir := t.TempDir()
+		writeFile(t, filepath.Join(outDir, "report_kraken"),
+			"97.00\t970\t970\tS\t562\tEscherichia coli\n"+
+				"0.50\t5\t5\tS\t573\tKlebsiella pneumoniae\n")
+
+		p := pipeline.NewCabgenPipeline(&mocks.MockToolRunner{RunFunc: successRun},
+			defaultConfig(), nil)
+		ctx := pipeline.WithAnalysisParameters(context.Background(),
+			pipeline.AnalysisParameters{
+				Kraken2: &pipeline.Kraken2Parameters{MinPercent: 1},
+			})
+		composition, err := p.RunKraken2(ctx, 4, "contigs.fa", outDir)
+		assert.NoError(t, err)
+		assert.Len(t, composition.Species, 1)
+		assert.Nil(t, composition.Secondary())
 	})
 
 	t.Run("Error", func(t *testing.T) {
 		p := pipeline.NewCabgenPipeline(&mocks.MockToolRunner{RunFunc: errorRun},
 			defaultConfig(), nil)
-		first, second, err := p.RunKraken2(context.Background(), 4,
+		composition, err := p.RunKraken2(context.Background(), 4,
 			"contigs.fa", "/out")
 		assert.Error(t, err)
-		assert.Nil(t, first)
-		assert.Nil(t, second)
+		assert.Nil(t, composition)
 	})
 }
 
@@ -281,8 +366,10 @@ func TestProcessSpecies(t *testing.T) {
 		assert.NoError(t, err)
 		assert.NotNil(t, result)
 		assert.Equal(t, "Acinetobacter baumannii", result.DisplayName)
-		assert.Contains(t, result.OtherMutations, "GyrA:A3C")
-		assert.Contains(t, result.PoliMutations, "PmrA:A3C")
+		assert.Contains(t, pipeline.RenderMutations(result.OtherMutations),
+			"GyrA:A3C")
+		assert.Contains(t, pipeline.RenderMutations(result.PoliMutations),
+			"PmrA:A3C")
 	})
 
 	t.Run("Success - Klebsiella Finds Mutations", func(t *testing.T) {
@@ -298,7 +385,8 @@ func TestProcessSpecies(t *testing.T) {
 		result, err := p.ProcessSpecies(context.Background(), 4, sampleID,
 			"Klebsiella pneumoniae", "contigs.fa", outDir)
 		assert.NoError(t, err)
-		assert.Contains(t, result.OtherMutations, "GyrA:A3C")
+		assert.Contains(t, pipeline.RenderMutations(result.OtherMutations),
+			"GyrA:A3C")
 	})
 
 	t.Run("Success - Single Word Species Name", func(t *testing.T) {
@@ -320,9 +408,7 @@ func TestProcessSpecies(t *testing.T) {
 	t.Run("Success - MLST Parsed", func(t *testing.T) {
 		outDir := t.TempDir()
 		sampleID := "s1"
-		mlstPath := filepath.Join(outDir, "mlst.csv")
-		writeFile(t, mlstPath,
-			"contigs.fa,abaumannii,2,oxa0001,ompA0001\n")
+		mlstOutput := "contigs.fa,abaumannii,2,oxa0001,ompA0001\n"
 		writeFile(t, filepath.Join(outDir, sampleID+"_blastPoli"),
 			organismMockContent)
 		writeFile(t, filepath.Join(outDir, sampleID+"_blastOther"),
@@ -330,22 +416,21 @@ func TestProcessSpecies(t *testing.T) {
 
 		p := pipeline.NewCabgenPipeline(&mocks.MockToolRunner{
 			RunFunc: func(ctx context.Context, args []string) (string, error) {
-				return mlstPath, nil
+				return mlstOutput, nil
 			},
 		}, defaultConfig(), nil)
 		result, err := p.ProcessSpecies(context.Background(), 4, sampleID,
 			"Acinetobacter baumannii", "contigs.fa", outDir)
 		assert.NoError(t, err)
 		assert.Equal(t, "abaumannii (ST2)", result.MLSTSpecies)
+		assert.FileExists(t, filepath.Join(outDir, "mlst.csv"))
 	})
 
 	t.Run("Success - MLST Skips When Scheme And ST Are Dash",
 		func(t *testing.T) {
 			outDir := t.TempDir()
 			sampleID := "s1"
-			mlstPath := filepath.Join(outDir, "mlst.csv")
-			writeFile(t, mlstPath,
-				"contigs.fa,-,-,oxa0001,ompA0001\n")
+			mlstOutput := "contigs.fa,-,-,oxa0001,ompA0001\n"
 			writeFile(t, filepath.Join(outDir, sampleID+"_blastPoli"),
 				organismMockContent)
 			writeFile(t, filepath.Join(outDir, sampleID+"_blastOther"),
@@ -354,7 +439,7 @@ func TestProcessSpecies(t *testing.T) {
 			p := pipeline.NewCabgenPipeline(&mocks.MockToolRunner{
 				RunFunc: func(ctx context.Context, args []string) (
 					string, error) {
-					return mlstPath, nil
+					return mlstOutput, nil
 				},
 			}, defaultConfig(), nil)
 			result, err := p.ProcessSpecies(context.Background(), 4,
@@ -365,6 +450,106 @@ func TestProcessSpecies(t *testing.T) {
 				result.MLSTSpecies)
 		})
 
+	t.Run("Success - MLST Uses Profile Exclusions", func(t *testing.T) {
+		var excluded [][]string
+		runner := &mocks.MockToolRunner{
+			RunFunc: successRun,
+			BuildMLSTCmdFunc: func(_, _, _ string, exclude []string) []string {
+				excluded = append(excluded, exclude)
+				return []string{"mlst"}
+			},
+		}
+		p := pipeline.NewCabgenPipeline(runner, defaultConfig(), nil)
+
+		_, err := p.ProcessSpecies(context.Background(), 4, "s1",
+			"Klebsiella pneumoniae", "contigs.fa", t.TempDir())
+		assert.NoError(t, err)
+		_, err = p.ProcessSpecies(context.Background(), 4, "s1",
+			"Staphylococcus aureus", "contigs.fa", t.TempDir())
+		assert.NoError(t, err)
+
+		assert.Equal(t, [][]string{nil, {"abaumannii"}}, excluded)
+	})
+
+	t.Run("Success - Profile Gene Lists Filter Mutations", func(t *testing.T) {
+		outDir := t.TempDir()
+		sampleID := "s1"
+		writeFile(t, filepath.Join(outDir, sampleID+"_blastPoli"),
+			organismMockContent)
+		writeFile(t, filepath.Join(outDir, sampleID+"_blastOther"),
+			organismMockContent)
+
+		cfg := defaultConfig()
+		cfg.Species = &pipeline.SpeciesRegistry{
+			Profiles: []pipeline.SpeciesProfile{{
+				Name:           "Escherichia coli",
+				KrakenMatchers: []string{"Escherichia coli"},
+				PoliGenes:      []string{"MgrB"},
+				OtherGenes:     []string{"GyrA"},
+				PoliDB:         "/blast/poli/proteins_ecoli_poli.fasta",
+				OtherDB:        "/blast/other/proteins_ecoli_other.fasta",
+			}},
+		}
+
+		p := pipeline.NewCabgenPipeline(&mocks.MockToolRunner{
+			RunFunc: successRun,
+		}, cfg, nil)
+		result, err := p.ProcessSpecies(context.Background(), 4, sampleID,
+			"Escherichia coli", "contigs.fa", outDir)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"GyrA:A3C"},
+			pipeline.RenderMutations(result.OtherMutations))
+		assert.Empty(t, result.PoliMutations)
+	})
+
+	t.Run("Success - Searches Each Configured Database", func(t *testing.T) {
+		outDir := t.TempDir()
+		sampleID := "s1"
+		writeFile(t, filepath.Join(outDir, sampleID+"_blastOther"),
+			organismMockContent)
+		writeFile(t, filepath.Join(outDir, sampleID+"_blastRRNA"),
+			"23S|NR_103752.1\t20\tcontig_2\t1\t20\t1\t20\t20\t95.000\t"+
+				"ACTTACGTACGTACGTACGT\tACGTACGTACGTACGTACGT\n")
+
+		cfg := defaultConfig()
+		cfg.Species = &pipeline.SpeciesRegistry{
+			Profiles: []pipeline.SpeciesProfile{{
+				Name:           "Enterococcus",
+				KrakenMatchers: []string{"Enterococcus faecium"},
+				OtherGenes:     []string{"GyrA"},
+				OtherDB:        "/blast/other/proteins_enterococcus.fasta",
+				RRNAGenes:      []string{"23S"},
+				RRNADB:         "/blast/rrna/23S_enterococcus.fasta",
+			}},
+		}
+
+		var searched []string
+		p := pipeline.NewCabgenPipeline(&mocks.MockToolRunner{
+			RunFunc: successRun,
+			BuildBlastXCmdFunc: func(blastDB, _, _ string,
+				_ pipeline.BlastXParameters) []string {
+				searched = append(searched, "blastx "+blastDB)
+				return []string{"blastx"}
+			},
+			BuildBlastNCmdFunc: func(blastDB, _, _ string,
+				_ pipeline.BlastNParameters) []string {
+				searched = append(searched, "blastn "+blastDB)
+				return []string{"blastn"}
+			},
+		}, cfg, nil)
+		result, err := p.ProcessSpecies(context.Background(), 4, sampleID,
+			"Enterococcus faecium", "contigs.fa", outDir)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{
+			"blastx /blast/other/proteins_enterococcus.fasta",
+			"blastn /blast/rrna/23S_enterococcus.fasta",
+		}, searched)
+		assert.Equal(t, []string{"GyrA:A3C", "23S:G3T"},
+			pipeline.RenderMutations(result.OtherMutations))
+		assert.Empty(t, result.PoliMutations)
+	})
+
 	t.Run("Success - BlastX Poli Fails Returns Partial Result", func(t *testing.T) {
 		outDir := t.TempDir()
 		sampleID := "s1"
@@ -379,8 +564,8 @@ func TestProcessSpecies(t *testing.T) {
 			},
 		}, defaultConfig(), nil)
 
-		// Enterobacter cloacae triggers the Enterobacter branch, which
-		// runs MLST, FastANI, then BlastX (poli first). The mock fails on
+		// Enterobacter cloacae matches a profile with a FastANI list, so
+		// ProcessSpecies runs MLST, FastANI, then BlastX (poli first). The mock fails on
 		// call 3, which is the BlastX poli invocation.
 		result, err := p.ProcessSpecies(context.Background(), 4, sampleID,
 			"Enterobacter cloacae", "contigs.fa", outDir)
@@ -410,19 +595,19 @@ func TestProcessSpeciesLogging(t *testing.T) {
 		found := false
 		for _, entry := range logs.All() {
 			if entry.Level == zapcore.DebugLevel &&
-				entry.Message == "Species did not match any known genus, skipping BlastX/FastANI" {
+				entry.Message == "Species did not match any species profile, skipping BlastX/FastANI" {
 				found = true
 			}
 		}
-		assert.True(t, found, "expected debug log about unknown genus")
+		assert.True(t, found, "expected debug log about unknown species")
 	})
 
-	t.Run("Logs warning when FastANI ref list is empty", func(t *testing.T) {
+	t.Run("Logs debug when FastANI ref list is empty", func(t *testing.T) {
 		outDir := t.TempDir()
-		logger, logs := testutils.NewMockLogger(zapcore.WarnLevel)
+		logger, logs := testutils.NewMockLogger(zapcore.DebugLevel)
 
 		cfg := defaultConfig()
-		cfg.FastaniListAcineto = ""
+		cfg.Species.Profiles[0].FastANIList = ""
 
 		p := pipeline.NewCabgenPipeline(
 			&mocks.MockToolRunner{RunFunc: successRun},
@@ -435,12 +620,12 @@ func TestProcessSpeciesLogging(t *testing.T) {
 
 		found := false
 		for _, entry := range logs.All() {
-			if entry.Level == zapcore.WarnLevel &&
-				entry.Message == "Matched genus but FASTANI ref list not configured, skipping FastANI" {
+			if entry.Level == zapcore.DebugLevel &&
+				entry.Message == "Species profile has no FastANI reference list, skipping FastANI" {
 				found = true
 			}
 		}
-		assert.True(t, found, "expected warning log about missing FastANI ref list")
+		assert.True(t, found, "expected debug log about missing FastANI ref list")
 	})
 
 	t.Run("Logs error when FastANI execution fails", func(t *testing.T) {
@@ -0,0 +1,302 @@
+package pipeline
+
+import (
+	"bytes"
+	"embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/pelletier/go-toml/v2"
+)
+
+//go:embed profiles/*.toml
+var defaultProfilesFS embed.FS
+
+// SpeciesProfile describes how a species identified by Kraken2 is typed:
+// the BlastX databases and target genes searched for mutations, the BlastN
+// database for rRNA targets, the FastANI reference list and the MLST schemes
+// mlst must not pick, and the AMR engine used for it.
+type SpeciesProfile struct {
+	Name string `toml:"name" json:"name"`
+	// KrakenMatchers are matched against the Kraken2 genus and species,
+	// lowercased and without spaces (e.g. "klebsiellapneumoniae").
+	KrakenMatchers []string `toml:"kraken_matchers" json:"kraken_matchers"`
+	PoliGenes      []string `toml:"poli_genes" json:"poli_genes"`
+	OtherGenes     []string `toml:"other_genes" json:"other_genes"`
+	PoliDB         string   `toml:"poli_db" json:"poli_db"`
+	OtherDB        string   `toml:"other_db" json:"other_db"`
+	// RRNAGenes are searched in RRNADB, a nucleotide database, with blastn
+	// and reported with the other mutations.
+	RRNAGenes   []string `toml:"rrna_genes" json:"rrna_genes"`
+	RRNADB      string   `toml:"rrna_db" json:"rrna_db"`
+	FastANIList string   `toml:"fastani_list" json:"fastani_list"`
+	MLSTExclude []string `toml:"mlst_exclude" json:"mlst_exclude"`
+	// AMREngine overrides the deployment engine for the species.
+	AMREngine string `toml:"amr_engine" json:"amr_engine"`
+	// AMRFinderOrganism is the --organism of AMRFinderPlus (e.g.
+	// "Klebsiella_pneumoniae"), which enables its point mutations.
+	AMRFinderOrganism string `toml:"amrfinder_organism" json:"amrfinder_organism"`
+
+	// Source is the file the profile was loaded from.
+	Source string `toml:"-" json:"-"`
+	// unsetVars are the environment variables referenced by the paths but
+	// not set when the profile was loaded.
+	unsetVars []string
+}
+
+// Matches reports whether the normalized Kraken2 name belongs to the profile.
+func (p *SpeciesProfile) Matches(normalizedName string) bool {
+	for _, matcher := range p.KrakenMatchers {
+		if matcher = normalizeSpeciesName(matcher); matcher != "" &&
+			strings.Contains(normalizedName, matcher) {
+			return true
+		}
+	}
+	return false
+}
+
+// Validate returns the problems that keep the profile from being used.
+func (p *SpeciesProfile) Validate() []string {
+	var issues []string
+	if strings.TrimSpace(p.Name) == "" {
+		issues = append(issues, "name is required")
+	}
+	if len(p.KrakenMatchers) == 0 {
+		issues = append(issues, "kraken_matchers is required")
+	}
+	if _, err := ParseAMREngine(p.AMREngine); err != nil {
+		issues = append(issues, fmt.Sprintf("amr_engine: %v", err))
+	}
+	for _, name := range p.unsetVars {
+		issues = append(issues, fmt.Sprintf(
+			"environment variable %s is not set", name))
+	}
+
+	databases := []struct {
+		genesField, dbField string
+		genes               []string
+		db                  string
+	}{
+		{"poli_genes", "poli_db", p.PoliGenes, p.PoliDB},
+		{"other_genes", "other_db", p.OtherGenes, p.OtherDB},
+		{"rrna_genes", "rrna_db", p.RRNAGenes, p.RRNADB},
+	}
+	for _, database := range databases {
+		if database.db != "" && len(database.genes) == 0 {
+			issues = append(issues, fmt.Sprintf("%s is required with %s",
+				database.genesField, database.dbField))
+		}
+		if database.db == "" && len(database.genes) > 0 {
+			issues = append(issues, fmt.Sprintf("%s is required with %s",
+				database.dbField, database.genesField))
+		}
+	}
+
+	paths := []struct{ field, path string }{
+		{"poli_db", p.PoliDB},
+		{"other_db", p.OtherDB},
+		{"rrna_db", p.RRNADB},
+		{"fastani_list", p.FastANIList},
+	}
+	for _, entry := range paths {
+		if entry.path == "" {
+			continue
+		}
+		if _, err := os.Stat(entry.path); err != nil {
+			issues = append(issues, fmt.Sprintf("%s: path %s not accessible",
+				entry.field, entry.path))
+		}
+	}
+
+	return issues
+}
+
+// SpeciesProfileCheck is the validation result of one loaded profile.
+type SpeciesProfileCheck struct {
+	Profile *SpeciesProfile
+	Issues  []string
+}
+
+// SpeciesRegistry holds the species profiles in load order. The first
+// profile matching a species is used.
+type SpeciesRegistry struct {
+	Profiles []SpeciesProfile
+}
+
+// Match returns the profile for the normalized Kraken2 name, or nil.
+func (r *SpeciesRegistry) Match(normalizedName string) *SpeciesProfile {
+	if r == nil {
+		return nil
+	}
+	for i := range r.Profiles {
+		if r.Profiles[i].Matches(normalizedName) {
+			return &r.Profiles[i]
+		}
+	}
+	return nil
+}
+
+// MLSTExclude returns the schemes excluded by any profile, used when no
+// profile matches the species.
+func (r *SpeciesRegistry) MLSTExclude() []string {
+	if r == nil {
+		return nil
+	}
+	var exclude []string
+	for _, profile := range r.Profiles {
+		for _, scheme := range profile.MLSTExclude {
+			if !slices.Contains(exclude, scheme) {
+				exclude = append(exclude, scheme)
+			}
+		}
+	}
+	return exclude
+}
+
+// Check validates every profile, including name clashes between them.
+func (r *SpeciesRegistry) Check() []SpeciesProfileCheck {
+	checks := make([]SpeciesProfileCheck, 0, len(r.Profiles))
+	seen := map[string]bool{}
+	for i := range r.Profiles {
+		profile := &r.Profiles[i]
+		issues := profile.Validate()
+
+		name := strings.ToLower(strings.TrimSpace(profile.Name))
+		if name != "" && seen[name] {
+			issues = append(issues, "duplicate profile name")
+		}
+		seen[name] = true
+
+		checks = append(checks, SpeciesProfileCheck{
+			Profile: profile, Issues: issues,
+		})
+	}
+	return checks
+}
+
+// Validate returns an error listing every profile issue, or nil.
+func (r *SpeciesRegistry) Validate() error {
+	var errs []error
+	for _, check := range r.Check() {
+		for _, issue := range check.Issues {
+			errs = append(errs, fmt.Errorf("species profile %q (%s): %s",
+				check.Profile.Name, check.Profile.Source, issue))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Paths returns the database and reference list paths of every profile.
+func (r *SpeciesRegistry) Paths() []string {
+	var paths []string
+	for _, profile := range r.Profiles {
+		for _, p := range []string{
+			profile.PoliDB, profile.OtherDB, profile.RRNADB,
+			profile.FastANIList,
+		} {
+			if p != "" && !slices.Contains(paths, p) {
+				paths = append(paths, p)
+			}
+		}
+	}
+	return paths
+}
+
+// DefaultSpeciesRegistry returns the built-in profiles, whose paths are read
+// from the POLI_DB_*, OTHER_DB_*, RRNA_DB_* and FASTANI_LIST_* environment
+// variables.
+func DefaultSpeciesRegistry() (*SpeciesRegistry, error) {
+	return loadSpeciesRegistry(defaultProfilesFS, "profiles")
+}
+
+// LoadSpeciesRegistry reads every .toml and .json profile in dir, in file
+// name order. Paths may reference environment variables as ${NAME}.
+func LoadSpeciesRegistry(dir string) (*SpeciesRegistry, error) {
+	registry, err := loadSpeciesRegistry(os.DirFS(dir), ".")
+	if err != nil {
+		return nil, err
+	}
+	for i := range registry.Profiles {
+		registry.Profiles[i].Source = filepath.Join(dir,
+			registry.Profiles[i].Source)
+	}
+	return registry, nil
+}
+
+func loadSpeciesRegistry(fsys fs.FS, dir string) (*SpeciesRegistry, error) {
+	entries, err := fs.ReadDir(fsys, dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read species profiles: %w", err)
+	}
+
+	registry := &SpeciesRegistry{}
+	for _, entry := range entries {
+		ext := path.Ext(entry.Name())
+		if entry.IsDir() || (ext != ".toml" && ext != ".json") {
+			continue
+		}
+
+		raw, err := fs.ReadFile(fsys, path.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read species profile %s: %w",
+				entry.Name(), err)
+		}
+		profile, err := parseSpeciesProfile(raw, ext)
+		if err != nil {
+			return nil, fmt.Errorf("invalid species profile %s: %w",
+				entry.Name(), err)
+		}
+		profile.Source = entry.Name()
+		registry.Profiles = append(registry.Profiles, profile)
+	}
+
+	if len(registry.Profiles) == 0 {
+		return nil, fmt.Errorf("no species profiles found in %s", dir)
+	}
+
+	return registry, nil
+}
+
+func parseSpeciesProfile(raw []byte, ext string) (SpeciesProfile, error) {
+	var profile SpeciesProfile
+	if ext == ".json" {
+		decoder := json.NewDecoder(bytes.NewReader(raw))
+		decoder.DisallowUnknownFields()
+		if err := decoder.Decode(&profile); err != nil {
+			return profile, err
+		}
+	} else {
+		decoder := toml.NewDecoder(bytes.NewReader(raw))
+		decoder.DisallowUnknownFields()
+		if err := decoder.Decode(&profile); err != nil {
+			return profile, err
+		}
+	}
+
+	expand := func(name string) string {
+		value, ok := os.LookupEnv(name)
+		if !ok || value == "" {
+			if !slices.Contains(profile.unsetVars, name) {
+				profile.unsetVars = append(profile.unsetVars, name)
+			}
+		}
+		return value
+	}
+	profile.PoliDB = os.Expand(profile.PoliDB, expand)
+	profile.OtherDB = os.Expand(profile.OtherDB, expand)
+	profile.RRNADB = os.Expand(profile.RRNADB, expand)
+	profile.FastANIList = os.Expand(profile.FastANIList, expand)
+
+	return profile, nil
+}
+
+func normalizeSpeciesName(name string) string {
+	return strings.ToLower(strings.Join(strings.Fields(name), ""))
+}
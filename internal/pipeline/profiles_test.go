@@ -0,0 +1,117 @@
+package pipeline
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDefaultSpeciesRegistry(t *testing.T) {
+	t.Setenv("POLI_DB_KLEB", "/dbs/poli/proteins_kleb_poli.fasta")
+
+	registry, err := DefaultSpeciesRegistry()
+
+	assert.NoError(t, err)
+	assert.Len(t, registry.Profiles, 7)
+
+	profile := registry.Match("klebsiellapneumoniae")
+	assert.NotNil(t, profile)
+	assert.Equal(t, "Klebsiella pneumoniae", profile.Name)
+	assert.Equal(t, "/dbs/poli/proteins_kleb_poli.fasta", profile.PoliDB)
+	assert.Equal(t, "Klebsiella_pneumoniae", profile.AMRFinderOrganism)
+	assert.Equal(t, "klebsiella.toml", profile.Source)
+
+	assert.Equal(t, "Acinetobacter baumannii complex",
+		registry.Match("acinetobacterpittii").Name)
+	assert.Equal(t, "Enterobacter cloacae complex",
+		registry.Match("enterobacterhormaechei").Name)
+	assert.Equal(t, "Pseudomonas aeruginosa",
+		registry.Match("pseudomonasaeruginosa").Name)
+	assert.Equal(t, "Escherichia coli",
+		registry.Match("escherichiacoli").Name)
+	assert.Equal(t, "Staphylococcus aureus",
+		registry.Match("staphylococcusaureus").Name)
+	assert.Equal(t, "Enterococcus", registry.Match("enterococcusfaecalis").Name)
+	assert.Nil(t, registry.Match("salmonellaenterica"))
+	assert.Equal(t, []string{"abaumannii"}, registry.MLSTExclude())
+}
+
+func TestLoadSpeciesRegistry(t *testing.T) {
+	t.Run("Success - TOML And JSON", func(t *testing.T) {
+		dir := t.TempDir()
+		db := filepath.Join(dir, "ecoli.fasta")
+		assert.NoError(t, os.WriteFile(db, []byte(">GyrA\nM\n"), 0644))
+		t.Setenv("ECOLI_DB", db)
+
+		assert.NoError(t, os.WriteFile(filepath.Join(dir, "a_ecoli.toml"),
+			[]byte(`name = "Escherichia coli"
+kraken_matchers = ["Escherichia coli"]
+poli_genes = ["MgrB"]
+other_genes = ["GyrA"]
+poli_db = "${ECOLI_DB}"
+other_db = "${ECOLI_DB}"
+`), 0644))
+		assert.NoError(t, os.WriteFile(filepath.Join(dir, "b_saureus.json"),
+			[]byte(`{"name":"Staphylococcus aureus",`+
+				`"kraken_matchers":["Staphylococcus aureus"],`+
+				`"mlst_exclude":["saureus_2"]}`), 0644))
+		assert.NoError(t, os.WriteFile(filepath.Join(dir, "README.md"),
+			[]byte("notes"), 0644))
+
+		registry, err := LoadSpeciesRegistry(dir)
+
+		assert.NoError(t, err)
+		assert.Len(t, registry.Profiles, 2)
+		assert.Equal(t, db, registry.Profiles[0].OtherDB)
+		assert.Equal(t, filepath.Join(dir, "a_ecoli.toml"),
+			registry.Profiles[0].Source)
+		assert.Equal(t, "Staphylococcus aureus",
+			registry.Match("staphylococcusaureus").Name)
+		assert.NoError(t, registry.Validate())
+	})
+
+	t.Run("Error - Unknown Field", func(t *testing.T) {
+		dir := t.TempDir()
+		assert.NoError(t, os.WriteFile(filepath.Join(dir, "ecoli.toml"),
+			[]byte("name = \"Escherichia coli\"\ngenes = [\"GyrA\"]\n"),
+			0644))
+
+		_, err := LoadSpeciesRegistry(dir)
+		assert.Error(t, err)
+	})
+
+	t.Run("Error - No Profiles", func(t *testing.T) {
+		_, err := LoadSpeciesRegistry(t.TempDir())
+		assert.Error(t, err)
+	})
+}
+
+func TestSpeciesRegistryCheck(t *testing.T) {
+	registry := &SpeciesRegistry{Profiles: []SpeciesProfile{
+		{
+			Name:           "Klebsiella pneumoniae",
+			KrakenMatchers: []string{"Klebsiella pneumoniae"},
+			PoliDB:         filepath.Join(t.TempDir(), "missing.fasta"),
+			RRNAGenes:      []string{"23S"},
+			unsetVars:      []string{"OTHER_DB_KLEB"},
+		},
+		{Name: "klebsiella pneumoniae", AMREngine: "rgi"},
+	}}
+
+	checks := registry.Check()
+
+	assert.Len(t, checks, 2)
+	assert.ElementsMatch(t, []string{
+		"environment variable OTHER_DB_KLEB is not set",
+		"poli_genes is required with poli_db",
+		"rrna_db is required with rrna_genes",
+		"poli_db: path " + registry.Profiles[0].PoliDB + " not accessible",
+	}, checks[0].Issues)
+	assert.ElementsMatch(t, []string{
+		"kraken_matchers is required", "duplicate profile name",
+		`amr_engine: unknown AMR engine "rgi"`,
+	}, checks[1].Issues)
+	assert.Error(t, registry.Validate())
+}
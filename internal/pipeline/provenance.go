@@ -0,0 +1,293 @@
+package pipeline
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// databaseVersionFiles are read, in order, from a database directory (or
+// the directory holding a database file) to report its version.
+var databaseVersionFiles = []string{"VERSION", "version.txt", "VERSION.txt"}
+
+// DatabaseFingerprint identifies the content of a reference database when
+// an analysis ran. Checksum covers the key files of the database, so a
+// changed checksum means the database was updated.
+type DatabaseFingerprint struct {
+	Name       string     `json:"name"`
+	Path       string     `json:"path,omitempty"`
+	Checksum   string     `json:"checksum,omitempty"`
+	ModifiedAt *time.Time `json:"modified_at,omitempty"`
+	Version    string     `json:"version,omitempty"`
+	Error      string     `json:"error,omitempty"`
+}
+
+// DatabaseSource is a configured database path. KeyFiles are the files
+// checksummed when Path is a directory; every regular file in it is listed
+// by name and size when none of them exists.
+type DatabaseSource struct {
+	Name     string
+	Path     string
+	KeyFiles []string
+}
+
+// DatabaseSources returns the database paths set in the configuration and
+// in the species profiles. Empty paths are left out.
+func (c *ToolsConfig) DatabaseSources() []DatabaseSource {
+	sources := []DatabaseSource{
+		{Name: "kraken2", Path: c.KrakenDBPath,
+			KeyFiles: []string{"opts.k2d", "taxo.k2d", "seqid2taxid.map"}},
+		{Name: "resfinder_catalog", Path: c.ResfinderDBPath},
+		{Name: "amrfinder", Path: c.AMRFinderDBPath,
+			KeyFiles: []string{"version.txt", "AMRProt", "AMR.LIB"}},
+	}
+
+	for _, profile := range c.SpeciesRegistry().Profiles {
+		for _, entry := range []struct{ field, path string }{
+			{"poli_db", profile.PoliDB},
+			{"other_db", profile.OtherDB},
+			{"rrna_db", profile.RRNADB},
+			{"fastani_list", profile.FastANIList},
+		} {
+			sources = append(sources, DatabaseSource{
+				Name: profile.Name + "/" + entry.field, Path: entry.path,
+			})
+		}
+	}
+
+	kept := sources[:0]
+	for _, source := range sources {
+		if source.Path != "" {
+			kept = append(kept, source)
+		}
+	}
+	return kept
+}
+
+type databaseCacheEntry struct {
+	signature   string
+	fingerprint DatabaseFingerprint
+}
+
+var (
+	databaseCacheMu sync.Mutex
+	databaseCache   = map[string]databaseCacheEntry{}
+)
+
+// FingerprintDatabase checksums the key files of source once per process.
+// They are read again when the modification time or size of one of them
+// changes. Failures are reported in the fingerprint.
+func FingerprintDatabase(source DatabaseSource) DatabaseFingerprint {
+	fingerprint := DatabaseFingerprint{Name: source.Name, Path: source.Path}
+
+	files, listing, err := databaseFiles(source)
+	if err != nil {
+		fingerprint.Error = err.Error()
+		return fingerprint
+	}
+
+	var signature strings.Builder
+	var modifiedAt time.Time
+	for _, file := range files {
+		info, err := os.Stat(file)
+		if err != nil {
+			fingerprint.Error = err.Error()
+			return fingerprint
+		}
+		fmt.Fprintf(&signature, "%s:%d:%d;", file, info.Size(),
+			info.ModTime().UnixNano())
+		if info.ModTime().After(modifiedAt) {
+			modifiedAt = info.ModTime()
+		}
+	}
+
+	databaseCacheMu.Lock()
+	defer databaseCacheMu.Unlock()
+
+	// Profiles may share a database, so entries are keyed by path.
+	if cached, ok := databaseCache[source.Path]; ok &&
+		cached.signature == signature.String() {
+		fingerprint = cached.fingerprint
+		fingerprint.Name = source.Name
+		return fingerprint
+	}
+
+	checksum, err := checksumFiles(files, listing)
+	if err != nil {
+		fingerprint.Error = err.Error()
+		return fingerprint
+	}
+
+	modifiedAt = modifiedAt.UTC()
+	fingerprint.Checksum = checksum
+	fingerprint.ModifiedAt = &modifiedAt
+	fingerprint.Version = databaseVersion(source.Path)
+
+	databaseCache[source.Path] = databaseCacheEntry{
+		signature: signature.String(), fingerprint: fingerprint,
+	}
+	return fingerprint
+}
+
+// databaseFiles returns the files of source that are checksummed, sorted,
+// and whether they are the directory listing rather than its key files.
+func databaseFiles(source DatabaseSource) ([]string, bool, error) {
+	info, err := os.Stat(source.Path)
+	if err != nil {
+		return nil, false, err
+	}
+	if !info.IsDir() {
+		return []string{source.Path}, false, nil
+	}
+
+	var files []string
+	for _, name := range source.KeyFiles {
+		file := filepath.Join(source.Path, name)
+		if info, err := os.Stat(file); err == nil && !info.IsDir() {
+			files = append(files, file)
+		}
+	}
+	if len(files) > 0 {
+		sort.Strings(files)
+		return files, false, nil
+	}
+
+	// Without key files only the listing is fingerprinted: the files of
+	// some databases are too large to be read for every analysis.
+	entries, err := os.ReadDir(source.Path)
+	if err != nil {
+		return nil, false, err
+	}
+	for _, entry := range entries {
+		if entry.Type().IsRegular() {
+			files = append(files, filepath.Join(source.Path, entry.Name()))
+		}
+	}
+	if len(files) == 0 {
+		return nil, false, fmt.Errorf("no database files in %s", source.Path)
+	}
+	return files, true, nil
+}
+
+// checksumFiles hashes the names and sizes of files and, unless only the
+// directory listing is fingerprinted, their content.
+func checksumFiles(files []string, listing bool) (string, error) {
+	hash := sha256.New()
+	for _, file := range files {
+		info, err := os.Stat(file)
+		if err != nil {
+			return "", err
+		}
+		fmt.Fprintf(hash, "%s\x00%d\x00", filepath.Base(file), info.Size())
+		if listing {
+			continue
+		}
+
+		f, err := os.Open(file)
+		if err != nil {
+			return "", err
+		}
+		_, err = io.Copy(hash, f)
+		f.Close()
+		if err != nil {
+			return "", err
+		}
+	}
+
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// databaseVersion returns the first line of the version file next to the
+// database, or "".
+func databaseVersion(path string) string {
+	dir := path
+	if info, err := os.Stat(path); err == nil && !info.IsDir() {
+		dir = filepath.Dir(path)
+	}
+
+	for _, name := range databaseVersionFiles {
+		raw, err := os.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			continue
+		}
+		line, _, _ := strings.Cut(string(raw), "\n")
+		if line = strings.TrimSpace(line); line != "" {
+			return line
+		}
+	}
+	return ""
+}
+
+// ParseAbricateList reads the output of abricate --list into one
+// fingerprint per database, versioned by its date and sequence count.
+func ParseAbricateList(output string) []DatabaseFingerprint {
+	var fingerprints []DatabaseFingerprint
+	scanner := bufio.NewScanner(strings.NewReader(output))
+	for scanner.Scan() {
+		fields := strings.Split(scanner.Text(), "\t")
+		if len(fields) < 4 || fields[0] == "DATABASE" {
+			continue
+		}
+
+		name := strings.TrimSpace(fields[0])
+		sequences := strings.TrimSpace(fields[1])
+		date := strings.TrimSpace(fields[3])
+		checksum := sha256.Sum256([]byte(strings.Join(fields, "\t")))
+
+		fingerprints = append(fingerprints, DatabaseFingerprint{
+			Name:     "abricate/" + name,
+			Checksum: hex.EncodeToString(checksum[:]),
+			Version:  fmt.Sprintf("%s (%s sequences)", date, sequences),
+		})
+	}
+	return fingerprints
+}
+
+// CollectDatabaseProvenance fingerprints every configured database and the
+// databases listed by abricate. The fingerprints are sorted by name.
+func CollectDatabaseProvenance(ctx context.Context, commander Commander,
+	config *ToolsConfig) []DatabaseFingerprint {
+	var fingerprints []DatabaseFingerprint
+	for _, source := range config.DatabaseSources() {
+		fingerprints = append(fingerprints, FingerprintDatabase(source))
+	}
+
+	if config.AbricatePath != "" {
+		cmd := commander.Command(ctx, config.AbricatePath, "--list")
+		var stdout, stderr bytes.Buffer
+		cmd.SetStdout(&stdout)
+		cmd.SetStderr(&stderr)
+
+		if err := cmd.Run(); err != nil {
+			fingerprints = append(fingerprints, DatabaseFingerprint{
+				Name: "abricate", Error: err.Error(),
+			})
+		} else {
+			fingerprints = append(fingerprints,
+				ParseAbricateList(stdout.String())...)
+		}
+	}
+
+	sort.Slice(fingerprints, func(i, j int) bool {
+		return fingerprints[i].Name < fingerprints[j].Name
+	})
+	return fingerprints
+}
+
+// OutdatedDatabase is a database an analysis ran against that has changed
+// since.
+type OutdatedDatabase struct {
+	Name    string              `json:"name"`
+	Used    DatabaseFingerprint `json:"used"`
+	Current DatabaseFingerprint `json:"current"`
+}
@@ -0,0 +1,161 @@
+package pipeline_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/CABGenOrg/cabgen_backend/internal/pipeline"
+	"github.com/CABGenOrg/cabgen_backend/internal/testutils/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func writeDatabaseFile(t *testing.T, path, content string) {
+	t.Helper()
+	assert.NoError(t, os.WriteFile(path, []byte(content), 0644))
+}
+
+func TestFingerprintDatabase(t *testing.T) {
+	t.Run("Success - File", func(t *testing.T) {
+		dir := t.TempDir()
+		path := filepath.Join(dir, "phenotypes.txt")
+		writeDatabaseFile(t, path, "blaOXA-23\tBeta-lactam\n")
+		writeDatabaseFile(t, filepath.Join(dir, "VERSION"), "2.3.2\n")
+
+		source := pipeline.DatabaseSource{Name: "resfinder", Path: path}
+		first := pipeline.FingerprintDatabase(source)
+
+		assert.Empty(t, first.Error)
+		assert.Len(t, first.Checksum, 64)
+		assert.Equal(t, "2.3.2", first.Version)
+		assert.NotNil(t, first.ModifiedAt)
+
+		writeDatabaseFile(t, path, "blaOXA-48\tBeta-lactam\n")
+		later := time.Now().Add(time.Hour)
+		assert.NoError(t, os.Chtimes(path, later, later))
+
+		updated := pipeline.FingerprintDatabase(source)
+		assert.NotEqual(t, first.Checksum, updated.Checksum)
+	})
+
+	t.Run("Success - Key Files", func(t *testing.T) {
+		dir := t.TempDir()
+		writeDatabaseFile(t, filepath.Join(dir, "opts.k2d"), "opts")
+		writeDatabaseFile(t, filepath.Join(dir, "taxo.k2d"), "taxo")
+		writeDatabaseFile(t, filepath.Join(dir, "hash.k2d"), "hash")
+
+		source := pipeline.DatabaseSource{
+			Name: "kraken2", Path: dir,
+			KeyFiles: []string{"opts.k2d", "taxo.k2d"},
+		}
+		first := pipeline.FingerprintDatabase(source)
+		assert.Empty(t, first.Error)
+
+		// Files other than the key files are not fingerprinted.
+		writeDatabaseFile(t, filepath.Join(dir, "hash.k2d"), "rebuilt")
+		assert.Equal(t, first.Checksum,
+			pipeline.FingerprintDatabase(source).Checksum)
+
+		writeDatabaseFile(t, filepath.Join(dir, "taxo.k2d"), "taxonomy")
+		assert.NotEqual(t, first.Checksum,
+			pipeline.FingerprintDatabase(source).Checksum)
+	})
+
+	t.Run("Success - Listing Without Key Files", func(t *testing.T) {
+		dir := t.TempDir()
+		writeDatabaseFile(t, filepath.Join(dir, "AMRProt"), "prot")
+
+		source := pipeline.DatabaseSource{
+			Name: "amrfinder", Path: dir, KeyFiles: []string{"version.txt"},
+		}
+		first := pipeline.FingerprintDatabase(source)
+		assert.Empty(t, first.Error)
+
+		writeDatabaseFile(t, filepath.Join(dir, "AMR.LIB"), "lib")
+		assert.NotEqual(t, first.Checksum,
+			pipeline.FingerprintDatabase(source).Checksum)
+	})
+
+	t.Run("Error - Missing Path", func(t *testing.T) {
+		fingerprint := pipeline.FingerprintDatabase(pipeline.DatabaseSource{
+			Name: "kraken2", Path: filepath.Join(t.TempDir(), "missing"),
+		})
+
+		assert.Equal(t, "kraken2", fingerprint.Name)
+		assert.Empty(t, fingerprint.Checksum)
+		assert.NotEmpty(t, fingerprint.Error)
+	})
+}
+
+func TestParseAbricateList(t *testing.T) {
+	output := strings.Join([]string{
+		"DATABASE\tSEQUENCES\tDBTYPE\tDATE",
+		"resfinder\t3077\tnucl\t2023-Jan-12",
+		"vfdb\t2597\tnucl\t2023-Jan-12",
+		"",
+	}, "\n")
+
+	fingerprints := pipeline.ParseAbricateList(output)
+
+	assert.Len(t, fingerprints, 2)
+	assert.Equal(t, "abricate/resfinder", fingerprints[0].Name)
+	assert.Equal(t, "2023-Jan-12 (3077 sequences)", fingerprints[0].Version)
+	assert.NotEqual(t, fingerprints[0].Checksum, fingerprints[1].Checksum)
+}
+
+func TestCollectDatabaseProvenance(t *testing.T) {
+	krakenDir := t.TempDir()
+	writeDatabaseFile(t, filepath.Join(krakenDir, "opts.k2d"), "opts")
+	fastANIList := filepath.Join(t.TempDir(), "kpn.txt")
+	writeDatabaseFile(t, fastANIList, "/refs/kpn1.fna\n")
+
+	config := &pipeline.ToolsConfig{
+		KrakenDBPath: krakenDir,
+		AbricatePath: "abricate",
+		Species: &pipeline.SpeciesRegistry{Profiles: []pipeline.SpeciesProfile{
+			{Name: "kpneumoniae", FastANIList: fastANIList},
+		}},
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		cmd := &mocks.MockCommander{
+			CommandFunc: func(_ context.Context, name string,
+				args ...string) pipeline.Cmd {
+				assert.Equal(t, "abricate", name)
+				assert.Equal(t, []string{"--list"}, args)
+				return &mocks.MockCmd{StdoutContent: "DATABASE\tSEQUENCES\t" +
+					"DBTYPE\tDATE\nvfdb\t2597\tnucl\t2023-Jan-12\n"}
+			},
+		}
+
+		fingerprints := pipeline.CollectDatabaseProvenance(
+			context.Background(), cmd, config)
+
+		names := make([]string, len(fingerprints))
+		for i, fingerprint := range fingerprints {
+			names[i] = fingerprint.Name
+			assert.Empty(t, fingerprint.Error)
+		}
+		assert.Equal(t, []string{
+			"abricate/vfdb", "kpneumoniae/fastani_list", "kraken2",
+		}, names)
+	})
+
+	t.Run("Error - Abricate List", func(t *testing.T) {
+		cmd := &mocks.MockCommander{
+			CommandFunc: func(_ context.Context, _ string,
+				_ ...string) pipeline.Cmd {
+				return &mocks.MockCmd{RunErr: os.ErrNotExist}
+			},
+		}
+
+		fingerprints := pipeline.CollectDatabaseProvenance(
+			context.Background(), cmd, config)
+
+		assert.Equal(t, "abricate", fingerprints[0].Name)
+		assert.NotEmpty(t, fingerprints[0].Error)
+	})
+}
@@ -0,0 +1,189 @@
+package pipeline
+
+import (
+	"fmt"
+	"strings"
+)
+
+// QCMetric is an assembly measure a QC rule checks.
+type QCMetric string
+
+const (
+	QCMetricCompleteness  QCMetric = "completeness"
+	QCMetricContamination QCMetric = "contamination"
+	QCMetricCoverage      QCMetric = "coverage"
+	QCMetricN50           QCMetric = "n50"
+	QCMetricContigs       QCMetric = "contigs"
+	QCMetricGenomeSize    QCMetric = "genome_size"
+	// Kraken2 percent of the second most abundant species
+	QCMetricSecondarySpecies QCMetric = "secondary_species"
+)
+
+var QCMetrics = []QCMetric{QCMetricCompleteness, QCMetricContamination,
+	QCMetricCoverage, QCMetricN50, QCMetricContigs, QCMetricGenomeSize,
+	QCMetricSecondarySpecies}
+
+func (m QCMetric) IsValid() bool {
+	switch m {
+	case QCMetricCompleteness, QCMetricContamination, QCMetricCoverage,
+		QCMetricN50, QCMetricContigs, QCMetricGenomeSize,
+		QCMetricSecondarySpecies:
+		return true
+	default:
+		return false
+	}
+}
+
+// QCSeverity is what breaking a rule does to the verdict.
+type QCSeverity string
+
+const (
+	QCSeverityWarn QCSeverity = "WARN"
+	QCSeverityFail QCSeverity = "FAIL"
+)
+
+func (s QCSeverity) IsValid() bool {
+	return s == QCSeverityWarn || s == QCSeverityFail
+}
+
+type QCVerdict string
+
+const (
+	QCVerdictPass QCVerdict = "PASS"
+	QCVerdictWarn QCVerdict = "WARN"
+	QCVerdictFail QCVerdict = "FAIL"
+)
+
+func (v QCVerdict) IsValid() bool {
+	switch v {
+	case QCVerdictPass, QCVerdictWarn, QCVerdictFail:
+		return true
+	default:
+		return false
+	}
+}
+
+// QCRule keeps a metric within [Min, Max]. Rules naming a species only apply
+// to assemblies of that species and replace the generic rules of the same
+// metric.
+type QCRule struct {
+	Metric   QCMetric   `json:"metric"`
+	Species  string     `json:"species,omitempty"`
+	Min      *float64   `json:"min,omitempty"`
+	Max      *float64   `json:"max,omitempty"`
+	Severity QCSeverity `json:"severity"`
+}
+
+func (r QCRule) Validate() error {
+	if !r.Metric.IsValid() {
+		return fmt.Errorf("%w: unknown metric %q", ErrInvalidQCRule, r.Metric)
+	}
+	if !r.Severity.IsValid() {
+		return fmt.Errorf("%w: severity must be WARN or FAIL",
+			ErrInvalidQCRule)
+	}
+	if r.Min == nil && r.Max == nil {
+		return fmt.Errorf("%w: min or max is required", ErrInvalidQCRule)
+	}
+	if r.Min != nil && r.Max != nil && *r.Min > *r.Max {
+		return fmt.Errorf("%w: min is greater than max", ErrInvalidQCRule)
+	}
+
+	return nil
+}
+
+// appliesTo matches the rule species against the species name, which may
+// carry a subspecies or strain after it.
+func (r QCRule) appliesTo(species string) bool {
+	if r.Species == "" {
+		return true
+	}
+
+	ruleSpecies := strings.ToLower(strings.TrimSpace(r.Species))
+	species = strings.ToLower(strings.TrimSpace(species))
+	return species == ruleSpecies ||
+		strings.HasPrefix(species, ruleSpecies+" ")
+}
+
+func (r QCRule) breaks(value float64) bool {
+	return (r.Min != nil && value < *r.Min) || (r.Max != nil && value > *r.Max)
+}
+
+// QCValues are the measures of an assembly. Nil values were not measured and
+// their rules are skipped.
+type QCValues struct {
+	Species          string
+	Completeness     *float64
+	Contamination    *float64
+	Coverage         *float64
+	N50              *float64
+	Contigs          *float64
+	GenomeSize       *float64
+	SecondarySpecies *float64
+}
+
+func (v QCValues) value(metric QCMetric) *float64 {
+	switch metric {
+	case QCMetricCompleteness:
+		return v.Completeness
+	case QCMetricContamination:
+		return v.Contamination
+	case QCMetricCoverage:
+		return v.Coverage
+	case QCMetricN50:
+		return v.N50
+	case QCMetricContigs:
+		return v.Contigs
+	case QCMetricGenomeSize:
+		return v.GenomeSize
+	case QCMetricSecondarySpecies:
+		return v.SecondarySpecies
+	default:
+		return nil
+	}
+}
+
+// QCFailure is a broken rule and the value that broke it.
+type QCFailure struct {
+	QCRule
+	Value float64 `json:"value"`
+}
+
+type QCReport struct {
+	Verdict     QCVerdict   `json:"verdict"`
+	FailedRules []QCFailure `json:"failed_rules,omitempty"`
+}
+
+// EvaluateQC checks values against rules. The verdict is FAIL when a FAIL
+// rule breaks, WARN when only WARN rules break and PASS otherwise.
+func EvaluateQC(values QCValues, rules []QCRule) QCReport {
+	speciesRules := map[QCMetric]bool{}
+	for _, rule := range rules {
+		if rule.Species != "" && rule.appliesTo(values.Species) {
+			speciesRules[rule.Metric] = true
+		}
+	}
+
+	report := QCReport{Verdict: QCVerdictPass}
+	for _, rule := range rules {
+		if !rule.appliesTo(values.Species) ||
+			(rule.Species == "" && speciesRules[rule.Metric]) {
+			continue
+		}
+
+		value := values.value(rule.Metric)
+		if value == nil || !rule.breaks(*value) {
+			continue
+		}
+
+		report.FailedRules = append(report.FailedRules,
+			QCFailure{QCRule: rule, Value: *value})
+		if rule.Severity == QCSeverityFail {
+			report.Verdict = QCVerdictFail
+		} else if report.Verdict == QCVerdictPass {
+			report.Verdict = QCVerdictWarn
+		}
+	}
+
+	return report
+}
@@ -0,0 +1,117 @@
+package pipeline
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func bound(v float64) *float64 {
+	return &v
+}
+
+func TestQCRuleValidate(t *testing.T) {
+	tests := []struct {
+		name  string
+		rule  QCRule
+		valid bool
+	}{
+		{"Valid", QCRule{Metric: QCMetricCoverage, Min: bound(30),
+			Severity: QCSeverityWarn}, true},
+		{"Valid range", QCRule{Metric: QCMetricGenomeSize, Min: bound(5e6),
+			Max: bound(6e6), Severity: QCSeverityFail}, true},
+		{"Unknown metric", QCRule{Metric: "gc", Min: bound(1),
+			Severity: QCSeverityFail}, false},
+		{"Unknown severity", QCRule{Metric: QCMetricN50, Min: bound(1),
+			Severity: "INFO"}, false},
+		{"No bounds", QCRule{Metric: QCMetricN50,
+			Severity: QCSeverityFail}, false},
+		{"Inverted range", QCRule{Metric: QCMetricContigs, Min: bound(500),
+			Max: bound(10), Severity: QCSeverityFail}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.rule.Validate()
+			if tt.valid {
+				assert.NoError(t, err)
+			} else {
+				assert.True(t, errors.Is(err, ErrInvalidQCRule))
+			}
+		})
+	}
+}
+
+func TestEvaluateQC(t *testing.T) {
+	values := QCValues{
+		Species:          "Klebsiella pneumoniae subsp. pneumoniae",
+		Completeness:     bound(98),
+		Contamination:    bound(6),
+		Coverage:         bound(8),
+		Contigs:          bound(120),
+		GenomeSize:       bound(5.5e6),
+		SecondarySpecies: bound(0),
+	}
+
+	t.Run("Pass", func(t *testing.T) {
+		report := EvaluateQC(values, []QCRule{
+			{Metric: QCMetricCompleteness, Min: bound(90),
+				Severity: QCSeverityFail},
+			{Metric: QCMetricSecondarySpecies, Max: bound(0),
+				Severity: QCSeverityWarn},
+		})
+
+		assert.Equal(t, QCReport{Verdict: QCVerdictPass}, report)
+	})
+
+	t.Run("Warn", func(t *testing.T) {
+		report := EvaluateQC(values, []QCRule{
+			{Metric: QCMetricContamination, Max: bound(5),
+				Severity: QCSeverityWarn},
+		})
+
+		assert.Equal(t, QCVerdictWarn, report.Verdict)
+		if assert.Len(t, report.FailedRules, 1) {
+			assert.Equal(t, QCMetricContamination,
+				report.FailedRules[0].Metric)
+			assert.Equal(t, 6.0, report.FailedRules[0].Value)
+		}
+	})
+
+	t.Run("Fail outranks warn", func(t *testing.T) {
+		report := EvaluateQC(values, []QCRule{
+			{Metric: QCMetricContamination, Max: bound(5),
+				Severity: QCSeverityWarn},
+			{Metric: QCMetricCoverage, Min: bound(20),
+				Severity: QCSeverityFail},
+			{Metric: QCMetricContigs, Max: bound(500),
+				Severity: QCSeverityFail},
+		})
+
+		assert.Equal(t, QCVerdictFail, report.Verdict)
+		assert.Len(t, report.FailedRules, 2)
+	})
+
+	t.Run("Species rule replaces generic rule", func(t *testing.T) {
+		report := EvaluateQC(values, []QCRule{
+			{Metric: QCMetricGenomeSize, Min: bound(2e6), Max: bound(4e6),
+				Severity: QCSeverityFail},
+			{Metric: QCMetricGenomeSize, Species: "klebsiella pneumoniae",
+				Min: bound(5e6), Max: bound(6.5e6), Severity: QCSeverityFail},
+			{Metric: QCMetricGenomeSize, Species: "Escherichia coli",
+				Min: bound(4.5e6), Max: bound(5e6), Severity: QCSeverityFail},
+		})
+
+		assert.Equal(t, QCReport{Verdict: QCVerdictPass}, report)
+	})
+
+	t.Run("Unmeasured metric is skipped", func(t *testing.T) {
+		report := EvaluateQC(values, []QCRule{
+			{Metric: QCMetricN50, Min: bound(20000),
+				Severity: QCSeverityFail},
+		})
+
+		assert.Equal(t, QCVerdictPass, report.Verdict)
+	})
+}
@@ -0,0 +1,265 @@
+package pipeline
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+)
+
+// phredOffset is the ASCII offset of the Sanger / Illumina 1.8+ qualities.
+const phredOffset = 33
+
+// ReadQC summarizes the reads of a FASTQ file. GCPercent is computed over
+// the A, C, G and T bases; NRate, MeanPhred and the Q20/Q30 fractions over
+// every base. LengthHistogram counts the reads of each length or, for long
+// reads, of each HistogramBinWidth wide bin keyed by its lower bound.
+type ReadQC struct {
+	Reads             int64           `json:"reads"`
+	Bases             int64           `json:"bases"`
+	MeanLength        float64         `json:"mean_length"`
+	MedianLength      float64         `json:"median_length"`
+	LengthHistogram   map[int64]int64 `json:"length_histogram"`
+	MeanPhred         float64         `json:"mean_phred"`
+	Q20Fraction       float64         `json:"q20_fraction"`
+	Q30Fraction       float64         `json:"q30_fraction"`
+	GCPercent         float64         `json:"gc_percent"`
+	NRate             float64         `json:"n_rate"`
+	ReadN50           int64           `json:"read_n50,omitempty"`
+	HistogramBinWidth int64           `json:"histogram_bin_width,omitempty"`
+}
+
+// ReadQCReport holds the summary of each read file of a sample.
+type ReadQCReport struct {
+	Read1     *ReadQC `json:"read1,omitempty"`
+	Read2     *ReadQC `json:"read2,omitempty"`
+	LongReads *ReadQC `json:"long_reads,omitempty"`
+}
+
+// Headline combines the short-read pair, or the long reads when there is no
+// pair, into the reads, bases, mean length, mean Phred and Q30 fraction of
+// the sample. It is nil when there is no read QC.
+func (r *ReadQCReport) Headline() *ReadQC {
+	if r == nil {
+		return nil
+	}
+	files := []*ReadQC{r.Read1, r.Read2}
+	if r.Read1 == nil && r.Read2 == nil {
+		files = []*ReadQC{r.LongReads}
+	}
+
+	var headline ReadQC
+	var qualitySum, q30 float64
+	for _, qc := range files {
+		if qc == nil {
+			continue
+		}
+		headline.Reads += qc.Reads
+		headline.Bases += qc.Bases
+		qualitySum += qc.MeanPhred * float64(qc.Bases)
+		q30 += qc.Q30Fraction * float64(qc.Bases)
+	}
+	if headline.Reads == 0 {
+		return nil
+	}
+
+	headline.MeanLength = float64(headline.Bases) / float64(headline.Reads)
+	if headline.Bases > 0 {
+		headline.MeanPhred = qualitySum / float64(headline.Bases)
+		headline.Q30Fraction = q30 / float64(headline.Bases)
+	}
+	return &headline
+}
+
+// LongReadHistogramBinWidth is the bin width of the long-read length
+// histogram, whose reads rarely share an exact length.
+const LongReadHistogramBinWidth = 1000
+
+// ComputeReadQC reads a FASTQ stream record by record, keeping only the
+// counters and the length histogram in memory.
+func ComputeReadQC(r io.Reader) (*ReadQC, error) {
+	scanner := bufio.NewScanner(r)
+
+	const maxCapacity = 1024 * 1024 * 4
+	buf := make([]byte, maxCapacity)
+	scanner.Buffer(buf, maxCapacity)
+
+	qc := &ReadQC{LengthHistogram: map[int64]int64{}}
+	var qualitySum, q20, q30, gc, acgt, nCount int64
+	var sequenceLength int
+	lineNumber := 0
+
+	for scanner.Scan() {
+		lineNumber++
+		line := scanner.Bytes()
+		if n := len(line); n > 0 && line[n-1] == '\r' {
+			line = line[:n-1]
+		}
+
+		switch lineNumber % 4 {
+		case 1:
+			if len(line) == 0 || line[0] != '@' {
+				return nil, fmt.Errorf("%w: expected a read header at line %d",
+					ErrInvalidFormat, lineNumber)
+			}
+		case 2:
+			sequenceLength = len(line)
+			for _, base := range line {
+				switch base {
+				case 'G', 'C', 'g', 'c':
+					gc++
+					acgt++
+				case 'A', 'T', 'a', 't':
+					acgt++
+				case 'N', 'n':
+					nCount++
+				}
+			}
+		case 3:
+			if len(line) == 0 || line[0] != '+' {
+				return nil, fmt.Errorf("%w: expected a '+' separator at line %d",
+					ErrInvalidFormat, lineNumber)
+			}
+		case 0:
+			if len(line) != sequenceLength {
+				return nil, fmt.Errorf("%w: quality and sequence lengths "+
+					"differ at line %d", ErrInvalidFormat, lineNumber)
+			}
+			for _, symbol := range line {
+				quality := int64(symbol) - phredOffset
+				if quality < 0 {
+					return nil, fmt.Errorf("%w: invalid quality at line %d",
+						ErrInvalidFormat, lineNumber)
+				}
+				qualitySum += quality
+				if quality >= 20 {
+					q20++
+				}
+				if quality >= 30 {
+					q30++
+				}
+			}
+			qc.Reads++
+			qc.Bases += int64(sequenceLength)
+			qc.LengthHistogram[int64(sequenceLength)]++
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrCorruptedInput, err)
+	}
+	if lineNumber%4 != 0 {
+		return nil, fmt.Errorf("%w: truncated record at line %d",
+			ErrCorruptedInput, lineNumber)
+	}
+	if qc.Reads == 0 {
+		return nil, ErrEmptyReads
+	}
+
+	qc.MeanLength = float64(qc.Bases) / float64(qc.Reads)
+	qc.MedianLength = medianLength(qc.LengthHistogram, qc.Reads)
+	if qc.Bases > 0 {
+		bases := float64(qc.Bases)
+		qc.MeanPhred = float64(qualitySum) / bases
+		qc.Q20Fraction = float64(q20) / bases
+		qc.Q30Fraction = float64(q30) / bases
+		qc.NRate = float64(nCount) / bases
+	}
+	if acgt > 0 {
+		qc.GCPercent = float64(gc) / float64(acgt) * 100
+	}
+
+	return qc, nil
+}
+
+// medianLength walks the histogram in length order up to the middle read(s).
+func medianLength(histogram map[int64]int64, reads int64) float64 {
+	lengths := make([]int64, 0, len(histogram))
+	for length := range histogram {
+		lengths = append(lengths, length)
+	}
+	slices.Sort(lengths)
+
+	// Zero-based positions of the middle reads; equal for odd counts.
+	lower, upper := (reads-1)/2, reads/2
+	var seen int64
+	var lowerLength int64 = -1
+	for _, length := range lengths {
+		seen += histogram[length]
+		if lowerLength < 0 && seen > lower {
+			lowerLength = length
+		}
+		if seen > upper {
+			return float64(lowerLength+length) / 2
+		}
+	}
+	return 0
+}
+
+// GetReadQC summarizes a FASTQ file, gzipped or not.
+func GetReadQC(filePath string) (*ReadQC, error) {
+	reader, err := openSequenceFile(filePath)
+	if errors.Is(err, errEmptyFile) {
+		return nil, ErrEmptyReads
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+
+	return ComputeReadQC(reader)
+}
+
+// ComputeLongReadQC summarizes a long-read FASTQ stream, adding the read
+// length N50 and binning the length histogram.
+func ComputeLongReadQC(r io.Reader) (*ReadQC, error) {
+	qc, err := ComputeReadQC(r)
+	if err != nil {
+		return nil, err
+	}
+
+	qc.ReadN50 = readN50(qc.LengthHistogram, qc.Bases)
+	binned := make(map[int64]int64, len(qc.LengthHistogram))
+	for length, reads := range qc.LengthHistogram {
+		binned[length/LongReadHistogramBinWidth*
+			LongReadHistogramBinWidth] += reads
+	}
+	qc.LengthHistogram = binned
+	qc.HistogramBinWidth = LongReadHistogramBinWidth
+
+	return qc, nil
+}
+
+// readN50 is the length of the shortest read among the longest reads that
+// together hold half of the bases.
+func readN50(histogram map[int64]int64, bases int64) int64 {
+	lengths := make([]int64, 0, len(histogram))
+	for length := range histogram {
+		lengths = append(lengths, length)
+	}
+	slices.Sort(lengths)
+
+	var covered int64
+	for i := len(lengths) - 1; i >= 0; i-- {
+		covered += lengths[i] * histogram[lengths[i]]
+		if covered*2 >= bases {
+			return lengths[i]
+		}
+	}
+	return 0
+}
+
+// GetLongReadQC summarizes a long-read FASTQ file, gzipped or not.
+func GetLongReadQC(filePath string) (*ReadQC, error) {
+	reader, err := openSequenceFile(filePath)
+	if errors.Is(err, errEmptyFile) {
+		return nil, ErrEmptyReads
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+
+	return ComputeLongReadQC(reader)
+}
@@ -0,0 +1,188 @@
+package pipeline
+
+import (
+	"compress/gzip"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestComputeReadQC(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		// Qualities: '5' is Q20, '?' is Q30 and '+' is Q10.
+		fastq := "@read1\nACGTAC\n+\n??????\n" +
+			"@read2\nGGNN\n+read2\n55++\n" +
+			"@read3\nATATAT\n+\n?????5\n"
+
+		qc, err := ComputeReadQC(strings.NewReader(fastq))
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(3), qc.Reads)
+		assert.Equal(t, int64(16), qc.Bases)
+		assert.InDelta(t, 16.0/3, qc.MeanLength, 1e-9)
+		assert.Equal(t, 6.0, qc.MedianLength)
+		assert.Equal(t, map[int64]int64{4: 1, 6: 2}, qc.LengthHistogram)
+		assert.InDelta(t, (11*30.0+3*20+2*10)/16, qc.MeanPhred, 1e-9)
+		assert.InDelta(t, 14.0/16, qc.Q20Fraction, 1e-9)
+		assert.InDelta(t, 11.0/16, qc.Q30Fraction, 1e-9)
+		// 3 + 2 + 0 GC bases out of 14 A/C/G/T.
+		assert.InDelta(t, 5.0/14*100, qc.GCPercent, 1e-9)
+		assert.InDelta(t, 2.0/16, qc.NRate, 1e-9)
+	})
+
+	t.Run("Success - Even Read Count Median", func(t *testing.T) {
+		fastq := "@a\nACGT\n+\nIIII\n@b\nACGTAC\n+\nIIIIII\n"
+
+		qc, err := ComputeReadQC(strings.NewReader(fastq))
+
+		assert.NoError(t, err)
+		assert.Equal(t, 5.0, qc.MedianLength)
+	})
+
+	t.Run("Error - Not A FASTQ", func(t *testing.T) {
+		_, err := ComputeReadQC(strings.NewReader(">a\nACGT\n"))
+		assert.ErrorIs(t, err, ErrInvalidFormat)
+	})
+
+	t.Run("Error - Missing Separator", func(t *testing.T) {
+		_, err := ComputeReadQC(strings.NewReader("@a\nACGT\nIIII\nIIII\n"))
+		assert.ErrorIs(t, err, ErrInvalidFormat)
+	})
+
+	t.Run("Error - Quality Length Mismatch", func(t *testing.T) {
+		_, err := ComputeReadQC(strings.NewReader("@a\nACGT\n+\nIII\n"))
+		assert.ErrorIs(t, err, ErrInvalidFormat)
+	})
+
+	t.Run("Error - Truncated Record", func(t *testing.T) {
+		_, err := ComputeReadQC(strings.NewReader("@a\nACGT\n+\n"))
+		assert.ErrorIs(t, err, ErrCorruptedInput)
+	})
+}
+
+func TestGetReadQC(t *testing.T) {
+	t.Run("Success - Gzipped", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "reads_R1.fastq.gz")
+		file, err := os.Create(path)
+		assert.NoError(t, err)
+		writer := gzip.NewWriter(file)
+		_, err = writer.Write([]byte("@a\nACGT\n+\nIIII\n"))
+		assert.NoError(t, err)
+		assert.NoError(t, writer.Close())
+		assert.NoError(t, file.Close())
+
+		qc, err := GetReadQC(path)
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), qc.Reads)
+		assert.Equal(t, 40.0, qc.MeanPhred)
+		assert.Equal(t, 1.0, qc.Q30Fraction)
+	})
+
+	t.Run("Error - Empty File", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "reads_R1.fastq")
+		assert.NoError(t, os.WriteFile(path, nil, 0644))
+
+		_, err := GetReadQC(path)
+		assert.ErrorIs(t, err, ErrEmptyReads)
+	})
+
+	t.Run("Error - File Not Found", func(t *testing.T) {
+		_, err := GetReadQC(filepath.Join(t.TempDir(), "missing.fastq"))
+		assert.ErrorIs(t, err, ErrFileNotFound)
+	})
+}
+
+func TestReadQCReportHeadline(t *testing.T) {
+	t.Run("Success - Short-Read Pair", func(t *testing.T) {
+		report := &ReadQCReport{
+			Read1: &ReadQC{Reads: 2, Bases: 300, MeanPhred: 30,
+				Q30Fraction: 0.9},
+			Read2: &ReadQC{Reads: 2, Bases: 100, MeanPhred: 20,
+				Q30Fraction: 0.5},
+			LongReads: &ReadQC{Reads: 1, Bases: 9000, MeanPhred: 12},
+		}
+
+		headline := report.Headline()
+
+		if assert.NotNil(t, headline) {
+			assert.Equal(t, int64(4), headline.Reads)
+			assert.Equal(t, int64(400), headline.Bases)
+			assert.Equal(t, 100.0, headline.MeanLength)
+			assert.Equal(t, 27.5, headline.MeanPhred)
+			assert.InDelta(t, 0.8, headline.Q30Fraction, 1e-9)
+		}
+	})
+
+	t.Run("Success - Long Reads", func(t *testing.T) {
+		report := &ReadQCReport{
+			LongReads: &ReadQC{Reads: 2, Bases: 9000, MeanPhred: 12,
+				Q30Fraction: 0.1},
+		}
+
+		headline := report.Headline()
+
+		if assert.NotNil(t, headline) {
+			assert.Equal(t, int64(2), headline.Reads)
+			assert.Equal(t, 4500.0, headline.MeanLength)
+			assert.Equal(t, 12.0, headline.MeanPhred)
+			assert.InDelta(t, 0.1, headline.Q30Fraction, 1e-9)
+		}
+	})
+
+	t.Run("Success - No Read QC", func(t *testing.T) {
+		assert.Nil(t, (*ReadQCReport)(nil).Headline())
+		assert.Nil(t, (&ReadQCReport{}).Headline())
+	})
+}
+
+func TestComputeLongReadQC(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		read := func(name string, length int) string {
+			return "@" + name + "\n" + strings.Repeat("A", length) + "\n+\n" +
+				strings.Repeat("5", length) + "\n"
+		}
+		// 9000 bases: the 4000 and 3000 bp reads hold more than half.
+		fastq := read("a", 4000) + read("b", 3000) + read("c", 1500) +
+			read("d", 500)
+
+		qc, err := ComputeLongReadQC(strings.NewReader(fastq))
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(4), qc.Reads)
+		assert.Equal(t, int64(9000), qc.Bases)
+		assert.Equal(t, int64(3000), qc.ReadN50)
+		assert.Equal(t, 2250.0, qc.MedianLength)
+		assert.Equal(t, int64(LongReadHistogramBinWidth), qc.HistogramBinWidth)
+		assert.Equal(t, map[int64]int64{0: 1, 1000: 1, 3000: 1, 4000: 1},
+			qc.LengthHistogram)
+		assert.Equal(t, 20.0, qc.MeanPhred)
+	})
+
+	t.Run("Error - Empty Reads", func(t *testing.T) {
+		_, err := ComputeLongReadQC(strings.NewReader(""))
+		assert.ErrorIs(t, err, ErrEmptyReads)
+	})
+}
+
+func TestGetLongReadQC(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "nanopore.fastq")
+		assert.NoError(t, os.WriteFile(path,
+			[]byte("@a\nACGTACGTAC\n+\n++++++++++\n"), 0644))
+
+		qc, err := GetLongReadQC(path)
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(10), qc.ReadN50)
+		assert.Equal(t, map[int64]int64{0: 1}, qc.LengthHistogram)
+	})
+
+	t.Run("Error - File Not Found", func(t *testing.T) {
+		_, err := GetLongReadQC(filepath.Join(t.TempDir(), "missing.fastq"))
+		assert.ErrorIs(t, err, ErrFileNotFound)
+	})
+}
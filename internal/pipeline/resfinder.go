@@ -0,0 +1,261 @@
+package pipeline
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ResfinderPhenotype is an entry of the ResFinder phenotypes.txt catalog.
+type ResfinderPhenotype struct {
+	// Accession is the "Gene_accession no." column, the allele and its
+	// GenBank accession (blaOXA-23_1_AY795964).
+	Accession   string
+	Gene        string
+	Classes     []string
+	Antibiotics []string
+	PMIDs       []string
+	Mechanism   string
+}
+
+// ResfinderCatalogIssue is a catalog line that could not be parsed.
+type ResfinderCatalogIssue struct {
+	Line   int
+	Entry  string
+	Reason string
+}
+
+func (i ResfinderCatalogIssue) String() string {
+	return fmt.Sprintf("line %d (%s): %s", i.Line, i.Entry, i.Reason)
+}
+
+// ResfinderCatalog holds the phenotypes.txt entries by accession and by gene.
+type ResfinderCatalog struct {
+	Entries     []ResfinderPhenotype
+	byAccession map[string]int
+	byGene      map[string]int
+}
+
+// resfinderColumns are the phenotypes.txt columns read by the loader. The
+// first three are required.
+var resfinderColumns = []string{
+	"Gene_accession no.", "Class", "Phenotype", "PMID",
+	"Mechanism of resistance",
+}
+
+// ParseResfinderCatalog reads a phenotypes.txt catalog. Lines that cannot be
+// parsed are returned as issues and left out of the catalog; only a missing
+// or unknown header is an error.
+func ParseResfinderCatalog(r io.Reader) (*ResfinderCatalog,
+	[]ResfinderCatalogIssue, error) {
+	scanner := bufio.NewScanner(r)
+
+	const maxCapacity = 1024 * 1024
+	buf := make([]byte, maxCapacity)
+	scanner.Buffer(buf, maxCapacity)
+
+	if !scanner.Scan() {
+		if err := scanner.Err(); err != nil {
+			return nil, nil, fmt.Errorf(
+				"An error occurred while reading the file: %v", err)
+		}
+		return nil, nil, errors.New("Empty Resfinder reference file")
+	}
+
+	header := strings.Split(strings.TrimRight(scanner.Text(), "\r\n"), "\t")
+	index := map[string]int{}
+	for i, column := range header {
+		index[strings.TrimSpace(strings.TrimPrefix(column, "#"))] = i
+	}
+	for _, column := range resfinderColumns[:3] {
+		if _, ok := index[column]; !ok {
+			return nil, nil, fmt.Errorf(
+				"invalid Resfinder reference header: missing %s column",
+				column)
+		}
+	}
+
+	catalog := &ResfinderCatalog{
+		byAccession: map[string]int{},
+		byGene:      map[string]int{},
+	}
+	var issues []ResfinderCatalogIssue
+
+	lineNumber := 1
+	for scanner.Scan() {
+		lineNumber++
+		line := strings.TrimRight(scanner.Text(), "\r\n")
+		if strings.TrimSpace(line) == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		fields := strings.Split(line, "\t")
+		column := func(name string) string {
+			i, ok := index[name]
+			if !ok || i >= len(fields) {
+				return ""
+			}
+			return strings.TrimSpace(fields[i])
+		}
+
+		entry := ResfinderPhenotype{
+			Accession:   column("Gene_accession no."),
+			Classes:     splitResfinderList(column("Class")),
+			Antibiotics: splitResfinderList(column("Phenotype")),
+			PMIDs:       splitResfinderList(column("PMID")),
+			Mechanism:   column("Mechanism of resistance"),
+		}
+		issue := func(reason string) {
+			issues = append(issues, ResfinderCatalogIssue{
+				Line: lineNumber, Entry: entry.Accession, Reason: reason,
+			})
+		}
+
+		gene, ok := resfinderGeneName(entry.Accession)
+		switch {
+		case entry.Accession == "":
+			issue("missing gene accession")
+			continue
+		case !ok:
+			issue("gene accession is not GENE_VARIANT_ACCESSION")
+			continue
+		case len(entry.Classes) == 0:
+			issue("missing class")
+			continue
+		case len(entry.Antibiotics) == 0:
+			issue("missing phenotype")
+			continue
+		}
+		entry.Gene = gene
+		if _, duplicated := catalog.byAccession[entry.Accession]; duplicated {
+			issue("duplicate gene accession")
+			continue
+		}
+
+		catalog.Entries = append(catalog.Entries, entry)
+		position := len(catalog.Entries) - 1
+		catalog.byAccession[entry.Accession] = position
+		if _, ok := catalog.byGene[gene]; !ok {
+			catalog.byGene[gene] = position
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, nil, fmt.Errorf(
+			"An error occurred while reading the file: %v", err)
+	}
+
+	return catalog, issues, nil
+}
+
+// Lookup returns the entry of an ABRicate hit: by allele and accession
+// (blaOXA-23_1 and AY795964) first, then by gene name (blaOXA-23).
+func (c *ResfinderCatalog) Lookup(allele, accession string) (
+	*ResfinderPhenotype, bool) {
+	if c == nil {
+		return nil, false
+	}
+	if accession != "" {
+		if i, ok := c.byAccession[allele+"_"+accession]; ok {
+			return &c.Entries[i], true
+		}
+	}
+
+	gene := allele
+	if name, ok := resfinderGeneName(allele + "_"); ok {
+		gene = name
+	}
+	if i, ok := c.byGene[gene]; ok {
+		return &c.Entries[i], true
+	}
+	return nil, false
+}
+
+// resfinderGeneName returns the gene of a catalog accession, the part
+// before the variant number: blaOXA-23 for blaOXA-23_1_AY795964. Gene names
+// may themselves contain underscores (aac(6')-Ib_cr_1_DQ303918).
+func resfinderGeneName(accession string) (string, bool) {
+	parts := strings.Split(accession, "_")
+	if len(parts) < 3 {
+		return "", false
+	}
+	for i := len(parts) - 2; i > 0; i-- {
+		if isDigits(parts[i]) {
+			return strings.Join(parts[:i], "_"), true
+		}
+	}
+	return "", false
+}
+
+func isDigits(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+func splitResfinderList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+type resfinderCacheEntry struct {
+	modTime time.Time
+	size    int64
+	catalog *ResfinderCatalog
+}
+
+var (
+	resfinderCacheMu sync.Mutex
+	resfinderCache   = map[string]resfinderCacheEntry{}
+)
+
+// LoadResfinderCatalog reads the catalog at path once per process. It is
+// read again when the file changes.
+func LoadResfinderCatalog(path string) (*ResfinderCatalog, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"Failed to open Resfinder reference file:%v", err)
+	}
+
+	resfinderCacheMu.Lock()
+	defer resfinderCacheMu.Unlock()
+
+	if cached, ok := resfinderCache[path]; ok &&
+		cached.modTime.Equal(info.ModTime()) && cached.size == info.Size() {
+		return cached.catalog, nil
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"Failed to open Resfinder reference file:%v", err)
+	}
+	defer file.Close()
+
+	catalog, _, err := ParseResfinderCatalog(file)
+	if err != nil {
+		return nil, err
+	}
+
+	resfinderCache[path] = resfinderCacheEntry{
+		modTime: info.ModTime(), size: info.Size(), catalog: catalog,
+	}
+	return catalog, nil
+}
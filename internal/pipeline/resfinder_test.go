@@ -0,0 +1,135 @@
+package pipeline
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const resfinderCatalogHeader = "Gene_accession no.\tClass\tPhenotype\tPMID\t" +
+	"Mechanism of resistance\tNotes\tRequired_gene"
+
+func TestParseResfinderCatalog(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		content := resfinderCatalogHeader + "\n" +
+			"blaOXA-23_1_AY795964\tBeta-lactam\tAmoxicillin, Imipenem, " +
+			"Meropenem\t16023012, 19346357\tAntibiotic inactivation\t\t\n" +
+			"aac(6')-Ib-cr_1_DQ303918\tAminoglycoside, Quinolone\t" +
+			"Amikacin, Ciprofloxacin\t16369540\tAntibiotic inactivation\t\t\n"
+
+		catalog, issues, err := ParseResfinderCatalog(
+			strings.NewReader(content))
+
+		assert.NoError(t, err)
+		assert.Empty(t, issues)
+		assert.Equal(t, []ResfinderPhenotype{
+			{
+				Accession: "blaOXA-23_1_AY795964", Gene: "blaOXA-23",
+				Classes:     []string{"Beta-lactam"},
+				Antibiotics: []string{"Amoxicillin", "Imipenem", "Meropenem"},
+				PMIDs:       []string{"16023012", "19346357"},
+				Mechanism:   "Antibiotic inactivation",
+			},
+			{
+				Accession: "aac(6')-Ib-cr_1_DQ303918", Gene: "aac(6')-Ib-cr",
+				Classes:     []string{"Aminoglycoside", "Quinolone"},
+				Antibiotics: []string{"Amikacin", "Ciprofloxacin"},
+				PMIDs:       []string{"16369540"},
+				Mechanism:   "Antibiotic inactivation",
+			},
+		}, catalog.Entries)
+	})
+
+	t.Run("Success - Reports Unparsable Lines", func(t *testing.T) {
+		content := resfinderCatalogHeader + "\n" +
+			"blaOXA-23_1_AY795964\tBeta-lactam\tMeropenem\t\t\t\t\n" +
+			"blaOXA-23\tBeta-lactam\tMeropenem\t\t\t\t\n" +
+			"blaTEM-1B_1_AY458016\t\tAmpicillin\t\t\t\t\n" +
+			"blaTEM-1C_1_FJ560503\tBeta-lactam\n" +
+			"blaOXA-23_1_AY795964\tBeta-lactam\tImipenem\t\t\t\t\n"
+
+		catalog, issues, err := ParseResfinderCatalog(
+			strings.NewReader(content))
+
+		assert.NoError(t, err)
+		assert.Len(t, catalog.Entries, 1)
+		assert.Equal(t, []ResfinderCatalogIssue{
+			{Line: 3, Entry: "blaOXA-23",
+				Reason: "gene accession is not GENE_VARIANT_ACCESSION"},
+			{Line: 4, Entry: "blaTEM-1B_1_AY458016", Reason: "missing class"},
+			{Line: 5, Entry: "blaTEM-1C_1_FJ560503",
+				Reason: "missing phenotype"},
+			{Line: 6, Entry: "blaOXA-23_1_AY795964",
+				Reason: "duplicate gene accession"},
+		}, issues)
+		assert.Equal(t, "line 4 (blaTEM-1B_1_AY458016): missing class",
+			issues[1].String())
+	})
+
+	t.Run("Error - Missing Column", func(t *testing.T) {
+		_, _, err := ParseResfinderCatalog(strings.NewReader(
+			"Gene_accession no.\tClass\n"))
+		assert.ErrorContains(t, err, "missing Phenotype column")
+	})
+
+	t.Run("Error - Empty File", func(t *testing.T) {
+		_, _, err := ParseResfinderCatalog(strings.NewReader(""))
+		assert.ErrorContains(t, err, "Empty Resfinder reference file")
+	})
+}
+
+func TestResfinderCatalogLookup(t *testing.T) {
+	catalog, _, err := ParseResfinderCatalog(strings.NewReader(
+		resfinderCatalogHeader + "\n" +
+			"oqxA_1_EU370913\tQuinolone\tCiprofloxacin\t\t\t\t\n" +
+			"oqxA_2_EU370914\tQuinolone\tNalidixic acid\t\t\t\t\n" +
+			"oqxA10_1_KX458016\tQuinolone\tNorfloxacin\t\t\t\t\n"))
+	assert.NoError(t, err)
+
+	entry, ok := catalog.Lookup("oqxA_2", "EU370914")
+	assert.True(t, ok)
+	assert.Equal(t, []string{"Nalidixic acid"}, entry.Antibiotics)
+
+	entry, ok = catalog.Lookup("oqxA_3", "MISSING")
+	assert.True(t, ok)
+	assert.Equal(t, "oqxA_1_EU370913", entry.Accession)
+
+	entry, ok = catalog.Lookup("oqxA10_4", "")
+	assert.True(t, ok)
+	assert.Equal(t, []string{"Norfloxacin"}, entry.Antibiotics)
+
+	_, ok = catalog.Lookup("oqx", "")
+	assert.False(t, ok)
+
+	_, ok = (*ResfinderCatalog)(nil).Lookup("oqxA_1", "EU370913")
+	assert.False(t, ok)
+}
+
+func TestLoadResfinderCatalog(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "phenotypes.txt")
+	assert.NoError(t, os.WriteFile(path, []byte(resfinderCatalogHeader+"\n"+
+		"oqxA_1_EU370913\tQuinolone\tCiprofloxacin\t\t\t\t\n"), 0644))
+
+	first, err := LoadResfinderCatalog(path)
+	assert.NoError(t, err)
+	second, err := LoadResfinderCatalog(path)
+	assert.NoError(t, err)
+	assert.Same(t, first, second)
+
+	assert.NoError(t, os.WriteFile(path, []byte(resfinderCatalogHeader+"\n"+
+		"oqxB_1_EU370914\tQuinolone\tNalidixic acid\t\t\t\t\n"), 0644))
+	later := time.Now().Add(time.Minute)
+	assert.NoError(t, os.Chtimes(path, later, later))
+
+	reloaded, err := LoadResfinderCatalog(path)
+	assert.NoError(t, err)
+	assert.NotSame(t, first, reloaded)
+	assert.Equal(t, "oqxB", reloaded.Entries[0].Gene)
+
+	_, err = LoadResfinderCatalog(filepath.Join(t.TempDir(), "missing.txt"))
+	assert.Error(t, err)
+}
@@ -0,0 +1,361 @@
+package pipeline
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pelletier/go-toml/v2"
+)
+
+// StepResources are the limits a step runs under. Zero values are unlimited,
+// except Threads, where zero keeps the threads shared by every step.
+type StepResources struct {
+	Timeout time.Duration
+	Threads int
+	// MaxMemory is in bytes.
+	MaxMemory int64
+}
+
+// ResourceLimits holds the limits of every step. Steps without their own
+// entry, and the fields an entry leaves unset, use Default.
+type ResourceLimits struct {
+	Default StepResources
+	Steps   map[string]StepResources
+}
+
+type stepResourcesFile struct {
+	Timeout   string `toml:"timeout"`
+	Threads   int    `toml:"threads"`
+	MaxMemory string `toml:"max_memory"`
+}
+
+type resourceLimitsFile struct {
+	Default stepResourcesFile            `toml:"default"`
+	Steps   map[string]stepResourcesFile `toml:"steps"`
+}
+
+// resourceSteps are the step names a limits file may configure.
+var resourceSteps = []string{
+	StepNameFastQC, StepNameUnicycler, StepNameStats, StepNameProkka,
+	StepNameCheckM, StepNameKraken2, StepNameSpecies, StepNameAbricate,
+	StepNameAMRFinder, StepNameCoverage,
+}
+
+// LoadResourceLimits reads the step limits at path, e.g.:
+//
+//	[default]
+//	timeout = "12h"
+//
+//	[steps.Unicycler]
+//	timeout = "6h"
+//	threads = 16
+//	max_memory = "24G"
+func LoadResourceLimits(path string) (*ResourceLimits, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read resource limits: %w", err)
+	}
+
+	var file resourceLimitsFile
+	decoder := toml.NewDecoder(bytes.NewReader(raw))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&file); err != nil {
+		return nil, fmt.Errorf("invalid resource limits %s: %w", path, err)
+	}
+
+	limits := &ResourceLimits{Steps: map[string]StepResources{}}
+	limits.Default, err = file.Default.parse()
+	if err != nil {
+		return nil, fmt.Errorf("invalid resource limits %s: default: %w",
+			path, err)
+	}
+	for name, entry := range file.Steps {
+		step := resourceStepName(name)
+		if step == "" {
+			return nil, fmt.Errorf("invalid resource limits %s: unknown "+
+				"step %q", path, name)
+		}
+		resources, err := entry.parse()
+		if err != nil {
+			return nil, fmt.Errorf("invalid resource limits %s: steps.%s: %w",
+				path, name, err)
+		}
+		limits.Steps[step] = resources
+	}
+
+	return limits, nil
+}
+
+func resourceStepName(name string) string {
+	for _, step := range resourceSteps {
+		if strings.EqualFold(step, name) {
+			return step
+		}
+	}
+	return ""
+}
+
+func (f stepResourcesFile) parse() (StepResources, error) {
+	var resources StepResources
+	if f.Timeout != "" {
+		timeout, err := time.ParseDuration(f.Timeout)
+		if err != nil || timeout <= 0 {
+			return resources, fmt.Errorf("invalid timeout %q", f.Timeout)
+		}
+		resources.Timeout = timeout
+	}
+	if f.Threads < 0 {
+		return resources, fmt.Errorf("threads must be positive")
+	}
+	resources.Threads = f.Threads
+	if f.MaxMemory != "" {
+		memory, err := ParseMemory(f.MaxMemory)
+		if err != nil {
+			return resources, err
+		}
+		resources.MaxMemory = memory
+	}
+	return resources, nil
+}
+
+// ParseMemory parses a size in bytes with an optional binary unit suffix
+// ("512M", "16G", "16GiB").
+func ParseMemory(raw string) (int64, error) {
+	value := strings.ToUpper(strings.TrimSpace(raw))
+	value = strings.TrimSuffix(strings.TrimSuffix(value, "B"), "I")
+
+	multiplier := int64(1)
+	if value != "" {
+		switch value[len(value)-1] {
+		case 'K':
+			multiplier = 1 << 10
+		case 'M':
+			multiplier = 1 << 20
+		case 'G':
+			multiplier = 1 << 30
+		case 'T':
+			multiplier = 1 << 40
+		}
+		if multiplier > 1 {
+			value = value[:len(value)-1]
+		}
+	}
+
+	size, err := strconv.ParseFloat(value, 64)
+	if err != nil || size <= 0 {
+		return 0, fmt.Errorf("invalid memory size %q", raw)
+	}
+	return int64(size * float64(multiplier)), nil
+}
+
+// For returns the limits of step. It is safe to call on a nil
+// *ResourceLimits, which has no limits.
+func (l *ResourceLimits) For(step string) StepResources {
+	if l == nil {
+		return StepResources{}
+	}
+
+	resources := l.Default
+	override, ok := l.Steps[resourceStepName(step)]
+	if !ok {
+		return resources
+	}
+	if override.Timeout > 0 {
+		resources.Timeout = override.Timeout
+	}
+	if override.Threads > 0 {
+		resources.Threads = override.Threads
+	}
+	if override.MaxMemory > 0 {
+		resources.MaxMemory = override.MaxMemory
+	}
+	return resources
+}
+
+type stepResourcesKey struct{}
+
+// errStepDeadline is the cause of the contexts cancelled by a step timeout,
+// which tells them apart from the deadline of the whole analysis.
+var errStepDeadline = errors.New("step deadline exceeded")
+
+// StepContext returns the context step runs under: tagged with the step
+// (see WithStep), carrying its limits and cancelled when its timeout
+// expires.
+func (l *ResourceLimits) StepContext(ctx context.Context, step string) (
+	context.Context, context.CancelFunc) {
+	resources := l.For(step)
+	ctx = context.WithValue(WithStep(ctx, step), stepResourcesKey{},
+		resources)
+	if resources.Timeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeoutCause(ctx, resources.Timeout, errStepDeadline)
+}
+
+func StepResourcesFromContext(ctx context.Context) StepResources {
+	resources, _ := ctx.Value(stepResourcesKey{}).(StepResources)
+	return resources
+}
+
+// ResourceError returns ErrStepTimeout or ErrStepOutOfMemory when err was
+// caused by the step exceeding the limits of ctx, a context returned by
+// StepContext, and nil otherwise.
+func ResourceError(ctx context.Context, err error) error {
+	if err == nil {
+		return nil
+	}
+	if errors.Is(context.Cause(ctx), errStepDeadline) {
+		return ErrStepTimeout
+	}
+	if ctx.Err() != nil {
+		return nil
+	}
+	if StepResourcesFromContext(ctx).MaxMemory > 0 && isOutOfMemory(err) {
+		return ErrStepOutOfMemory
+	}
+	return nil
+}
+
+// errOOMKilled wraps the error of a tool the kernel OOM killer stopped, as
+// recorded by the cgroup of its systemd scope or by its container runtime.
+var errOOMKilled = errors.New("killed by the OOM killer")
+
+// isOutOfMemory reports whether err comes from a tool with evidence of an
+// OOM kill. A SIGKILL or an exit code of 137 is not enough: cancelled and
+// timed out tools are killed too, and tools may log allocation failures
+// they recovered from.
+func isOutOfMemory(err error) bool {
+	return errors.Is(err, errOOMKilled)
+}
+
+// oomCheckCmd wraps the error of a failed tool in errOOMKilled when
+// oomKilled finds evidence of an OOM kill. cleanup runs once the tool exits.
+type oomCheckCmd struct {
+	Cmd
+	oomKilled func() bool
+	cleanup   func()
+}
+
+func (c *oomCheckCmd) Start() error {
+	err := c.Cmd.Start()
+	if err != nil {
+		c.cleanup()
+	}
+	return err
+}
+
+func (c *oomCheckCmd) Run() error  { return c.check(c.Cmd.Run()) }
+func (c *oomCheckCmd) Wait() error { return c.check(c.Cmd.Wait()) }
+
+func (c *oomCheckCmd) check(err error) error {
+	defer c.cleanup()
+	if err != nil && c.oomKilled() {
+		return fmt.Errorf("%w: %w", errOOMKilled, err)
+	}
+	return err
+}
+
+type MemoryLimiter string
+
+const (
+	// MemoryLimiterSystemd, the default, runs the tool in a transient
+	// systemd scope whose cgroup caps the memory of the tool and its
+	// children and records the OOM kills.
+	MemoryLimiterSystemd MemoryLimiter = "systemd"
+	// MemoryLimiterUlimit caps the virtual memory of the tool process. It
+	// breaks tools that reserve more address space than they use, such as
+	// the JVM of FastQC and Pilon, and leaves no evidence of an OOM kill,
+	// so its steps never fail as out of memory.
+	MemoryLimiterUlimit MemoryLimiter = "ulimit"
+)
+
+func ParseMemoryLimiter(name string) (MemoryLimiter, error) {
+	limiter := MemoryLimiter(strings.ToLower(strings.TrimSpace(name)))
+	switch limiter {
+	case "":
+		return MemoryLimiterSystemd, nil
+	case MemoryLimiterUlimit, MemoryLimiterSystemd:
+		return limiter, nil
+	default:
+		return "", fmt.Errorf("unknown memory limiter %q", name)
+	}
+}
+
+// ResourceCommander enforces the memory limit of the running step (see
+// StepContext) on the tools run through Next.
+type ResourceCommander struct {
+	Next    Commander
+	Limiter MemoryLimiter
+}
+
+func (c *ResourceCommander) Command(ctx context.Context, name string,
+	args ...string) Cmd {
+	memory := StepResourcesFromContext(ctx).MaxMemory
+	if memory <= 0 {
+		return c.Next.Command(ctx, name, args...)
+	}
+
+	switch c.Limiter {
+	case MemoryLimiterSystemd:
+		return c.systemdCommand(ctx, memory, name, args)
+	default:
+		return c.Next.Command(ctx, "sh", append([]string{
+			"-c", `ulimit -v "$1" && shift && exec "$@"`, "sh",
+			strconv.FormatInt(memory/1024, 10), name,
+		}, args...)...)
+	}
+}
+
+// scopeOOMScript runs the tool as a child of a shell in the scope, then
+// writes the oom_kill counter of the scope cgroup to the marker file before
+// exiting with the tool status. The counter is also written when the scope
+// is stopped, as systemd does after an OOM kill by default.
+const scopeOOMScript = `marker=$1
+shift
+report() {
+	cgroup=$(sed -n 's/^0:://p' /proc/self/cgroup)
+	sed -n 's/^oom_kill //p' "/sys/fs/cgroup$cgroup/memory.events" \
+		>"$marker" 2>/dev/null
+}
+trap 'report; exit 143' TERM
+"$@"
+status=$?
+report
+exit $status`
+
+func (c *ResourceCommander) systemdCommand(ctx context.Context,
+	memory int64, name string, args []string) Cmd {
+	scopeArgs := []string{
+		"--scope", "--quiet", "--collect",
+		"-p", fmt.Sprintf("MemoryMax=%d", memory),
+		"-p", "MemorySwapMax=0",
+	}
+
+	marker, err := os.CreateTemp("", "cabgen-oom-")
+	if err != nil {
+		return c.Next.Command(ctx, "systemd-run",
+			append(append(scopeArgs, name), args...)...)
+	}
+	marker.Close()
+
+	scopeArgs = append(scopeArgs, "sh", "-c", scopeOOMScript, "sh",
+		marker.Name(), name)
+	return &oomCheckCmd{
+		Cmd: c.Next.Command(ctx, "systemd-run", append(scopeArgs, args...)...),
+		oomKilled: func() bool {
+			raw, err := os.ReadFile(marker.Name())
+			if err != nil {
+				return false
+			}
+			kills, err := strconv.Atoi(strings.TrimSpace(string(raw)))
+			return err == nil && kills > 0
+		},
+		cleanup: func() { os.Remove(marker.Name()) },
+	}
+}
@@ -0,0 +1,299 @@
+package pipeline
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func writeResourceLimits(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "resources.toml")
+	assert.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	return path
+}
+
+func TestLoadResourceLimits(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		path := writeResourceLimits(t, `
+[default]
+timeout = "12h"
+max_memory = "32G"
+
+[steps.unicycler]
+timeout = "6h"
+threads = 16
+
+[steps.Kraken2]
+max_memory = "64GiB"
+`)
+
+		limits, err := LoadResourceLimits(path)
+
+		assert.NoError(t, err)
+		assert.Equal(t, StepResources{
+			Timeout: 12 * time.Hour, MaxMemory: 32 << 30,
+		}, limits.Default)
+		assert.Equal(t, StepResources{Timeout: 6 * time.Hour, Threads: 16},
+			limits.Steps[StepNameUnicycler])
+		assert.Equal(t, StepResources{MaxMemory: 64 << 30},
+			limits.Steps[StepNameKraken2])
+	})
+
+	t.Run("Error - Unknown Step", func(t *testing.T) {
+		path := writeResourceLimits(t, "[steps.spades]\nthreads = 4\n")
+
+		_, err := LoadResourceLimits(path)
+
+		assert.ErrorContains(t, err, `unknown step "spades"`)
+	})
+
+	t.Run("Error - Invalid Timeout", func(t *testing.T) {
+		path := writeResourceLimits(t, "[steps.Prokka]\ntimeout = \"1 day\"\n")
+
+		_, err := LoadResourceLimits(path)
+
+		assert.ErrorContains(t, err, `steps.Prokka: invalid timeout "1 day"`)
+	})
+
+	t.Run("Error - Unknown Field", func(t *testing.T) {
+		path := writeResourceLimits(t, "[default]\ncpus = 4\n")
+
+		_, err := LoadResourceLimits(path)
+
+		assert.Error(t, err)
+	})
+
+	t.Run("Error - Missing File", func(t *testing.T) {
+		_, err := LoadResourceLimits(filepath.Join(t.TempDir(), "missing"))
+
+		assert.Error(t, err)
+	})
+}
+
+func TestParseMemory(t *testing.T) {
+	tests := []struct {
+		raw      string
+		expected int64
+	}{
+		{"1024", 1024},
+		{"512k", 512 << 10},
+		{"512M", 512 << 20},
+		{"1.5G", 3 << 29},
+		{"16GiB", 16 << 30},
+		{"1TB", 1 << 40},
+	}
+	for _, tt := range tests {
+		t.Run(tt.raw, func(t *testing.T) {
+			memory, err := ParseMemory(tt.raw)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, memory)
+		})
+	}
+
+	t.Run("Error - Invalid", func(t *testing.T) {
+		for _, raw := range []string{"", "G", "-1G", "lots"} {
+			_, err := ParseMemory(raw)
+			assert.Error(t, err, raw)
+		}
+	})
+}
+
+func TestResourceLimitsFor(t *testing.T) {
+	limits := &ResourceLimits{
+		Default: StepResources{Timeout: time.Hour, MaxMemory: 1 << 30},
+		Steps: map[string]StepResources{
+			StepNameCheckM: {Threads: 8, MaxMemory: 4 << 30},
+		},
+	}
+
+	assert.Equal(t, StepResources{
+		Timeout: time.Hour, Threads: 8, MaxMemory: 4 << 30,
+	}, limits.For("checkm"))
+	assert.Equal(t, limits.Default, limits.For(StepNameProkka))
+
+	var none *ResourceLimits
+	assert.Equal(t, StepResources{}, none.For(StepNameProkka))
+}
+
+func TestResourceError(t *testing.T) {
+	limits := &ResourceLimits{Default: StepResources{MaxMemory: 1 << 30}}
+
+	t.Run("Timeout", func(t *testing.T) {
+		limits := &ResourceLimits{
+			Default: StepResources{Timeout: time.Millisecond},
+		}
+		ctx, cancel := limits.StepContext(context.Background(),
+			StepNameProkka)
+		defer cancel()
+		<-ctx.Done()
+
+		assert.Equal(t, ErrStepTimeout, ResourceError(ctx, ctx.Err()))
+	})
+
+	t.Run("Analysis Deadline Is Not A Step Timeout", func(t *testing.T) {
+		parent, cancelParent := context.WithTimeout(context.Background(),
+			time.Millisecond)
+		defer cancelParent()
+		ctx, cancel := limits.StepContext(parent, StepNameProkka)
+		defer cancel()
+		<-ctx.Done()
+
+		assert.Nil(t, ResourceError(ctx, ctx.Err()))
+	})
+
+	t.Run("OOM Kill", func(t *testing.T) {
+		ctx, cancel := limits.StepContext(context.Background(),
+			StepNameProkka)
+		defer cancel()
+
+		err := fmt.Errorf("%w: %w", errOOMKilled,
+			errors.New("signal: killed"))
+
+		assert.Equal(t, ErrStepOutOfMemory, ResourceError(ctx, err))
+	})
+
+	t.Run("Killed Process Without OOM Evidence", func(t *testing.T) {
+		ctx, cancel := limits.StepContext(context.Background(),
+			StepNameProkka)
+		defer cancel()
+
+		_, err := NewToolRunner(&RealCommander{}).Run(ctx,
+			[]string{"sh", "-c", "kill -9 $$"})
+
+		assert.Nil(t, ResourceError(ctx, err))
+	})
+
+	t.Run("Logged Allocation Failure", func(t *testing.T) {
+		ctx, cancel := limits.StepContext(context.Background(),
+			StepNameProkka)
+		defer cancel()
+
+		err := errors.New("Error: Cannot allocate memory")
+
+		assert.Nil(t, ResourceError(ctx, err))
+	})
+
+	t.Run("No Memory Limit", func(t *testing.T) {
+		ctx, cancel := (*ResourceLimits)(nil).StepContext(
+			context.Background(), StepNameProkka)
+		defer cancel()
+
+		err := fmt.Errorf("%w: %w", errOOMKilled,
+			errors.New("signal: killed"))
+
+		assert.Nil(t, ResourceError(ctx, err))
+	})
+}
+
+func TestResourceCommander(t *testing.T) {
+	limits := &ResourceLimits{Default: StepResources{MaxMemory: 512 << 20}}
+	ctx, cancel := limits.StepContext(context.Background(), StepNameProkka)
+	defer cancel()
+
+	t.Run("Ulimit", func(t *testing.T) {
+		commander := &ResourceCommander{
+			Next: &RealCommander{}, Limiter: MemoryLimiterUlimit,
+		}
+
+		output, err := NewToolRunner(commander).Run(ctx,
+			[]string{"sh", "-c", "ulimit -v; echo \"$@\"", "sh", "a", "b"})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "524288\na b", strings.TrimSpace(output))
+	})
+
+	// systemdCommander records the systemd-run arguments and runs the tool
+	// with the given scope oom_kill counter written to the marker file.
+	systemdCommander := func(oomKills string, runErr error,
+		gotArgs *[]string) *ResourceCommander {
+		return &ResourceCommander{
+			Next: &mockCommander{
+				cmdFunc: func(_ context.Context, name string,
+					args ...string) Cmd {
+					assert.Equal(t, "systemd-run", name)
+					*gotArgs = args
+					return &mockCmd{runFunc: func() error {
+						marker := args[11]
+						assert.NoError(t, os.WriteFile(marker,
+							[]byte(oomKills+"\n"), 0644))
+						return runErr
+					}}
+				},
+			},
+			Limiter: MemoryLimiterSystemd,
+		}
+	}
+
+	t.Run("Systemd", func(t *testing.T) {
+		var gotArgs []string
+		commander := systemdCommander("0", nil, &gotArgs)
+
+		_, err := NewToolRunner(commander).Run(ctx,
+			[]string{"prokka", "--cpus", "4"})
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{
+			"--scope", "--quiet", "--collect",
+			"-p", "MemoryMax=536870912", "-p", "MemorySwapMax=0",
+			"sh", "-c", scopeOOMScript, "sh", gotArgs[11],
+			"prokka", "--cpus", "4",
+		}, gotArgs)
+		assert.NoFileExists(t, gotArgs[11])
+	})
+
+	t.Run("Systemd - OOM Kill", func(t *testing.T) {
+		var gotArgs []string
+		commander := systemdCommander("1", errors.New("exit status 137"),
+			&gotArgs)
+
+		_, err := NewToolRunner(commander).Run(ctx, []string{"prokka"})
+
+		assert.Equal(t, ErrStepOutOfMemory, ResourceError(ctx, err))
+		assert.NoFileExists(t, gotArgs[11])
+	})
+
+	t.Run("Systemd - Failure Without OOM Kill", func(t *testing.T) {
+		var gotArgs []string
+		commander := systemdCommander("0", errors.New("exit status 137"),
+			&gotArgs)
+
+		_, err := NewToolRunner(commander).Run(ctx, []string{"prokka"})
+
+		assert.Error(t, err)
+		assert.Nil(t, ResourceError(ctx, err))
+	})
+
+	t.Run("Without Limit", func(t *testing.T) {
+		commander := &ResourceCommander{
+			Next: &RealCommander{}, Limiter: MemoryLimiterUlimit,
+		}
+
+		output, err := NewToolRunner(commander).Run(context.Background(),
+			[]string{"echo", "ok"})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "ok\n", output)
+	})
+}
+
+func TestParseMemoryLimiter(t *testing.T) {
+	limiter, err := ParseMemoryLimiter("")
+	assert.NoError(t, err)
+	assert.Equal(t, MemoryLimiterSystemd, limiter)
+
+	limiter, err = ParseMemoryLimiter(" Ulimit ")
+	assert.NoError(t, err)
+	assert.Equal(t, MemoryLimiterUlimit, limiter)
+
+	_, err = ParseMemoryLimiter("cgroupfs")
+	assert.Error(t, err)
+}
@@ -0,0 +1,484 @@
+package pipeline
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+)
+
+// Step names match the analysis steps reported to the user.
+const (
+	// StepNameFastQC runs before the genome steps, outside of the graph.
+	StepNameFastQC    = "FastQC"
+	StepNameUnicycler = "Unicycler"
+	StepNameStats     = "AssemblyStats"
+	StepNameProkka    = "Prokka"
+	StepNameCheckM    = "CheckM"
+	StepNameKraken2   = "Kraken2"
+	StepNameSpecies   = "Species"
+	StepNameAbricate  = "Abricate"
+	StepNameAMRFinder = "AMRFinderPlus"
+	StepNameCoverage  = "Coverage"
+)
+
+// Keys of the values exchanged between steps. Result keys share the JSON
+// names of the analysis results, so the final data maps onto them directly.
+const (
+	KeyRead1      = "read1"
+	KeyRead2      = "read2"
+	KeyLongReads  = "long_reads"
+	KeyAssembly   = "assembly"
+	KeyAnnotation = "annotation"
+
+	KeyKrakenPrimary   = "kraken_primary"
+	KeyKrakenSecondary = "kraken_secondary"
+	KeyTaxonomy        = "taxonomy"
+
+	KeyCompleteness       = "completeness"
+	KeyContamination      = "contamination"
+	KeyGenomeSize         = "genome_size"
+	KeyN50                = "n50"
+	KeyAssemblyStats      = "assembly_stats"
+	KeyPrimarySpecies     = "primary_species"
+	KeySecondarySpecies   = "secondary_species"
+	KeyMLST               = "mlst"
+	KeyPoliMutations      = "poli_mutations"
+	KeyOtherMutations     = "other_mutations"
+	KeyAcquiredResistance = "acquired_resistance"
+	KeyPointMutations     = "point_mutations"
+	KeyVFDB               = "vfdb"
+	KeyPlasmidFinder      = "plasmid"
+	KeyCoverage           = "coverage"
+	KeyLongReadCoverage   = "long_read_coverage"
+)
+
+// GenomeSeeds are the keys the caller may set before running the genome
+// steps.
+var GenomeSeeds = []string{KeyRead1, KeyRead2, KeyLongReads, KeyAssembly}
+
+// StepEnv holds what the genome steps need besides the step data.
+type StepEnv struct {
+	Pipeline    CabgenPipeline
+	Threads     int
+	SampleID    string
+	OriginCode  string
+	AssemblyDir string
+	AMRDir      string
+}
+
+// threads returns the threads of the running step, or the shared ones when
+// its limits do not set any.
+func (e StepEnv) threads(ctx context.Context) int {
+	if threads := StepResourcesFromContext(ctx).Threads; threads > 0 {
+		return threads
+	}
+	return e.Threads
+}
+
+// GenomeSteps returns the genome analysis steps. New tools are added here as
+// new steps; the analysis runner only executes the graph.
+func GenomeSteps(env StepEnv) []Step {
+	return []Step{
+		unicyclerStep(env),
+		assemblyStatsStep(),
+		prokkaStep(env),
+		checkMStep(env),
+		kraken2Step(env),
+		speciesStep(env),
+		abricateStep(env),
+		amrFinderStep(env),
+		coverageStep(),
+	}
+}
+
+func unicyclerStep(env StepEnv) Step {
+	return Step{
+		Name:    StepNameUnicycler,
+		Inputs:  []string{KeyRead1, KeyRead2, KeyLongReads},
+		Outputs: []StepOutput{Output[string](KeyAssembly)},
+		Err:     ErrUnicycler,
+
+		PassInputErrors: true,
+		Skip: func(data *StepData) bool {
+			_, ok := Value[string](data, KeyAssembly)
+			return ok
+		},
+		Run: func(ctx context.Context, data *StepData) (*StepResult, error) {
+			var reads UnicyclerReads
+			reads.Read1, _ = Value[string](data, KeyRead1)
+			reads.Read2, _ = Value[string](data, KeyRead2)
+			reads.LongReads, _ = Value[string](data, KeyLongReads)
+			if !reads.hasShortReads() && reads.LongReads == "" {
+				return nil, fmt.Errorf("no input files: need FASTA, " +
+					"FASTQ or long reads")
+			}
+
+			assembly, err := env.Pipeline.RunUnicycler(ctx, env.threads(ctx),
+				reads, env.Pipeline.GetConfig().SpadesPath,
+				env.AssemblyDir,
+				fmt.Sprintf("%s_assembly.fasta", env.OriginCode))
+			if err != nil {
+				return nil, err
+			}
+
+			return &StepResult{
+				Values: map[string]any{KeyAssembly: assembly},
+				Files:  []string{assembly},
+			}, nil
+		},
+	}
+}
+
+// assemblyStatsStep describes the assembly, built by Unicycler or uploaded.
+func assemblyStatsStep() Step {
+	return Step{
+		Name:      StepNameStats,
+		DependsOn: []string{StepNameUnicycler},
+		Inputs:    []string{KeyAssembly},
+		Outputs:   []StepOutput{Output[*AssemblyStats](KeyAssemblyStats)},
+		Optional:  true,
+		Run: func(ctx context.Context, data *StepData) (*StepResult, error) {
+			assembly, _ := Value[string](data, KeyAssembly)
+			stats, err := GetAssemblyStats(assembly)
+			if err != nil {
+				return nil, err
+			}
+
+			return &StepResult{
+				Values: map[string]any{KeyAssemblyStats: stats},
+			}, nil
+		},
+	}
+}
+
+func prokkaStep(env StepEnv) Step {
+	return Step{
+		Name:      StepNameProkka,
+		DependsOn: []string{StepNameUnicycler},
+		Inputs:    []string{KeyAssembly},
+		Outputs:   []StepOutput{Output[string](KeyAnnotation)},
+		Err:       ErrProkka,
+
+		PassInputErrors: true,
+		Run: func(ctx context.Context, data *StepData) (*StepResult, error) {
+			assembly, _ := Value[string](data, KeyAssembly)
+			prokkaOutDir := filepath.Join(env.AssemblyDir, "prokka")
+			if err := env.Pipeline.RunProkka(ctx, env.threads(ctx), assembly,
+				prokkaOutDir); err != nil {
+				return nil, err
+			}
+
+			annotation := filepath.Join(prokkaOutDir, "genome.ffn")
+			return &StepResult{
+				Values: map[string]any{KeyAnnotation: annotation},
+				Files:  []string{annotation},
+			}, nil
+		},
+	}
+}
+
+func checkMStep(env StepEnv) Step {
+	return Step{
+		Name:      StepNameCheckM,
+		DependsOn: []string{StepNameUnicycler},
+		Inputs:    []string{KeyAssembly},
+		Outputs: []StepOutput{
+			Output[string](KeyCompleteness),
+			Output[string](KeyContamination),
+			Output[string](KeyGenomeSize),
+			Output[string](KeyN50),
+		},
+		Err: ErrCheckM,
+		Run: func(ctx context.Context, data *StepData) (*StepResult, error) {
+			assembly, _ := Value[string](data, KeyAssembly)
+			ext := filepath.Ext(assembly)
+			sample := strings.TrimSuffix(filepath.Base(assembly), ext)
+			output := filepath.Join(env.AssemblyDir, "checkm_output")
+
+			result, err := env.Pipeline.RunCheckM(ctx, env.threads(ctx),
+				sample, env.AssemblyDir, output)
+			if err != nil {
+				return nil, err
+			}
+			if result == nil {
+				return &StepResult{}, nil
+			}
+
+			return &StepResult{Values: map[string]any{
+				KeyCompleteness:  result.Completeness,
+				KeyContamination: result.Contamination,
+				KeyGenomeSize:    result.GenomeSize,
+				KeyN50:           result.N50,
+			}}, nil
+		},
+	}
+}
+
+func kraken2Step(env StepEnv) Step {
+	return Step{
+		Name:      StepNameKraken2,
+		DependsOn: []string{StepNameUnicycler},
+		Inputs:    []string{KeyAssembly},
+		Outputs: []StepOutput{
+			Output[*KrakenSpecies](KeyKrakenPrimary),
+			Output[*KrakenSpecies](KeyKrakenSecondary),
+			Output[*KrakenComposition](KeyTaxonomy),
+		},
+		Err: ErrKraken2,
+		Run: func(ctx context.Context, data *StepData) (*StepResult, error) {
+			assembly, _ := Value[string](data, KeyAssembly)
+			composition, err := env.Pipeline.RunKraken2(ctx,
+				env.threads(ctx), assembly, env.AssemblyDir)
+			if err != nil {
+				return nil, err
+			}
+
+			values := map[string]any{}
+			if composition == nil {
+				return &StepResult{Values: values}, nil
+			}
+			values[KeyTaxonomy] = composition
+			if primary := composition.Primary(); primary != nil {
+				values[KeyKrakenPrimary] = primary
+			}
+			if secondary := composition.Secondary(); secondary != nil {
+				values[KeyKrakenSecondary] = secondary
+			}
+			return &StepResult{Values: values}, nil
+		},
+	}
+}
+
+func speciesStep(env StepEnv) Step {
+	return Step{
+		Name:      StepNameSpecies,
+		DependsOn: []string{StepNameKraken2},
+		Inputs: []string{KeyAssembly, KeyKrakenPrimary,
+			KeyKrakenSecondary},
+		Outputs: []StepOutput{
+			Output[string](KeyPrimarySpecies),
+			Output[string](KeyMLST),
+			Output[[]string](KeyPoliMutations),
+			Output[[]string](KeyOtherMutations),
+			Output[string](KeySecondarySpecies),
+		},
+		Err: ErrSpecies,
+		Skip: func(data *StepData) bool {
+			_, hasPrimary := Value[*KrakenSpecies](data, KeyKrakenPrimary)
+			_, hasSecondary := Value[*KrakenSpecies](data,
+				KeyKrakenSecondary)
+			return !hasPrimary && !hasSecondary
+		},
+		Run: func(ctx context.Context, data *StepData) (*StepResult, error) {
+			values := map[string]any{}
+
+			if primary, ok := Value[*KrakenSpecies](data,
+				KeyKrakenPrimary); ok {
+				assembly, _ := Value[string](data, KeyAssembly)
+				result, err := env.Pipeline.ProcessSpecies(ctx,
+					env.threads(ctx), env.SampleID, primary.Name, assembly,
+					env.AssemblyDir)
+				if err != nil {
+					return nil, err
+				}
+				if result != nil {
+					values[KeyPrimarySpecies] = result.DisplayName
+					values[KeyMLST] = result.MLSTSpecies
+					values[KeyPoliMutations] = RenderMutations(
+						result.PoliMutations)
+					values[KeyOtherMutations] = RenderMutations(
+						result.OtherMutations)
+				}
+			}
+
+			if secondary, ok := Value[*KrakenSpecies](data,
+				KeyKrakenSecondary); ok {
+				params := AnalysisParametersFromContext(ctx,
+					env.Pipeline.GetConfig().Parameters)
+				if secondary.Percent >= params.Kraken2.SecondaryMinPercent {
+					values[KeySecondarySpecies] = secondary.Name
+				}
+			}
+
+			return &StepResult{Values: values}, nil
+		},
+	}
+}
+
+// amrEngine returns the engine chosen for the species Kraken2 found, with
+// its AMRFinderPlus organism.
+func amrEngine(env StepEnv, data *StepData) (AMREngine, string) {
+	name := ""
+	if primary, ok := Value[*KrakenSpecies](data, KeyKrakenPrimary); ok &&
+		primary != nil {
+		name = primary.Name
+	}
+	return env.Pipeline.GetConfig().AMREngineFor(name)
+}
+
+// abricateStep searches VFDB and PlasmidFinder, and ResFinder when ABRicate
+// is the AMR engine of the species.
+func abricateStep(env StepEnv) Step {
+	return Step{
+		Name:      StepNameAbricate,
+		DependsOn: []string{StepNameProkka, StepNameKraken2},
+		Inputs:    []string{KeyAnnotation, KeyKrakenPrimary},
+		Outputs: []StepOutput{
+			Output[[]AMRHit](KeyAcquiredResistance),
+			Output[[]AMRHit](KeyVFDB),
+			Output[[]AMRHit](KeyPlasmidFinder),
+		},
+		Err: ErrAbricate,
+		Run: func(ctx context.Context, data *StepData) (*StepResult, error) {
+			input, _ := Value[string](data, KeyAnnotation)
+			params := AnalysisParametersFromContext(ctx,
+				env.Pipeline.GetConfig().Parameters)
+			abricateDBs := []struct{ db, output string }{
+				{"vfdb", fmt.Sprintf("%s_outAbricateVFDB", env.SampleID)},
+				{"plasmidfinder", fmt.Sprintf("%s_outAbricatePlasmid",
+					env.SampleID)},
+			}
+			if engine, _ := amrEngine(env, data); engine == AMREngineAbricate {
+				abricateDBs = append([]struct{ db, output string }{
+					{"resfinder", fmt.Sprintf("%s_outAbricateRes",
+						env.SampleID)},
+				}, abricateDBs...)
+			}
+
+			values := map[string]any{}
+			files := make([]string, 0, len(abricateDBs))
+			for _, entry := range abricateDBs {
+				outputFile := filepath.Join(env.AMRDir, entry.output)
+				if err := env.Pipeline.RunAbricate(ctx, env.threads(ctx),
+					entry.db, input, outputFile); err != nil {
+					return nil, fmt.Errorf("%s: %w", entry.db, err)
+				}
+
+				rawResult, err := GetAbricateResult(outputFile,
+					*params.Abricate)
+				if err != nil {
+					return nil, fmt.Errorf("%s result: %w", entry.db, err)
+				}
+
+				switch entry.db {
+				case "resfinder":
+					hits, err := ProcessResfinder(rawResult,
+						env.Pipeline.GetConfig().ResfinderDBPath)
+					if err != nil {
+						return nil, fmt.Errorf("process resfinder: %w", err)
+					}
+					values[KeyAcquiredResistance] = hits
+				case "vfdb":
+					values[KeyVFDB] = ProcessVFDB(rawResult)
+				case "plasmidfinder":
+					values[KeyPlasmidFinder] = ProcessPlasmidFinder(rawResult)
+				}
+				files = append(files, outputFile)
+			}
+
+			return &StepResult{Values: values, Files: files}, nil
+		},
+	}
+}
+
+// amrFinderStep runs AMRFinderPlus on the assembly when it is the AMR
+// engine of the species.
+func amrFinderStep(env StepEnv) Step {
+	return Step{
+		Name:      StepNameAMRFinder,
+		DependsOn: []string{StepNameUnicycler, StepNameKraken2},
+		Inputs:    []string{KeyAssembly, KeyKrakenPrimary},
+		Outputs: []StepOutput{
+			Output[[]AMRHit](KeyAcquiredResistance),
+			Output[[]AMRHit](KeyPointMutations),
+		},
+		Err: ErrAMRFinder,
+		Skip: func(data *StepData) bool {
+			engine, _ := amrEngine(env, data)
+			return engine != AMREngineAMRFinderPlus
+		},
+		Run: func(ctx context.Context, data *StepData) (*StepResult, error) {
+			assembly, _ := Value[string](data, KeyAssembly)
+			_, organism := amrEngine(env, data)
+			outputFile := filepath.Join(env.AMRDir,
+				fmt.Sprintf("%s_outAMRFinder.tsv", env.SampleID))
+
+			if err := env.Pipeline.RunAMRFinder(ctx, env.threads(ctx),
+				assembly, organism, outputFile); err != nil {
+				return nil, err
+			}
+
+			result, err := GetAMRFinderResult(outputFile)
+			if err != nil {
+				return nil, err
+			}
+
+			return &StepResult{
+				Values: map[string]any{
+					KeyAcquiredResistance: result.Genes,
+					KeyPointMutations:     result.PointMutations,
+				},
+				Files: []string{outputFile},
+			}, nil
+		},
+	}
+}
+
+// coverageStep uses the assembly length as the genome size. It only waits
+// for the assembly statistics, so a failed CheckM does not cost the coverage.
+// Short and long reads get their own depth.
+func coverageStep() Step {
+	genomeSize := func(data *StepData) int64 {
+		if stats, ok := Value[*AssemblyStats](data,
+			KeyAssemblyStats); ok && stats != nil {
+			return stats.TotalLength
+		}
+		return 0
+	}
+	reads := func(data *StepData) UnicyclerReads {
+		var reads UnicyclerReads
+		reads.Read1, _ = Value[string](data, KeyRead1)
+		reads.Read2, _ = Value[string](data, KeyRead2)
+		reads.LongReads, _ = Value[string](data, KeyLongReads)
+		return reads
+	}
+
+	return Step{
+		Name:      StepNameCoverage,
+		DependsOn: []string{StepNameStats},
+		Inputs:    []string{KeyRead1, KeyRead2, KeyLongReads, KeyAssemblyStats},
+		Outputs: []StepOutput{Output[float64](KeyCoverage),
+			Output[float64](KeyLongReadCoverage)},
+		Optional: true,
+		Skip: func(data *StepData) bool {
+			reads := reads(data)
+			return (!reads.hasShortReads() && reads.LongReads == "") ||
+				genomeSize(data) <= 0
+		},
+		Run: func(ctx context.Context, data *StepData) (*StepResult, error) {
+			reads := reads(data)
+			size := genomeSize(data)
+			values := map[string]any{}
+
+			if reads.hasShortReads() {
+				coverage, err := CalculateCoverage(reads.Read1, reads.Read2,
+					size)
+				if err != nil {
+					return nil, err
+				}
+				values[KeyCoverage] = coverage
+			}
+			if reads.LongReads != "" {
+				coverage, err := CalculateLongReadCoverage(reads.LongReads,
+					size)
+				if err != nil {
+					return nil, err
+				}
+				values[KeyLongReadCoverage] = coverage
+			}
+
+			return &StepResult{Values: values}, nil
+		},
+	}
+}
@@ -0,0 +1,124 @@
+package pipeline
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+const toolLogDirName = "logs"
+
+// ToolInvocation is the record of a single tool run.
+type ToolInvocation struct {
+	Step      string
+	Command   []string
+	Stdout    string
+	Stderr    string
+	ExitCode  int
+	StartedAt time.Time
+	Duration  time.Duration
+}
+
+// Tool returns the base name of the executable that was run.
+func (i ToolInvocation) Tool() string {
+	if len(i.Command) == 0 {
+		return ""
+	}
+	return filepath.Base(i.Command[0])
+}
+
+// ToolRecorder receives every tool invocation made with a context carrying
+// it (see WithToolRecorder).
+type ToolRecorder interface {
+	Record(ctx context.Context, invocation ToolInvocation)
+}
+
+type toolRecorderKey struct{}
+
+type stepKey struct{}
+
+func WithToolRecorder(ctx context.Context,
+	recorder ToolRecorder) context.Context {
+	return context.WithValue(ctx, toolRecorderKey{}, recorder)
+}
+
+func ToolRecorderFromContext(ctx context.Context) ToolRecorder {
+	recorder, _ := ctx.Value(toolRecorderKey{}).(ToolRecorder)
+	return recorder
+}
+
+// WithStep tags the tool invocations made with ctx with the step running
+// them.
+func WithStep(ctx context.Context, step string) context.Context {
+	return context.WithValue(ctx, stepKey{}, step)
+}
+
+func StepFromContext(ctx context.Context) string {
+	step, _ := ctx.Value(stepKey{}).(string)
+	return step
+}
+
+// exitCode returns the process exit code for err, or -1 when the process
+// could not be started or was killed.
+func exitCode(err error) int {
+	if err == nil {
+		return 0
+	}
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		return exitErr.ExitCode()
+	}
+	return -1
+}
+
+// ToolLogDir stores the raw output of tool invocations inside the analysis
+// folder.
+type ToolLogDir struct {
+	Dir string
+}
+
+type ToolLogFiles struct {
+	Stdout string
+	Stderr string
+}
+
+func NewToolLogDir(analysisDir string) *ToolLogDir {
+	return &ToolLogDir{Dir: filepath.Join(analysisDir, toolLogDirName)}
+}
+
+// Write saves stdout and stderr of the invocation to their own files, named
+// after its start time, step and tool so runs never overwrite each other.
+func (d *ToolLogDir) Write(invocation ToolInvocation) (*ToolLogFiles, error) {
+	if err := os.MkdirAll(d.Dir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create log dir: %w", err)
+	}
+
+	step := invocation.Step
+	if step == "" {
+		step = "none"
+	}
+	prefix := strings.Join([]string{
+		invocation.StartedAt.UTC().Format("20060102T150405.000000000"),
+		step, invocation.Tool(),
+	}, "_")
+
+	files := &ToolLogFiles{
+		Stdout: filepath.Join(d.Dir, prefix+".stdout.log"),
+		Stderr: filepath.Join(d.Dir, prefix+".stderr.log"),
+	}
+	if err := os.WriteFile(files.Stdout, []byte(invocation.Stdout),
+		0644); err != nil {
+		return nil, fmt.Errorf("failed to write stdout log: %w", err)
+	}
+	if err := os.WriteFile(files.Stderr, []byte(invocation.Stderr),
+		0644); err != nil {
+		return nil, fmt.Errorf("failed to write stderr log: %w", err)
+	}
+
+	return files, nil
+}
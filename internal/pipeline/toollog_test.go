@@ -0,0 +1,90 @@
+package pipeline
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type recordingRecorder struct {
+	invocations []ToolInvocation
+}
+
+func (r *recordingRecorder) Record(_ context.Context,
+	invocation ToolInvocation) {
+	r.invocations = append(r.invocations, invocation)
+}
+
+func TestExitCode(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		assert.Equal(t, 0, exitCode(nil))
+	})
+
+	t.Run("Success - Process Exit Code", func(t *testing.T) {
+		err := exec.Command("sh", "-c", "exit 3").Run()
+		assert.Equal(t, 3, exitCode(err))
+	})
+
+	t.Run("Success - Not Started", func(t *testing.T) {
+		err := exec.Command("/nonexistent/tool").Run()
+		assert.Equal(t, -1, exitCode(err))
+	})
+}
+
+func TestToolLogDirWrite(t *testing.T) {
+	invocation := ToolInvocation{
+		Step:      StepNameKraken2,
+		Command:   []string{"/opt/bin/kraken2", "--db", "std"},
+		Stdout:    "classified",
+		Stderr:    "loading database",
+		StartedAt: time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC),
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		analysisDir := t.TempDir()
+		logDir := NewToolLogDir(analysisDir)
+
+		files, err := logDir.Write(invocation)
+
+		assert.NoError(t, err)
+		assert.Equal(t, filepath.Join(analysisDir, "logs"),
+			filepath.Dir(files.Stdout))
+		assert.True(t, strings.HasSuffix(files.Stdout,
+			"_Kraken2_kraken2.stdout.log"))
+
+		stdout, err := os.ReadFile(files.Stdout)
+		assert.NoError(t, err)
+		assert.Equal(t, "classified", string(stdout))
+
+		stderr, err := os.ReadFile(files.Stderr)
+		assert.NoError(t, err)
+		assert.Equal(t, "loading database", string(stderr))
+	})
+
+	t.Run("Success - Without Step", func(t *testing.T) {
+		noStep := invocation
+		noStep.Step = ""
+
+		files, err := NewToolLogDir(t.TempDir()).Write(noStep)
+
+		assert.NoError(t, err)
+		assert.True(t, strings.HasSuffix(files.Stderr,
+			"_none_kraken2.stderr.log"))
+	})
+
+	t.Run("Error - Invalid Dir", func(t *testing.T) {
+		file := filepath.Join(t.TempDir(), "file")
+		assert.NoError(t, os.WriteFile(file, nil, 0644))
+
+		files, err := NewToolLogDir(file).Write(invocation)
+
+		assert.Error(t, err)
+		assert.Nil(t, files)
+	})
+}
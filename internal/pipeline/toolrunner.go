@@ -4,14 +4,19 @@ import (
 	"bytes"
 	"context"
 	"fmt"
+	"strconv"
 	"strings"
+	"time"
 )
 
 type ToolRunner interface {
-	BuildBlastXCmd(blastDB, inputFile, outputFile string) []string
+	BuildBlastXCmd(blastDB, inputFile, outputFile string,
+		params BlastXParameters) []string
+	BuildBlastNCmd(blastDB, inputFile, outputFile string,
+		params BlastNParameters) []string
 	BuildFastQCCmd(fastqcCmd, read1, read2, outputDir string) []string
-	BuildUnicyclerCmd(unicyclerCmd, read1, read2, outputDir, threads,
-		spadesPath string) []string
+	BuildUnicyclerCmd(unicyclerCmd string, reads UnicyclerReads, outputDir,
+		threads, spadesPath string, params UnicyclerParameters) []string
 	BuildProkkaCmd(prokkaCmd, outputDir, prefix, assemblyPath,
 		threads string) []string
 	BuildCheckMLineageCmd(checkmCmd, inputDir, outputDir,
@@ -21,9 +26,11 @@ type ToolRunner interface {
 		assemblyPath string) []string
 	BuildSplitterCmd(threads, inputFile, outputFilePrefix string) []string
 	BuildFastANICmd(fastaniCmd, query, refList, output, threads string) []string
-	BuildAbricateCmd(abricateCmd, db, inputFile, outputFile,
-		threads string) []string
-	BuildMLSTCmd(mlstCmd, threads, assemblyPath, outputFile string) []string
+	BuildAbricateCmd(abricateCmd, db, inputFile, threads string) []string
+	BuildAMRFinderCmd(amrfinderCmd, assemblyPath, organism, dbPath,
+		outputFile, threads string) []string
+	BuildMLSTCmd(mlstCmd, threads, assemblyPath string,
+		exclude []string) []string
 	Run(ctx context.Context, args []string) (string, error)
 }
 
@@ -37,47 +44,92 @@ func NewToolRunner(commander Commander) ToolRunner {
 	}
 }
 
-func (r *toolRunner) BuildBlastXCmd(blastDB, inputFile,
-	outputFile string) []string {
-	if blastDB == "" || inputFile == "" || outputFile == "" {
+func (r *toolRunner) BuildBlastXCmd(blastDB, inputFile, outputFile string,
+	params BlastXParameters) []string {
+	if blastDB == "" || inputFile == "" || outputFile == "" ||
+		params.Evalue <= 0 {
 		return nil
 	}
 
 	return []string{
-		"blastx", "-db", blastDB, "-query", inputFile, "-evalue", "0.001",
-		"-out", outputFile,
+		"blastx", "-db", blastDB, "-query", inputFile,
+		"-evalue", strconv.FormatFloat(params.Evalue, 'g', -1, 64),
+		"-outfmt", BlastOutputFormat, "-out", outputFile,
+	}
+}
+
+// BuildBlastNCmd searches nucleotide targets (e.g. 23S rRNA).
+func (r *toolRunner) BuildBlastNCmd(blastDB, inputFile, outputFile string,
+	params BlastNParameters) []string {
+	if blastDB == "" || inputFile == "" || outputFile == "" ||
+		params.Evalue <= 0 {
+		return nil
+	}
+
+	return []string{
+		"blastn", "-db", blastDB, "-query", inputFile,
+		"-evalue", strconv.FormatFloat(params.Evalue, 'g', -1, 64),
+		"-outfmt", BlastOutputFormat, "-out", outputFile,
 	}
 }
 
 func (r *toolRunner) BuildFastQCCmd(fastqcCmd, read1, read2,
 	outputDir string) []string {
-	if fastqcCmd == "" || read1 == "" || read2 == "" || outputDir == "" {
+	if fastqcCmd == "" || read1 == "" || outputDir == "" {
 		return nil
 	}
 
-	return []string{fastqcCmd, "--quiet", read1, read2, "--outdir", outputDir}
+	args := []string{fastqcCmd, "--quiet", read1}
+	if read2 != "" {
+		args = append(args, read2)
+	}
+
+	return append(args, "--outdir", outputDir)
 }
 
-func (r *toolRunner) BuildUnicyclerCmd(unicyclerCmd, read1, read2, outputDir,
-	threads, spadesPath string) []string {
-	if unicyclerCmd == "" || read1 == "" || read2 == "" || outputDir == "" ||
-		threads == "" {
+// UnicyclerReads are the reads of an assembly: short reads, long reads, or
+// both for a hybrid assembly. Short reads are single-end when Read2 is empty.
+type UnicyclerReads struct {
+	Read1     string
+	Read2     string
+	LongReads string
+}
+
+func (r UnicyclerReads) hasPair() bool {
+	return r.Read1 != "" && r.Read2 != ""
+}
+
+func (r UnicyclerReads) hasShortReads() bool {
+	return r.Read1 != ""
+}
+
+func (r *toolRunner) BuildUnicyclerCmd(unicyclerCmd string,
+	reads UnicyclerReads, outputDir, threads, spadesPath string,
+	params UnicyclerParameters) []string {
+	if unicyclerCmd == "" ||
+		(!reads.hasShortReads() && reads.LongReads == "") ||
+		outputDir == "" || threads == "" || params.Mode == "" ||
+		params.MinFastaLength <= 0 {
 		return nil
 	}
 
+	args := []string{unicyclerCmd}
+	if reads.hasPair() {
+		args = append(args, "-1", reads.Read1, "-2", reads.Read2)
+	} else if reads.hasShortReads() {
+		args = append(args, "-s", reads.Read1)
+	}
+	if reads.LongReads != "" {
+		args = append(args, "-l", reads.LongReads)
+	}
+	args = append(args, "-o", outputDir,
+		"--min_fasta_length", strconv.Itoa(params.MinFastaLength),
+		"--mode", params.Mode, "-t", threads)
 	if spadesPath != "" {
-		return []string{
-			unicyclerCmd, "-1", read1, "-2", read2, "-o", outputDir,
-			"--min_fasta_length", "500", "--mode", "conservative",
-			"-t", threads, "--spades_path", spadesPath,
-		}
+		args = append(args, "--spades_path", spadesPath)
 	}
 
-	return []string{
-		unicyclerCmd, "-1", read1, "-2", read2, "-o", outputDir,
-		"--min_fasta_length", "500", "--mode", "conservative",
-		"-t", threads,
-	}
+	return args
 }
 
 func (r *toolRunner) BuildProkkaCmd(prokkaCmd, outputDir, prefix,
@@ -160,31 +212,49 @@ func (r *toolRunner) BuildFastANICmd(fastaniCmd, query, refList,
 }
 
 func (r *toolRunner) BuildAbricateCmd(abricateCmd, db, inputFile,
-	outputFile, threads string) []string {
-	if abricateCmd == "" || db == "" || inputFile == "" || outputFile == "" ||
-		threads == "" {
+	threads string) []string {
+	if abricateCmd == "" || db == "" || inputFile == "" || threads == "" {
 		return nil
 	}
 
 	return []string{
-		"sh", "-c",
-		fmt.Sprintf("%s --db %s %s > %s --threads %s",
-			abricateCmd, db, inputFile, outputFile, threads),
+		abricateCmd, "--db", db, "--threads", threads, inputFile,
 	}
 }
 
-func (r *toolRunner) BuildMLSTCmd(mlstCmd, threads, assemblyPath,
-	outputFile string) []string {
-	if mlstCmd == "" || threads == "" || assemblyPath == "" ||
-		outputFile == "" {
+// BuildAMRFinderCmd searches the assembly nucleotides. Point mutations are
+// only reported when organism is set; dbPath overrides the database
+// installed with amrfinder.
+func (r *toolRunner) BuildAMRFinderCmd(amrfinderCmd, assemblyPath, organism,
+	dbPath, outputFile, threads string) []string {
+	if amrfinderCmd == "" || assemblyPath == "" || outputFile == "" ||
+		threads == "" {
 		return nil
 	}
 
-	return []string{
-		"sh", "-c",
-		fmt.Sprintf("%s --threads %s --exclude abaumannii --csv %s > %s",
-			mlstCmd, threads, assemblyPath, outputFile),
+	args := []string{amrfinderCmd, "-n", assemblyPath, "--threads", threads}
+	if organism != "" {
+		args = append(args, "--organism", organism)
+	}
+	if dbPath != "" {
+		args = append(args, "--database", dbPath)
+	}
+
+	return append(args, "-o", outputFile)
+}
+
+func (r *toolRunner) BuildMLSTCmd(mlstCmd, threads, assemblyPath string,
+	exclude []string) []string {
+	if mlstCmd == "" || threads == "" || assemblyPath == "" {
+		return nil
 	}
+
+	args := []string{mlstCmd, "--threads", threads}
+	if len(exclude) > 0 {
+		args = append(args, "--exclude", strings.Join(exclude, ","))
+	}
+
+	return append(args, "--csv", assemblyPath)
 }
 
 func (r *toolRunner) Run(ctx context.Context, args []string) (string, error) {
@@ -201,9 +271,25 @@ func (r *toolRunner) Run(ctx context.Context, args []string) (string, error) {
 	cmd.SetStdout(&stdout)
 	cmd.SetStderr(&stderr)
 
-	if err := cmd.Run(); err != nil {
+	started := time.Now()
+	err := cmd.Run()
+	if recorder := ToolRecorderFromContext(ctx); recorder != nil {
+		recorder.Record(ctx, ToolInvocation{
+			Step:      StepFromContext(ctx),
+			Command:   args,
+			Stdout:    stdout.String(),
+			Stderr:    stderr.String(),
+			ExitCode:  exitCode(err),
+			StartedAt: started,
+			Duration:  time.Since(started),
+		})
+	}
+
+	if err != nil {
 		stderrStr := stderr.String()
-		if userErr := classifyToolError(stderrStr); userErr != nil {
+		// An OOM kill takes precedence over what the tool logged.
+		userErr := classifyToolError(stderrStr)
+		if userErr != nil && !isOutOfMemory(err) {
 			return "", fmt.Errorf(
 				"%w: command '%s' failed. Output: %s. Error: %s",
 				userErr, strings.Join(args, " "), stdout.String(), stderrStr)
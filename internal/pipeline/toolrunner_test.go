@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"io"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -284,30 +285,109 @@ func TestToolRunnerRun(t *testing.T) {
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "cmd a b c")
 	})
+
+	t.Run("Success - Records Invocation", func(t *testing.T) {
+		runner := NewToolRunner(&mockCommander{
+			cmdFunc: func(ctx context.Context, name string, args ...string) Cmd {
+				return &mockCmd{
+					runFunc: func() error {
+						return fmt.Errorf("exit status 1")
+					},
+					stderr: "database not found",
+				}
+			},
+		})
+		recorder := &recordingRecorder{}
+		recordCtx := WithStep(WithToolRecorder(ctx, recorder), StepNameCheckM)
+
+		_, err := runner.Run(recordCtx, []string{"/usr/bin/checkm", "qa"})
+
+		assert.Error(t, err)
+		assert.Len(t, recorder.invocations, 1)
+		invocation := recorder.invocations[0]
+		assert.Equal(t, StepNameCheckM, invocation.Step)
+		assert.Equal(t, "checkm", invocation.Tool())
+		assert.Equal(t, []string{"/usr/bin/checkm", "qa"}, invocation.Command)
+		assert.Equal(t, "database not found", invocation.Stderr)
+		assert.Equal(t, -1, invocation.ExitCode)
+		assert.False(t, invocation.StartedAt.IsZero())
+	})
+
+	t.Run("Error - Cancelled Stops Child Processes", func(t *testing.T) {
+		runner := NewToolRunner(&RealCommander{})
+		cancelCtx, cancel := context.WithCancel(ctx)
+		time.AfterFunc(100*time.Millisecond, cancel)
+
+		started := time.Now()
+		_, err := runner.Run(cancelCtx,
+			[]string{"sh", "-c", "sleep 30 & wait"})
+
+		assert.Error(t, err)
+		assert.Less(t, time.Since(started), ToolStopGracePeriod)
+	})
 }
 
 func TestBuildBlastXCmd(t *testing.T) {
 	runner := &toolRunner{}
+	blastx := *DefaultAnalysisParameters().BlastX
 
 	t.Run("Success", func(t *testing.T) {
-		result := runner.BuildBlastXCmd("nr", "contigs.fa", "blastx_out.txt")
+		result := runner.BuildBlastXCmd("nr", "contigs.fa", "blastx_out.txt", blastx)
 
 		assert.Equal(t, []string{
 			"blastx", "-db", "nr", "-query", "contigs.fa",
-			"-evalue", "0.001", "-out", "blastx_out.txt",
+			"-evalue", "0.001", "-outfmt", BlastOutputFormat,
+			"-out", "blastx_out.txt",
 		}, result)
 	})
 
 	t.Run("Empty blastDB", func(t *testing.T) {
-		assert.Nil(t, runner.BuildBlastXCmd("", "contigs.fa", "blastx_out.txt"))
+		assert.Nil(t, runner.BuildBlastXCmd("", "contigs.fa", "blastx_out.txt", blastx))
 	})
 
 	t.Run("Empty inputFile", func(t *testing.T) {
-		assert.Nil(t, runner.BuildBlastXCmd("nr", "", "blastx_out.txt"))
+		assert.Nil(t, runner.BuildBlastXCmd("nr", "", "blastx_out.txt", blastx))
 	})
 
 	t.Run("Empty outputFile", func(t *testing.T) {
-		assert.Nil(t, runner.BuildBlastXCmd("nr", "contigs.fa", ""))
+		assert.Nil(t, runner.BuildBlastXCmd("nr", "contigs.fa", "", blastx))
+	})
+
+	t.Run("Success - Custom Evalue", func(t *testing.T) {
+		result := runner.BuildBlastXCmd("nr", "contigs.fa", "blastx_out.txt",
+			BlastXParameters{Evalue: 1e-10})
+
+		assert.Contains(t, result, "1e-10")
+	})
+
+	t.Run("Missing evalue", func(t *testing.T) {
+		assert.Nil(t, runner.BuildBlastXCmd("nr", "contigs.fa", "blastx_out.txt", BlastXParameters{}))
+	})
+}
+
+func TestBuildBlastNCmd(t *testing.T) {
+	runner := &toolRunner{}
+	blastn := *DefaultAnalysisParameters().BlastN
+
+	t.Run("Success", func(t *testing.T) {
+		result := runner.BuildBlastNCmd("/dbs/23S.fasta", "contigs.fa",
+			"blastn_out.txt", blastn)
+
+		assert.Equal(t, []string{
+			"blastn", "-db", "/dbs/23S.fasta", "-query", "contigs.fa",
+			"-evalue", "0.001", "-outfmt", BlastOutputFormat,
+			"-out", "blastn_out.txt",
+		}, result)
+	})
+
+	t.Run("Empty blastDB", func(t *testing.T) {
+		assert.Nil(t, runner.BuildBlastNCmd("", "contigs.fa",
+			"blastn_out.txt", blastn))
+	})
+
+	t.Run("Missing evalue", func(t *testing.T) {
+		assert.Nil(t, runner.BuildBlastNCmd("/dbs/23S.fasta", "contigs.fa",
+			"blastn_out.txt", BlastNParameters{}))
 	})
 }
 
@@ -328,8 +408,12 @@ func TestBuildFastQCCmd(t *testing.T) {
 		assert.Nil(t, runner.BuildFastQCCmd("fastqc", "", "read2.fq", "/out"))
 	})
 
-	t.Run("Empty read2", func(t *testing.T) {
-		assert.Nil(t, runner.BuildFastQCCmd("fastqc", "read1.fq", "", "/out"))
+	t.Run("Success - Single-end", func(t *testing.T) {
+		result := runner.BuildFastQCCmd("fastqc", "read1.fq", "", "/out")
+
+		assert.Equal(t, []string{
+			"fastqc", "--quiet", "read1.fq", "--outdir", "/out",
+		}, result)
 	})
 
 	t.Run("Empty outputDir", func(t *testing.T) {
@@ -339,9 +423,11 @@ func TestBuildFastQCCmd(t *testing.T) {
 
 func TestBuildUnicyclerCmd(t *testing.T) {
 	runner := &toolRunner{}
+	unicycler := *DefaultAnalysisParameters().Unicycler
+	pair := UnicyclerReads{Read1: "r1.fq", Read2: "r2.fq"}
 
 	t.Run("Success", func(t *testing.T) {
-		result := runner.BuildUnicyclerCmd("unicycler", "r1.fq", "r2.fq", "/out", "4", "/spades")
+		result := runner.BuildUnicyclerCmd("unicycler", pair, "/out", "4", "/spades", unicycler)
 
 		assert.Equal(t, []string{
 			"unicycler", "-1", "r1.fq", "-2", "r2.fq", "-o", "/out",
@@ -350,16 +436,58 @@ func TestBuildUnicyclerCmd(t *testing.T) {
 		}, result)
 	})
 
+	t.Run("Success - Custom Mode", func(t *testing.T) {
+		result := runner.BuildUnicyclerCmd("unicycler", pair, "/out", "4", "",
+			UnicyclerParameters{Mode: "bold", MinFastaLength: 200})
+
+		assert.Equal(t, []string{
+			"unicycler", "-1", "r1.fq", "-2", "r2.fq", "-o", "/out",
+			"--min_fasta_length", "200", "--mode", "bold", "-t", "4",
+		}, result)
+	})
+
+	t.Run("Success - Long Reads", func(t *testing.T) {
+		result := runner.BuildUnicyclerCmd("unicycler", UnicyclerReads{LongReads: "long.fq"}, "/out", "4", "", unicycler)
+
+		assert.Equal(t, []string{
+			"unicycler", "-l", "long.fq", "-o", "/out",
+			"--min_fasta_length", "500", "--mode", "conservative", "-t", "4",
+		}, result)
+	})
+
+	t.Run("Success - Hybrid", func(t *testing.T) {
+		result := runner.BuildUnicyclerCmd("unicycler", UnicyclerReads{Read1: "r1.fq", Read2: "r2.fq", LongReads: "long.fq"}, "/out", "4", "", unicycler)
+
+		assert.Equal(t, []string{
+			"unicycler", "-1", "r1.fq", "-2", "r2.fq", "-l", "long.fq",
+			"-o", "/out", "--min_fasta_length", "500", "--mode",
+			"conservative", "-t", "4",
+		}, result)
+	})
+
+	t.Run("Success - Single-end", func(t *testing.T) {
+		result := runner.BuildUnicyclerCmd("unicycler", UnicyclerReads{Read1: "r1.fq"}, "/out", "4", "", unicycler)
+
+		assert.Equal(t, []string{
+			"unicycler", "-s", "r1.fq", "-o", "/out", "--min_fasta_length",
+			"500", "--mode", "conservative", "-t", "4",
+		}, result)
+	})
+
 	t.Run("Empty unicyclerCmd", func(t *testing.T) {
-		assert.Nil(t, runner.BuildUnicyclerCmd("", "r1.fq", "r2.fq", "/out", "4", "/spades"))
+		assert.Nil(t, runner.BuildUnicyclerCmd("", pair, "/out", "4", "/spades", unicycler))
 	})
 
 	t.Run("Empty read1", func(t *testing.T) {
-		assert.Nil(t, runner.BuildUnicyclerCmd("unicycler", "", "r2.fq", "/out", "4", "/spades"))
+		assert.Nil(t, runner.BuildUnicyclerCmd("unicycler", UnicyclerReads{Read2: "r2.fq"}, "/out", "4", "/spades", unicycler))
 	})
 
 	t.Run("Empty threads", func(t *testing.T) {
-		assert.Nil(t, runner.BuildUnicyclerCmd("unicycler", "r1.fq", "r2.fq", "/out", "", "/spades"))
+		assert.Nil(t, runner.BuildUnicyclerCmd("unicycler", pair, "/out", "", "/spades", unicycler))
+	})
+
+	t.Run("Missing mode", func(t *testing.T) {
+		assert.Nil(t, runner.BuildUnicyclerCmd("unicycler", pair, "/out", "4", "/spades", UnicyclerParameters{}))
 	})
 }
 
@@ -501,20 +629,54 @@ func TestBuildAbricateCmd(t *testing.T) {
 	runner := &toolRunner{}
 
 	t.Run("Success", func(t *testing.T) {
-		result := runner.BuildAbricateCmd("abricate", "resfinder", "input.fna", "out.txt", "4")
+		result := runner.BuildAbricateCmd("abricate", "resfinder", "input.fna", "4")
 
 		assert.Equal(t, []string{
-			"sh", "-c",
-			"abricate --db resfinder input.fna > out.txt --threads 4",
+			"abricate", "--db", "resfinder", "--threads", "4", "input.fna",
 		}, result)
 	})
 
 	t.Run("Empty abricateCmd", func(t *testing.T) {
-		assert.Nil(t, runner.BuildAbricateCmd("", "resfinder", "input.fna", "out.txt", "4"))
+		assert.Nil(t, runner.BuildAbricateCmd("", "resfinder", "input.fna", "4"))
 	})
 
 	t.Run("Empty db", func(t *testing.T) {
-		assert.Nil(t, runner.BuildAbricateCmd("abricate", "", "input.fna", "out.txt", "4"))
+		assert.Nil(t, runner.BuildAbricateCmd("abricate", "", "input.fna", "4"))
+	})
+}
+
+func TestBuildAMRFinderCmd(t *testing.T) {
+	runner := &toolRunner{}
+
+	t.Run("Success", func(t *testing.T) {
+		result := runner.BuildAMRFinderCmd("amrfinder", "contigs.fa",
+			"Klebsiella_pneumoniae", "/dbs/amrfinder", "out.tsv", "4")
+
+		assert.Equal(t, []string{
+			"amrfinder", "-n", "contigs.fa", "--threads", "4",
+			"--organism", "Klebsiella_pneumoniae",
+			"--database", "/dbs/amrfinder", "-o", "out.tsv",
+		}, result)
+	})
+
+	t.Run("Success - No Organism Or Database", func(t *testing.T) {
+		result := runner.BuildAMRFinderCmd("amrfinder", "contigs.fa", "", "",
+			"out.tsv", "4")
+
+		assert.Equal(t, []string{
+			"amrfinder", "-n", "contigs.fa", "--threads", "4",
+			"-o", "out.tsv",
+		}, result)
+	})
+
+	t.Run("Empty amrfinderCmd", func(t *testing.T) {
+		assert.Nil(t, runner.BuildAMRFinderCmd("", "contigs.fa", "", "",
+			"out.tsv", "4"))
+	})
+
+	t.Run("Empty outputFile", func(t *testing.T) {
+		assert.Nil(t, runner.BuildAMRFinderCmd("amrfinder", "contigs.fa", "",
+			"", "", "4"))
 	})
 }
 
@@ -522,19 +684,28 @@ func TestBuildMLSTCmd(t *testing.T) {
 	runner := &toolRunner{}
 
 	t.Run("Success", func(t *testing.T) {
-		result := runner.BuildMLSTCmd("mlst", "4", "contigs.fa", "out.txt")
+		result := runner.BuildMLSTCmd("mlst", "4", "contigs.fa",
+			[]string{"abaumannii", "ecoli"})
+
+		assert.Equal(t, []string{
+			"mlst", "--threads", "4", "--exclude", "abaumannii,ecoli",
+			"--csv", "contigs.fa",
+		}, result)
+	})
+
+	t.Run("Success - No Exclusions", func(t *testing.T) {
+		result := runner.BuildMLSTCmd("mlst", "4", "contigs.fa", nil)
 
 		assert.Equal(t, []string{
-			"sh", "-c",
-			"mlst --threads 4 --exclude abaumannii --csv contigs.fa > out.txt",
+			"mlst", "--threads", "4", "--csv", "contigs.fa",
 		}, result)
 	})
 
 	t.Run("Empty mlstCmd", func(t *testing.T) {
-		assert.Nil(t, runner.BuildMLSTCmd("", "4", "contigs.fa", "out.txt"))
+		assert.Nil(t, runner.BuildMLSTCmd("", "4", "contigs.fa", nil))
 	})
 
 	t.Run("Empty threads", func(t *testing.T) {
-		assert.Nil(t, runner.BuildMLSTCmd("mlst", "", "contigs.fa", "out.txt"))
+		assert.Nil(t, runner.BuildMLSTCmd("mlst", "", "contigs.fa", nil))
 	})
 }
@@ -0,0 +1,364 @@
+package pipeline
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+)
+
+// FileValidation describes an uploaded sequence file that passed validation.
+type FileValidation struct {
+	File       string `json:"file"`
+	Format     string `json:"format"`
+	Compressed bool   `json:"compressed"`
+	Records    int64  `json:"records"`
+	Bases      int64  `json:"bases"`
+}
+
+const (
+	FormatFastq = "fastq"
+	FormatFasta = "fasta"
+)
+
+// fastqRecordReader reads a FASTQ file one record at a time, checking its
+// structure on the way.
+type fastqRecordReader struct {
+	scanner    *bufio.Scanner
+	file       string
+	lineNumber int
+	name       []byte
+	sequence   int
+}
+
+func newFastqRecordReader(r io.Reader, file string) *fastqRecordReader {
+	scanner := bufio.NewScanner(r)
+
+	const maxCapacity = 1024 * 1024 * 4
+	buf := make([]byte, maxCapacity)
+	scanner.Buffer(buf, maxCapacity)
+
+	return &fastqRecordReader{scanner: scanner, file: file}
+}
+
+func (f *fastqRecordReader) errorf(sentinel error, format string,
+	args ...any) error {
+	return fmt.Errorf("%w: %s: %s", sentinel, f.file,
+		fmt.Sprintf(format, args...))
+}
+
+// next reads the following record and returns false at the end of the file.
+// The read name lives until the next call.
+func (f *fastqRecordReader) next() (bool, error) {
+	for i := 0; i < 4; i++ {
+		if !f.scanner.Scan() {
+			if err := f.scanner.Err(); err != nil {
+				return false, f.errorf(ErrCorruptedInput, "%v", err)
+			}
+			if i == 0 {
+				return false, nil
+			}
+			return false, f.errorf(ErrCorruptedInput,
+				"truncated record at line %d", f.lineNumber)
+		}
+		f.lineNumber++
+		line := bytes.TrimRight(f.scanner.Bytes(), "\r")
+
+		switch i {
+		case 0:
+			if len(line) < 2 || line[0] != '@' {
+				return false, f.errorf(ErrInvalidFormat,
+					"expected a read header at line %d", f.lineNumber)
+			}
+			f.name = append(f.name[:0], readName(line[1:])...)
+		case 1:
+			if position := bytes.IndexFunc(line, func(r rune) bool {
+				return !isNucleotide(byte(r))
+			}); position >= 0 {
+				return false, f.errorf(ErrInvalidFormat,
+					"unexpected base %q at line %d", line[position],
+					f.lineNumber)
+			}
+			f.sequence = len(line)
+		case 2:
+			if len(line) == 0 || line[0] != '+' {
+				return false, f.errorf(ErrInvalidFormat,
+					"expected a '+' separator at line %d", f.lineNumber)
+			}
+		case 3:
+			if len(line) != f.sequence {
+				return false, f.errorf(ErrInvalidFormat,
+					"quality and sequence lengths differ at line %d",
+					f.lineNumber)
+			}
+			for _, symbol := range line {
+				if symbol < '!' || symbol > '~' {
+					return false, f.errorf(ErrInvalidFormat,
+						"invalid quality at line %d", f.lineNumber)
+				}
+			}
+		}
+	}
+	return true, nil
+}
+
+// readName is the read identifier without the description and without the
+// /1 or /2 mate suffix of older Illumina headers.
+func readName(header []byte) []byte {
+	if end := bytes.IndexAny(header, " \t"); end >= 0 {
+		header = header[:end]
+	}
+	if n := len(header); n > 2 && header[n-2] == '/' &&
+		(header[n-1] == '1' || header[n-1] == '2') {
+		header = header[:n-2]
+	}
+	return header
+}
+
+// isNucleotide accepts the IUPAC nucleotide codes, in either case.
+func isNucleotide(base byte) bool {
+	switch base | 0x20 {
+	case 'a', 'c', 'g', 't', 'u', 'n', 'r', 'y', 'k', 'm', 's', 'w', 'b',
+		'd', 'h', 'v':
+		return true
+	}
+	return false
+}
+
+// openForValidation opens an uploaded file and checks it starts like the
+// expected format.
+func openForValidation(filePath, format string) (*sequenceFile,
+	*bufio.Reader, error) {
+	file := filepath.Base(filePath)
+
+	reader, err := openSequenceFile(filePath)
+	if errors.Is(err, errEmptyFile) {
+		return nil, nil, fmt.Errorf("%w: %s", ErrEmptyReads, file)
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w: %s", err, file)
+	}
+
+	buffered := bufio.NewReader(reader)
+	first, err := buffered.Peek(1)
+	if err != nil {
+		reader.Close()
+		if errors.Is(err, io.EOF) {
+			return nil, nil, fmt.Errorf("%w: %s", ErrEmptyReads, file)
+		}
+		return nil, nil, fmt.Errorf("%w: %s: %v", ErrCorruptedInput, file,
+			err)
+	}
+
+	switch {
+	case first[0] == '@' && format == FormatFastq,
+		first[0] == '>' && format == FormatFasta:
+		return reader, buffered, nil
+	case first[0] == '>':
+		err = fmt.Errorf("%w: %s is a FASTA file, expected FASTQ",
+			ErrInvalidFormat, file)
+	case first[0] == '@':
+		err = fmt.Errorf("%w: %s is a FASTQ file, expected FASTA",
+			ErrInvalidFormat, file)
+	default:
+		err = fmt.Errorf("%w: %s is not a %s file", ErrInvalidFormat, file,
+			format)
+	}
+	reader.Close()
+	return nil, nil, err
+}
+
+// fastqValidation follows a FASTQ file through validation.
+type fastqValidation struct {
+	*FileValidation
+	reader   *sequenceFile
+	records  *fastqRecordReader
+	previous []byte
+}
+
+func openFastqValidation(filePath string) (*fastqValidation, error) {
+	reader, buffered, err := openForValidation(filePath, FormatFastq)
+	if err != nil {
+		return nil, err
+	}
+
+	file := filepath.Base(filePath)
+	return &fastqValidation{
+		FileValidation: &FileValidation{
+			File: file, Format: FormatFastq, Compressed: reader.compressed,
+		},
+		reader:  reader,
+		records: newFastqRecordReader(buffered, file),
+	}, nil
+}
+
+// next validates the following record. Both mates of a read one after the
+// other mean the file is interleaved.
+func (v *fastqValidation) next() (bool, error) {
+	ok, err := v.records.next()
+	if err != nil || !ok {
+		return false, err
+	}
+	if v.previous != nil && bytes.Equal(v.previous, v.records.name) {
+		return false, v.records.errorf(ErrInvalidFormat,
+			"read %s appears twice in a row at line %d, the file looks "+
+				"interleaved", v.records.name, v.records.lineNumber-3)
+	}
+	v.previous = append(v.previous[:0], v.records.name...)
+	v.Records++
+	v.Bases += int64(v.records.sequence)
+	return true, nil
+}
+
+// finish checks the file held at least one read.
+func (v *fastqValidation) finish() (*FileValidation, error) {
+	if v.Records == 0 {
+		return nil, fmt.Errorf("%w: %s", ErrEmptyReads, v.File)
+	}
+	return v.FileValidation, nil
+}
+
+// ValidateFastq reads a whole FASTQ file, gzipped or not, checking the
+// record structure and that it does not hold both mates of a pair.
+func ValidateFastq(filePath string) (*FileValidation, error) {
+	validation, err := openFastqValidation(filePath)
+	if err != nil {
+		return nil, err
+	}
+	defer validation.reader.Close()
+
+	for {
+		ok, err := validation.next()
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			return validation.finish()
+		}
+	}
+}
+
+// ValidatePairedFastq validates both read files in a single pass and checks
+// they hold the same reads in the same order.
+func ValidatePairedFastq(read1, read2 string) (*FileValidation,
+	*FileValidation, error) {
+	validation1, err := openFastqValidation(read1)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer validation1.reader.Close()
+	validation2, err := openFastqValidation(read2)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer validation2.reader.Close()
+
+	for {
+		ok1, err := validation1.next()
+		if err != nil {
+			return nil, nil, err
+		}
+		ok2, err := validation2.next()
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if ok1 != ok2 {
+			shorter, longer := validation1, validation2
+			if ok1 {
+				shorter, longer = validation2, validation1
+			}
+			return nil, nil, fmt.Errorf("%w: %s ends after %d reads, "+
+				"before %s", ErrUnpairedReads, shorter.File, shorter.Records,
+				longer.File)
+		}
+		if !ok1 {
+			break
+		}
+		if !bytes.Equal(validation1.records.name,
+			validation2.records.name) {
+			return nil, nil, fmt.Errorf("%w: read %d is %s in %s and %s "+
+				"in %s", ErrUnpairedReads, validation1.Records,
+				validation1.records.name, validation1.File,
+				validation2.records.name, validation2.File)
+		}
+	}
+
+	result1, err := validation1.finish()
+	if err != nil {
+		return nil, nil, err
+	}
+	result2, err := validation2.finish()
+	if err != nil {
+		return nil, nil, err
+	}
+	return result1, result2, nil
+}
+
+// ValidateFasta reads a whole FASTA file, gzipped or not, checking that
+// every record has a named header and nucleotide sequence.
+func ValidateFasta(filePath string) (*FileValidation, error) {
+	reader, buffered, err := openForValidation(filePath, FormatFasta)
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+
+	validation := &FileValidation{
+		File:       filepath.Base(filePath),
+		Format:     FormatFasta,
+		Compressed: reader.compressed,
+	}
+	invalid := func(format string, args ...any) error {
+		return fmt.Errorf("%w: %s: %s", ErrInvalidFormat, validation.File,
+			fmt.Sprintf(format, args...))
+	}
+
+	scanner := bufio.NewScanner(buffered)
+	const maxCapacity = 1024 * 1024 * 4
+	buf := make([]byte, maxCapacity)
+	scanner.Buffer(buf, maxCapacity)
+
+	var recordBases int64 = -1
+	lineNumber := 0
+	for scanner.Scan() {
+		lineNumber++
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+
+		if line[0] == '>' {
+			if len(bytes.TrimSpace(line[1:])) == 0 {
+				return nil, invalid("empty header at line %d", lineNumber)
+			}
+			if recordBases == 0 {
+				return nil, invalid("record before line %d has no sequence",
+					lineNumber)
+			}
+			validation.Records++
+			recordBases = 0
+			continue
+		}
+
+		for _, base := range line {
+			if !isNucleotide(base) && base != '-' && base != '*' {
+				return nil, invalid("unexpected base %q at line %d", base,
+					lineNumber)
+			}
+		}
+		recordBases += int64(len(line))
+		validation.Bases += int64(len(line))
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %s: %v", ErrCorruptedInput,
+			validation.File, err)
+	}
+	if recordBases == 0 {
+		return nil, invalid("the last record has no sequence")
+	}
+	return validation, nil
+}
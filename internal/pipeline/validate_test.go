@@ -0,0 +1,209 @@
+package pipeline
+
+import (
+	"bytes"
+	"compress/gzip"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func writeValidationFile(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	assert.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	return path
+}
+
+func gzipContent(t *testing.T, content string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	writer := gzip.NewWriter(&buf)
+	_, err := writer.Write([]byte(content))
+	assert.NoError(t, err)
+	assert.NoError(t, writer.Close())
+	return buf.Bytes()
+}
+
+func TestValidateFastq(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		path := writeValidationFile(t, "reads_R1.fastq",
+			"@read1 1:N:0:1\nACGTN\n+\nIIIII\n@read2/1\nACG\n+read2\nII#\n")
+
+		validation, err := ValidateFastq(path)
+
+		assert.NoError(t, err)
+		assert.Equal(t, &FileValidation{
+			File: "reads_R1.fastq", Format: FormatFastq, Records: 2, Bases: 8,
+		}, validation)
+	})
+
+	t.Run("Success - Gzipped", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "reads_R1.fastq.gz")
+		assert.NoError(t, os.WriteFile(path,
+			gzipContent(t, "@read1\nACGT\n+\nIIII\n"), 0644))
+
+		validation, err := ValidateFastq(path)
+
+		assert.NoError(t, err)
+		assert.True(t, validation.Compressed)
+		assert.Equal(t, int64(1), validation.Records)
+	})
+
+	t.Run("Error - FASTA Instead Of FASTQ", func(t *testing.T) {
+		path := writeValidationFile(t, "reads_R1.fastq", ">contig\nACGT\n")
+
+		_, err := ValidateFastq(path)
+
+		assert.ErrorIs(t, err, ErrInvalidFormat)
+		assert.ErrorContains(t, err, "is a FASTA file")
+	})
+
+	t.Run("Error - Unsupported Archive", func(t *testing.T) {
+		path := writeValidationFile(t, "reads_R1.fastq.bz2", "BZh91AY&SY")
+
+		_, err := ValidateFastq(path)
+
+		assert.ErrorIs(t, err, ErrInvalidFormat)
+		assert.ErrorContains(t, err, "bzip2")
+	})
+
+	t.Run("Error - Truncated Gzip", func(t *testing.T) {
+		content := gzipContent(t, "@read1\nACGT\n+\nIIII\n@read2\nACGT\n"+
+			"+\nIIII\n")
+		path := filepath.Join(t.TempDir(), "reads_R1.fastq.gz")
+		assert.NoError(t, os.WriteFile(path, content[:len(content)-6], 0644))
+
+		_, err := ValidateFastq(path)
+
+		assert.ErrorIs(t, err, ErrCorruptedInput)
+	})
+
+	t.Run("Error - Truncated Record", func(t *testing.T) {
+		path := writeValidationFile(t, "reads_R1.fastq",
+			"@read1\nACGT\n+\nIIII\n@read2\nACGT\n")
+
+		_, err := ValidateFastq(path)
+
+		assert.ErrorIs(t, err, ErrCorruptedInput)
+		assert.ErrorContains(t, err, "line 6")
+	})
+
+	t.Run("Error - Invalid Base", func(t *testing.T) {
+		path := writeValidationFile(t, "reads_R1.fastq",
+			"@read1\nACXT\n+\nIIII\n")
+
+		_, err := ValidateFastq(path)
+
+		assert.ErrorIs(t, err, ErrInvalidFormat)
+		assert.ErrorContains(t, err, "'X'")
+	})
+
+	t.Run("Error - Interleaved", func(t *testing.T) {
+		path := writeValidationFile(t, "reads.fastq",
+			"@read1/1\nACGT\n+\nIIII\n@read1/2\nACGT\n+\nIIII\n")
+
+		_, err := ValidateFastq(path)
+
+		assert.ErrorIs(t, err, ErrInvalidFormat)
+		assert.ErrorContains(t, err, "interleaved")
+	})
+
+	t.Run("Error - Empty File", func(t *testing.T) {
+		path := writeValidationFile(t, "reads_R1.fastq", "")
+
+		_, err := ValidateFastq(path)
+
+		assert.ErrorIs(t, err, ErrEmptyReads)
+	})
+}
+
+func TestValidatePairedFastq(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		read1 := writeValidationFile(t, "reads_R1.fastq",
+			"@read1/1\nACGT\n+\nIIII\n@read2 1:N:0\nAC\n+\nII\n")
+		read2 := writeValidationFile(t, "reads_R2.fastq",
+			"@read1/2\nTTTT\n+\nIIII\n@read2 2:N:0\nGGG\n+\nIII\n")
+
+		validation1, validation2, err := ValidatePairedFastq(read1, read2)
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(2), validation1.Records)
+		assert.Equal(t, int64(7), validation2.Bases)
+	})
+
+	t.Run("Error - Read Count Mismatch", func(t *testing.T) {
+		read1 := writeValidationFile(t, "reads_R1.fastq",
+			"@read1\nACGT\n+\nIIII\n@read2\nACGT\n+\nIIII\n")
+		read2 := writeValidationFile(t, "reads_R2.fastq",
+			"@read1\nACGT\n+\nIIII\n")
+
+		_, _, err := ValidatePairedFastq(read1, read2)
+
+		assert.ErrorIs(t, err, ErrUnpairedReads)
+		assert.ErrorContains(t, err, "reads_R2.fastq ends after 1 reads")
+	})
+
+	t.Run("Error - Read Name Mismatch", func(t *testing.T) {
+		read1 := writeValidationFile(t, "reads_R1.fastq",
+			"@read1\nACGT\n+\nIIII\n")
+		read2 := writeValidationFile(t, "reads_R2.fastq",
+			"@other\nACGT\n+\nIIII\n")
+
+		_, _, err := ValidatePairedFastq(read1, read2)
+
+		assert.ErrorIs(t, err, ErrUnpairedReads)
+		assert.ErrorContains(t, err, "read 1 is read1")
+	})
+}
+
+func TestValidateFasta(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		path := writeValidationFile(t, "assembly.fasta",
+			">contig_1 length=8\nACGT\nNNRY\n\n>contig_2\nacgt-\n")
+
+		validation, err := ValidateFasta(path)
+
+		assert.NoError(t, err)
+		assert.Equal(t, &FileValidation{
+			File: "assembly.fasta", Format: FormatFasta, Records: 2, Bases: 13,
+		}, validation)
+	})
+
+	t.Run("Error - FASTQ Instead Of FASTA", func(t *testing.T) {
+		path := writeValidationFile(t, "assembly.fasta",
+			"@read1\nACGT\n+\nIIII\n")
+
+		_, err := ValidateFasta(path)
+
+		assert.ErrorIs(t, err, ErrInvalidFormat)
+	})
+
+	t.Run("Error - Empty Header", func(t *testing.T) {
+		path := writeValidationFile(t, "assembly.fasta", ">\nACGT\n")
+
+		_, err := ValidateFasta(path)
+
+		assert.ErrorIs(t, err, ErrInvalidFormat)
+		assert.ErrorContains(t, err, "empty header")
+	})
+
+	t.Run("Error - Protein Sequence", func(t *testing.T) {
+		path := writeValidationFile(t, "assembly.fasta", ">p\nMKLLPE\n")
+
+		_, err := ValidateFasta(path)
+
+		assert.ErrorIs(t, err, ErrInvalidFormat)
+	})
+
+	t.Run("Error - Record Without Sequence", func(t *testing.T) {
+		path := writeValidationFile(t, "assembly.fasta",
+			">contig_1\n>contig_2\nACGT\n")
+
+		_, err := ValidateFasta(path)
+
+		assert.ErrorContains(t, err, "no sequence")
+	})
+}
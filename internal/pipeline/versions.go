@@ -25,6 +25,7 @@ var tools = []toolSpec{
 	{"Kraken2", []string{"kraken2", "--version"}, `version ([\d.]+)`},
 	{"FastANI", []string{"fastANI", "--version"}, `([\d.]+)`},
 	{"Abricate", []string{"abricate", "--version"}, `([\d.]+)`},
+	{"AMRFinderPlus", []string{"amrfinder", "--version"}, `([\d.]+)`},
 	{"MLST", []string{"mlst", "--version"}, `([\d.]+)`},
 	{"Blast", []string{"blastx", "-version"}, `blastx: ([\d.]+)`},
 }
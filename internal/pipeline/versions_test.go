@@ -19,6 +19,7 @@ func TestGetBioinfoProgramVersions(t *testing.T) {
 		"kraken2":   "kraken2 version 1.2.3",
 		"fastANI":   "fastANI 1.2.3",
 		"abricate":  "abricate 1.2.3",
+		"amrfinder": "1.2.3",
 		"mlst":      "mlst 1.2.3",
 		"blastx":    "blastx: 1.2.3",
 	}
@@ -35,14 +36,14 @@ func TestGetBioinfoProgramVersions(t *testing.T) {
 			context.Background(), cmd,
 		)
 
-		assert.Len(t, result, 9)
+		assert.Len(t, result, 10)
 
 		expected := map[string]string{
 			"FastQC": "1.2.3", "Unicycler": "1.2.3",
 			"Prokka": "1.2.3", "CheckM": "1.2.3",
 			"Kraken2": "1.2.3", "FastANI": "1.2.3",
-			"Abricate": "1.2.3", "MLST": "1.2.3",
-			"Blast": "1.2.3",
+			"Abricate": "1.2.3", "AMRFinderPlus": "1.2.3",
+			"MLST": "1.2.3", "Blast": "1.2.3",
 		}
 
 		for _, tv := range result {
@@ -102,7 +103,7 @@ func TestGetBioinfoProgramVersions(t *testing.T) {
 			context.Background(), cmd,
 		)
 
-		assert.Len(t, result, 9)
+		assert.Len(t, result, 10)
 
 		for _, tv := range result {
 			if failingNames[tv.Name] {
@@ -125,7 +126,7 @@ func TestGetBioinfoProgramVersions(t *testing.T) {
 			context.Background(), cmd,
 		)
 
-		assert.Len(t, result, 9)
+		assert.Len(t, result, 10)
 
 		for _, tv := range result {
 			assert.Equal(t, "unknown", tv.Version)
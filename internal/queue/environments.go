@@ -0,0 +1,90 @@
+package queue
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/CABGenOrg/cabgen_backend/internal/models"
+	"github.com/redis/go-redis/v9"
+)
+
+// WorkerEnvironmentsKeyPrefix prefixes the key holding the environment of
+// each running analysis worker, e.g. cabgen:worker-environments:host:42.
+const WorkerEnvironmentsKeyPrefix = "cabgen:worker-environments:"
+
+// WorkerRegistry stores the environments of the analysis workers in Redis so
+// the API can list them. Every environment expires after TTL unless the
+// worker publishes it again, so a worker that dies without unregistering
+// drops out of the list. TTL is not used by readers.
+type WorkerRegistry struct {
+	Client *redis.Client
+	TTL    time.Duration
+}
+
+func NewWorkerRegistry(client *redis.Client,
+	ttl time.Duration) *WorkerRegistry {
+	return &WorkerRegistry{Client: client, TTL: ttl}
+}
+
+// PublishWorkerEnvironment registers the worker or refreshes its entry.
+func (r *WorkerRegistry) PublishWorkerEnvironment(ctx context.Context,
+	environment models.WorkerEnvironment) error {
+	payload, err := json.Marshal(environment)
+	if err != nil {
+		return fmt.Errorf("failed to marshal worker environment: %w", err)
+	}
+
+	return r.Client.Set(ctx, WorkerEnvironmentsKeyPrefix+environment.ID,
+		payload, r.TTL).Err()
+}
+
+// RemoveWorkerEnvironment unregisters a worker that is shutting down.
+func (r *WorkerRegistry) RemoveWorkerEnvironment(ctx context.Context,
+	id string) error {
+	return r.Client.Del(ctx, WorkerEnvironmentsKeyPrefix+id).Err()
+}
+
+// GetWorkerEnvironments returns the registered workers sorted by ID.
+// Entries that expired meanwhile or cannot be decoded are skipped.
+func (r *WorkerRegistry) GetWorkerEnvironments(ctx context.Context) (
+	[]models.WorkerEnvironment, error) {
+	var keys []string
+	iter := r.Client.Scan(ctx, 0, WorkerEnvironmentsKeyPrefix+"*", 0).
+		Iterator()
+	for iter.Next(ctx) {
+		keys = append(keys, iter.Val())
+	}
+	if err := iter.Err(); err != nil {
+		return nil, err
+	}
+
+	environments := make([]models.WorkerEnvironment, 0, len(keys))
+	if len(keys) == 0 {
+		return environments, nil
+	}
+
+	payloads, err := r.Client.MGet(ctx, keys...).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, payload := range payloads {
+		raw, ok := payload.(string)
+		if !ok {
+			continue
+		}
+		var environment models.WorkerEnvironment
+		if err := json.Unmarshal([]byte(raw), &environment); err != nil {
+			continue
+		}
+		environments = append(environments, environment)
+	}
+
+	sort.Slice(environments, func(i, j int) bool {
+		return environments[i].ID < environments[j].ID
+	})
+	return environments, nil
+}
@@ -0,0 +1,84 @@
+package queue_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/CABGenOrg/cabgen_backend/internal/models"
+	"github.com/CABGenOrg/cabgen_backend/internal/pipeline"
+	"github.com/CABGenOrg/cabgen_backend/internal/queue"
+	"github.com/alicebob/miniredis/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWorkerRegistry(t *testing.T) {
+	ctx := context.Background()
+	mr, err := miniredis.Run()
+	require.NoError(t, err)
+	defer mr.Close()
+
+	client, err := queue.NewRedisClient(mr.Addr())
+	require.NoError(t, err)
+	registry := queue.NewWorkerRegistry(client, time.Minute)
+
+	t.Run("Success - Publish And List", func(t *testing.T) {
+		for _, id := range []string{"worker-b:2", "worker-a:1"} {
+			require.NoError(t, registry.PublishWorkerEnvironment(ctx,
+				models.WorkerEnvironment{
+					ID: id, Status: models.WorkerStatusReady,
+					Tools: []pipeline.ToolVersion{
+						{Name: "Prokka", Version: "1.14.6"},
+					},
+				}))
+		}
+		mr.Set(queue.WorkerEnvironmentsKeyPrefix+"broken", "{")
+
+		environments, err := registry.GetWorkerEnvironments(ctx)
+
+		assert.NoError(t, err)
+		if assert.Len(t, environments, 2) {
+			assert.Equal(t, "worker-a:1", environments[0].ID)
+			assert.Equal(t, "1.14.6", environments[1].Tools[0].Version)
+		}
+	})
+
+	t.Run("Success - Remove", func(t *testing.T) {
+		require.NoError(t, registry.RemoveWorkerEnvironment(ctx,
+			"worker-a:1"))
+
+		environments, err := registry.GetWorkerEnvironments(ctx)
+
+		assert.NoError(t, err)
+		assert.Len(t, environments, 1)
+	})
+
+	t.Run("Success - Expired Worker Dropped", func(t *testing.T) {
+		require.NoError(t, registry.PublishWorkerEnvironment(ctx,
+			models.WorkerEnvironment{ID: "worker-c:3"}))
+		mr.FastForward(30 * time.Second)
+		// Refreshing keeps a live worker registered.
+		require.NoError(t, registry.PublishWorkerEnvironment(ctx,
+			models.WorkerEnvironment{ID: "worker-b:2"}))
+		mr.FastForward(45 * time.Second)
+
+		environments, err := registry.GetWorkerEnvironments(ctx)
+
+		assert.NoError(t, err)
+		if assert.Len(t, environments, 1) {
+			assert.Equal(t, "worker-b:2", environments[0].ID)
+		}
+	})
+
+	t.Run("Error - Closed Client", func(t *testing.T) {
+		closed, err := queue.NewRedisClient(mr.Addr())
+		require.NoError(t, err)
+		closed.Close()
+
+		_, err = queue.NewWorkerRegistry(closed, time.Minute).
+			GetWorkerEnvironments(ctx)
+
+		assert.Error(t, err)
+	})
+}
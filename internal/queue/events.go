@@ -0,0 +1,98 @@
+package queue
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/CABGenOrg/cabgen_backend/internal/models"
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+)
+
+const analysisEventsChannelPrefix = "cabgen:analysis-events:"
+
+func NewRedisClient(redisAddr string) (*redis.Client, error) {
+	if redisAddr == "" {
+		return nil, errors.New("Redis address is empty")
+	}
+
+	client := redis.NewClient(&redis.Options{
+		Addr: redisAddr,
+		DB:   0,
+	})
+	if err := client.Ping(context.Background()).Err(); err != nil {
+		return nil, err
+	}
+
+	return client, nil
+}
+
+// AnalysisEventsChannel is the pub/sub channel carrying the events of every
+// analysis owned by the user.
+func AnalysisEventsChannel(userID uuid.UUID) string {
+	return analysisEventsChannelPrefix + userID.String()
+}
+
+// AnalysisEventBus publishes analysis events through Redis pub/sub so the API
+// can stream the transitions made by the analysis worker.
+type AnalysisEventBus struct {
+	Client *redis.Client
+}
+
+func NewAnalysisEventBus(client *redis.Client) *AnalysisEventBus {
+	return &AnalysisEventBus{Client: client}
+}
+
+func (b *AnalysisEventBus) PublishAnalysisEvent(ctx context.Context,
+	event models.AnalysisEvent) error {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to marshal analysis event: %w", err)
+	}
+
+	return b.Client.Publish(ctx, AnalysisEventsChannel(event.UserID),
+		payload).Err()
+}
+
+// SubscribeAnalysisEvents returns the events of the user's analyses until ctx
+// is done or the returned close func is called.
+func (b *AnalysisEventBus) SubscribeAnalysisEvents(ctx context.Context,
+	userID uuid.UUID) (<-chan models.AnalysisEvent, func() error, error) {
+	pubsub := b.Client.Subscribe(ctx, AnalysisEventsChannel(userID))
+	if _, err := pubsub.Receive(ctx); err != nil {
+		_ = pubsub.Close()
+		return nil, nil, fmt.Errorf("failed to subscribe: %w", err)
+	}
+
+	events := make(chan models.AnalysisEvent)
+	go func() {
+		defer close(events)
+		messages := pubsub.Channel()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case msg, ok := <-messages:
+				if !ok {
+					return
+				}
+
+				var event models.AnalysisEvent
+				if err := json.Unmarshal([]byte(msg.Payload),
+					&event); err != nil {
+					continue
+				}
+
+				select {
+				case events <- event:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+
+	return events, pubsub.Close, nil
+}
@@ -0,0 +1,93 @@
+package queue_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/CABGenOrg/cabgen_backend/internal/models"
+	"github.com/CABGenOrg/cabgen_backend/internal/queue"
+	"github.com/alicebob/miniredis/v2"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewRedisClient(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		mr, err := miniredis.Run()
+		require.NoError(t, err)
+		defer mr.Close()
+
+		client, err := queue.NewRedisClient(mr.Addr())
+
+		assert.NoError(t, err)
+		assert.NotNil(t, client)
+	})
+
+	t.Run("Error - URL", func(t *testing.T) {
+		client, err := queue.NewRedisClient("")
+
+		assert.Error(t, err)
+		assert.ErrorContains(t, err, "Redis address is empty")
+		assert.Nil(t, client)
+	})
+
+	t.Run("Error - Ping", func(t *testing.T) {
+		client, err := queue.NewRedisClient("localhost:1")
+
+		assert.Error(t, err)
+		assert.Nil(t, client)
+	})
+}
+
+func TestAnalysisEventBus(t *testing.T) {
+	mr, err := miniredis.Run()
+	require.NoError(t, err)
+	defer mr.Close()
+
+	client, err := queue.NewRedisClient(mr.Addr())
+	require.NoError(t, err)
+	bus := queue.NewAnalysisEventBus(client)
+
+	t.Run("Success - Delivers User Events", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		userID := uuid.New()
+
+		events, closeSub, err := bus.SubscribeAnalysisEvents(ctx, userID)
+		require.NoError(t, err)
+		defer closeSub()
+
+		other := models.AnalysisEvent{AnalysisID: uuid.New(),
+			UserID: uuid.New(), Status: models.AnalysisStatusRunning}
+		event := models.AnalysisEvent{AnalysisID: uuid.New(), UserID: userID,
+			Status: models.AnalysisStatusRunning, Step: models.StepProkka}
+		assert.NoError(t, bus.PublishAnalysisEvent(ctx, other))
+		assert.NoError(t, bus.PublishAnalysisEvent(ctx, event))
+
+		select {
+		case got := <-events:
+			assert.Equal(t, event.AnalysisID, got.AnalysisID)
+			assert.Equal(t, models.StepProkka, got.Step)
+		case <-time.After(5 * time.Second):
+			t.Fatal("event not delivered")
+		}
+	})
+
+	t.Run("Success - Closes On Context Done", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+
+		events, closeSub, err := bus.SubscribeAnalysisEvents(ctx, uuid.New())
+		require.NoError(t, err)
+		defer closeSub()
+		cancel()
+
+		select {
+		case _, ok := <-events:
+			assert.False(t, ok)
+		case <-time.After(5 * time.Second):
+			t.Fatal("events channel not closed")
+		}
+	})
+}
@@ -0,0 +1,72 @@
+package queue
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/CABGenOrg/cabgen_backend/internal/models"
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+)
+
+// AnalysisHeartbeatsKey is the hash holding the heartbeat of every running
+// analysis, keyed by analysis ID.
+const AnalysisHeartbeatsKey = "cabgen:analysis-heartbeats"
+
+// AnalysisHeartbeats stores the heartbeats of the running analyses in Redis.
+// WorkerID identifies the worker writing them and may be empty for readers.
+type AnalysisHeartbeats struct {
+	Client   *redis.Client
+	WorkerID string
+}
+
+func NewAnalysisHeartbeats(client *redis.Client,
+	workerID string) *AnalysisHeartbeats {
+	return &AnalysisHeartbeats{Client: client, WorkerID: workerID}
+}
+
+// Beat records that the worker is still running step of the analysis.
+func (h *AnalysisHeartbeats) Beat(ctx context.Context, analysisID uuid.UUID,
+	step models.AnalysisStep) error {
+	payload, err := json.Marshal(models.AnalysisHeartbeat{
+		AnalysisID: analysisID,
+		WorkerID:   h.WorkerID,
+		Step:       step,
+		LastSeen:   time.Now().UTC(),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal analysis heartbeat: %w", err)
+	}
+
+	return h.Client.HSet(ctx, AnalysisHeartbeatsKey, analysisID.String(),
+		payload).Err()
+}
+
+func (h *AnalysisHeartbeats) RemoveHeartbeat(ctx context.Context,
+	analysisID uuid.UUID) error {
+	return h.Client.HDel(ctx, AnalysisHeartbeatsKey,
+		analysisID.String()).Err()
+}
+
+// GetHeartbeats returns the heartbeats by analysis ID. Entries that cannot
+// be decoded are skipped.
+func (h *AnalysisHeartbeats) GetHeartbeats(ctx context.Context) (
+	map[uuid.UUID]models.AnalysisHeartbeat, error) {
+	entries, err := h.Client.HGetAll(ctx, AnalysisHeartbeatsKey).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	heartbeats := make(map[uuid.UUID]models.AnalysisHeartbeat, len(entries))
+	for _, payload := range entries {
+		var heartbeat models.AnalysisHeartbeat
+		if err := json.Unmarshal([]byte(payload), &heartbeat); err != nil {
+			continue
+		}
+		heartbeats[heartbeat.AnalysisID] = heartbeat
+	}
+
+	return heartbeats, nil
+}
@@ -0,0 +1,62 @@
+package queue_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/CABGenOrg/cabgen_backend/internal/models"
+	"github.com/CABGenOrg/cabgen_backend/internal/queue"
+	"github.com/alicebob/miniredis/v2"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAnalysisHeartbeats(t *testing.T) {
+	ctx := context.Background()
+	mr, err := miniredis.Run()
+	require.NoError(t, err)
+	defer mr.Close()
+
+	client, err := queue.NewRedisClient(mr.Addr())
+	require.NoError(t, err)
+	heartbeats := queue.NewAnalysisHeartbeats(client, "worker-a:1")
+	analysisID := uuid.New()
+
+	t.Run("Success - Beat And List", func(t *testing.T) {
+		before := time.Now().UTC()
+		require.NoError(t, heartbeats.Beat(ctx, analysisID,
+			models.StepProkka))
+		mr.HSet(queue.AnalysisHeartbeatsKey, "broken", "{")
+
+		got, err := heartbeats.GetHeartbeats(ctx)
+
+		assert.NoError(t, err)
+		if assert.Len(t, got, 1) {
+			heartbeat := got[analysisID]
+			assert.Equal(t, "worker-a:1", heartbeat.WorkerID)
+			assert.Equal(t, models.StepProkka, heartbeat.Step)
+			assert.False(t, heartbeat.LastSeen.Before(before))
+		}
+	})
+
+	t.Run("Success - Remove", func(t *testing.T) {
+		require.NoError(t, heartbeats.RemoveHeartbeat(ctx, analysisID))
+
+		got, err := heartbeats.GetHeartbeats(ctx)
+
+		assert.NoError(t, err)
+		assert.Empty(t, got)
+	})
+
+	t.Run("Error - Closed Client", func(t *testing.T) {
+		closed, err := queue.NewRedisClient(mr.Addr())
+		require.NoError(t, err)
+		closed.Close()
+
+		_, err = queue.NewAnalysisHeartbeats(closed, "").GetHeartbeats(ctx)
+
+		assert.Error(t, err)
+	})
+}
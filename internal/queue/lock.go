@@ -0,0 +1,47 @@
+package queue
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+)
+
+// AnalysisReaperLockKey keeps the reaper, which every API replica schedules,
+// to one replica at a time.
+const AnalysisReaperLockKey = "cabgen:analysis-reaper-lock"
+
+// unlockScript deletes the lock only while it holds the owner's token, so a
+// lock that expired and was taken by another replica is left alone.
+var unlockScript = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("DEL", KEYS[1])
+end
+return 0`)
+
+// Lock is a Redis lock that expires after TTL, so a replica that dies while
+// holding it does not block the others.
+type Lock struct {
+	Client *redis.Client
+	Key    string
+	TTL    time.Duration
+}
+
+func NewLock(client *redis.Client, key string, ttl time.Duration) *Lock {
+	return &Lock{Client: client, Key: key, TTL: ttl}
+}
+
+// TryLock takes the lock when it is free. The returned func releases it.
+func (l *Lock) TryLock(ctx context.Context) (
+	func(context.Context) error, bool, error) {
+	token := uuid.NewString()
+	locked, err := l.Client.SetNX(ctx, l.Key, token, l.TTL).Result()
+	if err != nil || !locked {
+		return nil, false, err
+	}
+
+	return func(ctx context.Context) error {
+		return unlockScript.Run(ctx, l.Client, []string{l.Key}, token).Err()
+	}, true, nil
+}
@@ -0,0 +1,64 @@
+package queue_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/CABGenOrg/cabgen_backend/internal/queue"
+	"github.com/alicebob/miniredis/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLock(t *testing.T) {
+	ctx := context.Background()
+	mr, err := miniredis.Run()
+	require.NoError(t, err)
+	defer mr.Close()
+
+	client, err := queue.NewRedisClient(mr.Addr())
+	require.NoError(t, err)
+	lock := queue.NewLock(client, queue.AnalysisReaperLockKey, time.Minute)
+
+	t.Run("Success - Held By One Owner", func(t *testing.T) {
+		unlock, locked, err := lock.TryLock(ctx)
+		require.NoError(t, err)
+		require.True(t, locked)
+		assert.Equal(t, time.Minute, mr.TTL(queue.AnalysisReaperLockKey))
+
+		_, locked, err = lock.TryLock(ctx)
+		assert.NoError(t, err)
+		assert.False(t, locked)
+
+		require.NoError(t, unlock(ctx))
+		_, locked, err = lock.TryLock(ctx)
+		assert.NoError(t, err)
+		assert.True(t, locked)
+		mr.Del(queue.AnalysisReaperLockKey)
+	})
+
+	t.Run("Success - Expired Lock Kept For New Owner", func(t *testing.T) {
+		unlock, locked, err := lock.TryLock(ctx)
+		require.NoError(t, err)
+		require.True(t, locked)
+
+		mr.FastForward(2 * time.Minute)
+		_, locked, err = lock.TryLock(ctx)
+		require.NoError(t, err)
+		require.True(t, locked)
+
+		require.NoError(t, unlock(ctx))
+		assert.True(t, mr.Exists(queue.AnalysisReaperLockKey))
+	})
+
+	t.Run("Error - Redis Down", func(t *testing.T) {
+		mr.SetError("connection refused")
+		defer mr.SetError("")
+
+		_, locked, err := lock.TryLock(ctx)
+
+		assert.Error(t, err)
+		assert.False(t, locked)
+	})
+}
@@ -25,6 +25,7 @@ const (
 
 type AnalysisProcessPayload struct {
 	AnalysisID uuid.UUID `json:"analysis_id"`
+	Resume     bool      `json:"resume,omitempty"`
 }
 
 type WelcomeEmailPayload struct {
@@ -68,7 +69,23 @@ type EmailUpdateConfirmationPayload struct {
 
 func NewAnalysisProcessTask(analysisID uuid.UUID) (
 	*asynq.Task, error) {
-	payload := AnalysisProcessPayload{AnalysisID: analysisID}
+	return newAnalysisProcessTask(AnalysisProcessPayload{
+		AnalysisID: analysisID,
+	})
+}
+
+// NewAnalysisResumeTask enqueues the same process task, but the worker skips
+// the steps that already have valid checkpoints.
+func NewAnalysisResumeTask(analysisID uuid.UUID) (
+	*asynq.Task, error) {
+	return newAnalysisProcessTask(AnalysisProcessPayload{
+		AnalysisID: analysisID,
+		Resume:     true,
+	})
+}
+
+func newAnalysisProcessTask(payload AnalysisProcessPayload) (
+	*asynq.Task, error) {
 	payloadBytes, err := json.Marshal(payload)
 	if err != nil {
 		return nil, err
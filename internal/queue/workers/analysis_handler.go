@@ -3,6 +3,7 @@ package workers
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 
 	"github.com/CABGenOrg/cabgen_backend/internal/logging"
@@ -39,9 +40,34 @@ func (h *AnalysisTaskHandler) ProcessTask(ctx context.Context,
 			"AnalysisTaskHandler", "ProcessTask", "TASK_STARTED",
 			zap.String("task_type", t.Type()),
 			zap.String("analysis_id", p.AnalysisID.String()),
+			zap.Bool("resume", p.Resume),
 		)...)
 
-		if err := h.AnalysisRunnerService.Run(ctx, p.AnalysisID); err != nil {
+		run := h.AnalysisRunnerService.Run
+		if p.Resume {
+			run = h.AnalysisRunnerService.Resume
+		}
+
+		err := run(ctx, p.AnalysisID)
+		// A cancelled analysis is not retried.
+		if errors.Is(err, services.ErrAnalysisCancelled) {
+			h.Logger.Info("Task cancelled", logging.ServiceInfoLogging(
+				"AnalysisTaskHandler", "ProcessTask", "TASK_CANCELLED",
+				zap.String("task_type", t.Type()),
+				zap.String("analysis_id", p.AnalysisID.String()),
+			)...)
+			return nil
+		}
+		// Neither is one that was cancelled or reaped while it ran.
+		if errors.Is(err, services.ErrAnalysisNotRunning) {
+			h.Logger.Info("Task dropped", logging.ServiceInfoLogging(
+				"AnalysisTaskHandler", "ProcessTask", "TASK_DROPPED",
+				zap.String("task_type", t.Type()),
+				zap.String("analysis_id", p.AnalysisID.String()),
+			)...)
+			return nil
+		}
+		if err != nil {
 			h.Logger.Error("Task failed", logging.ServiceLogging(
 				"AnalysisTaskHandler", "ProcessTask", logging.AnalysisRunError,
 				err)...)
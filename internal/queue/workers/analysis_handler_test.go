@@ -8,6 +8,7 @@ import (
 
 	"github.com/CABGenOrg/cabgen_backend/internal/queue/tasks"
 	"github.com/CABGenOrg/cabgen_backend/internal/queue/workers"
+	"github.com/CABGenOrg/cabgen_backend/internal/services"
 	"github.com/CABGenOrg/cabgen_backend/internal/testutils/mocks"
 	"github.com/google/uuid"
 	"github.com/hibiken/asynq"
@@ -37,6 +38,61 @@ func TestAnalysisTaskHandlerProcessTask(t *testing.T) {
 		assert.NoError(t, err)
 	})
 
+	t.Run("Success - Resume", func(t *testing.T) {
+		analysisID := uuid.New()
+		mockService := &mocks.MockAnalysisRunnerService{
+			RunFunc: func(ctx context.Context,
+				receivedID uuid.UUID) error {
+				t.Fatal("Run must not be called for a resume task")
+				return nil
+			},
+			ResumeFunc: func(ctx context.Context,
+				receivedID uuid.UUID) error {
+				assert.Equal(t, analysisID, receivedID)
+				return nil
+			},
+		}
+		handler := workers.NewAnalysisTaskHandler(mockService, zap.NewNop())
+
+		task, err := tasks.NewAnalysisResumeTask(analysisID)
+		assert.NoError(t, err)
+
+		err = handler.ProcessTask(ctx, task)
+		assert.NoError(t, err)
+	})
+
+	t.Run("Success - Cancelled", func(t *testing.T) {
+		mockService := &mocks.MockAnalysisRunnerService{
+			RunFunc: func(ctx context.Context,
+				receivedID uuid.UUID) error {
+				return services.ErrAnalysisCancelled
+			},
+		}
+		handler := workers.NewAnalysisTaskHandler(mockService, zap.NewNop())
+
+		task, err := tasks.NewAnalysisProcessTask(uuid.New())
+		assert.NoError(t, err)
+
+		err = handler.ProcessTask(ctx, task)
+		assert.NoError(t, err)
+	})
+
+	t.Run("Success - Not Running", func(t *testing.T) {
+		mockService := &mocks.MockAnalysisRunnerService{
+			RunFunc: func(ctx context.Context,
+				receivedID uuid.UUID) error {
+				return services.ErrAnalysisNotRunning
+			},
+		}
+		handler := workers.NewAnalysisTaskHandler(mockService, zap.NewNop())
+
+		task, err := tasks.NewAnalysisProcessTask(uuid.New())
+		assert.NoError(t, err)
+
+		err = handler.ProcessTask(ctx, task)
+		assert.NoError(t, err)
+	})
+
 	t.Run("Error - JSON Unmarshal", func(t *testing.T) {
 		mockService := &mocks.MockAnalysisRunnerService{}
 		handler := workers.NewAnalysisTaskHandler(mockService, zap.NewNop())
@@ -2,10 +2,12 @@ package repositories
 
 import (
 	"context"
+	"sort"
 
 	"github.com/CABGenOrg/cabgen_backend/internal/models"
 	"github.com/google/uuid"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type AnalysisRepository interface {
@@ -15,10 +17,24 @@ type AnalysisRepository interface {
 		userID uuid.UUID) ([]models.Analysis, error)
 	GetAnalysisByID(ctx context.Context, analysisID uuid.UUID) (
 		*models.Analysis, error)
+	GetAnalysesByStatus(ctx context.Context, status models.AnalysisStatus) (
+		[]models.Analysis, error)
 	CreateAnalysis(ctx context.Context, analysis *models.Analysis) error
 	UpdateAnalysis(ctx context.Context, analysis *models.Analysis) error
+	UpdateAnalysisIfStatus(ctx context.Context, analysisID uuid.UUID,
+		statuses []models.AnalysisStatus, fields map[string]any) (bool, error)
+	SaveAnalysisIfStatus(ctx context.Context, analysis *models.Analysis,
+		statuses []models.AnalysisStatus) (bool, error)
 	UpdateSample(ctx context.Context, sample *models.Sample) error
 	DeleteAnalysis(ctx context.Context, analysis *models.Analysis) error
+	CreateAnalysisLog(ctx context.Context, log *models.AnalysisLog) error
+	GetAnalysisLogs(ctx context.Context, analysisID uuid.UUID,
+		step models.AnalysisStep) ([]models.AnalysisLog, error)
+	ReplaceAnalysisDatabases(ctx context.Context, analysisID uuid.UUID,
+		databases []models.AnalysisDatabase) error
+	GetOutdatedAnalysisDatabases(ctx context.Context,
+		current map[string][]string) ([]models.AnalysisDatabase, error)
+	GetActiveQCRules(ctx context.Context) ([]models.QCRule, error)
 }
 
 type analysisRepo struct {
@@ -75,6 +91,10 @@ func (r *analysisRepo) GetAnalyses(ctx context.Context, userID uuid.UUID,
 		}
 	}
 
+	if filter.QCVerdict != "" {
+		query = query.Where("qc_verdict = ?", filter.QCVerdict)
+	}
+
 	if err := query.Find(&analyses).Error; err != nil {
 		return nil, err
 	}
@@ -104,8 +124,8 @@ func (r *analysisRepo) GetAnalysesByIDs(ctx context.Context,
 func (r *analysisRepo) GetAnalysisByID(ctx context.Context,
 	analysisID uuid.UUID) (*models.Analysis, error) {
 	var analysis models.Analysis
-	if err := r.DB.WithContext(ctx).Preload("Sample").Preload("User").
-		Where("id = ?", analysisID).First(
+	if err := r.DB.WithContext(ctx).Preload("Sample.Microorganism").
+		Preload("User").Where("id = ?", analysisID).First(
 		&analysis).Error; err != nil {
 		return nil, err
 	}
@@ -113,6 +133,17 @@ func (r *analysisRepo) GetAnalysisByID(ctx context.Context,
 	return &analysis, nil
 }
 
+func (r *analysisRepo) GetAnalysesByStatus(ctx context.Context,
+	status models.AnalysisStatus) ([]models.Analysis, error) {
+	var analyses []models.Analysis
+	if err := r.DB.WithContext(ctx).Where("status = ?", status).
+		Find(&analyses).Error; err != nil {
+		return nil, err
+	}
+
+	return analyses, nil
+}
+
 func (r *analysisRepo) CreateAnalysis(ctx context.Context,
 	analysis *models.Analysis) error {
 	return r.DB.WithContext(ctx).Create(analysis).Error
@@ -123,6 +154,36 @@ func (r *analysisRepo) UpdateAnalysis(ctx context.Context,
 	return r.DB.WithContext(ctx).Save(analysis).Error
 }
 
+// UpdateAnalysisIfStatus writes only the given columns, and only while the
+// stored status is one of statuses. It reports whether a row was updated.
+func (r *analysisRepo) UpdateAnalysisIfStatus(ctx context.Context,
+	analysisID uuid.UUID, statuses []models.AnalysisStatus,
+	fields map[string]any) (bool, error) {
+	result := r.DB.WithContext(ctx).Model(&models.Analysis{}).
+		Where("id = ? AND status IN ?", analysisID, statuses).
+		Updates(fields)
+	if result.Error != nil {
+		return false, result.Error
+	}
+
+	return result.RowsAffected > 0, nil
+}
+
+// SaveAnalysisIfStatus is UpdateAnalysis guarded by the stored status, so a
+// stale copy cannot overwrite a status another process has written.
+func (r *analysisRepo) SaveAnalysisIfStatus(ctx context.Context,
+	analysis *models.Analysis, statuses []models.AnalysisStatus) (
+	bool, error) {
+	result := r.DB.WithContext(ctx).Model(analysis).
+		Where("status IN ?", statuses).
+		Select("*").Omit(clause.Associations, "CreatedAt").Updates(analysis)
+	if result.Error != nil {
+		return false, result.Error
+	}
+
+	return result.RowsAffected > 0, nil
+}
+
 func (r *analysisRepo) UpdateSample(ctx context.Context,
 	sample *models.Sample) error {
 	return r.DB.WithContext(ctx).Save(sample).Error
@@ -132,3 +193,96 @@ func (r *analysisRepo) DeleteAnalysis(ctx context.Context,
 	analysis *models.Analysis) error {
 	return r.DB.WithContext(ctx).Delete(analysis).Error
 }
+
+func (r *analysisRepo) CreateAnalysisLog(ctx context.Context,
+	log *models.AnalysisLog) error {
+	return r.DB.WithContext(ctx).Create(log).Error
+}
+
+func (r *analysisRepo) GetAnalysisLogs(ctx context.Context,
+	analysisID uuid.UUID, step models.AnalysisStep) (
+	[]models.AnalysisLog, error) {
+	var logs []models.AnalysisLog
+
+	query := r.DB.WithContext(ctx).Where("analysis_id = ?", analysisID)
+	if step != "" {
+		query = query.Where("step = ?", step)
+	}
+
+	if err := query.Order("started_at").Find(&logs).Error; err != nil {
+		return nil, err
+	}
+
+	return logs, nil
+}
+
+// ReplaceAnalysisDatabases stores the databases of a finished analysis,
+// dropping the ones of a previous run.
+func (r *analysisRepo) ReplaceAnalysisDatabases(ctx context.Context,
+	analysisID uuid.UUID, databases []models.AnalysisDatabase) error {
+	return r.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("analysis_id = ?", analysisID).
+			Delete(&models.AnalysisDatabase{}).Error; err != nil {
+			return err
+		}
+		if len(databases) == 0 {
+			return nil
+		}
+		return tx.Omit(clause.Associations).Create(&databases).Error
+	})
+}
+
+// GetOutdatedAnalysisDatabases returns the databases of the finished
+// analyses whose checksum is none of the current checksums of that database,
+// with their analysis. Databases missing from current are not compared. The
+// newest analyses come first.
+func (r *analysisRepo) GetOutdatedAnalysisDatabases(ctx context.Context,
+	current map[string][]string) ([]models.AnalysisDatabase, error) {
+	var databases []models.AnalysisDatabase
+	if len(current) == 0 {
+		return databases, nil
+	}
+
+	names := make([]string, 0, len(current))
+	for name := range current {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	outdated := r.DB.Session(&gorm.Session{NewDB: true})
+	for i, name := range names {
+		condition := "analysis_databases.name = ? AND " +
+			"analysis_databases.checksum NOT IN ?"
+		if i == 0 {
+			outdated = outdated.Where(condition, name, current[name])
+		} else {
+			outdated = outdated.Or(condition, name, current[name])
+		}
+	}
+
+	err := r.DB.WithContext(ctx).
+		Preload("Analysis.Sample").Preload("Analysis.User").
+		Joins("JOIN analyses ON analyses.id = analysis_databases.analysis_id").
+		Where("analyses.status = ?", models.AnalysisStatusDone).
+		Where(outdated).
+		Order("analyses.finished_at DESC").
+		Order("analysis_databases.analysis_id").
+		Order("analysis_databases.name").
+		Find(&databases).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return databases, nil
+}
+
+func (r *analysisRepo) GetActiveQCRules(ctx context.Context) (
+	[]models.QCRule, error) {
+	var rules []models.QCRule
+	if err := r.DB.WithContext(ctx).Where("is_active = true").
+		Find(&rules).Error; err != nil {
+		return nil, err
+	}
+
+	return rules, nil
+}
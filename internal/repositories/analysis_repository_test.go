@@ -3,8 +3,10 @@ package repositories_test
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/CABGenOrg/cabgen_backend/internal/models"
+	"github.com/CABGenOrg/cabgen_backend/internal/pipeline"
 	"github.com/CABGenOrg/cabgen_backend/internal/repositories"
 	"github.com/CABGenOrg/cabgen_backend/internal/testutils"
 	testmodels "github.com/CABGenOrg/cabgen_backend/internal/testutils/models"
@@ -154,6 +156,34 @@ func TestGetAnalysesFilters(t *testing.T) {
 		assert.Len(t, analyses, 1)
 	})
 
+	t.Run("Admin - Filter by QC Verdict Returns Subset", func(t *testing.T) {
+		filterDB := testutils.NewMockDB()
+		repo := repositories.NewAnalysisRepository(filterDB)
+
+		passAnalysis := mockAnalysis
+		passAnalysis.ID = uuid.New()
+		passAnalysis.QCVerdict = pipeline.QCVerdictPass
+		filterDB.Create(&passAnalysis)
+
+		failAnalysis := mockAnalysis
+		failAnalysis.ID = uuid.New()
+		failAnalysis.QCVerdict = pipeline.QCVerdictFail
+		filterDB.Create(&failAnalysis)
+
+		filter := models.AnalysisFilter{QCVerdict: pipeline.QCVerdictFail}
+		analyses, err := repo.GetAnalyses(ctx, uuid.Nil, filter)
+
+		assert.NoError(t, err)
+		assert.Len(t, analyses, 1)
+		assert.Equal(t, failAnalysis.ID, analyses[0].ID)
+
+		analyses, err = repo.GetAnalyses(ctx, mockUser.ID, filter)
+
+		assert.NoError(t, err)
+		assert.Len(t, analyses, 1)
+		assert.Equal(t, failAnalysis.ID, analyses[0].ID)
+	})
+
 	t.Run("Empty filter - both paths", func(t *testing.T) {
 		filterDB := testutils.NewMockDB()
 		repo := repositories.NewAnalysisRepository(filterDB)
@@ -170,6 +200,77 @@ func TestGetAnalysesFilters(t *testing.T) {
 	})
 }
 
+func TestGetActiveQCRules(t *testing.T) {
+	ctx := context.Background()
+
+	db := testutils.NewMockDB()
+	repo := repositories.NewAnalysisRepository(db)
+
+	minCompleteness, maxContamination := 90.0, 5.0
+	active := testmodels.NewQCRule(uuid.NewString(),
+		pipeline.QCMetricCompleteness, &minCompleteness, nil,
+		pipeline.QCSeverityFail, true)
+	inactive := testmodels.NewQCRule(uuid.NewString(),
+		pipeline.QCMetricContamination, nil, &maxContamination,
+		pipeline.QCSeverityWarn, false)
+	db.Create(&active)
+	db.Create(&inactive)
+
+	t.Run("Success", func(t *testing.T) {
+		rules, err := repo.GetActiveQCRules(ctx)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []models.QCRule{active}, rules)
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		mockDB, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+		assert.NoError(t, err)
+
+		mockAnalysisRepo := repositories.NewAnalysisRepository(mockDB)
+		rules, err := mockAnalysisRepo.GetActiveQCRules(ctx)
+
+		assert.Error(t, err)
+		assert.Empty(t, rules)
+	})
+}
+
+func TestGetAnalysesByStatus(t *testing.T) {
+	ctx := context.Background()
+
+	db := testutils.NewMockDB()
+	repo := repositories.NewAnalysisRepository(db)
+
+	running := testmodels.CreateMockAnalysis()
+	running.Status = models.AnalysisStatusRunning
+	done := testmodels.CreateMockAnalysis()
+	done.ID = uuid.New()
+	done.Status = models.AnalysisStatusDone
+	db.Create(&running)
+	db.Create(&done)
+
+	t.Run("Success", func(t *testing.T) {
+		analyses, err := repo.GetAnalysesByStatus(ctx,
+			models.AnalysisStatusRunning)
+
+		assert.NoError(t, err)
+		assert.Len(t, analyses, 1)
+		assert.Equal(t, running.ID, analyses[0].ID)
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		mockDB, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+		assert.NoError(t, err)
+
+		mockAnalysisRepo := repositories.NewAnalysisRepository(mockDB)
+		analyses, err := mockAnalysisRepo.GetAnalysesByStatus(ctx,
+			models.AnalysisStatusRunning)
+
+		assert.Error(t, err)
+		assert.Empty(t, analyses)
+	})
+}
+
 func TestGetAnalysisByID(t *testing.T) {
 	ctx := context.Background()
 
@@ -185,6 +286,8 @@ func TestGetAnalysisByID(t *testing.T) {
 		assert.NoError(t, err)
 		assert.Equal(t, analysis.ID, resultAnalysis.ID)
 		assert.Equal(t, analysis.Metrics, resultAnalysis.Metrics)
+		assert.Equal(t, analysis.Sample.Microorganism.Species,
+			resultAnalysis.Sample.Microorganism.Species)
 	})
 
 	t.Run("Error - Not Found", func(t *testing.T) {
@@ -276,6 +379,119 @@ func TestUpdateAnalysis(t *testing.T) {
 	})
 }
 
+func TestUpdateAnalysisIfStatus(t *testing.T) {
+	ctx := context.Background()
+
+	db := testutils.NewMockDB()
+	repo := repositories.NewAnalysisRepository(db)
+
+	analysis := testmodels.CreateMockAnalysis()
+	analysis.Status = models.AnalysisStatusRunning
+	db.Create(&analysis)
+
+	running := []models.AnalysisStatus{models.AnalysisStatusRunning}
+
+	t.Run("Success", func(t *testing.T) {
+		updated, err := repo.UpdateAnalysisIfStatus(ctx, analysis.ID, running,
+			map[string]any{"step": models.StepProkka})
+
+		assert.NoError(t, err)
+		assert.True(t, updated)
+
+		var result models.Analysis
+		assert.NoError(t, db.Where("id = ?", analysis.ID).First(&result).Error)
+		assert.Equal(t, models.StepProkka, result.Step)
+		assert.Equal(t, models.AnalysisStatusRunning, result.Status)
+	})
+
+	t.Run("Status Mismatch", func(t *testing.T) {
+		updated, err := repo.UpdateAnalysisIfStatus(ctx, analysis.ID,
+			[]models.AnalysisStatus{models.AnalysisStatusPending},
+			map[string]any{"step": models.StepUnicycler})
+
+		assert.NoError(t, err)
+		assert.False(t, updated)
+
+		var result models.Analysis
+		assert.NoError(t, db.Where("id = ?", analysis.ID).First(&result).Error)
+		assert.Equal(t, models.StepProkka, result.Step)
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		mockDB, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+		assert.NoError(t, err)
+
+		mockAnalysisRepo := repositories.NewAnalysisRepository(mockDB)
+		updated, err := mockAnalysisRepo.UpdateAnalysisIfStatus(ctx,
+			analysis.ID, running, map[string]any{"step": models.StepProkka})
+
+		assert.Error(t, err)
+		assert.False(t, updated)
+	})
+}
+
+func TestSaveAnalysisIfStatus(t *testing.T) {
+	ctx := context.Background()
+
+	db := testutils.NewMockDB()
+	repo := repositories.NewAnalysisRepository(db)
+
+	analysis := testmodels.CreateMockAnalysis()
+	analysis.Status = models.AnalysisStatusRunning
+	db.Create(&analysis)
+
+	running := []models.AnalysisStatus{models.AnalysisStatusRunning}
+
+	t.Run("Status Mismatch", func(t *testing.T) {
+		db.Model(&models.Analysis{}).Where("id = ?", analysis.ID).
+			Update("status", models.AnalysisStatusCancelled)
+		defer db.Model(&models.Analysis{}).Where("id = ?", analysis.ID).
+			Update("status", models.AnalysisStatusRunning)
+
+		stale := analysis
+		stale.Status = models.AnalysisStatusDone
+
+		updated, err := repo.SaveAnalysisIfStatus(ctx, &stale, running)
+
+		assert.NoError(t, err)
+		assert.False(t, updated)
+
+		var result models.Analysis
+		assert.NoError(t, db.Where("id = ?", analysis.ID).First(&result).Error)
+		assert.Equal(t, models.AnalysisStatusCancelled, result.Status)
+	})
+
+	t.Run("Success", func(t *testing.T) {
+		finished := analysis
+		finished.Status = models.AnalysisStatusDone
+		finished.Step = ""
+		finished.Metrics = nil
+
+		updated, err := repo.SaveAnalysisIfStatus(ctx, &finished, running)
+
+		assert.NoError(t, err)
+		assert.True(t, updated)
+
+		var result models.Analysis
+		assert.NoError(t, db.Where("id = ?", analysis.ID).First(&result).Error)
+		assert.Equal(t, models.AnalysisStatusDone, result.Status)
+		assert.Empty(t, result.Step)
+		assert.Nil(t, result.Metrics)
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		mockDB, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+		assert.NoError(t, err)
+
+		mockAnalysisRepo := repositories.NewAnalysisRepository(mockDB)
+		updated, err := mockAnalysisRepo.SaveAnalysisIfStatus(ctx,
+			&analysis, running)
+
+		assert.Error(t, err)
+		assert.False(t, updated)
+	})
+}
+
 func TestAnalysisUpdateSample(t *testing.T) {
 	ctx := context.Background()
 
@@ -343,3 +559,210 @@ func TestDeleteAnalysis(t *testing.T) {
 		assert.Error(t, err)
 	})
 }
+
+func TestCreateAnalysisLog(t *testing.T) {
+	ctx := context.Background()
+
+	db := testutils.NewMockDB()
+	repo := repositories.NewAnalysisRepository(db)
+
+	analysis := testmodels.CreateMockAnalysis()
+	db.Create(&analysis)
+
+	t.Run("Success", func(t *testing.T) {
+		log := testmodels.NewAnalysisLog(analysis.ID, models.StepCheckM,
+			time.Now())
+		err := repo.CreateAnalysisLog(ctx, &log)
+		assert.NoError(t, err)
+
+		var result models.AnalysisLog
+		err = db.Where("id = ?", log.ID).First(&result).Error
+
+		assert.NoError(t, err)
+		assert.Equal(t, models.StepCheckM, result.Step)
+		assert.Equal(t, analysis.ID, result.AnalysisID)
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		mockDB, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+		assert.NoError(t, err)
+
+		mockAnalysisRepo := repositories.NewAnalysisRepository(mockDB)
+		err = mockAnalysisRepo.CreateAnalysisLog(ctx, &models.AnalysisLog{})
+
+		assert.Error(t, err)
+	})
+}
+
+func TestGetAnalysisLogs(t *testing.T) {
+	ctx := context.Background()
+
+	db := testutils.NewMockDB()
+	repo := repositories.NewAnalysisRepository(db)
+
+	analysis := testmodels.CreateMockAnalysis()
+	db.Create(&analysis)
+
+	started := time.Date(2024, time.May, 11, 0, 0, 0, 0, time.UTC)
+	kraken := testmodels.NewAnalysisLog(analysis.ID, models.StepKraken2,
+		started.Add(time.Minute))
+	checkm := testmodels.NewAnalysisLog(analysis.ID, models.StepCheckM,
+		started)
+	other := testmodels.NewAnalysisLog(uuid.New(), models.StepCheckM,
+		started)
+	db.Create(&kraken)
+	db.Create(&checkm)
+	db.Create(&other)
+
+	t.Run("Success - All Steps", func(t *testing.T) {
+		logs, err := repo.GetAnalysisLogs(ctx, analysis.ID, "")
+
+		assert.NoError(t, err)
+		assert.Len(t, logs, 2)
+		assert.Equal(t, checkm.ID, logs[0].ID)
+		assert.Equal(t, kraken.ID, logs[1].ID)
+	})
+
+	t.Run("Success - Step Filter", func(t *testing.T) {
+		logs, err := repo.GetAnalysisLogs(ctx, analysis.ID,
+			models.StepKraken2)
+
+		assert.NoError(t, err)
+		assert.Len(t, logs, 1)
+		assert.Equal(t, kraken.ID, logs[0].ID)
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		mockDB, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+		assert.NoError(t, err)
+
+		mockAnalysisRepo := repositories.NewAnalysisRepository(mockDB)
+		logs, err := mockAnalysisRepo.GetAnalysisLogs(ctx, uuid.New(), "")
+
+		assert.Error(t, err)
+		assert.Empty(t, logs)
+	})
+}
+
+func TestReplaceAnalysisDatabases(t *testing.T) {
+	ctx := context.Background()
+
+	db := testutils.NewMockDB()
+	repo := repositories.NewAnalysisRepository(db)
+
+	analysis := testmodels.CreateMockAnalysis()
+	db.Create(&analysis)
+
+	t.Run("Success", func(t *testing.T) {
+		err := repo.ReplaceAnalysisDatabases(ctx, analysis.ID,
+			models.NewAnalysisDatabases(analysis.ID,
+				[]pipeline.DatabaseFingerprint{
+					{Name: "kraken2", Checksum: "k1"},
+					{Name: "abricate/vfdb", Checksum: "v1"},
+				}))
+		assert.NoError(t, err)
+
+		// A rerun replaces the databases of the previous run.
+		err = repo.ReplaceAnalysisDatabases(ctx, analysis.ID,
+			models.NewAnalysisDatabases(analysis.ID,
+				[]pipeline.DatabaseFingerprint{
+					{Name: "kraken2", Checksum: "k2"},
+					{Name: "amrfinder", Error: "not accessible"},
+				}))
+		assert.NoError(t, err)
+
+		var result []models.AnalysisDatabase
+		err = db.Where("analysis_id = ?", analysis.ID).Find(&result).Error
+
+		assert.NoError(t, err)
+		if assert.Len(t, result, 1) {
+			assert.Equal(t, "kraken2", result[0].Name)
+			assert.Equal(t, "k2", result[0].Checksum)
+		}
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		mockDB, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+		assert.NoError(t, err)
+
+		mockAnalysisRepo := repositories.NewAnalysisRepository(mockDB)
+		err = mockAnalysisRepo.ReplaceAnalysisDatabases(ctx, uuid.New(), nil)
+
+		assert.Error(t, err)
+	})
+}
+
+func TestGetOutdatedAnalysisDatabases(t *testing.T) {
+	ctx := context.Background()
+
+	db := testutils.NewMockDB()
+	repo := repositories.NewAnalysisRepository(db)
+
+	// The analyses share a sample, whose related records are created once.
+	base := testmodels.CreateMockAnalysis()
+	newAnalysis := func(status models.AnalysisStatus, finishedAt time.Time,
+		databases ...pipeline.DatabaseFingerprint) models.Analysis {
+		analysis := base
+		analysis.ID = uuid.New()
+		analysis.Status = status
+		analysis.FinishedAt = &finishedAt
+		assert.NoError(t, db.Create(&analysis).Error)
+		assert.NoError(t, repo.ReplaceAnalysisDatabases(ctx, analysis.ID,
+			models.NewAnalysisDatabases(analysis.ID, databases)))
+		return analysis
+	}
+
+	now := time.Now()
+	old := newAnalysis(models.AnalysisStatusDone, now.Add(-48*time.Hour),
+		pipeline.DatabaseFingerprint{Name: "kraken2", Checksum: "k1"},
+		pipeline.DatabaseFingerprint{Name: "abricate/vfdb", Checksum: "v0"},
+		pipeline.DatabaseFingerprint{Name: "retired", Checksum: "r1"})
+	recent := newAnalysis(models.AnalysisStatusDone, now,
+		pipeline.DatabaseFingerprint{Name: "kraken2", Checksum: "k1"},
+		pipeline.DatabaseFingerprint{Name: "abricate/vfdb", Checksum: "v1"})
+	newAnalysis(models.AnalysisStatusDone, now,
+		pipeline.DatabaseFingerprint{Name: "kraken2", Checksum: "k3"})
+	newAnalysis(models.AnalysisStatusFailed, now,
+		pipeline.DatabaseFingerprint{Name: "kraken2", Checksum: "k0"})
+
+	current := map[string][]string{
+		"kraken2":       {"k2", "k3"},
+		"abricate/vfdb": {"v1"},
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		databases, err := repo.GetOutdatedAnalysisDatabases(ctx, current)
+
+		assert.NoError(t, err)
+		if assert.Len(t, databases, 3) {
+			assert.Equal(t, recent.ID, databases[0].AnalysisID)
+			assert.Equal(t, "kraken2", databases[0].Name)
+			assert.Equal(t, recent.Sample.ID, databases[0].Analysis.Sample.ID)
+
+			assert.Equal(t, old.ID, databases[1].AnalysisID)
+			assert.Equal(t, "abricate/vfdb", databases[1].Name)
+			assert.Equal(t, "v0", databases[1].Checksum)
+			assert.Equal(t, old.ID, databases[2].AnalysisID)
+			assert.Equal(t, "kraken2", databases[2].Name)
+		}
+	})
+
+	t.Run("Success - No Current Databases", func(t *testing.T) {
+		databases, err := repo.GetOutdatedAnalysisDatabases(ctx, nil)
+
+		assert.NoError(t, err)
+		assert.Empty(t, databases)
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		mockDB, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+		assert.NoError(t, err)
+
+		mockAnalysisRepo := repositories.NewAnalysisRepository(mockDB)
+		databases, err := mockAnalysisRepo.GetOutdatedAnalysisDatabases(ctx,
+			current)
+
+		assert.Error(t, err)
+		assert.Empty(t, databases)
+	})
+}
@@ -0,0 +1,61 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/CABGenOrg/cabgen_backend/internal/models"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type QCRuleRepository interface {
+	GetQCRules(ctx context.Context) ([]models.QCRule, error)
+	GetQCRuleByID(ctx context.Context, ID uuid.UUID) (*models.QCRule, error)
+	CreateQCRule(ctx context.Context, rule *models.QCRule) error
+	UpdateQCRule(ctx context.Context, rule *models.QCRule) error
+	DeleteQCRule(ctx context.Context, rule *models.QCRule) error
+}
+
+type qcRuleRepo struct {
+	DB *gorm.DB
+}
+
+func NewQCRuleRepo(db *gorm.DB) QCRuleRepository {
+	return &qcRuleRepo{DB: db}
+}
+
+func (r *qcRuleRepo) GetQCRules(ctx context.Context) ([]models.QCRule, error) {
+	var rules []models.QCRule
+	if err := r.DB.WithContext(ctx).Order("metric, species").
+		Find(&rules).Error; err != nil {
+		return nil, err
+	}
+
+	return rules, nil
+}
+
+func (r *qcRuleRepo) GetQCRuleByID(ctx context.Context,
+	ID uuid.UUID) (*models.QCRule, error) {
+	var rule models.QCRule
+	if err := r.DB.WithContext(ctx).Where("id = ?", ID).
+		First(&rule).Error; err != nil {
+		return nil, err
+	}
+
+	return &rule, nil
+}
+
+func (r *qcRuleRepo) CreateQCRule(ctx context.Context,
+	rule *models.QCRule) error {
+	return r.DB.WithContext(ctx).Create(rule).Error
+}
+
+func (r *qcRuleRepo) UpdateQCRule(ctx context.Context,
+	rule *models.QCRule) error {
+	return r.DB.WithContext(ctx).Save(rule).Error
+}
+
+func (r *qcRuleRepo) DeleteQCRule(ctx context.Context,
+	rule *models.QCRule) error {
+	return r.DB.WithContext(ctx).Delete(rule).Error
+}
@@ -0,0 +1,192 @@
+package repositories_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/CABGenOrg/cabgen_backend/internal/models"
+	"github.com/CABGenOrg/cabgen_backend/internal/pipeline"
+	"github.com/CABGenOrg/cabgen_backend/internal/repositories"
+	"github.com/CABGenOrg/cabgen_backend/internal/testutils"
+	"github.com/glebarez/sqlite"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+
+	testmodels "github.com/CABGenOrg/cabgen_backend/internal/testutils/models"
+)
+
+func TestNewQCRuleRepo(t *testing.T) {
+	db := testutils.NewMockDB()
+	result := repositories.NewQCRuleRepo(db)
+
+	assert.NotEmpty(t, result)
+}
+
+func TestGetQCRules(t *testing.T) {
+	db := testutils.NewMockDB()
+	repo := repositories.NewQCRuleRepo(db)
+
+	minCompleteness, maxContamination := 90.0, 5.0
+	rule := testmodels.NewQCRule(uuid.NewString(),
+		pipeline.QCMetricContamination, nil, &maxContamination,
+		pipeline.QCSeverityWarn, true)
+	rule2 := testmodels.NewQCRule(uuid.NewString(),
+		pipeline.QCMetricCompleteness, &minCompleteness, nil,
+		pipeline.QCSeverityFail, false)
+	db.Create(&rule)
+	db.Create(&rule2)
+
+	t.Run("Success", func(t *testing.T) {
+		rules, err := repo.GetQCRules(context.Background())
+
+		expected := []models.QCRule{rule2, rule}
+
+		assert.NoError(t, err)
+		assert.Equal(t, expected, rules)
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		mockDB, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+		assert.NoError(t, err)
+
+		mockQCRuleRepo := repositories.NewQCRuleRepo(mockDB)
+		rules, err := mockQCRuleRepo.GetQCRules(context.Background())
+
+		assert.Error(t, err)
+		assert.Empty(t, rules)
+	})
+}
+
+func TestGetQCRuleByID(t *testing.T) {
+	db := testutils.NewMockDB()
+	repo := repositories.NewQCRuleRepo(db)
+
+	id := uuid.New()
+	minCoverage := 30.0
+	rule := testmodels.NewQCRule(id.String(), pipeline.QCMetricCoverage,
+		&minCoverage, nil, pipeline.QCSeverityFail, true)
+	db.Create(&rule)
+
+	t.Run("Success", func(t *testing.T) {
+		result, err := repo.GetQCRuleByID(context.Background(), id)
+
+		assert.NoError(t, err)
+		assert.Equal(t, &rule, result)
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		mockDB, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+		assert.NoError(t, err)
+
+		mockQCRuleRepo := repositories.NewQCRuleRepo(mockDB)
+		result, err := mockQCRuleRepo.GetQCRuleByID(context.Background(),
+			uuid.UUID{})
+
+		assert.Error(t, err)
+		assert.Empty(t, result)
+	})
+}
+
+func TestCreateQCRule(t *testing.T) {
+	db := testutils.NewMockDB()
+	repo := repositories.NewQCRuleRepo(db)
+
+	t.Run("Success", func(t *testing.T) {
+		maxContigs := 500.0
+		rule := testmodels.NewQCRule(uuid.NewString(),
+			pipeline.QCMetricContigs, nil, &maxContigs,
+			pipeline.QCSeverityWarn, true)
+		rule.Species = "Klebsiella pneumoniae"
+
+		err := repo.CreateQCRule(context.Background(), &rule)
+		assert.NoError(t, err)
+
+		var result models.QCRule
+		err = db.Where("id = ?", rule.ID).First(&result).Error
+
+		assert.NoError(t, err)
+		assert.Equal(t, rule, result)
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		mockDB, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+		assert.NoError(t, err)
+
+		mockQCRuleRepo := repositories.NewQCRuleRepo(mockDB)
+		err = mockQCRuleRepo.CreateQCRule(context.Background(),
+			&models.QCRule{})
+
+		assert.Error(t, err)
+	})
+}
+
+func TestUpdateQCRule(t *testing.T) {
+	db := testutils.NewMockDB()
+	repo := repositories.NewQCRuleRepo(db)
+
+	minN50 := 10000.0
+	rule := testmodels.NewQCRule(uuid.NewString(), pipeline.QCMetricN50,
+		&minN50, nil, pipeline.QCSeverityWarn, true)
+	db.Create(&rule)
+
+	t.Run("Success", func(t *testing.T) {
+		newMin := 20000.0
+		ruleToUpdate := rule
+		ruleToUpdate.Min = &newMin
+		ruleToUpdate.Severity = pipeline.QCSeverityFail
+
+		err := repo.UpdateQCRule(context.Background(), &ruleToUpdate)
+		assert.NoError(t, err)
+
+		var result models.QCRule
+		err = db.Where("id = ?", rule.ID).First(&result).Error
+
+		assert.NoError(t, err)
+		assert.Equal(t, ruleToUpdate, result)
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		mockDB, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+		assert.NoError(t, err)
+
+		mockQCRuleRepo := repositories.NewQCRuleRepo(mockDB)
+		err = mockQCRuleRepo.UpdateQCRule(context.Background(),
+			&models.QCRule{})
+
+		assert.Error(t, err)
+	})
+}
+
+func TestDeleteQCRule(t *testing.T) {
+	db := testutils.NewMockDB()
+	repo := repositories.NewQCRuleRepo(db)
+
+	minN50 := 10000.0
+	rule := testmodels.NewQCRule(uuid.NewString(), pipeline.QCMetricN50,
+		&minN50, nil, pipeline.QCSeverityWarn, true)
+	db.Create(&rule)
+
+	t.Run("Success", func(t *testing.T) {
+		err := repo.DeleteQCRule(context.Background(), &rule)
+		assert.NoError(t, err)
+
+		var result models.QCRule
+		err = db.Where("id = ?", rule.ID).First(&result).Error
+
+		assert.Error(t, err)
+		assert.ErrorContains(t, err, "record not found")
+		assert.Empty(t, result)
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		mockDB, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+		assert.NoError(t, err)
+
+		mockQCRuleRepo := repositories.NewQCRuleRepo(mockDB)
+		err = mockQCRuleRepo.DeleteQCRule(context.Background(),
+			&models.QCRule{})
+
+		assert.Error(t, err)
+	})
+}
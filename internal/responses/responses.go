@@ -52,6 +52,11 @@ const (
 	SequencerModelAlreadyExistsError          = "admin.sequencer.modelAlreadyExists.error"
 	SequencerNotFoundError                    = "admin.sequencer.notFound.error"
 	SequencerDeleted                          = "admin.sequencer.delete.success"
+	SequencerInvalidReadTechnology            = "admin.sequencer.invalidReadTechnology"
+	QCRuleCreationSuccess                     = "admin.qcRule.create.success"
+	QCRuleNotFoundError                       = "admin.qcRule.notFound.error"
+	QCRuleInvalidError                        = "admin.qcRule.invalid.error"
+	QCRuleDeleted                             = "admin.qcRule.delete.success"
 	SampleSourceValidationMissingLanguage     = "admin.sampleSource.validation.missingLanguage"
 	SampleSourceValidationMissingTranslation  = "admin.sampleSource.validation.missingTranslation"
 	SampleSourceCreationSuccess               = "admin.sampleSource.create.success"
@@ -80,6 +85,12 @@ const (
 	SampleMissingFastq1                       = "admin.sample.missingFastq1"
 	SampleMissingFastq2                       = "admin.sample.missingFastq2"
 	SampleMissingFiles                        = "admin.sample.missingFiles"
+	SampleMissingLongReads                    = "admin.sample.missingLongReads"
+	SampleUploadInvalidFormat                 = "admin.sample.upload.invalidFormat"
+	SampleUploadCorruptedFile                 = "admin.sample.upload.corruptedFile"
+	SampleUploadEmptyFile                     = "admin.sample.upload.emptyFile"
+	SampleUploadUnpairedReads                 = "admin.sample.upload.unpairedReads"
+	SampleUploadFileNotFound                  = "admin.sample.upload.fileNotFound"
 	SampleContentTypeError                    = "admin.sample.contentType.error"
 	SampleNotFoundError                       = "admin.sample.notFound.error"
 	SampleDeleted                             = "admin.sample.delete.success"
@@ -92,8 +103,17 @@ const (
 	AnalysisExceededLimitError                = "analysis.exceededLimit.error"
 	AnalysisFastQCDownloadError               = "analysis.fastqcDownload.error"
 	AnalysisZipNotFound                       = "analysis.zipNotFound.error"
+	AnalysisTaxonomyNotFound                  = "analysis.taxonomyNotFound.error"
 	AnalysisDeleted                           = "analysis.delete.success"
 	AnalysisDeleteRunningError                = "analysis.deleteRunning.error"
+	AnalysisResumeSuccess                     = "analysis.resume.success"
+	AnalysisNotResumableError                 = "analysis.notResumable.error"
+	AnalysisCancelSuccess                     = "analysis.cancel.success"
+	AnalysisNotCancellableError               = "analysis.notCancellable.error"
+	AnalysisInvalidStep                       = "analysis.invalidStep.error"
+	AnalysisInvalidParameters                 = "analysis.invalidParameters.error"
+	AnalysisAvailableTypes                    = "analysis.create.availableTypes"
+	AnalysisNoAvailableTypes                  = "analysis.create.noAvailableTypes"
 	TicketCreationSuccess                     = "ticket.create.success"
 	TicketDelete                              = "ticket.delete.success"
 	TicketNotFoundError                       = "ticket.notFound.error"
@@ -6,14 +6,23 @@ import (
 )
 
 func SetupAdminAnalysisRoutes(r *gin.RouterGroup,
-	handler *analysis.AdminAnalysisHandler) {
+	handler *analysis.AdminAnalysisHandler,
+	eventHandler *analysis.AdminAnalysisEventHandler) {
 	analysisRouter := r.Group("/analyses")
 
 	analysisRouter.GET("", handler.GetAnalyses)
+	analysisRouter.GET("/outdated-databases", handler.GetOutdatedAnalyses)
 	analysisRouter.GET("/:analysisId", handler.GetAnalysisByID)
 	analysisRouter.GET("/:analysisId/download/zip", handler.DownloadZip)
+	analysisRouter.GET("/:analysisId/logs", handler.GetAnalysisLogs)
+	analysisRouter.GET("/:analysisId/logs/:step", handler.GetAnalysisLogs)
+	analysisRouter.GET("/:analysisId/taxonomy", handler.GetAnalysisTaxonomy)
+	analysisRouter.GET("/:analysisId/events",
+		eventHandler.StreamAnalysisEvents)
 	analysisRouter.POST("", handler.CreateAnalysis)
 	analysisRouter.POST("/download/tsv", handler.DownloadBatchTSV)
+	analysisRouter.POST("/:analysisId/resume", handler.ResumeAnalysis)
+	analysisRouter.POST("/:analysisId/cancel", handler.CancelAnalysis)
 	analysisRouter.PUT("/:analysisId", handler.UpdateAnalysis)
 	analysisRouter.DELETE("/:analysisId", handler.DeleteAnalysis)
 }
@@ -0,0 +1,16 @@
+package admin
+
+import (
+	"github.com/CABGenOrg/cabgen_backend/internal/handlers/admin/qcrule"
+	"github.com/gin-gonic/gin"
+)
+
+func SetupAdminQCRuleRoutes(r *gin.RouterGroup, handler *qcrule.AdminQCRuleHandler) {
+	qcRuleRouter := r.Group("/qc-rules")
+
+	qcRuleRouter.GET("", handler.GetQCRules)
+	qcRuleRouter.GET("/:qcRuleId", handler.GetQCRuleByID)
+	qcRuleRouter.POST("", handler.CreateQCRule)
+	qcRuleRouter.PUT("/:qcRuleId", handler.UpdateQCRule)
+	qcRuleRouter.DELETE("/:qcRuleId", handler.DeleteQCRule)
+}
@@ -0,0 +1,11 @@
+package admin
+
+import (
+	"github.com/CABGenOrg/cabgen_backend/internal/handlers/admin/speciesprofile"
+	"github.com/gin-gonic/gin"
+)
+
+func SetupAdminSpeciesProfileRoutes(r *gin.RouterGroup,
+	handler *speciesprofile.AdminSpeciesProfileHandler) {
+	r.GET("/species-profiles", handler.GetSpeciesProfiles)
+}
@@ -0,0 +1,11 @@
+package admin
+
+import (
+	"github.com/CABGenOrg/cabgen_backend/internal/handlers/admin/worker"
+	"github.com/gin-gonic/gin"
+)
+
+func SetupAdminWorkerRoutes(r *gin.RouterGroup,
+	handler *worker.AdminWorkerHandler) {
+	r.GET("/workers", handler.GetWorkers)
+}
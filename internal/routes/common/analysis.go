@@ -6,14 +6,23 @@ import (
 )
 
 func SetupAnalysisRoutes(r *gin.RouterGroup,
-	handler *analysis.AnalysisHandler) {
+	handler *analysis.AnalysisHandler,
+	eventHandler *analysis.AnalysisEventHandler) {
 	analysisRouter := r.Group("/analyses")
 
 	analysisRouter.GET("", handler.GetAnalyses)
+	analysisRouter.GET("/events", eventHandler.StreamUserEvents)
 	analysisRouter.GET("/:analysisId", handler.GetAnalysisByID)
 	analysisRouter.GET("/:analysisId/:fastqcReport", handler.GetAnalysisFastQCByID)
 	analysisRouter.GET("/:analysisId/download/zip", handler.DownloadZip)
+	analysisRouter.GET("/:analysisId/logs", handler.GetAnalysisLogs)
+	analysisRouter.GET("/:analysisId/logs/:step", handler.GetAnalysisLogs)
+	analysisRouter.GET("/:analysisId/taxonomy", handler.GetAnalysisTaxonomy)
+	analysisRouter.GET("/:analysisId/events",
+		eventHandler.StreamAnalysisEvents)
 	analysisRouter.POST("", handler.CreateAnalysis)
 	analysisRouter.POST("/download/tsv", handler.DownloadBatchTSV)
+	analysisRouter.POST("/:analysisId/resume", handler.ResumeAnalysis)
+	analysisRouter.POST("/:analysisId/cancel", handler.CancelAnalysis)
 	analysisRouter.DELETE("/:analysisId", handler.DeleteAnalysis)
 }
@@ -0,0 +1,152 @@
+package services
+
+import (
+	"context"
+	"errors"
+
+	"github.com/CABGenOrg/cabgen_backend/internal/logging"
+	"github.com/CABGenOrg/cabgen_backend/internal/models"
+	"github.com/CABGenOrg/cabgen_backend/internal/repositories"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+type AnalysisEventPublisher interface {
+	PublishAnalysisEvent(ctx context.Context, event models.AnalysisEvent) error
+}
+
+type AnalysisEventSubscriber interface {
+	SubscribeAnalysisEvents(ctx context.Context, userID uuid.UUID) (
+		<-chan models.AnalysisEvent, func() error, error)
+}
+
+type AnalysisEventService interface {
+	SubscribeAnalysis(ctx context.Context, analysisID, userID uuid.UUID) (
+		<-chan models.AnalysisEvent, error)
+	SubscribeUser(ctx context.Context, userID uuid.UUID) (
+		<-chan models.AnalysisEvent, error)
+}
+
+type analysisEventService struct {
+	Repo       repositories.AnalysisRepository
+	Subscriber AnalysisEventSubscriber
+	Logger     *zap.Logger
+}
+
+func NewAnalysisEventService(repo repositories.AnalysisRepository,
+	subscriber AnalysisEventSubscriber,
+	logger *zap.Logger) AnalysisEventService {
+	return &analysisEventService{
+		Repo:       repo,
+		Subscriber: subscriber,
+		Logger:     logger,
+	}
+}
+
+func isFinishedStatus(status models.AnalysisStatus) bool {
+	return status == models.AnalysisStatusDone ||
+		status == models.AnalysisStatusFailed ||
+		status == models.AnalysisStatusCancelled
+}
+
+// SubscribeAnalysis streams the current state of the analysis followed by its
+// transitions. The stream ends once the analysis is done, failed or
+// cancelled.
+func (s *analysisEventService) SubscribeAnalysis(ctx context.Context,
+	analysisID, userID uuid.UUID) (<-chan models.AnalysisEvent, error) {
+	analysis, err := s.Repo.GetAnalysisByID(ctx, analysisID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		s.Logger.Error("Service Error", logging.ServiceLogging(
+			"AnalysisEventService", "SubscribeAnalysis",
+			logging.DatabaseNotFoundError, err,
+		)...)
+		return nil, ErrNotFound
+	}
+
+	if err != nil {
+		s.Logger.Error("Service Error", logging.ServiceLogging(
+			"AnalysisEventService", "SubscribeAnalysis",
+			logging.DatabaseError, err)...)
+		return nil, ErrInternal
+	}
+
+	if userID != uuid.Nil && userID != analysis.UserID {
+		s.Logger.Error("Service Error", logging.ServiceLogging(
+			"AnalysisEventService", "SubscribeAnalysis",
+			logging.Unauthorized, err,
+		)...)
+		return nil, ErrUnauthorized
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	events, closeSub, err := s.Subscriber.SubscribeAnalysisEvents(ctx,
+		analysis.UserID)
+	if err != nil {
+		cancel()
+		s.Logger.Error("Service Error", logging.ServiceLogging(
+			"AnalysisEventService", "SubscribeAnalysis",
+			logging.EventSubscribeError, err,
+		)...)
+		return nil, ErrInternal
+	}
+
+	// Read the analysis again now that the subscription is active, so no
+	// transition made in between is lost.
+	if current, err := s.Repo.GetAnalysisByID(ctx, analysisID); err == nil {
+		analysis = current
+	}
+
+	out := make(chan models.AnalysisEvent, 1)
+	out <- models.NewAnalysisEvent(analysis)
+	if isFinishedStatus(analysis.Status) {
+		cancel()
+		_ = closeSub()
+		close(out)
+		return out, nil
+	}
+
+	go func() {
+		defer close(out)
+		defer cancel()
+		defer closeSub()
+
+		for event := range events {
+			if event.AnalysisID != analysisID {
+				continue
+			}
+
+			select {
+			case out <- event:
+			case <-ctx.Done():
+				return
+			}
+
+			if isFinishedStatus(event.Status) {
+				return
+			}
+		}
+	}()
+
+	return out, nil
+}
+
+// SubscribeUser streams the transitions of every analysis owned by the user.
+func (s *analysisEventService) SubscribeUser(ctx context.Context,
+	userID uuid.UUID) (<-chan models.AnalysisEvent, error) {
+	events, closeSub, err := s.Subscriber.SubscribeAnalysisEvents(ctx, userID)
+	if err != nil {
+		s.Logger.Error("Service Error", logging.ServiceLogging(
+			"AnalysisEventService", "SubscribeUser",
+			logging.EventSubscribeError, err,
+		)...)
+		return nil, ErrInternal
+	}
+
+	go func() {
+		<-ctx.Done()
+		_ = closeSub()
+	}()
+
+	return events, nil
+}
@@ -0,0 +1,215 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/CABGenOrg/cabgen_backend/internal/models"
+	"github.com/CABGenOrg/cabgen_backend/internal/services"
+	"github.com/CABGenOrg/cabgen_backend/internal/testutils"
+	"github.com/CABGenOrg/cabgen_backend/internal/testutils/mocks"
+	testmodels "github.com/CABGenOrg/cabgen_backend/internal/testutils/models"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+func collectEvents(events <-chan models.AnalysisEvent) []models.AnalysisEvent {
+	var result []models.AnalysisEvent
+	for event := range events {
+		result = append(result, event)
+	}
+	return result
+}
+
+func TestAnalysisEventSubscribeAnalysis(t *testing.T) {
+	ctx := context.Background()
+	mock := testmodels.CreateMockAnalysis()
+	mock.Status = models.AnalysisStatusRunning
+	mock.Step = models.StepUnicycler
+
+	getAnalysis := func(ctx context.Context,
+		analysisID uuid.UUID) (*models.Analysis, error) {
+		mockCopy := mock
+		return &mockCopy, nil
+	}
+
+	t.Run("Success - Streams Until Finished", func(t *testing.T) {
+		repo := &mocks.MockAnalysisRepository{
+			GetAnalysisByIDFunc: getAnalysis,
+		}
+		subscriber := &mocks.MockAnalysisEventSubscriber{
+			SubscribeAnalysisEventsFunc: func(ctx context.Context,
+				userID uuid.UUID) (<-chan models.AnalysisEvent, func() error,
+				error) {
+				assert.Equal(t, mock.UserID, userID)
+				events := make(chan models.AnalysisEvent, 4)
+				events <- models.AnalysisEvent{AnalysisID: uuid.New(),
+					Status: models.AnalysisStatusRunning}
+				events <- models.AnalysisEvent{AnalysisID: mock.ID,
+					Status: models.AnalysisStatusRunning,
+					Step:   models.StepProkka}
+				events <- models.AnalysisEvent{AnalysisID: mock.ID,
+					Status: models.AnalysisStatusDone}
+				events <- models.AnalysisEvent{AnalysisID: mock.ID,
+					Status: models.AnalysisStatusPending}
+				return events, func() error { return nil }, nil
+			},
+		}
+
+		svc := services.NewAnalysisEventService(repo, subscriber, zap.NewNop())
+		events, err := svc.SubscribeAnalysis(ctx, mock.ID, mock.UserID)
+
+		assert.NoError(t, err)
+		result := collectEvents(events)
+		assert.Len(t, result, 3)
+		assert.Equal(t, models.StepUnicycler, result[0].Step)
+		assert.Equal(t, models.StepProkka, result[1].Step)
+		assert.Equal(t, models.AnalysisStatusDone, result[2].Status)
+	})
+
+	t.Run("Success - Finished Analysis", func(t *testing.T) {
+		finished := mock
+		finished.Status = models.AnalysisStatusFailed
+		repo := &mocks.MockAnalysisRepository{
+			GetAnalysisByIDFunc: func(ctx context.Context,
+				analysisID uuid.UUID) (*models.Analysis, error) {
+				return &finished, nil
+			},
+		}
+
+		svc := services.NewAnalysisEventService(repo,
+			&mocks.MockAnalysisEventSubscriber{}, zap.NewNop())
+		events, err := svc.SubscribeAnalysis(ctx, mock.ID, uuid.Nil)
+
+		assert.NoError(t, err)
+		result := collectEvents(events)
+		assert.Len(t, result, 1)
+		assert.Equal(t, models.AnalysisStatusFailed, result[0].Status)
+	})
+
+	t.Run("Error - Not Found", func(t *testing.T) {
+		repo := &mocks.MockAnalysisRepository{
+			GetAnalysisByIDFunc: func(ctx context.Context,
+				analysisID uuid.UUID) (*models.Analysis, error) {
+				return nil, gorm.ErrRecordNotFound
+			},
+		}
+
+		mockLogger, logs := testutils.NewMockLogger(zap.ErrorLevel)
+
+		svc := services.NewAnalysisEventService(repo,
+			&mocks.MockAnalysisEventSubscriber{}, mockLogger)
+		events, err := svc.SubscribeAnalysis(ctx, mock.ID, mock.UserID)
+
+		assert.ErrorIs(t, err, services.ErrNotFound)
+		assert.Nil(t, events)
+		assert.Equal(t, 1, logs.Len())
+	})
+
+	t.Run("Error - Unauthorized", func(t *testing.T) {
+		repo := &mocks.MockAnalysisRepository{
+			GetAnalysisByIDFunc: getAnalysis,
+		}
+
+		mockLogger, logs := testutils.NewMockLogger(zap.ErrorLevel)
+
+		svc := services.NewAnalysisEventService(repo,
+			&mocks.MockAnalysisEventSubscriber{}, mockLogger)
+		events, err := svc.SubscribeAnalysis(ctx, mock.ID, uuid.New())
+
+		assert.ErrorIs(t, err, services.ErrUnauthorized)
+		assert.Nil(t, events)
+		assert.Equal(t, 1, logs.Len())
+	})
+
+	t.Run("Error - DB Internal", func(t *testing.T) {
+		repo := &mocks.MockAnalysisRepository{
+			GetAnalysisByIDFunc: func(ctx context.Context,
+				analysisID uuid.UUID) (*models.Analysis, error) {
+				return nil, errors.New("db down")
+			},
+		}
+
+		mockLogger, logs := testutils.NewMockLogger(zap.ErrorLevel)
+
+		svc := services.NewAnalysisEventService(repo,
+			&mocks.MockAnalysisEventSubscriber{}, mockLogger)
+		events, err := svc.SubscribeAnalysis(ctx, mock.ID, mock.UserID)
+
+		assert.ErrorIs(t, err, services.ErrInternal)
+		assert.Nil(t, events)
+		assert.Equal(t, 1, logs.Len())
+	})
+
+	t.Run("Error - Subscribe", func(t *testing.T) {
+		repo := &mocks.MockAnalysisRepository{
+			GetAnalysisByIDFunc: getAnalysis,
+		}
+		subscriber := &mocks.MockAnalysisEventSubscriber{
+			SubscribeAnalysisEventsFunc: func(ctx context.Context,
+				userID uuid.UUID) (<-chan models.AnalysisEvent, func() error,
+				error) {
+				return nil, nil, errors.New("redis down")
+			},
+		}
+
+		mockLogger, logs := testutils.NewMockLogger(zap.ErrorLevel)
+
+		svc := services.NewAnalysisEventService(repo, subscriber, mockLogger)
+		events, err := svc.SubscribeAnalysis(ctx, mock.ID, mock.UserID)
+
+		assert.ErrorIs(t, err, services.ErrInternal)
+		assert.Nil(t, events)
+		assert.Equal(t, 1, logs.Len())
+	})
+}
+
+func TestAnalysisEventSubscribeUser(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+
+	t.Run("Success", func(t *testing.T) {
+		subscriber := &mocks.MockAnalysisEventSubscriber{
+			SubscribeAnalysisEventsFunc: func(ctx context.Context,
+				id uuid.UUID) (<-chan models.AnalysisEvent, func() error,
+				error) {
+				assert.Equal(t, userID, id)
+				events := make(chan models.AnalysisEvent, 1)
+				events <- models.AnalysisEvent{UserID: userID,
+					Status: models.AnalysisStatusRunning}
+				close(events)
+				return events, func() error { return nil }, nil
+			},
+		}
+
+		svc := services.NewAnalysisEventService(
+			&mocks.MockAnalysisRepository{}, subscriber, zap.NewNop())
+		events, err := svc.SubscribeUser(ctx, userID)
+
+		assert.NoError(t, err)
+		assert.Len(t, collectEvents(events), 1)
+	})
+
+	t.Run("Error - Subscribe", func(t *testing.T) {
+		subscriber := &mocks.MockAnalysisEventSubscriber{
+			SubscribeAnalysisEventsFunc: func(ctx context.Context,
+				id uuid.UUID) (<-chan models.AnalysisEvent, func() error,
+				error) {
+				return nil, nil, errors.New("redis down")
+			},
+		}
+
+		mockLogger, logs := testutils.NewMockLogger(zap.ErrorLevel)
+
+		svc := services.NewAnalysisEventService(
+			&mocks.MockAnalysisRepository{}, subscriber, mockLogger)
+		events, err := svc.SubscribeUser(ctx, userID)
+
+		assert.ErrorIs(t, err, services.ErrInternal)
+		assert.Nil(t, events)
+		assert.Equal(t, 1, logs.Len())
+	})
+}
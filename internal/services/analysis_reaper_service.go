@@ -0,0 +1,282 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/CABGenOrg/cabgen_backend/internal/logging"
+	"github.com/CABGenOrg/cabgen_backend/internal/models"
+	"github.com/CABGenOrg/cabgen_backend/internal/pipeline"
+	"github.com/CABGenOrg/cabgen_backend/internal/queue/tasks"
+	"github.com/CABGenOrg/cabgen_backend/internal/repositories"
+	"github.com/google/uuid"
+	"github.com/hibiken/asynq"
+	"go.uber.org/zap"
+)
+
+// AnalysisHeartbeatWriter is used by the worker to tell it is still running
+// an analysis.
+type AnalysisHeartbeatWriter interface {
+	Beat(ctx context.Context, analysisID uuid.UUID,
+		step models.AnalysisStep) error
+	RemoveHeartbeat(ctx context.Context, analysisID uuid.UUID) error
+}
+
+type AnalysisHeartbeatReader interface {
+	GetHeartbeats(ctx context.Context) (
+		map[uuid.UUID]models.AnalysisHeartbeat, error)
+	RemoveHeartbeat(ctx context.Context, analysisID uuid.UUID) error
+}
+
+// Locker keeps work scheduled by every API replica to one of them at a time.
+type Locker interface {
+	TryLock(ctx context.Context) (func(context.Context) error, bool, error)
+}
+
+type AnalysisReaperService interface {
+	Reap(ctx context.Context) (int, error)
+}
+
+// analysisReaperService fails the RUNNING analyses whose worker stopped
+// sending heartbeats for TTL and, with Requeue, enqueues them again to
+// resume from their checkpoints.
+type analysisReaperService struct {
+	Repo        repositories.AnalysisRepository
+	Heartbeats  AnalysisHeartbeatReader
+	AsynqClient TaskEnqueuer
+	Canceller   TaskCanceller
+	Lock        Locker
+	Events      AnalysisEventPublisher
+	Logger      *zap.Logger
+	TTL         time.Duration
+	Requeue     bool
+}
+
+func NewAnalysisReaperService(repo repositories.AnalysisRepository,
+	heartbeats AnalysisHeartbeatReader, asynqClient TaskEnqueuer,
+	canceller TaskCanceller, lock Locker, events AnalysisEventPublisher,
+	logger *zap.Logger, ttl time.Duration,
+	requeue bool) AnalysisReaperService {
+	return &analysisReaperService{
+		Repo:        repo,
+		Heartbeats:  heartbeats,
+		AsynqClient: asynqClient,
+		Canceller:   canceller,
+		Lock:        lock,
+		Events:      events,
+		Logger:      logger,
+		TTL:         ttl,
+		Requeue:     requeue,
+	}
+}
+
+// Reap returns the number of analyses failed because their worker was lost.
+// Analyses without any heartbeat are given TTL from their start. Only the
+// replica holding the lock reaps; the others return 0.
+func (s *analysisReaperService) Reap(ctx context.Context) (int, error) {
+	unlock, locked, err := s.Lock.TryLock(ctx)
+	if err != nil {
+		s.Logger.Error("Service Error", logging.ServiceLogging(
+			"AnalysisReaperService", "Reap", logging.RedisLockError, err,
+		)...)
+		return 0, ErrInternal
+	}
+	if !locked {
+		return 0, nil
+	}
+	defer func() {
+		if err := unlock(context.WithoutCancel(ctx)); err != nil {
+			s.Logger.Warn("Service Warning", logging.ServiceLogging(
+				"AnalysisReaperService", "Reap", logging.RedisLockError, err,
+			)...)
+		}
+	}()
+
+	analyses, err := s.Repo.GetAnalysesByStatus(ctx,
+		models.AnalysisStatusRunning)
+	if err != nil {
+		s.Logger.Error("Service Error", logging.ServiceLogging(
+			"AnalysisReaperService", "Reap", logging.DatabaseError, err,
+		)...)
+		return 0, ErrInternal
+	}
+	if len(analyses) == 0 {
+		return 0, nil
+	}
+
+	heartbeats, err := s.Heartbeats.GetHeartbeats(ctx)
+	if err != nil {
+		s.Logger.Error("Service Error", logging.ServiceLogging(
+			"AnalysisReaperService", "Reap", logging.HeartbeatError, err,
+		)...)
+		return 0, ErrInternal
+	}
+
+	now := time.Now()
+	reaped := 0
+	for i := range analyses {
+		analysis := &analyses[i]
+
+		heartbeat, ok := heartbeats[analysis.ID]
+		lastSeen := heartbeat.LastSeen
+		if !ok {
+			if analysis.StartedAt == nil {
+				continue
+			}
+			lastSeen = *analysis.StartedAt
+		}
+		if now.Sub(lastSeen) <= s.TTL {
+			continue
+		}
+
+		if s.reap(ctx, analysis, heartbeat, lastSeen) {
+			reaped++
+		}
+	}
+
+	return reaped, nil
+}
+
+func (s *analysisReaperService) reap(ctx context.Context,
+	analysis *models.Analysis, heartbeat models.AnalysisHeartbeat,
+	lastSeen time.Time) bool {
+	s.Logger.Warn(fmt.Sprintf(
+		"%s: Worker lost, last seen %s ago", analysis.ID.String(),
+		time.Since(lastSeen).Round(time.Second)),
+		logging.ServiceInfoLogging("AnalysisReaperService", "reap",
+			logging.AnalysisReaped,
+			zap.String("analysis_id", analysis.ID.String()),
+			zap.String("worker_id", heartbeat.WorkerID),
+			zap.String("step", string(analysis.Step)),
+			zap.Time("last_seen", lastSeen),
+		)...)
+
+	// The worker may have finished, or the analysis been cancelled, since
+	// it was listed.
+	finished := time.Now()
+	message := pipeline.ErrWorkerLost.Error()
+	failed, err := s.Repo.UpdateAnalysisIfStatus(ctx, analysis.ID,
+		[]models.AnalysisStatus{models.AnalysisStatusRunning},
+		map[string]any{
+			"status":        models.AnalysisStatusFailed,
+			"error_message": message,
+			"finished_at":   finished,
+		})
+	if err != nil {
+		s.Logger.Error("Service Error", logging.ServiceLogging(
+			"AnalysisReaperService", "reap", logging.DatabaseError, err,
+		)...)
+		return false
+	}
+	if !failed {
+		return false
+	}
+	analysis.Status = models.AnalysisStatusFailed
+	analysis.ErrorMessage = &message
+	analysis.FinishedAt = &finished
+	s.publishEvent(ctx, analysis)
+
+	if err := s.Heartbeats.RemoveHeartbeat(ctx, analysis.ID); err != nil {
+		s.Logger.Warn("Service Warning", logging.ServiceLogging(
+			"AnalysisReaperService", "reap", logging.HeartbeatError, err,
+		)...)
+	}
+
+	if s.Requeue {
+		s.requeue(ctx, analysis)
+	}
+	return true
+}
+
+// requeue resumes the failed analysis from its checkpoints in a new task.
+// The lost task is deleted first, so asynq cannot run it again next to the
+// new one. While asynq still holds its lease it cannot be deleted; the
+// analysis then stays FAILED and asynq retries the lost task instead.
+func (s *analysisReaperService) requeue(ctx context.Context,
+	analysis *models.Analysis) {
+	if analysis.TaskID != nil {
+		err := s.Canceller.DeleteTask(tasks.QueueAnalysis, *analysis.TaskID)
+		if err != nil && !errors.Is(err, asynq.ErrTaskNotFound) {
+			s.Logger.Warn("Service Warning", logging.ServiceLogging(
+				"AnalysisReaperService", "requeue",
+				logging.TaskCancelError, err,
+			)...)
+			return
+		}
+	}
+
+	task, err := tasks.NewAnalysisResumeTask(analysis.ID)
+	if err != nil {
+		s.Logger.Error("Service Error", logging.ServiceLogging(
+			"AnalysisReaperService", "requeue", logging.AsynqTaskError, err,
+		)...)
+		return
+	}
+
+	info, err := s.AsynqClient.EnqueueContext(ctx, task,
+		asynq.Queue(tasks.QueueAnalysis))
+	if err != nil {
+		s.Logger.Error("Service Error", logging.ServiceLogging(
+			"AnalysisReaperService", "requeue",
+			logging.RedisDispatchError, err,
+		)...)
+		return
+	}
+	s.Logger.Info("Redis Task Info", logging.ServiceInfoLogging(
+		"AnalysisReaperService", "requeue",
+		logging.TaskEnqueuedSuccess, zap.String("task_id", info.ID),
+		zap.String("queue", info.Queue),
+		zap.String("analysis_id", analysis.ID.String()),
+	)...)
+
+	taskID := info.ID
+	requeued, err := s.Repo.UpdateAnalysisIfStatus(ctx, analysis.ID,
+		[]models.AnalysisStatus{models.AnalysisStatusFailed},
+		map[string]any{
+			"status":        models.AnalysisStatusPending,
+			"step":          models.AnalysisStep(""),
+			"error_message": nil,
+			"finished_at":   nil,
+			"task_id":       taskID,
+		})
+	if err != nil {
+		s.Logger.Error("Service Error", logging.ServiceLogging(
+			"AnalysisReaperService", "requeue", logging.DatabaseError, err,
+		)...)
+	}
+	if err != nil || !requeued {
+		// The new task is dropped with the requeue, which also happens when
+		// the analysis was resumed or deleted meanwhile.
+		if err := s.Canceller.DeleteTask(tasks.QueueAnalysis,
+			taskID); err != nil {
+			s.Logger.Warn("Service Warning", logging.ServiceLogging(
+				"AnalysisReaperService", "requeue",
+				logging.TaskCancelError, err,
+			)...)
+		}
+		return
+	}
+	analysis.Status = models.AnalysisStatusPending
+	analysis.Step = ""
+	analysis.ErrorMessage = nil
+	analysis.FinishedAt = nil
+	analysis.TaskID = &taskID
+	s.publishEvent(ctx, analysis)
+}
+
+func (s *analysisReaperService) publishEvent(ctx context.Context,
+	analysis *models.Analysis) {
+	if s.Events == nil {
+		return
+	}
+
+	if err := s.Events.PublishAnalysisEvent(ctx,
+		models.NewAnalysisEvent(analysis)); err != nil {
+		s.Logger.Warn("Service Warning", logging.ServiceLogging(
+			"AnalysisReaperService", "publishEvent",
+			logging.EventEmitterError, err,
+		)...)
+	}
+}
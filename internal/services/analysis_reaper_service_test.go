@@ -0,0 +1,425 @@
+package services_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/CABGenOrg/cabgen_backend/internal/models"
+	"github.com/CABGenOrg/cabgen_backend/internal/pipeline"
+	"github.com/CABGenOrg/cabgen_backend/internal/queue/tasks"
+	"github.com/CABGenOrg/cabgen_backend/internal/services"
+	"github.com/CABGenOrg/cabgen_backend/internal/testutils"
+	"github.com/CABGenOrg/cabgen_backend/internal/testutils/mocks"
+	testmodels "github.com/CABGenOrg/cabgen_backend/internal/testutils/models"
+	"github.com/google/uuid"
+	"github.com/hibiken/asynq"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+func newRunningAnalysis() models.Analysis {
+	analysis := testmodels.CreateMockAnalysis()
+	analysis.Status = models.AnalysisStatusRunning
+	analysis.Step = models.StepUnicycler
+	return analysis
+}
+
+func TestAnalysisReaperReap(t *testing.T) {
+	ctx := context.Background()
+	ttl := 2 * time.Minute
+
+	t.Run("Success - Expired Heartbeat", func(t *testing.T) {
+		mock := newRunningAnalysis()
+		var statuses []models.AnalysisStatus
+		var fields map[string]any
+		repo := &mocks.MockAnalysisRepository{
+			GetAnalysesByStatusFunc: func(_ context.Context,
+				status models.AnalysisStatus) ([]models.Analysis, error) {
+				assert.Equal(t, models.AnalysisStatusRunning, status)
+				return []models.Analysis{mock}, nil
+			},
+			UpdateAnalysisIfStatusFunc: func(_ context.Context,
+				analysisID uuid.UUID, from []models.AnalysisStatus,
+				updates map[string]any) (bool, error) {
+				assert.Equal(t, mock.ID, analysisID)
+				statuses = from
+				fields = updates
+				return true, nil
+			},
+		}
+		var removed []uuid.UUID
+		heartbeats := &mocks.MockAnalysisHeartbeats{
+			GetHeartbeatsFunc: func(_ context.Context) (
+				map[uuid.UUID]models.AnalysisHeartbeat, error) {
+				return map[uuid.UUID]models.AnalysisHeartbeat{
+					mock.ID: {
+						AnalysisID: mock.ID,
+						WorkerID:   "worker:1",
+						Step:       models.StepUnicycler,
+						LastSeen:   time.Now().Add(-time.Hour),
+					},
+				}, nil
+			},
+			RemoveHeartbeatFunc: func(_ context.Context,
+				analysisID uuid.UUID) error {
+				removed = append(removed, analysisID)
+				return nil
+			},
+		}
+		publisher := &mocks.MockAnalysisEventPublisher{}
+		logger, logs := testutils.NewMockLogger(zap.WarnLevel)
+
+		svc := services.NewAnalysisReaperService(repo, heartbeats,
+			&mocks.MockTaskEnqueuer{}, &mocks.MockTaskCanceller{},
+			&mocks.MockLocker{}, publisher, logger, ttl, false)
+		reaped, err := svc.Reap(ctx)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 1, reaped)
+		assert.Equal(t, []models.AnalysisStatus{
+			models.AnalysisStatusRunning,
+		}, statuses)
+		assert.Equal(t, models.AnalysisStatusFailed, fields["status"])
+		assert.Equal(t, pipeline.ErrWorkerLost.Error(),
+			fields["error_message"])
+		assert.NotNil(t, fields["finished_at"])
+		assert.Equal(t, []uuid.UUID{mock.ID}, removed)
+		if assert.Len(t, publisher.Events, 1) {
+			assert.Equal(t, models.AnalysisStatusFailed,
+				publisher.Events[0].Status)
+		}
+		if assert.Equal(t, 1, logs.Len()) {
+			fields := logs.All()[0].ContextMap()
+			assert.Equal(t, "worker:1", fields["worker_id"])
+			assert.Equal(t, mock.ID.String(), fields["analysis_id"])
+		}
+	})
+
+	t.Run("Success - Fresh Heartbeat", func(t *testing.T) {
+		mock := newRunningAnalysis()
+		repo := &mocks.MockAnalysisRepository{
+			GetAnalysesByStatusFunc: func(_ context.Context,
+				_ models.AnalysisStatus) ([]models.Analysis, error) {
+				return []models.Analysis{mock}, nil
+			},
+			UpdateAnalysisIfStatusFunc: func(_ context.Context, _ uuid.UUID,
+				_ []models.AnalysisStatus, _ map[string]any) (bool, error) {
+				t.Fatal("analysis with a fresh heartbeat was updated")
+				return false, nil
+			},
+		}
+		heartbeats := &mocks.MockAnalysisHeartbeats{
+			GetHeartbeatsFunc: func(_ context.Context) (
+				map[uuid.UUID]models.AnalysisHeartbeat, error) {
+				return map[uuid.UUID]models.AnalysisHeartbeat{
+					mock.ID: {AnalysisID: mock.ID, LastSeen: time.Now()},
+				}, nil
+			},
+		}
+
+		svc := services.NewAnalysisReaperService(repo, heartbeats,
+			&mocks.MockTaskEnqueuer{}, &mocks.MockTaskCanceller{},
+			&mocks.MockLocker{}, nil, zap.NewNop(), ttl, false)
+		reaped, err := svc.Reap(ctx)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 0, reaped)
+	})
+
+	t.Run("Success - Without Heartbeat", func(t *testing.T) {
+		stale := newRunningAnalysis()
+		recent := newRunningAnalysis()
+		startedAt := time.Now()
+		recent.StartedAt = &startedAt
+
+		var updated []uuid.UUID
+		repo := &mocks.MockAnalysisRepository{
+			GetAnalysesByStatusFunc: func(_ context.Context,
+				_ models.AnalysisStatus) ([]models.Analysis, error) {
+				return []models.Analysis{stale, recent}, nil
+			},
+			UpdateAnalysisIfStatusFunc: func(_ context.Context,
+				analysisID uuid.UUID, _ []models.AnalysisStatus,
+				_ map[string]any) (bool, error) {
+				updated = append(updated, analysisID)
+				return true, nil
+			},
+		}
+
+		svc := services.NewAnalysisReaperService(repo,
+			&mocks.MockAnalysisHeartbeats{}, &mocks.MockTaskEnqueuer{},
+			&mocks.MockTaskCanceller{}, &mocks.MockLocker{}, nil,
+			zap.NewNop(), ttl, false)
+		reaped, err := svc.Reap(ctx)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 1, reaped)
+		assert.Equal(t, []uuid.UUID{stale.ID}, updated)
+	})
+
+	t.Run("Success - Requeue", func(t *testing.T) {
+		mock := newRunningAnalysis()
+		lostTaskID := "lost-task-id"
+		mock.TaskID = &lostTaskID
+		var statuses []models.AnalysisStatus
+		var requeued map[string]any
+		repo := &mocks.MockAnalysisRepository{
+			GetAnalysesByStatusFunc: func(_ context.Context,
+				_ models.AnalysisStatus) ([]models.Analysis, error) {
+				return []models.Analysis{mock}, nil
+			},
+			UpdateAnalysisIfStatusFunc: func(_ context.Context, _ uuid.UUID,
+				from []models.AnalysisStatus,
+				fields map[string]any) (bool, error) {
+				statuses = append(statuses,
+					fields["status"].(models.AnalysisStatus))
+				if from[0] == models.AnalysisStatusFailed {
+					requeued = fields
+				}
+				return true, nil
+			},
+		}
+		var deleted []string
+		canceller := &mocks.MockTaskCanceller{
+			DeleteTaskFunc: func(queue, id string) error {
+				assert.Equal(t, tasks.QueueAnalysis, queue)
+				deleted = append(deleted, id)
+				return nil
+			},
+		}
+		var payload tasks.AnalysisProcessPayload
+		enqueuer := &mocks.MockTaskEnqueuer{
+			EnqueueContextFunc: func(_ context.Context, task *asynq.Task,
+				_ ...asynq.Option) (*asynq.TaskInfo, error) {
+				assert.Equal(t, tasks.TaskTypeAnalysisProcess, task.Type())
+				assert.NoError(t, json.Unmarshal(task.Payload(), &payload))
+				return &asynq.TaskInfo{
+					ID: "resume-task-id", Queue: tasks.QueueAnalysis,
+				}, nil
+			},
+		}
+		publisher := &mocks.MockAnalysisEventPublisher{}
+
+		svc := services.NewAnalysisReaperService(repo,
+			&mocks.MockAnalysisHeartbeats{}, enqueuer, canceller,
+			&mocks.MockLocker{}, publisher, zap.NewNop(), ttl, true)
+		reaped, err := svc.Reap(ctx)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 1, reaped)
+		assert.Equal(t, []models.AnalysisStatus{
+			models.AnalysisStatusFailed, models.AnalysisStatusPending,
+		}, statuses)
+		assert.Equal(t, mock.ID, payload.AnalysisID)
+		assert.True(t, payload.Resume)
+		// The lost task is deleted before its replacement is enqueued.
+		assert.Equal(t, []string{lostTaskID}, deleted)
+		if assert.NotNil(t, requeued) {
+			assert.Equal(t, "resume-task-id", requeued["task_id"])
+			assert.Nil(t, requeued["error_message"])
+			assert.Nil(t, requeued["finished_at"])
+			assert.Empty(t, requeued["step"])
+		}
+		assert.Len(t, publisher.Events, 2)
+	})
+
+	t.Run("Warning - Lost Task Still Leased", func(t *testing.T) {
+		mock := newRunningAnalysis()
+		lostTaskID := "lost-task-id"
+		mock.TaskID = &lostTaskID
+		var statuses []models.AnalysisStatus
+		repo := &mocks.MockAnalysisRepository{
+			GetAnalysesByStatusFunc: func(_ context.Context,
+				_ models.AnalysisStatus) ([]models.Analysis, error) {
+				return []models.Analysis{mock}, nil
+			},
+			UpdateAnalysisIfStatusFunc: func(_ context.Context, _ uuid.UUID,
+				_ []models.AnalysisStatus,
+				fields map[string]any) (bool, error) {
+				statuses = append(statuses,
+					fields["status"].(models.AnalysisStatus))
+				return true, nil
+			},
+		}
+		canceller := &mocks.MockTaskCanceller{
+			DeleteTaskFunc: func(_, _ string) error {
+				return errors.New("cannot delete task in active state")
+			},
+		}
+		enqueuer := &mocks.MockTaskEnqueuer{
+			EnqueueContextFunc: func(_ context.Context, _ *asynq.Task,
+				_ ...asynq.Option) (*asynq.TaskInfo, error) {
+				t.Fatal("a leased task must not get a replacement")
+				return nil, nil
+			},
+		}
+		logger, logs := testutils.NewMockLogger(zap.WarnLevel)
+
+		svc := services.NewAnalysisReaperService(repo,
+			&mocks.MockAnalysisHeartbeats{}, enqueuer, canceller,
+			&mocks.MockLocker{}, nil, logger, ttl, true)
+		reaped, err := svc.Reap(ctx)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 1, reaped)
+		assert.Equal(t, []models.AnalysisStatus{
+			models.AnalysisStatusFailed,
+		}, statuses)
+		assert.Equal(t, 2, logs.Len())
+	})
+
+	t.Run("Success - Finished Before Reaped", func(t *testing.T) {
+		mock := newRunningAnalysis()
+		repo := &mocks.MockAnalysisRepository{
+			GetAnalysesByStatusFunc: func(_ context.Context,
+				_ models.AnalysisStatus) ([]models.Analysis, error) {
+				return []models.Analysis{mock}, nil
+			},
+			UpdateAnalysisIfStatusFunc: func(_ context.Context, _ uuid.UUID,
+				_ []models.AnalysisStatus, _ map[string]any) (bool, error) {
+				return false, nil
+			},
+		}
+		publisher := &mocks.MockAnalysisEventPublisher{}
+
+		svc := services.NewAnalysisReaperService(repo,
+			&mocks.MockAnalysisHeartbeats{}, &mocks.MockTaskEnqueuer{},
+			&mocks.MockTaskCanceller{}, &mocks.MockLocker{}, publisher,
+			zap.NewNop(), ttl, true)
+		reaped, err := svc.Reap(ctx)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 0, reaped)
+		assert.Empty(t, publisher.Events)
+	})
+
+	t.Run("Success - Locked By Another Replica", func(t *testing.T) {
+		repo := &mocks.MockAnalysisRepository{
+			GetAnalysesByStatusFunc: func(_ context.Context,
+				_ models.AnalysisStatus) ([]models.Analysis, error) {
+				t.Fatal("analyses listed without the reaper lock")
+				return nil, nil
+			},
+		}
+		lock := &mocks.MockLocker{
+			TryLockFunc: func(_ context.Context) (
+				func(context.Context) error, bool, error) {
+				return nil, false, nil
+			},
+		}
+
+		svc := services.NewAnalysisReaperService(repo,
+			&mocks.MockAnalysisHeartbeats{}, &mocks.MockTaskEnqueuer{},
+			&mocks.MockTaskCanceller{}, lock, nil, zap.NewNop(), ttl, false)
+		reaped, err := svc.Reap(ctx)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 0, reaped)
+	})
+
+	t.Run("Warning - Requeue Failure Keeps Analysis Failed",
+		func(t *testing.T) {
+			mock := newRunningAnalysis()
+			var statuses []models.AnalysisStatus
+			repo := &mocks.MockAnalysisRepository{
+				GetAnalysesByStatusFunc: func(_ context.Context,
+					_ models.AnalysisStatus) ([]models.Analysis, error) {
+					return []models.Analysis{mock}, nil
+				},
+				UpdateAnalysisIfStatusFunc: func(_ context.Context,
+					_ uuid.UUID, _ []models.AnalysisStatus,
+					fields map[string]any) (bool, error) {
+					statuses = append(statuses,
+						fields["status"].(models.AnalysisStatus))
+					return true, nil
+				},
+			}
+			enqueuer := &mocks.MockTaskEnqueuer{
+				EnqueueContextFunc: func(_ context.Context, _ *asynq.Task,
+					_ ...asynq.Option) (*asynq.TaskInfo, error) {
+					return nil, errors.New("connection refused")
+				},
+			}
+
+			svc := services.NewAnalysisReaperService(repo,
+				&mocks.MockAnalysisHeartbeats{}, enqueuer,
+				&mocks.MockTaskCanceller{}, &mocks.MockLocker{}, nil,
+				zap.NewNop(), ttl, true)
+			reaped, err := svc.Reap(ctx)
+
+			assert.NoError(t, err)
+			assert.Equal(t, 1, reaped)
+			assert.Equal(t, []models.AnalysisStatus{
+				models.AnalysisStatusFailed,
+			}, statuses)
+		})
+
+	t.Run("Error - Get Analyses", func(t *testing.T) {
+		repo := &mocks.MockAnalysisRepository{
+			GetAnalysesByStatusFunc: func(_ context.Context,
+				_ models.AnalysisStatus) ([]models.Analysis, error) {
+				return nil, errors.New("db error")
+			},
+		}
+		logger, logs := testutils.NewMockLogger(zap.ErrorLevel)
+
+		svc := services.NewAnalysisReaperService(repo,
+			&mocks.MockAnalysisHeartbeats{}, &mocks.MockTaskEnqueuer{},
+			&mocks.MockTaskCanceller{}, &mocks.MockLocker{}, nil, logger,
+			ttl, false)
+		reaped, err := svc.Reap(ctx)
+
+		assert.ErrorIs(t, err, services.ErrInternal)
+		assert.Equal(t, 0, reaped)
+		assert.Equal(t, 1, logs.Len())
+	})
+
+	t.Run("Error - Lock", func(t *testing.T) {
+		lock := &mocks.MockLocker{
+			TryLockFunc: func(_ context.Context) (
+				func(context.Context) error, bool, error) {
+				return nil, false, errors.New("connection refused")
+			},
+		}
+		logger, logs := testutils.NewMockLogger(zap.ErrorLevel)
+
+		svc := services.NewAnalysisReaperService(
+			&mocks.MockAnalysisRepository{}, &mocks.MockAnalysisHeartbeats{},
+			&mocks.MockTaskEnqueuer{}, &mocks.MockTaskCanceller{}, lock, nil,
+			logger, ttl, false)
+		reaped, err := svc.Reap(ctx)
+
+		assert.ErrorIs(t, err, services.ErrInternal)
+		assert.Equal(t, 0, reaped)
+		assert.Equal(t, 1, logs.Len())
+	})
+
+	t.Run("Error - Get Heartbeats", func(t *testing.T) {
+		mock := newRunningAnalysis()
+		repo := &mocks.MockAnalysisRepository{
+			GetAnalysesByStatusFunc: func(_ context.Context,
+				_ models.AnalysisStatus) ([]models.Analysis, error) {
+				return []models.Analysis{mock}, nil
+			},
+		}
+		heartbeats := &mocks.MockAnalysisHeartbeats{
+			GetHeartbeatsFunc: func(_ context.Context) (
+				map[uuid.UUID]models.AnalysisHeartbeat, error) {
+				return nil, errors.New("connection refused")
+			},
+		}
+		logger, logs := testutils.NewMockLogger(zap.ErrorLevel)
+
+		svc := services.NewAnalysisReaperService(repo, heartbeats,
+			&mocks.MockTaskEnqueuer{}, &mocks.MockTaskCanceller{},
+			&mocks.MockLocker{}, nil, logger, ttl, false)
+		reaped, err := svc.Reap(ctx)
+
+		assert.ErrorIs(t, err, services.ErrInternal)
+		assert.Equal(t, 0, reaped)
+		assert.Equal(t, 1, logs.Len())
+	})
+}
@@ -9,8 +9,6 @@ import (
 	"os"
 	"path/filepath"
 	"runtime"
-	"strconv"
-	"strings"
 	"sync"
 	"time"
 
@@ -27,14 +25,13 @@ import (
 	"gorm.io/gorm"
 )
 
-const SecondarySpeciesContaminationThreshold = 5.0
-
 var (
 	versionsOnce sync.Once
 	versions     []pipeline.ToolVersion
 )
 
 type AnalysisRunnerFolders struct {
+	AnalysisDir string
 	QCDir       string
 	AssemblyDir string
 	AMRDir      string
@@ -43,6 +40,80 @@ type AnalysisRunnerFolders struct {
 
 type AnalysisRunnerService interface {
 	Run(ctx context.Context, analysisID uuid.UUID) error
+	Resume(ctx context.Context, analysisID uuid.UUID) error
+}
+
+// stepCheckpoints tracks whether checkpointed steps may still be restored.
+// Once a step has to run again, every step after it runs as well.
+type stepCheckpoints struct {
+	store  *pipeline.CheckpointStore
+	resume bool
+}
+
+type fastQCCheckpoint struct {
+	FastQC1 string                 `json:"fastqc1"`
+	FastQC2 string                 `json:"fastqc2"`
+	ReadQC  *pipeline.ReadQCReport `json:"read_qc,omitempty"`
+}
+
+type longReadQCCheckpoint struct {
+	ReadQC *pipeline.ReadQC `json:"read_qc"`
+}
+
+// analysisToolRecorder writes the output of every tool run of an analysis to
+// its logs folder and indexes it in the database.
+type analysisToolRecorder struct {
+	Repo       repositories.AnalysisRepository
+	Logger     *zap.Logger
+	AnalysisID uuid.UUID
+	LogDir     *pipeline.ToolLogDir
+}
+
+func (r *analysisToolRecorder) Record(ctx context.Context,
+	invocation pipeline.ToolInvocation) {
+	files, err := r.LogDir.Write(invocation)
+	if err != nil {
+		r.Logger.Warn(fmt.Sprintf(
+			"%s: Failed to write %s tool log", r.AnalysisID.String(),
+			invocation.Tool()),
+			logging.ServiceLogging(
+				"AnalysisRunnerService", "Record",
+				logging.ToolLogError, err,
+			)...)
+	}
+
+	log := models.NewAnalysisLog(r.AnalysisID, invocation, files)
+	if err := r.Repo.CreateAnalysisLog(ctx, &log); err != nil {
+		r.Logger.Warn(fmt.Sprintf(
+			"%s: Failed to index %s tool log", r.AnalysisID.String(),
+			invocation.Tool()),
+			logging.ServiceLogging(
+				"AnalysisRunnerService", "Record",
+				logging.DatabaseError, err,
+			)...)
+	}
+}
+
+// analysisRun is the state one run shares with its steps through the context.
+type analysisRun struct {
+	// stop ends the run once the analysis is no longer RUNNING.
+	stop context.CancelCauseFunc
+
+	// mu serializes step updates from concurrently running steps.
+	mu   sync.Mutex
+	step models.AnalysisStep
+}
+
+type analysisRunKey struct{}
+
+func withAnalysisRun(ctx context.Context,
+	run *analysisRun) context.Context {
+	return context.WithValue(ctx, analysisRunKey{}, run)
+}
+
+func analysisRunFrom(ctx context.Context) *analysisRun {
+	run, _ := ctx.Value(analysisRunKey{}).(*analysisRun)
+	return run
 }
 
 type analysisRunnerService struct {
@@ -50,6 +121,8 @@ type analysisRunnerService struct {
 	Pipeline    pipeline.CabgenPipeline
 	Commander   pipeline.Commander
 	AsynqClient TaskEnqueuer
+	Events      AnalysisEventPublisher
+	Heartbeats  AnalysisHeartbeatWriter
 	Logger      *zap.Logger
 	RootDir     string
 }
@@ -59,12 +132,16 @@ func NewAnalysisRunnerService(
 	pipeline pipeline.CabgenPipeline,
 	commander pipeline.Commander,
 	asynqClient TaskEnqueuer,
+	events AnalysisEventPublisher,
+	heartbeats AnalysisHeartbeatWriter,
 	logger *zap.Logger, rootDir string) AnalysisRunnerService {
 	return &analysisRunnerService{
 		Repo:        repo,
 		Pipeline:    pipeline,
 		Commander:   commander,
 		AsynqClient: asynqClient,
+		Events:      events,
+		Heartbeats:  heartbeats,
 		Logger:      logger,
 		RootDir:     rootDir,
 	}
@@ -91,7 +168,7 @@ func (s *analysisRunnerService) prepareFolders(
 	}
 
 	return &AnalysisRunnerFolders{
-		QCDir: qcDir, AssemblyDir: assemblyDir, AMRDir: amrDir,
+		AnalysisDir: rootDir, QCDir: qcDir, AssemblyDir: assemblyDir, AMRDir: amrDir,
 		ReportDir: reportDir,
 	}, nil
 }
@@ -103,54 +180,131 @@ func (s *analysisRunnerService) getVersions(ctx context.Context,
 	})
 }
 
+func (s *analysisRunnerService) restoreCheckpoint(analysis *models.Analysis,
+	checkpoints *stepCheckpoints, step models.AnalysisStep, dst any) bool {
+	if !checkpoints.resume {
+		return false
+	}
+
+	if !checkpoints.store.Load(string(step), dst) {
+		// Later steps consumed this step's outputs, so they must run again.
+		checkpoints.resume = false
+		return false
+	}
+
+	s.Logger.Info(
+		fmt.Sprintf("%s: Restored %s step from checkpoint",
+			analysis.ID.String(), step),
+		logging.ServiceInfoLogging("AnalysisRunnerService",
+			"restoreCheckpoint", logging.CheckpointRestored)...,
+	)
+	return true
+}
+
+func (s *analysisRunnerService) saveCheckpoint(analysis *models.Analysis,
+	checkpoints *stepCheckpoints, step models.AnalysisStep, outputs any,
+	files ...string) {
+	if err := checkpoints.store.Save(string(step), outputs,
+		files...); err != nil {
+		s.Logger.Warn(fmt.Sprintf(
+			"%s: Failed to save %s checkpoint", analysis.ID.String(), step),
+			logging.ServiceLogging(
+				"AnalysisRunnerService", "saveCheckpoint",
+				logging.CheckpointError, err,
+			)...)
+	}
+}
+
 func (s *analysisRunnerService) runFastQC(ctx context.Context,
-	analysis *models.Analysis, outputDir string) error {
-	s.updateStep(ctx, analysis, models.StepFastQC)
+	analysis *models.Analysis, results *models.AnalysisResults,
+	outputDir string, checkpoints *stepCheckpoints) error {
 	s.Logger.Info(
 		fmt.Sprintf("%s: Started FastQC step", analysis.ID.String()),
 		logging.ServiceInfoLogging("AnalysisRunnerService", "runFastQC",
 			"CabgenPipeline")...,
 	)
 
-	fastq1Path, ok := utils.ResolveSampleFilePath(
-		s.RootDir,
-		analysis.UserID.String(),
-		analysis.SampleID.String(),
-		*analysis.Sample.Fastq1,
-		"fastq", "",
-	)
-	if !ok {
-		return fmt.Errorf("fastq1 file not found: %s", *analysis.Sample.Fastq1)
-	}
-	fastq2Path, ok := utils.ResolveSampleFilePath(
-		s.RootDir,
-		analysis.UserID.String(),
-		analysis.SampleID.String(),
-		*analysis.Sample.Fastq2,
-		"fastq", "",
-	)
-	if !ok {
-		return fmt.Errorf("fastq2 file not found: %s", *analysis.Sample.Fastq2)
-	}
+	var outputs fastQCCheckpoint
+	if !s.restoreCheckpoint(analysis, checkpoints, models.StepFastQC,
+		&outputs) {
+		s.updateStep(ctx, analysis, models.StepFastQC)
 
-	fastqc1, fastqc2, err := s.Pipeline.RunFastQC(
-		ctx, fastq1Path, fastq2Path, outputDir)
-	if err != nil {
-		s.Logger.Error(fmt.Sprintf(
-			"%s: Failed FastQC step: %v", analysis.ID.String(), err),
-			logging.ServiceLogging(
-				"AnalysisRunnerService", "runFastQC",
-				logging.AnalysisRunError, err,
-			)...)
-		if isInputError(err) {
-			return err
+		fastq1Path, ok := utils.ResolveSampleFilePath(
+			s.RootDir,
+			analysis.UserID.String(),
+			analysis.SampleID.String(),
+			*analysis.Sample.Fastq1,
+			"fastq", "",
+		)
+		if !ok {
+			return fmt.Errorf("fastq1 file not found: %s", *analysis.Sample.Fastq1)
+		}
+		// Single-end samples have no fastq2.
+		var fastq2Path string
+		if analysis.Sample.Fastq2 != nil {
+			fastq2Path, ok = utils.ResolveSampleFilePath(
+				s.RootDir,
+				analysis.UserID.String(),
+				analysis.SampleID.String(),
+				*analysis.Sample.Fastq2,
+				"fastq", "",
+			)
+			if !ok {
+				return fmt.Errorf("fastq2 file not found: %s",
+					*analysis.Sample.Fastq2)
+			}
+		}
+
+		stepCtx, cancel := s.Pipeline.GetConfig().Resources.StepContext(ctx,
+			pipeline.StepNameFastQC)
+		fastqc1, fastqc2, err := s.Pipeline.RunFastQC(stepCtx, fastq1Path,
+			fastq2Path, outputDir)
+		limitErr := pipeline.ResourceError(stepCtx, err)
+		cancel()
+		if err != nil {
+			s.Logger.Error(fmt.Sprintf(
+				"%s: Failed FastQC step: %v", analysis.ID.String(), err),
+				logging.ServiceLogging(
+					"AnalysisRunnerService", "runFastQC",
+					logging.AnalysisRunError, err,
+				)...)
+			if limitErr != nil {
+				return limitErr
+			}
+			if pipeline.IsInputError(err) {
+				return err
+			}
+			return pipeline.ErrFastQC
+		}
+
+		outputs = fastQCCheckpoint{
+			FastQC1: fastqc1,
+			FastQC2: fastqc2,
+			ReadQC: &pipeline.ReadQCReport{
+				Read1: s.readQC(analysis, fastq1Path),
+			},
+		}
+		files := []string{fastqc1}
+		if fastq2Path != "" {
+			outputs.ReadQC.Read2 = s.readQC(analysis, fastq2Path)
+			files = append(files, fastqc2)
 		}
-		return pipeline.ErrFastQC
+		s.saveCheckpoint(analysis, checkpoints, models.StepFastQC, outputs,
+			files...)
 	}
 
-	analysis.FastQC1 = &fastqc1
-	analysis.FastQC2 = &fastqc2
-	if err := s.Repo.UpdateAnalysis(ctx, analysis); err != nil {
+	analysis.FastQC1 = &outputs.FastQC1
+	analysis.FastQC2 = nil
+	if outputs.FastQC2 != "" {
+		analysis.FastQC2 = &outputs.FastQC2
+	}
+	results.ReadQC = outputs.ReadQC
+	if err := s.updateRunning(ctx, analysis, map[string]any{
+		"fast_qc1": analysis.FastQC1,
+		"fast_qc2": analysis.FastQC2,
+	}); errors.Is(err, ErrAnalysisNotRunning) {
+		return err
+	} else if err != nil {
 		s.Logger.Error(fmt.Sprintf(
 			"%s: Failed to update analysis in FastQC step: %v",
 			analysis.ID.String(), err),
@@ -164,16 +318,86 @@ func (s *analysisRunnerService) runFastQC(ctx context.Context,
 	return nil
 }
 
+// readQC summarizes a read file next to the FastQC report. FastQC already
+// accepted the file, so a failure here is only logged.
+func (s *analysisRunnerService) readQC(analysis *models.Analysis,
+	fastqPath string) *pipeline.ReadQC {
+	qc, err := pipeline.GetReadQC(fastqPath)
+	if err != nil {
+		s.Logger.Warn(fmt.Sprintf(
+			"%s: Failed to summarize reads of %s: %v", analysis.ID.String(),
+			filepath.Base(fastqPath), err),
+			logging.ServiceLogging(
+				"AnalysisRunnerService", "readQC",
+				logging.AnalysisRunError, err,
+			)...)
+		return nil
+	}
+	return qc
+}
+
+// runLongReadQC summarizes the long reads. Unlike short reads there is no
+// FastQC report, so a file that cannot be read fails the analysis.
+func (s *analysisRunnerService) runLongReadQC(ctx context.Context,
+	analysis *models.Analysis, results *models.AnalysisResults,
+	checkpoints *stepCheckpoints) error {
+	s.Logger.Info(
+		fmt.Sprintf("%s: Started long-read QC step", analysis.ID.String()),
+		logging.ServiceInfoLogging("AnalysisRunnerService", "runLongReadQC",
+			"CabgenPipeline")...,
+	)
+
+	var outputs longReadQCCheckpoint
+	if !s.restoreCheckpoint(analysis, checkpoints, models.StepLongReadQC,
+		&outputs) {
+		s.updateStep(ctx, analysis, models.StepLongReadQC)
+
+		longReadsPath, ok := utils.ResolveSampleFilePath(
+			s.RootDir,
+			analysis.UserID.String(),
+			analysis.SampleID.String(),
+			*analysis.Sample.LongReads,
+			"fastq", "",
+		)
+		if !ok {
+			return fmt.Errorf("long reads file not found: %s",
+				*analysis.Sample.LongReads)
+		}
+
+		qc, err := pipeline.GetLongReadQC(longReadsPath)
+		if err != nil {
+			s.Logger.Error(fmt.Sprintf(
+				"%s: Failed long-read QC step: %v", analysis.ID.String(), err),
+				logging.ServiceLogging(
+					"AnalysisRunnerService", "runLongReadQC",
+					logging.AnalysisRunError, err,
+				)...)
+			return err
+		}
+
+		outputs = longReadQCCheckpoint{ReadQC: qc}
+		s.saveCheckpoint(analysis, checkpoints, models.StepLongReadQC,
+			outputs)
+	}
+
+	if results.ReadQC == nil {
+		results.ReadQC = &pipeline.ReadQCReport{}
+	}
+	results.ReadQC.LongReads = outputs.ReadQC
+
+	return nil
+}
+
 func (s *analysisRunnerService) runGenome(ctx context.Context,
 	analysis *models.Analysis, results *models.AnalysisResults,
-	folders *AnalysisRunnerFolders) error {
+	folders *AnalysisRunnerFolders, checkpoints *stepCheckpoints) error {
 	s.Logger.Info(
 		fmt.Sprintf("%s: Started Genome step", analysis.ID.String()),
 		logging.ServiceInfoLogging("AnalysisRunnerService", "runGenome",
 			"CabgenPipeline")...,
 	)
 
-	// Using 80% of total cores
+	// Using 80% of total cores unless the step limits set the threads
 	threads := int(math.Round(
 		(float64(runtime.NumCPU()) * 0.8) /
 			float64(config.AnalysisConcurrency)))
@@ -208,10 +432,29 @@ func (s *analysisRunnerService) runGenome(ctx context.Context,
 		}
 	}
 
-	if analysis.Sample.Fastq1 != nil && analysis.Sample.Fastq2 != nil &&
-		analysis.Sample.Fasta == nil {
-		s.updateStep(ctx, analysis, models.StepUnicycler)
-
+	data := pipeline.NewStepData()
+	if assemblyPath != nil {
+		data.Set(pipeline.KeyAssembly, *assemblyPath)
+	}
+	// Long-read assemblies ignore any short reads of the sample.
+	shortReads := analysis.Type != models.AnalysisTypeLongRead
+	longReads := analysis.Type == models.AnalysisTypeLongRead ||
+		analysis.Type == models.AnalysisTypeHybrid
+	if longReads && analysis.Sample.LongReads != nil {
+		longReadsPath, ok := utils.ResolveSampleFilePath(
+			s.RootDir,
+			analysis.UserID.String(),
+			analysis.SampleID.String(),
+			*analysis.Sample.LongReads,
+			"fastq", "",
+		)
+		if !ok {
+			return fmt.Errorf("long reads file not found: %s",
+				*analysis.Sample.LongReads)
+		}
+		data.Set(pipeline.KeyLongReads, longReadsPath)
+	}
+	if shortReads && analysis.Sample.Fastq1 != nil {
 		fastq1Path, ok := utils.ResolveSampleFilePath(
 			s.RootDir,
 			analysis.UserID.String(),
@@ -222,6 +465,11 @@ func (s *analysisRunnerService) runGenome(ctx context.Context,
 		if !ok {
 			return fmt.Errorf("fastq1 file not found: %s", *analysis.Sample.Fastq1)
 		}
+		data.Set(pipeline.KeyRead1, fastq1Path)
+	}
+	// Without fastq2 the reads are assembled as single-end.
+	if shortReads && analysis.Sample.Fastq1 != nil &&
+		analysis.Sample.Fastq2 != nil {
 		fastq2Path, ok := utils.ResolveSampleFilePath(
 			s.RootDir,
 			analysis.UserID.String(),
@@ -232,268 +480,332 @@ func (s *analysisRunnerService) runGenome(ctx context.Context,
 		if !ok {
 			return fmt.Errorf("fastq2 file not found: %s", *analysis.Sample.Fastq2)
 		}
-
-		assemblyOutPath := fmt.Sprintf("%s_assembly.fasta",
-			analysis.Sample.OriginCode)
-		assembly, err := s.Pipeline.RunUnicycler(ctx, threads,
-			fastq1Path, fastq2Path,
-			s.Pipeline.GetConfig().SpadesPath, folders.AssemblyDir,
-			assemblyOutPath)
-		if err != nil {
-			s.Logger.Error(fmt.Sprintf(
-				"%s: Failed Genome step - Unicycler: %v",
-				analysis.ID.String(), err),
-				logging.ServiceLogging(
-					"AnalysisRunnerService", "runGenome",
-					logging.AnalysisRunError, err,
-				)...)
-			if isInputError(err) {
-				return err
-			}
-			return pipeline.ErrUnicycler
-		}
-		assemblyPath = &assembly
-		assemblyFileName := filepath.Base(assembly)
-		analysis.Sample.Fasta = &assemblyFileName
-
-		if err := s.Repo.UpdateSample(ctx, &analysis.Sample); err != nil {
-			s.Logger.Warn(fmt.Sprintf(
-				"%s: Failed to persist assembly path to sample",
-				analysis.ID.String()),
-				logging.ServiceLogging(
-					"AnalysisRunnerService", "runGenome",
-					logging.AnalysisRunError, err,
-				)...)
-		}
+		data.Set(pipeline.KeyRead2, fastq2Path)
 	}
-
-	if assemblyPath == nil {
-		return fmt.Errorf("no input files: need FASTA or FASTQ pair")
-	}
-
-	prokkaOutDir := filepath.Join(folders.AssemblyDir, "prokka")
-	s.updateStep(ctx, analysis, models.StepProkka)
-	if err := s.Pipeline.RunProkka(ctx, threads, *assemblyPath,
-		prokkaOutDir); err != nil {
-		s.Logger.Error(fmt.Sprintf(
-			"%s: Failed Genome step - Prokka: %v", analysis.ID.String(),
-			err),
-			logging.ServiceLogging(
-				"AnalysisRunnerService", "runGenome",
-				logging.AnalysisRunError, err,
-			)...)
-		if isInputError(err) {
-			return err
+	if _, ok := data.Get(pipeline.KeyRead1); !ok && assemblyPath == nil {
+		if _, ok := data.Get(pipeline.KeyLongReads); !ok {
+			return fmt.Errorf("no input files: need FASTA, FASTQ or " +
+				"long reads")
 		}
-		return pipeline.ErrProkka
 	}
 
-	ext := filepath.Ext(*assemblyPath)
-	checkmSample := strings.TrimSuffix(filepath.Base(*assemblyPath), ext)
-	checkMOutput := filepath.Join(folders.AssemblyDir, "checkm_output")
-	s.updateStep(ctx, analysis, models.StepCheckM)
-	checkmResult, err := s.Pipeline.RunCheckM(ctx, threads, checkmSample,
-		folders.AssemblyDir, checkMOutput)
+	graph, err := pipeline.NewStepGraph(pipeline.GenomeSeeds,
+		pipeline.GenomeSteps(pipeline.StepEnv{
+			Pipeline:    s.Pipeline,
+			Threads:     threads,
+			SampleID:    analysis.SampleID.String(),
+			OriginCode:  analysis.Sample.OriginCode,
+			AssemblyDir: folders.AssemblyDir,
+			AMRDir:      folders.AMRDir,
+		})...)
 	if err != nil {
-		s.Logger.Error(fmt.Sprintf(
-			"%s: Failed Genome step - CheckM: %v", analysis.ID.String(),
-			err),
-			logging.ServiceLogging(
-				"AnalysisRunnerService", "runGenome",
-				logging.AnalysisRunError, err,
-			)...)
-		return pipeline.ErrCheckM
+		s.Logger.Error("Service Error", logging.ServiceLogging(
+			"AnalysisRunnerService", "runGenome",
+			logging.AnalysisRunError, err,
+		)...)
+		return err
 	}
 
-	if checkmResult != nil {
-		results.CheckMCompleteness = checkmResult.Completeness
-		results.CheckMContamination = checkmResult.Contamination
-		results.CheckMGenomeSize = checkmResult.GenomeSize
-		results.CheckMN50 = checkmResult.N50
-	}
+	runErr := graph.Run(ctx, data, pipeline.StepRunOptions{
+		Checkpoints: checkpoints.store,
+		Resume:      checkpoints.resume,
+		Resources:   s.Pipeline.GetConfig().Resources,
+		Hooks: pipeline.StepHooks{
+			OnStart: func(step string) {
+				s.updateStep(ctx, analysis, models.AnalysisStep(step))
+			},
+			OnRestore: func(step string) {
+				s.Logger.Info(
+					fmt.Sprintf("%s: Restored %s step from checkpoint",
+						analysis.ID.String(), step),
+					logging.ServiceInfoLogging("AnalysisRunnerService",
+						"runGenome", logging.CheckpointRestored)...,
+				)
+			},
+			OnError: func(step string, err error) {
+				s.Logger.Error(fmt.Sprintf(
+					"%s: Failed Genome step - %s: %v",
+					analysis.ID.String(), step, err),
+					logging.ServiceLogging(
+						"AnalysisRunnerService", "runGenome",
+						logging.AnalysisRunError, err,
+					)...)
+			},
+			OnCheckpointError: func(step string, err error) {
+				s.Logger.Warn(fmt.Sprintf(
+					"%s: Failed to save %s checkpoint",
+					analysis.ID.String(), step),
+					logging.ServiceLogging(
+						"AnalysisRunnerService", "runGenome",
+						logging.CheckpointError, err,
+					)...)
+			},
+		},
+	})
 
-	s.updateStep(ctx, analysis, models.StepKraken2)
-	krakenResult1, krakenResult2, err := s.Pipeline.RunKraken2(ctx, threads,
-		*assemblyPath, folders.AssemblyDir)
-	if err != nil {
-		s.Logger.Error(fmt.Sprintf(
-			"%s: Failed Genome step - Kraken2: %v", analysis.ID.String(),
-			err),
-			logging.ServiceLogging(
-				"AnalysisRunnerService", "runGenome",
-				logging.AnalysisRunError, err,
-			)...)
-		return pipeline.ErrKraken2
-	}
+	if assembly, ok := pipeline.Value[string](data,
+		pipeline.KeyAssembly); ok && analysis.Sample.Fasta == nil {
+		assemblyFileName := filepath.Base(assembly)
+		analysis.Sample.Fasta = &assemblyFileName
 
-	if krakenResult1 != nil {
-		s.updateStep(ctx, analysis, models.StepSpecies)
-		speciesResult, err := s.Pipeline.ProcessSpecies(ctx, threads,
-			analysis.SampleID.String(), krakenResult1.Name, *assemblyPath,
-			folders.AssemblyDir)
-		if err != nil {
-			s.Logger.Error(fmt.Sprintf(
-				"%s: Failed Genome step - Species: %v", analysis.ID.String(),
-				err),
+		if err := s.Repo.UpdateSample(ctx, &analysis.Sample); err != nil {
+			s.Logger.Warn(fmt.Sprintf(
+				"%s: Failed to persist assembly path to sample",
+				analysis.ID.String()),
 				logging.ServiceLogging(
 					"AnalysisRunnerService", "runGenome",
 					logging.AnalysisRunError, err,
 				)...)
-			return pipeline.ErrSpecies
-		}
-
-		if speciesResult != nil {
-			results.PrimarySpeciesName = speciesResult.DisplayName
-			results.MLST = speciesResult.MLSTSpecies
-			results.PoliMutations = speciesResult.PoliMutations
-			results.OtherMutations = speciesResult.OtherMutations
 		}
 	}
 
-	if krakenResult2 != nil {
-		contamination, err := strconv.ParseFloat(
-			results.CheckMContamination, 32)
-		if err != nil {
+	// Step data keys match the JSON names of the results.
+	if raw, err := json.Marshal(data.Snapshot()); err == nil {
+		if err := json.Unmarshal(raw, results); err != nil {
 			s.Logger.Warn(fmt.Sprintf(
-				"%s: Invalid CheckM contamination value: %q",
-				analysis.ID.String(), results.CheckMContamination),
+				"%s: Failed to map Genome step results: %v",
+				analysis.ID.String(), err),
 				logging.ServiceLogging(
 					"AnalysisRunnerService", "runGenome",
 					logging.AnalysisRunError, err,
 				)...)
 		}
-		if contamination > SecondarySpeciesContaminationThreshold {
-			results.SecondarySpeciesName = krakenResult2.Name
-		}
 	}
 
-	abricateInput := filepath.Join(prokkaOutDir, "genome.ffn")
-	abricateDBs := map[string]string{
-		"resfinder": filepath.Join(folders.AMRDir, fmt.Sprintf(
-			"%s_outAbricateRes", analysis.SampleID.String())),
-		"vfdb": filepath.Join(folders.AMRDir, fmt.Sprintf(
-			"%s_outAbricateVFDB", analysis.SampleID.String())),
-		"plasmidfinder": filepath.Join(folders.AMRDir, fmt.Sprintf(
-			"%s_outAbricatePlasmid", analysis.SampleID.String())),
-	}
-	s.updateStep(ctx, analysis, models.StepAbricate)
-	for db, outputFile := range abricateDBs {
-		if err := s.Pipeline.RunAbricate(ctx, threads, db, abricateInput,
-			outputFile); err != nil {
-			s.Logger.Error(fmt.Sprintf(
-				"%s: Failed Genome step - Abricate (%s): %v",
-				analysis.ID.String(), db, err),
-				logging.ServiceLogging(
-					"AnalysisRunnerService", "runGenome",
-					logging.AnalysisRunError, err,
-				)...)
-			return pipeline.ErrAbricate
-		}
+	params := pipeline.AnalysisParametersFromContext(ctx,
+		s.Pipeline.GetConfig().Parameters)
+	results.Parameters = &params
 
-		rawResult, err := pipeline.GetAbricateResult(outputFile)
-		if err != nil {
-			s.Logger.Error(fmt.Sprintf(
-				"%s: Failed Genome step - Abricate Result (%s): %v",
-				analysis.ID.String(), db, err),
-				logging.ServiceLogging(
-					"AnalysisRunnerService", "runGenome",
-					logging.AnalysisRunError, err,
-				)...)
-			return pipeline.ErrAbricate
-		}
+	return runErr
+}
 
-		switch db {
-		case "resfinder":
-			genes, err := pipeline.ProcessResfinder(rawResult,
-				s.Pipeline.GetConfig().ResfinderDBPath)
-			if err != nil {
-				s.Logger.Error(fmt.Sprintf(
-					"%s: Failed Genome step - ProcessResfinder: %v",
-					analysis.ID.String(), err),
-					logging.ServiceLogging(
-						"AnalysisRunnerService", "runGenome",
-						logging.AnalysisRunError, err,
-					)...)
-				return pipeline.ErrAbricate
-			}
-			results.AcquiredResistance = genes
-		case "vfdb":
-			results.VFDB = pipeline.ProcessVFDB(rawResult)
-		case "plasmidfinder":
-			results.PlasmidFinder = pipeline.ProcessPlasmidFinder(rawResult)
-		}
+func (s *analysisRunnerService) runComplete(ctx context.Context,
+	analysis *models.Analysis, results *models.AnalysisResults,
+	folders *AnalysisRunnerFolders, checkpoints *stepCheckpoints) error {
+
+	if err := s.runFastQC(ctx, analysis, results, folders.QCDir,
+		checkpoints); err != nil {
+		return err
 	}
 
-	genomeSize, _ := strconv.Atoi(results.CheckMGenomeSize)
-	if analysis.Sample.Fastq1 != nil && analysis.Sample.Fastq2 != nil &&
-		genomeSize > 0 {
-		s.updateStep(ctx, analysis, models.StepCoverage)
-		fastq1Path, ok := utils.ResolveSampleFilePath(
-			s.RootDir,
-			analysis.UserID.String(),
-			analysis.SampleID.String(),
-			*analysis.Sample.Fastq1,
-			"fastq", "",
-		)
-		if !ok {
-			return fmt.Errorf("fastq1 file not found: %s", *analysis.Sample.Fastq1)
-		}
-		fastq2Path, ok := utils.ResolveSampleFilePath(
-			s.RootDir,
-			analysis.UserID.String(),
-			analysis.SampleID.String(),
-			*analysis.Sample.Fastq2,
-			"fastq", "",
-		)
-		if !ok {
-			return fmt.Errorf("fastq2 file not found: %s", *analysis.Sample.Fastq2)
-		}
-		coverage, err := pipeline.CalculateCoverage(
-			fastq1Path, fastq2Path, int64(genomeSize))
-		if err != nil {
-			s.Logger.Error(fmt.Sprintf(
-				"%s: Failed Genome step - CalculateCoverage: %v",
-				analysis.ID.String(), err),
-				logging.ServiceLogging(
-					"AnalysisRunnerService", "runGenome",
-					logging.AnalysisRunError, err,
-				)...)
-		} else {
-			results.Coverage = coverage
-		}
+	if err := s.runGenome(ctx, analysis, results, folders,
+		checkpoints); err != nil {
+		return err
 	}
 
 	return nil
 }
 
-func (s *analysisRunnerService) runComplete(ctx context.Context,
+func (s *analysisRunnerService) runLongRead(ctx context.Context,
 	analysis *models.Analysis, results *models.AnalysisResults,
-	folders *AnalysisRunnerFolders) error {
+	folders *AnalysisRunnerFolders, checkpoints *stepCheckpoints) error {
+	if err := s.runLongReadQC(ctx, analysis, results,
+		checkpoints); err != nil {
+		return err
+	}
+
+	return s.runGenome(ctx, analysis, results, folders, checkpoints)
+}
 
-	if err := s.runFastQC(ctx, analysis, folders.QCDir); err != nil {
+func (s *analysisRunnerService) runHybrid(ctx context.Context,
+	analysis *models.Analysis, results *models.AnalysisResults,
+	folders *AnalysisRunnerFolders, checkpoints *stepCheckpoints) error {
+	if err := s.runFastQC(ctx, analysis, results, folders.QCDir,
+		checkpoints); err != nil {
 		return err
 	}
 
-	if err := s.runGenome(ctx, analysis, results, folders); err != nil {
+	if err := s.runLongReadQC(ctx, analysis, results,
+		checkpoints); err != nil {
 		return err
 	}
 
-	return nil
+	return s.runGenome(ctx, analysis, results, folders, checkpoints)
 }
 
 func (s *analysisRunnerService) updateStep(ctx context.Context,
 	analysis *models.Analysis, step models.AnalysisStep) {
+	current := analysisRunFrom(ctx)
+	current.mu.Lock()
+	defer current.mu.Unlock()
+
+	current.step = step
 	analysis.Step = step
-	if err := s.Repo.UpdateAnalysis(ctx, analysis); err != nil {
+	err := s.updateRunning(ctx, analysis, map[string]any{"step": step})
+	if errors.Is(err, ErrAnalysisNotRunning) {
+		return
+	}
+	if err != nil {
 		s.Logger.Warn("Service Warning", logging.ServiceLogging(
 			"AnalysisRunnerService", "updateStep",
 			logging.DatabaseError, err,
 		)...)
+		return
+	}
+	s.publishEvent(ctx, analysis)
+}
+
+// updateRunning writes the given columns while the analysis is RUNNING. Once
+// it no longer is, because it was cancelled or reaped, the run is stopped
+// and ErrAnalysisNotRunning returned.
+func (s *analysisRunnerService) updateRunning(ctx context.Context,
+	analysis *models.Analysis, fields map[string]any) error {
+	updated, err := s.Repo.UpdateAnalysisIfStatus(ctx, analysis.ID,
+		[]models.AnalysisStatus{models.AnalysisStatusRunning}, fields)
+	if err != nil {
+		return err
+	}
+	if !updated {
+		analysisRunFrom(ctx).stop(ErrAnalysisNotRunning)
+		return ErrAnalysisNotRunning
+	}
+
+	return nil
+}
+
+// startHeartbeat beats for the analysis every AnalysisHeartbeatInterval
+// until the returned func is called, which also removes the heartbeat, so
+// the reaper can tell a running analysis from one whose worker was lost.
+func (s *analysisRunnerService) startHeartbeat(ctx context.Context,
+	analysis *models.Analysis, current *analysisRun) func() {
+	if s.Heartbeats == nil {
+		return func() {}
+	}
+
+	beat := func() {
+		current.mu.Lock()
+		step := current.step
+		current.mu.Unlock()
+
+		if err := s.Heartbeats.Beat(ctx, analysis.ID, step); err != nil {
+			s.Logger.Warn("Service Warning", logging.ServiceLogging(
+				"AnalysisRunnerService", "startHeartbeat",
+				logging.HeartbeatError, err,
+			)...)
+		}
+	}
+	beat()
+
+	done := make(chan struct{})
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+		ticker := time.NewTicker(config.AnalysisHeartbeatInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				beat()
+			}
+		}
+	}()
+
+	return func() {
+		close(done)
+		<-stopped
+		if err := s.Heartbeats.RemoveHeartbeat(context.WithoutCancel(ctx),
+			analysis.ID); err != nil {
+			s.Logger.Warn("Service Warning", logging.ServiceLogging(
+				"AnalysisRunnerService", "startHeartbeat",
+				logging.HeartbeatError, err,
+			)...)
+		}
+	}
+}
+
+// abandoned reports whether the analysis left RUNNING while it ran, because
+// it was cancelled or reaped, as opposed to its task context ending for
+// another reason, like a worker shutdown. The outputs of a cancelled run are
+// removed; a reaped one is left to the run that took it over. The returned
+// error is the one the run ends with.
+func (s *analysisRunnerService) abandoned(ctx context.Context,
+	analysis *models.Analysis, folders *AnalysisRunnerFolders,
+	checkpoints *stepCheckpoints) (bool, error) {
+	stored, err := s.Repo.GetAnalysisByID(context.WithoutCancel(ctx),
+		analysis.ID)
+	if err != nil {
+		s.Logger.Warn("Service Warning", logging.ServiceLogging(
+			"AnalysisRunnerService", "abandoned",
+			logging.DatabaseError, err,
+		)...)
+		return false, nil
+	}
+	if stored.Status == models.AnalysisStatusRunning {
+		return false, nil
+	}
+
+	*analysis = *stored
+	if analysis.Status == models.AnalysisStatusCancelled {
+		s.cleanupCancelled(ctx, analysis, folders, checkpoints)
+		return true, ErrAnalysisCancelled
+	}
+
+	s.Logger.Info(fmt.Sprintf("Analysis %s is %s, dropping its run",
+		analysis.ID.String(), analysis.Status),
+		logging.ServiceInfoLogging("AnalysisRunnerService", "Run",
+			logging.AnalysisNotRunning,
+			zap.String("analysis_id", analysis.ID.String()),
+		)...)
+	return true, ErrAnalysisNotRunning
+}
+
+// cleanupCancelled removes the partial outputs and checkpoints of a cancelled
+// analysis. The tool logs are kept to show how far it went.
+func (s *analysisRunnerService) cleanupCancelled(ctx context.Context,
+	analysis *models.Analysis, folders *AnalysisRunnerFolders,
+	checkpoints *stepCheckpoints) {
+	ctx = context.WithoutCancel(ctx)
+
+	for _, dir := range []string{
+		folders.QCDir, folders.AssemblyDir, folders.AMRDir, folders.ReportDir,
+	} {
+		if err := os.RemoveAll(dir); err != nil {
+			s.Logger.Warn("Service Warning", logging.ServiceLogging(
+				"AnalysisRunnerService", "cleanupCancelled",
+				logging.DeleteFolderError, err,
+			)...)
+		}
+	}
+	if err := checkpoints.store.Clear(); err != nil {
+		s.Logger.Warn("Service Warning", logging.ServiceLogging(
+			"AnalysisRunnerService", "cleanupCancelled",
+			logging.CheckpointError, err,
+		)...)
 	}
+
+	s.Logger.Info(fmt.Sprintf("Analysis %s cancelled", analysis.ID.String()),
+		logging.ServiceInfoLogging("AnalysisRunnerService", "Run",
+			logging.AnalysisCancelled,
+			zap.String("analysis_id", analysis.ID.String()),
+		)...)
+	s.publishEvent(ctx, analysis)
 }
 
+// publishEvent notifies the clients watching the analysis of its current
+// status and step. Failures are only logged: the stored analysis stays the
+// source of truth.
+func (s *analysisRunnerService) publishEvent(ctx context.Context,
+	analysis *models.Analysis) {
+	if s.Events == nil {
+		return
+	}
+
+	if err := s.Events.PublishAnalysisEvent(ctx,
+		models.NewAnalysisEvent(analysis)); err != nil {
+		s.Logger.Warn("Service Warning", logging.ServiceLogging(
+			"AnalysisRunnerService", "publishEvent",
+			logging.EventEmitterError, err,
+		)...)
+	}
+}
+
+// finalizeAnalysis stores the outcome of the run. It reports false when it
+// could not, including when the analysis is no longer RUNNING.
 func (s *analysisRunnerService) finalizeAnalysis(ctx context.Context,
-	analysis *models.Analysis, results *models.AnalysisResults, runErr error) {
+	analysis *models.Analysis, results *models.AnalysisResults,
+	runErr error) bool {
 	finished := time.Now()
 	analysis.FinishedAt = &finished
 	analysis.Step = ""
@@ -508,6 +820,17 @@ func (s *analysisRunnerService) finalizeAnalysis(ctx context.Context,
 		analysis.Status = models.AnalysisStatusDone
 	}
 
+	copyReadQC(analysis, results)
+	results.QC = nil
+	results.Concordance = nil
+	analysis.QCVerdict = ""
+	analysis.SpeciesConcordance = ""
+	if analysis.Status == models.AnalysisStatusDone &&
+		analysis.Type != models.AnalysisTypeFastQC {
+		s.checkConcordance(analysis, results)
+		s.evaluateQC(ctx, analysis, results)
+	}
+
 	jsonData, err := json.Marshal(results)
 	if err != nil {
 		s.Logger.Warn(fmt.Sprintf(
@@ -525,11 +848,111 @@ func (s *analysisRunnerService) finalizeAnalysis(ctx context.Context,
 		s.zipAnalysisResults(analysis)
 	}
 
-	if err := s.Repo.UpdateAnalysis(ctx, analysis); err != nil {
+	updated, err := s.Repo.SaveAnalysisIfStatus(ctx, analysis,
+		[]models.AnalysisStatus{models.AnalysisStatusRunning})
+	if err != nil {
 		s.Logger.Error("Service Error", logging.ServiceLogging(
 			"AnalysisRunnerService", "Run",
 			logging.DatabaseError, err,
 		)...)
+		return false
+	}
+	if !updated {
+		return false
+	}
+	if analysis.Status == models.AnalysisStatusDone {
+		s.recordDatabases(ctx, analysis, results)
+	}
+	s.publishEvent(ctx, analysis)
+	return true
+}
+
+// recordDatabases stores the databases a finished analysis ran against so
+// the outdated ones can be queried. A failure only leaves the analysis out of
+// that list.
+func (s *analysisRunnerService) recordDatabases(ctx context.Context,
+	analysis *models.Analysis, results *models.AnalysisResults) {
+	databases := models.NewAnalysisDatabases(analysis.ID, results.Databases)
+	if err := s.Repo.ReplaceAnalysisDatabases(ctx, analysis.ID,
+		databases); err != nil {
+		s.Logger.Warn(fmt.Sprintf(
+			"%s: Failed to record analysis databases", analysis.ID.String()),
+			logging.ServiceLogging(
+				"AnalysisRunnerService", "recordDatabases",
+				logging.DatabaseError, err,
+			)...)
+	}
+}
+
+// copyReadQC copies the headline of the read QC to the analysis columns,
+// clearing them when the run has none.
+func copyReadQC(analysis *models.Analysis, results *models.AnalysisResults) {
+	analysis.ReadCount = nil
+	analysis.ReadMeanQuality = nil
+	analysis.ReadQ30Percent = nil
+	analysis.ReadMeanLength = nil
+
+	headline := results.ReadQC.Headline()
+	if headline == nil {
+		return
+	}
+	q30Percent := headline.Q30Fraction * 100
+	analysis.ReadCount = &headline.Reads
+	analysis.ReadMeanQuality = &headline.MeanPhred
+	analysis.ReadQ30Percent = &q30Percent
+	analysis.ReadMeanLength = &headline.MeanLength
+}
+
+// evaluateQC checks the results against the active QC rules. The analysis
+// stays without a verdict when the rules cannot be read.
+func (s *analysisRunnerService) evaluateQC(ctx context.Context,
+	analysis *models.Analysis, results *models.AnalysisResults) {
+	rules, err := s.Repo.GetActiveQCRules(ctx)
+	if err != nil {
+		s.Logger.Warn(fmt.Sprintf(
+			"%s: Failed to load QC rules", analysis.ID.String()),
+			logging.ServiceLogging(
+				"AnalysisRunnerService", "evaluateQC",
+				logging.DatabaseError, err,
+			)...)
+		return
+	}
+
+	qcRules := make([]pipeline.QCRule, len(rules))
+	for i, rule := range rules {
+		qcRules[i] = rule.ToPipelineRule()
+	}
+
+	report := pipeline.EvaluateQC(results.QCValues(), qcRules)
+	results.QC = &report
+	analysis.QCVerdict = report.Verdict
+}
+
+// checkConcordance compares the species declared for the sample with the one
+// identified by the pipeline.
+func (s *analysisRunnerService) checkConcordance(analysis *models.Analysis,
+	results *models.AnalysisResults) {
+	microorganism := analysis.Sample.Microorganism
+	declared := pipeline.SpeciesRef{Name: microorganism.Species}
+	if microorganism.TaxID != nil {
+		declared.TaxID = *microorganism.TaxID
+	}
+	detected := pipeline.SpeciesRef{
+		Name:  results.PrimarySpeciesName,
+		TaxID: results.Taxonomy.SpeciesTaxID(results.PrimarySpeciesName),
+	}
+
+	report := pipeline.CheckSpeciesConcordance(declared, detected)
+	results.Concordance = &report
+	analysis.SpeciesConcordance = report.Status
+
+	if report.Status == pipeline.SpeciesDiscordant {
+		s.Logger.Warn(fmt.Sprintf(
+			"%s: Declared species %q but detected %q",
+			analysis.ID.String(), declared.Name, detected.Name),
+			logging.ServiceInfoLogging("AnalysisRunnerService",
+				"checkConcordance", logging.SpeciesDiscordant)...,
+		)
 	}
 }
 
@@ -565,6 +988,16 @@ func (s *analysisRunnerService) zipAnalysisResults(
 
 func (s *analysisRunnerService) Run(ctx context.Context,
 	analysisID uuid.UUID) error {
+	return s.run(ctx, analysisID, false)
+}
+
+func (s *analysisRunnerService) Resume(ctx context.Context,
+	analysisID uuid.UUID) error {
+	return s.run(ctx, analysisID, true)
+}
+
+func (s *analysisRunnerService) run(ctx context.Context,
+	analysisID uuid.UUID, resume bool) error {
 	analysis, err := s.Repo.GetAnalysisByID(ctx, analysisID)
 	if errors.Is(err, gorm.ErrRecordNotFound) {
 		s.Logger.Error("Service Error", logging.ServiceLogging(
@@ -581,20 +1014,58 @@ func (s *analysisRunnerService) Run(ctx context.Context,
 		return ErrInternal
 	}
 
+	if analysis.Status == models.AnalysisStatusCancelled {
+		s.Logger.Info(fmt.Sprintf("Analysis %s was cancelled before it "+
+			"started", analysisID.String()),
+			logging.ServiceInfoLogging("AnalysisRunnerService", "Run",
+				logging.AnalysisCancelled)...)
+		return ErrAnalysisCancelled
+	}
+
 	s.getVersions(ctx)
 
+	// Failed analyses start again when asynq retries their task.
 	start := time.Now()
-	analysis.Status = models.AnalysisStatusRunning
-	analysis.StartedAt = &start
-	if err := s.Repo.UpdateAnalysis(ctx, analysis); err != nil {
+	started, err := s.Repo.UpdateAnalysisIfStatus(ctx, analysis.ID,
+		[]models.AnalysisStatus{
+			models.AnalysisStatusPending, models.AnalysisStatusRunning,
+			models.AnalysisStatusFailed,
+		}, map[string]any{
+			"status":     models.AnalysisStatusRunning,
+			"started_at": start,
+		})
+	if err != nil {
 		s.Logger.Error("Service Error", logging.ServiceLogging(
 			"AnalysisRunnerService", "Run",
 			logging.DatabaseError, err,
 		)...)
 		return ErrInternal
 	}
+	if !started {
+		s.Logger.Info(fmt.Sprintf("Analysis %s changed status before it "+
+			"started", analysisID.String()),
+			logging.ServiceInfoLogging("AnalysisRunnerService", "Run",
+				logging.AnalysisNotRunning)...)
+		return ErrAnalysisNotRunning
+	}
+	analysis.Status = models.AnalysisStatusRunning
+	analysis.StartedAt = &start
+	s.publishEvent(ctx, analysis)
+
+	runCtx, stop := context.WithCancelCause(ctx)
+	defer stop(nil)
+	current := &analysisRun{stop: stop, step: analysis.Step}
+
+	stopHeartbeat := s.startHeartbeat(ctx, analysis, current)
+	defer stopHeartbeat()
+
+	ctx = withAnalysisRun(runCtx, current)
 
 	var results models.AnalysisResults
+	// Databases are fingerprinted for every analysis: unlike the tools they
+	// may be updated while the worker runs.
+	results.Databases = pipeline.CollectDatabaseProvenance(ctx, s.Commander,
+		s.Pipeline.GetConfig())
 
 	folders, err := s.prepareFolders(analysis.UserID.String(),
 		analysis.SampleID.String(), analysis.ID.String())
@@ -609,9 +1080,40 @@ func (s *analysisRunnerService) Run(ctx context.Context,
 		return pipeline.ErrAnalysisRun
 	}
 
+	ctx = pipeline.WithAnalysisDir(ctx, folders.AnalysisDir)
+
+	params, err := analysis.Type.ParseParameters(analysis.Parameters)
+	if err != nil {
+		s.Logger.Warn("Service Warning", logging.ServiceLogging(
+			"AnalysisRunnerService", "Run",
+			logging.InvalidParametersError, err,
+		)...)
+		params = pipeline.AnalysisParameters{}
+	}
+	ctx = pipeline.WithAnalysisParameters(ctx, params)
+	ctx = pipeline.WithToolRecorder(ctx, &analysisToolRecorder{
+		Repo:       s.Repo,
+		Logger:     s.Logger,
+		AnalysisID: analysis.ID,
+		LogDir:     pipeline.NewToolLogDir(folders.AnalysisDir),
+	})
+
+	checkpoints := &stepCheckpoints{
+		store:  pipeline.NewCheckpointStore(folders.AnalysisDir),
+		resume: resume,
+	}
+	if !resume {
+		if err := checkpoints.store.Clear(); err != nil {
+			s.Logger.Warn("Service Warning", logging.ServiceLogging(
+				"AnalysisRunnerService", "Run",
+				logging.CheckpointError, err,
+			)...)
+		}
+	}
+
 	s.Logger.Info(
-		fmt.Sprintf("Analysis %s started (type: %s)", analysisID.String(),
-			analysis.Type),
+		fmt.Sprintf("Analysis %s started (type: %s, resume: %t)",
+			analysisID.String(), analysis.Type, resume),
 		logging.ServiceInfoLogging("AnalysisRunnerService", "Run",
 			"CabgenPipeline")...,
 	)
@@ -619,11 +1121,19 @@ func (s *analysisRunnerService) Run(ctx context.Context,
 	var runErr error
 	switch analysis.Type {
 	case models.AnalysisTypeFastQC:
-		runErr = s.runFastQC(ctx, analysis, folders.QCDir)
+		runErr = s.runFastQC(ctx, analysis, &results, folders.QCDir,
+			checkpoints)
 	case models.AnalysisTypeGenome:
-		runErr = s.runGenome(ctx, analysis, &results, folders)
+		runErr = s.runGenome(ctx, analysis, &results, folders, checkpoints)
 	case models.AnalysisTypeComplete:
-		runErr = s.runComplete(ctx, analysis, &results, folders)
+		runErr = s.runComplete(ctx, analysis, &results, folders,
+			checkpoints)
+	case models.AnalysisTypeLongRead:
+		runErr = s.runLongRead(ctx, analysis, &results, folders,
+			checkpoints)
+	case models.AnalysisTypeHybrid:
+		runErr = s.runHybrid(ctx, analysis, &results, folders,
+			checkpoints)
 	default:
 		s.Logger.Error(fmt.Sprintf(
 			"Analysis %s: unknown analysis type %s", analysisID.String(),
@@ -636,7 +1146,19 @@ func (s *analysisRunnerService) Run(ctx context.Context,
 		runErr = pipeline.ErrUnknownAnalysisType
 	}
 
-	s.finalizeAnalysis(ctx, analysis, &results, runErr)
+	if ctx.Err() != nil {
+		if abandoned, err := s.abandoned(ctx, analysis, folders,
+			checkpoints); abandoned {
+			return err
+		}
+	}
+
+	if !s.finalizeAnalysis(ctx, analysis, &results, runErr) {
+		if abandoned, err := s.abandoned(ctx, analysis, folders,
+			checkpoints); abandoned {
+			return err
+		}
+	}
 
 	shouldEnqueueEmail := runErr == nil
 	if !shouldEnqueueEmail {
@@ -693,10 +1215,3 @@ func (s *analysisRunnerService) Run(ctx context.Context,
 
 	return nil
 }
-
-func isInputError(err error) bool {
-	return errors.Is(err, pipeline.ErrCorruptedInput) ||
-		errors.Is(err, pipeline.ErrEmptyReads) ||
-		errors.Is(err, pipeline.ErrInvalidFormat) ||
-		errors.Is(err, pipeline.ErrFileNotFound)
-}
@@ -10,6 +10,7 @@ import (
 	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/CABGenOrg/cabgen_backend/internal/config"
 	"github.com/CABGenOrg/cabgen_backend/internal/models"
@@ -23,6 +24,8 @@ import (
 	"github.com/hibiken/asynq"
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+	"gorm.io/datatypes"
 	"gorm.io/gorm"
 )
 
@@ -45,19 +48,33 @@ func TestAnalysisRunnerRun(t *testing.T) {
 		createTestFastq(t, rootDir, mock.UserID, mock.SampleID, fq2)
 
 		updated := (*models.Analysis)(nil)
+		var databases []models.AnalysisDatabase
 		repo := &mocks.MockAnalysisRepository{
 			GetAnalysisByIDFunc: func(_ context.Context,
 				_ uuid.UUID) (*models.Analysis, error) {
 				mockCopy := mock
 				return &mockCopy, nil
 			},
-			UpdateAnalysisFunc: func(_ context.Context,
-				analysis *models.Analysis) error {
+			SaveAnalysisIfStatusFunc: func(_ context.Context,
+				analysis *models.Analysis,
+				_ []models.AnalysisStatus) (bool, error) {
 				updated = analysis
+				return true, nil
+			},
+			ReplaceAnalysisDatabasesFunc: func(_ context.Context,
+				analysisID uuid.UUID,
+				stored []models.AnalysisDatabase) error {
+				assert.Equal(t, mock.ID, analysisID)
+				databases = stored
 				return nil
 			},
 		}
-		pl := &mocks.MockCabgenPipeline{}
+		pl := &mocks.MockCabgenPipeline{
+			Config: pipeline.ToolsConfig{
+				ResfinderDBPath: newResfinderRef(t),
+				Species:         &pipeline.SpeciesRegistry{},
+			},
+		}
 		enqueuer := &mocks.MockTaskEnqueuer{
 			EnqueueContextFunc: func(_ context.Context,
 				task *asynq.Task, _ ...asynq.Option) (*asynq.TaskInfo,
@@ -68,7 +85,7 @@ func TestAnalysisRunnerRun(t *testing.T) {
 			},
 		}
 
-		svc := services.NewAnalysisRunnerService(repo, pl, &mocks.MockCommander{}, enqueuer,
+		svc := services.NewAnalysisRunnerService(repo, pl, &mocks.MockCommander{}, enqueuer, nil, nil,
 			zap.NewNop(), rootDir)
 		err := svc.Run(ctx, mock.ID)
 
@@ -81,8 +98,419 @@ func TestAnalysisRunnerRun(t *testing.T) {
 		assert.NotNil(t, updated.FastQC1)
 		assert.NotNil(t, updated.FastQC2)
 		assert.Empty(t, updated.Step)
+
+		var results models.AnalysisResults
+		assert.NoError(t, json.Unmarshal(updated.Metrics, &results))
+		if assert.NotNil(t, results.ReadQC) {
+			assert.Equal(t, int64(1), results.ReadQC.Read1.Reads)
+			assert.Equal(t, int64(4), results.ReadQC.Read2.Bases)
+			assert.Equal(t, 40.0, results.ReadQC.Read1.MeanPhred)
+			assert.Equal(t, 50.0, results.ReadQC.Read2.GCPercent)
+		}
+		if assert.NotNil(t, updated.ReadCount) &&
+			assert.NotNil(t, updated.ReadMeanLength) {
+			assert.Equal(t, int64(2), *updated.ReadCount)
+			assert.Equal(t, 4.0, *updated.ReadMeanLength)
+		}
+		assert.NotNil(t, updated.ReadMeanQuality)
+		assert.NotNil(t, updated.ReadQ30Percent)
+		if assert.Len(t, results.Databases, 1) {
+			assert.Equal(t, "resfinder_catalog", results.Databases[0].Name)
+			assert.NotEmpty(t, results.Databases[0].Checksum)
+		}
+		if assert.Len(t, databases, 1) {
+			assert.Equal(t, "resfinder_catalog", databases[0].Name)
+			assert.Equal(t, results.Databases[0].Checksum,
+				databases[0].Checksum)
+		}
+	})
+
+	t.Run("Success - Records Tool Logs", func(t *testing.T) {
+		rootDir := t.TempDir()
+		mock := testmodels.CreateMockAnalysis()
+		mock.Type = models.AnalysisTypeFastQC
+		mock.Status = models.AnalysisStatusPending
+		fq1, fq2 := "r1.fq", "r2.fq"
+		mock.Sample.Fastq1 = &fq1
+		mock.Sample.Fastq2 = &fq2
+		createTestFastq(t, rootDir, mock.UserID, mock.SampleID, fq1)
+		createTestFastq(t, rootDir, mock.UserID, mock.SampleID, fq2)
+
+		var logs []*models.AnalysisLog
+		repo := &mocks.MockAnalysisRepository{
+			GetAnalysisByIDFunc: func(_ context.Context,
+				_ uuid.UUID) (*models.Analysis, error) {
+				mockCopy := mock
+				return &mockCopy, nil
+			},
+			CreateAnalysisLogFunc: func(_ context.Context,
+				log *models.AnalysisLog) error {
+				logs = append(logs, log)
+				return nil
+			},
+		}
+		pl := &mocks.MockCabgenPipeline{
+			RunFastQCFunc: func(ctx context.Context, read1, read2,
+				outputDir string) (string, string, error) {
+				recorder := pipeline.ToolRecorderFromContext(ctx)
+				assert.NotNil(t, recorder)
+				recorder.Record(ctx, pipeline.ToolInvocation{
+					Step:      pipeline.StepFromContext(ctx),
+					Command:   []string{"fastqc", read1, read2},
+					Stdout:    "Analysis complete",
+					StartedAt: time.Now(),
+				})
+				return "qc1.html", "qc2.html", nil
+			},
+		}
+
+		svc := services.NewAnalysisRunnerService(repo, pl, &mocks.MockCommander{},
+			&mocks.MockTaskEnqueuer{}, nil, nil, zap.NewNop(), rootDir)
+		err := svc.Run(ctx, mock.ID)
+
+		assert.NoError(t, err)
+		assert.Len(t, logs, 1)
+		assert.Equal(t, models.StepFastQC, logs[0].Step)
+		assert.Equal(t, "fastqc", logs[0].Tool)
+		assert.Equal(t, mock.ID, logs[0].AnalysisID)
+
+		stdout, err := os.ReadFile(logs[0].StdoutPath)
+		assert.NoError(t, err)
+		assert.Equal(t, "Analysis complete", string(stdout))
+	})
+
+	t.Run("Success - Publishes Events", func(t *testing.T) {
+		rootDir := t.TempDir()
+		mock := testmodels.CreateMockAnalysis()
+		mock.Type = models.AnalysisTypeFastQC
+		mock.Status = models.AnalysisStatusPending
+		fq1, fq2 := "r1.fq", "r2.fq"
+		mock.Sample.Fastq1 = &fq1
+		mock.Sample.Fastq2 = &fq2
+		createTestFastq(t, rootDir, mock.UserID, mock.SampleID, fq1)
+		createTestFastq(t, rootDir, mock.UserID, mock.SampleID, fq2)
+
+		repo := &mocks.MockAnalysisRepository{
+			GetAnalysisByIDFunc: func(_ context.Context,
+				_ uuid.UUID) (*models.Analysis, error) {
+				mockCopy := mock
+				return &mockCopy, nil
+			},
+		}
+		publisher := &mocks.MockAnalysisEventPublisher{}
+
+		svc := services.NewAnalysisRunnerService(repo, &mocks.MockCabgenPipeline{},
+			&mocks.MockCommander{}, &mocks.MockTaskEnqueuer{}, publisher, nil,
+			zap.NewNop(), rootDir)
+		err := svc.Run(ctx, mock.ID)
+
+		assert.NoError(t, err)
+		assert.Len(t, publisher.Events, 3)
+		assert.Equal(t, models.AnalysisStatusRunning, publisher.Events[0].Status)
+		assert.Equal(t, models.StepFastQC, publisher.Events[1].Step)
+		assert.Equal(t, models.AnalysisStatusDone, publisher.Events[2].Status)
+		for _, event := range publisher.Events {
+			assert.Equal(t, mock.ID, event.AnalysisID)
+			assert.Equal(t, mock.UserID, event.UserID)
+		}
 	})
 
+	t.Run("Success - Writes Heartbeats", func(t *testing.T) {
+		rootDir := t.TempDir()
+		mock := testmodels.CreateMockAnalysis()
+		mock.Type = models.AnalysisTypeFastQC
+		mock.Status = models.AnalysisStatusPending
+		fq1, fq2 := "r1.fq", "r2.fq"
+		mock.Sample.Fastq1 = &fq1
+		mock.Sample.Fastq2 = &fq2
+		createTestFastq(t, rootDir, mock.UserID, mock.SampleID, fq1)
+		createTestFastq(t, rootDir, mock.UserID, mock.SampleID, fq2)
+
+		repo := &mocks.MockAnalysisRepository{
+			GetAnalysisByIDFunc: func(_ context.Context,
+				_ uuid.UUID) (*models.Analysis, error) {
+				mockCopy := mock
+				return &mockCopy, nil
+			},
+		}
+		var beats []uuid.UUID
+		var removed []uuid.UUID
+		heartbeats := &mocks.MockAnalysisHeartbeats{
+			BeatFunc: func(_ context.Context, analysisID uuid.UUID,
+				_ models.AnalysisStep) error {
+				beats = append(beats, analysisID)
+				return nil
+			},
+			RemoveHeartbeatFunc: func(_ context.Context,
+				analysisID uuid.UUID) error {
+				removed = append(removed, analysisID)
+				return nil
+			},
+		}
+
+		svc := services.NewAnalysisRunnerService(repo, &mocks.MockCabgenPipeline{},
+			&mocks.MockCommander{}, &mocks.MockTaskEnqueuer{}, nil, heartbeats,
+			zap.NewNop(), rootDir)
+		err := svc.Run(ctx, mock.ID)
+
+		assert.NoError(t, err)
+		if assert.NotEmpty(t, beats) {
+			assert.Equal(t, mock.ID, beats[0])
+		}
+		assert.Equal(t, []uuid.UUID{mock.ID}, removed)
+	})
+
+	t.Run("Cancelled - Before Start", func(t *testing.T) {
+		mock := testmodels.CreateMockAnalysis()
+		mock.Type = models.AnalysisTypeFastQC
+		mock.Status = models.AnalysisStatusCancelled
+
+		repo := &mocks.MockAnalysisRepository{
+			GetAnalysisByIDFunc: func(_ context.Context,
+				_ uuid.UUID) (*models.Analysis, error) {
+				mockCopy := mock
+				return &mockCopy, nil
+			},
+			UpdateAnalysisIfStatusFunc: func(_ context.Context, _ uuid.UUID,
+				_ []models.AnalysisStatus, _ map[string]any) (bool, error) {
+				t.Fatal("a cancelled analysis must not be updated")
+				return false, nil
+			},
+		}
+		pl := &mocks.MockCabgenPipeline{
+			RunFastQCFunc: func(_ context.Context, _, _,
+				_ string) (string, string, error) {
+				t.Fatal("a cancelled analysis must not run")
+				return "", "", nil
+			},
+		}
+
+		svc := services.NewAnalysisRunnerService(repo, pl, &mocks.MockCommander{},
+			&mocks.MockTaskEnqueuer{}, nil, nil, zap.NewNop(), t.TempDir())
+		err := svc.Run(ctx, mock.ID)
+
+		assert.ErrorIs(t, err, services.ErrAnalysisCancelled)
+	})
+
+	t.Run("Cancelled - While Running", func(t *testing.T) {
+		rootDir := t.TempDir()
+		mock := testmodels.CreateMockAnalysis()
+		mock.Type = models.AnalysisTypeFastQC
+		mock.Status = models.AnalysisStatusPending
+		fq1, fq2 := "r1.fq", "r2.fq"
+		mock.Sample.Fastq1 = &fq1
+		mock.Sample.Fastq2 = &fq2
+		createTestFastq(t, rootDir, mock.UserID, mock.SampleID, fq1)
+		createTestFastq(t, rootDir, mock.UserID, mock.SampleID, fq2)
+
+		cancelled := false
+		var statuses []models.AnalysisStatus
+		repo := &mocks.MockAnalysisRepository{
+			GetAnalysisByIDFunc: func(_ context.Context,
+				_ uuid.UUID) (*models.Analysis, error) {
+				mockCopy := mock
+				if cancelled {
+					mockCopy.Status = models.AnalysisStatusCancelled
+				}
+				return &mockCopy, nil
+			},
+			UpdateAnalysisIfStatusFunc: func(_ context.Context, _ uuid.UUID,
+				_ []models.AnalysisStatus, _ map[string]any) (bool, error) {
+				return !cancelled, nil
+			},
+			SaveAnalysisIfStatusFunc: func(_ context.Context,
+				analysis *models.Analysis,
+				_ []models.AnalysisStatus) (bool, error) {
+				statuses = append(statuses, analysis.Status)
+				return !cancelled, nil
+			},
+		}
+		runCtx, cancel := context.WithCancel(ctx)
+		defer cancel()
+		var qcDir string
+		pl := &mocks.MockCabgenPipeline{
+			RunFastQCFunc: func(ctx context.Context, _, _,
+				outputDir string) (string, string, error) {
+				qcDir = outputDir
+				assert.NoError(t, os.WriteFile(
+					filepath.Join(outputDir, "partial.html"), nil, 0644))
+				cancelled = true
+				cancel()
+				return "", "", ctx.Err()
+			},
+		}
+		publisher := &mocks.MockAnalysisEventPublisher{}
+
+		svc := services.NewAnalysisRunnerService(repo, pl, &mocks.MockCommander{},
+			&mocks.MockTaskEnqueuer{}, publisher, nil, zap.NewNop(), rootDir)
+		err := svc.Run(runCtx, mock.ID)
+
+		assert.ErrorIs(t, err, services.ErrAnalysisCancelled)
+		assert.NotEmpty(t, qcDir)
+		assert.NoDirExists(t, qcDir)
+		assert.NotContains(t, statuses, models.AnalysisStatusFailed)
+		if assert.NotEmpty(t, publisher.Events) {
+			last := publisher.Events[len(publisher.Events)-1]
+			assert.Equal(t, models.AnalysisStatusCancelled, last.Status)
+		}
+	})
+
+	t.Run("Cancelled - Step Update Stops Run", func(t *testing.T) {
+		rootDir := t.TempDir()
+		mock := testmodels.CreateMockAnalysis()
+		mock.Type = models.AnalysisTypeComplete
+		mock.Status = models.AnalysisStatusPending
+		fq1, fq2 := "r1.fq", "r2.fq"
+		mock.Sample.Fastq1 = &fq1
+		mock.Sample.Fastq2 = &fq2
+		createTestFastq(t, rootDir, mock.UserID, mock.SampleID, fq1)
+		createTestFastq(t, rootDir, mock.UserID, mock.SampleID, fq2)
+
+		// The analysis is cancelled while FastQC runs, but the task context
+		// is not: the worker only learns about it from its next write.
+		cancelled := false
+		var steps []models.AnalysisStep
+		repo := &mocks.MockAnalysisRepository{
+			GetAnalysisByIDFunc: func(_ context.Context,
+				_ uuid.UUID) (*models.Analysis, error) {
+				mockCopy := mock
+				if cancelled {
+					mockCopy.Status = models.AnalysisStatusCancelled
+				}
+				return &mockCopy, nil
+			},
+			UpdateAnalysisIfStatusFunc: func(_ context.Context, _ uuid.UUID,
+				_ []models.AnalysisStatus, fields map[string]any) (bool, error) {
+				if step, ok := fields["step"].(models.AnalysisStep); ok {
+					steps = append(steps, step)
+				}
+				return !cancelled, nil
+			},
+			SaveAnalysisIfStatusFunc: func(_ context.Context,
+				_ *models.Analysis,
+				_ []models.AnalysisStatus) (bool, error) {
+				t.Fatal("a cancelled analysis must not be finalized")
+				return false, nil
+			},
+		}
+		pl := &mocks.MockCabgenPipeline{
+			RunFastQCFunc: func(_ context.Context, _, _,
+				_ string) (string, string, error) {
+				cancelled = true
+				return "fastqc1.html", "fastqc2.html", nil
+			},
+			RunUnicyclerFunc: func(_ context.Context, _ int,
+				_ pipeline.UnicyclerReads, _, _, _ string) (string, error) {
+				t.Fatal("a cancelled analysis must not run its next step")
+				return "", nil
+			},
+		}
+
+		svc := services.NewAnalysisRunnerService(repo, pl, &mocks.MockCommander{},
+			&mocks.MockTaskEnqueuer{}, nil, nil, zap.NewNop(), rootDir)
+		err := svc.Run(ctx, mock.ID)
+
+		assert.ErrorIs(t, err, services.ErrAnalysisCancelled)
+		assert.Equal(t, []models.AnalysisStep{models.StepFastQC}, steps)
+	})
+
+	t.Run("Reaped - While Running", func(t *testing.T) {
+		rootDir := t.TempDir()
+		mock := testmodels.CreateMockAnalysis()
+		mock.Type = models.AnalysisTypeFastQC
+		mock.Status = models.AnalysisStatusPending
+		fq1, fq2 := "r1.fq", "r2.fq"
+		mock.Sample.Fastq1 = &fq1
+		mock.Sample.Fastq2 = &fq2
+		createTestFastq(t, rootDir, mock.UserID, mock.SampleID, fq1)
+		createTestFastq(t, rootDir, mock.UserID, mock.SampleID, fq2)
+
+		reaped := false
+		repo := &mocks.MockAnalysisRepository{
+			GetAnalysisByIDFunc: func(_ context.Context,
+				_ uuid.UUID) (*models.Analysis, error) {
+				mockCopy := mock
+				if reaped {
+					mockCopy.Status = models.AnalysisStatusPending
+				}
+				return &mockCopy, nil
+			},
+			SaveAnalysisIfStatusFunc: func(_ context.Context,
+				_ *models.Analysis,
+				_ []models.AnalysisStatus) (bool, error) {
+				return false, nil
+			},
+		}
+		var qcDir string
+		pl := &mocks.MockCabgenPipeline{
+			RunFastQCFunc: func(_ context.Context, _, _,
+				outputDir string) (string, string, error) {
+				qcDir = outputDir
+				reaped = true
+				return "fastqc1.html", "fastqc2.html", nil
+			},
+		}
+		enqueuer := &mocks.MockTaskEnqueuer{
+			EnqueueContextFunc: func(_ context.Context, _ *asynq.Task,
+				_ ...asynq.Option) (*asynq.TaskInfo, error) {
+				t.Fatal("a reaped run must not send the done email")
+				return nil, nil
+			},
+		}
+
+		svc := services.NewAnalysisRunnerService(repo, pl, &mocks.MockCommander{},
+			enqueuer, nil, nil, zap.NewNop(), rootDir)
+		err := svc.Run(ctx, mock.ID)
+
+		assert.ErrorIs(t, err, services.ErrAnalysisNotRunning)
+		// The run that took the analysis over owns its folders.
+		assert.DirExists(t, qcDir)
+	})
+
+	t.Run("Warning - Publish Failure Does Not Fail Analysis",
+		func(t *testing.T) {
+			rootDir := t.TempDir()
+			mock := testmodels.CreateMockAnalysis()
+			mock.Type = models.AnalysisTypeFastQC
+			mock.Status = models.AnalysisStatusPending
+			fq1, fq2 := "r1.fq", "r2.fq"
+			mock.Sample.Fastq1 = &fq1
+			mock.Sample.Fastq2 = &fq2
+			createTestFastq(t, rootDir, mock.UserID, mock.SampleID, fq1)
+			createTestFastq(t, rootDir, mock.UserID, mock.SampleID, fq2)
+
+			var updated *models.Analysis
+			repo := &mocks.MockAnalysisRepository{
+				GetAnalysisByIDFunc: func(_ context.Context,
+					_ uuid.UUID) (*models.Analysis, error) {
+					mockCopy := mock
+					return &mockCopy, nil
+				},
+				SaveAnalysisIfStatusFunc: func(_ context.Context,
+					analysis *models.Analysis,
+					_ []models.AnalysisStatus) (bool, error) {
+					updated = analysis
+					return true, nil
+				},
+			}
+			publisher := &mocks.MockAnalysisEventPublisher{
+				PublishAnalysisEventFunc: func(_ context.Context,
+					_ models.AnalysisEvent) error {
+					return errors.New("redis down")
+				},
+			}
+			mockLogger, logs := testutils.NewMockLogger(zap.WarnLevel)
+
+			svc := services.NewAnalysisRunnerService(repo,
+				&mocks.MockCabgenPipeline{}, &mocks.MockCommander{},
+				&mocks.MockTaskEnqueuer{}, publisher, nil, mockLogger, rootDir)
+			err := svc.Run(ctx, mock.ID)
+
+			assert.NoError(t, err)
+			assert.Equal(t, models.AnalysisStatusDone, updated.Status)
+			assert.Equal(t, 3, logs.FilterMessage("Service Warning").Len())
+		})
+
 	t.Run("Error - Not Found", func(t *testing.T) {
 		repo := &mocks.MockAnalysisRepository{
 			GetAnalysisByIDFunc: func(_ context.Context,
@@ -93,7 +521,7 @@ func TestAnalysisRunnerRun(t *testing.T) {
 		mockLogger, logs := testutils.NewMockLogger(zap.ErrorLevel)
 
 		svc := services.NewAnalysisRunnerService(repo, nil, &mocks.MockCommander{},
-			&mocks.MockTaskEnqueuer{}, mockLogger, t.TempDir())
+			&mocks.MockTaskEnqueuer{}, nil, nil, mockLogger, t.TempDir())
 		err := svc.Run(ctx, uuid.New())
 
 		assert.ErrorIs(t, err, services.ErrNotFound)
@@ -110,7 +538,7 @@ func TestAnalysisRunnerRun(t *testing.T) {
 		mockLogger, logs := testutils.NewMockLogger(zap.ErrorLevel)
 
 		svc := services.NewAnalysisRunnerService(repo, nil, &mocks.MockCommander{},
-			&mocks.MockTaskEnqueuer{}, mockLogger, t.TempDir())
+			&mocks.MockTaskEnqueuer{}, nil, nil, mockLogger, t.TempDir())
 		err := svc.Run(ctx, uuid.New())
 
 		assert.ErrorIs(t, err, services.ErrInternal)
@@ -131,15 +559,15 @@ func TestAnalysisRunnerRun(t *testing.T) {
 				mockCopy := mock
 				return &mockCopy, nil
 			},
-			UpdateAnalysisFunc: func(_ context.Context,
-				_ *models.Analysis) error {
-				return gorm.ErrInvalidTransaction
+			UpdateAnalysisIfStatusFunc: func(_ context.Context, _ uuid.UUID,
+				_ []models.AnalysisStatus, _ map[string]any) (bool, error) {
+				return false, gorm.ErrInvalidTransaction
 			},
 		}
 		mockLogger, logs := testutils.NewMockLogger(zap.ErrorLevel)
 
 		svc := services.NewAnalysisRunnerService(repo, nil, &mocks.MockCommander{},
-			&mocks.MockTaskEnqueuer{}, mockLogger, t.TempDir())
+			&mocks.MockTaskEnqueuer{}, nil, nil, mockLogger, t.TempDir())
 		err := svc.Run(ctx, mock.ID)
 
 		assert.ErrorIs(t, err, services.ErrInternal)
@@ -165,11 +593,18 @@ func TestAnalysisRunnerRun(t *testing.T) {
 				mockCopy := mock
 				return &mockCopy, nil
 			},
-			UpdateAnalysisFunc: func(_ context.Context,
-				analysis *models.Analysis) error {
+			UpdateAnalysisIfStatusFunc: func(_ context.Context, _ uuid.UUID,
+				_ []models.AnalysisStatus, fields map[string]any) (bool, error) {
+				if step, ok := fields["step"].(models.AnalysisStep); ok {
+					steps = append(steps, step)
+				}
+				return true, nil
+			},
+			SaveAnalysisIfStatusFunc: func(_ context.Context,
+				analysis *models.Analysis,
+				_ []models.AnalysisStatus) (bool, error) {
 				updated = analysis
-				steps = append(steps, analysis.Step)
-				return nil
+				return true, nil
 			},
 		}
 		pl := &mocks.MockCabgenPipeline{
@@ -181,7 +616,7 @@ func TestAnalysisRunnerRun(t *testing.T) {
 		mockLogger, _ := testutils.NewMockLogger(zap.ErrorLevel)
 
 		svc := services.NewAnalysisRunnerService(repo, pl, &mocks.MockCommander{},
-			&mocks.MockTaskEnqueuer{}, mockLogger, rootDir)
+			&mocks.MockTaskEnqueuer{}, nil, nil, mockLogger, rootDir)
 		err := svc.Run(ctx, mock.ID)
 
 		assert.ErrorIs(t, err, pipeline.ErrAnalysisRun)
@@ -213,10 +648,11 @@ func TestAnalysisRunnerRun(t *testing.T) {
 				mockCopy := mock
 				return &mockCopy, nil
 			},
-			UpdateAnalysisFunc: func(_ context.Context,
-				analysis *models.Analysis) error {
+			SaveAnalysisIfStatusFunc: func(_ context.Context,
+				analysis *models.Analysis,
+				_ []models.AnalysisStatus) (bool, error) {
 				updated = analysis
-				return nil
+				return true, nil
 			},
 		}
 		pl := &mocks.MockCabgenPipeline{
@@ -227,7 +663,7 @@ func TestAnalysisRunnerRun(t *testing.T) {
 		}
 
 		svc := services.NewAnalysisRunnerService(repo, pl, &mocks.MockCommander{},
-			&mocks.MockTaskEnqueuer{}, zap.NewNop(), rootDir)
+			&mocks.MockTaskEnqueuer{}, nil, nil, zap.NewNop(), rootDir)
 		err := svc.Run(ctx, mock.ID)
 
 		assert.ErrorIs(t, err, pipeline.ErrAnalysisRun)
@@ -240,6 +676,59 @@ func TestAnalysisRunnerRun(t *testing.T) {
 			pipeline.ErrFastQC.Error())
 	})
 
+	t.Run("Error - FastQC Timeout", func(t *testing.T) {
+		rootDir := t.TempDir()
+		mock := testmodels.CreateMockAnalysis()
+		mock.Type = models.AnalysisTypeFastQC
+		mock.Status = models.AnalysisStatusPending
+		fq1 := "r1.fq"
+		mock.Sample.Fastq1 = &fq1
+		mock.Sample.Fastq2 = nil
+		createTestFastq(t, rootDir, mock.UserID, mock.SampleID, fq1)
+
+		updated := (*models.Analysis)(nil)
+		repo := &mocks.MockAnalysisRepository{
+			GetAnalysisByIDFunc: func(_ context.Context,
+				_ uuid.UUID) (*models.Analysis, error) {
+				mockCopy := mock
+				return &mockCopy, nil
+			},
+			SaveAnalysisIfStatusFunc: func(_ context.Context,
+				analysis *models.Analysis,
+				_ []models.AnalysisStatus) (bool, error) {
+				updated = analysis
+				return true, nil
+			},
+		}
+		pl := &mocks.MockCabgenPipeline{
+			Config: pipeline.ToolsConfig{
+				Resources: &pipeline.ResourceLimits{
+					Steps: map[string]pipeline.StepResources{
+						pipeline.StepNameFastQC: {
+							Timeout: 10 * time.Millisecond,
+						},
+					},
+				},
+			},
+			RunFastQCFunc: func(ctx context.Context, read1, read2,
+				outputDir string) (string, string, error) {
+				<-ctx.Done()
+				return "", "", ctx.Err()
+			},
+		}
+
+		svc := services.NewAnalysisRunnerService(repo, pl, &mocks.MockCommander{},
+			&mocks.MockTaskEnqueuer{}, nil, nil, zap.NewNop(), rootDir)
+		err := svc.Run(ctx, mock.ID)
+
+		assert.ErrorIs(t, err, pipeline.ErrAnalysisRun)
+		assert.NotNil(t, updated)
+		assert.Equal(t, models.AnalysisStatusFailed, updated.Status)
+		assert.NotNil(t, updated.ErrorMessage)
+		assert.Equal(t, pipeline.ErrStepTimeout.Error(),
+			*updated.ErrorMessage)
+	})
+
 	t.Run("Error - Unicycler Input Error Preserved", func(t *testing.T) {
 		rootDir := t.TempDir()
 		mock := testmodels.CreateMockAnalysis()
@@ -259,22 +748,24 @@ func TestAnalysisRunnerRun(t *testing.T) {
 				mockCopy := mock
 				return &mockCopy, nil
 			},
-			UpdateAnalysisFunc: func(_ context.Context,
-				analysis *models.Analysis) error {
+			SaveAnalysisIfStatusFunc: func(_ context.Context,
+				analysis *models.Analysis,
+				_ []models.AnalysisStatus) (bool, error) {
 				updated = analysis
-				return nil
+				return true, nil
 			},
 		}
 		pl := &mocks.MockCabgenPipeline{
 			RunUnicyclerFunc: func(_ context.Context, threads int,
-				read1, read2, spadesPath, outputDir, outputFile string) (
+				reads pipeline.UnicyclerReads, spadesPath, outputDir,
+				outputFile string) (
 				string, error) {
 				return "", pipeline.ErrInvalidFormat
 			},
 		}
 
 		svc := services.NewAnalysisRunnerService(repo, pl, &mocks.MockCommander{},
-			&mocks.MockTaskEnqueuer{}, zap.NewNop(), rootDir)
+			&mocks.MockTaskEnqueuer{}, nil, nil, zap.NewNop(), rootDir)
 		err := svc.Run(ctx, mock.ID)
 
 		assert.ErrorIs(t, err, pipeline.ErrAnalysisRun)
@@ -305,10 +796,11 @@ func TestAnalysisRunnerRun(t *testing.T) {
 				mockCopy := mock
 				return &mockCopy, nil
 			},
-			UpdateAnalysisFunc: func(_ context.Context,
-				analysis *models.Analysis) error {
+			SaveAnalysisIfStatusFunc: func(_ context.Context,
+				analysis *models.Analysis,
+				_ []models.AnalysisStatus) (bool, error) {
 				updated = analysis
-				return nil
+				return true, nil
 			},
 		}
 		pl := &mocks.MockCabgenPipeline{
@@ -319,7 +811,7 @@ func TestAnalysisRunnerRun(t *testing.T) {
 		}
 
 		svc := services.NewAnalysisRunnerService(repo, pl, &mocks.MockCommander{},
-			&mocks.MockTaskEnqueuer{}, zap.NewNop(), rootDir)
+			&mocks.MockTaskEnqueuer{}, nil, nil, zap.NewNop(), rootDir)
 		err := svc.Run(ctx, mock.ID)
 
 		assert.ErrorIs(t, err, pipeline.ErrAnalysisRun)
@@ -349,22 +841,23 @@ func TestAnalysisRunnerRun(t *testing.T) {
 				mockCopy := mock
 				return &mockCopy, nil
 			},
-			UpdateAnalysisFunc: func(_ context.Context,
-				analysis *models.Analysis) error {
+			SaveAnalysisIfStatusFunc: func(_ context.Context,
+				analysis *models.Analysis,
+				_ []models.AnalysisStatus) (bool, error) {
 				updated = analysis
-				return nil
+				return true, nil
 			},
 		}
 		pl := &mocks.MockCabgenPipeline{
 			RunKraken2Func: func(_ context.Context, threads int,
-				assembly, outputDir string) (*pipeline.KrakenSpecies,
-				*pipeline.KrakenSpecies, error) {
-				return nil, nil, errors.New("kraken2 crashed")
+				assembly, outputDir string) (*pipeline.KrakenComposition,
+				error) {
+				return nil, errors.New("kraken2 crashed")
 			},
 		}
 
 		svc := services.NewAnalysisRunnerService(repo, pl, &mocks.MockCommander{},
-			&mocks.MockTaskEnqueuer{}, zap.NewNop(), rootDir)
+			&mocks.MockTaskEnqueuer{}, nil, nil, zap.NewNop(), rootDir)
 		err := svc.Run(ctx, mock.ID)
 
 		assert.ErrorIs(t, err, pipeline.ErrAnalysisRun)
@@ -394,19 +887,23 @@ func TestAnalysisRunnerRun(t *testing.T) {
 				mockCopy := mock
 				return &mockCopy, nil
 			},
-			UpdateAnalysisFunc: func(_ context.Context,
-				analysis *models.Analysis) error {
+			SaveAnalysisIfStatusFunc: func(_ context.Context,
+				analysis *models.Analysis,
+				_ []models.AnalysisStatus) (bool, error) {
 				updated = analysis
-				return nil
+				return true, nil
 			},
 		}
 		pl := &mocks.MockCabgenPipeline{
 			RunKraken2Func: func(_ context.Context, threads int,
-				assembly, outputDir string) (*pipeline.KrakenSpecies,
-				*pipeline.KrakenSpecies, error) {
-				return &pipeline.KrakenSpecies{
-					Name: "Escherichia coli", Count: 100,
-				}, nil, nil
+				assembly, outputDir string) (*pipeline.KrakenComposition,
+				error) {
+				return &pipeline.KrakenComposition{
+					Species: []pipeline.KrakenTaxon{{
+						Name: "Escherichia coli", CladeReads: 100,
+						Percent: 100,
+					}},
+				}, nil
 			},
 			ProcessSpeciesFunc: func(_ context.Context, threads int,
 				sampleID, mostCommon, assemblyPath, outputDir string) (
@@ -416,7 +913,7 @@ func TestAnalysisRunnerRun(t *testing.T) {
 		}
 
 		svc := services.NewAnalysisRunnerService(repo, pl, &mocks.MockCommander{},
-			&mocks.MockTaskEnqueuer{}, zap.NewNop(), rootDir)
+			&mocks.MockTaskEnqueuer{}, nil, nil, zap.NewNop(), rootDir)
 		err := svc.Run(ctx, mock.ID)
 
 		assert.ErrorIs(t, err, pipeline.ErrAnalysisRun)
@@ -446,10 +943,11 @@ func TestAnalysisRunnerRun(t *testing.T) {
 				mockCopy := mock
 				return &mockCopy, nil
 			},
-			UpdateAnalysisFunc: func(_ context.Context,
-				analysis *models.Analysis) error {
+			SaveAnalysisIfStatusFunc: func(_ context.Context,
+				analysis *models.Analysis,
+				_ []models.AnalysisStatus) (bool, error) {
 				updated = analysis
-				return nil
+				return true, nil
 			},
 		}
 
@@ -463,7 +961,7 @@ func TestAnalysisRunnerRun(t *testing.T) {
 		}
 
 		svc := services.NewAnalysisRunnerService(repo,
-			&mocks.MockCabgenPipeline{}, &mocks.MockCommander{}, enqueuer,
+			&mocks.MockCabgenPipeline{}, &mocks.MockCommander{}, enqueuer, nil, nil,
 			zap.NewNop(), rootDir)
 		err := svc.Run(ctx, mock.ID)
 
@@ -492,15 +990,16 @@ func TestAnalysisRunnerRun(t *testing.T) {
 				mockCopy := mock
 				return &mockCopy, nil
 			},
-			UpdateAnalysisFunc: func(_ context.Context,
-				analysis *models.Analysis) error {
+			SaveAnalysisIfStatusFunc: func(_ context.Context,
+				analysis *models.Analysis,
+				_ []models.AnalysisStatus) (bool, error) {
 				updated = analysis
-				return nil
+				return true, nil
 			},
 		}
 
 		svc := services.NewAnalysisRunnerService(repo,
-			&mocks.MockCabgenPipeline{}, &mocks.MockCommander{}, &mocks.MockTaskEnqueuer{},
+			&mocks.MockCabgenPipeline{}, &mocks.MockCommander{}, &mocks.MockTaskEnqueuer{}, nil, nil,
 			zap.NewNop(), "/nonexistent_root_no_perms/x")
 		err := svc.Run(ctx, mock.ID)
 
@@ -532,23 +1031,31 @@ func TestAnalysisRunnerRun(t *testing.T) {
 				mockCopy := mock
 				return &mockCopy, nil
 			},
-			UpdateAnalysisFunc: func(_ context.Context,
-				analysis *models.Analysis) error {
+			UpdateAnalysisIfStatusFunc: func(_ context.Context, _ uuid.UUID,
+				_ []models.AnalysisStatus, fields map[string]any) (bool, error) {
+				if step, ok := fields["step"].(models.AnalysisStep); ok {
+					steps = append(steps, step)
+				}
+				return true, nil
+			},
+			SaveAnalysisIfStatusFunc: func(_ context.Context,
+				analysis *models.Analysis,
+				_ []models.AnalysisStatus) (bool, error) {
 				updated = analysis
-				steps = append(steps, analysis.Step)
-				return nil
+				return true, nil
 			},
 		}
 		pl := &mocks.MockCabgenPipeline{
 			RunUnicyclerFunc: func(_ context.Context, threads int,
-				read1, read2, spadesPath, outputDir, outputFile string) (
+				reads pipeline.UnicyclerReads, spadesPath, outputDir,
+				outputFile string) (
 				string, error) {
 				return "", errors.New("spades missing")
 			},
 		}
 
 		svc := services.NewAnalysisRunnerService(repo, pl, &mocks.MockCommander{},
-			&mocks.MockTaskEnqueuer{}, zap.NewNop(), rootDir)
+			&mocks.MockTaskEnqueuer{}, nil, nil, zap.NewNop(), rootDir)
 		err := svc.Run(ctx, mock.ID)
 
 		assert.ErrorIs(t, err, pipeline.ErrAnalysisRun)
@@ -580,9 +1087,10 @@ func TestAnalysisRunnerRun(t *testing.T) {
 				mockCopy := mock
 				return &mockCopy, nil
 			},
-			UpdateAnalysisFunc: func(_ context.Context,
-				_ *models.Analysis) error {
-				return nil
+			SaveAnalysisIfStatusFunc: func(_ context.Context,
+				_ *models.Analysis,
+				_ []models.AnalysisStatus) (bool, error) {
+				return true, nil
 			},
 		}
 		enqueuer := &mocks.MockTaskEnqueuer{
@@ -595,7 +1103,7 @@ func TestAnalysisRunnerRun(t *testing.T) {
 		mockLogger, logs := testutils.NewMockLogger(zap.ErrorLevel)
 
 		svc := services.NewAnalysisRunnerService(repo,
-			&mocks.MockCabgenPipeline{}, &mocks.MockCommander{}, enqueuer, mockLogger,
+			&mocks.MockCabgenPipeline{}, &mocks.MockCommander{}, enqueuer, nil, nil, mockLogger,
 			rootDir)
 		err := svc.Run(ctx, mock.ID)
 
@@ -609,81 +1117,211 @@ func writeAbricateOutput(outputFile string) error {
 	return os.WriteFile(outputFile, []byte(line+"\n"), 0644)
 }
 
+func writeAMRFinderOutput(outputFile string) error {
+	report := strings.Join([]string{
+		"Contig id\tStart\tStop\tStrand\tElement symbol\tElement name\t" +
+			"Type\tSubtype\tClass\tSubclass\t% Coverage of reference\t" +
+			"% Identity to reference\tClosest reference accession",
+		"seq1\t1\t822\t+\tblaTEM-1\tclass A beta-lactamase TEM-1\tAMR\t" +
+			"AMR\tBETA-LACTAM\tBETA-LACTAM\t100.00\t100.00\tWP_000027057.1",
+		"seq1\t900\t3527\t+\tgyrA_S83L\tEscherichia coli gyrA\tAMR\t" +
+			"POINT\tQUINOLONE\tQUINOLONE\tNA\tNA\tWP_000072067.1",
+	}, "\n")
+	return os.WriteFile(outputFile, []byte(report+"\n"), 0644)
+}
+
 func newResfinderRef(t *testing.T) string {
 	t.Helper()
-	ref := strings.Repeat("P", 10000) + "\n" +
-		strings.Join([]string{"blaTEM", "", "", "", "", "", "", "",
-			"", "", "", "", "", "", "", "", "Ampicillin"}, "\t") + "\n"
-	path := filepath.Join(t.TempDir(), "resfinder_ref.txt")
+	ref := "Gene_accession no.\tClass\tPhenotype\tPMID\n" +
+		"blaTEM-1B_1_AY458016\tBeta-lactam\tAmpicillin\t16023012\n"
+	path := filepath.Join(t.TempDir(), "phenotypes.txt")
 	if err := os.WriteFile(path, []byte(ref), 0644); err != nil {
 		t.Fatal(err)
 	}
 	return path
 }
 
-func createTestFasta(t *testing.T, rootDir string,
-	userID, sampleID uuid.UUID, fileName, content string) string {
-	t.Helper()
-	fullPath := filepath.Join(rootDir, "uploads", "users",
-		userID.String(), "samples", sampleID.String(), fileName)
-	os.MkdirAll(filepath.Dir(fullPath), 0755)
-	if err := os.WriteFile(fullPath, []byte(content), 0644); err != nil {
-		t.Fatal(err)
-	}
-	return fileName
-}
+func createTestFasta(t *testing.T, rootDir string,
+	userID, sampleID uuid.UUID, fileName, content string) string {
+	t.Helper()
+	fullPath := filepath.Join(rootDir, "uploads", "users",
+		userID.String(), "samples", sampleID.String(), fileName)
+	os.MkdirAll(filepath.Dir(fullPath), 0755)
+	if err := os.WriteFile(fullPath, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return fileName
+}
+
+func createTestFastq(t *testing.T, rootDir string,
+	userID, sampleID uuid.UUID, fileName string) {
+	t.Helper()
+	fullPath := filepath.Join(rootDir, "uploads", "users",
+		userID.String(), "samples", sampleID.String(), fileName)
+	os.MkdirAll(filepath.Dir(fullPath), 0755)
+	if err := os.WriteFile(fullPath, []byte("@read\nATCG\n+\nIIII\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestAnalysisRunnerGenome(t *testing.T) {
+	ctx := context.Background()
+
+	originalConcurrency := config.AnalysisConcurrency
+	config.AnalysisConcurrency = 4
+	t.Cleanup(func() { config.AnalysisConcurrency = originalConcurrency })
+
+	t.Run("Success - Existing FASTA", func(t *testing.T) {
+		rootDir := t.TempDir()
+		mock := testmodels.CreateMockAnalysis()
+		mock.Type = models.AnalysisTypeGenome
+		mock.Status = models.AnalysisStatusPending
+		fq1, fq2 := "r1.fq", "r2.fq"
+		relFasta := createTestFasta(t, rootDir, mock.UserID,
+			mock.SampleID, "contigs.fasta", ">seq1\nATCGATCG\n")
+		mock.Sample.Fastq1 = &fq1
+		mock.Sample.Fastq2 = &fq2
+		mock.Sample.Fasta = &relFasta
+
+		unicyclerCalled := false
+		repo := &mocks.MockAnalysisRepository{
+			GetAnalysisByIDFunc: func(_ context.Context,
+				_ uuid.UUID) (*models.Analysis, error) {
+				mockCopy := mock
+				return &mockCopy, nil
+			},
+			SaveAnalysisIfStatusFunc: func(_ context.Context,
+				_ *models.Analysis,
+				_ []models.AnalysisStatus) (bool, error) {
+				return true, nil
+			},
+		}
+		pl := &mocks.MockCabgenPipeline{
+			Config: pipeline.ToolsConfig{
+				ResfinderDBPath: newResfinderRef(t),
+			},
+			RunUnicyclerFunc: func(_ context.Context, threads int,
+				reads pipeline.UnicyclerReads, spadesPath, outputDir,
+				outputFile string) (
+				string, error) {
+				unicyclerCalled = true
+				return "assembly.fa", nil
+			},
+			RunAbricateFunc: func(_ context.Context, threads int,
+				db, input, outputFile string) error {
+				return writeAbricateOutput(outputFile)
+			},
+		}
+
+		svc := services.NewAnalysisRunnerService(repo, pl, &mocks.MockCommander{},
+			&mocks.MockTaskEnqueuer{}, nil, nil, zap.NewNop(), rootDir)
+		err := svc.Run(ctx, mock.ID)
+
+		assert.NoError(t, err)
+		assert.False(t, unicyclerCalled,
+			"Unicycler should not run when Fasta already present")
+	})
+
+	t.Run("Success - Coverage From Assembly Length Without CheckM",
+		func(t *testing.T) {
+			rootDir := t.TempDir()
+			mock := testmodels.CreateMockAnalysis()
+			mock.Type = models.AnalysisTypeGenome
+			mock.Status = models.AnalysisStatusPending
+			fq1, fq2 := "r1.fq", "r2.fq"
+			createTestFastq(t, rootDir, mock.UserID, mock.SampleID, fq1)
+			createTestFastq(t, rootDir, mock.UserID, mock.SampleID, fq2)
+			relFasta := createTestFasta(t, rootDir, mock.UserID,
+				mock.SampleID, "contigs.fasta", ">seq1\nATCGATCG\n")
+			mock.Sample.Fastq1 = &fq1
+			mock.Sample.Fastq2 = &fq2
+			mock.Sample.Fasta = &relFasta
+
+			updated := (*models.Analysis)(nil)
+			repo := &mocks.MockAnalysisRepository{
+				GetAnalysisByIDFunc: func(_ context.Context,
+					_ uuid.UUID) (*models.Analysis, error) {
+					mockCopy := mock
+					return &mockCopy, nil
+				},
+				SaveAnalysisIfStatusFunc: func(_ context.Context,
+					analysis *models.Analysis,
+					_ []models.AnalysisStatus) (bool, error) {
+					updated = analysis
+					return true, nil
+				},
+			}
+			pl := &mocks.MockCabgenPipeline{
+				Config: pipeline.ToolsConfig{
+					ResfinderDBPath: newResfinderRef(t),
+				},
+				RunCheckMFunc: func(_ context.Context, threads int,
+					sample, assemblyDir, outputDir string) (
+					*pipeline.CheckMResult, error) {
+					return nil, nil
+				},
+				RunAbricateFunc: func(_ context.Context, threads int,
+					db, input, outputFile string) error {
+					return writeAbricateOutput(outputFile)
+				},
+			}
 
-func createTestFastq(t *testing.T, rootDir string,
-	userID, sampleID uuid.UUID, fileName string) {
-	t.Helper()
-	fullPath := filepath.Join(rootDir, "uploads", "users",
-		userID.String(), "samples", sampleID.String(), fileName)
-	os.MkdirAll(filepath.Dir(fullPath), 0755)
-	if err := os.WriteFile(fullPath, []byte("@read\nATCG\n+\nIIII\n"), 0644); err != nil {
-		t.Fatal(err)
-	}
-}
+			svc := services.NewAnalysisRunnerService(repo, pl,
+				&mocks.MockCommander{}, &mocks.MockTaskEnqueuer{}, nil, nil,
+				zap.NewNop(), rootDir)
+			err := svc.Run(ctx, mock.ID)
 
-func TestAnalysisRunnerGenome(t *testing.T) {
-	ctx := context.Background()
+			assert.NoError(t, err)
+			assert.NotNil(t, updated)
 
-	originalConcurrency := config.AnalysisConcurrency
-	config.AnalysisConcurrency = 4
-	t.Cleanup(func() { config.AnalysisConcurrency = originalConcurrency })
+			var results models.AnalysisResults
+			assert.NoError(t, json.Unmarshal(updated.Metrics, &results))
+			assert.Empty(t, results.CheckMGenomeSize)
+			assert.Equal(t, &pipeline.AssemblyStats{
+				Contigs: 1, TotalLength: 8, N50: 8, L50: 1, N90: 8,
+				GCPercent: 50, LongestContig: 8,
+			}, results.AssemblyStats)
+			// Two reads of 4 bases over the 8 bases of the assembly.
+			assert.Equal(t, 1.0, results.Coverage)
+		})
 
-	t.Run("Success - Existing FASTA", func(t *testing.T) {
+	t.Run("Error - CheckM Keeps Coverage", func(t *testing.T) {
 		rootDir := t.TempDir()
 		mock := testmodels.CreateMockAnalysis()
 		mock.Type = models.AnalysisTypeGenome
 		mock.Status = models.AnalysisStatusPending
 		fq1, fq2 := "r1.fq", "r2.fq"
+		createTestFastq(t, rootDir, mock.UserID, mock.SampleID, fq1)
+		createTestFastq(t, rootDir, mock.UserID, mock.SampleID, fq2)
 		relFasta := createTestFasta(t, rootDir, mock.UserID,
 			mock.SampleID, "contigs.fasta", ">seq1\nATCGATCG\n")
 		mock.Sample.Fastq1 = &fq1
 		mock.Sample.Fastq2 = &fq2
 		mock.Sample.Fasta = &relFasta
 
-		unicyclerCalled := false
+		var steps []models.AnalysisStep
 		repo := &mocks.MockAnalysisRepository{
 			GetAnalysisByIDFunc: func(_ context.Context,
 				_ uuid.UUID) (*models.Analysis, error) {
 				mockCopy := mock
 				return &mockCopy, nil
 			},
-			UpdateAnalysisFunc: func(_ context.Context,
-				_ *models.Analysis) error {
-				return nil
+			UpdateAnalysisIfStatusFunc: func(_ context.Context, _ uuid.UUID,
+				_ []models.AnalysisStatus, fields map[string]any) (bool, error) {
+				if step, ok := fields["step"].(models.AnalysisStep); ok {
+					steps = append(steps, step)
+				}
+				return true, nil
 			},
 		}
 		pl := &mocks.MockCabgenPipeline{
 			Config: pipeline.ToolsConfig{
 				ResfinderDBPath: newResfinderRef(t),
 			},
-			RunUnicyclerFunc: func(_ context.Context, threads int,
-				read1, read2, spadesPath, outputDir, outputFile string) (
-				string, error) {
-				unicyclerCalled = true
-				return "assembly.fa", nil
+			RunCheckMFunc: func(_ context.Context, threads int,
+				sample, assemblyDir, outputDir string) (
+				*pipeline.CheckMResult, error) {
+				return nil, fmt.Errorf("checkm db corrupt")
 			},
 			RunAbricateFunc: func(_ context.Context, threads int,
 				db, input, outputFile string) error {
@@ -691,13 +1329,13 @@ func TestAnalysisRunnerGenome(t *testing.T) {
 			},
 		}
 
-		svc := services.NewAnalysisRunnerService(repo, pl, &mocks.MockCommander{},
-			&mocks.MockTaskEnqueuer{}, zap.NewNop(), rootDir)
+		svc := services.NewAnalysisRunnerService(repo, pl,
+			&mocks.MockCommander{}, &mocks.MockTaskEnqueuer{}, nil, nil,
+			zap.NewNop(), rootDir)
 		err := svc.Run(ctx, mock.ID)
 
-		assert.NoError(t, err)
-		assert.False(t, unicyclerCalled,
-			"Unicycler should not run when Fasta already present")
+		assert.ErrorIs(t, err, pipeline.ErrAnalysisRun)
+		assert.Contains(t, steps, models.StepCoverage)
 	})
 
 	t.Run("Success - FASTA Only Copies to AssemblyDir", func(t *testing.T) {
@@ -719,9 +1357,10 @@ func TestAnalysisRunnerGenome(t *testing.T) {
 				mockCopy := mock
 				return &mockCopy, nil
 			},
-			UpdateAnalysisFunc: func(_ context.Context,
-				_ *models.Analysis) error {
-				return nil
+			SaveAnalysisIfStatusFunc: func(_ context.Context,
+				_ *models.Analysis,
+				_ []models.AnalysisStatus) (bool, error) {
+				return true, nil
 			},
 		}
 		pl := &mocks.MockCabgenPipeline{
@@ -735,7 +1374,7 @@ func TestAnalysisRunnerGenome(t *testing.T) {
 		}
 
 		svc := services.NewAnalysisRunnerService(repo, pl, &mocks.MockCommander{},
-			&mocks.MockTaskEnqueuer{}, zap.NewNop(), rootDir)
+			&mocks.MockTaskEnqueuer{}, nil, nil, zap.NewNop(), rootDir)
 		err := svc.Run(ctx, mock.ID)
 
 		assert.NoError(t, err)
@@ -779,9 +1418,10 @@ func TestAnalysisRunnerGenome(t *testing.T) {
 				mockCopy := mock
 				return &mockCopy, nil
 			},
-			UpdateAnalysisFunc: func(_ context.Context,
-				_ *models.Analysis) error {
-				return nil
+			SaveAnalysisIfStatusFunc: func(_ context.Context,
+				_ *models.Analysis,
+				_ []models.AnalysisStatus) (bool, error) {
+				return true, nil
 			},
 		}
 		pl := &mocks.MockCabgenPipeline{
@@ -795,7 +1435,7 @@ func TestAnalysisRunnerGenome(t *testing.T) {
 		}
 
 		svc := services.NewAnalysisRunnerService(repo, pl,
-			&mocks.MockCommander{}, &mocks.MockTaskEnqueuer{},
+			&mocks.MockCommander{}, &mocks.MockTaskEnqueuer{}, nil, nil,
 			zap.NewNop(), rootDir)
 		err := svc.Run(ctx, mock.ID)
 
@@ -824,9 +1464,10 @@ func TestAnalysisRunnerGenome(t *testing.T) {
 				mockCopy := mock
 				return &mockCopy, nil
 			},
-			UpdateAnalysisFunc: func(_ context.Context,
-				_ *models.Analysis) error {
-				return nil
+			SaveAnalysisIfStatusFunc: func(_ context.Context,
+				_ *models.Analysis,
+				_ []models.AnalysisStatus) (bool, error) {
+				return true, nil
 			},
 			UpdateSampleFunc: func(_ context.Context,
 				sample *models.Sample) error {
@@ -839,7 +1480,8 @@ func TestAnalysisRunnerGenome(t *testing.T) {
 				ResfinderDBPath: newResfinderRef(t),
 			},
 			RunUnicyclerFunc: func(_ context.Context, threads int,
-				read1, read2, spadesPath, outputDir, outputFile string) (
+				reads pipeline.UnicyclerReads, spadesPath, outputDir,
+				outputFile string) (
 				string, error) {
 				unicyclerCalled = true
 				capturedOutputFile = outputFile
@@ -852,7 +1494,7 @@ func TestAnalysisRunnerGenome(t *testing.T) {
 		}
 
 		svc := services.NewAnalysisRunnerService(repo, pl, &mocks.MockCommander{},
-			&mocks.MockTaskEnqueuer{}, zap.NewNop(), t.TempDir())
+			&mocks.MockTaskEnqueuer{}, nil, nil, zap.NewNop(), t.TempDir())
 		err := svc.Run(ctx, mock.ID)
 
 		assert.NoError(t, err)
@@ -865,6 +1507,282 @@ func TestAnalysisRunnerGenome(t *testing.T) {
 			capturedOutputFile)
 	})
 
+	t.Run("Success - Long Reads Only", func(t *testing.T) {
+		rootDir := t.TempDir()
+		mock := testmodels.CreateMockAnalysis()
+		mock.Type = models.AnalysisTypeLongRead
+		mock.Status = models.AnalysisStatusPending
+		fq1, fq2, longReads := "r1.fq", "r2.fq", "nanopore.fq"
+		mock.Sample.Fastq1 = &fq1
+		mock.Sample.Fastq2 = &fq2
+		mock.Sample.LongReads = &longReads
+		mock.Sample.Fasta = nil
+		createTestFastq(t, rootDir, mock.UserID, mock.SampleID, longReads)
+
+		var finalMetrics datatypes.JSON
+		var steps []models.AnalysisStep
+		repo := &mocks.MockAnalysisRepository{
+			GetAnalysisByIDFunc: func(_ context.Context,
+				_ uuid.UUID) (*models.Analysis, error) {
+				mockCopy := mock
+				return &mockCopy, nil
+			},
+			UpdateAnalysisIfStatusFunc: func(_ context.Context, _ uuid.UUID,
+				_ []models.AnalysisStatus, fields map[string]any) (bool, error) {
+				if step, ok := fields["step"].(models.AnalysisStep); ok {
+					if len(steps) == 0 || steps[len(steps)-1] != step {
+						steps = append(steps, step)
+					}
+				}
+				return true, nil
+			},
+			SaveAnalysisIfStatusFunc: func(_ context.Context,
+				analysis *models.Analysis,
+				_ []models.AnalysisStatus) (bool, error) {
+				finalMetrics = analysis.Metrics
+				return true, nil
+			},
+		}
+		var usedReads pipeline.UnicyclerReads
+		fastQCCalled := false
+		pl := &mocks.MockCabgenPipeline{
+			Config: pipeline.ToolsConfig{
+				ResfinderDBPath: newResfinderRef(t),
+			},
+			RunFastQCFunc: func(_ context.Context, read1, read2,
+				outputDir string) (string, string, error) {
+				fastQCCalled = true
+				return "", "", nil
+			},
+			RunUnicyclerFunc: func(_ context.Context, threads int,
+				reads pipeline.UnicyclerReads, spadesPath, outputDir,
+				outputFile string) (string, error) {
+				usedReads = reads
+				return "assembly.fa", nil
+			},
+			RunAbricateFunc: func(_ context.Context, threads int,
+				db, input, outputFile string) error {
+				return writeAbricateOutput(outputFile)
+			},
+		}
+
+		svc := services.NewAnalysisRunnerService(repo, pl, &mocks.MockCommander{},
+			&mocks.MockTaskEnqueuer{}, nil, nil, zap.NewNop(), rootDir)
+		err := svc.Run(ctx, mock.ID)
+
+		assert.NoError(t, err)
+		assert.False(t, fastQCCalled)
+		assert.Empty(t, usedReads.Read1)
+		assert.Empty(t, usedReads.Read2)
+		assert.Equal(t, longReads, filepath.Base(usedReads.LongReads))
+		if assert.NotEmpty(t, steps) {
+			assert.Equal(t, models.StepLongReadQC, steps[0])
+		}
+
+		var results models.AnalysisResults
+		assert.NoError(t, json.Unmarshal(finalMetrics, &results))
+		if assert.NotNil(t, results.ReadQC) &&
+			assert.NotNil(t, results.ReadQC.LongReads) {
+			assert.Nil(t, results.ReadQC.Read1)
+			assert.Equal(t, int64(4), results.ReadQC.LongReads.ReadN50)
+		}
+	})
+
+	t.Run("Success - Single-end", func(t *testing.T) {
+		rootDir := t.TempDir()
+		mock := testmodels.CreateMockAnalysis()
+		mock.Type = models.AnalysisTypeComplete
+		mock.Status = models.AnalysisStatusPending
+		fq1 := "single.fq"
+		mock.Sample.Fastq1 = &fq1
+		mock.Sample.Fastq2 = nil
+		mock.Sample.Fasta = nil
+		createTestFastq(t, rootDir, mock.UserID, mock.SampleID, fq1)
+
+		var finalMetrics datatypes.JSON
+		var fastQC2 *string
+		repo := &mocks.MockAnalysisRepository{
+			GetAnalysisByIDFunc: func(_ context.Context,
+				_ uuid.UUID) (*models.Analysis, error) {
+				mockCopy := mock
+				return &mockCopy, nil
+			},
+			SaveAnalysisIfStatusFunc: func(_ context.Context,
+				analysis *models.Analysis,
+				_ []models.AnalysisStatus) (bool, error) {
+				finalMetrics = analysis.Metrics
+				fastQC2 = analysis.FastQC2
+				return true, nil
+			},
+		}
+		var fastQCRead2 *string
+		var usedReads pipeline.UnicyclerReads
+		pl := &mocks.MockCabgenPipeline{
+			Config: pipeline.ToolsConfig{
+				ResfinderDBPath: newResfinderRef(t),
+			},
+			RunFastQCFunc: func(_ context.Context, read1, read2,
+				outputDir string) (string, string, error) {
+				fastQCRead2 = &read2
+				return filepath.Join(outputDir, "single_fastqc.html"), "",
+					nil
+			},
+			RunUnicyclerFunc: func(_ context.Context, threads int,
+				reads pipeline.UnicyclerReads, spadesPath, outputDir,
+				outputFile string) (string, error) {
+				usedReads = reads
+				return "assembly.fa", nil
+			},
+			RunAbricateFunc: func(_ context.Context, threads int,
+				db, input, outputFile string) error {
+				return writeAbricateOutput(outputFile)
+			},
+		}
+
+		svc := services.NewAnalysisRunnerService(repo, pl, &mocks.MockCommander{},
+			&mocks.MockTaskEnqueuer{}, nil, nil, zap.NewNop(), rootDir)
+		err := svc.Run(ctx, mock.ID)
+
+		assert.NoError(t, err)
+		if assert.NotNil(t, fastQCRead2) {
+			assert.Empty(t, *fastQCRead2)
+		}
+		assert.Nil(t, fastQC2)
+		assert.Equal(t, fq1, filepath.Base(usedReads.Read1))
+		assert.Empty(t, usedReads.Read2)
+
+		var results models.AnalysisResults
+		assert.NoError(t, json.Unmarshal(finalMetrics, &results))
+		if assert.NotNil(t, results.ReadQC) {
+			assert.NotNil(t, results.ReadQC.Read1)
+			assert.Nil(t, results.ReadQC.Read2)
+		}
+	})
+
+	t.Run("Success - Hybrid", func(t *testing.T) {
+		rootDir := t.TempDir()
+		mock := testmodels.CreateMockAnalysis()
+		mock.Type = models.AnalysisTypeHybrid
+		mock.Status = models.AnalysisStatusPending
+		fq1, fq2, longReads := "r1.fq", "r2.fq", "nanopore.fq"
+		mock.Sample.Fastq1 = &fq1
+		mock.Sample.Fastq2 = &fq2
+		mock.Sample.LongReads = &longReads
+		mock.Sample.Fasta = nil
+		for _, file := range []string{fq1, fq2, longReads} {
+			createTestFastq(t, rootDir, mock.UserID, mock.SampleID, file)
+		}
+
+		var finalMetrics datatypes.JSON
+		repo := &mocks.MockAnalysisRepository{
+			GetAnalysisByIDFunc: func(_ context.Context,
+				_ uuid.UUID) (*models.Analysis, error) {
+				mockCopy := mock
+				return &mockCopy, nil
+			},
+			SaveAnalysisIfStatusFunc: func(_ context.Context,
+				analysis *models.Analysis,
+				_ []models.AnalysisStatus) (bool, error) {
+				finalMetrics = analysis.Metrics
+				return true, nil
+			},
+		}
+		var usedReads pipeline.UnicyclerReads
+		pl := &mocks.MockCabgenPipeline{
+			Config: pipeline.ToolsConfig{
+				ResfinderDBPath: newResfinderRef(t),
+			},
+			RunUnicyclerFunc: func(_ context.Context, threads int,
+				reads pipeline.UnicyclerReads, spadesPath, outputDir,
+				outputFile string) (string, error) {
+				usedReads = reads
+				return "assembly.fa", nil
+			},
+			RunAbricateFunc: func(_ context.Context, threads int,
+				db, input, outputFile string) error {
+				return writeAbricateOutput(outputFile)
+			},
+		}
+
+		svc := services.NewAnalysisRunnerService(repo, pl, &mocks.MockCommander{},
+			&mocks.MockTaskEnqueuer{}, nil, nil, zap.NewNop(), rootDir)
+		err := svc.Run(ctx, mock.ID)
+
+		assert.NoError(t, err)
+		assert.Equal(t, fq1, filepath.Base(usedReads.Read1))
+		assert.Equal(t, fq2, filepath.Base(usedReads.Read2))
+		assert.Equal(t, longReads, filepath.Base(usedReads.LongReads))
+
+		var results models.AnalysisResults
+		assert.NoError(t, json.Unmarshal(finalMetrics, &results))
+		if assert.NotNil(t, results.ReadQC) {
+			assert.NotNil(t, results.ReadQC.Read1)
+			assert.NotNil(t, results.ReadQC.LongReads)
+		}
+	})
+
+	t.Run("Success - Uses And Echoes Parameters", func(t *testing.T) {
+		mock := testmodels.CreateMockAnalysis()
+		mock.Type = models.AnalysisTypeGenome
+		mock.Status = models.AnalysisStatusPending
+		mock.Parameters = datatypes.JSON(`{"unicycler":{"mode":"bold"}}`)
+		fq1, fq2 := "r1.fq", "r2.fq"
+		mock.Sample.Fastq1 = &fq1
+		mock.Sample.Fastq2 = &fq2
+		mock.Sample.Fasta = nil
+
+		var finalMetrics datatypes.JSON
+		repo := &mocks.MockAnalysisRepository{
+			GetAnalysisByIDFunc: func(_ context.Context,
+				_ uuid.UUID) (*models.Analysis, error) {
+				mockCopy := mock
+				return &mockCopy, nil
+			},
+			SaveAnalysisIfStatusFunc: func(_ context.Context,
+				analysis *models.Analysis,
+				_ []models.AnalysisStatus) (bool, error) {
+				finalMetrics = analysis.Metrics
+				return true, nil
+			},
+		}
+		profile := pipeline.AnalysisParameters{
+			BlastX: &pipeline.BlastXParameters{Evalue: 1e-5},
+		}
+		var usedMode string
+		pl := &mocks.MockCabgenPipeline{
+			Config: pipeline.ToolsConfig{
+				ResfinderDBPath: newResfinderRef(t),
+				Parameters:      profile,
+			},
+			RunUnicyclerFunc: func(ctx context.Context, threads int,
+				reads pipeline.UnicyclerReads, spadesPath, outputDir,
+				outputFile string) (
+				string, error) {
+				usedMode = pipeline.AnalysisParametersFromContext(ctx,
+					profile).Unicycler.Mode
+				return "assembly.fa", nil
+			},
+			RunAbricateFunc: func(_ context.Context, threads int,
+				db, input, outputFile string) error {
+				return writeAbricateOutput(outputFile)
+			},
+		}
+
+		svc := services.NewAnalysisRunnerService(repo, pl, &mocks.MockCommander{},
+			&mocks.MockTaskEnqueuer{}, nil, nil, zap.NewNop(), t.TempDir())
+		err := svc.Run(ctx, mock.ID)
+		assert.NoError(t, err)
+		assert.Equal(t, "bold", usedMode)
+
+		var results models.AnalysisResults
+		assert.NoError(t, json.Unmarshal(finalMetrics, &results))
+		assert.NotNil(t, results.Parameters)
+		assert.Equal(t, "bold", results.Parameters.Unicycler.Mode)
+		assert.Equal(t, 500, results.Parameters.Unicycler.MinFastaLength)
+		assert.Equal(t, 1e-5, results.Parameters.BlastX.Evalue)
+		assert.Equal(t, 90.0, results.Parameters.Abricate.MinCoverage)
+	})
+
 	t.Run("Success - FASTA not found, fallback to reads", func(t *testing.T) {
 		mock := testmodels.CreateMockAnalysis()
 		mock.Type = models.AnalysisTypeGenome
@@ -885,9 +1803,10 @@ func TestAnalysisRunnerGenome(t *testing.T) {
 				mockCopy := mock
 				return &mockCopy, nil
 			},
-			UpdateAnalysisFunc: func(_ context.Context,
-				_ *models.Analysis) error {
-				return nil
+			SaveAnalysisIfStatusFunc: func(_ context.Context,
+				_ *models.Analysis,
+				_ []models.AnalysisStatus) (bool, error) {
+				return true, nil
 			},
 			UpdateSampleFunc: func(_ context.Context,
 				sample *models.Sample) error {
@@ -900,7 +1819,8 @@ func TestAnalysisRunnerGenome(t *testing.T) {
 				ResfinderDBPath: newResfinderRef(t),
 			},
 			RunUnicyclerFunc: func(_ context.Context, threads int,
-				read1, read2, spadesPath, outputDir, outputFile string) (
+				reads pipeline.UnicyclerReads, spadesPath, outputDir,
+				outputFile string) (
 				string, error) {
 				unicyclerCalled = true
 				return "assembly.fa", nil
@@ -912,7 +1832,7 @@ func TestAnalysisRunnerGenome(t *testing.T) {
 		}
 
 		svc := services.NewAnalysisRunnerService(repo, pl, &mocks.MockCommander{},
-			&mocks.MockTaskEnqueuer{}, mockLogger, t.TempDir())
+			&mocks.MockTaskEnqueuer{}, nil, nil, mockLogger, t.TempDir())
 		err := svc.Run(ctx, mock.ID)
 
 		assert.NoError(t, err)
@@ -947,15 +1867,16 @@ func TestAnalysisRunnerGenome(t *testing.T) {
 				mockCopy := mock
 				return &mockCopy, nil
 			},
-			UpdateAnalysisFunc: func(_ context.Context,
-				_ *models.Analysis) error {
-				return nil
+			SaveAnalysisIfStatusFunc: func(_ context.Context,
+				_ *models.Analysis,
+				_ []models.AnalysisStatus) (bool, error) {
+				return true, nil
 			},
 		}
 
 		svc := services.NewAnalysisRunnerService(repo,
 			&mocks.MockCabgenPipeline{}, &mocks.MockCommander{},
-			&mocks.MockTaskEnqueuer{}, zap.NewNop(), t.TempDir())
+			&mocks.MockTaskEnqueuer{}, nil, nil, zap.NewNop(), t.TempDir())
 		err := svc.Run(ctx, mock.ID)
 
 		assert.ErrorIs(t, err, pipeline.ErrAnalysisRun)
@@ -979,11 +1900,18 @@ func TestAnalysisRunnerGenome(t *testing.T) {
 				mockCopy := mock
 				return &mockCopy, nil
 			},
-			UpdateAnalysisFunc: func(_ context.Context,
-				analysis *models.Analysis) error {
+			UpdateAnalysisIfStatusFunc: func(_ context.Context, _ uuid.UUID,
+				_ []models.AnalysisStatus, fields map[string]any) (bool, error) {
+				if step, ok := fields["step"].(models.AnalysisStep); ok {
+					steps = append(steps, step)
+				}
+				return true, nil
+			},
+			SaveAnalysisIfStatusFunc: func(_ context.Context,
+				analysis *models.Analysis,
+				_ []models.AnalysisStatus) (bool, error) {
 				updated = analysis
-				steps = append(steps, analysis.Step)
-				return nil
+				return true, nil
 			},
 		}
 		var failedDB string
@@ -998,7 +1926,7 @@ func TestAnalysisRunnerGenome(t *testing.T) {
 		}
 
 		svc := services.NewAnalysisRunnerService(repo, pl, &mocks.MockCommander{},
-			&mocks.MockTaskEnqueuer{}, zap.NewNop(), t.TempDir())
+			&mocks.MockTaskEnqueuer{}, nil, nil, zap.NewNop(), t.TempDir())
 		err := svc.Run(ctx, mock.ID)
 
 		assert.ErrorIs(t, err, pipeline.ErrAnalysisRun)
@@ -1032,11 +1960,18 @@ func TestAnalysisRunnerGenome(t *testing.T) {
 				mockCopy := mock
 				return &mockCopy, nil
 			},
-			UpdateAnalysisFunc: func(_ context.Context,
-				analysis *models.Analysis) error {
+			UpdateAnalysisIfStatusFunc: func(_ context.Context, _ uuid.UUID,
+				_ []models.AnalysisStatus, fields map[string]any) (bool, error) {
+				if step, ok := fields["step"].(models.AnalysisStep); ok {
+					steps = append(steps, step)
+				}
+				return true, nil
+			},
+			SaveAnalysisIfStatusFunc: func(_ context.Context,
+				analysis *models.Analysis,
+				_ []models.AnalysisStatus) (bool, error) {
 				updated = analysis
-				steps = append(steps, analysis.Step)
-				return nil
+				return true, nil
 			},
 		}
 		pl := &mocks.MockCabgenPipeline{
@@ -1048,7 +1983,7 @@ func TestAnalysisRunnerGenome(t *testing.T) {
 		}
 
 		svc := services.NewAnalysisRunnerService(repo, pl, &mocks.MockCommander{},
-			&mocks.MockTaskEnqueuer{}, zap.NewNop(), t.TempDir())
+			&mocks.MockTaskEnqueuer{}, nil, nil, zap.NewNop(), t.TempDir())
 		err := svc.Run(ctx, mock.ID)
 
 		assert.ErrorIs(t, err, pipeline.ErrAnalysisRun)
@@ -1081,11 +2016,18 @@ func TestAnalysisRunnerGenome(t *testing.T) {
 				mockCopy := mock
 				return &mockCopy, nil
 			},
-			UpdateAnalysisFunc: func(_ context.Context,
-				analysis *models.Analysis) error {
+			UpdateAnalysisIfStatusFunc: func(_ context.Context, _ uuid.UUID,
+				_ []models.AnalysisStatus, fields map[string]any) (bool, error) {
+				if step, ok := fields["step"].(models.AnalysisStep); ok {
+					steps = append(steps, step)
+				}
+				return true, nil
+			},
+			SaveAnalysisIfStatusFunc: func(_ context.Context,
+				analysis *models.Analysis,
+				_ []models.AnalysisStatus) (bool, error) {
 				updated = analysis
-				steps = append(steps, analysis.Step)
-				return nil
+				return true, nil
 			},
 		}
 		pl := &mocks.MockCabgenPipeline{
@@ -1098,7 +2040,7 @@ func TestAnalysisRunnerGenome(t *testing.T) {
 		}
 
 		svc := services.NewAnalysisRunnerService(repo, pl, &mocks.MockCommander{},
-			&mocks.MockTaskEnqueuer{}, zap.NewNop(), t.TempDir())
+			&mocks.MockTaskEnqueuer{}, nil, nil, zap.NewNop(), t.TempDir())
 		err := svc.Run(ctx, mock.ID)
 
 		assert.ErrorIs(t, err, pipeline.ErrAnalysisRun)
@@ -1126,10 +2068,11 @@ func TestAnalysisRunnerGenome(t *testing.T) {
 				mockCopy := mock
 				return &mockCopy, nil
 			},
-			UpdateAnalysisFunc: func(_ context.Context,
-				analysis *models.Analysis) error {
+			SaveAnalysisIfStatusFunc: func(_ context.Context,
+				analysis *models.Analysis,
+				_ []models.AnalysisStatus) (bool, error) {
 				updated = analysis
-				return nil
+				return true, nil
 			},
 		}
 		pl := &mocks.MockCabgenPipeline{
@@ -1152,7 +2095,7 @@ func TestAnalysisRunnerGenome(t *testing.T) {
 		mockLogger, logs := testutils.NewMockLogger(zap.ErrorLevel)
 
 		svc := services.NewAnalysisRunnerService(repo, pl, &mocks.MockCommander{},
-			&mocks.MockTaskEnqueuer{}, mockLogger, t.TempDir())
+			&mocks.MockTaskEnqueuer{}, nil, nil, mockLogger, t.TempDir())
 		err := svc.Run(ctx, mock.ID)
 
 		assert.NoError(t, err)
@@ -1161,7 +2104,7 @@ func TestAnalysisRunnerGenome(t *testing.T) {
 		assert.GreaterOrEqual(t, logs.Len(), 1)
 	})
 
-	t.Run("Success - Secondary Species Written When Contamination > 5%",
+	t.Run("Success - Secondary Species Written At Kraken Cut-Off",
 		func(t *testing.T) {
 			rootDir := t.TempDir()
 			mock := testmodels.CreateMockAnalysis()
@@ -1180,22 +2123,27 @@ func TestAnalysisRunnerGenome(t *testing.T) {
 					mockCopy := mock
 					return &mockCopy, nil
 				},
-				UpdateAnalysisFunc: func(_ context.Context,
-					analysis *models.Analysis) error {
+				SaveAnalysisIfStatusFunc: func(_ context.Context,
+					analysis *models.Analysis,
+					_ []models.AnalysisStatus) (bool, error) {
 					updated = analysis
-					return nil
+					return true, nil
 				},
 			}
 			pl := &mocks.MockCabgenPipeline{
 				Config: pipeline.ToolsConfig{
 					ResfinderDBPath: newResfinderRef(t),
 				},
-				RunCheckMFunc: func(_ context.Context, threads int,
-					sample, assemblyDir, outputDir string) (
-					*pipeline.CheckMResult, error) {
-					return &pipeline.CheckMResult{
-						Completeness: "99.5", Contamination: "8.0",
-						GenomeSize: "5000000", N50: "100000",
+				RunKraken2Func: func(_ context.Context, threads int,
+					assembly, outputDir string) (
+					*pipeline.KrakenComposition, error) {
+					return &pipeline.KrakenComposition{
+						Species: []pipeline.KrakenTaxon{
+							{Name: "Escherichia coli", TaxID: 562,
+								CladeReads: 920, Percent: 92},
+							{Name: "Klebsiella pneumoniae", TaxID: 573,
+								CladeReads: 50, Percent: 5},
+						},
 					}, nil
 				},
 				RunAbricateFunc: func(_ context.Context, threads int,
@@ -1205,7 +2153,7 @@ func TestAnalysisRunnerGenome(t *testing.T) {
 			}
 
 			svc := services.NewAnalysisRunnerService(repo, pl, &mocks.MockCommander{},
-				&mocks.MockTaskEnqueuer{}, zap.NewNop(), rootDir)
+				&mocks.MockTaskEnqueuer{}, nil, nil, zap.NewNop(), rootDir)
 			err := svc.Run(ctx, mock.ID)
 
 			assert.NoError(t, err)
@@ -1217,9 +2165,12 @@ func TestAnalysisRunnerGenome(t *testing.T) {
 			assert.NotEmpty(t, results.SecondarySpeciesName)
 			assert.Equal(t, "Klebsiella pneumoniae",
 				results.SecondarySpeciesName)
+			assert.NotNil(t, results.Taxonomy)
+			assert.Len(t, results.Taxonomy.Species, 2)
+			assert.Equal(t, 573, results.Taxonomy.Species[1].TaxID)
 		})
 
-	t.Run("Success - Secondary Species Not Written When Contamination <= 5%",
+	t.Run("Success - Secondary Species Not Written Below Kraken Cut-Off",
 		func(t *testing.T) {
 			rootDir := t.TempDir()
 			mock := testmodels.CreateMockAnalysis()
@@ -1238,10 +2189,11 @@ func TestAnalysisRunnerGenome(t *testing.T) {
 					mockCopy := mock
 					return &mockCopy, nil
 				},
-				UpdateAnalysisFunc: func(_ context.Context,
-					analysis *models.Analysis) error {
+				SaveAnalysisIfStatusFunc: func(_ context.Context,
+					analysis *models.Analysis,
+					_ []models.AnalysisStatus) (bool, error) {
 					updated = analysis
-					return nil
+					return true, nil
 				},
 			}
 			pl := &mocks.MockCabgenPipeline{
@@ -1252,7 +2204,7 @@ func TestAnalysisRunnerGenome(t *testing.T) {
 					sample, assemblyDir, outputDir string) (
 					*pipeline.CheckMResult, error) {
 					return &pipeline.CheckMResult{
-						Completeness: "99.5", Contamination: "0.5",
+						Completeness: "99.5", Contamination: "8.0",
 						GenomeSize: "5000000", N50: "100000",
 					}, nil
 				},
@@ -1263,7 +2215,7 @@ func TestAnalysisRunnerGenome(t *testing.T) {
 			}
 
 			svc := services.NewAnalysisRunnerService(repo, pl, &mocks.MockCommander{},
-				&mocks.MockTaskEnqueuer{}, zap.NewNop(), rootDir)
+				&mocks.MockTaskEnqueuer{}, nil, nil, zap.NewNop(), rootDir)
 			err := svc.Run(ctx, mock.ID)
 
 			assert.NoError(t, err)
@@ -1273,7 +2225,73 @@ func TestAnalysisRunnerGenome(t *testing.T) {
 			var results models.AnalysisResults
 			assert.NoError(t, json.Unmarshal(updated.Metrics, &results))
 			assert.Empty(t, results.SecondarySpeciesName)
+			assert.NotNil(t, results.Taxonomy)
+			assert.Len(t, results.Taxonomy.Species, 2)
 		})
+
+	t.Run("Success - AMRFinderPlus Engine", func(t *testing.T) {
+		rootDir := t.TempDir()
+		mock := testmodels.CreateMockAnalysis()
+		mock.Type = models.AnalysisTypeGenome
+		mock.Status = models.AnalysisStatusPending
+		relFasta := createTestFasta(t, rootDir, mock.UserID,
+			mock.SampleID, "contigs.fasta", ">seq1\nATCG\n")
+		mock.Sample.Fastq1 = nil
+		mock.Sample.Fastq2 = nil
+		mock.Sample.Fasta = &relFasta
+
+		updated := (*models.Analysis)(nil)
+		repo := &mocks.MockAnalysisRepository{
+			GetAnalysisByIDFunc: func(_ context.Context,
+				_ uuid.UUID) (*models.Analysis, error) {
+				mockCopy := mock
+				return &mockCopy, nil
+			},
+			SaveAnalysisIfStatusFunc: func(_ context.Context,
+				analysis *models.Analysis,
+				_ []models.AnalysisStatus) (bool, error) {
+				updated = analysis
+				return true, nil
+			},
+		}
+		var abricateDBs []string
+		organism := ""
+		pl := &mocks.MockCabgenPipeline{
+			Config: pipeline.ToolsConfig{
+				AMREngine: pipeline.AMREngineAMRFinderPlus,
+			},
+			RunAbricateFunc: func(_ context.Context, threads int,
+				db, input, outputFile string) error {
+				abricateDBs = append(abricateDBs, db)
+				return writeAbricateOutput(outputFile)
+			},
+			RunAMRFinderFunc: func(_ context.Context, threads int,
+				assembly, org, outputFile string) error {
+				organism = org
+				return writeAMRFinderOutput(outputFile)
+			},
+		}
+
+		svc := services.NewAnalysisRunnerService(repo, pl,
+			&mocks.MockCommander{}, &mocks.MockTaskEnqueuer{}, nil, nil,
+			zap.NewNop(), rootDir)
+		err := svc.Run(ctx, mock.ID)
+
+		assert.NoError(t, err)
+		assert.NotNil(t, updated)
+		assert.Equal(t, models.AnalysisStatusDone, updated.Status)
+		assert.Equal(t, []string{"vfdb", "plasmidfinder"}, abricateDBs)
+		assert.Equal(t, "Escherichia", organism)
+
+		var results models.AnalysisResults
+		assert.NoError(t, json.Unmarshal(updated.Metrics, &results))
+		assert.Len(t, results.AcquiredResistance, 1)
+		assert.Equal(t, pipeline.AMREngineAMRFinderPlus,
+			results.AcquiredResistance[0].Engine)
+		assert.Len(t, results.PointMutations, 1)
+		assert.Equal(t, "gyrA_S83L", results.PointMutations[0].Allele)
+		assert.Equal(t, pipeline.AMREngineAbricate, results.VFDB[0].Engine)
+	})
 }
 
 func TestAnalysisRunnerComplete(t *testing.T) {
@@ -1300,9 +2318,10 @@ func TestAnalysisRunnerComplete(t *testing.T) {
 				mockCopy := mock
 				return &mockCopy, nil
 			},
-			UpdateAnalysisFunc: func(_ context.Context,
-				_ *models.Analysis) error {
-				return nil
+			SaveAnalysisIfStatusFunc: func(_ context.Context,
+				_ *models.Analysis,
+				_ []models.AnalysisStatus) (bool, error) {
+				return true, nil
 			},
 		}
 		pl := &mocks.MockCabgenPipeline{
@@ -1321,7 +2340,7 @@ func TestAnalysisRunnerComplete(t *testing.T) {
 		}
 
 		svc := services.NewAnalysisRunnerService(repo, pl, &mocks.MockCommander{},
-			&mocks.MockTaskEnqueuer{}, zap.NewNop(), t.TempDir())
+			&mocks.MockTaskEnqueuer{}, nil, nil, zap.NewNop(), t.TempDir())
 		err := svc.Run(ctx, mock.ID)
 
 		assert.NoError(t, err)
@@ -1346,10 +2365,11 @@ func TestAnalysisRunnerComplete(t *testing.T) {
 				mockCopy := mock
 				return &mockCopy, nil
 			},
-			UpdateAnalysisFunc: func(_ context.Context,
-				analysis *models.Analysis) error {
+			SaveAnalysisIfStatusFunc: func(_ context.Context,
+				analysis *models.Analysis,
+				_ []models.AnalysisStatus) (bool, error) {
 				updated = analysis
-				return nil
+				return true, nil
 			},
 		}
 		pl := &mocks.MockCabgenPipeline{
@@ -1360,7 +2380,7 @@ func TestAnalysisRunnerComplete(t *testing.T) {
 		}
 
 		svc := services.NewAnalysisRunnerService(repo, pl, &mocks.MockCommander{},
-			&mocks.MockTaskEnqueuer{}, zap.NewNop(), t.TempDir())
+			&mocks.MockTaskEnqueuer{}, nil, nil, zap.NewNop(), t.TempDir())
 		err := svc.Run(ctx, mock.ID)
 
 		assert.ErrorIs(t, err, pipeline.ErrAnalysisRun)
@@ -1372,6 +2392,202 @@ func TestAnalysisRunnerComplete(t *testing.T) {
 	})
 }
 
+func TestAnalysisRunnerQCGate(t *testing.T) {
+	ctx := context.Background()
+	minCompleteness, maxContamination := 90.0, 5.0
+
+	run := func(t *testing.T, rules func(context.Context) (
+		[]models.QCRule, error)) *models.Analysis {
+		rootDir := t.TempDir()
+		mock := testmodels.CreateMockAnalysis()
+		mock.Type = models.AnalysisTypeGenome
+		mock.Status = models.AnalysisStatusPending
+		relFasta := createTestFasta(t, rootDir, mock.UserID,
+			mock.SampleID, "contigs.fasta", ">seq1\nATCG\n")
+		mock.Sample.Fastq1 = nil
+		mock.Sample.Fastq2 = nil
+		mock.Sample.Fasta = &relFasta
+
+		updated := (*models.Analysis)(nil)
+		repo := &mocks.MockAnalysisRepository{
+			GetAnalysisByIDFunc: func(_ context.Context,
+				_ uuid.UUID) (*models.Analysis, error) {
+				mockCopy := mock
+				return &mockCopy, nil
+			},
+			SaveAnalysisIfStatusFunc: func(_ context.Context,
+				analysis *models.Analysis,
+				_ []models.AnalysisStatus) (bool, error) {
+				updated = analysis
+				return true, nil
+			},
+			GetActiveQCRulesFunc: rules,
+		}
+		pl := &mocks.MockCabgenPipeline{
+			Config: pipeline.ToolsConfig{
+				ResfinderDBPath: newResfinderRef(t),
+			},
+			RunCheckMFunc: func(_ context.Context, threads int,
+				sample, assemblyDir, outputDir string) (
+				*pipeline.CheckMResult, error) {
+				return &pipeline.CheckMResult{
+					Completeness: "60.0", Contamination: "2.0",
+					GenomeSize: "5000000", N50: "100000",
+				}, nil
+			},
+			RunAbricateFunc: func(_ context.Context, threads int,
+				db, input, outputFile string) error {
+				return writeAbricateOutput(outputFile)
+			},
+		}
+
+		svc := services.NewAnalysisRunnerService(repo, pl, &mocks.MockCommander{},
+			&mocks.MockTaskEnqueuer{}, nil, nil, zap.NewNop(), rootDir)
+		err := svc.Run(ctx, mock.ID)
+
+		assert.NoError(t, err)
+		return updated
+	}
+
+	t.Run("Success - Fail Verdict", func(t *testing.T) {
+		updated := run(t, func(context.Context) ([]models.QCRule, error) {
+			return []models.QCRule{
+				{Metric: pipeline.QCMetricCompleteness, Min: &minCompleteness,
+					Severity: pipeline.QCSeverityFail, IsActive: true},
+				{Metric: pipeline.QCMetricContamination,
+					Max: &maxContamination, Severity: pipeline.QCSeverityWarn,
+					IsActive: true},
+			}, nil
+		})
+
+		if assert.NotNil(t, updated) {
+			assert.Equal(t, models.AnalysisStatusDone, updated.Status)
+			assert.Equal(t, pipeline.QCVerdictFail, updated.QCVerdict)
+
+			var results models.AnalysisResults
+			assert.NoError(t, json.Unmarshal(updated.Metrics, &results))
+			if assert.NotNil(t, results.QC) &&
+				assert.Len(t, results.QC.FailedRules, 1) {
+				assert.Equal(t, pipeline.QCMetricCompleteness,
+					results.QC.FailedRules[0].Metric)
+				assert.Equal(t, 60.0, results.QC.FailedRules[0].Value)
+			}
+		}
+	})
+
+	t.Run("Success - Pass Without Rules", func(t *testing.T) {
+		updated := run(t, nil)
+
+		if assert.NotNil(t, updated) {
+			assert.Equal(t, pipeline.QCVerdictPass, updated.QCVerdict)
+		}
+	})
+
+	t.Run("Warning - Rules Not Loaded", func(t *testing.T) {
+		updated := run(t, func(context.Context) ([]models.QCRule, error) {
+			return nil, fmt.Errorf("db down")
+		})
+
+		if assert.NotNil(t, updated) {
+			assert.Equal(t, models.AnalysisStatusDone, updated.Status)
+			assert.Empty(t, updated.QCVerdict)
+		}
+	})
+}
+
+func TestAnalysisRunnerSpeciesConcordance(t *testing.T) {
+	ctx := context.Background()
+
+	run := func(t *testing.T, declared models.Microorganism) (
+		*models.Analysis, *observer.ObservedLogs) {
+		rootDir := t.TempDir()
+		mock := testmodels.CreateMockAnalysis()
+		mock.Type = models.AnalysisTypeGenome
+		mock.Status = models.AnalysisStatusPending
+		relFasta := createTestFasta(t, rootDir, mock.UserID,
+			mock.SampleID, "contigs.fasta", ">seq1\nATCG\n")
+		mock.Sample.Fastq1 = nil
+		mock.Sample.Fastq2 = nil
+		mock.Sample.Fasta = &relFasta
+		mock.Sample.Microorganism = declared
+
+		updated := (*models.Analysis)(nil)
+		repo := &mocks.MockAnalysisRepository{
+			GetAnalysisByIDFunc: func(_ context.Context,
+				_ uuid.UUID) (*models.Analysis, error) {
+				mockCopy := mock
+				return &mockCopy, nil
+			},
+			SaveAnalysisIfStatusFunc: func(_ context.Context,
+				analysis *models.Analysis,
+				_ []models.AnalysisStatus) (bool, error) {
+				updated = analysis
+				return true, nil
+			},
+		}
+		pl := &mocks.MockCabgenPipeline{
+			Config: pipeline.ToolsConfig{
+				ResfinderDBPath: newResfinderRef(t),
+			},
+			RunAbricateFunc: func(_ context.Context, threads int,
+				db, input, outputFile string) error {
+				return writeAbricateOutput(outputFile)
+			},
+		}
+		mockLogger, logs := testutils.NewMockLogger(zap.WarnLevel)
+
+		svc := services.NewAnalysisRunnerService(repo, pl, &mocks.MockCommander{},
+			&mocks.MockTaskEnqueuer{}, nil, nil, mockLogger, rootDir)
+		err := svc.Run(ctx, mock.ID)
+
+		assert.NoError(t, err)
+		return updated, logs
+	}
+
+	t.Run("Success - Concordant By Taxid", func(t *testing.T) {
+		taxID := 562
+		updated, _ := run(t, models.Microorganism{
+			Species: "E. coli", TaxID: &taxID,
+		})
+
+		if assert.NotNil(t, updated) {
+			assert.Equal(t, pipeline.SpeciesConcordant,
+				updated.SpeciesConcordance)
+
+			var results models.AnalysisResults
+			assert.NoError(t, json.Unmarshal(updated.Metrics, &results))
+			if assert.NotNil(t, results.Concordance) {
+				assert.Equal(t, pipeline.SpeciesRef{
+					Name: "Escherichia coli", TaxID: 562,
+				}, results.Concordance.Detected)
+			}
+		}
+	})
+
+	t.Run("Success - Discordant", func(t *testing.T) {
+		updated, logs := run(t, models.Microorganism{
+			Species: "Klebsiella pneumoniae",
+		})
+
+		if assert.NotNil(t, updated) {
+			assert.Equal(t, models.AnalysisStatusDone, updated.Status)
+			assert.Equal(t, pipeline.SpeciesDiscordant,
+				updated.SpeciesConcordance)
+		}
+		assert.Equal(t, 1, logs.FilterMessageSnippet(
+			"Declared species").Len())
+	})
+
+	t.Run("Success - Inconclusive With Genus Declared", func(t *testing.T) {
+		updated, _ := run(t, models.Microorganism{Species: "Escherichia"})
+
+		if assert.NotNil(t, updated) {
+			assert.Equal(t, pipeline.SpeciesInconclusive,
+				updated.SpeciesConcordance)
+		}
+	})
+}
+
 func TestAnalysisRunnerPrepareFolders(t *testing.T) {
 	t.Run("Success", func(t *testing.T) {
 		mock := testmodels.CreateMockAnalysis()
@@ -1388,14 +2604,15 @@ func TestAnalysisRunnerPrepareFolders(t *testing.T) {
 				mockCopy := mock
 				return &mockCopy, nil
 			},
-			UpdateAnalysisFunc: func(_ context.Context,
-				_ *models.Analysis) error {
-				return nil
+			SaveAnalysisIfStatusFunc: func(_ context.Context,
+				_ *models.Analysis,
+				_ []models.AnalysisStatus) (bool, error) {
+				return true, nil
 			},
 		}
 
 		svc := services.NewAnalysisRunnerService(repo,
-			&mocks.MockCabgenPipeline{}, &mocks.MockCommander{}, &mocks.MockTaskEnqueuer{},
+			&mocks.MockCabgenPipeline{}, &mocks.MockCommander{}, &mocks.MockTaskEnqueuer{}, nil, nil,
 			zap.NewNop(), root)
 		err := svc.Run(context.Background(), mock.ID)
 
@@ -1420,10 +2637,11 @@ func TestAnalysisRunnerZipResults(t *testing.T) {
 				mockCopy := mock
 				return &mockCopy, nil
 			},
-			UpdateAnalysisFunc: func(_ context.Context,
-				analysis *models.Analysis) error {
+			SaveAnalysisIfStatusFunc: func(_ context.Context,
+				analysis *models.Analysis,
+				_ []models.AnalysisStatus) (bool, error) {
 				*updated = analysis
-				return nil
+				return true, nil
 			},
 		}
 	}
@@ -1461,7 +2679,7 @@ func TestAnalysisRunnerZipResults(t *testing.T) {
 		}
 		rootDir := t.TempDir()
 
-		svc := services.NewAnalysisRunnerService(repo, pl, &mocks.MockCommander{}, enqueuer,
+		svc := services.NewAnalysisRunnerService(repo, pl, &mocks.MockCommander{}, enqueuer, nil, nil,
 			zap.NewNop(), rootDir)
 		err := svc.Run(ctx, mock.ID)
 
@@ -1517,7 +2735,7 @@ func TestAnalysisRunnerZipResults(t *testing.T) {
 		mockLogger, logs := testutils.NewMockLogger(zap.WarnLevel)
 
 		svc := services.NewAnalysisRunnerService(repo, pl, &mocks.MockCommander{},
-			&mocks.MockTaskEnqueuer{}, mockLogger, t.TempDir())
+			&mocks.MockTaskEnqueuer{}, nil, nil, mockLogger, t.TempDir())
 		err := svc.Run(ctx, mock.ID)
 
 		assert.NoError(t, err)
@@ -1527,3 +2745,182 @@ func TestAnalysisRunnerZipResults(t *testing.T) {
 		assert.GreaterOrEqual(t, logs.Len(), 1)
 	})
 }
+
+func TestAnalysisRunnerResume(t *testing.T) {
+	ctx := context.Background()
+
+	originalConcurrency := config.AnalysisConcurrency
+	config.AnalysisConcurrency = 4
+	t.Cleanup(func() { config.AnalysisConcurrency = originalConcurrency })
+
+	type stepCalls struct {
+		prokka, checkm, kraken, species, abricate int
+	}
+
+	setup := func(t *testing.T) (models.Analysis, string,
+		*models.Analysis, *stepCalls, *bool, *mocks.MockAnalysisRepository,
+		*mocks.MockCabgenPipeline) {
+		rootDir := t.TempDir()
+		mock := testmodels.CreateMockAnalysis()
+		mock.Type = models.AnalysisTypeGenome
+		mock.Status = models.AnalysisStatusPending
+		relFasta := createTestFasta(t, rootDir, mock.UserID,
+			mock.SampleID, "contigs.fasta", ">seq1\nATCGATCG\n")
+		mock.Sample.Fastq1 = nil
+		mock.Sample.Fastq2 = nil
+		mock.Sample.Fasta = &relFasta
+
+		updated := &models.Analysis{}
+		calls := &stepCalls{}
+		abricateFails := true
+		repo := &mocks.MockAnalysisRepository{
+			GetAnalysisByIDFunc: func(_ context.Context,
+				_ uuid.UUID) (*models.Analysis, error) {
+				mockCopy := mock
+				return &mockCopy, nil
+			},
+			SaveAnalysisIfStatusFunc: func(_ context.Context,
+				analysis *models.Analysis,
+				_ []models.AnalysisStatus) (bool, error) {
+				*updated = *analysis
+				return true, nil
+			},
+		}
+		pl := &mocks.MockCabgenPipeline{
+			Config: pipeline.ToolsConfig{
+				ResfinderDBPath: newResfinderRef(t),
+			},
+			RunProkkaFunc: func(_ context.Context, threads int,
+				assembly, outputDir string) error {
+				calls.prokka++
+				if err := os.MkdirAll(outputDir, 0755); err != nil {
+					return err
+				}
+				return os.WriteFile(filepath.Join(outputDir, "genome.ffn"),
+					[]byte(">gene\nATG\n"), 0644)
+			},
+			RunCheckMFunc: func(_ context.Context, threads int, sample,
+				assemblyDir, outputDir string) (*pipeline.CheckMResult,
+				error) {
+				calls.checkm++
+				return &pipeline.CheckMResult{Completeness: "97.1",
+					Contamination: "8.2", GenomeSize: "5100000",
+					N50: "90000"}, nil
+			},
+			RunKraken2Func: func(_ context.Context, threads int, assembly,
+				outputDir string) (*pipeline.KrakenComposition, error) {
+				calls.kraken++
+				return &pipeline.KrakenComposition{
+					Species: []pipeline.KrakenTaxon{
+						{Name: "Escherichia coli", CladeReads: 100,
+							Percent: 91.7},
+						{Name: "Klebsiella pneumoniae", CladeReads: 9,
+							Percent: 8.3},
+					},
+				}, nil
+			},
+			ProcessSpeciesFunc: func(_ context.Context, threads int,
+				sampleID, mostCommon, assemblyPath, outputDir string) (
+				*pipeline.SpeciesResult, error) {
+				calls.species++
+				return &pipeline.SpeciesResult{
+					DisplayName: "Escherichia coli",
+					MLSTSpecies: "ecoli (ST: 131)",
+				}, nil
+			},
+			RunAbricateFunc: func(_ context.Context, threads int,
+				db, input, outputFile string) error {
+				calls.abricate++
+				if abricateFails {
+					return errors.New("abricate segfault")
+				}
+				return writeAbricateOutput(outputFile)
+			},
+		}
+
+		return mock, rootDir, updated, calls, &abricateFails, repo, pl
+	}
+
+	t.Run("Success - Skips Checkpointed Steps", func(t *testing.T) {
+		mock, rootDir, updated, calls, abricateFails, repo, pl := setup(t)
+		svc := services.NewAnalysisRunnerService(repo, pl,
+			&mocks.MockCommander{}, &mocks.MockTaskEnqueuer{}, nil, nil, zap.NewNop(),
+			rootDir)
+
+		err := svc.Run(ctx, mock.ID)
+		assert.ErrorIs(t, err, pipeline.ErrAnalysisRun)
+		assert.Equal(t, models.AnalysisStatusFailed, updated.Status)
+
+		*abricateFails = false
+		err = svc.Resume(ctx, mock.ID)
+
+		assert.NoError(t, err)
+		assert.Equal(t, models.AnalysisStatusDone, updated.Status)
+		assert.Equal(t, 1, calls.prokka)
+		assert.Equal(t, 1, calls.checkm)
+		assert.Equal(t, 1, calls.kraken)
+		assert.Equal(t, 1, calls.species)
+
+		var results models.AnalysisResults
+		assert.NoError(t, json.Unmarshal(updated.Metrics, &results))
+		assert.Equal(t, "97.1", results.CheckMCompleteness)
+		assert.Equal(t, "5100000", results.CheckMGenomeSize)
+		assert.Equal(t, "Escherichia coli", results.PrimarySpeciesName)
+		assert.Equal(t, "ecoli (ST: 131)", results.MLST)
+		assert.Equal(t, "Klebsiella pneumoniae",
+			results.SecondarySpeciesName)
+		assert.NotEmpty(t, results.AcquiredResistance)
+	})
+
+	t.Run("Success - Invalid Checkpoint Reruns Dependent Steps",
+		func(t *testing.T) {
+			mock, rootDir, updated, calls, abricateFails, repo, pl := setup(t)
+			svc := services.NewAnalysisRunnerService(repo, pl,
+				&mocks.MockCommander{}, &mocks.MockTaskEnqueuer{}, nil, nil,
+				zap.NewNop(), rootDir)
+
+			err := svc.Run(ctx, mock.ID)
+			assert.ErrorIs(t, err, pipeline.ErrAnalysisRun)
+
+			analysisDir := filepath.Join(rootDir, "uploads", "users",
+				mock.UserID.String(), "samples", mock.SampleID.String(),
+				"analyses", mock.ID.String())
+			assert.NoError(t, os.Remove(filepath.Join(analysisDir,
+				"assembly", "prokka", "genome.ffn")))
+
+			*abricateFails = false
+			err = svc.Resume(ctx, mock.ID)
+
+			assert.NoError(t, err)
+			assert.Equal(t, models.AnalysisStatusDone, updated.Status)
+			assert.Equal(t, 2, calls.prokka)
+			assert.Equal(t, 1, calls.checkm)
+			assert.Equal(t, 1, calls.kraken)
+			assert.Equal(t, 1, calls.species)
+
+			var results models.AnalysisResults
+			assert.NoError(t, json.Unmarshal(updated.Metrics, &results))
+			assert.Equal(t, "Escherichia coli", results.PrimarySpeciesName)
+			assert.NotEmpty(t, results.AcquiredResistance)
+		})
+
+	t.Run("Success - Run Ignores Previous Checkpoints", func(t *testing.T) {
+		mock, rootDir, updated, calls, abricateFails, repo, pl := setup(t)
+		svc := services.NewAnalysisRunnerService(repo, pl,
+			&mocks.MockCommander{}, &mocks.MockTaskEnqueuer{}, nil, nil, zap.NewNop(),
+			rootDir)
+
+		err := svc.Run(ctx, mock.ID)
+		assert.ErrorIs(t, err, pipeline.ErrAnalysisRun)
+
+		*abricateFails = false
+		err = svc.Run(ctx, mock.ID)
+
+		assert.NoError(t, err)
+		assert.Equal(t, models.AnalysisStatusDone, updated.Status)
+		assert.Equal(t, 2, calls.prokka)
+		assert.Equal(t, 2, calls.checkm)
+		assert.Equal(t, 2, calls.kraken)
+		assert.Equal(t, 2, calls.species)
+	})
+}
@@ -2,12 +2,17 @@ package services
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
+	"fmt"
+	"io"
 	"os"
 	"path/filepath"
+	"time"
 
 	"github.com/CABGenOrg/cabgen_backend/internal/logging"
 	"github.com/CABGenOrg/cabgen_backend/internal/models"
+	"github.com/CABGenOrg/cabgen_backend/internal/pipeline"
 	"github.com/CABGenOrg/cabgen_backend/internal/queue/tasks"
 	"github.com/CABGenOrg/cabgen_backend/internal/repositories"
 	"github.com/CABGenOrg/cabgen_backend/internal/validations"
@@ -17,6 +22,9 @@ import (
 	"gorm.io/gorm"
 )
 
+// MaxToolLogOutputBytes caps how much of each tool output file is returned.
+const MaxToolLogOutputBytes = 1 << 20
+
 type AnalysisService interface {
 	FindAll(ctx context.Context, userID uuid.UUID, filter models.AnalysisFilter,
 		language string) (
@@ -31,6 +39,17 @@ type AnalysisService interface {
 		input models.AdminAnalysisUpdateInput, language string) (
 		*models.AnalysisResponse, error)
 	Delete(ctx context.Context, analysisID, userID uuid.UUID) error
+	Resume(ctx context.Context, analysisID, userID uuid.UUID,
+		language string) (*models.AnalysisResponse, error)
+	Cancel(ctx context.Context, analysisID, userID uuid.UUID,
+		language string) (*models.AnalysisResponse, error)
+	FindLogs(ctx context.Context, analysisID, userID uuid.UUID,
+		step models.AnalysisStep, withOutput bool) (
+		[]models.AnalysisLogResponse, error)
+	FindTaxonomy(ctx context.Context, analysisID, userID uuid.UUID) (
+		*pipeline.KrakenComposition, error)
+	FindOutdatedDatabases(ctx context.Context, language string) (
+		[]models.OutdatedAnalysisResponse, error)
 	DownloadZip(ctx context.Context, analysisID, userID uuid.UUID) (string,
 		error)
 	DownloadBatchTSV(ctx context.Context, analysisIDs []uuid.UUID,
@@ -43,6 +62,7 @@ type analysisService struct {
 	UserRepo    repositories.UserRepository
 	AsynqClient TaskEnqueuer
 	Canceller   TaskCanceller
+	Workers     WorkerEnvironmentReader
 	Logger      *zap.Logger
 	RootDir     string
 }
@@ -53,6 +73,7 @@ func NewAnalysisService(
 	userRepo repositories.UserRepository,
 	asynqClient TaskEnqueuer,
 	canceller TaskCanceller,
+	workers WorkerEnvironmentReader,
 	logger *zap.Logger,
 	rootDir string,
 ) AnalysisService {
@@ -62,6 +83,7 @@ func NewAnalysisService(
 		UserRepo:    userRepo,
 		AsynqClient: asynqClient,
 		Canceller:   canceller,
+		Workers:     workers,
 		Logger:      logger,
 		RootDir:     rootDir,
 	}
@@ -179,44 +201,25 @@ func (s *analysisService) Create(ctx context.Context,
 		return nil, ErrInternal
 	}
 
-	if sample.Fastq1 == nil && sample.Fastq2 == nil &&
-		sample.Fasta == nil {
+	if err := analysisInputError(input.Type, sample); err != nil {
 		s.Logger.Error("Service Error",
 			logging.ServiceLogging(
 				"AnalysisService", "Create",
-				logging.MissingFileError, ErrMissingFiles,
+				logging.MissingFileError, err,
 			)...)
-		return nil, ErrMissingFiles
+		return nil, &AnalysisInputError{
+			Err:       err,
+			Available: availableAnalysisTypes(sample),
+		}
 	}
 
-	switch input.Type {
-	case models.AnalysisTypeFastQC, models.AnalysisTypeComplete:
-		if sample.Fastq1 == nil {
-			s.Logger.Error("Service Error",
-				logging.ServiceLogging(
-					"AnalysisService", "Create",
-					logging.MissingFileError, ErrMissingFastq1,
-				)...)
-			return nil, ErrMissingFastq1
-		}
-		if sample.Fastq2 == nil {
-			s.Logger.Error("Service Error",
-				logging.ServiceLogging(
-					"AnalysisService", "Create",
-					logging.MissingFileError, ErrMissingFastq2,
-				)...)
-			return nil, ErrMissingFastq2
-		}
-	case models.AnalysisTypeGenome:
-		if (sample.Fastq1 == nil || sample.Fastq2 == nil) &&
-			sample.Fasta == nil {
-			s.Logger.Error("Service Error",
-				logging.ServiceLogging(
-					"AnalysisService", "Create",
-					logging.MissingFileError, ErrMissingFiles,
-				)...)
-			return nil, ErrMissingFiles
-		}
+	if _, err := input.Type.ParseParameters(input.Parameters); err != nil {
+		s.Logger.Error("Service Error",
+			logging.ServiceLogging(
+				"AnalysisService", "Create",
+				logging.InvalidParametersError, err,
+			)...)
+		return nil, ErrInvalidAnalysisParameters
 	}
 
 	user, err := s.UserRepo.GetUserByID(ctx, input.UserID)
@@ -238,10 +241,11 @@ func (s *analysisService) Create(ctx context.Context,
 	}
 
 	analysis := models.Analysis{
-		Type:     input.Type,
-		Status:   models.AnalysisStatusPending,
-		SampleID: sample.ID,
-		UserID:   input.UserID,
+		Type:       input.Type,
+		Status:     models.AnalysisStatusPending,
+		Parameters: input.Parameters,
+		SampleID:   sample.ID,
+		UserID:     input.UserID,
 	}
 
 	analysis.Sample = *sample
@@ -326,7 +330,8 @@ func (s *analysisService) Update(ctx context.Context, analysisID uuid.UUID,
 		return nil, ErrInternal
 	}
 
-	if analysis.Status == models.AnalysisStatusFailed &&
+	if (analysis.Status == models.AnalysisStatusFailed ||
+		analysis.Status == models.AnalysisStatusCancelled) &&
 		analysis.TaskID != nil {
 		if err := s.Canceller.CancelProcessing(*analysis.TaskID); err != nil {
 			s.Logger.Warn("Service Warning", logging.ServiceLogging(
@@ -449,6 +454,388 @@ func (s *analysisService) Delete(ctx context.Context,
 	return nil
 }
 
+func (s *analysisService) Resume(ctx context.Context, analysisID,
+	userID uuid.UUID, language string) (*models.AnalysisResponse, error) {
+	analysis, err := s.Repo.GetAnalysisByID(ctx, analysisID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		s.Logger.Error("Service Error", logging.ServiceLogging(
+			"AnalysisService", "Resume", logging.DatabaseNotFoundError, err,
+		)...)
+		return nil, ErrNotFound
+	}
+
+	if err != nil {
+		s.Logger.Error("Service Error", logging.ServiceLogging(
+			"AnalysisService", "Resume", logging.DatabaseError, err,
+		)...)
+		return nil, ErrInternal
+	}
+
+	if userID != uuid.Nil && userID != analysis.UserID {
+		s.Logger.Error("Service Error", logging.ServiceLogging(
+			"AnalysisService", "Resume", logging.Unauthorized, err,
+		)...)
+		return nil, ErrUnauthorized
+	}
+
+	if analysis.Status != models.AnalysisStatusFailed {
+		s.Logger.Error("Service Error", logging.ServiceLogging(
+			"AnalysisService", "Resume", logging.AnalysisRunError,
+			ErrAnalysisNotResumable,
+		)...)
+		return nil, ErrAnalysisNotResumable
+	}
+
+	analysis.Status = models.AnalysisStatusPending
+	analysis.Step = ""
+	analysis.ErrorMessage = nil
+	analysis.FinishedAt = nil
+	if err := s.Repo.UpdateAnalysis(ctx, analysis); err != nil {
+		s.Logger.Error("Service Error", logging.ServiceLogging(
+			"AnalysisService", "Resume", logging.DatabaseError, err,
+		)...)
+		return nil, ErrInternal
+	}
+
+	task, err := tasks.NewAnalysisResumeTask(analysis.ID)
+	if err != nil {
+		s.Logger.Error("Service Error", logging.ServiceLogging(
+			"AnalysisService", "Resume", logging.AsynqTaskError,
+			err,
+		)...)
+	} else {
+		info, err := s.AsynqClient.EnqueueContext(ctx, task,
+			asynq.Queue(tasks.QueueAnalysis))
+		if err != nil {
+			s.Logger.Error("Service Error", logging.ServiceLogging(
+				"AnalysisService", "Resume",
+				logging.RedisDispatchError, err,
+			)...)
+		} else {
+			s.Logger.Info("Redis Task Info", logging.ServiceInfoLogging(
+				"AnalysisService", "Resume",
+				logging.TaskEnqueuedSuccess, zap.String("task_id", info.ID),
+				zap.String("queue", info.Queue),
+			)...)
+
+			taskID := info.ID
+			analysis.TaskID = &taskID
+			if err := s.Repo.UpdateAnalysis(ctx, analysis); err != nil {
+				s.Logger.Warn("Service Warning", logging.ServiceLogging(
+					"AnalysisService", "Resume",
+					logging.DatabaseError, err,
+				)...)
+			}
+		}
+	}
+
+	response := analysis.ToResponse(language)
+	return &response, nil
+}
+
+// Cancel stops a pending or running analysis. A pending task is removed from
+// the queue; a running one has its context cancelled, which kills its tools,
+// and the worker cleans up the partial outputs once they exit.
+func (s *analysisService) Cancel(ctx context.Context, analysisID,
+	userID uuid.UUID, language string) (*models.AnalysisResponse, error) {
+	analysis, err := s.Repo.GetAnalysisByID(ctx, analysisID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		s.Logger.Error("Service Error", logging.ServiceLogging(
+			"AnalysisService", "Cancel", logging.DatabaseNotFoundError, err,
+		)...)
+		return nil, ErrNotFound
+	}
+
+	if err != nil {
+		s.Logger.Error("Service Error", logging.ServiceLogging(
+			"AnalysisService", "Cancel", logging.DatabaseError, err,
+		)...)
+		return nil, ErrInternal
+	}
+
+	if userID != uuid.Nil && userID != analysis.UserID {
+		s.Logger.Error("Service Error", logging.ServiceLogging(
+			"AnalysisService", "Cancel", logging.Unauthorized, err,
+		)...)
+		return nil, ErrUnauthorized
+	}
+
+	if !analysis.Status.CanTransitionTo(models.AnalysisStatusCancelled) {
+		s.Logger.Error("Service Error", logging.ServiceLogging(
+			"AnalysisService", "Cancel", logging.AnalysisRunError,
+			ErrAnalysisNotCancellable,
+		)...)
+		return nil, ErrAnalysisNotCancellable
+	}
+
+	// Only the status columns are written, and only while the analysis can
+	// still be cancelled: the worker owns the rest of the row.
+	previous := analysis.Status
+	finished := time.Now()
+	cancelled, err := s.Repo.UpdateAnalysisIfStatus(ctx, analysis.ID,
+		[]models.AnalysisStatus{
+			models.AnalysisStatusPending, models.AnalysisStatusRunning,
+		}, map[string]any{
+			"status":      models.AnalysisStatusCancelled,
+			"step":        models.AnalysisStep(""),
+			"finished_at": finished,
+		})
+	if err != nil {
+		s.Logger.Error("Service Error", logging.ServiceLogging(
+			"AnalysisService", "Cancel", logging.DatabaseError, err,
+		)...)
+		return nil, ErrInternal
+	}
+	if !cancelled {
+		// The analysis finished while the request was handled.
+		s.Logger.Error("Service Error", logging.ServiceLogging(
+			"AnalysisService", "Cancel", logging.AnalysisRunError,
+			ErrAnalysisNotCancellable,
+		)...)
+		return nil, ErrAnalysisNotCancellable
+	}
+	analysis.Status = models.AnalysisStatusCancelled
+	analysis.Step = ""
+	analysis.FinishedAt = &finished
+
+	running := previous == models.AnalysisStatusRunning
+	if analysis.TaskID != nil {
+		running = s.stopTask(*analysis.TaskID, running)
+	}
+
+	// The worker cleans up after its tools exit; a pending analysis may still
+	// hold the outputs and checkpoints of an earlier run.
+	if !running {
+		analysisDir := s.getAnalysisFolderPath(analysis.UserID,
+			analysis.SampleID, analysis.ID)
+		if err := os.RemoveAll(analysisDir); err != nil {
+			s.Logger.Warn("Service Warning", logging.ServiceLogging(
+				"AnalysisService", "Cancel", logging.DeleteFolderError, err,
+			)...)
+		}
+	}
+
+	s.Logger.Info(fmt.Sprintf("Analysis %s cancelled", analysis.ID.String()),
+		logging.ServiceInfoLogging("AnalysisService", "Cancel",
+			logging.AnalysisCancelled,
+			zap.String("analysis_id", analysis.ID.String()),
+			zap.String("previous_status", string(previous)),
+		)...)
+
+	response := analysis.ToResponse(language)
+	return &response, nil
+}
+
+// stopTask deletes the queued task or, when it is already being processed,
+// cancels it. It reports whether the task may still be running.
+func (s *analysisService) stopTask(taskID string, running bool) bool {
+	if !running {
+		err := s.Canceller.DeleteTask(tasks.QueueAnalysis, taskID)
+		if err == nil || errors.Is(err, asynq.ErrTaskNotFound) {
+			return false
+		}
+		// The worker picked the task up in the meantime.
+		s.Logger.Warn("Service Warning", logging.ServiceLogging(
+			"AnalysisService", "stopTask", logging.TaskCancelError, err,
+		)...)
+	}
+
+	if err := s.Canceller.CancelProcessing(taskID); err != nil {
+		s.Logger.Warn("Service Warning", logging.ServiceLogging(
+			"AnalysisService", "stopTask", logging.TaskCancelError, err,
+		)...)
+	}
+	return true
+}
+
+func (s *analysisService) FindLogs(ctx context.Context, analysisID,
+	userID uuid.UUID, step models.AnalysisStep, withOutput bool) (
+	[]models.AnalysisLogResponse, error) {
+	if step != "" && !step.IsValid() {
+		return nil, ErrInvalidAnalysisStep
+	}
+
+	analysis, err := s.Repo.GetAnalysisByID(ctx, analysisID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		s.Logger.Error("Service Error", logging.ServiceLogging(
+			"AnalysisService", "FindLogs", logging.DatabaseNotFoundError, err,
+		)...)
+		return nil, ErrNotFound
+	}
+
+	if err != nil {
+		s.Logger.Error("Service Error", logging.ServiceLogging(
+			"AnalysisService", "FindLogs", logging.DatabaseError, err,
+		)...)
+		return nil, ErrInternal
+	}
+
+	if userID != uuid.Nil && userID != analysis.UserID {
+		s.Logger.Error("Service Error", logging.ServiceLogging(
+			"AnalysisService", "FindLogs", logging.Unauthorized, err,
+		)...)
+		return nil, ErrUnauthorized
+	}
+
+	logs, err := s.Repo.GetAnalysisLogs(ctx, analysisID, step)
+	if err != nil {
+		s.Logger.Error("Service Error", logging.ServiceLogging(
+			"AnalysisService", "FindLogs", logging.DatabaseError, err,
+		)...)
+		return nil, ErrInternal
+	}
+
+	responses := make([]models.AnalysisLogResponse, len(logs))
+	for i, log := range logs {
+		responses[i] = log.ToResponse()
+		if withOutput {
+			responses[i].Stdout = s.readToolLog(log.StdoutPath)
+			responses[i].Stderr = s.readToolLog(log.StderrPath)
+		}
+	}
+
+	return responses, nil
+}
+
+// FindTaxonomy returns the Kraken2 composition stored with the results of
+// the analysis.
+func (s *analysisService) FindTaxonomy(ctx context.Context, analysisID,
+	userID uuid.UUID) (*pipeline.KrakenComposition, error) {
+	analysis, err := s.Repo.GetAnalysisByID(ctx, analysisID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		s.Logger.Error("Service Error", logging.ServiceLogging(
+			"AnalysisService", "FindTaxonomy", logging.DatabaseNotFoundError,
+			err,
+		)...)
+		return nil, ErrNotFound
+	}
+
+	if err != nil {
+		s.Logger.Error("Service Error", logging.ServiceLogging(
+			"AnalysisService", "FindTaxonomy", logging.DatabaseError, err,
+		)...)
+		return nil, ErrInternal
+	}
+
+	if userID != uuid.Nil && userID != analysis.UserID {
+		s.Logger.Error("Service Error", logging.ServiceLogging(
+			"AnalysisService", "FindTaxonomy", logging.Unauthorized, err,
+		)...)
+		return nil, ErrUnauthorized
+	}
+
+	var results models.AnalysisResults
+	if len(analysis.Metrics) > 0 {
+		if err := json.Unmarshal(analysis.Metrics, &results); err != nil {
+			s.Logger.Error("Service Error", logging.ServiceLogging(
+				"AnalysisService", "FindTaxonomy", logging.DatabaseError, err,
+			)...)
+			return nil, ErrInternal
+		}
+	}
+
+	if results.Taxonomy == nil {
+		return nil, ErrTaxonomyNotFound
+	}
+
+	return results.Taxonomy, nil
+}
+
+// FindOutdatedDatabases lists the finished analyses run against a database
+// that none of the live workers has anymore. The current fingerprints are
+// the ones the workers published; without workers nothing is outdated.
+func (s *analysisService) FindOutdatedDatabases(ctx context.Context,
+	language string) ([]models.OutdatedAnalysisResponse, error) {
+	environments, err := s.Workers.GetWorkerEnvironments(ctx)
+	if err != nil {
+		s.Logger.Error("Service Error", logging.ServiceLogging(
+			"AnalysisService", "FindOutdatedDatabases",
+			logging.WorkerRegistryError, err,
+		)...)
+		return nil, ErrInternal
+	}
+
+	// Workers are sorted by ID, so the fingerprint shown as current is the
+	// same on every request even when the workers disagree.
+	current := map[string]pipeline.DatabaseFingerprint{}
+	checksums := map[string][]string{}
+	for _, environment := range environments {
+		for _, fingerprint := range environment.Databases {
+			if fingerprint.Checksum == "" {
+				continue
+			}
+			if _, ok := current[fingerprint.Name]; !ok {
+				current[fingerprint.Name] = fingerprint
+			}
+			checksums[fingerprint.Name] = append(
+				checksums[fingerprint.Name], fingerprint.Checksum)
+		}
+	}
+
+	databases, err := s.Repo.GetOutdatedAnalysisDatabases(ctx, checksums)
+	if err != nil {
+		s.Logger.Error("Service Error", logging.ServiceLogging(
+			"AnalysisService", "FindOutdatedDatabases",
+			logging.DatabaseError, err,
+		)...)
+		return nil, ErrInternal
+	}
+
+	responses := []models.OutdatedAnalysisResponse{}
+	for _, database := range databases {
+		outdated := pipeline.OutdatedDatabase{
+			Name:    database.Name,
+			Used:    database.ToFingerprint(),
+			Current: current[database.Name],
+		}
+		last := len(responses) - 1
+		if last >= 0 && responses[last].Analysis.ID == database.AnalysisID {
+			responses[last].Databases = append(responses[last].Databases,
+				outdated)
+			continue
+		}
+		responses = append(responses, models.OutdatedAnalysisResponse{
+			Analysis:  database.Analysis.ToResponse(language),
+			Databases: []pipeline.OutdatedDatabase{outdated},
+		})
+	}
+
+	return responses, nil
+}
+
+// readToolLog returns the end of a tool output file, keeping responses small
+// for chatty tools.
+func (s *analysisService) readToolLog(path string) *string {
+	if path == "" {
+		return nil
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		s.Logger.Warn("Service Warning", logging.ServiceLogging(
+			"AnalysisService", "readToolLog", logging.MissingFileError, err,
+		)...)
+		return nil
+	}
+	defer file.Close()
+
+	if info, err := file.Stat(); err == nil &&
+		info.Size() > MaxToolLogOutputBytes {
+		if _, err := file.Seek(-MaxToolLogOutputBytes,
+			io.SeekEnd); err != nil {
+			return nil
+		}
+	}
+
+	data, err := io.ReadAll(io.LimitReader(file, MaxToolLogOutputBytes))
+	if err != nil {
+		return nil
+	}
+
+	output := string(data)
+	return &output
+}
+
 func (s *analysisService) DownloadZip(ctx context.Context, analysisID,
 	userID uuid.UUID) (string, error) {
 	analysis, err := s.Repo.GetAnalysisByID(ctx, analysisID)
@@ -539,3 +926,63 @@ func (s *analysisService) DownloadBatchTSV(ctx context.Context,
 	}
 	return responses, nil
 }
+
+// AnalysisInputError is returned when the sample files cannot feed the
+// requested analysis. Available lists the analysis types they can feed.
+type AnalysisInputError struct {
+	Err       error
+	Available []models.AnalysisType
+}
+
+func (e *AnalysisInputError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *AnalysisInputError) Unwrap() error {
+	return e.Err
+}
+
+// analysisInputError reports the file analysisType needs and the sample
+// lacks. Short reads may be a pair or single-end, so only fastq1 is required.
+func analysisInputError(analysisType models.AnalysisType,
+	sample *models.Sample) error {
+	if sample.Fastq1 == nil && sample.Fastq2 == nil &&
+		sample.Fasta == nil && sample.LongReads == nil {
+		return ErrMissingFiles
+	}
+
+	switch analysisType {
+	case models.AnalysisTypeFastQC, models.AnalysisTypeComplete:
+		if sample.Fastq1 == nil {
+			return ErrMissingFastq1
+		}
+	case models.AnalysisTypeHybrid:
+		if sample.Fastq1 == nil {
+			return ErrMissingFastq1
+		}
+		if sample.LongReads == nil {
+			return ErrMissingLongReads
+		}
+	case models.AnalysisTypeLongRead:
+		if sample.LongReads == nil {
+			return ErrMissingLongReads
+		}
+	case models.AnalysisTypeGenome:
+		if sample.Fastq1 == nil && sample.Fasta == nil {
+			return ErrMissingFiles
+		}
+	}
+
+	return nil
+}
+
+// availableAnalysisTypes lists the analysis types the sample files can feed.
+func availableAnalysisTypes(sample *models.Sample) []models.AnalysisType {
+	var available []models.AnalysisType
+	for _, analysisType := range models.AnalysisTypes {
+		if analysisInputError(analysisType, sample) == nil {
+			available = append(available, analysisType)
+		}
+	}
+	return available
+}
@@ -2,12 +2,16 @@ package services_test
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/CABGenOrg/cabgen_backend/internal/models"
+	"github.com/CABGenOrg/cabgen_backend/internal/pipeline"
+	"github.com/CABGenOrg/cabgen_backend/internal/queue/tasks"
 	"github.com/CABGenOrg/cabgen_backend/internal/services"
 	"github.com/CABGenOrg/cabgen_backend/internal/testutils"
 	"github.com/CABGenOrg/cabgen_backend/internal/testutils/mocks"
@@ -17,6 +21,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
+	"gorm.io/datatypes"
 	"gorm.io/gorm"
 )
 
@@ -32,7 +37,7 @@ func TestAnalysisFindAll(t *testing.T) {
 			},
 		}
 
-		svc := services.NewAnalysisService(analysisRepo, nil, nil, nil, nil, nil, t.TempDir())
+		svc := services.NewAnalysisService(analysisRepo, nil, nil, nil, nil, nil, nil, t.TempDir())
 		result, err := svc.FindAll(ctx, uuid.Nil, models.AnalysisFilter{}, "en")
 
 		assert.NoError(t, err)
@@ -50,7 +55,7 @@ func TestAnalysisFindAll(t *testing.T) {
 
 		mockLogger, logs := testutils.NewMockLogger(zapcore.ErrorLevel)
 
-		svc := services.NewAnalysisService(analysisRepo, nil, nil, nil, nil, mockLogger, t.TempDir())
+		svc := services.NewAnalysisService(analysisRepo, nil, nil, nil, nil, nil, mockLogger, t.TempDir())
 		result, err := svc.FindAll(ctx, uuid.Nil, models.AnalysisFilter{}, "en")
 
 		assert.Error(t, err)
@@ -73,7 +78,7 @@ func TestAnalysisFindManyByIDs(t *testing.T) {
 			},
 		}
 
-		svc := services.NewAnalysisService(analysisRepo, nil, nil, nil, nil, nil, t.TempDir())
+		svc := services.NewAnalysisService(analysisRepo, nil, nil, nil, nil, nil, nil, t.TempDir())
 		result, err := svc.FindManyByIDs(ctx, []uuid.UUID{mock.ID},
 			mock.User.ID, "en")
 
@@ -85,7 +90,7 @@ func TestAnalysisFindManyByIDs(t *testing.T) {
 	t.Run("Success - Empty Analysis IDs", func(t *testing.T) {
 		analysisRepo := &mocks.MockAnalysisRepository{}
 
-		svc := services.NewAnalysisService(analysisRepo, nil, nil, nil, nil, nil, t.TempDir())
+		svc := services.NewAnalysisService(analysisRepo, nil, nil, nil, nil, nil, nil, t.TempDir())
 		result, err := svc.FindManyByIDs(ctx, []uuid.UUID{},
 			mock.User.ID, "en")
 
@@ -98,7 +103,7 @@ func TestAnalysisFindManyByIDs(t *testing.T) {
 
 		mockLogger, logs := testutils.NewMockLogger(zapcore.ErrorLevel)
 
-		svc := services.NewAnalysisService(analysisRepo, nil, nil, nil, nil, mockLogger, t.TempDir())
+		svc := services.NewAnalysisService(analysisRepo, nil, nil, nil, nil, nil, mockLogger, t.TempDir())
 		result, err := svc.FindManyByIDs(ctx, make([]uuid.UUID,
 			models.AnalysesByBatch+1), mock.User.ID, "en")
 
@@ -119,7 +124,7 @@ func TestAnalysisFindManyByIDs(t *testing.T) {
 
 		mockLogger, logs := testutils.NewMockLogger(zapcore.ErrorLevel)
 
-		svc := services.NewAnalysisService(analysisRepo, nil, nil, nil, nil, mockLogger, t.TempDir())
+		svc := services.NewAnalysisService(analysisRepo, nil, nil, nil, nil, nil, mockLogger, t.TempDir())
 		result, err := svc.FindManyByIDs(ctx, []uuid.UUID{mock.ID},
 			mock.User.ID, "en")
 
@@ -142,7 +147,7 @@ func TestAnalysisFindByID(t *testing.T) {
 			},
 		}
 
-		svc := services.NewAnalysisService(analysisRepo, nil, nil, nil, nil, nil, t.TempDir())
+		svc := services.NewAnalysisService(analysisRepo, nil, nil, nil, nil, nil, nil, t.TempDir())
 		result, err := svc.FindByID(ctx, mock.ID, mock.UserID, "en")
 
 		assert.NoError(t, err)
@@ -162,7 +167,7 @@ func TestAnalysisFindByID(t *testing.T) {
 
 		mockLogger, logs := testutils.NewMockLogger(zap.ErrorLevel)
 
-		svc := services.NewAnalysisService(analysisRepo, nil, nil, nil, nil, mockLogger, t.TempDir())
+		svc := services.NewAnalysisService(analysisRepo, nil, nil, nil, nil, nil, mockLogger, t.TempDir())
 		result, err := svc.FindByID(ctx, mock.ID, mock.UserID, "en")
 
 		assert.Error(t, err)
@@ -181,7 +186,7 @@ func TestAnalysisFindByID(t *testing.T) {
 
 		mockLogger, logs := testutils.NewMockLogger(zap.ErrorLevel)
 
-		svc := services.NewAnalysisService(analysisRepo, nil, nil, nil, nil, mockLogger, t.TempDir())
+		svc := services.NewAnalysisService(analysisRepo, nil, nil, nil, nil, nil, mockLogger, t.TempDir())
 		result, err := svc.FindByID(ctx, mock.ID, uuid.New(), "en")
 
 		assert.Error(t, err)
@@ -200,7 +205,7 @@ func TestAnalysisFindByID(t *testing.T) {
 
 		mockLogger, logs := testutils.NewMockLogger(zap.ErrorLevel)
 
-		svc := services.NewAnalysisService(analysisRepo, nil, nil, nil, nil, mockLogger, t.TempDir())
+		svc := services.NewAnalysisService(analysisRepo, nil, nil, nil, nil, nil, mockLogger, t.TempDir())
 		result, err := svc.FindByID(ctx, mock.ID, mock.UserID, "en")
 
 		assert.Error(t, err)
@@ -234,7 +239,7 @@ func TestAnalysisCreate(t *testing.T) {
 		mockLogger, logs := testutils.NewMockLogger(zap.InfoLevel)
 
 		svc := services.NewAnalysisService(analysisRepo, sampleRepo,
-			userRepo, enqueuer, nil, mockLogger, t.TempDir())
+			userRepo, enqueuer, nil, nil, mockLogger, t.TempDir())
 		result, err := svc.Create(ctx, input, "en")
 
 		expected := models.AnalysisResponse{
@@ -278,7 +283,7 @@ func TestAnalysisCreate(t *testing.T) {
 		mockLogger, _ := testutils.NewMockLogger(zap.InfoLevel)
 
 		svc := services.NewAnalysisService(analysisRepo, sampleRepo,
-			userRepo, enqueuer, nil, mockLogger, t.TempDir())
+			userRepo, enqueuer, nil, nil, mockLogger, t.TempDir())
 		result, err := svc.Create(ctx, input, "en")
 
 		assert.NoError(t, err)
@@ -311,7 +316,7 @@ func TestAnalysisCreate(t *testing.T) {
 		mockLogger, logs := testutils.NewMockLogger(zap.ErrorLevel)
 
 		svc := services.NewAnalysisService(analysisRepo, sampleRepo,
-			userRepo, failingEnqueuer, nil, mockLogger, t.TempDir())
+			userRepo, failingEnqueuer, nil, nil, mockLogger, t.TempDir())
 		result, err := svc.Create(ctx, input, "en")
 
 		expected := models.AnalysisResponse{
@@ -329,6 +334,68 @@ func TestAnalysisCreate(t *testing.T) {
 		assert.Equal(t, 1, logs.Len())
 	})
 
+	t.Run("Success - Stores Parameters", func(t *testing.T) {
+		var created *models.Analysis
+		analysisRepo := &mocks.MockAnalysisRepository{
+			CreateAnalysisFunc: func(ctx context.Context,
+				analysis *models.Analysis) error {
+				created = analysis
+				return nil
+			},
+		}
+		sampleRepo := &mocks.MockSampleRepository{
+			GetSampleByIDFunc: func(ctx context.Context,
+				ID uuid.UUID) (*models.Sample, error) {
+				return &mock.Sample, nil
+			},
+		}
+		userRepo := &mocks.MockUserRepository{
+			GetUserByIDFunc: func(ctx context.Context,
+				ID uuid.UUID) (*models.User, error) {
+				return &mock.User, nil
+			},
+		}
+		mockLogger, _ := testutils.NewMockLogger(zap.InfoLevel)
+
+		paramsInput := input
+		paramsInput.Parameters = datatypes.JSON(
+			`{"unicycler":{"mode":"bold"}}`)
+
+		svc := services.NewAnalysisService(analysisRepo, sampleRepo,
+			userRepo, &mocks.MockTaskEnqueuer{}, nil, nil, mockLogger,
+			t.TempDir())
+		result, err := svc.Create(ctx, paramsInput, "en")
+
+		assert.NoError(t, err)
+		assert.NotNil(t, created)
+		assert.JSONEq(t, `{"unicycler":{"mode":"bold"}}`,
+			string(created.Parameters))
+		assert.JSONEq(t, `{"unicycler":{"mode":"bold"}}`,
+			string(result.Parameters))
+	})
+
+	t.Run("Error - Invalid Parameters", func(t *testing.T) {
+		sampleRepo := &mocks.MockSampleRepository{
+			GetSampleByIDFunc: func(ctx context.Context,
+				ID uuid.UUID) (*models.Sample, error) {
+				return &mock.Sample, nil
+			},
+		}
+		mockLogger, logs := testutils.NewMockLogger(zap.ErrorLevel)
+
+		paramsInput := input
+		paramsInput.Parameters = datatypes.JSON(
+			`{"blastx":{"evalue":-1}}`)
+
+		svc := services.NewAnalysisService(&mocks.MockAnalysisRepository{},
+			sampleRepo, nil, nil, nil, nil, mockLogger, t.TempDir())
+		result, err := svc.Create(ctx, paramsInput, "en")
+
+		assert.ErrorIs(t, err, services.ErrInvalidAnalysisParameters)
+		assert.Nil(t, result)
+		assert.Equal(t, 1, logs.Len())
+	})
+
 	t.Run("Error - Sample Not Found", func(t *testing.T) {
 		analysisRepo := &mocks.MockAnalysisRepository{}
 		sampleRepo := &mocks.MockSampleRepository{
@@ -341,7 +408,7 @@ func TestAnalysisCreate(t *testing.T) {
 		mockLogger, logs := testutils.NewMockLogger(zap.ErrorLevel)
 
 		svc := services.NewAnalysisService(analysisRepo, sampleRepo,
-			nil, nil, nil, mockLogger, t.TempDir())
+			nil, nil, nil, nil, mockLogger, t.TempDir())
 		result, err := svc.Create(ctx, input, "en")
 
 		assert.Error(t, err)
@@ -362,7 +429,7 @@ func TestAnalysisCreate(t *testing.T) {
 		mockLogger, logs := testutils.NewMockLogger(zap.ErrorLevel)
 
 		svc := services.NewAnalysisService(analysisRepo, sampleRepo,
-			nil, nil, nil, mockLogger, t.TempDir())
+			nil, nil, nil, nil, mockLogger, t.TempDir())
 		result, err := svc.Create(ctx, input, "en")
 
 		assert.Error(t, err)
@@ -383,7 +450,7 @@ func TestAnalysisCreate(t *testing.T) {
 		mockLogger, logs := testutils.NewMockLogger(zap.ErrorLevel)
 
 		svc := services.NewAnalysisService(analysisRepo, sampleRepo,
-			nil, nil, nil, mockLogger, t.TempDir())
+			nil, nil, nil, nil, mockLogger, t.TempDir())
 
 		result, err := svc.Create(ctx, models.AnalysisCreateDTO{
 			Type:     models.AnalysisTypeComplete,
@@ -412,7 +479,7 @@ func TestAnalysisCreate(t *testing.T) {
 			mockLogger, logs := testutils.NewMockLogger(zap.ErrorLevel)
 
 			svc := services.NewAnalysisService(analysisRepo, sampleRepo,
-				nil, nil, nil, mockLogger, t.TempDir())
+				nil, nil, nil, nil, mockLogger, t.TempDir())
 
 			errorInput := models.AnalysisCreateDTO{
 				Type:     models.AnalysisTypeFastQC,
@@ -427,36 +494,67 @@ func TestAnalysisCreate(t *testing.T) {
 			assert.Equal(t, 1, logs.Len())
 		})
 
-	t.Run("Error - Sample Missing Fastq2 in FASTQC analysis",
-		func(t *testing.T) {
-			analysisRepo := &mocks.MockAnalysisRepository{}
-			sampleRepo := &mocks.MockSampleRepository{
-				GetSampleByIDFunc: func(ctx context.Context,
-					ID uuid.UUID) (*models.Sample, error) {
-					fastq1 := "reads1.fastq"
-					fasta := "assembly.fasta"
-					sample := &models.Sample{Fastq1: &fastq1, Fasta: &fasta}
-					return sample, nil
-				},
-			}
+	t.Run("Error - Lists Available Analyses", func(t *testing.T) {
+		analysisRepo := &mocks.MockAnalysisRepository{}
+		sampleRepo := &mocks.MockSampleRepository{
+			GetSampleByIDFunc: func(ctx context.Context,
+				ID uuid.UUID) (*models.Sample, error) {
+				fastq1 := "reads1.fastq"
+				fasta := "assembly.fasta"
+				return &models.Sample{Fastq1: &fastq1, Fasta: &fasta}, nil
+			},
+		}
 
-			mockLogger, logs := testutils.NewMockLogger(zap.ErrorLevel)
+		mockLogger, logs := testutils.NewMockLogger(zap.ErrorLevel)
 
-			svc := services.NewAnalysisService(analysisRepo, sampleRepo,
-				nil, nil, nil, mockLogger, t.TempDir())
+		svc := services.NewAnalysisService(analysisRepo, sampleRepo,
+			nil, nil, nil, nil, mockLogger, t.TempDir())
 
-			errorInput := models.AnalysisCreateDTO{
-				Type:     models.AnalysisTypeFastQC,
+		result, err := svc.Create(ctx, models.AnalysisCreateDTO{
+			Type:     models.AnalysisTypeLongRead,
+			SampleID: input.SampleID,
+			UserID:   input.UserID,
+		}, "en")
+
+		var inputErr *services.AnalysisInputError
+		assert.ErrorIs(t, err, services.ErrMissingLongReads)
+		assert.ErrorAs(t, err, &inputErr)
+		assert.Equal(t, []models.AnalysisType{
+			models.AnalysisTypeFastQC, models.AnalysisTypeGenome,
+			models.AnalysisTypeComplete,
+		}, inputErr.Available)
+		assert.Nil(t, result)
+		assert.Equal(t, 1, logs.Len())
+	})
+
+	t.Run("Error - Sample Missing Long Reads", func(t *testing.T) {
+		fastq1, fastq2 := "reads1.fastq", "reads2.fastq"
+		sampleRepo := &mocks.MockSampleRepository{
+			GetSampleByIDFunc: func(ctx context.Context,
+				ID uuid.UUID) (*models.Sample, error) {
+				return &models.Sample{Fastq1: &fastq1, Fastq2: &fastq2}, nil
+			},
+		}
+
+		for _, analysisType := range []models.AnalysisType{
+			models.AnalysisTypeLongRead, models.AnalysisTypeHybrid,
+		} {
+			mockLogger, logs := testutils.NewMockLogger(zap.ErrorLevel)
+			svc := services.NewAnalysisService(
+				&mocks.MockAnalysisRepository{}, sampleRepo, nil, nil, nil, nil,
+				mockLogger, t.TempDir())
+
+			result, err := svc.Create(ctx, models.AnalysisCreateDTO{
+				Type:     analysisType,
 				SampleID: input.SampleID,
 				UserID:   input.UserID,
-			}
-			result, err := svc.Create(ctx, errorInput, "en")
+			}, "en")
 
-			assert.Error(t, err)
-			assert.ErrorIs(t, err, services.ErrMissingFastq2)
+			assert.ErrorIs(t, err, services.ErrMissingLongReads, analysisType)
 			assert.Nil(t, result)
 			assert.Equal(t, 1, logs.Len())
-		})
+		}
+	})
 
 	t.Run("Error - Complete With Only Fasta",
 		func(t *testing.T) {
@@ -477,7 +575,7 @@ func TestAnalysisCreate(t *testing.T) {
 			mockLogger, logs := testutils.NewMockLogger(zap.ErrorLevel)
 
 			svc := services.NewAnalysisService(analysisRepo, sampleRepo,
-				nil, nil, nil, mockLogger, t.TempDir())
+				nil, nil, nil, nil, mockLogger, t.TempDir())
 
 			result, err := svc.Create(ctx, input, "en")
 
@@ -499,7 +597,7 @@ func TestAnalysisCreate(t *testing.T) {
 		mockLogger, logs := testutils.NewMockLogger(zap.ErrorLevel)
 
 		svc := services.NewAnalysisService(analysisRepo, sampleRepo,
-			nil, nil, nil, mockLogger, t.TempDir())
+			nil, nil, nil, nil, mockLogger, t.TempDir())
 
 		result, err := svc.Create(ctx, models.AnalysisCreateDTO{
 			Type:     models.AnalysisTypeComplete,
@@ -513,20 +611,31 @@ func TestAnalysisCreate(t *testing.T) {
 		assert.Equal(t, 1, logs.Len())
 	})
 
-	t.Run("Error - Complete Missing Fastq2", func(t *testing.T) {
+	t.Run("Success - Complete With Single-end Reads", func(t *testing.T) {
 		analysisRepo := &mocks.MockAnalysisRepository{}
 		sampleRepo := &mocks.MockSampleRepository{
 			GetSampleByIDFunc: func(ctx context.Context,
 				ID uuid.UUID) (*models.Sample, error) {
 				fastq1 := "reads1.fastq"
-				return &models.Sample{Fastq1: &fastq1}, nil
+				return &models.Sample{
+					ID:         mock.Sample.ID,
+					OriginCode: mock.Sample.OriginCode,
+					Fastq1:     &fastq1,
+				}, nil
+			},
+		}
+		userRepo := &mocks.MockUserRepository{
+			GetUserByIDFunc: func(ctx context.Context,
+				ID uuid.UUID) (*models.User, error) {
+				return &mock.User, nil
 			},
 		}
 
-		mockLogger, logs := testutils.NewMockLogger(zap.ErrorLevel)
+		mockLogger, logs := testutils.NewMockLogger(zap.InfoLevel)
 
+		enqueuer := &mocks.MockTaskEnqueuer{}
 		svc := services.NewAnalysisService(analysisRepo, sampleRepo,
-			nil, nil, nil, mockLogger, t.TempDir())
+			userRepo, enqueuer, nil, nil, mockLogger, t.TempDir())
 
 		result, err := svc.Create(ctx, models.AnalysisCreateDTO{
 			Type:     models.AnalysisTypeComplete,
@@ -534,9 +643,9 @@ func TestAnalysisCreate(t *testing.T) {
 			UserID:   input.UserID,
 		}, "en")
 
-		assert.Error(t, err)
-		assert.ErrorIs(t, err, services.ErrMissingFastq2)
-		assert.Nil(t, result)
+		assert.NoError(t, err)
+		assert.NotNil(t, result)
+		assert.Equal(t, models.AnalysisTypeComplete, result.Type)
 		assert.Equal(t, 1, logs.Len())
 	})
 
@@ -564,7 +673,7 @@ func TestAnalysisCreate(t *testing.T) {
 
 		enqueuer := &mocks.MockTaskEnqueuer{}
 		svc := services.NewAnalysisService(analysisRepo, sampleRepo,
-			userRepo, enqueuer, nil, mockLogger, t.TempDir())
+			userRepo, enqueuer, nil, nil, mockLogger, t.TempDir())
 
 		result, err := svc.Create(ctx, models.AnalysisCreateDTO{
 			Type:     models.AnalysisTypeGenome,
@@ -599,7 +708,7 @@ func TestAnalysisCreate(t *testing.T) {
 		mockLogger, logs := testutils.NewMockLogger(zap.ErrorLevel)
 
 		svc := services.NewAnalysisService(analysisRepo, sampleRepo,
-			nil, nil, nil, mockLogger, t.TempDir())
+			nil, nil, nil, nil, mockLogger, t.TempDir())
 
 		result, err := svc.Create(ctx, models.AnalysisCreateDTO{
 			Type:     models.AnalysisTypeGenome,
@@ -631,7 +740,7 @@ func TestAnalysisCreate(t *testing.T) {
 		mockLogger, logs := testutils.NewMockLogger(zap.ErrorLevel)
 
 		svc := services.NewAnalysisService(analysisRepo, sampleRepo,
-			userRepo, nil, nil, mockLogger, t.TempDir())
+			userRepo, nil, nil, nil, mockLogger, t.TempDir())
 		result, err := svc.Create(ctx, input, "en")
 
 		assert.Error(t, err)
@@ -658,7 +767,7 @@ func TestAnalysisCreate(t *testing.T) {
 		mockLogger, logs := testutils.NewMockLogger(zap.ErrorLevel)
 
 		svc := services.NewAnalysisService(analysisRepo, sampleRepo,
-			userRepo, nil, nil, mockLogger, t.TempDir())
+			userRepo, nil, nil, nil, mockLogger, t.TempDir())
 		result, err := svc.Create(ctx, input, "en")
 
 		assert.Error(t, err)
@@ -690,7 +799,7 @@ func TestAnalysisCreate(t *testing.T) {
 		mockLogger, logs := testutils.NewMockLogger(zap.ErrorLevel)
 
 		svc := services.NewAnalysisService(analysisRepo, sampleRepo, userRepo,
-			nil, nil, mockLogger, t.TempDir())
+			nil, nil, nil, mockLogger, t.TempDir())
 		result, err := svc.Create(ctx, input, "en")
 
 		assert.Error(t, err)
@@ -735,7 +844,7 @@ func TestAnalysisUpdate(t *testing.T) {
 		mockLogger, logs := testutils.NewMockLogger(zap.InfoLevel)
 
 		svc := services.NewAnalysisService(analysisRepo, nil, nil,
-			enqueuer, nil, mockLogger, t.TempDir())
+			enqueuer, nil, nil, mockLogger, t.TempDir())
 		result, err := svc.Update(ctx, mock.ID, updateInputPending, "en")
 
 		assert.NoError(t, err)
@@ -767,7 +876,7 @@ func TestAnalysisUpdate(t *testing.T) {
 		mockLogger, _ := testutils.NewMockLogger(zap.InfoLevel)
 
 		svc := services.NewAnalysisService(analysisRepo, nil, nil,
-			enqueuer, nil, mockLogger, t.TempDir())
+			enqueuer, nil, nil, mockLogger, t.TempDir())
 		result, err := svc.Update(ctx, mockCopy.ID, updateInputPending, "en")
 
 		assert.NoError(t, err)
@@ -794,7 +903,7 @@ func TestAnalysisUpdate(t *testing.T) {
 
 		canceller := &mocks.MockTaskCanceller{}
 		svc := services.NewAnalysisService(analysisRepo, nil, nil,
-			enqueuer, canceller, mockLogger, t.TempDir())
+			enqueuer, canceller, nil, mockLogger, t.TempDir())
 		result, err := svc.Update(ctx, mock.ID, updateInputFailed, "en")
 
 		assert.NoError(t, err)
@@ -825,7 +934,7 @@ func TestAnalysisUpdate(t *testing.T) {
 		mockLogger, _ := testutils.NewMockLogger(zap.InfoLevel)
 
 		svc := services.NewAnalysisService(analysisRepo, nil, nil,
-			enqueuer, canceller, mockLogger, t.TempDir())
+			enqueuer, canceller, nil, mockLogger, t.TempDir())
 		result, err := svc.Update(ctx, mockWithTaskID.ID, updateInputFailed, "en")
 
 		assert.NoError(t, err)
@@ -860,7 +969,7 @@ func TestAnalysisUpdate(t *testing.T) {
 		mockLogger, logs := testutils.NewMockLogger(zap.WarnLevel)
 
 		svc := services.NewAnalysisService(analysisRepo, nil, nil,
-			enqueuer, canceller, mockLogger, t.TempDir())
+			enqueuer, canceller, nil, mockLogger, t.TempDir())
 		result, err := svc.Update(ctx, mockWithTaskID.ID, updateInputFailed, "en")
 
 		assert.NoError(t, err)
@@ -889,7 +998,7 @@ func TestAnalysisUpdate(t *testing.T) {
 		mockLogger, _ := testutils.NewMockLogger(zap.InfoLevel)
 
 		svc := services.NewAnalysisService(analysisRepo, nil, nil,
-			enqueuer, canceller, mockLogger, t.TempDir())
+			enqueuer, canceller, nil, mockLogger, t.TempDir())
 		result, err := svc.Update(ctx, mockNoTaskID.ID, updateInputFailed, "en")
 
 		assert.NoError(t, err)
@@ -920,7 +1029,7 @@ func TestAnalysisUpdate(t *testing.T) {
 
 		canceller := &mocks.MockTaskCanceller{}
 		svc := services.NewAnalysisService(analysisRepo, nil, nil,
-			failingEnqueuer, canceller, mockLogger, t.TempDir())
+			failingEnqueuer, canceller, nil, mockLogger, t.TempDir())
 		result, err := svc.Update(ctx, mock.ID, updateInputFailed, "en")
 
 		assert.NoError(t, err)
@@ -937,7 +1046,7 @@ func TestAnalysisUpdate(t *testing.T) {
 			},
 		}
 
-		svc := services.NewAnalysisService(analysisRepo, nil, nil, nil, nil, nil,
+		svc := services.NewAnalysisService(analysisRepo, nil, nil, nil, nil, nil, nil,
 			t.TempDir())
 		result, err := svc.Update(ctx, mock.ID, updateInputRunning, "en")
 
@@ -956,7 +1065,7 @@ func TestAnalysisUpdate(t *testing.T) {
 
 		mockLogger, logs := testutils.NewMockLogger(zap.ErrorLevel)
 
-		svc := services.NewAnalysisService(analysisRepo, nil, nil, nil, nil,
+		svc := services.NewAnalysisService(analysisRepo, nil, nil, nil, nil, nil,
 			mockLogger, t.TempDir())
 		result, err := svc.Update(ctx, mock.ID, updateInputPending, "en")
 
@@ -976,7 +1085,7 @@ func TestAnalysisUpdate(t *testing.T) {
 
 		mockLogger, logs := testutils.NewMockLogger(zap.ErrorLevel)
 
-		svc := services.NewAnalysisService(analysisRepo, nil, nil, nil, nil,
+		svc := services.NewAnalysisService(analysisRepo, nil, nil, nil, nil, nil,
 			mockLogger, t.TempDir())
 		result, err := svc.Update(ctx, mock.ID, updateInputPending, "en")
 
@@ -1000,7 +1109,7 @@ func TestAnalysisUpdate(t *testing.T) {
 
 		mockLogger, logs := testutils.NewMockLogger(zap.ErrorLevel)
 
-		svc := services.NewAnalysisService(analysisRepo, nil, nil, nil, nil,
+		svc := services.NewAnalysisService(analysisRepo, nil, nil, nil, nil, nil,
 			mockLogger, t.TempDir())
 		result, err := svc.Update(ctx, mock.ID, updateInputFailed, "en")
 
@@ -1027,7 +1136,7 @@ func TestAnalysisDelete(t *testing.T) {
 			},
 		}
 
-		svc := services.NewAnalysisService(analysisRepo, nil, nil, nil, nil, nil, t.TempDir())
+		svc := services.NewAnalysisService(analysisRepo, nil, nil, nil, nil, nil, nil, t.TempDir())
 		err := svc.Delete(ctx, mock.ID, mock.UserID)
 
 		assert.NoError(t, err)
@@ -1054,7 +1163,7 @@ func TestAnalysisDelete(t *testing.T) {
 			},
 		}
 
-		svc := services.NewAnalysisService(analysisRepo, nil, nil, nil, nil, nil,
+		svc := services.NewAnalysisService(analysisRepo, nil, nil, nil, nil, nil, nil,
 			rootDir)
 		err = svc.Delete(ctx, mock.ID, mock.UserID)
 
@@ -1080,7 +1189,7 @@ func TestAnalysisDelete(t *testing.T) {
 		}
 
 		mockLogger, logs := testutils.NewMockLogger(zap.WarnLevel)
-		svc := services.NewAnalysisService(analysisRepo, nil, nil, nil, nil,
+		svc := services.NewAnalysisService(analysisRepo, nil, nil, nil, nil, nil,
 			mockLogger, rootDirFile)
 		err = svc.Delete(ctx, mock.ID, mock.UserID)
 
@@ -1098,7 +1207,7 @@ func TestAnalysisDelete(t *testing.T) {
 
 		mockLogger, logs := testutils.NewMockLogger(zap.ErrorLevel)
 
-		svc := services.NewAnalysisService(analysisRepo, nil, nil, nil, nil, mockLogger, t.TempDir())
+		svc := services.NewAnalysisService(analysisRepo, nil, nil, nil, nil, nil, mockLogger, t.TempDir())
 		err := svc.Delete(ctx, mock.ID, mock.UserID)
 
 		assert.Error(t, err)
@@ -1116,7 +1225,7 @@ func TestAnalysisDelete(t *testing.T) {
 
 		mockLogger, logs := testutils.NewMockLogger(zap.ErrorLevel)
 
-		svc := services.NewAnalysisService(analysisRepo, nil, nil, nil, nil, mockLogger, t.TempDir())
+		svc := services.NewAnalysisService(analysisRepo, nil, nil, nil, nil, nil, mockLogger, t.TempDir())
 		err := svc.Delete(ctx, mock.ID, uuid.New())
 
 		assert.Error(t, err)
@@ -1134,7 +1243,7 @@ func TestAnalysisDelete(t *testing.T) {
 
 		mockLogger, logs := testutils.NewMockLogger(zap.ErrorLevel)
 
-		svc := services.NewAnalysisService(analysisRepo, nil, nil, nil, nil, mockLogger, t.TempDir())
+		svc := services.NewAnalysisService(analysisRepo, nil, nil, nil, nil, nil, mockLogger, t.TempDir())
 		err := svc.Delete(ctx, mock.ID, mock.UserID)
 
 		assert.Error(t, err)
@@ -1155,7 +1264,7 @@ func TestAnalysisDelete(t *testing.T) {
 
 		mockLogger, logs := testutils.NewMockLogger(zap.ErrorLevel)
 
-		svc := services.NewAnalysisService(analysisRepo, nil, nil, nil, nil, mockLogger, t.TempDir())
+		svc := services.NewAnalysisService(analysisRepo, nil, nil, nil, nil, nil, mockLogger, t.TempDir())
 		err := svc.Delete(ctx, runningMock.ID, runningMock.UserID)
 
 		assert.Error(t, err)
@@ -1177,7 +1286,7 @@ func TestAnalysisDelete(t *testing.T) {
 
 		mockLogger, logs := testutils.NewMockLogger(zap.ErrorLevel)
 
-		svc := services.NewAnalysisService(analysisRepo, nil, nil, nil, nil, mockLogger, t.TempDir())
+		svc := services.NewAnalysisService(analysisRepo, nil, nil, nil, nil, nil, mockLogger, t.TempDir())
 		err := svc.Delete(ctx, mock.ID, mock.UserID)
 
 		assert.Error(t, err)
@@ -1186,115 +1295,772 @@ func TestAnalysisDelete(t *testing.T) {
 	})
 }
 
-func TestAnalysisDownloadZip(t *testing.T) {
+func TestAnalysisResume(t *testing.T) {
 	ctx := context.Background()
 
-	newRepo := func(analysisReturn func() (*models.Analysis, error)) *mocks.MockAnalysisRepository {
-		return &mocks.MockAnalysisRepository{
+	newFailedMock := func() models.Analysis {
+		mock := testmodels.CreateMockAnalysis()
+		errMsg := "The Kraken2 step failed. Create a new analysis."
+		mock.Status = models.AnalysisStatusFailed
+		mock.ErrorMessage = &errMsg
+		return mock
+	}
+
+	t.Run("Success - Enqueues Resume Task", func(t *testing.T) {
+		mock := newFailedMock()
+		var capturedAnalysis *models.Analysis
+		analysisRepo := &mocks.MockAnalysisRepository{
 			GetAnalysisByIDFunc: func(ctx context.Context,
 				analysisID uuid.UUID) (*models.Analysis, error) {
-				return analysisReturn()
+				return &mock, nil
+			},
+			UpdateAnalysisFunc: func(ctx context.Context,
+				analysis *models.Analysis) error {
+				capturedAnalysis = analysis
+				return nil
 			},
 		}
-	}
-
-	t.Run("Success", func(t *testing.T) {
-		rootDir := t.TempDir()
-		zipPath := filepath.Join(rootDir, "results.zip")
-		err := os.WriteFile(zipPath, []byte("x"), 0644)
-		assert.NoError(t, err)
 
-		mock := testmodels.CreateMockAnalysis()
-		mock.Status = models.AnalysisStatusDone
-		mock.ResultsZipPath = &zipPath
+		var enqueuedPayload tasks.AnalysisProcessPayload
+		enqueuer := &mocks.MockTaskEnqueuer{
+			EnqueueContextFunc: func(ctx context.Context, task *asynq.Task,
+				opts ...asynq.Option) (*asynq.TaskInfo, error) {
+				assert.Equal(t, tasks.TaskTypeAnalysisProcess, task.Type())
+				err := json.Unmarshal(task.Payload(), &enqueuedPayload)
+				assert.NoError(t, err)
+				return &asynq.TaskInfo{ID: "resume-task-id",
+					Queue: tasks.QueueAnalysis}, nil
+			},
+		}
+		mockLogger, _ := testutils.NewMockLogger(zap.InfoLevel)
 
-		svc := services.NewAnalysisService(newRepo(func() (*models.Analysis,
-			error) {
-			return &mock, nil
-		}), nil, nil, nil, nil, zap.NewNop(),
-			rootDir)
-		gotPath, err := svc.DownloadZip(ctx, mock.ID, mock.UserID)
+		svc := services.NewAnalysisService(analysisRepo, nil, nil,
+			enqueuer, nil, nil, mockLogger, t.TempDir())
+		result, err := svc.Resume(ctx, mock.ID, mock.UserID, "en")
 
 		assert.NoError(t, err)
-		assert.Equal(t, zipPath, gotPath)
+		assert.NotNil(t, result)
+		assert.Equal(t, models.AnalysisStatusPending, result.Status)
+		assert.Nil(t, result.ErrorMessage)
+		assert.Equal(t, mock.ID, enqueuedPayload.AnalysisID)
+		assert.True(t, enqueuedPayload.Resume)
+		assert.NotNil(t, capturedAnalysis)
+		assert.Equal(t, "resume-task-id", *capturedAnalysis.TaskID)
 	})
 
-	t.Run("Error - Not Found", func(t *testing.T) {
-		mockLogger, logs := testutils.NewMockLogger(zap.ErrorLevel)
+	t.Run("Success - Admin", func(t *testing.T) {
+		mock := newFailedMock()
+		analysisRepo := &mocks.MockAnalysisRepository{
+			GetAnalysisByIDFunc: func(ctx context.Context,
+				analysisID uuid.UUID) (*models.Analysis, error) {
+				return &mock, nil
+			},
+		}
+		mockLogger, _ := testutils.NewMockLogger(zap.InfoLevel)
 
-		svc := services.NewAnalysisService(newRepo(func() (*models.Analysis,
-			error) {
-			return nil, gorm.ErrRecordNotFound
-		}), nil, nil, nil, nil,
-			mockLogger, t.TempDir())
-		_, err := svc.DownloadZip(ctx, uuid.New(), uuid.Nil)
+		svc := services.NewAnalysisService(analysisRepo, nil, nil,
+			&mocks.MockTaskEnqueuer{}, nil, nil, mockLogger, t.TempDir())
+		result, err := svc.Resume(ctx, mock.ID, uuid.Nil, "en")
 
-		assert.ErrorIs(t, err, services.ErrNotFound)
-		assert.Equal(t, 1, logs.Len())
+		assert.NoError(t, err)
+		assert.Equal(t, models.AnalysisStatusPending, result.Status)
 	})
 
-	t.Run("Error - DB Internal on Get", func(t *testing.T) {
+	t.Run("Success - Soft Fail Asynq", func(t *testing.T) {
+		mock := newFailedMock()
+		analysisRepo := &mocks.MockAnalysisRepository{
+			GetAnalysisByIDFunc: func(ctx context.Context,
+				analysisID uuid.UUID) (*models.Analysis, error) {
+				return &mock, nil
+			},
+		}
+		enqueuer := &mocks.MockTaskEnqueuer{
+			EnqueueContextFunc: func(ctx context.Context, task *asynq.Task,
+				opts ...asynq.Option) (*asynq.TaskInfo, error) {
+				return nil, errors.New("redis down")
+			},
+		}
 		mockLogger, logs := testutils.NewMockLogger(zap.ErrorLevel)
 
-		svc := services.NewAnalysisService(newRepo(func() (*models.Analysis,
-			error) {
-			return nil, gorm.ErrInvalidTransaction
-		}), nil, nil, nil, nil,
-			mockLogger, t.TempDir())
-		_, err := svc.DownloadZip(ctx, uuid.New(), uuid.Nil)
+		svc := services.NewAnalysisService(analysisRepo, nil, nil,
+			enqueuer, nil, nil, mockLogger, t.TempDir())
+		result, err := svc.Resume(ctx, mock.ID, mock.UserID, "en")
 
-		assert.ErrorIs(t, err, services.ErrInternal)
+		assert.NoError(t, err)
+		assert.NotNil(t, result)
+		assert.Nil(t, mock.TaskID)
 		assert.Equal(t, 1, logs.Len())
 	})
 
-	t.Run("Error - Unauthorized", func(t *testing.T) {
+	t.Run("Error - Not Failed", func(t *testing.T) {
 		mock := testmodels.CreateMockAnalysis()
-		mock.ResultsZipPath = nil
+		analysisRepo := &mocks.MockAnalysisRepository{
+			GetAnalysisByIDFunc: func(ctx context.Context,
+				analysisID uuid.UUID) (*models.Analysis, error) {
+				return &mock, nil
+			},
+		}
 		mockLogger, logs := testutils.NewMockLogger(zap.ErrorLevel)
 
-		svc := services.NewAnalysisService(newRepo(func() (*models.Analysis,
-			error) {
-			return &mock, nil
-		}), nil, nil, nil, nil, mockLogger,
-			t.TempDir())
-		_, err := svc.DownloadZip(ctx, mock.ID, uuid.New())
+		svc := services.NewAnalysisService(analysisRepo, nil, nil,
+			&mocks.MockTaskEnqueuer{}, nil, nil, mockLogger, t.TempDir())
+		result, err := svc.Resume(ctx, mock.ID, mock.UserID, "en")
 
-		assert.ErrorIs(t, err, services.ErrUnauthorized)
+		assert.Nil(t, result)
+		assert.ErrorIs(t, err, services.ErrAnalysisNotResumable)
 		assert.Equal(t, 1, logs.Len())
 	})
 
-	t.Run("Error - Analysis Not Done", func(t *testing.T) {
-		mock := testmodels.CreateMockAnalysis()
-		mock.Status = models.AnalysisStatusRunning
-		mock.ResultsZipPath = nil
+	t.Run("Error - Not Found", func(t *testing.T) {
+		analysisRepo := &mocks.MockAnalysisRepository{
+			GetAnalysisByIDFunc: func(ctx context.Context,
+				analysisID uuid.UUID) (*models.Analysis, error) {
+				return nil, gorm.ErrRecordNotFound
+			},
+		}
 		mockLogger, logs := testutils.NewMockLogger(zap.ErrorLevel)
 
-		svc := services.NewAnalysisService(newRepo(func() (*models.Analysis,
-			error) {
-			return &mock, nil
-		}), nil, nil, nil, nil, mockLogger,
-			t.TempDir())
-		_, err := svc.DownloadZip(ctx, mock.ID, mock.UserID)
+		svc := services.NewAnalysisService(analysisRepo, nil, nil, nil, nil, nil,
+			mockLogger, t.TempDir())
+		result, err := svc.Resume(ctx, uuid.New(), uuid.Nil, "en")
 
-		assert.ErrorIs(t, err, services.ErrZipNotFound)
+		assert.Nil(t, result)
+		assert.ErrorIs(t, err, services.ErrNotFound)
 		assert.Equal(t, 1, logs.Len())
 	})
 
-	t.Run("Error - ResultsZipPath Not Set", func(t *testing.T) {
-		mock := testmodels.CreateMockAnalysis()
-		mock.Status = models.AnalysisStatusDone
-		mock.ResultsZipPath = nil
+	t.Run("Error - Unauthorized", func(t *testing.T) {
+		mock := newFailedMock()
+		analysisRepo := &mocks.MockAnalysisRepository{
+			GetAnalysisByIDFunc: func(ctx context.Context,
+				analysisID uuid.UUID) (*models.Analysis, error) {
+				return &mock, nil
+			},
+		}
 		mockLogger, logs := testutils.NewMockLogger(zap.ErrorLevel)
 
-		svc := services.NewAnalysisService(newRepo(func() (*models.Analysis,
-			error) {
-			return &mock, nil
-		}), nil, nil, nil, nil, mockLogger,
-			t.TempDir())
-		_, err := svc.DownloadZip(ctx, mock.ID, mock.UserID)
+		svc := services.NewAnalysisService(analysisRepo, nil, nil, nil, nil, nil,
+			mockLogger, t.TempDir())
+		result, err := svc.Resume(ctx, mock.ID, uuid.New(), "en")
 
-		assert.ErrorIs(t, err, services.ErrZipNotFound)
-		assert.Equal(t, 1, logs.Len())
+		assert.Nil(t, result)
+		assert.ErrorIs(t, err, services.ErrUnauthorized)
+		assert.Equal(t, 1, logs.Len())
+	})
+
+	t.Run("Error - DB Internal on Update", func(t *testing.T) {
+		mock := newFailedMock()
+		analysisRepo := &mocks.MockAnalysisRepository{
+			GetAnalysisByIDFunc: func(ctx context.Context,
+				analysisID uuid.UUID) (*models.Analysis, error) {
+				return &mock, nil
+			},
+			UpdateAnalysisFunc: func(ctx context.Context,
+				analysis *models.Analysis) error {
+				return gorm.ErrInvalidTransaction
+			},
+		}
+		mockLogger, logs := testutils.NewMockLogger(zap.ErrorLevel)
+
+		svc := services.NewAnalysisService(analysisRepo, nil, nil,
+			&mocks.MockTaskEnqueuer{}, nil, nil, mockLogger, t.TempDir())
+		result, err := svc.Resume(ctx, mock.ID, mock.UserID, "en")
+
+		assert.Nil(t, result)
+		assert.ErrorIs(t, err, services.ErrInternal)
+		assert.Equal(t, 1, logs.Len())
+	})
+}
+
+func TestAnalysisCancel(t *testing.T) {
+	ctx := context.Background()
+
+	newMock := func(status models.AnalysisStatus) models.Analysis {
+		mock := testmodels.CreateMockAnalysis()
+		taskID := "analysis-task-id"
+		mock.Status = status
+		mock.Step = models.StepProkka
+		mock.TaskID = &taskID
+		return mock
+	}
+
+	createAnalysisDir := func(t *testing.T, rootDir string,
+		mock models.Analysis) string {
+		t.Helper()
+		dir := filepath.Join(rootDir, "uploads", "users",
+			mock.UserID.String(), "samples", mock.SampleID.String(),
+			"analyses", mock.ID.String())
+		assert.NoError(t, os.MkdirAll(filepath.Join(dir, "assembly"), 0755))
+		return dir
+	}
+
+	t.Run("Success - Running", func(t *testing.T) {
+		mock := newMock(models.AnalysisStatusRunning)
+		var capturedStatuses []models.AnalysisStatus
+		var capturedFields map[string]any
+		analysisRepo := &mocks.MockAnalysisRepository{
+			GetAnalysisByIDFunc: func(ctx context.Context,
+				analysisID uuid.UUID) (*models.Analysis, error) {
+				return &mock, nil
+			},
+			UpdateAnalysisFunc: func(ctx context.Context,
+				analysis *models.Analysis) error {
+				t.Fatal("cancel must not save the whole analysis")
+				return nil
+			},
+			UpdateAnalysisIfStatusFunc: func(ctx context.Context,
+				analysisID uuid.UUID, statuses []models.AnalysisStatus,
+				fields map[string]any) (bool, error) {
+				capturedStatuses = statuses
+				capturedFields = fields
+				return true, nil
+			},
+		}
+		canceller := &mocks.MockTaskCanceller{}
+		rootDir := t.TempDir()
+		analysisDir := createAnalysisDir(t, rootDir, mock)
+		mockLogger, _ := testutils.NewMockLogger(zap.InfoLevel)
+
+		svc := services.NewAnalysisService(analysisRepo, nil, nil,
+			&mocks.MockTaskEnqueuer{}, canceller, nil, mockLogger, rootDir)
+		result, err := svc.Cancel(ctx, mock.ID, mock.UserID, "en")
+
+		assert.NoError(t, err)
+		assert.Equal(t, models.AnalysisStatusCancelled, result.Status)
+		assert.ElementsMatch(t, []models.AnalysisStatus{
+			models.AnalysisStatusPending, models.AnalysisStatusRunning,
+		}, capturedStatuses)
+		assert.Equal(t, models.AnalysisStatusCancelled,
+			capturedFields["status"])
+		assert.Empty(t, capturedFields["step"])
+		assert.NotNil(t, capturedFields["finished_at"])
+		assert.Equal(t, "analysis-task-id", canceller.CalledWith)
+		assert.Empty(t, canceller.DeleteCalledWith)
+		// The worker cleans up once its tools exit.
+		assert.DirExists(t, analysisDir)
+	})
+
+	t.Run("Success - Pending", func(t *testing.T) {
+		mock := newMock(models.AnalysisStatusPending)
+		analysisRepo := &mocks.MockAnalysisRepository{
+			GetAnalysisByIDFunc: func(ctx context.Context,
+				analysisID uuid.UUID) (*models.Analysis, error) {
+				return &mock, nil
+			},
+		}
+		var deletedQueue string
+		canceller := &mocks.MockTaskCanceller{
+			DeleteTaskFunc: func(queue, id string) error {
+				deletedQueue = queue
+				return nil
+			},
+		}
+		rootDir := t.TempDir()
+		analysisDir := createAnalysisDir(t, rootDir, mock)
+		mockLogger, _ := testutils.NewMockLogger(zap.InfoLevel)
+
+		svc := services.NewAnalysisService(analysisRepo, nil, nil,
+			&mocks.MockTaskEnqueuer{}, canceller, nil, mockLogger, rootDir)
+		result, err := svc.Cancel(ctx, mock.ID, mock.UserID, "en")
+
+		assert.NoError(t, err)
+		assert.Equal(t, models.AnalysisStatusCancelled, result.Status)
+		assert.Equal(t, tasks.QueueAnalysis, deletedQueue)
+		assert.Equal(t, "analysis-task-id", canceller.DeleteCalledWith)
+		assert.False(t, canceller.Called)
+		assert.NoDirExists(t, analysisDir)
+	})
+
+	t.Run("Success - Pending Task Already Active", func(t *testing.T) {
+		mock := newMock(models.AnalysisStatusPending)
+		analysisRepo := &mocks.MockAnalysisRepository{
+			GetAnalysisByIDFunc: func(ctx context.Context,
+				analysisID uuid.UUID) (*models.Analysis, error) {
+				return &mock, nil
+			},
+		}
+		canceller := &mocks.MockTaskCanceller{
+			DeleteTaskFunc: func(queue, id string) error {
+				return errors.New("cannot delete task in active state")
+			},
+		}
+		rootDir := t.TempDir()
+		analysisDir := createAnalysisDir(t, rootDir, mock)
+		mockLogger, logs := testutils.NewMockLogger(zap.WarnLevel)
+
+		svc := services.NewAnalysisService(analysisRepo, nil, nil,
+			&mocks.MockTaskEnqueuer{}, canceller, nil, mockLogger, rootDir)
+		result, err := svc.Cancel(ctx, mock.ID, mock.UserID, "en")
+
+		assert.NoError(t, err)
+		assert.Equal(t, models.AnalysisStatusCancelled, result.Status)
+		assert.Equal(t, "analysis-task-id", canceller.CalledWith)
+		assert.DirExists(t, analysisDir)
+		assert.Equal(t, 1, logs.Len())
+	})
+
+	t.Run("Success - Admin", func(t *testing.T) {
+		mock := newMock(models.AnalysisStatusRunning)
+		analysisRepo := &mocks.MockAnalysisRepository{
+			GetAnalysisByIDFunc: func(ctx context.Context,
+				analysisID uuid.UUID) (*models.Analysis, error) {
+				return &mock, nil
+			},
+		}
+		mockLogger, _ := testutils.NewMockLogger(zap.InfoLevel)
+
+		svc := services.NewAnalysisService(analysisRepo, nil, nil,
+			&mocks.MockTaskEnqueuer{}, &mocks.MockTaskCanceller{}, nil,
+			mockLogger, t.TempDir())
+		result, err := svc.Cancel(ctx, mock.ID, uuid.Nil, "en")
+
+		assert.NoError(t, err)
+		assert.Equal(t, models.AnalysisStatusCancelled, result.Status)
+	})
+
+	t.Run("Error - Not Cancellable", func(t *testing.T) {
+		mock := newMock(models.AnalysisStatusDone)
+		analysisRepo := &mocks.MockAnalysisRepository{
+			GetAnalysisByIDFunc: func(ctx context.Context,
+				analysisID uuid.UUID) (*models.Analysis, error) {
+				return &mock, nil
+			},
+		}
+		canceller := &mocks.MockTaskCanceller{}
+		mockLogger, logs := testutils.NewMockLogger(zap.ErrorLevel)
+
+		svc := services.NewAnalysisService(analysisRepo, nil, nil,
+			&mocks.MockTaskEnqueuer{}, canceller, nil, mockLogger, t.TempDir())
+		result, err := svc.Cancel(ctx, mock.ID, mock.UserID, "en")
+
+		assert.Nil(t, result)
+		assert.ErrorIs(t, err, services.ErrAnalysisNotCancellable)
+		assert.False(t, canceller.Called)
+		assert.Equal(t, 1, logs.Len())
+	})
+
+	t.Run("Error - Finished Meanwhile", func(t *testing.T) {
+		mock := newMock(models.AnalysisStatusRunning)
+		analysisRepo := &mocks.MockAnalysisRepository{
+			GetAnalysisByIDFunc: func(ctx context.Context,
+				analysisID uuid.UUID) (*models.Analysis, error) {
+				return &mock, nil
+			},
+			UpdateAnalysisIfStatusFunc: func(ctx context.Context,
+				analysisID uuid.UUID, statuses []models.AnalysisStatus,
+				fields map[string]any) (bool, error) {
+				return false, nil
+			},
+		}
+		canceller := &mocks.MockTaskCanceller{}
+		mockLogger, logs := testutils.NewMockLogger(zap.ErrorLevel)
+
+		svc := services.NewAnalysisService(analysisRepo, nil, nil, nil,
+			canceller, nil, mockLogger, t.TempDir())
+		result, err := svc.Cancel(ctx, mock.ID, mock.UserID, "en")
+
+		assert.Nil(t, result)
+		assert.ErrorIs(t, err, services.ErrAnalysisNotCancellable)
+		assert.False(t, canceller.Called)
+		assert.Equal(t, 1, logs.Len())
+	})
+
+	t.Run("Error - Not Found", func(t *testing.T) {
+		analysisRepo := &mocks.MockAnalysisRepository{
+			GetAnalysisByIDFunc: func(ctx context.Context,
+				analysisID uuid.UUID) (*models.Analysis, error) {
+				return nil, gorm.ErrRecordNotFound
+			},
+		}
+		mockLogger, logs := testutils.NewMockLogger(zap.ErrorLevel)
+
+		svc := services.NewAnalysisService(analysisRepo, nil, nil, nil, nil, nil,
+			mockLogger, t.TempDir())
+		result, err := svc.Cancel(ctx, uuid.New(), uuid.Nil, "en")
+
+		assert.Nil(t, result)
+		assert.ErrorIs(t, err, services.ErrNotFound)
+		assert.Equal(t, 1, logs.Len())
+	})
+
+	t.Run("Error - Unauthorized", func(t *testing.T) {
+		mock := newMock(models.AnalysisStatusRunning)
+		analysisRepo := &mocks.MockAnalysisRepository{
+			GetAnalysisByIDFunc: func(ctx context.Context,
+				analysisID uuid.UUID) (*models.Analysis, error) {
+				return &mock, nil
+			},
+		}
+		canceller := &mocks.MockTaskCanceller{}
+		mockLogger, logs := testutils.NewMockLogger(zap.ErrorLevel)
+
+		svc := services.NewAnalysisService(analysisRepo, nil, nil, nil,
+			canceller, nil, mockLogger, t.TempDir())
+		result, err := svc.Cancel(ctx, mock.ID, uuid.New(), "en")
+
+		assert.Nil(t, result)
+		assert.ErrorIs(t, err, services.ErrUnauthorized)
+		assert.False(t, canceller.Called)
+		assert.Equal(t, 1, logs.Len())
+	})
+
+	t.Run("Error - DB Internal on Update", func(t *testing.T) {
+		mock := newMock(models.AnalysisStatusRunning)
+		analysisRepo := &mocks.MockAnalysisRepository{
+			GetAnalysisByIDFunc: func(ctx context.Context,
+				analysisID uuid.UUID) (*models.Analysis, error) {
+				return &mock, nil
+			},
+			UpdateAnalysisIfStatusFunc: func(ctx context.Context,
+				analysisID uuid.UUID, statuses []models.AnalysisStatus,
+				fields map[string]any) (bool, error) {
+				return false, gorm.ErrInvalidTransaction
+			},
+		}
+		canceller := &mocks.MockTaskCanceller{}
+		mockLogger, logs := testutils.NewMockLogger(zap.ErrorLevel)
+
+		svc := services.NewAnalysisService(analysisRepo, nil, nil, nil,
+			canceller, nil, mockLogger, t.TempDir())
+		result, err := svc.Cancel(ctx, mock.ID, mock.UserID, "en")
+
+		assert.Nil(t, result)
+		assert.ErrorIs(t, err, services.ErrInternal)
+		assert.False(t, canceller.Called)
+		assert.Equal(t, 1, logs.Len())
+	})
+}
+
+func TestAnalysisFindLogs(t *testing.T) {
+	ctx := context.Background()
+	mock := testmodels.CreateMockAnalysis()
+	getAnalysis := func(ctx context.Context,
+		analysisID uuid.UUID) (*models.Analysis, error) {
+		return &mock, nil
+	}
+
+	t.Run("Success - Without Output", func(t *testing.T) {
+		mockLog := testmodels.NewAnalysisLog(mock.ID, models.StepCheckM,
+			time.Now().UTC())
+		analysisRepo := &mocks.MockAnalysisRepository{
+			GetAnalysisByIDFunc: getAnalysis,
+			GetAnalysisLogsFunc: func(ctx context.Context, analysisID uuid.UUID,
+				step models.AnalysisStep) ([]models.AnalysisLog, error) {
+				assert.Equal(t, models.AnalysisStep(""), step)
+				return []models.AnalysisLog{mockLog}, nil
+			},
+		}
+
+		svc := services.NewAnalysisService(analysisRepo, nil, nil, nil, nil, nil, nil, t.TempDir())
+		result, err := svc.FindLogs(ctx, mock.ID, mock.UserID, "", false)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []models.AnalysisLogResponse{mockLog.ToResponse()},
+			result)
+	})
+
+	t.Run("Success - With Output", func(t *testing.T) {
+		dir := t.TempDir()
+		mockLog := testmodels.NewAnalysisLog(mock.ID, models.StepCheckM,
+			time.Now().UTC())
+		mockLog.StdoutPath = filepath.Join(dir, "checkm.stdout.log")
+		mockLog.StderrPath = filepath.Join(dir, "missing.stderr.log")
+		assert.NoError(t, os.WriteFile(mockLog.StdoutPath,
+			[]byte("completeness 99.1\n"), 0644))
+
+		analysisRepo := &mocks.MockAnalysisRepository{
+			GetAnalysisByIDFunc: getAnalysis,
+			GetAnalysisLogsFunc: func(ctx context.Context, analysisID uuid.UUID,
+				step models.AnalysisStep) ([]models.AnalysisLog, error) {
+				assert.Equal(t, models.StepCheckM, step)
+				return []models.AnalysisLog{mockLog}, nil
+			},
+		}
+
+		mockLogger, logs := testutils.NewMockLogger(zap.WarnLevel)
+
+		svc := services.NewAnalysisService(analysisRepo, nil, nil, nil, nil, nil, mockLogger, t.TempDir())
+		result, err := svc.FindLogs(ctx, mock.ID, uuid.Nil, models.StepCheckM,
+			true)
+
+		assert.NoError(t, err)
+		assert.Len(t, result, 1)
+		assert.Equal(t, "completeness 99.1\n", *result[0].Stdout)
+		assert.Nil(t, result[0].Stderr)
+		assert.Equal(t, 1, logs.Len())
+	})
+
+	t.Run("Error - Invalid Step", func(t *testing.T) {
+		svc := services.NewAnalysisService(&mocks.MockAnalysisRepository{}, nil, nil, nil, nil, nil, nil, t.TempDir())
+		result, err := svc.FindLogs(ctx, mock.ID, mock.UserID, "Unknown",
+			false)
+
+		assert.ErrorIs(t, err, services.ErrInvalidAnalysisStep)
+		assert.Nil(t, result)
+	})
+
+	t.Run("Error - Not Found", func(t *testing.T) {
+		analysisRepo := &mocks.MockAnalysisRepository{
+			GetAnalysisByIDFunc: func(ctx context.Context,
+				analysisID uuid.UUID) (*models.Analysis, error) {
+				return nil, gorm.ErrRecordNotFound
+			},
+		}
+
+		mockLogger, logs := testutils.NewMockLogger(zap.ErrorLevel)
+
+		svc := services.NewAnalysisService(analysisRepo, nil, nil, nil, nil, nil, mockLogger, t.TempDir())
+		result, err := svc.FindLogs(ctx, mock.ID, mock.UserID, "", false)
+
+		assert.ErrorIs(t, err, services.ErrNotFound)
+		assert.Nil(t, result)
+		assert.Equal(t, 1, logs.Len())
+	})
+
+	t.Run("Error - Unauthorized", func(t *testing.T) {
+		analysisRepo := &mocks.MockAnalysisRepository{
+			GetAnalysisByIDFunc: getAnalysis,
+		}
+
+		mockLogger, logs := testutils.NewMockLogger(zap.ErrorLevel)
+
+		svc := services.NewAnalysisService(analysisRepo, nil, nil, nil, nil, nil, mockLogger, t.TempDir())
+		result, err := svc.FindLogs(ctx, mock.ID, uuid.New(), "", false)
+
+		assert.ErrorIs(t, err, services.ErrUnauthorized)
+		assert.Nil(t, result)
+		assert.Equal(t, 1, logs.Len())
+	})
+
+	t.Run("Error - DB Internal on Logs", func(t *testing.T) {
+		analysisRepo := &mocks.MockAnalysisRepository{
+			GetAnalysisByIDFunc: getAnalysis,
+			GetAnalysisLogsFunc: func(ctx context.Context, analysisID uuid.UUID,
+				step models.AnalysisStep) ([]models.AnalysisLog, error) {
+				return nil, errors.New("db down")
+			},
+		}
+
+		mockLogger, logs := testutils.NewMockLogger(zap.ErrorLevel)
+
+		svc := services.NewAnalysisService(analysisRepo, nil, nil, nil, nil, nil, mockLogger, t.TempDir())
+		result, err := svc.FindLogs(ctx, mock.ID, mock.UserID, "", false)
+
+		assert.ErrorIs(t, err, services.ErrInternal)
+		assert.Nil(t, result)
+		assert.Equal(t, 1, logs.Len())
+	})
+}
+
+func TestAnalysisFindTaxonomy(t *testing.T) {
+	ctx := context.Background()
+	taxonomy := &pipeline.KrakenComposition{
+		Species: []pipeline.KrakenTaxon{
+			{Name: "Escherichia coli", TaxID: 562, CladeReads: 950,
+				Percent: 95},
+		},
+		Genus:        []pipeline.KrakenTaxon{},
+		Unclassified: 5,
+	}
+	metrics, err := json.Marshal(models.AnalysisResults{
+		PrimarySpeciesName: "Escherichia coli", Taxonomy: taxonomy,
+	})
+	assert.NoError(t, err)
+
+	mock := testmodels.CreateMockAnalysis()
+	newRepo := func(metrics datatypes.JSON) *mocks.MockAnalysisRepository {
+		mockCopy := mock
+		mockCopy.Metrics = metrics
+		return &mocks.MockAnalysisRepository{
+			GetAnalysisByIDFunc: func(ctx context.Context,
+				analysisID uuid.UUID) (*models.Analysis, error) {
+				return &mockCopy, nil
+			},
+		}
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		svc := services.NewAnalysisService(newRepo(metrics), nil, nil, nil,
+			nil, nil, zap.NewNop(), t.TempDir())
+		result, err := svc.FindTaxonomy(ctx, mock.ID, mock.UserID)
+
+		assert.NoError(t, err)
+		assert.Equal(t, taxonomy, result)
+	})
+
+	t.Run("Success - Admin", func(t *testing.T) {
+		svc := services.NewAnalysisService(newRepo(metrics), nil, nil, nil,
+			nil, nil, zap.NewNop(), t.TempDir())
+		result, err := svc.FindTaxonomy(ctx, mock.ID, uuid.Nil)
+
+		assert.NoError(t, err)
+		assert.Equal(t, taxonomy, result)
+	})
+
+	t.Run("Error - Taxonomy Not Found", func(t *testing.T) {
+		svc := services.NewAnalysisService(
+			newRepo(datatypes.JSON(`{"primary_species":"Escherichia coli"}`)),
+			nil, nil, nil, nil, nil, zap.NewNop(), t.TempDir())
+		result, err := svc.FindTaxonomy(ctx, mock.ID, mock.UserID)
+
+		assert.ErrorIs(t, err, services.ErrTaxonomyNotFound)
+		assert.Nil(t, result)
+	})
+
+	t.Run("Error - Not Found", func(t *testing.T) {
+		analysisRepo := &mocks.MockAnalysisRepository{
+			GetAnalysisByIDFunc: func(ctx context.Context,
+				analysisID uuid.UUID) (*models.Analysis, error) {
+				return nil, gorm.ErrRecordNotFound
+			},
+		}
+
+		mockLogger, logs := testutils.NewMockLogger(zap.ErrorLevel)
+
+		svc := services.NewAnalysisService(analysisRepo, nil, nil, nil, nil, nil,
+			mockLogger, t.TempDir())
+		result, err := svc.FindTaxonomy(ctx, mock.ID, mock.UserID)
+
+		assert.ErrorIs(t, err, services.ErrNotFound)
+		assert.Nil(t, result)
+		assert.Equal(t, 1, logs.Len())
+	})
+
+	t.Run("Error - Unauthorized", func(t *testing.T) {
+		mockLogger, logs := testutils.NewMockLogger(zap.ErrorLevel)
+
+		svc := services.NewAnalysisService(newRepo(metrics), nil, nil, nil,
+			nil, nil, mockLogger, t.TempDir())
+		result, err := svc.FindTaxonomy(ctx, mock.ID, uuid.New())
+
+		assert.ErrorIs(t, err, services.ErrUnauthorized)
+		assert.Nil(t, result)
+		assert.Equal(t, 1, logs.Len())
+	})
+
+	t.Run("Error - Invalid Metrics", func(t *testing.T) {
+		mockLogger, logs := testutils.NewMockLogger(zap.ErrorLevel)
+
+		svc := services.NewAnalysisService(
+			newRepo(datatypes.JSON(`{"taxonomy":[1]}`)), nil, nil, nil, nil, nil,
+			mockLogger, t.TempDir())
+		result, err := svc.FindTaxonomy(ctx, mock.ID, mock.UserID)
+
+		assert.ErrorIs(t, err, services.ErrInternal)
+		assert.Nil(t, result)
+		assert.Equal(t, 1, logs.Len())
+	})
+}
+
+func TestAnalysisDownloadZip(t *testing.T) {
+	ctx := context.Background()
+
+	newRepo := func(analysisReturn func() (*models.Analysis, error)) *mocks.MockAnalysisRepository {
+		return &mocks.MockAnalysisRepository{
+			GetAnalysisByIDFunc: func(ctx context.Context,
+				analysisID uuid.UUID) (*models.Analysis, error) {
+				return analysisReturn()
+			},
+		}
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		rootDir := t.TempDir()
+		zipPath := filepath.Join(rootDir, "results.zip")
+		err := os.WriteFile(zipPath, []byte("x"), 0644)
+		assert.NoError(t, err)
+
+		mock := testmodels.CreateMockAnalysis()
+		mock.Status = models.AnalysisStatusDone
+		mock.ResultsZipPath = &zipPath
+
+		svc := services.NewAnalysisService(newRepo(func() (*models.Analysis,
+			error) {
+			return &mock, nil
+		}), nil, nil, nil, nil, nil, zap.NewNop(),
+			rootDir)
+		gotPath, err := svc.DownloadZip(ctx, mock.ID, mock.UserID)
+
+		assert.NoError(t, err)
+		assert.Equal(t, zipPath, gotPath)
+	})
+
+	t.Run("Error - Not Found", func(t *testing.T) {
+		mockLogger, logs := testutils.NewMockLogger(zap.ErrorLevel)
+
+		svc := services.NewAnalysisService(newRepo(func() (*models.Analysis,
+			error) {
+			return nil, gorm.ErrRecordNotFound
+		}), nil, nil, nil, nil, nil,
+			mockLogger, t.TempDir())
+		_, err := svc.DownloadZip(ctx, uuid.New(), uuid.Nil)
+
+		assert.ErrorIs(t, err, services.ErrNotFound)
+		assert.Equal(t, 1, logs.Len())
+	})
+
+	t.Run("Error - DB Internal on Get", func(t *testing.T) {
+		mockLogger, logs := testutils.NewMockLogger(zap.ErrorLevel)
+
+		svc := services.NewAnalysisService(newRepo(func() (*models.Analysis,
+			error) {
+			return nil, gorm.ErrInvalidTransaction
+		}), nil, nil, nil, nil, nil,
+			mockLogger, t.TempDir())
+		_, err := svc.DownloadZip(ctx, uuid.New(), uuid.Nil)
+
+		assert.ErrorIs(t, err, services.ErrInternal)
+		assert.Equal(t, 1, logs.Len())
+	})
+
+	t.Run("Error - Unauthorized", func(t *testing.T) {
+		mock := testmodels.CreateMockAnalysis()
+		mock.ResultsZipPath = nil
+		mockLogger, logs := testutils.NewMockLogger(zap.ErrorLevel)
+
+		svc := services.NewAnalysisService(newRepo(func() (*models.Analysis,
+			error) {
+			return &mock, nil
+		}), nil, nil, nil, nil, nil, mockLogger,
+			t.TempDir())
+		_, err := svc.DownloadZip(ctx, mock.ID, uuid.New())
+
+		assert.ErrorIs(t, err, services.ErrUnauthorized)
+		assert.Equal(t, 1, logs.Len())
+	})
+
+	t.Run("Error - Analysis Not Done", func(t *testing.T) {
+		mock := testmodels.CreateMockAnalysis()
+		mock.Status = models.AnalysisStatusRunning
+		mock.ResultsZipPath = nil
+		mockLogger, logs := testutils.NewMockLogger(zap.ErrorLevel)
+
+		svc := services.NewAnalysisService(newRepo(func() (*models.Analysis,
+			error) {
+			return &mock, nil
+		}), nil, nil, nil, nil, nil, mockLogger,
+			t.TempDir())
+		_, err := svc.DownloadZip(ctx, mock.ID, mock.UserID)
+
+		assert.ErrorIs(t, err, services.ErrZipNotFound)
+		assert.Equal(t, 1, logs.Len())
+	})
+
+	t.Run("Error - ResultsZipPath Not Set", func(t *testing.T) {
+		mock := testmodels.CreateMockAnalysis()
+		mock.Status = models.AnalysisStatusDone
+		mock.ResultsZipPath = nil
+		mockLogger, logs := testutils.NewMockLogger(zap.ErrorLevel)
+
+		svc := services.NewAnalysisService(newRepo(func() (*models.Analysis,
+			error) {
+			return &mock, nil
+		}), nil, nil, nil, nil, nil, mockLogger,
+			t.TempDir())
+		_, err := svc.DownloadZip(ctx, mock.ID, mock.UserID)
+
+		assert.ErrorIs(t, err, services.ErrZipNotFound)
+		assert.Equal(t, 1, logs.Len())
 	})
 
 	t.Run("Error - Zip File Missing on Disk", func(t *testing.T) {
@@ -1307,7 +2073,7 @@ func TestAnalysisDownloadZip(t *testing.T) {
 		svc := services.NewAnalysisService(newRepo(func() (*models.Analysis,
 			error) {
 			return &mock, nil
-		}), nil, nil, nil, nil, mockLogger,
+		}), nil, nil, nil, nil, nil, mockLogger,
 			t.TempDir())
 		_, err := svc.DownloadZip(ctx, mock.ID, mock.UserID)
 
@@ -1337,7 +2103,7 @@ func TestAnalysisDownloadBatchTSV(t *testing.T) {
 				return []models.Analysis{mock}, nil
 			},
 		}
-		svc := services.NewAnalysisService(successRepo, nil, nil, nil, nil,
+		svc := services.NewAnalysisService(successRepo, nil, nil, nil, nil, nil,
 			zap.NewNop(), t.TempDir())
 		responses, err := svc.DownloadBatchTSV(ctx,
 			[]uuid.UUID{mock.ID}, mock.UserID, "en")
@@ -1353,7 +2119,7 @@ func TestAnalysisDownloadBatchTSV(t *testing.T) {
 		}
 
 		mockLogger, logs := testutils.NewMockLogger(zap.ErrorLevel)
-		svc := services.NewAnalysisService(analysisRepo, nil, nil, nil, nil,
+		svc := services.NewAnalysisService(analysisRepo, nil, nil, nil, nil, nil,
 			mockLogger, t.TempDir())
 		responses, err := svc.DownloadBatchTSV(ctx, ids, mock.UserID, "en")
 
@@ -1363,7 +2129,7 @@ func TestAnalysisDownloadBatchTSV(t *testing.T) {
 	})
 
 	t.Run("Success - Empty IDs Returns Empty List", func(t *testing.T) {
-		svc := services.NewAnalysisService(analysisRepo, nil, nil, nil, nil,
+		svc := services.NewAnalysisService(analysisRepo, nil, nil, nil, nil, nil,
 			zap.NewNop(), t.TempDir())
 		responses, err := svc.DownloadBatchTSV(ctx, []uuid.UUID{},
 			mock.UserID, "en")
@@ -1374,7 +2140,7 @@ func TestAnalysisDownloadBatchTSV(t *testing.T) {
 
 	t.Run("Error - FASTQC in Batch", func(t *testing.T) {
 		mockLogger, logs := testutils.NewMockLogger(zap.ErrorLevel)
-		svc := services.NewAnalysisService(analysisRepo, nil, nil, nil, nil,
+		svc := services.NewAnalysisService(analysisRepo, nil, nil, nil, nil, nil,
 			mockLogger, t.TempDir())
 		responses, err := svc.DownloadBatchTSV(ctx,
 			[]uuid.UUID{mock.ID, fastqcMock.ID}, mock.UserID, "en")
@@ -1393,7 +2159,7 @@ func TestAnalysisDownloadBatchTSV(t *testing.T) {
 		}
 
 		mockLogger, logs := testutils.NewMockLogger(zap.ErrorLevel)
-		svc := services.NewAnalysisService(failRepo, nil, nil, nil, nil,
+		svc := services.NewAnalysisService(failRepo, nil, nil, nil, nil, nil,
 			mockLogger, t.TempDir())
 		responses, err := svc.DownloadBatchTSV(ctx,
 			[]uuid.UUID{mock.ID}, mock.UserID, "en")
@@ -1403,3 +2169,122 @@ func TestAnalysisDownloadBatchTSV(t *testing.T) {
 		assert.Equal(t, 1, logs.Len())
 	})
 }
+
+func TestAnalysisFindOutdatedDatabases(t *testing.T) {
+	ctx := context.Background()
+
+	// Two workers disagree on kraken2; the first by ID is shown as current.
+	workers := &mocks.MockWorkerEnvironmentReader{
+		GetWorkerEnvironmentsFunc: func(ctx context.Context) (
+			[]models.WorkerEnvironment, error) {
+			return []models.WorkerEnvironment{
+				{ID: "worker-a:1", Databases: []pipeline.DatabaseFingerprint{
+					{Name: "kraken2", Checksum: "k2", Version: "2024-01"},
+					{Name: "abricate/vfdb", Checksum: "v1"},
+					{Name: "amrfinder", Error: "not accessible"},
+				}},
+				{ID: "worker-b:2", Databases: []pipeline.DatabaseFingerprint{
+					{Name: "kraken2", Checksum: "k3"},
+				}},
+			}, nil
+		},
+	}
+
+	first := testmodels.CreateMockAnalysis()
+	first.ID = uuid.New()
+	second := testmodels.CreateMockAnalysis()
+	second.ID = uuid.New()
+
+	t.Run("Success", func(t *testing.T) {
+		analysisRepo := &mocks.MockAnalysisRepository{
+			GetOutdatedAnalysisDatabasesFunc: func(ctx context.Context,
+				current map[string][]string) (
+				[]models.AnalysisDatabase, error) {
+				assert.Equal(t, map[string][]string{
+					"kraken2":       {"k2", "k3"},
+					"abricate/vfdb": {"v1"},
+				}, current)
+				return []models.AnalysisDatabase{
+					{Name: "abricate/vfdb", Checksum: "v0",
+						AnalysisID: first.ID, Analysis: first},
+					{Name: "kraken2", Checksum: "k1",
+						AnalysisID: first.ID, Analysis: first},
+					{Name: "kraken2", Checksum: "k1",
+						AnalysisID: second.ID, Analysis: second},
+				}, nil
+			},
+		}
+
+		svc := services.NewAnalysisService(analysisRepo, nil, nil, nil,
+			nil, workers, zap.NewNop(), t.TempDir())
+		result, err := svc.FindOutdatedDatabases(ctx, "en")
+
+		assert.NoError(t, err)
+		if assert.Len(t, result, 2) {
+			assert.Equal(t, first.ID, result[0].Analysis.ID)
+			if assert.Len(t, result[0].Databases, 2) {
+				kraken2 := result[0].Databases[1]
+				assert.Equal(t, "kraken2", kraken2.Name)
+				assert.Equal(t, "k1", kraken2.Used.Checksum)
+				assert.Equal(t, "k2", kraken2.Current.Checksum)
+				assert.Equal(t, "2024-01", kraken2.Current.Version)
+			}
+			assert.Equal(t, second.ID, result[1].Analysis.ID)
+			assert.Len(t, result[1].Databases, 1)
+		}
+	})
+
+	t.Run("Success - None Outdated", func(t *testing.T) {
+		analysisRepo := &mocks.MockAnalysisRepository{
+			GetOutdatedAnalysisDatabasesFunc: func(ctx context.Context,
+				current map[string][]string) (
+				[]models.AnalysisDatabase, error) {
+				return nil, nil
+			},
+		}
+
+		svc := services.NewAnalysisService(analysisRepo, nil, nil, nil,
+			nil, workers, zap.NewNop(), t.TempDir())
+		result, err := svc.FindOutdatedDatabases(ctx, "en")
+
+		assert.NoError(t, err)
+		assert.Empty(t, result)
+	})
+
+	t.Run("Error - Worker Registry", func(t *testing.T) {
+		failingWorkers := &mocks.MockWorkerEnvironmentReader{
+			GetWorkerEnvironmentsFunc: func(ctx context.Context) (
+				[]models.WorkerEnvironment, error) {
+				return nil, errors.New("redis down")
+			},
+		}
+		mockLogger, logs := testutils.NewMockLogger(zap.ErrorLevel)
+
+		svc := services.NewAnalysisService(&mocks.MockAnalysisRepository{},
+			nil, nil, nil, nil, failingWorkers, mockLogger, t.TempDir())
+		result, err := svc.FindOutdatedDatabases(ctx, "en")
+
+		assert.ErrorIs(t, err, services.ErrInternal)
+		assert.Nil(t, result)
+		assert.Equal(t, 1, logs.Len())
+	})
+
+	t.Run("Error - Internal", func(t *testing.T) {
+		analysisRepo := &mocks.MockAnalysisRepository{
+			GetOutdatedAnalysisDatabasesFunc: func(ctx context.Context,
+				current map[string][]string) (
+				[]models.AnalysisDatabase, error) {
+				return nil, gorm.ErrInvalidTransaction
+			},
+		}
+		mockLogger, logs := testutils.NewMockLogger(zap.ErrorLevel)
+
+		svc := services.NewAnalysisService(analysisRepo, nil, nil, nil,
+			nil, workers, mockLogger, t.TempDir())
+		result, err := svc.FindOutdatedDatabases(ctx, "en")
+
+		assert.ErrorIs(t, err, services.ErrInternal)
+		assert.Nil(t, result)
+		assert.Equal(t, 1, logs.Len())
+	})
+}
@@ -2,12 +2,15 @@ package services
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"strings"
 
 	"github.com/CABGenOrg/cabgen_backend/internal/config"
 	"github.com/CABGenOrg/cabgen_backend/internal/email"
 	"github.com/CABGenOrg/cabgen_backend/internal/logging"
 	"github.com/CABGenOrg/cabgen_backend/internal/models"
+	"github.com/CABGenOrg/cabgen_backend/internal/pipeline"
 	"github.com/CABGenOrg/cabgen_backend/internal/repositories"
 	"github.com/CABGenOrg/cabgen_backend/internal/translation"
 	"github.com/google/uuid"
@@ -193,6 +196,8 @@ func (s *emailService) SendAnalysisDoneEmail(ctx context.Context,
 		"Name":             analysis.User.Name,
 		"SampleOriginCode": analysis.Sample.OriginCode,
 		"StatusText":       statusText,
+		"QCText":           s.analysisQCText(localizer, analysis),
+		"SpeciesText":      s.analysisSpeciesText(localizer, analysis),
 	})
 
 	cfg := email.EmailConfig{
@@ -221,6 +226,92 @@ func (s *emailService) SendAnalysisDoneEmail(ctx context.Context,
 	return nil
 }
 
+// analysisQCText highlights the QC gate verdict of a finished analysis,
+// listing the rules the assembly broke. Analyses without a verdict get
+// no QC section.
+func (s *emailService) analysisQCText(localizer *i18n.Localizer,
+	analysis *models.Analysis) string {
+	var messageID string
+	switch analysis.QCVerdict {
+	case pipeline.QCVerdictPass:
+		messageID = "email.analysis_done.qc_pass"
+	case pipeline.QCVerdictWarn:
+		messageID = "email.analysis_done.qc_warn"
+	case pipeline.QCVerdictFail:
+		messageID = "email.analysis_done.qc_fail"
+	default:
+		return ""
+	}
+
+	var results models.AnalysisResults
+	if len(analysis.Metrics) > 0 {
+		if err := json.Unmarshal(analysis.Metrics, &results); err != nil {
+			s.Logger.Warn("Service Warning", logging.ServiceLogging(
+				"EmailService", "SendAnalysisDoneEmail",
+				logging.QCGateError, err,
+			)...)
+		}
+	}
+
+	text := s.localize(localizer, messageID, nil)
+	if results.QC == nil || len(results.QC.FailedRules) == 0 {
+		return text
+	}
+
+	var rules strings.Builder
+	rules.WriteString("<ul>")
+	for _, failure := range results.QC.FailedRules {
+		rules.WriteString("<li>" + formatQCFailure(failure) + "</li>")
+	}
+	rules.WriteString("</ul>")
+
+	return text + rules.String()
+}
+
+// analysisSpeciesText warns the owner that the detected species is not the
+// declared one. It is only sent when NOTIFY_SPECIES_DISCORDANCE is set.
+func (s *emailService) analysisSpeciesText(localizer *i18n.Localizer,
+	analysis *models.Analysis) string {
+	if !config.NotifySpeciesDiscordance ||
+		analysis.SpeciesConcordance != pipeline.SpeciesDiscordant {
+		return ""
+	}
+
+	var results models.AnalysisResults
+	if err := json.Unmarshal(analysis.Metrics, &results); err != nil ||
+		results.Concordance == nil {
+		s.Logger.Warn("Service Warning", logging.ServiceLogging(
+			"EmailService", "SendAnalysisDoneEmail",
+			logging.DatabaseError, err,
+		)...)
+		return ""
+	}
+
+	return s.localize(localizer, "email.analysis_done.species_discordant",
+		map[string]any{
+			"Declared": results.Concordance.Declared.Name,
+			"Detected": results.Concordance.Detected.Name,
+		})
+}
+
+func formatQCFailure(failure pipeline.QCFailure) string {
+	text := fmt.Sprintf("%s = %g", failure.Metric, failure.Value)
+	var bounds []string
+	if failure.Min != nil {
+		bounds = append(bounds, fmt.Sprintf("min %g", *failure.Min))
+	}
+	if failure.Max != nil {
+		bounds = append(bounds, fmt.Sprintf("max %g", *failure.Max))
+	}
+	if len(bounds) > 0 {
+		text += " (" + strings.Join(bounds, ", ") + ")"
+	}
+	if failure.Species != "" {
+		text += " [" + failure.Species + "]"
+	}
+	return text
+}
+
 func (s *emailService) SendAdminTicketEmail(ctx context.Context,
 	ticketID uuid.UUID) error {
 	ticket, err := s.TicketRepo.GetTicketByID(ctx, ticketID)
@@ -1,11 +1,17 @@
 package services_test
 
 import (
+	"bytes"
 	"context"
+	"io"
+	"mime/quotedprintable"
 	"os"
+	"strings"
 	"testing"
 
+	"github.com/CABGenOrg/cabgen_backend/internal/config"
 	"github.com/CABGenOrg/cabgen_backend/internal/models"
+	"github.com/CABGenOrg/cabgen_backend/internal/pipeline"
 	"github.com/CABGenOrg/cabgen_backend/internal/services"
 	"github.com/CABGenOrg/cabgen_backend/internal/testutils"
 	"github.com/CABGenOrg/cabgen_backend/internal/testutils/mocks"
@@ -14,6 +20,8 @@ import (
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/zap"
+	gomail "gopkg.in/mail.v2"
+	"gorm.io/datatypes"
 	"gorm.io/gorm"
 )
 
@@ -22,6 +30,21 @@ func TestMain(m *testing.M) {
 	os.Exit(m.Run())
 }
 
+func emailBody(t *testing.T, msg *gomail.Message) string {
+	t.Helper()
+
+	var raw bytes.Buffer
+	_, err := msg.WriteTo(&raw)
+	assert.NoError(t, err)
+
+	_, encoded, _ := strings.Cut(raw.String(), "\r\n\r\n")
+	body, err := io.ReadAll(quotedprintable.NewReader(
+		strings.NewReader(encoded)))
+	assert.NoError(t, err)
+
+	return string(body)
+}
+
 func TestSendAdminAlertEmail(t *testing.T) {
 	ctx := context.Background()
 	userID := uuid.New()
@@ -220,6 +243,84 @@ func TestSendAnalysisDoneEmail(t *testing.T) {
 		assert.Equal(t, 1, logs.Len())
 	})
 
+	t.Run("Success - QC Failed Rules Highlighted", func(t *testing.T) {
+		minCompleteness := 90.0
+		qcAnalysis := testmodels.CreateMockAnalysis()
+		qcAnalysis.Status = models.AnalysisStatusDone
+		qcAnalysis.User.Language = "en"
+		qcAnalysis.QCVerdict = pipeline.QCVerdictFail
+		qcAnalysis.Metrics = datatypes.JSON(testutils.ToJSON(
+			models.AnalysisResults{QC: &pipeline.QCReport{
+				Verdict: pipeline.QCVerdictFail,
+				FailedRules: []pipeline.QCFailure{{
+					QCRule: pipeline.QCRule{
+						Metric:   pipeline.QCMetricCompleteness,
+						Min:      &minCompleteness,
+						Severity: pipeline.QCSeverityFail,
+					},
+					Value: 60,
+				}},
+			}}))
+		analysisRepo := &mocks.MockAnalysisRepository{
+			GetAnalysisByIDFunc: func(ctx context.Context, id uuid.UUID) (
+				*models.Analysis, error) {
+				return &qcAnalysis, nil
+			},
+		}
+		sender := &mocks.MockEmailSender{}
+		mockLogger, _ := testutils.NewMockLogger(zap.InfoLevel)
+
+		svc := services.NewEmailService(nil, analysisRepo, nil, sender,
+			mockLogger)
+		err := svc.SendAnalysisDoneEmail(ctx, analysisID)
+
+		assert.NoError(t, err)
+		if assert.Len(t, sender.Sent, 1) {
+			body := emailBody(t, sender.Sent[0])
+			assert.Contains(t, body, "failed</strong>")
+			assert.Contains(t, body, "<li>completeness = 60 (min 90)</li>")
+		}
+	})
+
+	t.Run("Success - Species Discordance Notified", func(t *testing.T) {
+		originalNotify := config.NotifySpeciesDiscordance
+		t.Cleanup(func() { config.NotifySpeciesDiscordance = originalNotify })
+
+		discordant := testmodels.CreateMockAnalysis()
+		discordant.Status = models.AnalysisStatusDone
+		discordant.User.Language = "en"
+		discordant.SpeciesConcordance = pipeline.SpeciesDiscordant
+		discordant.Metrics = datatypes.JSON(testutils.ToJSON(
+			models.AnalysisResults{Concordance: &pipeline.ConcordanceReport{
+				Status:   pipeline.SpeciesDiscordant,
+				Declared: pipeline.SpeciesRef{Name: "Klebsiella pneumoniae"},
+				Detected: pipeline.SpeciesRef{Name: "Escherichia coli"},
+			}}))
+		analysisRepo := &mocks.MockAnalysisRepository{
+			GetAnalysisByIDFunc: func(ctx context.Context, id uuid.UUID) (
+				*models.Analysis, error) {
+				return &discordant, nil
+			},
+		}
+
+		for _, notify := range []bool{true, false} {
+			config.NotifySpeciesDiscordance = notify
+			sender := &mocks.MockEmailSender{}
+
+			svc := services.NewEmailService(nil, analysisRepo, nil, sender,
+				zap.NewNop())
+			err := svc.SendAnalysisDoneEmail(ctx, analysisID)
+
+			assert.NoError(t, err)
+			if assert.Len(t, sender.Sent, 1) {
+				body := emailBody(t, sender.Sent[0])
+				assert.Equal(t, notify, strings.Contains(body,
+					"declared as <em>Klebsiella pneumoniae</em>, but the "+
+						"analysis identified <em>Escherichia coli</em>"))
+			}
+		}
+	})
+
 	t.Run("Error - Analysis Not Found", func(t *testing.T) {
 		analysisRepo := &mocks.MockAnalysisRepository{
 			GetAnalysisByIDFunc: func(ctx context.Context, id uuid.UUID) (
@@ -26,12 +26,14 @@ var ErrHealthServiceNotFound = errors.New("health service not found")
 var ErrMissingFiles = errors.New("missing files")
 var ErrMissingFastq1 = errors.New("missing fastq1 file")
 var ErrMissingFastq2 = errors.New("missing fastq2 file")
+var ErrMissingLongReads = errors.New("missing long reads file")
 var ErrCreateFolder = errors.New("cannot create folder")
 var ErrDeleteRunningAnalysis = errors.New("cannot delete analysis")
 var ErrSampleNotFound = errors.New("sample not found")
 var ErrExceededDownloadLimit = errors.New("exceeded download limit")
 var ErrFastQCDownload = errors.New("FASTQC analysis cannot be downloaded")
 var ErrZipNotFound = errors.New("zip file not available for this analysis")
+var ErrTaxonomyNotFound = errors.New("taxonomy not available for this analysis")
 var ErrTicketAlreadyResolvedStatus = errors.New("ticket already resolved")
 var ErrTicketIsNotOpen = errors.New("ticket in progress or resolved")
 var ErrDeleteActiveTicket = errors.New("cannot delete active ticket")
@@ -43,3 +45,10 @@ var ErrExpiredEmailUpdateToken = errors.New("email update token is expired")
 var ErrEmailSame = errors.New("new email is the same as current email")
 var ErrDuplicateTask = errors.New("duplicate task already pending")
 var ErrInvalidStatusTransition = errors.New("invalid status transition")
+var ErrAnalysisNotResumable = errors.New("only failed analyses can be resumed")
+var ErrAnalysisNotCancellable = errors.New("only pending or running analyses can be cancelled")
+var ErrAnalysisCancelled = errors.New("analysis cancelled")
+var ErrAnalysisNotRunning = errors.New("analysis is no longer running")
+var ErrInvalidAnalysisStep = errors.New("invalid analysis step")
+var ErrInvalidAnalysisParameters = errors.New("invalid analysis parameters")
+var ErrInvalidQCRule = errors.New("invalid QC rule")
@@ -7,6 +7,7 @@ import (
 
 	"github.com/CABGenOrg/cabgen_backend/internal/logging"
 	"github.com/CABGenOrg/cabgen_backend/internal/models"
+	"github.com/CABGenOrg/cabgen_backend/internal/pipeline"
 	"github.com/CABGenOrg/cabgen_backend/internal/repositories"
 	"github.com/google/uuid"
 	"go.uber.org/zap"
@@ -85,6 +86,17 @@ func (s *metricsService) GetMetrics(ctx context.Context) (
 			metrics.AnalysesByStatus.Pending++
 		case models.AnalysisStatusFailed:
 			metrics.AnalysesByStatus.Failed++
+		case models.AnalysisStatusCancelled:
+			metrics.AnalysesByStatus.Cancelled++
+		}
+
+		switch analysis.SpeciesConcordance {
+		case pipeline.SpeciesConcordant:
+			metrics.SpeciesConcordance.Concordant++
+		case pipeline.SpeciesDiscordant:
+			metrics.SpeciesConcordance.Discordant++
+		case pipeline.SpeciesInconclusive:
+			metrics.SpeciesConcordance.Inconclusive++
 		}
 	}
 
@@ -168,9 +180,9 @@ func uniqueSpeciesResults(analyses []models.Analysis) (map[string]struct{}, map[
 		if results.PrimarySpeciesName != "" {
 			species[results.PrimarySpeciesName] = struct{}{}
 		}
-		for _, gene := range results.AcquiredResistance {
-			if gene != "" {
-				genes[gene] = struct{}{}
+		for _, hit := range results.AcquiredResistance {
+			if hit.Gene != "" {
+				genes[hit.Gene] = struct{}{}
 			}
 		}
 	}
@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/CABGenOrg/cabgen_backend/internal/models"
+	"github.com/CABGenOrg/cabgen_backend/internal/pipeline"
 	"github.com/CABGenOrg/cabgen_backend/internal/services"
 	"github.com/CABGenOrg/cabgen_backend/internal/testutils"
 	"github.com/CABGenOrg/cabgen_backend/internal/testutils/mocks"
@@ -25,7 +26,7 @@ func TestMetricsGetMetrics(t *testing.T) {
 	t.Run("Success", func(t *testing.T) {
 		mockDone := testmodels.CreateMockAnalysis()
 		mockDone.Status = models.AnalysisStatusDone
-		mockDone.Metrics = []byte(`{"primary_species":"Acinetobacter baumannii","acquired_resistance":["blaOXA-23"]}`)
+		mockDone.Metrics = []byte(`{"primary_species":"Acinetobacter baumannii","acquired_resistance":[{"gene":"blaOXA-23","database":"resfinder"}]}`)
 
 		sampleRepo := &mocks.MockSampleRepository{
 			GetSamplesFunc: func(ctx context.Context, input string,
@@ -100,10 +101,10 @@ func TestMetricsGetMetrics(t *testing.T) {
 	t.Run("Success - Aggregates Species and Genes", func(t *testing.T) {
 		mockDone := testmodels.CreateMockAnalysis()
 		mockDone.Status = models.AnalysisStatusDone
-		mockDone.Metrics = []byte(`{"primary_species":"Acinetobacter baumannii","acquired_resistance":["blaOXA-23","armA"]}`)
+		mockDone.Metrics = []byte(`{"primary_species":"Acinetobacter baumannii","acquired_resistance":[{"gene":"blaOXA-23","database":"resfinder"},{"gene":"armA","database":"resfinder"}]}`)
 		mockDuplicate := testmodels.CreateMockAnalysis()
 		mockDuplicate.Status = models.AnalysisStatusDone
-		mockDuplicate.Metrics = []byte(`{"primary_species":"Acinetobacter baumannii","acquired_resistance":["blaOXA-23"]}`)
+		mockDuplicate.Metrics = []byte(`{"primary_species":"Acinetobacter baumannii","acquired_resistance":[{"gene":"blaOXA-23","database":"resfinder"}]}`)
 		mockInvalid := testmodels.CreateMockAnalysis()
 		mockInvalid.Status = models.AnalysisStatusDone
 		mockInvalid.Metrics = []byte(`{"primary_species":`)
@@ -140,10 +141,10 @@ func TestMetricsGetMetrics(t *testing.T) {
 	t.Run("Non-Done Analyses Excluded", func(t *testing.T) {
 		mockDone := testmodels.CreateMockAnalysis()
 		mockDone.Status = models.AnalysisStatusDone
-		mockDone.Metrics = []byte(`{"primary_species":"Acinetobacter baumannii","acquired_resistance":["blaOXA-23"]}`)
+		mockDone.Metrics = []byte(`{"primary_species":"Acinetobacter baumannii","acquired_resistance":[{"gene":"blaOXA-23","database":"resfinder"}]}`)
 		mockPending := testmodels.CreateMockAnalysis()
 		mockPending.Status = models.AnalysisStatusPending
-		mockPending.Metrics = []byte(`{"primary_species":"Other","acquired_resistance":["x"]}`)
+		mockPending.Metrics = []byte(`{"primary_species":"Other","acquired_resistance":[{"gene":"x","database":"resfinder"}]}`)
 		mockEmptyResult := testmodels.CreateMockAnalysis()
 		mockEmptyResult.Status = models.AnalysisStatusDone
 		mockEmptyResult.Metrics = []byte(`{}`)
@@ -177,6 +178,34 @@ func TestMetricsGetMetrics(t *testing.T) {
 		assert.Equal(t, int64(3), result.TotalAnalyses)
 	})
 
+	t.Run("Success - Counts Species Concordance", func(t *testing.T) {
+		mockConcordant := testmodels.CreateMockAnalysis()
+		mockConcordant.SpeciesConcordance = pipeline.SpeciesConcordant
+		mockDiscordant := testmodels.CreateMockAnalysis()
+		mockDiscordant.SpeciesConcordance = pipeline.SpeciesDiscordant
+		mockInconclusive := testmodels.CreateMockAnalysis()
+		mockInconclusive.SpeciesConcordance = pipeline.SpeciesInconclusive
+		mockUnchecked := testmodels.CreateMockAnalysis()
+
+		sampleRepo := &mocks.MockSampleRepository{}
+		analysisRepo := &mocks.MockAnalysisRepository{
+			GetAnalysesFunc: func(ctx context.Context,
+				userID uuid.UUID, filter models.AnalysisFilter) ([]models.Analysis, error) {
+				return []models.Analysis{mockConcordant, mockDiscordant,
+					mockDiscordant, mockInconclusive, mockUnchecked}, nil
+			},
+		}
+		userRepo := &mocks.MockUserRepository{}
+
+		svc := services.NewMetricsService(sampleRepo, analysisRepo, userRepo, nil)
+		result, err := svc.GetMetrics(ctx)
+
+		assert.NoError(t, err)
+		assert.Equal(t, models.SpeciesConcordance{
+			Concordant: 1, Discordant: 2, Inconclusive: 1,
+		}, result.SpeciesConcordance)
+	})
+
 	t.Run("Error", func(t *testing.T) {
 		sampleRepo := &mocks.MockSampleRepository{
 			GetSamplesFunc: func(ctx context.Context, input string,
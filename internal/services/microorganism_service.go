@@ -116,6 +116,7 @@ func (s *microorganismService) Create(
 		Taxon:    input.Taxon,
 		Species:  input.Species,
 		Variety:  input.Variety,
+		TaxID:    input.TaxID,
 		IsActive: input.IsActive,
 	}
 
@@ -0,0 +1,182 @@
+package services
+
+import (
+	"context"
+	"errors"
+
+	"github.com/CABGenOrg/cabgen_backend/internal/logging"
+	"github.com/CABGenOrg/cabgen_backend/internal/models"
+	"github.com/CABGenOrg/cabgen_backend/internal/pipeline"
+	"github.com/CABGenOrg/cabgen_backend/internal/repositories"
+	"github.com/CABGenOrg/cabgen_backend/internal/validations"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+type QCRuleService interface {
+	FindAll(ctx context.Context) ([]models.QCRuleAdminTableResponse, error)
+	FindByID(ctx context.Context, ID uuid.UUID) (*models.QCRuleAdminTableResponse, error)
+	Create(ctx context.Context, input models.QCRuleCreateInput) (*models.QCRuleAdminTableResponse, error)
+	Update(ctx context.Context, ID uuid.UUID, input models.QCRuleUpdateInput) (*models.QCRuleAdminTableResponse, error)
+	Delete(ctx context.Context, ID uuid.UUID) error
+}
+
+type qcRuleService struct {
+	Repo   repositories.QCRuleRepository
+	Logger *zap.Logger
+}
+
+func NewQCRuleService(repo repositories.QCRuleRepository,
+	logger *zap.Logger) QCRuleService {
+	return &qcRuleService{Repo: repo, Logger: logger}
+}
+
+func (s *qcRuleService) FindAll(ctx context.Context) ([]models.QCRuleAdminTableResponse, error) {
+	rules, err := s.Repo.GetQCRules(ctx)
+	if err != nil {
+		s.Logger.Error("Service Error", logging.ServiceLogging(
+			"QCRuleService", "FindAll",
+			logging.DatabaseError, err,
+		)...)
+		return nil, ErrInternal
+	}
+
+	tableResponses := make([]models.QCRuleAdminTableResponse, len(rules))
+	for i, rule := range rules {
+		tableResponses[i] = rule.ToAdminTableResponse()
+	}
+
+	return tableResponses, nil
+}
+
+func (s *qcRuleService) FindByID(
+	ctx context.Context,
+	ID uuid.UUID) (*models.QCRuleAdminTableResponse, error) {
+	rule, err := s.Repo.GetQCRuleByID(ctx, ID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		s.Logger.Error("Service Error", logging.ServiceLogging(
+			"QCRuleService", "FindByID",
+			logging.DatabaseNotFoundError, err,
+		)...)
+		return nil, ErrNotFound
+	}
+
+	if err != nil {
+		s.Logger.Error("Service Error", logging.ServiceLogging(
+			"QCRuleService", "FindByID",
+			logging.DatabaseError, err,
+		)...)
+		return nil, ErrInternal
+	}
+
+	tableResponse := rule.ToAdminTableResponse()
+	return &tableResponse, nil
+}
+
+func (s *qcRuleService) Create(
+	ctx context.Context,
+	input models.QCRuleCreateInput) (*models.QCRuleAdminTableResponse, error) {
+	rule := models.QCRule{
+		Metric:   input.Metric,
+		Species:  input.Species,
+		Min:      input.Min,
+		Max:      input.Max,
+		Severity: input.Severity,
+		IsActive: input.IsActive,
+	}
+	if rule.Severity == "" {
+		rule.Severity = pipeline.QCSeverityFail
+	}
+
+	if err := rule.ToPipelineRule().Validate(); err != nil {
+		s.Logger.Error("Service Error", logging.ServiceLogging(
+			"QCRuleService", "Create",
+			logging.InvalidParametersError, err,
+		)...)
+		return nil, ErrInvalidQCRule
+	}
+
+	if err := s.Repo.CreateQCRule(ctx, &rule); err != nil {
+		s.Logger.Error("Service Error", logging.ServiceLogging(
+			"QCRuleService", "Create",
+			logging.DatabaseError, err,
+		)...)
+		return nil, ErrInternal
+	}
+
+	tableResponse := rule.ToAdminTableResponse()
+	return &tableResponse, nil
+}
+
+func (s *qcRuleService) Update(
+	ctx context.Context,
+	ID uuid.UUID,
+	input models.QCRuleUpdateInput) (*models.QCRuleAdminTableResponse, error) {
+	existingRule, err := s.Repo.GetQCRuleByID(ctx, ID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		s.Logger.Error("Service Error", logging.ServiceLogging(
+			"QCRuleService", "Update",
+			logging.DatabaseNotFoundError, err,
+		)...)
+		return nil, ErrNotFound
+	}
+
+	if err != nil {
+		s.Logger.Error("Service Error", logging.ServiceLogging(
+			"QCRuleService", "Update",
+			logging.DatabaseError, err,
+		)...)
+		return nil, ErrInternal
+	}
+
+	validations.ApplyQCRuleUpdate(existingRule, &input)
+
+	if err := existingRule.ToPipelineRule().Validate(); err != nil {
+		s.Logger.Error("Service Error", logging.ServiceLogging(
+			"QCRuleService", "Update",
+			logging.InvalidParametersError, err,
+		)...)
+		return nil, ErrInvalidQCRule
+	}
+
+	if err := s.Repo.UpdateQCRule(ctx, existingRule); err != nil {
+		s.Logger.Error("Service Error", logging.ServiceLogging(
+			"QCRuleService", "Update",
+			logging.DatabaseError, err,
+		)...)
+		return nil, ErrInternal
+	}
+
+	tableResponse := existingRule.ToAdminTableResponse()
+	return &tableResponse, nil
+}
+
+func (s *qcRuleService) Delete(ctx context.Context, ID uuid.UUID) error {
+	rule, err := s.Repo.GetQCRuleByID(ctx, ID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		s.Logger.Error("Service Error", logging.ServiceLogging(
+			"QCRuleService", "Delete",
+			logging.DatabaseNotFoundError, err,
+		)...)
+		return ErrNotFound
+	}
+
+	if err != nil {
+		s.Logger.Error("Service Error", logging.ServiceLogging(
+			"QCRuleService", "Delete",
+			logging.DatabaseError, err,
+		)...)
+		return ErrInternal
+	}
+
+	if err := s.Repo.DeleteQCRule(ctx, rule); err != nil {
+		s.Logger.Error("Service Error", logging.ServiceLogging(
+			"QCRuleService", "Delete",
+			logging.DatabaseError, err,
+		)...)
+		return ErrInternal
+	}
+
+	return nil
+}
@@ -0,0 +1,322 @@
+package services_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/CABGenOrg/cabgen_backend/internal/models"
+	"github.com/CABGenOrg/cabgen_backend/internal/pipeline"
+	"github.com/CABGenOrg/cabgen_backend/internal/services"
+	"github.com/CABGenOrg/cabgen_backend/internal/testutils"
+	"github.com/CABGenOrg/cabgen_backend/internal/testutils/mocks"
+	testmodels "github.com/CABGenOrg/cabgen_backend/internal/testutils/models"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+func newCompletenessRule() models.QCRule {
+	minCompleteness := 90.0
+	return testmodels.NewQCRule(uuid.NewString(),
+		pipeline.QCMetricCompleteness, &minCompleteness, nil,
+		pipeline.QCSeverityFail, true)
+}
+
+func TestQCRuleFindAll(t *testing.T) {
+	rule := newCompletenessRule()
+
+	t.Run("Success", func(t *testing.T) {
+		repo := &mocks.MockQCRuleRepository{
+			GetQCRulesFunc: func(ctx context.Context) ([]models.QCRule, error) {
+				return []models.QCRule{rule}, nil
+			},
+		}
+		service := services.NewQCRuleService(repo, nil)
+
+		expected := []models.QCRuleAdminTableResponse{rule.ToAdminTableResponse()}
+		rules, err := service.FindAll(context.Background())
+
+		assert.NoError(t, err)
+		assert.Equal(t, expected, rules)
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		repo := &mocks.MockQCRuleRepository{
+			GetQCRulesFunc: func(ctx context.Context) ([]models.QCRule, error) {
+				return nil, gorm.ErrInvalidTransaction
+			},
+		}
+
+		mockLogger, logs := testutils.NewMockLogger(zap.ErrorLevel)
+
+		service := services.NewQCRuleService(repo, mockLogger)
+		rules, err := service.FindAll(context.Background())
+
+		assert.ErrorIs(t, err, services.ErrInternal)
+		assert.Empty(t, rules)
+		assert.Equal(t, 1, logs.Len())
+	})
+}
+
+func TestQCRuleFindByID(t *testing.T) {
+	rule := newCompletenessRule()
+
+	t.Run("Success", func(t *testing.T) {
+		repo := &mocks.MockQCRuleRepository{
+			GetQCRuleByIDFunc: func(ctx context.Context, ID uuid.UUID) (*models.QCRule, error) {
+				return &rule, nil
+			},
+		}
+		service := services.NewQCRuleService(repo, nil)
+
+		expected := rule.ToAdminTableResponse()
+		result, err := service.FindByID(context.Background(), rule.ID)
+
+		assert.NoError(t, err)
+		assert.Equal(t, &expected, result)
+	})
+
+	t.Run("Error - Record not found", func(t *testing.T) {
+		repo := &mocks.MockQCRuleRepository{
+			GetQCRuleByIDFunc: func(ctx context.Context, ID uuid.UUID) (*models.QCRule, error) {
+				return nil, gorm.ErrRecordNotFound
+			},
+		}
+
+		mockLogger, logs := testutils.NewMockLogger(zap.ErrorLevel)
+
+		service := services.NewQCRuleService(repo, mockLogger)
+		result, err := service.FindByID(context.Background(), uuid.New())
+
+		assert.ErrorIs(t, err, services.ErrNotFound)
+		assert.Empty(t, result)
+		assert.Equal(t, 1, logs.Len())
+	})
+
+	t.Run("Error - Internal", func(t *testing.T) {
+		repo := &mocks.MockQCRuleRepository{
+			GetQCRuleByIDFunc: func(ctx context.Context, ID uuid.UUID) (*models.QCRule, error) {
+				return nil, gorm.ErrInvalidTransaction
+			},
+		}
+
+		mockLogger, logs := testutils.NewMockLogger(zap.ErrorLevel)
+
+		service := services.NewQCRuleService(repo, mockLogger)
+		result, err := service.FindByID(context.Background(), rule.ID)
+
+		assert.ErrorIs(t, err, services.ErrInternal)
+		assert.Empty(t, result)
+		assert.Equal(t, 1, logs.Len())
+	})
+}
+
+func TestQCRuleCreate(t *testing.T) {
+	minCoverage := 30.0
+
+	t.Run("Success - Defaults Severity to FAIL", func(t *testing.T) {
+		var created *models.QCRule
+		repo := &mocks.MockQCRuleRepository{
+			CreateQCRuleFunc: func(ctx context.Context, rule *models.QCRule) error {
+				created = rule
+				return nil
+			},
+		}
+		service := services.NewQCRuleService(repo, nil)
+
+		result, err := service.Create(context.Background(),
+			models.QCRuleCreateInput{
+				Metric:   pipeline.QCMetricCoverage,
+				Species:  "Escherichia coli",
+				Min:      &minCoverage,
+				IsActive: true,
+			})
+
+		assert.NoError(t, err)
+		if assert.NotNil(t, created) {
+			assert.Equal(t, pipeline.QCSeverityFail, created.Severity)
+		}
+		assert.Equal(t, pipeline.QCSeverityFail, result.Severity)
+		assert.Equal(t, "Escherichia coli", result.Species)
+	})
+
+	t.Run("Error - Invalid Rule", func(t *testing.T) {
+		mockLogger, logs := testutils.NewMockLogger(zap.ErrorLevel)
+
+		service := services.NewQCRuleService(&mocks.MockQCRuleRepository{},
+			mockLogger)
+		result, err := service.Create(context.Background(),
+			models.QCRuleCreateInput{Metric: "gc_content", Min: &minCoverage})
+
+		assert.ErrorIs(t, err, services.ErrInvalidQCRule)
+		assert.Empty(t, result)
+		assert.Equal(t, 1, logs.Len())
+	})
+
+	t.Run("Error - Missing Bounds", func(t *testing.T) {
+		mockLogger, logs := testutils.NewMockLogger(zap.ErrorLevel)
+
+		service := services.NewQCRuleService(&mocks.MockQCRuleRepository{},
+			mockLogger)
+		result, err := service.Create(context.Background(),
+			models.QCRuleCreateInput{Metric: pipeline.QCMetricCoverage})
+
+		assert.ErrorIs(t, err, services.ErrInvalidQCRule)
+		assert.Empty(t, result)
+		assert.Equal(t, 1, logs.Len())
+	})
+
+	t.Run("Error - Internal", func(t *testing.T) {
+		repo := &mocks.MockQCRuleRepository{
+			CreateQCRuleFunc: func(ctx context.Context, rule *models.QCRule) error {
+				return gorm.ErrInvalidTransaction
+			},
+		}
+
+		mockLogger, logs := testutils.NewMockLogger(zap.ErrorLevel)
+
+		service := services.NewQCRuleService(repo, mockLogger)
+		result, err := service.Create(context.Background(),
+			models.QCRuleCreateInput{
+				Metric: pipeline.QCMetricCoverage, Min: &minCoverage,
+			})
+
+		assert.ErrorIs(t, err, services.ErrInternal)
+		assert.Empty(t, result)
+		assert.Equal(t, 1, logs.Len())
+	})
+}
+
+func TestQCRuleUpdate(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		rule := newCompletenessRule()
+		repo := &mocks.MockQCRuleRepository{
+			GetQCRuleByIDFunc: func(ctx context.Context, ID uuid.UUID) (*models.QCRule, error) {
+				return &rule, nil
+			},
+		}
+		service := services.NewQCRuleService(repo, nil)
+
+		newMin := 95.0
+		severity := pipeline.QCSeverityWarn
+		result, err := service.Update(context.Background(), rule.ID,
+			models.QCRuleUpdateInput{Min: &newMin, Severity: &severity})
+
+		assert.NoError(t, err)
+		assert.Equal(t, &newMin, result.Min)
+		assert.Equal(t, pipeline.QCSeverityWarn, result.Severity)
+	})
+
+	t.Run("Error - Record not found", func(t *testing.T) {
+		repo := &mocks.MockQCRuleRepository{
+			GetQCRuleByIDFunc: func(ctx context.Context, ID uuid.UUID) (*models.QCRule, error) {
+				return nil, gorm.ErrRecordNotFound
+			},
+		}
+
+		mockLogger, logs := testutils.NewMockLogger(zap.ErrorLevel)
+
+		service := services.NewQCRuleService(repo, mockLogger)
+		result, err := service.Update(context.Background(), uuid.New(),
+			models.QCRuleUpdateInput{})
+
+		assert.ErrorIs(t, err, services.ErrNotFound)
+		assert.Empty(t, result)
+		assert.Equal(t, 1, logs.Len())
+	})
+
+	t.Run("Error - Min Above Max", func(t *testing.T) {
+		rule := newCompletenessRule()
+		repo := &mocks.MockQCRuleRepository{
+			GetQCRuleByIDFunc: func(ctx context.Context, ID uuid.UUID) (*models.QCRule, error) {
+				return &rule, nil
+			},
+		}
+
+		mockLogger, logs := testutils.NewMockLogger(zap.ErrorLevel)
+
+		service := services.NewQCRuleService(repo, mockLogger)
+		newMax := 50.0
+		result, err := service.Update(context.Background(), rule.ID,
+			models.QCRuleUpdateInput{Max: &newMax})
+
+		assert.ErrorIs(t, err, services.ErrInvalidQCRule)
+		assert.Empty(t, result)
+		assert.Equal(t, 1, logs.Len())
+	})
+
+	t.Run("Error - Internal", func(t *testing.T) {
+		rule := newCompletenessRule()
+		repo := &mocks.MockQCRuleRepository{
+			GetQCRuleByIDFunc: func(ctx context.Context, ID uuid.UUID) (*models.QCRule, error) {
+				return &rule, nil
+			},
+			UpdateQCRuleFunc: func(ctx context.Context, rule *models.QCRule) error {
+				return gorm.ErrInvalidTransaction
+			},
+		}
+
+		mockLogger, logs := testutils.NewMockLogger(zap.ErrorLevel)
+
+		service := services.NewQCRuleService(repo, mockLogger)
+		result, err := service.Update(context.Background(), rule.ID,
+			models.QCRuleUpdateInput{})
+
+		assert.ErrorIs(t, err, services.ErrInternal)
+		assert.Empty(t, result)
+		assert.Equal(t, 1, logs.Len())
+	})
+}
+
+func TestQCRuleDelete(t *testing.T) {
+	rule := newCompletenessRule()
+
+	t.Run("Success", func(t *testing.T) {
+		repo := &mocks.MockQCRuleRepository{
+			GetQCRuleByIDFunc: func(ctx context.Context, ID uuid.UUID) (*models.QCRule, error) {
+				return &rule, nil
+			},
+		}
+		service := services.NewQCRuleService(repo, nil)
+
+		err := service.Delete(context.Background(), rule.ID)
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("Error - Record not found", func(t *testing.T) {
+		repo := &mocks.MockQCRuleRepository{
+			GetQCRuleByIDFunc: func(ctx context.Context, ID uuid.UUID) (*models.QCRule, error) {
+				return nil, gorm.ErrRecordNotFound
+			},
+		}
+
+		mockLogger, logs := testutils.NewMockLogger(zap.ErrorLevel)
+
+		service := services.NewQCRuleService(repo, mockLogger)
+		err := service.Delete(context.Background(), uuid.New())
+
+		assert.ErrorIs(t, err, services.ErrNotFound)
+		assert.Equal(t, 1, logs.Len())
+	})
+
+	t.Run("Error - Internal", func(t *testing.T) {
+		repo := &mocks.MockQCRuleRepository{
+			GetQCRuleByIDFunc: func(ctx context.Context, ID uuid.UUID) (*models.QCRule, error) {
+				return &rule, nil
+			},
+			DeleteQCRuleFunc: func(ctx context.Context, rule *models.QCRule) error {
+				return gorm.ErrInvalidTransaction
+			},
+		}
+
+		mockLogger, logs := testutils.NewMockLogger(zap.ErrorLevel)
+
+		service := services.NewQCRuleService(repo, mockLogger)
+		err := service.Delete(context.Background(), rule.ID)
+
+		assert.ErrorIs(t, err, services.ErrInternal)
+		assert.Equal(t, 1, logs.Len())
+	})
+}
@@ -2,12 +2,15 @@ package services
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"os"
 	"path/filepath"
+	"time"
 
 	"github.com/CABGenOrg/cabgen_backend/internal/logging"
 	"github.com/CABGenOrg/cabgen_backend/internal/models"
+	"github.com/CABGenOrg/cabgen_backend/internal/pipeline"
 	"github.com/CABGenOrg/cabgen_backend/internal/repositories"
 	"github.com/CABGenOrg/cabgen_backend/internal/validations"
 	"github.com/google/uuid"
@@ -380,10 +383,9 @@ func (s *sampleService) AttachFiles(ctx context.Context,
 		return ErrUnauthorized
 	}
 
-	if input.Fastq1 == nil && input.Fastq2 == nil && input.Fasta == nil {
+	if input.Fastq1 == nil && input.Fastq2 == nil && input.Fasta == nil &&
+		input.LongReads == nil {
 		return ErrMissingFiles
-	} else if input.Fastq1 != nil && input.Fastq2 == nil {
-		return ErrMissingFastq2
 	} else if input.Fastq1 == nil && input.Fastq2 != nil {
 		return ErrMissingFastq1
 	}
@@ -391,6 +393,56 @@ func (s *sampleService) AttachFiles(ctx context.Context,
 	oldFastq1 := sample.Fastq1
 	oldFastq2 := sample.Fastq2
 	oldFasta := sample.Fasta
+	oldLongReads := sample.LongReads
+
+	sampleDir := s.getSampleFolderPath(sample.UserID, sampleID)
+
+	report, validationErr := validateSampleFiles(sampleDir, input)
+	if rawReport, err := json.Marshal(report); err == nil {
+		sample.ValidationReport = rawReport
+	}
+
+	if validationErr != nil {
+		s.Logger.Warn("Service Warning", logging.ServiceLogging(
+			"SampleService", "AttachFiles", logging.InvalidFileError,
+			validationErr,
+		)...)
+
+		if err := s.Repo.UpdateSample(ctx, sample); err != nil {
+			s.Logger.Error("Service Error", logging.ServiceLogging(
+				"SampleService", "AttachFiles",
+				logging.DatabaseError, err,
+			)...)
+		}
+		return validationErr
+	}
+
+	// Only validated uploads replace the files the sample points to, and
+	// they are stored under names of our own rather than the client's.
+	staged := []struct {
+		name       **string
+		kind       string
+		validation *pipeline.FileValidation
+	}{
+		{&input.Fastq1, "fastq1", report.Fastq1},
+		{&input.Fastq2, "fastq2", report.Fastq2},
+		{&input.Fasta, "assembly", report.Fasta},
+		{&input.LongReads, "long_reads", report.LongReads},
+	}
+	for _, file := range staged {
+		if *file.name == nil {
+			continue
+		}
+		name := sampleFileName(file.kind, file.validation)
+		if err := os.Rename(filepath.Join(sampleDir, **file.name),
+			filepath.Join(sampleDir, name)); err != nil {
+			s.Logger.Error("Service Error", logging.ServiceLogging(
+				"SampleService", "AttachFiles", logging.MoveFileError, err,
+			)...)
+			return ErrInternal
+		}
+		*file.name = &name
+	}
 
 	validations.ApplySampleFilesUpdate(sample, &input)
 
@@ -402,8 +454,6 @@ func (s *sampleService) AttachFiles(ctx context.Context,
 		return ErrInternal
 	}
 
-	sampleDir := s.getSampleFolderPath(sample.UserID, sampleID)
-
 	if oldFastq1 != nil && input.Fastq1 != nil && *oldFastq1 != *input.Fastq1 {
 		if err := os.Remove(filepath.Join(sampleDir, *oldFastq1)); err != nil {
 			s.Logger.Warn("Service Warning", logging.ServiceLogging(
@@ -411,7 +461,9 @@ func (s *sampleService) AttachFiles(ctx context.Context,
 			)...)
 		}
 	}
-	if oldFastq2 != nil && input.Fastq2 != nil && *oldFastq2 != *input.Fastq2 {
+	// Single-end reads replace both mates of the previous pair.
+	if oldFastq2 != nil && input.Fastq1 != nil &&
+		(input.Fastq2 == nil || *oldFastq2 != *input.Fastq2) {
 		if err := os.Remove(filepath.Join(sampleDir, *oldFastq2)); err != nil {
 			s.Logger.Warn("Service Warning", logging.ServiceLogging(
 				"SampleService", "AttachFiles", logging.DeleteFileError, err,
@@ -425,10 +477,80 @@ func (s *sampleService) AttachFiles(ctx context.Context,
 			)...)
 		}
 	}
+	if oldLongReads != nil && input.LongReads != nil &&
+		*oldLongReads != *input.LongReads {
+		if err := os.Remove(filepath.Join(sampleDir,
+			*oldLongReads)); err != nil {
+			s.Logger.Warn("Service Warning", logging.ServiceLogging(
+				"SampleService", "AttachFiles", logging.DeleteFileError, err,
+			)...)
+		}
+	}
 
 	return nil
 }
 
+// sampleFileName names a validated upload after its slot and detected format,
+// e.g. fastq1.fastq.gz, so re-uploads always land on the same file.
+func sampleFileName(kind string, validation *pipeline.FileValidation) string {
+	name := kind + "." + validation.Format
+	if validation.Compressed {
+		name += ".gz"
+	}
+	return name
+}
+
+// validateSampleFiles checks the uploaded files against their slot: the
+// short reads must be well-formed FASTQ mates or a single-end FASTQ, the long
+// reads a FASTQ and the assembly a nucleotide FASTA.
+func validateSampleFiles(sampleDir string,
+	input models.SampleAttachmentInput) (models.SampleValidationReport,
+	error) {
+	report := models.SampleValidationReport{ValidatedAt: time.Now()}
+	fail := func(err error) (models.SampleValidationReport, error) {
+		report.Error = err.Error()
+		return report, err
+	}
+
+	if input.Fastq1 != nil && input.Fastq2 != nil {
+		fastq1, fastq2, err := pipeline.ValidatePairedFastq(
+			filepath.Join(sampleDir, *input.Fastq1),
+			filepath.Join(sampleDir, *input.Fastq2))
+		if err != nil {
+			return fail(err)
+		}
+		report.Fastq1, report.Fastq2 = fastq1, fastq2
+	} else if input.Fastq1 != nil {
+		fastq1, err := pipeline.ValidateFastq(
+			filepath.Join(sampleDir, *input.Fastq1))
+		if err != nil {
+			return fail(err)
+		}
+		report.Fastq1 = fastq1
+	}
+
+	if input.LongReads != nil {
+		longReads, err := pipeline.ValidateFastq(
+			filepath.Join(sampleDir, *input.LongReads))
+		if err != nil {
+			return fail(err)
+		}
+		report.LongReads = longReads
+	}
+
+	if input.Fasta != nil {
+		fasta, err := pipeline.ValidateFasta(
+			filepath.Join(sampleDir, *input.Fasta))
+		if err != nil {
+			return fail(err)
+		}
+		report.Fasta = fasta
+	}
+
+	report.Valid = true
+	return report, nil
+}
+
 func (s *sampleService) Update(
 	ctx context.Context, sampleID, userID uuid.UUID,
 	input models.SampleUpdateDTO,
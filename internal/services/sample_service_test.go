@@ -2,12 +2,14 @@ package services_test
 
 import (
 	"context"
+	"encoding/json"
 	"os"
 	"path/filepath"
 	"runtime"
 	"testing"
 
 	"github.com/CABGenOrg/cabgen_backend/internal/models"
+	"github.com/CABGenOrg/cabgen_backend/internal/pipeline"
 	"github.com/CABGenOrg/cabgen_backend/internal/services"
 	"github.com/CABGenOrg/cabgen_backend/internal/testutils"
 	"github.com/CABGenOrg/cabgen_backend/internal/testutils/mocks"
@@ -648,6 +650,16 @@ func TestSampleAttachFiles(t *testing.T) {
 	fastq1 := "new_read1.fastq"
 	fastq2 := "new_read2.fastq"
 	fasta := "assembly.fasta"
+	longReads := "nanopore.fastq"
+	// Accepted uploads are moved away, so every test stages its own copy.
+	newUploads := func(t *testing.T) string {
+		return writeSampleUploads(t, mock, map[string]string{
+			fastq1:    "@read1/1\nACGT\n+\nIIII\n",
+			fastq2:    "@read1/2\nACGT\n+\nIIII\n",
+			fasta:     ">contig_1\nACGT\n",
+			longReads: "@long1\nACGTACGTACGT\n+\n++++++++++++\n",
+		})
+	}
 
 	t.Run("Success - Fastq pair", func(t *testing.T) {
 		sampleRepo := &mocks.MockSampleRepository{
@@ -664,7 +676,7 @@ func TestSampleAttachFiles(t *testing.T) {
 		mockLogger, _ := testutils.NewMockLogger(zap.ErrorLevel)
 
 		svc := services.NewSampleService(sampleRepo, nil, nil, nil, nil, nil,
-			nil, nil, nil, t.TempDir(), mockLogger)
+			nil, nil, nil, newUploads(t), mockLogger)
 		err := svc.AttachFiles(context.Background(), mock.ID, uuid.Nil,
 			models.SampleAttachmentInput{Fastq1: &fastq1, Fastq2: &fastq2})
 
@@ -689,13 +701,87 @@ func TestSampleAttachFiles(t *testing.T) {
 		mockLogger, _ := testutils.NewMockLogger(zap.ErrorLevel)
 
 		svc := services.NewSampleService(sampleRepo, nil, nil, nil, nil, nil,
-			nil, nil, nil, t.TempDir(), mockLogger)
+			nil, nil, nil, newUploads(t), mockLogger)
 		err := svc.AttachFiles(context.Background(), mock.ID, uuid.Nil,
 			models.SampleAttachmentInput{Fasta: &fasta})
 
 		assert.NoError(t, err)
 	})
 
+	t.Run("Success - Long reads", func(t *testing.T) {
+		var updated *models.Sample
+		sampleRepo := &mocks.MockSampleRepository{
+			GetSampleByIDFunc: func(ctx context.Context,
+				ID uuid.UUID) (*models.Sample, error) {
+				s := mock
+				return &s, nil
+			},
+			UpdateSampleFunc: func(ctx context.Context,
+				sample *models.Sample) error {
+				updated = sample
+				return nil
+			},
+		}
+
+		mockLogger, _ := testutils.NewMockLogger(zap.ErrorLevel)
+
+		svc := services.NewSampleService(sampleRepo, nil, nil, nil, nil, nil,
+			nil, nil, nil, newUploads(t), mockLogger)
+		err := svc.AttachFiles(context.Background(), mock.ID, uuid.Nil,
+			models.SampleAttachmentInput{LongReads: &longReads})
+
+		assert.NoError(t, err)
+		if assert.NotNil(t, updated) {
+			if assert.NotNil(t, updated.LongReads) {
+				assert.Equal(t, "long_reads.fastq", *updated.LongReads)
+			}
+			var report models.SampleValidationReport
+			assert.NoError(t, json.Unmarshal(updated.ValidationReport,
+				&report))
+			if assert.NotNil(t, report.LongReads) {
+				assert.Equal(t, int64(12), report.LongReads.Bases)
+			}
+		}
+	})
+
+	t.Run("Success - Single-end", func(t *testing.T) {
+		var updated *models.Sample
+		sampleRepo := &mocks.MockSampleRepository{
+			GetSampleByIDFunc: func(ctx context.Context,
+				ID uuid.UUID) (*models.Sample, error) {
+				s := mock
+				oldFastq2 := "old_read2.fastq"
+				s.Fastq2 = &oldFastq2
+				return &s, nil
+			},
+			UpdateSampleFunc: func(ctx context.Context,
+				sample *models.Sample) error {
+				updated = sample
+				return nil
+			},
+		}
+
+		mockLogger, _ := testutils.NewMockLogger(zap.ErrorLevel)
+
+		svc := services.NewSampleService(sampleRepo, nil, nil, nil, nil, nil,
+			nil, nil, nil, newUploads(t), mockLogger)
+		err := svc.AttachFiles(context.Background(), mock.ID, uuid.Nil,
+			models.SampleAttachmentInput{Fastq1: &fastq1})
+
+		assert.NoError(t, err)
+		if assert.NotNil(t, updated) {
+			if assert.NotNil(t, updated.Fastq1) {
+				assert.Equal(t, "fastq1.fastq", *updated.Fastq1)
+			}
+			assert.Nil(t, updated.Fastq2)
+			var report models.SampleValidationReport
+			assert.NoError(t, json.Unmarshal(updated.ValidationReport,
+				&report))
+			assert.NotNil(t, report.Fastq1)
+			assert.Nil(t, report.Fastq2)
+		}
+	})
+
 	t.Run("Error - Not Found", func(t *testing.T) {
 		sampleRepo := &mocks.MockSampleRepository{
 			GetSampleByIDFunc: func(ctx context.Context,
@@ -753,23 +839,6 @@ func TestSampleAttachFiles(t *testing.T) {
 		assert.ErrorIs(t, err, services.ErrMissingFiles)
 	})
 
-	t.Run("Error - Missing Fastq2", func(t *testing.T) {
-		sampleRepo := &mocks.MockSampleRepository{
-			GetSampleByIDFunc: func(ctx context.Context,
-				ID uuid.UUID) (*models.Sample, error) {
-				return &mock, nil
-			},
-		}
-
-		svc := services.NewSampleService(sampleRepo, nil, nil, nil, nil, nil,
-			nil, nil, nil, t.TempDir(), nil)
-		err := svc.AttachFiles(context.Background(), mock.ID, uuid.Nil,
-			models.SampleAttachmentInput{Fastq1: &fastq1})
-
-		assert.Error(t, err)
-		assert.ErrorIs(t, err, services.ErrMissingFastq2)
-	})
-
 	t.Run("Error - Missing Fastq1", func(t *testing.T) {
 		sampleRepo := &mocks.MockSampleRepository{
 			GetSampleByIDFunc: func(ctx context.Context,
@@ -802,7 +871,7 @@ func TestSampleAttachFiles(t *testing.T) {
 		mockLogger, logs := testutils.NewMockLogger(zap.ErrorLevel)
 
 		svc := services.NewSampleService(sampleRepo, nil, nil, nil, nil, nil,
-			nil, nil, nil, t.TempDir(), mockLogger)
+			nil, nil, nil, newUploads(t), mockLogger)
 		err := svc.AttachFiles(context.Background(), mock.ID, uuid.Nil,
 			models.SampleAttachmentInput{Fastq1: &fastq1, Fastq2: &fastq2})
 
@@ -830,7 +899,7 @@ func TestSampleAttachFiles(t *testing.T) {
 		mockLogger, logs := testutils.NewMockLogger(zap.WarnLevel)
 
 		svc := services.NewSampleService(sampleRepo, nil, nil, nil, nil, nil,
-			nil, nil, nil, t.TempDir(), mockLogger)
+			nil, nil, nil, newUploads(t), mockLogger)
 		err := svc.AttachFiles(context.Background(), mock.ID, uuid.Nil,
 			models.SampleAttachmentInput{Fastq1: &fastq1, Fastq2: &fastq2})
 
@@ -857,7 +926,7 @@ func TestSampleAttachFiles(t *testing.T) {
 		mockLogger, logs := testutils.NewMockLogger(zap.WarnLevel)
 
 		svc := services.NewSampleService(sampleRepo, nil, nil, nil, nil, nil,
-			nil, nil, nil, t.TempDir(), mockLogger)
+			nil, nil, nil, newUploads(t), mockLogger)
 		err := svc.AttachFiles(context.Background(), mock.ID, uuid.Nil,
 			models.SampleAttachmentInput{Fastq1: &fastq1, Fastq2: &fastq2})
 
@@ -884,13 +953,156 @@ func TestSampleAttachFiles(t *testing.T) {
 		mockLogger, logs := testutils.NewMockLogger(zap.WarnLevel)
 
 		svc := services.NewSampleService(sampleRepo, nil, nil, nil, nil, nil,
-			nil, nil, nil, t.TempDir(), mockLogger)
+			nil, nil, nil, newUploads(t), mockLogger)
 		err := svc.AttachFiles(context.Background(), mock.ID, uuid.Nil,
 			models.SampleAttachmentInput{Fasta: &fasta})
 
 		assert.NoError(t, err)
 		assert.Equal(t, 1, logs.FilterLevelExact(zapcore.WarnLevel).Len())
 	})
+
+	t.Run("Success - Re-upload Replaces File", func(t *testing.T) {
+		goodFastq1 := "fastq1.fastq"
+		stagedFastq1 := filepath.Join(".upload-1", "fastq1", "fastq1.fastq")
+		rootDir := writeSampleUploads(t, mock, map[string]string{
+			goodFastq1:   "@old1\nACGT\n+\nIIII\n",
+			stagedFastq1: "@new1\nACGT\n+\nIIII\n",
+		})
+
+		var updated *models.Sample
+		sampleRepo := &mocks.MockSampleRepository{
+			GetSampleByIDFunc: func(ctx context.Context,
+				ID uuid.UUID) (*models.Sample, error) {
+				s := mock
+				s.Fastq1, s.Fastq2 = &goodFastq1, nil
+				return &s, nil
+			},
+			UpdateSampleFunc: func(ctx context.Context,
+				sample *models.Sample) error {
+				updated = sample
+				return nil
+			},
+		}
+
+		mockLogger, logs := testutils.NewMockLogger(zap.WarnLevel)
+
+		svc := services.NewSampleService(sampleRepo, nil, nil, nil, nil, nil,
+			nil, nil, nil, rootDir, mockLogger)
+		err := svc.AttachFiles(context.Background(), mock.ID, uuid.Nil,
+			models.SampleAttachmentInput{Fastq1: &stagedFastq1})
+
+		assert.NoError(t, err)
+		assert.Equal(t, 0, logs.Len())
+		if assert.NotNil(t, updated) && assert.NotNil(t, updated.Fastq1) {
+			assert.Equal(t, goodFastq1, *updated.Fastq1)
+		}
+
+		sampleDir := filepath.Join(rootDir, "uploads", "users",
+			mock.UserID.String(), "samples", mock.ID.String())
+		content, readErr := os.ReadFile(filepath.Join(sampleDir, goodFastq1))
+		assert.NoError(t, readErr)
+		assert.Equal(t, "@new1\nACGT\n+\nIIII\n", string(content))
+	})
+
+	t.Run("Error - Invalid Upload Rejected", func(t *testing.T) {
+		goodFastq1, goodFastq2 := "fastq1.fastq", "fastq2.fastq"
+		badFastq1 := filepath.Join(".upload-1", "fastq1", "fastq1.fastq")
+		badFastq2 := filepath.Join(".upload-1", "fastq2", "fastq2.fastq")
+		rootDir := writeSampleUploads(t, mock, map[string]string{
+			goodFastq1: "@read1/1\nACGT\n+\nIIII\n",
+			goodFastq2: "@read1/2\nACGT\n+\nIIII\n",
+			badFastq1:  ">contig_1\nACGT\n",
+			badFastq2:  "@read1\nACGT\n+\nIIII\n",
+		})
+
+		var stored *models.Sample
+		sampleRepo := &mocks.MockSampleRepository{
+			GetSampleByIDFunc: func(ctx context.Context,
+				ID uuid.UUID) (*models.Sample, error) {
+				s := mock
+				s.Fastq1, s.Fastq2 = &goodFastq1, &goodFastq2
+				return &s, nil
+			},
+			UpdateSampleFunc: func(ctx context.Context,
+				sample *models.Sample) error {
+				stored = sample
+				return nil
+			},
+		}
+
+		mockLogger, logs := testutils.NewMockLogger(zap.WarnLevel)
+
+		svc := services.NewSampleService(sampleRepo, nil, nil, nil, nil, nil,
+			nil, nil, nil, rootDir, mockLogger)
+		err := svc.AttachFiles(context.Background(), mock.ID, uuid.Nil,
+			models.SampleAttachmentInput{Fastq1: &badFastq1,
+				Fastq2: &badFastq2})
+
+		assert.ErrorIs(t, err, pipeline.ErrInvalidFormat)
+		assert.Equal(t, 1, logs.FilterLevelExact(zapcore.WarnLevel).Len())
+		if assert.NotNil(t, stored) {
+			assert.Equal(t, &goodFastq1, stored.Fastq1)
+			assert.Equal(t, &goodFastq2, stored.Fastq2)
+
+			var report models.SampleValidationReport
+			assert.NoError(t, json.Unmarshal(stored.ValidationReport,
+				&report))
+			assert.False(t, report.Valid)
+			assert.Contains(t, report.Error, "is a FASTA file")
+		}
+
+		// The files the sample points to are left untouched.
+		sampleDir := filepath.Join(rootDir, "uploads", "users",
+			mock.UserID.String(), "samples", mock.ID.String())
+		content, readErr := os.ReadFile(filepath.Join(sampleDir, goodFastq1))
+		assert.NoError(t, readErr)
+		assert.Equal(t, "@read1/1\nACGT\n+\nIIII\n", string(content))
+	})
+
+	t.Run("Error - Unpaired Reads", func(t *testing.T) {
+		rootDir := writeSampleUploads(t, mock, map[string]string{
+			fastq1: "@read1\nACGT\n+\nIIII\n@read2\nACGT\n+\nIIII\n",
+			fastq2: "@read1\nACGT\n+\nIIII\n",
+		})
+
+		sampleRepo := &mocks.MockSampleRepository{
+			GetSampleByIDFunc: func(ctx context.Context,
+				ID uuid.UUID) (*models.Sample, error) {
+				s := mock
+				return &s, nil
+			},
+			UpdateSampleFunc: func(ctx context.Context,
+				sample *models.Sample) error {
+				return nil
+			},
+		}
+
+		mockLogger, _ := testutils.NewMockLogger(zap.WarnLevel)
+
+		svc := services.NewSampleService(sampleRepo, nil, nil, nil, nil, nil,
+			nil, nil, nil, rootDir, mockLogger)
+		err := svc.AttachFiles(context.Background(), mock.ID, uuid.Nil,
+			models.SampleAttachmentInput{Fastq1: &fastq1, Fastq2: &fastq2})
+
+		assert.ErrorIs(t, err, pipeline.ErrUnpairedReads)
+	})
+}
+
+// writeSampleUploads writes files into the folder of the sample as the upload
+// handler does and returns the root directory.
+func writeSampleUploads(t *testing.T, sample models.Sample,
+	files map[string]string) string {
+	t.Helper()
+	rootDir := t.TempDir()
+	sampleDir := filepath.Join(rootDir, "uploads", "users",
+		sample.UserID.String(), "samples", sample.ID.String())
+	assert.NoError(t, os.MkdirAll(sampleDir, 0755))
+	for name, content := range files {
+		path := filepath.Join(sampleDir, name)
+		assert.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		assert.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	}
+	return rootDir
 }
 
 func TestGetSampleForUpload(t *testing.T) {
@@ -977,6 +1189,10 @@ func TestAttachFilesUsesSampleOwnerID(t *testing.T) {
 		os.MkdirAll(ownerDir, 0755)
 		os.WriteFile(filepath.Join(ownerDir, oldFastq1),
 			[]byte("old content"), 0644)
+		os.WriteFile(filepath.Join(ownerDir, newFastq1),
+			[]byte("@read1\nACGT\n+\nIIII\n"), 0644)
+		os.WriteFile(filepath.Join(ownerDir, newFastq2),
+			[]byte("@read1\nACGT\n+\nIIII\n"), 0644)
 
 		sampleRepo := &mocks.MockSampleRepository{
 			GetSampleByIDFunc: func(ctx context.Context,
@@ -88,6 +88,16 @@ func (s *selectOptionsService) FindAllEnumSelects(ctx context.Context) (
 		})
 	}
 
+	// Read Technologies
+	for _, technology := range models.ReadTechnologies {
+		resp.ReadTechnologies = append(resp.ReadTechnologies,
+			models.SelectOption{
+				Label: "option.read_technology." + strings.ToLower(
+					string(technology)),
+				Value: string(technology),
+			})
+	}
+
 	// Languages
 	for _, lang := range translation.Languages {
 		resp.Languages = append(resp.Languages, models.SelectOption{
@@ -120,11 +130,15 @@ func (s *selectOptionsService) FindAllFormSelects(ctx context.Context,
 	if err != nil {
 		return nil, err
 	}
-	resp.Sequencers = make([]models.SelectOption, len(sequencers))
+	resp.Sequencers = make([]models.SequencerSelectOption, len(sequencers))
 	for i, seq := range sequencers {
-		resp.Sequencers[i] = models.SelectOption{
-			Label: seq.Brand,
-			Value: seq.ID.String(),
+		resp.Sequencers[i] = models.SequencerSelectOption{
+			SelectOption: models.SelectOption{
+				Label: seq.Brand,
+				Value: seq.ID.String(),
+			},
+			ReadTechnology: seq.ReadTechnology,
+			AnalysisTypes:  seq.ReadTechnology.AnalysisTypes(),
 		}
 	}
 
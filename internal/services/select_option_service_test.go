@@ -36,6 +36,12 @@ func TestSelectOptionFindAllEnumSelects(t *testing.T) {
 			{Label: "option.analysis_type.fastqc", Value: "FASTQC"},
 			{Label: "option.analysis_type.genome", Value: "GENOME"},
 			{Label: "option.analysis_type.complete", Value: "COMPLETE"},
+			{Label: "option.analysis_type.long_read", Value: "LONG_READ"},
+			{Label: "option.analysis_type.hybrid", Value: "HYBRID"},
+		},
+		ReadTechnologies: []models.SelectOption{
+			{Label: "option.read_technology.short_read", Value: "SHORT_READ"},
+			{Label: "option.read_technology.long_read", Value: "LONG_READ"},
 		},
 		Languages: []models.SelectOption{
 			{Label: "option.language.pt", Value: "pt"},
@@ -57,6 +63,7 @@ func TestSelectOptionFindAllEnumSelects(t *testing.T) {
 func TestSelectOptionFindAllFormSelects(t *testing.T) {
 	labID := uuid.New()
 	seqID := uuid.New()
+	longSeqID := uuid.New()
 	hsID := uuid.New()
 	originID := uuid.New()
 	microID := uuid.New()
@@ -72,7 +79,10 @@ func TestSelectOptionFindAllFormSelects(t *testing.T) {
 	seqRepo := &mocks.MockSequencerRepository{
 		GetActiveSequencersFunc: func(ctx context.Context) ([]models.Sequencer, error) {
 			return []models.Sequencer{
-				{ID: seqID, Brand: "Illumina"},
+				{ID: seqID, Brand: "Illumina",
+					ReadTechnology: models.ReadTechnologyShort},
+				{ID: longSeqID, Brand: "Oxford Nanopore",
+					ReadTechnology: models.ReadTechnologyLong},
 			}, nil
 		},
 	}
@@ -109,8 +119,26 @@ func TestSelectOptionFindAllFormSelects(t *testing.T) {
 		Laboratories: []models.SelectOption{
 			{Label: "LACEN/RJ", Value: labID.String()},
 		},
-		Sequencers: []models.SelectOption{
-			{Label: "Illumina", Value: seqID.String()},
+		Sequencers: []models.SequencerSelectOption{
+			{
+				SelectOption: models.SelectOption{
+					Label: "Illumina", Value: seqID.String(),
+				},
+				ReadTechnology: models.ReadTechnologyShort,
+				AnalysisTypes: []models.AnalysisType{
+					models.AnalysisTypeFastQC, models.AnalysisTypeGenome,
+					models.AnalysisTypeComplete, models.AnalysisTypeHybrid,
+				},
+			},
+			{
+				SelectOption: models.SelectOption{
+					Label: "Oxford Nanopore", Value: longSeqID.String(),
+				},
+				ReadTechnology: models.ReadTechnologyLong,
+				AnalysisTypes: []models.AnalysisType{
+					models.AnalysisTypeLongRead, models.AnalysisTypeHybrid,
+				},
+			},
 		},
 		HealthServices: []models.SelectOption{
 			{Label: "Hospital Central", Value: hsID.String()},
@@ -99,9 +99,13 @@ func (s *sequencerService) Create(
 	ctx context.Context,
 	input models.SequencerCreateInput) (*models.SequencerAdminTableResponse, error) {
 	sequencer := models.Sequencer{
-		Model:    input.Model,
-		Brand:    input.Brand,
-		IsActive: input.IsActive,
+		Model:          input.Model,
+		Brand:          input.Brand,
+		ReadTechnology: input.ReadTechnology,
+		IsActive:       input.IsActive,
+	}
+	if sequencer.ReadTechnology == "" {
+		sequencer.ReadTechnology = models.ReadTechnologyShort
 	}
 
 	existingSequencer, err := s.Repo.GetSequencerDuplicate(ctx, sequencer.Model, uuid.UUID{})
@@ -160,9 +160,10 @@ func TestSequencerCreate(t *testing.T) {
 		service := services.NewSequencerService(seqRepo, nil)
 
 		expected := models.SequencerAdminTableResponse{
-			Model:    input.Model,
-			Brand:    input.Brand,
-			IsActive: input.IsActive,
+			Model:          input.Model,
+			Brand:          input.Brand,
+			ReadTechnology: models.ReadTechnologyShort,
+			IsActive:       input.IsActive,
 		}
 		result, err := service.Create(context.Background(), input)
 
@@ -170,6 +171,25 @@ func TestSequencerCreate(t *testing.T) {
 		assert.Equal(t, &expected, result)
 	})
 
+	t.Run("Success - Long Read Technology", func(t *testing.T) {
+		var created models.Sequencer
+		seqRepo := &mocks.MockSequencerRepository{
+			CreateSequencerFunc: func(ctx context.Context, sequencer *models.Sequencer) error {
+				created = *sequencer
+				return nil
+			},
+		}
+		service := services.NewSequencerService(seqRepo, nil)
+
+		longReadInput := input
+		longReadInput.ReadTechnology = models.ReadTechnologyLong
+		result, err := service.Create(context.Background(), longReadInput)
+
+		assert.NoError(t, err)
+		assert.Equal(t, models.ReadTechnologyLong, result.ReadTechnology)
+		assert.Equal(t, models.ReadTechnologyLong, created.ReadTechnology)
+	})
+
 	t.Run("Error - Find duplicate", func(t *testing.T) {
 		seqRepo := &mocks.MockSequencerRepository{
 			GetSequencerDuplicateFunc: func(ctx context.Context, model string, ID uuid.UUID) (*models.Sequencer, error) {
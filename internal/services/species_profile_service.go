@@ -0,0 +1,52 @@
+package services
+
+import (
+	"context"
+
+	"github.com/CABGenOrg/cabgen_backend/internal/logging"
+	"github.com/CABGenOrg/cabgen_backend/internal/models"
+	"github.com/CABGenOrg/cabgen_backend/internal/pipeline"
+	"go.uber.org/zap"
+)
+
+type SpeciesProfileService interface {
+	FindAll(ctx context.Context) ([]models.SpeciesProfileResponse, error)
+}
+
+// SpeciesProfileLoader reads the species profiles the workers use.
+type SpeciesProfileLoader func() (*pipeline.SpeciesRegistry, error)
+
+type speciesProfileService struct {
+	Load   SpeciesProfileLoader
+	Logger *zap.Logger
+}
+
+func NewSpeciesProfileService(load SpeciesProfileLoader,
+	logger *zap.Logger) SpeciesProfileService {
+	return &speciesProfileService{
+		Load:   load,
+		Logger: logger,
+	}
+}
+
+// FindAll reloads the profiles so edits and missing databases show up
+// without restarting the server.
+func (s *speciesProfileService) FindAll(ctx context.Context) (
+	[]models.SpeciesProfileResponse, error) {
+	registry, err := s.Load()
+	if err != nil {
+		s.Logger.Error("Service Error", logging.ServiceLogging(
+			"SpeciesProfileService", "FindAll",
+			logging.SpeciesProfileError, err,
+		)...)
+		return nil, ErrInternal
+	}
+
+	checks := registry.Check()
+	profiles := make([]models.SpeciesProfileResponse, 0, len(checks))
+	for _, check := range checks {
+		profiles = append(profiles, models.ToSpeciesProfileResponse(check))
+	}
+
+	return profiles, nil
+}
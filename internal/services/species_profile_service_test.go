@@ -0,0 +1,55 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/CABGenOrg/cabgen_backend/internal/pipeline"
+	"github.com/CABGenOrg/cabgen_backend/internal/services"
+	"github.com/CABGenOrg/cabgen_backend/internal/testutils"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+func TestSpeciesProfileFindAll(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Success", func(t *testing.T) {
+		svc := services.NewSpeciesProfileService(
+			func() (*pipeline.SpeciesRegistry, error) {
+				return &pipeline.SpeciesRegistry{
+					Profiles: []pipeline.SpeciesProfile{
+						{
+							Name:           "Klebsiella pneumoniae",
+							KrakenMatchers: []string{"Klebsiella pneumoniae"},
+						},
+						{Name: "Klebsiella pneumoniae"},
+					},
+				}, nil
+			}, nil)
+
+		result, err := svc.FindAll(ctx)
+
+		assert.NoError(t, err)
+		assert.Len(t, result, 2)
+		assert.True(t, result[0].Valid)
+		assert.Empty(t, result[0].Issues)
+		assert.False(t, result[1].Valid)
+		assert.Contains(t, result[1].Issues, "duplicate profile name")
+	})
+
+	t.Run("Error - Load", func(t *testing.T) {
+		logger, logs := testutils.NewMockLogger(zap.ErrorLevel)
+		svc := services.NewSpeciesProfileService(
+			func() (*pipeline.SpeciesRegistry, error) {
+				return nil, errors.New("no species profiles found")
+			}, logger)
+
+		result, err := svc.FindAll(ctx)
+
+		assert.ErrorIs(t, err, services.ErrInternal)
+		assert.Nil(t, result)
+		assert.Equal(t, 1, logs.Len())
+	})
+}
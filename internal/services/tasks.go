@@ -11,6 +11,9 @@ type TaskEnqueuer interface {
 		opts ...asynq.Option) (*asynq.TaskInfo, error)
 }
 
+// TaskCanceller stops analysis tasks: CancelProcessing cancels the context of
+// an active task and DeleteTask removes one still waiting in its queue.
 type TaskCanceller interface {
 	CancelProcessing(id string) error
+	DeleteTask(queue, id string) error
 }
@@ -0,0 +1,46 @@
+package services
+
+import (
+	"context"
+
+	"github.com/CABGenOrg/cabgen_backend/internal/logging"
+	"github.com/CABGenOrg/cabgen_backend/internal/models"
+	"go.uber.org/zap"
+)
+
+type WorkerEnvironmentReader interface {
+	GetWorkerEnvironments(ctx context.Context) ([]models.WorkerEnvironment,
+		error)
+}
+
+type WorkerEnvironmentService interface {
+	FindAll(ctx context.Context) ([]models.WorkerEnvironment, error)
+}
+
+type workerEnvironmentService struct {
+	Registry WorkerEnvironmentReader
+	Logger   *zap.Logger
+}
+
+func NewWorkerEnvironmentService(registry WorkerEnvironmentReader,
+	logger *zap.Logger) WorkerEnvironmentService {
+	return &workerEnvironmentService{
+		Registry: registry,
+		Logger:   logger,
+	}
+}
+
+// FindAll returns the toolset published by every analysis worker.
+func (s *workerEnvironmentService) FindAll(ctx context.Context) (
+	[]models.WorkerEnvironment, error) {
+	environments, err := s.Registry.GetWorkerEnvironments(ctx)
+	if err != nil {
+		s.Logger.Error("Service Error", logging.ServiceLogging(
+			"WorkerEnvironmentService", "FindAll",
+			logging.WorkerRegistryError, err,
+		)...)
+		return nil, ErrInternal
+	}
+
+	return environments, nil
+}
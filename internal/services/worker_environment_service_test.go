@@ -0,0 +1,53 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/CABGenOrg/cabgen_backend/internal/models"
+	"github.com/CABGenOrg/cabgen_backend/internal/services"
+	"github.com/CABGenOrg/cabgen_backend/internal/testutils"
+	"github.com/CABGenOrg/cabgen_backend/internal/testutils/mocks"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+func TestWorkerEnvironmentFindAll(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Success", func(t *testing.T) {
+		environments := []models.WorkerEnvironment{
+			{ID: "worker:1", Status: models.WorkerStatusDegraded},
+		}
+		registry := &mocks.MockWorkerEnvironmentReader{
+			GetWorkerEnvironmentsFunc: func(ctx context.Context) (
+				[]models.WorkerEnvironment, error) {
+				return environments, nil
+			},
+		}
+
+		svc := services.NewWorkerEnvironmentService(registry, zap.NewNop())
+		result, err := svc.FindAll(ctx)
+
+		assert.NoError(t, err)
+		assert.Equal(t, environments, result)
+	})
+
+	t.Run("Error - Registry", func(t *testing.T) {
+		registry := &mocks.MockWorkerEnvironmentReader{
+			GetWorkerEnvironmentsFunc: func(ctx context.Context) (
+				[]models.WorkerEnvironment, error) {
+				return nil, errors.New("connection refused")
+			},
+		}
+		logger, logs := testutils.NewMockLogger(zap.ErrorLevel)
+
+		svc := services.NewWorkerEnvironmentService(registry, logger)
+		result, err := svc.FindAll(ctx)
+
+		assert.ErrorIs(t, err, services.ErrInternal)
+		assert.Nil(t, result)
+		assert.Equal(t, 1, logs.Len())
+	})
+}
@@ -0,0 +1,31 @@
+package data
+
+import (
+	"strings"
+
+	"github.com/CABGenOrg/cabgen_backend/internal/testutils"
+)
+
+var baseQCRuleCreateBody = map[string]any{
+	"metric":    "completeness",
+	"min":       90,
+	"severity":  "FAIL",
+	"is_active": true,
+}
+
+var CreateQCRuleTests = []Body{
+	{"Missing metric", testutils.ToJSON(func() map[string]any { b := testutils.CopyMap(baseQCRuleCreateBody); b["metric"] = ""; return b }()), `{"error":"QC metric is required."}`},
+	{"Species too long", testutils.ToJSON(func() map[string]any {
+		b := testutils.CopyMap(baseQCRuleCreateBody)
+		b["species"] = strings.Repeat("A", 256)
+		return b
+	}()), `{"error":"Species must be at most 255 characters long."}`},
+}
+
+var UpdateQCRuleTests = []Body{
+	{"Species too long", testutils.ToJSON(func() map[string]any {
+		b := testutils.CopyMap(baseQCRuleCreateBody)
+		b["species"] = strings.Repeat("A", 256)
+		return b
+	}()), `{"error":"Species must be at most 255 characters long."}`},
+}
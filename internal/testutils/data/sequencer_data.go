@@ -27,6 +27,11 @@ var CreateSequencerTests = []Body{
 		b["model"] = strings.Repeat("A", 256)
 		return b
 	}()), `{"error":"Sequencer model must have a maximum of 255 characters."}`},
+	{"Invalid read technology", testutils.ToJSON(func() map[string]any {
+		b := testutils.CopyMap(baseSequencerCreateBody)
+		b["read_technology"] = "SANGER"
+		return b
+	}()), `{"error":"Invalid read technology. Use SHORT_READ or LONG_READ."}`},
 }
 
 var UpdateSequencerTests = []Body{
@@ -42,4 +47,9 @@ var UpdateSequencerTests = []Body{
 		b["model"] = strings.Repeat("A", 256)
 		return b
 	}()), `{"error":"Sequencer model must have a maximum of 255 characters."}`},
+	{"Invalid read technology", testutils.ToJSON(func() map[string]any {
+		b := testutils.CopyMap(baseSequencerCreateBody)
+		b["read_technology"] = "SANGER"
+		return b
+	}()), `{"error":"Invalid read technology. Use SHORT_READ or LONG_READ."}`},
 }
@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"github.com/CABGenOrg/cabgen_backend/internal/models"
+	"github.com/CABGenOrg/cabgen_backend/internal/pipeline"
 	"github.com/google/uuid"
 )
 
@@ -14,14 +15,31 @@ type MockAnalysisRepository struct {
 		userID uuid.UUID) ([]models.Analysis, error)
 	GetAnalysisByIDFunc func(ctx context.Context, analysisID uuid.UUID) (
 		*models.Analysis, error)
+	GetAnalysesByStatusFunc func(ctx context.Context,
+		status models.AnalysisStatus) ([]models.Analysis, error)
 	CreateAnalysisFunc func(ctx context.Context,
 		analysis *models.Analysis) error
 	UpdateAnalysisFunc func(ctx context.Context,
 		analysis *models.Analysis) error
+	UpdateAnalysisIfStatusFunc func(ctx context.Context,
+		analysisID uuid.UUID, statuses []models.AnalysisStatus,
+		fields map[string]any) (bool, error)
+	SaveAnalysisIfStatusFunc func(ctx context.Context,
+		analysis *models.Analysis, statuses []models.AnalysisStatus) (
+		bool, error)
 	UpdateSampleFunc func(ctx context.Context,
 		sample *models.Sample) error
 	DeleteAnalysisFunc func(ctx context.Context,
 		analysis *models.Analysis) error
+	CreateAnalysisLogFunc func(ctx context.Context,
+		log *models.AnalysisLog) error
+	GetAnalysisLogsFunc func(ctx context.Context, analysisID uuid.UUID,
+		step models.AnalysisStep) ([]models.AnalysisLog, error)
+	ReplaceAnalysisDatabasesFunc func(ctx context.Context,
+		analysisID uuid.UUID, databases []models.AnalysisDatabase) error
+	GetOutdatedAnalysisDatabasesFunc func(ctx context.Context,
+		current map[string][]string) ([]models.AnalysisDatabase, error)
+	GetActiveQCRulesFunc func(ctx context.Context) ([]models.QCRule, error)
 }
 
 func (r *MockAnalysisRepository) GetAnalyses(ctx context.Context,
@@ -51,6 +69,15 @@ func (r *MockAnalysisRepository) GetAnalysisByID(ctx context.Context,
 	return nil, nil
 }
 
+func (r *MockAnalysisRepository) GetAnalysesByStatus(ctx context.Context,
+	status models.AnalysisStatus) ([]models.Analysis, error) {
+	if r.GetAnalysesByStatusFunc != nil {
+		return r.GetAnalysesByStatusFunc(ctx, status)
+	}
+
+	return nil, nil
+}
+
 func (r *MockAnalysisRepository) CreateAnalysis(ctx context.Context,
 	analysis *models.Analysis) error {
 	if r.CreateAnalysisFunc != nil {
@@ -69,6 +96,26 @@ func (r *MockAnalysisRepository) UpdateAnalysis(ctx context.Context,
 	return nil
 }
 
+func (r *MockAnalysisRepository) UpdateAnalysisIfStatus(
+	ctx context.Context, analysisID uuid.UUID,
+	statuses []models.AnalysisStatus, fields map[string]any) (bool, error) {
+	if r.UpdateAnalysisIfStatusFunc != nil {
+		return r.UpdateAnalysisIfStatusFunc(ctx, analysisID, statuses, fields)
+	}
+
+	return true, nil
+}
+
+func (r *MockAnalysisRepository) SaveAnalysisIfStatus(ctx context.Context,
+	analysis *models.Analysis, statuses []models.AnalysisStatus) (
+	bool, error) {
+	if r.SaveAnalysisIfStatusFunc != nil {
+		return r.SaveAnalysisIfStatusFunc(ctx, analysis, statuses)
+	}
+
+	return true, nil
+}
+
 func (r *MockAnalysisRepository) UpdateSample(ctx context.Context,
 	sample *models.Sample) error {
 	if r.UpdateSampleFunc != nil {
@@ -87,6 +134,54 @@ func (r *MockAnalysisRepository) DeleteAnalysis(ctx context.Context,
 	return nil
 }
 
+func (r *MockAnalysisRepository) CreateAnalysisLog(ctx context.Context,
+	log *models.AnalysisLog) error {
+	if r.CreateAnalysisLogFunc != nil {
+		return r.CreateAnalysisLogFunc(ctx, log)
+	}
+
+	return nil
+}
+
+func (r *MockAnalysisRepository) GetAnalysisLogs(ctx context.Context,
+	analysisID uuid.UUID, step models.AnalysisStep) (
+	[]models.AnalysisLog, error) {
+	if r.GetAnalysisLogsFunc != nil {
+		return r.GetAnalysisLogsFunc(ctx, analysisID, step)
+	}
+
+	return nil, nil
+}
+
+func (r *MockAnalysisRepository) ReplaceAnalysisDatabases(
+	ctx context.Context, analysisID uuid.UUID,
+	databases []models.AnalysisDatabase) error {
+	if r.ReplaceAnalysisDatabasesFunc != nil {
+		return r.ReplaceAnalysisDatabasesFunc(ctx, analysisID, databases)
+	}
+
+	return nil
+}
+
+func (r *MockAnalysisRepository) GetOutdatedAnalysisDatabases(
+	ctx context.Context, current map[string][]string) (
+	[]models.AnalysisDatabase, error) {
+	if r.GetOutdatedAnalysisDatabasesFunc != nil {
+		return r.GetOutdatedAnalysisDatabasesFunc(ctx, current)
+	}
+
+	return nil, nil
+}
+
+func (r *MockAnalysisRepository) GetActiveQCRules(ctx context.Context) (
+	[]models.QCRule, error) {
+	if r.GetActiveQCRulesFunc != nil {
+		return r.GetActiveQCRulesFunc(ctx)
+	}
+
+	return nil, nil
+}
+
 type MockAnalysisService struct {
 	FindAllFunc func(ctx context.Context, userID uuid.UUID,
 		filter models.AnalysisFilter, language string) (
@@ -100,7 +195,18 @@ type MockAnalysisService struct {
 	UpdateFunc func(ctx context.Context, analysisID uuid.UUID,
 		input models.AdminAnalysisUpdateInput, language string) (
 		*models.AnalysisResponse, error)
-	DeleteFunc      func(ctx context.Context, analysisID, userID uuid.UUID) error
+	DeleteFunc func(ctx context.Context, analysisID, userID uuid.UUID) error
+	ResumeFunc func(ctx context.Context, analysisID, userID uuid.UUID,
+		language string) (*models.AnalysisResponse, error)
+	CancelFunc func(ctx context.Context, analysisID, userID uuid.UUID,
+		language string) (*models.AnalysisResponse, error)
+	FindLogsFunc func(ctx context.Context, analysisID, userID uuid.UUID,
+		step models.AnalysisStep, withOutput bool) (
+		[]models.AnalysisLogResponse, error)
+	FindTaxonomyFunc func(ctx context.Context, analysisID,
+		userID uuid.UUID) (*pipeline.KrakenComposition, error)
+	FindOutdatedDatabasesFunc func(ctx context.Context, language string) (
+		[]models.OutdatedAnalysisResponse, error)
 	DownloadZipFunc func(ctx context.Context, analysisID,
 		userID uuid.UUID) (string, error)
 	DownloadBatchTSVFunc func(ctx context.Context, analysisIDs []uuid.UUID,
@@ -166,6 +272,52 @@ func (s *MockAnalysisService) Delete(ctx context.Context, analysisID,
 	return nil
 }
 
+func (s *MockAnalysisService) Resume(ctx context.Context, analysisID,
+	userID uuid.UUID, language string) (*models.AnalysisResponse, error) {
+	if s.ResumeFunc != nil {
+		return s.ResumeFunc(ctx, analysisID, userID, language)
+	}
+
+	return nil, nil
+}
+
+func (s *MockAnalysisService) Cancel(ctx context.Context, analysisID,
+	userID uuid.UUID, language string) (*models.AnalysisResponse, error) {
+	if s.CancelFunc != nil {
+		return s.CancelFunc(ctx, analysisID, userID, language)
+	}
+
+	return nil, nil
+}
+
+func (s *MockAnalysisService) FindLogs(ctx context.Context, analysisID,
+	userID uuid.UUID, step models.AnalysisStep, withOutput bool) (
+	[]models.AnalysisLogResponse, error) {
+	if s.FindLogsFunc != nil {
+		return s.FindLogsFunc(ctx, analysisID, userID, step, withOutput)
+	}
+
+	return nil, nil
+}
+
+func (s *MockAnalysisService) FindTaxonomy(ctx context.Context, analysisID,
+	userID uuid.UUID) (*pipeline.KrakenComposition, error) {
+	if s.FindTaxonomyFunc != nil {
+		return s.FindTaxonomyFunc(ctx, analysisID, userID)
+	}
+
+	return nil, nil
+}
+
+func (s *MockAnalysisService) FindOutdatedDatabases(ctx context.Context,
+	language string) ([]models.OutdatedAnalysisResponse, error) {
+	if s.FindOutdatedDatabasesFunc != nil {
+		return s.FindOutdatedDatabasesFunc(ctx, language)
+	}
+
+	return nil, nil
+}
+
 func (s *MockAnalysisService) DownloadZip(ctx context.Context, analysisID,
 	userID uuid.UUID) (string, error) {
 	if s.DownloadZipFunc != nil {
@@ -0,0 +1,72 @@
+package mocks
+
+import (
+	"context"
+	"sync"
+
+	"github.com/CABGenOrg/cabgen_backend/internal/models"
+	"github.com/google/uuid"
+)
+
+type MockAnalysisEventPublisher struct {
+	PublishAnalysisEventFunc func(ctx context.Context,
+		event models.AnalysisEvent) error
+
+	mu     sync.Mutex
+	Events []models.AnalysisEvent
+}
+
+func (m *MockAnalysisEventPublisher) PublishAnalysisEvent(ctx context.Context,
+	event models.AnalysisEvent) error {
+	m.mu.Lock()
+	m.Events = append(m.Events, event)
+	m.mu.Unlock()
+
+	if m.PublishAnalysisEventFunc != nil {
+		return m.PublishAnalysisEventFunc(ctx, event)
+	}
+
+	return nil
+}
+
+type MockAnalysisEventSubscriber struct {
+	SubscribeAnalysisEventsFunc func(ctx context.Context, userID uuid.UUID) (
+		<-chan models.AnalysisEvent, func() error, error)
+}
+
+func (m *MockAnalysisEventSubscriber) SubscribeAnalysisEvents(
+	ctx context.Context, userID uuid.UUID) (<-chan models.AnalysisEvent,
+	func() error, error) {
+	if m.SubscribeAnalysisEventsFunc != nil {
+		return m.SubscribeAnalysisEventsFunc(ctx, userID)
+	}
+
+	events := make(chan models.AnalysisEvent)
+	close(events)
+	return events, func() error { return nil }, nil
+}
+
+type MockAnalysisEventService struct {
+	SubscribeAnalysisFunc func(ctx context.Context, analysisID,
+		userID uuid.UUID) (<-chan models.AnalysisEvent, error)
+	SubscribeUserFunc func(ctx context.Context, userID uuid.UUID) (
+		<-chan models.AnalysisEvent, error)
+}
+
+func (s *MockAnalysisEventService) SubscribeAnalysis(ctx context.Context,
+	analysisID, userID uuid.UUID) (<-chan models.AnalysisEvent, error) {
+	if s.SubscribeAnalysisFunc != nil {
+		return s.SubscribeAnalysisFunc(ctx, analysisID, userID)
+	}
+
+	return nil, nil
+}
+
+func (s *MockAnalysisEventService) SubscribeUser(ctx context.Context,
+	userID uuid.UUID) (<-chan models.AnalysisEvent, error) {
+	if s.SubscribeUserFunc != nil {
+		return s.SubscribeUserFunc(ctx, userID)
+	}
+
+	return nil, nil
+}
@@ -7,7 +7,8 @@ import (
 )
 
 type MockAnalysisRunnerService struct {
-	RunFunc func(ctx context.Context, analysisID uuid.UUID) error
+	RunFunc    func(ctx context.Context, analysisID uuid.UUID) error
+	ResumeFunc func(ctx context.Context, analysisID uuid.UUID) error
 }
 
 func (s *MockAnalysisRunnerService) Run(ctx context.Context,
@@ -16,4 +17,12 @@ func (s *MockAnalysisRunnerService) Run(ctx context.Context,
 		return s.RunFunc(ctx, analysisID)
 	}
 	return nil
-}
\ No newline at end of file
+}
+
+func (s *MockAnalysisRunnerService) Resume(ctx context.Context,
+	analysisID uuid.UUID) error {
+	if s.ResumeFunc != nil {
+		return s.ResumeFunc(ctx, analysisID)
+	}
+	return nil
+}
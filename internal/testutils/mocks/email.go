@@ -10,12 +10,14 @@ import (
 
 type MockEmailSender struct {
 	ShouldFail bool
+	Sent       []*gomail.Message
 }
 
 func (m *MockEmailSender) Send(msg *gomail.Message) error {
 	if m.ShouldFail {
 		return errors.New("simulated send error")
 	}
+	m.Sent = append(m.Sent, msg)
 	return nil
 }
 
@@ -0,0 +1,43 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/CABGenOrg/cabgen_backend/internal/models"
+	"github.com/google/uuid"
+)
+
+type MockAnalysisHeartbeats struct {
+	BeatFunc func(ctx context.Context, analysisID uuid.UUID,
+		step models.AnalysisStep) error
+	RemoveHeartbeatFunc func(ctx context.Context, analysisID uuid.UUID) error
+	GetHeartbeatsFunc   func(ctx context.Context) (
+		map[uuid.UUID]models.AnalysisHeartbeat, error)
+}
+
+func (m *MockAnalysisHeartbeats) Beat(ctx context.Context,
+	analysisID uuid.UUID, step models.AnalysisStep) error {
+	if m.BeatFunc != nil {
+		return m.BeatFunc(ctx, analysisID, step)
+	}
+
+	return nil
+}
+
+func (m *MockAnalysisHeartbeats) RemoveHeartbeat(ctx context.Context,
+	analysisID uuid.UUID) error {
+	if m.RemoveHeartbeatFunc != nil {
+		return m.RemoveHeartbeatFunc(ctx, analysisID)
+	}
+
+	return nil
+}
+
+func (m *MockAnalysisHeartbeats) GetHeartbeats(ctx context.Context) (
+	map[uuid.UUID]models.AnalysisHeartbeat, error) {
+	if m.GetHeartbeatsFunc != nil {
+		return m.GetHeartbeatsFunc(ctx)
+	}
+
+	return nil, nil
+}
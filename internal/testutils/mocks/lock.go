@@ -0,0 +1,21 @@
+package mocks
+
+import "context"
+
+type MockLocker struct {
+	TryLockFunc func(ctx context.Context) (
+		func(context.Context) error, bool, error)
+	Unlocked bool
+}
+
+func (m *MockLocker) TryLock(ctx context.Context) (
+	func(context.Context) error, bool, error) {
+	if m.TryLockFunc != nil {
+		return m.TryLockFunc(ctx)
+	}
+
+	return func(context.Context) error {
+		m.Unlocked = true
+		return nil
+	}, true, nil
+}
@@ -9,17 +9,20 @@ import (
 type MockToolRunner struct {
 	RunFunc func(ctx context.Context, args []string) (string, error)
 
-	BuildBlastXCmdFunc        func(blastDB, inputFile, outputFile string) []string
+	BuildBlastXCmdFunc        func(blastDB, inputFile, outputFile string, params pipeline.BlastXParameters) []string
+	BuildBlastNCmdFunc        func(blastDB, inputFile, outputFile string, params pipeline.BlastNParameters) []string
 	BuildFastQCCmdFunc        func(fastqcCmd, read1, read2, outputDir string) []string
-	BuildUnicyclerCmdFunc     func(unicyclerCmd, read1, read2, outputDir, threads, spadesPath string) []string
+	BuildUnicyclerCmdFunc     func(unicyclerCmd string, reads pipeline.UnicyclerReads, outputDir, threads, spadesPath string, params pipeline.UnicyclerParameters) []string
 	BuildProkkaCmdFunc        func(prokkaCmd, outputDir, prefix, assemblyPath, threads string) []string
 	BuildCheckMLineageCmdFunc func(checkmCmd, inputDir, outputDir, threads string) []string
 	BuildCheckMQACmdFunc      func(checkmCmd, checkmDir, sample, threads string) []string
 	BuildKraken2CmdFunc       func(krakenCmd, dbPath, outputDir, threads, assemblyPath string) []string
 	BuildSplitterCmdFunc      func(threads, inputFile, outputFilePrefix string) []string
 	BuildFastANICmdFunc       func(fastaniCmd, query, refList, output, threads string) []string
-	BuildAbricateCmdFunc      func(abricateCmd, db, inputFile, outputFile, threads string) []string
-	BuildMLSTCmdFunc          func(mlstCmd, threads, assemblyPath, outputFile string) []string
+	BuildAbricateCmdFunc      func(abricateCmd, db, inputFile, threads string) []string
+	BuildAMRFinderCmdFunc     func(amrfinderCmd, assemblyPath, organism, dbPath, outputFile, threads string) []string
+	BuildMLSTCmdFunc          func(mlstCmd, threads, assemblyPath string,
+		exclude []string) []string
 }
 
 func (m *MockToolRunner) Run(ctx context.Context, args []string) (string, error) {
@@ -30,9 +33,17 @@ func (m *MockToolRunner) Run(ctx context.Context, args []string) (string, error)
 }
 
 func (m *MockToolRunner) BuildBlastXCmd(blastDB, inputFile,
-	outputFile string) []string {
+	outputFile string, params pipeline.BlastXParameters) []string {
 	if m.BuildBlastXCmdFunc != nil {
-		return m.BuildBlastXCmdFunc(blastDB, inputFile, outputFile)
+		return m.BuildBlastXCmdFunc(blastDB, inputFile, outputFile, params)
+	}
+	return nil
+}
+
+func (m *MockToolRunner) BuildBlastNCmd(blastDB, inputFile,
+	outputFile string, params pipeline.BlastNParameters) []string {
+	if m.BuildBlastNCmdFunc != nil {
+		return m.BuildBlastNCmdFunc(blastDB, inputFile, outputFile, params)
 	}
 	return nil
 }
@@ -45,11 +56,12 @@ func (m *MockToolRunner) BuildFastQCCmd(fastqcCmd, read1, read2,
 	return nil
 }
 
-func (m *MockToolRunner) BuildUnicyclerCmd(unicyclerCmd, read1, read2,
-	outputDir, threads, spadesPath string) []string {
+func (m *MockToolRunner) BuildUnicyclerCmd(unicyclerCmd string,
+	reads pipeline.UnicyclerReads, outputDir, threads, spadesPath string,
+	params pipeline.UnicyclerParameters) []string {
 	if m.BuildUnicyclerCmdFunc != nil {
-		return m.BuildUnicyclerCmdFunc(unicyclerCmd, read1, read2, outputDir,
-			threads, spadesPath)
+		return m.BuildUnicyclerCmdFunc(unicyclerCmd, reads, outputDir,
+			threads, spadesPath, params)
 	}
 	return nil
 }
@@ -107,18 +119,26 @@ func (m *MockToolRunner) BuildFastANICmd(fastaniCmd, query, refList,
 }
 
 func (m *MockToolRunner) BuildAbricateCmd(abricateCmd, db, inputFile,
-	outputFile, threads string) []string {
+	threads string) []string {
 	if m.BuildAbricateCmdFunc != nil {
-		return m.BuildAbricateCmdFunc(abricateCmd, db, inputFile, outputFile,
-			threads)
+		return m.BuildAbricateCmdFunc(abricateCmd, db, inputFile, threads)
 	}
 	return nil
 }
 
-func (m *MockToolRunner) BuildMLSTCmd(mlstCmd, threads, assemblyPath,
-	outputFile string) []string {
+func (m *MockToolRunner) BuildAMRFinderCmd(amrfinderCmd, assemblyPath,
+	organism, dbPath, outputFile, threads string) []string {
+	if m.BuildAMRFinderCmdFunc != nil {
+		return m.BuildAMRFinderCmdFunc(amrfinderCmd, assemblyPath, organism,
+			dbPath, outputFile, threads)
+	}
+	return nil
+}
+
+func (m *MockToolRunner) BuildMLSTCmd(mlstCmd, threads,
+	assemblyPath string, exclude []string) []string {
 	if m.BuildMLSTCmdFunc != nil {
-		return m.BuildMLSTCmdFunc(mlstCmd, threads, assemblyPath, outputFile)
+		return m.BuildMLSTCmdFunc(mlstCmd, threads, assemblyPath, exclude)
 	}
 	return nil
 }
@@ -128,18 +148,22 @@ type MockCabgenPipeline struct {
 	RunFastQCFunc func(ctx context.Context, read1, read2,
 		outputDir string) (string, string, error)
 	RunUnicyclerFunc func(ctx context.Context, threads int,
-		read1, read2, spadesPath, outputDir, outputFile string) (string, error)
+		reads pipeline.UnicyclerReads, spadesPath, outputDir,
+		outputFile string) (string, error)
 	RunProkkaFunc func(ctx context.Context, threads int,
 		assembly, outputDir string) error
 	RunCheckMFunc func(ctx context.Context, threads int, sample,
 		assemblyDir, outputDir string) (*pipeline.CheckMResult, error)
 	RunKraken2Func func(ctx context.Context, threads int,
-		assembly, outputDir string) (*pipeline.KrakenSpecies,
-		*pipeline.KrakenSpecies, error)
+		assembly, outputDir string) (*pipeline.KrakenComposition, error)
 	RunBlastXFunc func(ctx context.Context, query, DB,
 		outputFile string) error
+	RunBlastNFunc func(ctx context.Context, query, DB,
+		outputFile string) error
 	RunAbricateFunc func(ctx context.Context, threads int, db, input,
 		outputFile string) error
+	RunAMRFinderFunc func(ctx context.Context, threads int, assembly,
+		organism, outputFile string) error
 	ProcessSpeciesFunc func(ctx context.Context, threads int,
 		sampleID, mostCommon, assemblyPath, outputDir string) (
 		*pipeline.SpeciesResult, error)
@@ -158,9 +182,10 @@ func (m *MockCabgenPipeline) RunFastQC(ctx context.Context, read1, read2,
 }
 
 func (m *MockCabgenPipeline) RunUnicycler(ctx context.Context, threads int,
-	read1, read2, spadesPath, outputDir, outputFile string) (string, error) {
+	reads pipeline.UnicyclerReads, spadesPath, outputDir,
+	outputFile string) (string, error) {
 	if m.RunUnicyclerFunc != nil {
-		return m.RunUnicyclerFunc(ctx, threads, read1, read2, spadesPath,
+		return m.RunUnicyclerFunc(ctx, threads, reads, spadesPath,
 			outputDir, outputFile)
 	}
 	return "assembly.fasta", nil
@@ -186,13 +211,23 @@ func (m *MockCabgenPipeline) RunCheckM(ctx context.Context, threads int,
 }
 
 func (m *MockCabgenPipeline) RunKraken2(ctx context.Context, threads int,
-	assembly, outputDir string) (*pipeline.KrakenSpecies,
-	*pipeline.KrakenSpecies, error) {
+	assembly, outputDir string) (*pipeline.KrakenComposition, error) {
 	if m.RunKraken2Func != nil {
 		return m.RunKraken2Func(ctx, threads, assembly, outputDir)
 	}
-	return &pipeline.KrakenSpecies{Name: "Escherichia coli", Count: 100},
-		&pipeline.KrakenSpecies{Name: "Klebsiella pneumoniae", Count: 5}, nil
+	return &pipeline.KrakenComposition{
+		Species: []pipeline.KrakenTaxon{
+			{Name: "Escherichia coli", TaxID: 562, CladeReads: 100,
+				Percent: 95.24},
+			{Name: "Klebsiella pneumoniae", TaxID: 573, CladeReads: 5,
+				Percent: 4.76},
+		},
+		Genus: []pipeline.KrakenTaxon{
+			{Name: "Escherichia", TaxID: 561, CladeReads: 100,
+				Percent: 95.24},
+			{Name: "Klebsiella", TaxID: 570, CladeReads: 5, Percent: 4.76},
+		},
+	}, nil
 }
 
 func (m *MockCabgenPipeline) RunBlastX(ctx context.Context, query, DB,
@@ -203,6 +238,14 @@ func (m *MockCabgenPipeline) RunBlastX(ctx context.Context, query, DB,
 	return nil
 }
 
+func (m *MockCabgenPipeline) RunBlastN(ctx context.Context, query, DB,
+	outputFile string) error {
+	if m.RunBlastNFunc != nil {
+		return m.RunBlastNFunc(ctx, query, DB, outputFile)
+	}
+	return nil
+}
+
 func (m *MockCabgenPipeline) RunAbricate(ctx context.Context, threads int,
 	db, input, outputFile string) error {
 	if m.RunAbricateFunc != nil {
@@ -211,6 +254,15 @@ func (m *MockCabgenPipeline) RunAbricate(ctx context.Context, threads int,
 	return nil
 }
 
+func (m *MockCabgenPipeline) RunAMRFinder(ctx context.Context, threads int,
+	assembly, organism, outputFile string) error {
+	if m.RunAMRFinderFunc != nil {
+		return m.RunAMRFinderFunc(ctx, threads, assembly, organism,
+			outputFile)
+	}
+	return nil
+}
+
 func (m *MockCabgenPipeline) ProcessSpecies(ctx context.Context, threads int,
 	sampleID, mostCommon, assemblyPath, outputDir string) (
 	*pipeline.SpeciesResult, error) {
@@ -0,0 +1,94 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/CABGenOrg/cabgen_backend/internal/models"
+	"github.com/google/uuid"
+)
+
+type MockQCRuleRepository struct {
+	GetQCRulesFunc    func(ctx context.Context) ([]models.QCRule, error)
+	GetQCRuleByIDFunc func(ctx context.Context, ID uuid.UUID) (*models.QCRule, error)
+	CreateQCRuleFunc  func(ctx context.Context, rule *models.QCRule) error
+	UpdateQCRuleFunc  func(ctx context.Context, rule *models.QCRule) error
+	DeleteQCRuleFunc  func(ctx context.Context, rule *models.QCRule) error
+}
+
+func (r *MockQCRuleRepository) GetQCRules(ctx context.Context) ([]models.QCRule, error) {
+	if r.GetQCRulesFunc != nil {
+		return r.GetQCRulesFunc(ctx)
+	}
+	return nil, nil
+}
+
+func (r *MockQCRuleRepository) GetQCRuleByID(ctx context.Context, ID uuid.UUID) (*models.QCRule, error) {
+	if r.GetQCRuleByIDFunc != nil {
+		return r.GetQCRuleByIDFunc(ctx, ID)
+	}
+	return nil, nil
+}
+
+func (r *MockQCRuleRepository) CreateQCRule(ctx context.Context, rule *models.QCRule) error {
+	if r.CreateQCRuleFunc != nil {
+		return r.CreateQCRuleFunc(ctx, rule)
+	}
+	return nil
+}
+
+func (r *MockQCRuleRepository) UpdateQCRule(ctx context.Context, rule *models.QCRule) error {
+	if r.UpdateQCRuleFunc != nil {
+		return r.UpdateQCRuleFunc(ctx, rule)
+	}
+	return nil
+}
+
+func (r *MockQCRuleRepository) DeleteQCRule(ctx context.Context, rule *models.QCRule) error {
+	if r.DeleteQCRuleFunc != nil {
+		return r.DeleteQCRuleFunc(ctx, rule)
+	}
+	return nil
+}
+
+type MockQCRuleService struct {
+	FindAllFunc  func(ctx context.Context) ([]models.QCRuleAdminTableResponse, error)
+	FindByIDFunc func(ctx context.Context, ID uuid.UUID) (*models.QCRuleAdminTableResponse, error)
+	CreateFunc   func(ctx context.Context, input models.QCRuleCreateInput) (*models.QCRuleAdminTableResponse, error)
+	UpdateFunc   func(ctx context.Context, ID uuid.UUID, input models.QCRuleUpdateInput) (*models.QCRuleAdminTableResponse, error)
+	DeleteFunc   func(ctx context.Context, ID uuid.UUID) error
+}
+
+func (s *MockQCRuleService) FindAll(ctx context.Context) ([]models.QCRuleAdminTableResponse, error) {
+	if s.FindAllFunc != nil {
+		return s.FindAllFunc(ctx)
+	}
+	return nil, nil
+}
+
+func (s *MockQCRuleService) FindByID(ctx context.Context, ID uuid.UUID) (*models.QCRuleAdminTableResponse, error) {
+	if s.FindByIDFunc != nil {
+		return s.FindByIDFunc(ctx, ID)
+	}
+	return nil, nil
+}
+
+func (s *MockQCRuleService) Create(ctx context.Context, input models.QCRuleCreateInput) (*models.QCRuleAdminTableResponse, error) {
+	if s.CreateFunc != nil {
+		return s.CreateFunc(ctx, input)
+	}
+	return nil, nil
+}
+
+func (s *MockQCRuleService) Update(ctx context.Context, ID uuid.UUID, input models.QCRuleUpdateInput) (*models.QCRuleAdminTableResponse, error) {
+	if s.UpdateFunc != nil {
+		return s.UpdateFunc(ctx, ID, input)
+	}
+	return nil, nil
+}
+
+func (s *MockQCRuleService) Delete(ctx context.Context, ID uuid.UUID) error {
+	if s.DeleteFunc != nil {
+		return s.DeleteFunc(ctx, ID)
+	}
+	return nil
+}
@@ -0,0 +1,21 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/CABGenOrg/cabgen_backend/internal/models"
+)
+
+type MockSpeciesProfileService struct {
+	FindAllFunc func(ctx context.Context) ([]models.SpeciesProfileResponse,
+		error)
+}
+
+func (s *MockSpeciesProfileService) FindAll(ctx context.Context) (
+	[]models.SpeciesProfileResponse, error) {
+	if s.FindAllFunc != nil {
+		return s.FindAllFunc(ctx)
+	}
+
+	return nil, nil
+}
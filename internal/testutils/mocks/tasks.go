@@ -22,9 +22,11 @@ func (m *MockTaskEnqueuer) EnqueueContext(ctx context.Context,
 
 type MockTaskCanceller struct {
 	CancelProcessingFunc func(id string) error
+	DeleteTaskFunc       func(queue, id string) error
 	Called               bool
 	CalledWith           string
 	CallCount            int
+	DeleteCalledWith     string
 }
 
 func (m *MockTaskCanceller) CancelProcessing(id string) error {
@@ -36,3 +38,11 @@ func (m *MockTaskCanceller) CancelProcessing(id string) error {
 	}
 	return nil
 }
+
+func (m *MockTaskCanceller) DeleteTask(queue, id string) error {
+	m.DeleteCalledWith = id
+	if m.DeleteTaskFunc != nil {
+		return m.DeleteTaskFunc(queue, id)
+	}
+	return nil
+}
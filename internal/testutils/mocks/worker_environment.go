@@ -0,0 +1,34 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/CABGenOrg/cabgen_backend/internal/models"
+)
+
+type MockWorkerEnvironmentReader struct {
+	GetWorkerEnvironmentsFunc func(ctx context.Context) (
+		[]models.WorkerEnvironment, error)
+}
+
+func (m *MockWorkerEnvironmentReader) GetWorkerEnvironments(
+	ctx context.Context) ([]models.WorkerEnvironment, error) {
+	if m.GetWorkerEnvironmentsFunc != nil {
+		return m.GetWorkerEnvironmentsFunc(ctx)
+	}
+
+	return nil, nil
+}
+
+type MockWorkerEnvironmentService struct {
+	FindAllFunc func(ctx context.Context) ([]models.WorkerEnvironment, error)
+}
+
+func (s *MockWorkerEnvironmentService) FindAll(ctx context.Context) (
+	[]models.WorkerEnvironment, error) {
+	if s.FindAllFunc != nil {
+		return s.FindAllFunc(ctx)
+	}
+
+	return nil, nil
+}
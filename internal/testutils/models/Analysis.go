@@ -18,11 +18,20 @@ type Analysis struct {
 	Step   string                 `gorm:"type:varchar(20);default:''"`
 	TaskID *string                `gorm:"type:varchar(255)"`
 
+	// Parameters
+	Parameters datatypes.JSON `gorm:"type:jsonb"`
+
 	// Results
-	Metrics        datatypes.JSON `gorm:"type:jsonb"`
-	FastQC1        *string        `gorm:"type:varchar(255)"`
-	FastQC2        *string        `gorm:"type:varchar(255)"`
-	ResultsZipPath *string        `gorm:"type:varchar(255)"`
+	Metrics            datatypes.JSON `gorm:"type:jsonb"`
+	FastQC1            *string        `gorm:"type:varchar(255)"`
+	FastQC2            *string        `gorm:"type:varchar(255)"`
+	ResultsZipPath     *string        `gorm:"type:varchar(255)"`
+	QCVerdict          string         `gorm:"type:varchar(10);default:''"`
+	SpeciesConcordance string         `gorm:"type:varchar(20);default:''"`
+	ReadCount          *int64
+	ReadMeanQuality    *float64
+	ReadQ30Percent     *float64
+	ReadMeanLength     *float64
 
 	// Run Metadata
 	ErrorMessage *string `gorm:"type:text"`
@@ -0,0 +1,18 @@
+package models
+
+import "time"
+
+type AnalysisDatabase struct {
+	ID string `gorm:"primaryKey;default:(hex(randomblob(16)))"`
+
+	Name     string `gorm:"type:varchar(255);not null;index"`
+	Checksum string `gorm:"type:varchar(64);not null"`
+	Version  string `gorm:"type:varchar(255)"`
+
+	// Datetime
+	CreatedAt time.Time
+
+	// Foreign Keys
+	AnalysisID string   `gorm:"type:not null;index"`
+	Analysis   Analysis `gorm:"foreignKey:AnalysisID;references:ID;constraint:OnDelete:CASCADE"`
+}
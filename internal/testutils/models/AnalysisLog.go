@@ -0,0 +1,44 @@
+package models
+
+import (
+	"time"
+
+	rModels "github.com/CABGenOrg/cabgen_backend/internal/models"
+	"github.com/google/uuid"
+)
+
+type AnalysisLog struct {
+	ID string `gorm:"primaryKey;default:(hex(randomblob(16)))"`
+
+	Step       string `gorm:"type:varchar(20);not null;index"`
+	Tool       string `gorm:"type:varchar(100);not null"`
+	Command    string `gorm:"type:text;not null"`
+	ExitCode   int    `gorm:"not null"`
+	DurationMs int64  `gorm:"not null"`
+
+	// Paths
+	StdoutPath string `gorm:"type:varchar(255)"`
+	StderrPath string `gorm:"type:varchar(255)"`
+
+	// Datetime
+	StartedAt time.Time
+	CreatedAt time.Time
+
+	// Foreign Keys
+	AnalysisID string   `gorm:"type:not null;index"`
+	Analysis   Analysis `gorm:"foreignKey:AnalysisID;references:ID;constraint:OnDelete:CASCADE"`
+}
+
+func NewAnalysisLog(analysisID uuid.UUID, step rModels.AnalysisStep,
+	startedAt time.Time) rModels.AnalysisLog {
+	return rModels.AnalysisLog{
+		ID:         uuid.New(),
+		Step:       step,
+		Tool:       "tool",
+		Command:    "tool --input reads.fq",
+		ExitCode:   0,
+		DurationMs: 1500,
+		StartedAt:  startedAt,
+		AnalysisID: analysisID,
+	}
+}
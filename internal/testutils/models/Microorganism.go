@@ -10,6 +10,7 @@ type Microorganism struct {
 	Taxon    models.Taxon      `gorm:"not null" json:"taxon"`
 	Species  string            `gorm:"not null" json:"species"`
 	Variety  map[string]string `gorm:"json" json:"variety"`
+	TaxID    *int              `gorm:"column:taxid" json:"taxid"`
 	IsActive bool              `gorm:"not null" json:"is_active"`
 }
 
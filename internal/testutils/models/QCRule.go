@@ -0,0 +1,29 @@
+package models
+
+import (
+	"github.com/CABGenOrg/cabgen_backend/internal/models"
+	"github.com/CABGenOrg/cabgen_backend/internal/pipeline"
+	"github.com/google/uuid"
+)
+
+type QCRule struct {
+	ID       string   `gorm:"primaryKey;default:(hex(randomblob(16)))" json:"id"`
+	Metric   string   `gorm:"not null" json:"metric"`
+	Species  string   `gorm:"not null;default:''" json:"species"`
+	Min      *float64 `json:"min"`
+	Max      *float64 `json:"max"`
+	Severity string   `gorm:"not null;default:'FAIL'" json:"severity"`
+	IsActive bool     `gorm:"not null" json:"is_active"`
+}
+
+func NewQCRule(ID string, metric pipeline.QCMetric, min, max *float64,
+	severity pipeline.QCSeverity, isActive bool) models.QCRule {
+	return models.QCRule{
+		ID:       uuid.MustParse(ID),
+		Metric:   metric,
+		Min:      min,
+		Max:      max,
+		Severity: severity,
+		IsActive: isActive,
+	}
+}
@@ -5,6 +5,7 @@ import (
 
 	rModels "github.com/CABGenOrg/cabgen_backend/internal/models"
 	"github.com/google/uuid"
+	"gorm.io/datatypes"
 )
 
 type Sample struct {
@@ -21,6 +22,9 @@ type Sample struct {
 	Fastq1         *string         `gorm:"type:varchar(255);default:null" json:"fastq1,omitempty"`
 	Fastq2         *string         `gorm:"type:varchar(255);default:null" json:"fastq2,omitempty"`
 	Fasta          *string         `gorm:"type:varchar(255);default:null" json:"fasta,omitempty"`
+	LongReads      *string         `gorm:"type:varchar(255);default:null" json:"long_reads,omitempty"`
+	// Validation of the files of the last upload
+	ValidationReport datatypes.JSON `gorm:"type:jsonb" json:"validation_report,omitempty"`
 	// Foreign Keys
 	CountryID       uint                  `gorm:"not null" json:"-"`
 	Country         rModels.Country       `gorm:"foreignKey:CountryID;references:ID"`
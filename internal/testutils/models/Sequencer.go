@@ -6,17 +6,19 @@ import (
 )
 
 type Sequencer struct {
-	ID       string `gorm:"primaryKey;default:(hex(randomblob(16)))" json:"id"`
-	Model    string `gorm:"not null" json:"model"`
-	Brand    string `gorm:"not null" json:"brand"`
-	IsActive bool   `gorm:"not null" json:"is_active"`
+	ID             string `gorm:"primaryKey;default:(hex(randomblob(16)))" json:"id"`
+	Model          string `gorm:"not null" json:"model"`
+	Brand          string `gorm:"not null" json:"brand"`
+	ReadTechnology string `gorm:"not null;default:'SHORT_READ'" json:"read_technology"`
+	IsActive       bool   `gorm:"not null" json:"is_active"`
 }
 
 func NewSequencer(ID, model, brand string, isActive bool) models.Sequencer {
 	return models.Sequencer{
-		ID:       uuid.MustParse(ID),
-		Model:    model,
-		Brand:    brand,
-		IsActive: isActive,
+		ID:             uuid.MustParse(ID),
+		Model:          model,
+		Brand:          brand,
+		ReadTechnology: models.ReadTechnologyShort,
+		IsActive:       isActive,
 	}
 }
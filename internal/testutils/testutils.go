@@ -33,7 +33,9 @@ func NewMockDB() *gorm.DB {
 		&testmodels.Sequencer{}, &testmodels.SampleSource{},
 		&testmodels.Laboratory{}, &testmodels.Microorganism{},
 		&testmodels.HealthService{}, &testmodels.Sample{},
-		&testmodels.Analysis{}, &testmodels.Ticket{},
+		&testmodels.Analysis{}, &testmodels.AnalysisLog{},
+		&testmodels.AnalysisDatabase{},
+		&testmodels.Ticket{}, &testmodels.QCRule{},
 		&testmodels.PasswordReset{}, &testmodels.EmailUpdateRequest{})
 
 	return db
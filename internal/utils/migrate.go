@@ -0,0 +1,54 @@
+package utils
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/CABGenOrg/cabgen_backend/internal/models"
+	"gorm.io/gorm"
+)
+
+// MigrateAnalysisMetrics rewrites the metrics of analyses stored while AMR
+// hits were display strings. Metrics already in the current shape are left
+// untouched, so it is safe to run on every start.
+func MigrateAnalysisMetrics(db *gorm.DB) error {
+	ctx := context.Background()
+
+	var analyses []models.Analysis
+	migrated := 0
+	err := db.WithContext(ctx).Model(&models.Analysis{}).
+		Select("id", "metrics").Where("metrics IS NOT NULL").
+		FindInBatches(&analyses, models.AnalysesByBatch,
+			func(_ *gorm.DB, _ int) error {
+				for _, analysis := range analyses {
+					metrics, changed, err := models.MigrateAnalysisMetrics(
+						analysis.Metrics)
+					if err != nil {
+						log.Printf("skipping metrics of analysis %s: %v",
+							analysis.ID, err)
+						continue
+					}
+					if !changed {
+						continue
+					}
+
+					if err := db.WithContext(ctx).Model(&models.Analysis{}).
+						Where("id = ?", analysis.ID).
+						UpdateColumn("metrics", metrics).Error; err != nil {
+						return fmt.Errorf("cannot migrate metrics of analysis %s: %w",
+							analysis.ID, err)
+					}
+					migrated++
+				}
+				return nil
+			}).Error
+	if err != nil {
+		return err
+	}
+
+	if migrated > 0 {
+		log.Printf("migrated metrics of %d analyses", migrated)
+	}
+	return nil
+}
@@ -0,0 +1,48 @@
+package utils_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/CABGenOrg/cabgen_backend/internal/models"
+	"github.com/CABGenOrg/cabgen_backend/internal/pipeline"
+	"github.com/CABGenOrg/cabgen_backend/internal/testutils"
+	testmodels "github.com/CABGenOrg/cabgen_backend/internal/testutils/models"
+	"github.com/CABGenOrg/cabgen_backend/internal/utils"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/datatypes"
+)
+
+func TestMigrateAnalysisMetrics(t *testing.T) {
+	db := testutils.NewMockDB()
+
+	legacy := testmodels.CreateMockAnalysis()
+	legacy.Metrics = datatypes.JSON(`{"mlst":"ST2","acquired_resistance":` +
+		`["blaOXA-23_1 (resistance to meropenem) (allele confidence 100.00)"]}`)
+	assert.NoError(t, db.Create(&legacy).Error)
+
+	current := testmodels.CreateMockAnalysis()
+	current.Sample = legacy.Sample
+	current.SampleID = legacy.SampleID
+	current.User = legacy.User
+	current.UserID = legacy.UserID
+	current.Metrics = datatypes.JSON(`{"vfdb":[{"gene":"fimH",` +
+		`"allele":"fimH","database":"vfdb"}]}`)
+	assert.NoError(t, db.Create(&current).Error)
+
+	assert.NoError(t, utils.MigrateAnalysisMetrics(db))
+
+	var migrated models.Analysis
+	assert.NoError(t, db.First(&migrated, "id = ?", legacy.ID).Error)
+	var results models.AnalysisResults
+	assert.NoError(t, json.Unmarshal(migrated.Metrics, &results))
+	assert.Equal(t, "ST2", results.MLST)
+	assert.Equal(t, []pipeline.AMRHit{{
+		Gene: "blaOXA-23", Allele: "blaOXA-23_1", Database: "resfinder",
+		Engine: pipeline.AMREngineAbricate, Identity: 100, Phenotype: "meropenem",
+	}}, results.AcquiredResistance)
+
+	var untouched models.Analysis
+	assert.NoError(t, db.First(&untouched, "id = ?", current.ID).Error)
+	assert.JSONEq(t, string(current.Metrics), string(untouched.Metrics))
+}
@@ -8,12 +8,21 @@ import (
 	"strings"
 
 	"github.com/CABGenOrg/cabgen_backend/internal/models"
+	"github.com/CABGenOrg/cabgen_backend/internal/pipeline"
 )
 
 var metricsHeaders = []string{
 	"origin_code", "coverage", "completeness", "contamination", "genome_size",
 	"n50", "primary_species", "secondary_species", "mlst", "poli_mutations",
 	"other_mutations", "acquired_resistance", "vfdb", "plasmid",
+	"read1_reads", "read1_bases", "read1_mean_length", "read1_median_length",
+	"read1_mean_phred", "read1_q20", "read1_q30", "read1_gc_percent",
+	"read1_n_rate",
+	"read2_reads", "read2_bases", "read2_mean_length", "read2_median_length",
+	"read2_mean_phred", "read2_q20", "read2_q30", "read2_gc_percent",
+	"read2_n_rate",
+	"long_read_coverage", "long_reads_reads", "long_reads_bases",
+	"long_reads_mean_length", "long_reads_read_n50", "long_reads_mean_phred",
 }
 
 func GenerateMetricsTSV(analyses []models.AnalysisResponse) ([]byte, error) {
@@ -30,7 +39,7 @@ func GenerateMetricsTSV(analyses []models.AnalysisResponse) ([]byte, error) {
 	}
 
 	for _, a := range analyses {
-		var r models.AnalysisResults
+		var r models.AnalysisResultsResponse
 		if len(a.Metrics) > 0 {
 			_ = json.Unmarshal(a.Metrics, &r)
 		}
@@ -46,10 +55,19 @@ func GenerateMetricsTSV(analyses []models.AnalysisResponse) ([]byte, error) {
 			r.MLST,
 			strings.Join(r.PoliMutations, ","),
 			strings.Join(r.OtherMutations, ","),
-			strings.Join(r.AcquiredResistance, ","),
-			strings.Join(r.VFDB, ","),
-			strings.Join(r.PlasmidFinder, ","),
+			joinAMRHits(r.AcquiredResistance),
+			joinAMRHits(r.VFDB),
+			joinAMRHits(r.PlasmidFinder),
 		}
+		var read1, read2, longReads *pipeline.ReadQC
+		if r.ReadQC != nil {
+			read1, read2 = r.ReadQC.Read1, r.ReadQC.Read2
+			longReads = r.ReadQC.LongReads
+		}
+		row = append(row, readQCColumns(read1)...)
+		row = append(row, readQCColumns(read2)...)
+		row = append(row, formatTSVValue(r.LongReadCoverage))
+		row = append(row, longReadQCColumns(longReads)...)
 		if err := writer.Write(row); err != nil {
 			return nil, err
 		}
@@ -59,6 +77,50 @@ func GenerateMetricsTSV(analyses []models.AnalysisResponse) ([]byte, error) {
 	return buffer.Bytes(), writer.Error()
 }
 
+// joinAMRHits lists the hits as shown to the caller, in the language the
+// response was built for.
+func joinAMRHits(hits []models.AMRHitResponse) string {
+	displays := make([]string, 0, len(hits))
+	for _, hit := range hits {
+		displays = append(displays, hit.Display)
+	}
+	return strings.Join(displays, ",")
+}
+
+// readQCColumns renders the summary of a read file, or empty cells when the
+// file was not summarized.
+func readQCColumns(qc *pipeline.ReadQC) []string {
+	if qc == nil {
+		return make([]string, 9)
+	}
+	return []string{
+		fmt.Sprintf("%d", qc.Reads),
+		fmt.Sprintf("%d", qc.Bases),
+		fmt.Sprintf("%.2f", qc.MeanLength),
+		fmt.Sprintf("%g", qc.MedianLength),
+		fmt.Sprintf("%.2f", qc.MeanPhred),
+		fmt.Sprintf("%.4f", qc.Q20Fraction),
+		fmt.Sprintf("%.4f", qc.Q30Fraction),
+		fmt.Sprintf("%.2f", qc.GCPercent),
+		fmt.Sprintf("%.4f", qc.NRate),
+	}
+}
+
+// longReadQCColumns renders the long-read summary, whose N50 tells more than
+// the base composition already given for short reads.
+func longReadQCColumns(qc *pipeline.ReadQC) []string {
+	if qc == nil {
+		return make([]string, 5)
+	}
+	return []string{
+		fmt.Sprintf("%d", qc.Reads),
+		fmt.Sprintf("%d", qc.Bases),
+		fmt.Sprintf("%.2f", qc.MeanLength),
+		fmt.Sprintf("%d", qc.ReadN50),
+		fmt.Sprintf("%.2f", qc.MeanPhred),
+	}
+}
+
 func formatTSVValue(v any) string {
 	switch val := v.(type) {
 	case float64:
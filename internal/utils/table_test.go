@@ -1,9 +1,11 @@
 package utils_test
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/CABGenOrg/cabgen_backend/internal/models"
+	testmodels "github.com/CABGenOrg/cabgen_backend/internal/testutils/models"
 	"github.com/CABGenOrg/cabgen_backend/internal/utils"
 	"github.com/stretchr/testify/assert"
 	"gorm.io/datatypes"
@@ -29,9 +31,12 @@ func TestGenerateMetricsTSV(t *testing.T) {
 			"mlst": "ST502",
 			"poli_mutations": ["blaOXA-23", "blaOXA-51"],
 			"other_mutations": ["gyrA_S83L"],
-		"acquired_resistance": ["blaOXA-23", "armA"],
-		"vfdb": ["abaum_A"],
-		"plasmid": ["IncHI2"]
+			"acquired_resistance": [
+				{"gene": "blaOXA-23", "display": "blaOXA-23"},
+				{"gene": "armA", "display": "armA"}
+			],
+			"vfdb": [{"gene": "abaum_A", "display": "abaum_A"}],
+			"plasmid": [{"gene": "IncHI2", "display": "IncHI2"}]
 		}`)
 		analyses := []models.AnalysisResponse{{Metrics: metrics}}
 
@@ -51,8 +56,8 @@ func TestGenerateMetricsTSV(t *testing.T) {
 		assert.NoError(t, err)
 		body := string(result)
 		assert.Contains(t, body, "coverage\tcompleteness")
-		// Empty row with 14 tab-separated empty cells
-		assert.Contains(t, body, "\t\t\t\t\t\t\t\t\t\t\t\t\t\n")
+		// Empty row with 32 tab-separated empty cells
+		assert.Contains(t, body, "\n"+strings.Repeat("\t", 37)+"\n")
 	})
 
 	t.Run("Success - Multiple items", func(t *testing.T) {
@@ -75,7 +80,11 @@ func TestGenerateMetricsTSV(t *testing.T) {
 
 	t.Run("Success - Array fields joined with comma", func(t *testing.T) {
 		metrics := datatypes.JSON(`{
-			"acquired_resistance": ["blaOXA-23", "armA", "blaNDM-1"],
+			"acquired_resistance": [
+				{"gene": "blaOXA-23", "display": "blaOXA-23"},
+				{"gene": "armA", "display": "armA"},
+				{"gene": "blaNDM-1", "display": "blaNDM-1"}
+			],
 			"poli_mutations": ["mut1"]
 		}`)
 		analyses := []models.AnalysisResponse{{Metrics: metrics}}
@@ -88,6 +97,66 @@ func TestGenerateMetricsTSV(t *testing.T) {
 		assert.Contains(t, body, "mut1")
 	})
 
+	t.Run("Success - AMR hits rendered in the response language", func(t *testing.T) {
+		analysis := testmodels.CreateMockAnalysis()
+		analysis.Metrics = datatypes.JSON(`{"acquired_resistance":[{` +
+			`"gene":"blaOXA-23","allele":"blaOXA-23_1","database":"resfinder",` +
+			`"identity":100,"phenotype":"meropenem"}]}`)
+
+		result, err := utils.GenerateMetricsTSV([]models.AnalysisResponse{
+			analysis.ToResponse("pt"),
+		})
+
+		assert.NoError(t, err)
+		assert.Contains(t, string(result), "blaOXA-23_1 (resistência a "+
+			"meropenem) (confiança do alelo 100.00)")
+	})
+
+	t.Run("Success - Read quality per file", func(t *testing.T) {
+		metrics := datatypes.JSON(`{"read_qc": {"read1": {
+			"reads": 1000, "bases": 150000, "mean_length": 150,
+			"median_length": 150, "length_histogram": {"150": 1000},
+			"mean_phred": 35.123, "q20_fraction": 0.98765,
+			"q30_fraction": 0.9, "gc_percent": 39.456, "n_rate": 0.0001
+		}}}`)
+		analyses := []models.AnalysisResponse{{Metrics: metrics}}
+
+		result, err := utils.GenerateMetricsTSV(analyses)
+
+		assert.NoError(t, err)
+		lines := splitLines(string(result))
+		assert.Len(t, lines, 2)
+		header, cells := splitTabs(lines[0]), splitTabs(lines[1])
+		assert.Len(t, cells, len(header))
+		assert.Equal(t, []string{"1000", "150000", "150.00", "150", "35.12",
+			"0.9877", "0.9000", "39.46", "0.0001"}, cells[14:23])
+		assert.Equal(t, "read2_reads", header[23])
+		assert.Equal(t, make([]string, 9), cells[23:32])
+	})
+
+	t.Run("Success - Long reads", func(t *testing.T) {
+		metrics := datatypes.JSON(`{"long_read_coverage": 48.5,
+			"read_qc": {"long_reads": {
+			"reads": 2000, "bases": 16000000, "mean_length": 8000,
+			"median_length": 6500, "length_histogram": {"6000": 2000},
+			"mean_phred": 14.256, "q20_fraction": 0.2, "q30_fraction": 0.01,
+			"gc_percent": 50.1, "n_rate": 0, "read_n50": 12000,
+			"histogram_bin_width": 1000
+		}}}`)
+		analyses := []models.AnalysisResponse{{Metrics: metrics}}
+
+		result, err := utils.GenerateMetricsTSV(analyses)
+
+		assert.NoError(t, err)
+		lines := splitLines(string(result))
+		header, cells := splitTabs(lines[0]), splitTabs(lines[1])
+		assert.Len(t, cells, len(header))
+		assert.Equal(t, "long_read_coverage", header[32])
+		assert.Equal(t, []string{"48.5", "2000", "16000000", "8000.00",
+			"12000", "14.26"}, cells[32:])
+		assert.Equal(t, make([]string, 9), cells[14:23])
+	})
+
 	t.Run("Success - Coverage zero renders empty", func(t *testing.T) {
 		metrics := datatypes.JSON(`{"coverage": 0, "primary_species": "Sp"}`)
 		analyses := []models.AnalysisResponse{{Metrics: metrics}}
@@ -117,11 +117,41 @@ func ApplySequencerUpdate(sequencer *models.Sequencer, input *models.SequencerUp
 		sequencer.Model = *input.Model
 	}
 
+	if input.ReadTechnology != nil {
+		sequencer.ReadTechnology = *input.ReadTechnology
+	}
+
 	if input.IsActive != nil {
 		sequencer.IsActive = *input.IsActive
 	}
 }
 
+func ApplyQCRuleUpdate(rule *models.QCRule, input *models.QCRuleUpdateInput) {
+	if input.Metric != nil {
+		rule.Metric = *input.Metric
+	}
+
+	if input.Species != nil {
+		rule.Species = *input.Species
+	}
+
+	if input.Min != nil {
+		rule.Min = input.Min
+	}
+
+	if input.Max != nil {
+		rule.Max = input.Max
+	}
+
+	if input.Severity != nil {
+		rule.Severity = *input.Severity
+	}
+
+	if input.IsActive != nil {
+		rule.IsActive = *input.IsActive
+	}
+}
+
 func ApplySampleSourceUpdate(sampleSource *models.SampleSource, input *models.SampleSourceUpdateInput) {
 	if input.Names != nil {
 		sampleSource.Names = input.Names
@@ -174,6 +204,10 @@ func ApplyMicroorganismUpdate(microorganism *models.Microorganism,
 		microorganism.Variety = input.Variety
 	}
 
+	if input.TaxID != nil {
+		microorganism.TaxID = input.TaxID
+	}
+
 	if input.IsActive != nil {
 		microorganism.IsActive = *input.IsActive
 	}
@@ -223,7 +257,8 @@ func ApplyAnalysisUpdate(analysis *models.Analysis,
 			analysis.Step = ""
 		}
 
-		if *input.Status == models.AnalysisStatusFailed {
+		if *input.Status == models.AnalysisStatusFailed ||
+			*input.Status == models.AnalysisStatusCancelled {
 			finishedAt := time.Now()
 			analysis.FinishedAt = &finishedAt
 		}
@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/CABGenOrg/cabgen_backend/internal/models"
+	"github.com/CABGenOrg/cabgen_backend/internal/pipeline"
 	"github.com/CABGenOrg/cabgen_backend/internal/testutils"
 	testmodels "github.com/CABGenOrg/cabgen_backend/internal/testutils/models"
 	"github.com/CABGenOrg/cabgen_backend/internal/validations"
@@ -153,6 +154,41 @@ func TestApplySequencerUpdate(t *testing.T) {
 	assert.Equal(t, expected, sequencer)
 }
 
+func TestApplyQCRuleUpdate(t *testing.T) {
+	minCompleteness := 90.0
+	rule := models.QCRule{
+		ID:       uuid.New(),
+		Metric:   pipeline.QCMetricCompleteness,
+		Min:      &minCompleteness,
+		Severity: pipeline.QCSeverityFail,
+		IsActive: true,
+	}
+
+	species := "Klebsiella pneumoniae"
+	newMin := 95.0
+	severity := pipeline.QCSeverityWarn
+	isActive := false
+	ruleUpdate := models.QCRuleUpdateInput{
+		Species:  &species,
+		Min:      &newMin,
+		Severity: &severity,
+		IsActive: &isActive,
+	}
+
+	expected := models.QCRule{
+		ID:       rule.ID,
+		Metric:   rule.Metric,
+		Species:  species,
+		Min:      &newMin,
+		Severity: severity,
+		IsActive: isActive,
+	}
+
+	validations.ApplyQCRuleUpdate(&rule, &ruleUpdate)
+
+	assert.Equal(t, expected, rule)
+}
+
 func TestApplySampleSourceUpdate(t *testing.T) {
 	sampleSource := models.SampleSource{
 		ID: uuid.New(),
@@ -255,12 +291,14 @@ func TestApplyMicroorganismUpdate(t *testing.T) {
 		"en": "Variety",
 		"es": "Variedad",
 	}
+	taxID := 562
 	isActive := true
 
 	input := models.MicroorganismUpdateInput{
 		Taxon:    &taxon,
 		Species:  &species,
 		Variety:  variety,
+		TaxID:    &taxID,
 		IsActive: &isActive,
 	}
 
@@ -269,6 +307,7 @@ func TestApplyMicroorganismUpdate(t *testing.T) {
 		Taxon:    *input.Taxon,
 		Species:  *input.Species,
 		Variety:  input.Variety,
+		TaxID:    input.TaxID,
 		IsActive: *input.IsActive,
 	}
 
@@ -38,11 +38,16 @@ func ApplySampleFilesUpdate(sample *models.Sample,
 		sample.Fastq1 = input.Fastq1
 	}
 
-	if input.Fastq2 != nil {
+	// A lone fastq1 replaces the pair with single-end reads.
+	if input.Fastq1 != nil || input.Fastq2 != nil {
 		sample.Fastq2 = input.Fastq2
 	}
 
 	if input.Fasta != nil {
 		sample.Fasta = input.Fasta
 	}
+
+	if input.LongReads != nil {
+		sample.LongReads = input.LongReads
+	}
 }
@@ -116,3 +116,15 @@ func TestApplySampleFilesUpdate(t *testing.T) {
 
 	assert.Equal(t, expected, mock)
 }
+
+func TestApplySampleFilesUpdateSingleEnd(t *testing.T) {
+	mock := testmodels.CreateMockSample()
+	fastq1 := "single.fastq"
+
+	validations.ApplySampleFilesUpdate(&mock, &models.SampleAttachmentInput{
+		Fastq1: &fastq1,
+	})
+
+	assert.Equal(t, &fastq1, mock.Fastq1)
+	assert.Nil(t, mock.Fastq2)
+}
@@ -29,7 +29,8 @@ type Model interface {
 		models.AdminAnalysisUpdateInput | models.AnalysisTSVDownloadInput |
 		models.CreateTicketInput | models.ForgotPasswordInput |
 		models.ResetPasswordInput | models.UpdatePasswordInput |
-		models.RequestEmailUpdateInput | models.ConfirmEmailUpdateInput
+		models.RequestEmailUpdateInput | models.ConfirmEmailUpdateInput |
+		models.QCRuleCreateInput | models.QCRuleUpdateInput
 }
 
 func Validate[T Model](